@@ -8,14 +8,17 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"runtime/debug"
 	"strings"
 	"time"
 
+	"github.com/adrg/xdg"
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/color"
 	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/config/data"
+	"github.com/derailed/k9s/internal/demo"
 	"github.com/derailed/k9s/internal/slogs"
 	"github.com/derailed/k9s/internal/view"
 	"github.com/lmittmann/tint"
@@ -100,10 +103,18 @@ func run(*cobra.Command, []string) error {
 		}
 	}()
 
-	slog.SetDefault(slog.New(tint.NewHandler(logFile, &tint.Options{
+	slog.SetDefault(slog.New(slogs.NewContextHandler(tint.NewHandler(logFile, &tint.Options{
 		Level:      parseLevel(*k9sFlags.LogLevel),
 		TimeFormat: time.RFC3339,
-	})))
+	}))))
+
+	if *k9sFlags.Demo {
+		stop, err := startDemo()
+		if err != nil {
+			return fmt.Errorf("demo mode init failed: %w", err)
+		}
+		defer stop()
+	}
 
 	cfg, err := loadConfiguration()
 	if err != nil {
@@ -168,6 +179,53 @@ func loadConfiguration() (*config.Config, error) {
 	return k9sCfg, errs
 }
 
+// startDemo serves the demo clusters and points the session at them. Demo
+// sessions are read-only and keep their context configs, context selection
+// and state out of the user's dirs. Shell-outs target the demo clusters too.
+func startDemo() (func(), error) {
+	dir, err := os.MkdirTemp("", appName+"-demo-")
+	if err != nil {
+		return nil, err
+	}
+	srv, err := demo.Start()
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	stop := func() {
+		_ = srv.Close()
+		_ = os.RemoveAll(dir)
+	}
+	kcfg := filepath.Join(dir, "kubeconfig")
+	if err := srv.WriteKubeconfig(kcfg); err != nil {
+		stop()
+		return nil, err
+	}
+	slog.Info("Demo mode enabled",
+		slogs.URL, srv.URL,
+		slogs.Context, demo.DefaultContext,
+	)
+	*k8sFlags.KubeConfig = kcfg
+	if *k8sFlags.Context == "" {
+		*k8sFlags.Context = demo.DefaultContext
+	}
+	*k9sFlags.ReadOnly, *k9sFlags.Write = true, false
+	config.AppContextsDir = filepath.Join(dir, "clusters")
+	config.AppSelectedContextsFile = filepath.Join(dir, "selected_contexts")
+	for k, v := range map[string]string{
+		"XDG_STATE_HOME": filepath.Join(dir, "state"),
+		"KUBECONFIG":     kcfg,
+	} {
+		if err := os.Setenv(k, v); err != nil {
+			stop()
+			return nil, err
+		}
+	}
+	xdg.Reload()
+
+	return stop, nil
+}
+
 func parseLevel(level string) slog.Level {
 	switch level {
 	case "debug":
@@ -261,6 +319,12 @@ func initK9sFlags() {
 		"",
 		"Sets a path to a dir for a screen dumps",
 	)
+	rootCmd.Flags().BoolVar(
+		k9sFlags.Demo,
+		"demo",
+		false,
+		"Runs against built-in read-only demo clusters instead of your kubeconfig",
+	)
 	rootCmd.Flags()
 }
 
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package client
+
+import (
+	"path/filepath"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+var authErrors = []string{
+	"getting credentials",
+	"unauthorized",
+	"token has expired",
+	"certificate has expired",
+	"not logged in",
+}
+
+// IsAuthError checks if an error is caused by expired or missing credentials.
+func IsAuthError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if apierrors.IsUnauthorized(err) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range authErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoginCommand returns the interactive login command for exec credential
+// plugins known to require a proxy login, ie Teleport or the Rancher CLI.
+func LoginCommand(exec *api.ExecConfig) (string, []string, bool) {
+	if exec == nil {
+		return "", nil, false
+	}
+	switch strings.TrimSuffix(filepath.Base(exec.Command), ".exe") {
+	case "tsh":
+		args := []string{"login"}
+		for _, a := range exec.Args {
+			if strings.HasPrefix(a, "--proxy=") {
+				args = append(args, a)
+			}
+		}
+		return exec.Command, args, true
+	case "rancher":
+		// The Rancher CLI prompts for a login when its cached token expired.
+		return exec.Command, exec.Args, true
+	}
+
+	return "", nil, false
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package client_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestIsAuthError(t *testing.T) {
+	uu := map[string]struct {
+		err error
+		e   bool
+	}{
+		"none": {},
+		"unauthorized": {
+			err: apierrors.NewUnauthorized("nope"),
+			e:   true,
+		},
+		"exec": {
+			err: errors.New("getting credentials: exec: executable tsh failed with exit code 1"),
+			e:   true,
+		},
+		"dial": {
+			err: errors.New("dial tcp 10.0.0.1:443: i/o timeout"),
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, client.IsAuthError(u.err))
+		})
+	}
+}
+
+func TestLoginCommand(t *testing.T) {
+	uu := map[string]struct {
+		exec *api.ExecConfig
+		bin  string
+		args []string
+		ok   bool
+	}{
+		"none": {},
+		"tsh": {
+			exec: &api.ExecConfig{
+				Command: "/usr/local/bin/tsh",
+				Args:    []string{"kube", "credentials", "--kube-cluster=dev", "--proxy=tele.example.com:443"},
+			},
+			bin:  "/usr/local/bin/tsh",
+			args: []string{"login", "--proxy=tele.example.com:443"},
+			ok:   true,
+		},
+		"rancher": {
+			exec: &api.ExecConfig{
+				Command: "rancher",
+				Args:    []string{"token", "--server=rancher.example.com", "--user=u-1"},
+			},
+			bin:  "rancher",
+			args: []string{"token", "--server=rancher.example.com", "--user=u-1"},
+			ok:   true,
+		},
+		"unknown": {
+			exec: &api.ExecConfig{Command: "aws", Args: []string{"eks", "get-token"}},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			bin, args, ok := client.LoginCommand(u.exec)
+			assert.Equal(t, u.ok, ok)
+			assert.Equal(t, u.bin, bin)
+			assert.Equal(t, u.args, args)
+		})
+	}
+}
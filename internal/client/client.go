@@ -51,6 +51,7 @@ type APIClient struct {
 	mx                sync.RWMutex
 	cache             *cache.LRUExpireCache
 	connOK            bool
+	connErr           error
 	log               *slog.Logger
 }
 
@@ -88,6 +89,21 @@ func (a *APIClient) ConnectionOK() bool {
 	return a.connOK
 }
 
+// ConnectionError returns the last connectivity check error if any.
+func (a *APIClient) ConnectionError() error {
+	a.mx.RLock()
+	defer a.mx.RUnlock()
+
+	return a.connErr
+}
+
+func (a *APIClient) setConnError(err error) {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	a.connErr = err
+}
+
 func makeSAR(ns string, gvr *GVR, name string) *authorizationv1.SelfSubjectAccessReview {
 	if ns == ClusterScope {
 		ns = BlankNamespace
@@ -309,6 +325,7 @@ func (a *APIClient) CheckConnectivity() bool {
 	}()
 
 	cfg, err := a.config.RESTConfig()
+	a.setConnError(err)
 	if err != nil {
 		slog.Error("RestConfig load failed", slogs.Error, err)
 		a.connOK = false
@@ -318,6 +335,7 @@ func (a *APIClient) CheckConnectivity() bool {
 	client, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		slog.Error("Unable to connect to api server", slogs.Error, err)
+		a.setConnError(err)
 		a.setConnOK(false)
 		return a.getConnOK()
 	}
@@ -329,6 +347,7 @@ func (a *APIClient) CheckConnectivity() bool {
 		}
 	} else {
 		slog.Error("Unable to fetch server version", slogs.Error, err)
+		a.setConnError(err)
 		a.setConnOK(false)
 	}
 
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/derailed/k9s/internal/metrics"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -61,6 +62,9 @@ func (c *Config) RESTConfig() (*restclient.Config, error) {
 	if c.proxy != nil {
 		cfg.Proxy = c.proxy
 	}
+	if ct, err := c.CurrentContextName(); err == nil {
+		cfg.Wrap(metrics.InstrumentTransport(ct))
+	}
 
 	return cfg, nil
 }
@@ -87,8 +91,19 @@ func (c *Config) SwitchContext(name string) error {
 		return fmt.Errorf("context %q does not exist", name)
 	}
 	// !!BOZO!! Do you need to reset the flags?
+	c.flags = c.cloneFlags(&name, &ct.Cluster)
+
+	return nil
+}
+
+// Refresh drops the cached kubeconfig so newly added contexts become visible.
+func (c *Config) Refresh() {
+	c.flags = c.cloneFlags(c.flags.Context, c.flags.ClusterName)
+}
+
+func (c *Config) cloneFlags(context, cluster *string) *genericclioptions.ConfigFlags {
 	flags := genericclioptions.NewConfigFlags(UsePersistentConfig)
-	flags.Context, flags.ClusterName = &name, &ct.Cluster
+	flags.Context, flags.ClusterName = context, cluster
 	flags.Namespace = c.flags.Namespace
 	flags.Timeout = c.flags.Timeout
 	flags.KubeConfig = c.flags.KubeConfig
@@ -98,9 +113,7 @@ func (c *Config) SwitchContext(name string) error {
 	flags.Insecure = c.flags.Insecure
 	flags.BearerToken = c.flags.BearerToken
 
-	c.flags = flags
-
-	return nil
+	return flags
 }
 
 func (c *Config) Clone(ns string) (*genericclioptions.ConfigFlags, error) {
@@ -321,6 +334,23 @@ func (c *Config) CurrentUserName() (string, error) {
 	return "", errors.New("unable to locate current user")
 }
 
+// CurrentExecConfig retrieves the exec credential plugin of the active user if any.
+func (c *Config) CurrentExecConfig() (*api.ExecConfig, error) {
+	u, err := c.CurrentUserName()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := c.RawConfig()
+	if err != nil {
+		return nil, err
+	}
+	if info, ok := cfg.AuthInfos[u]; ok {
+		return info.Exec, nil
+	}
+
+	return nil, fmt.Errorf("unable to locate user %q", u)
+}
+
 // CurrentNamespaceName retrieves the active namespace.
 func (c *Config) CurrentNamespaceName() (string, error) {
 	ns, overridden, err := c.clientConfig().Namespace()
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 var kubeConfig = "./testdata/config"
@@ -214,6 +216,27 @@ func TestConfigSwitchContext(t *testing.T) {
 	assert.Equal(t, "blee", ctx)
 }
 
+func TestConfigRefresh(t *testing.T) {
+	kubeCfg := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, cp(kubeConfig, kubeCfg))
+
+	flags := genericclioptions.NewConfigFlags(client.UsePersistentConfig)
+	flags.KubeConfig = &kubeCfg
+	cfg := client.NewConfig(flags)
+	raw, err := cfg.RawConfig()
+	require.NoError(t, err)
+
+	raw = *raw.DeepCopy()
+	raw.Contexts["hop"] = raw.Contexts["fred"]
+	require.NoError(t, clientcmd.WriteToFile(raw, kubeCfg))
+	_, err = cfg.GetContext("hop")
+	require.Error(t, err)
+
+	cfg.Refresh()
+	_, err = cfg.GetContext("hop")
+	require.NoError(t, err)
+}
+
 func TestConfigAccess(t *testing.T) {
 	context := "duh"
 	flags := genericclioptions.ConfigFlags{
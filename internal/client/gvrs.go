@@ -39,6 +39,9 @@ var (
 	NpGVR  = NewGVR("networking.k8s.io/v1/networkpolicies")
 	ScGVR  = NewGVR("storage.k8s.io/v1/storageclasses")
 
+	// Coordination...
+	LeaseGVR = NewGVR("coordination.k8s.io/v1/leases")
+
 	// Policy...
 	PdbGVR = NewGVR("policy/v1/poddisruptionbudgets")
 	PspGVR = NewGVR("policy/v1beta1/podsecuritypolicies")
@@ -50,22 +53,52 @@ var (
 	PmxGVR = NewGVR("metrics.k8s.io/v1beta1/pods")
 
 	// K9s...
-	CpuGVR = NewGVR("cpu")
-	MemGVR = NewGVR("memory")
-	WkGVR  = NewGVR("workloads")
-	CoGVR  = NewGVR("containers")
-	CtGVR  = NewGVR("contexts")
-	RefGVR = NewGVR("references")
-	PuGVR  = NewGVR("pulses")
-	ScnGVR = NewGVR("scans")
-	DirGVR = NewGVR("dirs")
-	PfGVR  = NewGVR("portforwards")
-	SdGVR  = NewGVR("screendumps")
-	BeGVR  = NewGVR("benchmarks")
-	AliGVR = NewGVR("aliases")
-	XGVR   = NewGVR("xrays")
-	HlpGVR = NewGVR("help")
-	QGVR   = NewGVR("quit")
+	CpuGVR  = NewGVR("cpu")
+	MemGVR  = NewGVR("memory")
+	WkGVR   = NewGVR("workloads")
+	CoGVR   = NewGVR("containers")
+	CtGVR   = NewGVR("contexts")
+	RefGVR  = NewGVR("references")
+	PuGVR   = NewGVR("pulses")
+	ScnGVR  = NewGVR("scans")
+	DirGVR  = NewGVR("dirs")
+	PfGVR   = NewGVR("portforwards")
+	SdGVR   = NewGVR("screendumps")
+	BeGVR   = NewGVR("benchmarks")
+	AliGVR  = NewGVR("aliases")
+	XGVR    = NewGVR("xrays")
+	HlpGVR  = NewGVR("help")
+	QGVR    = NewGVR("quit")
+	OomGVR  = NewGVR("ooms")
+	EtcdGVR = NewGVR("etcdmembers")
+	RuleGVR = NewGVR("rules")
+	HygGVR  = NewGVR("hygiene")
+	CnyGVR  = NewGVR("comparisons")
+	WchGVR  = NewGVR("watchers")
+	InvGVR  = NewGVR("inventory")
+	JanGVR  = NewGVR("cleanups")
+
+	// Snapshots...
+	VolumeSnapshotGVR        = NewGVR("snapshot.storage.k8s.io/v1/volumesnapshots")
+	VolumeSnapshotClassGVR   = NewGVR("snapshot.storage.k8s.io/v1/volumesnapshotclasses")
+	VolumeSnapshotContentGVR = NewGVR("snapshot.storage.k8s.io/v1/volumesnapshotcontents")
+
+	// Longhorn...
+	LonghornBackupGVR  = NewGVR("longhorn.io/v1beta2/backups")
+	LonghornVolumeGVR  = NewGVR("longhorn.io/v1beta2/volumes")
+	LonghornReplicaGVR = NewGVR("longhorn.io/v1beta2/replicas")
+	LonghornNodeGVR    = NewGVR("longhorn.io/v1beta2/nodes")
+	LonghornTargetGVR  = NewGVR("longhorn.io/v1beta2/backuptargets")
+	LonghornJobGVR     = NewGVR("longhorn.io/v1beta2/recurringjobs")
+
+	// Rancher...
+	RancherClusterGVR = NewGVR("management.cattle.io/v3/clusters")
+	FleetClusterGVR   = NewGVR("fleet.cattle.io/v1alpha1/clusters")
+	FleetBundleGVR    = NewGVR("fleet.cattle.io/v1alpha1/bundles")
+	FleetGitRepoGVR   = NewGVR("fleet.cattle.io/v1alpha1/gitrepos")
+	RancherUserGVR    = NewGVR("management.cattle.io/v3/users")
+	RancherTokenGVR   = NewGVR("management.cattle.io/v3/tokens")
+	RancherGrbGVR     = NewGVR("management.cattle.io/v3/globalrolebindings")
 
 	// Helm...
 	HmGVR  = NewGVR("helm")
@@ -99,6 +132,14 @@ var reservedGVRs = sets.New(
 	XGVR,
 	HlpGVR,
 	QGVR,
+	OomGVR,
+	EtcdGVR,
+	RuleGVR,
+	HygGVR,
+	CnyGVR,
+	WchGVR,
+	InvGVR,
+	JanGVR,
 	HmGVR,
 	HmhGVR,
 	RbacGVR,
@@ -132,6 +132,9 @@ type Connection interface {
 	// CheckConnectivity checks if api server connection is happy or not.
 	CheckConnectivity() bool
 
+	// ConnectionError returns the last connectivity check error if any.
+	ConnectionError() error
+
 	// ActiveContext returns the current context name.
 	ActiveContext() string
 
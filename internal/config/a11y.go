@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+)
+
+// Accessibility tracks the screen reader output settings.
+type Accessibility struct {
+	// Output specifies the file or named pipe view dumps are appended to.
+	Output string `json:"output,omitempty" yaml:"output,omitempty"`
+}
+
+// A11yOutput returns the location view dumps are written to.
+func (k *K9s) A11yOutput() string {
+	if k.Accessibility != nil && k.Accessibility.Output != "" {
+		return k.Accessibility.Output
+	}
+	path, err := xdg.StateFile(filepath.Join(AppName, "a11y.jsonl"))
+	if err != nil {
+		return filepath.Join(AppConfigDir, "a11y.jsonl")
+	}
+
+	return path
+}
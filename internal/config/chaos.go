@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/derailed/k9s/internal/config/data"
+)
+
+// DefaultChaosDuration tracks how long chaos actions last before they are reverted.
+const DefaultChaosDuration = 5 * time.Minute
+
+// Chaos tracks the guarded chaos testing helpers.
+type Chaos struct {
+	Enable    bool     `json:"enable" yaml:"enable"`
+	Contexts  []string `json:"contexts" yaml:"contexts"`
+	Protected []string `json:"protected,omitempty" yaml:"protected,omitempty"`
+	Duration  string   `json:"duration,omitempty" yaml:"duration,omitempty"`
+}
+
+// Guard checks chaos actions are allowed on a context. A context must match
+// one of the chaos context patterns and none of the protected ones.
+func (c *Chaos) Guard(context string) error {
+	if c == nil || !c.Enable {
+		return errors.New("chaos actions are not enabled")
+	}
+	pp := c.Protected
+	if len(pp) == 0 {
+		pp = defaultProductionContexts
+	}
+	ok, err := matchContext(pp, context, "protected")
+	if err != nil {
+		return err
+	}
+	if ok {
+		return fmt.Errorf("context %q is protected from chaos actions", context)
+	}
+	if ok, err = matchContext(c.Contexts, context, "chaos"); err != nil || ok {
+		return err
+	}
+
+	return fmt.Errorf("context %q does not match any chaos context pattern", context)
+}
+
+// RevertAfter returns how long chaos actions last before they are reverted.
+func (c *Chaos) RevertAfter() (time.Duration, error) {
+	if c.Duration == "" {
+		return DefaultChaosDuration, nil
+	}
+	d, err := time.ParseDuration(c.Duration)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid chaos duration %q", c.Duration)
+	}
+
+	return d, nil
+}
+
+// ChaosAuditEntry represents an audited chaos action.
+type ChaosAuditEntry struct {
+	At      time.Time `json:"at"`
+	Context string    `json:"context"`
+	Action  string    `json:"action"`
+	Target  string    `json:"target"`
+	Result  string    `json:"result"`
+}
+
+// ChaosAuditFile returns the chaos audit log path.
+func ChaosAuditFile() (string, error) {
+	return xdg.StateFile(filepath.Join(AppName, "chaos-audit.log"))
+}
+
+// AppendChaosAudit appends an entry to a chaos audit log.
+func AppendChaosAudit(path string, e ChaosAuditEntry) error {
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, data.DefaultFileMod)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(raw, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// ChaosPending represents a chaos action waiting to be reverted, persisted so
+// a crash does not leave it in place.
+type ChaosPending struct {
+	Context string    `json:"context"`
+	Action  string    `json:"action"`
+	Target  string    `json:"target"`
+	Due     time.Time `json:"due"`
+}
+
+// ChaosPendingFile returns the path of the pending chaos reversals.
+func ChaosPendingFile() (string, error) {
+	return xdg.StateFile(filepath.Join(AppName, "chaos-pending.json"))
+}
+
+// LoadChaosPending loads the pending chaos reversals. A missing file holds none.
+func LoadChaosPending(path string) ([]ChaosPending, error) {
+	bb, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var pp []ChaosPending
+	if err := json.Unmarshal(bb, &pp); err != nil {
+		return nil, fmt.Errorf("chaos pending reversals %s: %w", path, err)
+	}
+
+	return pp, nil
+}
+
+// SaveChaosPending saves the pending chaos reversals, removing the file once
+// none are left.
+func SaveChaosPending(path string, pp []ChaosPending) error {
+	if len(pp) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	raw, err := json.Marshal(pp)
+	if err != nil {
+		return err
+	}
+	if err := data.EnsureDirPath(path, data.DefaultDirMod); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, raw, data.DefaultFileMod)
+}
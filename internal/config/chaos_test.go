@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaosGuard(t *testing.T) {
+	uu := map[string]struct {
+		c   *config.Chaos
+		ctx string
+		err string
+	}{
+		"disabled": {
+			c:   &config.Chaos{Contexts: []string{"dev"}},
+			ctx: "dev-1",
+			err: "chaos actions are not enabled",
+		},
+		"none": {
+			ctx: "dev-1",
+			err: "chaos actions are not enabled",
+		},
+		"allowed": {
+			c:   &config.Chaos{Enable: true, Contexts: []string{"^dev-", "^staging"}},
+			ctx: "staging-eu",
+		},
+		"no-match": {
+			c:   &config.Chaos{Enable: true, Contexts: []string{"^dev-"}},
+			ctx: "qa-1",
+			err: `context "qa-1" does not match any chaos context pattern`,
+		},
+		"default-protected": {
+			c:   &config.Chaos{Enable: true, Contexts: []string{".*"}},
+			ctx: "eu-PROD-1",
+			err: `context "eu-PROD-1" is protected from chaos actions`,
+		},
+		"custom-protected": {
+			c:   &config.Chaos{Enable: true, Contexts: []string{".*"}, Protected: []string{"^live"}},
+			ctx: "live-1",
+			err: `context "live-1" is protected from chaos actions`,
+		},
+		"bad-pattern": {
+			c:   &config.Chaos{Enable: true, Contexts: []string{"("}},
+			ctx: "dev",
+			err: "invalid chaos context pattern \"(\": error parsing regexp: missing closing ): `(`",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := u.c.Guard(u.ctx)
+			if u.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, u.err)
+		})
+	}
+}
+
+func TestChaosRevertAfter(t *testing.T) {
+	d, err := (&config.Chaos{}).RevertAfter()
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultChaosDuration, d)
+
+	d, err = (&config.Chaos{Duration: "10m"}).RevertAfter()
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, d)
+
+	_, err = (&config.Chaos{Duration: "0s"}).RevertAfter()
+	assert.EqualError(t, err, `invalid chaos duration "0s"`)
+}
+
+func TestAppendChaosAudit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	at := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, config.AppendChaosAudit(path, config.ChaosAuditEntry{At: at, Context: "dev", Action: "cordon", Target: "n1", Result: "done"}))
+	require.NoError(t, config.AppendChaosAudit(path, config.ChaosAuditEntry{At: at, Context: "dev", Action: "uncordon", Target: "n1", Result: "done"}))
+
+	bb, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"at":"2026-01-02T10:00:00Z","context":"dev","action":"cordon","target":"n1","result":"done"}
+{"at":"2026-01-02T10:00:00Z","context":"dev","action":"uncordon","target":"n1","result":"done"}
+`, string(bb))
+}
+
+func TestChaosPending(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rk9s", "chaos-pending.json")
+	pp, err := config.LoadChaosPending(path)
+	require.NoError(t, err)
+	assert.Empty(t, pp)
+
+	due := time.Date(2026, 1, 2, 10, 5, 0, 0, time.UTC)
+	ee := []config.ChaosPending{
+		{Context: "dev", Action: "node", Target: "n1", Due: due},
+		{Context: "dev", Action: "netdeny", Target: "default/rk9s-chaos-web", Due: due},
+	}
+	require.NoError(t, config.SaveChaosPending(path, ee))
+	pp, err = config.LoadChaosPending(path)
+	require.NoError(t, err)
+	assert.Equal(t, ee, pp)
+
+	require.NoError(t, config.SaveChaosPending(path, nil))
+	assert.NoFileExists(t, path)
+	require.NoError(t, config.SaveChaosPending(path, nil))
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MinCheckInterval represents the shortest scheduled check interval.
+const MinCheckInterval = time.Minute
+
+const (
+	// CheckPlugin runs a plugin on each selected context.
+	CheckPlugin = "plugin"
+
+	// CheckDiag runs a diagnostic across the selected contexts.
+	CheckDiag = "diag"
+
+	// CheckCount counts the resources matching a view command on each selected context.
+	CheckCount = "count"
+)
+
+// Check represents a background check run on a schedule.
+type Check struct {
+	Name   string `json:"name" yaml:"name"`
+	Every  string `json:"every" yaml:"every"`
+	Plugin string `json:"plugin,omitempty" yaml:"plugin,omitempty"`
+	Diag   string `json:"diag,omitempty" yaml:"diag,omitempty"`
+	Count  string `json:"count,omitempty" yaml:"count,omitempty"`
+	Max    int    `json:"max,omitempty" yaml:"max,omitempty"`
+}
+
+// Interval returns the check interval.
+func (c Check) Interval() (time.Duration, error) {
+	d, err := time.ParseDuration(c.Every)
+	if err != nil {
+		return 0, fmt.Errorf("check %q invalid interval: %w", c.Name, err)
+	}
+	if d < MinCheckInterval {
+		return 0, fmt.Errorf("check %q interval must be at least %s", c.Name, MinCheckInterval)
+	}
+
+	return d, nil
+}
+
+// Kind returns the check type or an error if the check is ambiguous.
+func (c Check) Kind() (string, error) {
+	var kk []string
+	if c.Plugin != "" {
+		kk = append(kk, CheckPlugin)
+	}
+	if c.Diag != "" {
+		kk = append(kk, CheckDiag)
+	}
+	if c.Count != "" {
+		kk = append(kk, CheckCount)
+	}
+	switch len(kk) {
+	case 0:
+		return "", fmt.Errorf("check %q needs one of plugin, diag or count", c.Name)
+	case 1:
+		return kk[0], nil
+	default:
+		return "", fmt.Errorf("check %q must set only one of plugin, diag or count", c.Name)
+	}
+}
+
+// Validate checks the check settings.
+func (c Check) Validate() error {
+	if c.Name == "" {
+		return errors.New("check name is required")
+	}
+	if _, err := c.Interval(); err != nil {
+		return err
+	}
+	_, err := c.Kind()
+
+	return err
+}
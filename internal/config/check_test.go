@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckValidate(t *testing.T) {
+	uu := map[string]struct {
+		c     config.Check
+		kind  string
+		every time.Duration
+		err   string
+	}{
+		"count": {
+			c:     config.Check{Name: "crashes", Every: "10m", Count: "pods /crash"},
+			kind:  config.CheckCount,
+			every: 10 * time.Minute,
+		},
+		"plugin": {
+			c:     config.Check{Name: "etcd", Every: "1h", Plugin: "etcd-health"},
+			kind:  config.CheckPlugin,
+			every: time.Hour,
+		},
+		"no-name": {
+			c:   config.Check{Every: "1h", Diag: "dns"},
+			err: "check name is required",
+		},
+		"too-often": {
+			c:   config.Check{Name: "dns", Every: "10s", Diag: "dns"},
+			err: `check "dns" interval must be at least 1m0s`,
+		},
+		"bad-interval": {
+			c:   config.Check{Name: "dns", Every: "often", Diag: "dns"},
+			err: `check "dns" invalid interval: time: invalid duration "often"`,
+		},
+		"none": {
+			c:   config.Check{Name: "dns", Every: "5m"},
+			err: `check "dns" needs one of plugin, diag or count`,
+		},
+		"ambiguous": {
+			c:   config.Check{Name: "dns", Every: "5m", Diag: "dns", Plugin: "dns"},
+			err: `check "dns" must set only one of plugin, diag or count`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := u.c.Validate()
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			kind, _ := u.c.Kind()
+			assert.Equal(t, u.kind, kind)
+			every, _ := u.c.Interval()
+			assert.Equal(t, u.every, every)
+		})
+	}
+}
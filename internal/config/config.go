@@ -234,6 +234,23 @@ func (c *Config) SetActiveView(view string) {
 	}
 }
 
+// ViewState returns the last state of a resource view in the current context.
+func (c *Config) ViewState(gvr string) (data.ViewState, bool) {
+	ct, err := c.K9s.ActiveContext()
+	if err != nil || ct.View == nil {
+		return data.ViewState{}, false
+	}
+
+	return ct.View.State(gvr)
+}
+
+// SetViewState records the state of a resource view in the current context.
+func (c *Config) SetViewState(gvr string, s data.ViewState) {
+	if ct, err := c.K9s.ActiveContext(); err == nil && ct.View != nil {
+		ct.View.SetState(gvr, s)
+	}
+}
+
 // GetConnection return an api server connection.
 func (c *Config) GetConnection() client.Connection {
 	return c.conn
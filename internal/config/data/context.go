@@ -20,6 +20,11 @@ type Context struct {
 	View         *View        `yaml:"view"`
 	FeatureGates FeatureGates `yaml:"featureGates"`
 	Proxy        *Proxy       `yaml:"proxy"`
+	Hooks        []Hook       `yaml:"hooks,omitempty"`
+	Gates        []Gate       `yaml:"gates,omitempty"`
+	Login        *Login       `yaml:"login,omitempty"`
+	Prometheus   *Prometheus  `yaml:"prometheus,omitempty"`
+	History      *History     `yaml:"history,omitempty"`
 	mx           sync.RWMutex
 }
 
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Endpoint tracks how to reach a monitoring endpoint, ie Prometheus or Loki,
+// either directly via a URL or via an in-cluster service reached through the
+// API server proxy or a port-forward.
+type Endpoint struct {
+	URL string `yaml:"url,omitempty"`
+
+	// Service is the in-cluster service as ns/name:port.
+	Service string `yaml:"service,omitempty"`
+
+	// Scheme is the service scheme, http or https. Defaults to http.
+	Scheme string `yaml:"scheme,omitempty"`
+
+	// PortForward reaches the service through a port-forward rather than the
+	// API server proxy.
+	PortForward bool `yaml:"portForward,omitempty"`
+
+	// Auth authenticates requests sent to the URL or the port-forward.
+	Auth *EndpointAuth `yaml:"auth,omitempty"`
+}
+
+// EndpointAuth tracks the credentials of a monitoring endpoint.
+type EndpointAuth struct {
+	BearerToken     string `yaml:"bearerToken,omitempty"`
+	BearerTokenFile string `yaml:"bearerTokenFile,omitempty"`
+	Username        string `yaml:"username,omitempty"`
+	Password        string `yaml:"password,omitempty"`
+	PasswordFile    string `yaml:"passwordFile,omitempty"`
+
+	// CAFile, CertFile and KeyFile configure TLS, mTLS with a client cert.
+	CAFile             string `yaml:"caFile,omitempty"`
+	CertFile           string `yaml:"certFile,omitempty"`
+	KeyFile            string `yaml:"keyFile,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify,omitempty"`
+}
+
+// ServiceScheme returns the scheme of the endpoint service.
+func (e *Endpoint) ServiceScheme() string {
+	if e == nil || e.Scheme == "" {
+		return "http"
+	}
+
+	return e.Scheme
+}
+
+// ServiceRef returns the namespace, name and port of the endpoint service or
+// of the given default ns/name:port when none is set.
+func (e *Endpoint) ServiceRef(dflt string, dfltPort int) (ns, name string, port int, err error) {
+	svc := dflt
+	if e != nil && e.Service != "" {
+		svc = e.Service
+	}
+	ns, rest, ok := strings.Cut(svc, "/")
+	if !ok {
+		return "", "", 0, fmt.Errorf("invalid service %q, expecting ns/name:port", svc)
+	}
+	name, sport, ok := strings.Cut(rest, ":")
+	if !ok {
+		return ns, name, dfltPort, nil
+	}
+	if port, err = strconv.Atoi(sport); err != nil {
+		return "", "", 0, fmt.Errorf("invalid service port %q", sport)
+	}
+
+	return ns, name, port, nil
+}
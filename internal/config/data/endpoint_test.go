@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/config/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointServiceRef(t *testing.T) {
+	type ref struct {
+		ns, name string
+		port     int
+	}
+	uu := map[string]struct {
+		e   *data.Endpoint
+		ref ref
+		err string
+	}{
+		"default": {
+			ref: ref{ns: "cattle-monitoring-system", name: "rancher-monitoring-prometheus", port: 9090},
+		},
+		"custom": {
+			e:   &data.Endpoint{Service: "monitoring/prometheus-operated:9091"},
+			ref: ref{ns: "monitoring", name: "prometheus-operated", port: 9091},
+		},
+		"no-port": {
+			e:   &data.Endpoint{Service: "monitoring/prometheus-operated"},
+			ref: ref{ns: "monitoring", name: "prometheus-operated", port: 9090},
+		},
+		"no-ns": {
+			e:   &data.Endpoint{Service: "prometheus-operated:9090"},
+			err: `invalid service "prometheus-operated:9090", expecting ns/name:port`,
+		},
+		"bad-port": {
+			e:   &data.Endpoint{Service: "monitoring/prometheus-operated:http"},
+			err: `invalid service port "http"`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ns, name, port, err := u.e.ServiceRef(data.DefaultPromService, 9090)
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.ref, ref{ns: ns, name: name, port: port})
+		})
+	}
+}
+
+func TestEndpointServiceScheme(t *testing.T) {
+	var e *data.Endpoint
+	assert.Equal(t, "http", e.ServiceScheme())
+	assert.Equal(t, "https", (&data.Endpoint{Scheme: "https"}).ServiceScheme())
+}
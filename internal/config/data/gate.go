@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package data
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultGateTimeout tracks how long a gate check may run before it fails.
+const DefaultGateTimeout = 10 * time.Second
+
+// Gate represents a check that must pass before mutations are enabled in a
+// context, ie a change-freeze calendar lookup or an on-call acknowledgment.
+type Gate struct {
+	Name    string   `yaml:"name"`
+	Command string   `yaml:"command"`
+	Args    []string `yaml:"args,omitempty"`
+	Timeout string   `yaml:"timeout,omitempty"`
+}
+
+// Deadline returns the gate timeout.
+func (g Gate) Deadline() (time.Duration, error) {
+	if g.Timeout == "" {
+		return DefaultGateTimeout, nil
+	}
+	d, err := time.ParseDuration(g.Timeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("gate %q invalid timeout %q", g.Name, g.Timeout)
+	}
+
+	return d, nil
+}
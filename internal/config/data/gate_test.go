@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package data_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGateDeadline(t *testing.T) {
+	uu := map[string]struct {
+		g   data.Gate
+		e   time.Duration
+		err string
+	}{
+		"default": {
+			g: data.Gate{Name: "freeze"},
+			e: data.DefaultGateTimeout,
+		},
+		"custom": {
+			g: data.Gate{Name: "oncall", Timeout: "1m"},
+			e: time.Minute,
+		},
+		"invalid": {
+			g:   data.Gate{Name: "oncall", Timeout: "later"},
+			err: `gate "oncall" invalid timeout "later"`,
+		},
+		"zero": {
+			g:   data.Gate{Name: "oncall", Timeout: "0s"},
+			err: `gate "oncall" invalid timeout "0s"`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			d, err := u.g.Deadline()
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, d)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package data
+
+import "time"
+
+// DefaultHistoryLookback tracks how far back resource history is searched.
+const DefaultHistoryLookback = 7 * 24 * time.Hour
+
+// History tracks a context's resource history backend, fed by the API server
+// audit events recorded at the RequestResponse level.
+type History struct {
+	// Path is a local audit log file or directory, ie synced from an S3 sink.
+	Path string `yaml:"path,omitempty"`
+
+	// Loki queries audit events from a Loki instance.
+	Loki *LokiHistory `yaml:"loki,omitempty"`
+
+	// Lookback specifies how far back to search, ie `72h`. Defaults to 7 days.
+	Lookback string `yaml:"lookback,omitempty"`
+}
+
+// LokiHistory tracks a Loki audit events stream.
+type LokiHistory struct {
+	Endpoint `yaml:",inline"`
+
+	// Selector is the LogQL stream selector of the audit events.
+	Selector string `yaml:"selector,omitempty"`
+
+	// OrgID sets the tenant of multi-tenant Loki instances.
+	OrgID string `yaml:"orgID,omitempty"`
+}
+
+// LookbackDuration returns the history search window.
+func (h *History) LookbackDuration() time.Duration {
+	if h == nil {
+		return DefaultHistoryLookback
+	}
+	if d, err := time.ParseDuration(h.Lookback); err == nil && d > 0 {
+		return d
+	}
+
+	return DefaultHistoryLookback
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package data
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultHookTimeout tracks how long a hook may run before it is canceled.
+const DefaultHookTimeout = 30 * time.Second
+
+// Hook represents a command run when a context is activated, ie a VPN check,
+// a SSO login or a port-forward setup.
+type Hook struct {
+	Name            string   `yaml:"name"`
+	Command         string   `yaml:"command"`
+	Args            []string `yaml:"args,omitempty"`
+	Timeout         string   `yaml:"timeout,omitempty"`
+	Background      bool     `yaml:"background,omitempty"`
+	ContinueOnError bool     `yaml:"continueOnError,omitempty"`
+}
+
+// Deadline returns the hook timeout.
+func (h Hook) Deadline() (time.Duration, error) {
+	if h.Timeout == "" {
+		return DefaultHookTimeout, nil
+	}
+	d, err := time.ParseDuration(h.Timeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("hook %q invalid timeout %q", h.Name, h.Timeout)
+	}
+
+	return d, nil
+}
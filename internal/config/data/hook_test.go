@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package data_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHookDeadline(t *testing.T) {
+	uu := map[string]struct {
+		h   data.Hook
+		e   time.Duration
+		err string
+	}{
+		"default": {
+			h: data.Hook{Name: "vpn"},
+			e: data.DefaultHookTimeout,
+		},
+		"custom": {
+			h: data.Hook{Name: "sso", Timeout: "2m"},
+			e: 2 * time.Minute,
+		},
+		"invalid": {
+			h:   data.Hook{Name: "sso", Timeout: "soon"},
+			err: `hook "sso" invalid timeout "soon"`,
+		},
+		"negative": {
+			h:   data.Hook{Name: "sso", Timeout: "-1s"},
+			err: `hook "sso" invalid timeout "-1s"`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			d, err := u.h.Deadline()
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, d)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package data
+
+// Login tracks a context's interactive login command, run when the context
+// credentials expire.
+type Login struct {
+	Command string   `yaml:"command"`
+	Args    []string `yaml:"args,omitempty"`
+}
@@ -6,6 +6,7 @@ package data
 import (
 	"log/slog"
 	"slices"
+	"strings"
 	"sync"
 
 	"github.com/derailed/k9s/internal/client"
@@ -100,6 +101,24 @@ func (n *Namespace) SetActive(ns string, _ KubeSettings) error {
 	return nil
 }
 
+// SetFavorites replaces the favorite namespaces in order. Locked favorites
+// are no longer updated as namespaces are visited.
+func (n *Namespace) SetFavorites(nn []string, lock bool) {
+	n.mx.Lock()
+	defer n.mx.Unlock()
+
+	favs := make([]string, 0, len(nn))
+	for _, ns := range nn {
+		ns = strings.TrimSpace(ns)
+		if ns == "" || slices.Contains(favs, ns) {
+			continue
+		}
+		favs = append(favs, ns)
+	}
+	n.Favorites, n.LockFavorites = favs, lock
+	n.trimFavNs()
+}
+
 func (n *Namespace) isAllNamespaces() bool {
 	return n.Active == client.NamespaceAll || n.Active == ""
 }
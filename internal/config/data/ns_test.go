@@ -75,3 +75,34 @@ func TestNSValidateRmFavs(t *testing.T) {
 
 	assert.Equal(t, []string{"default", "fred"}, ns.Favorites)
 }
+
+func TestNSSetFavorites(t *testing.T) {
+	uu := map[string]struct {
+		nn   []string
+		lock bool
+		e    []string
+	}{
+		"none": {
+			e: []string{},
+		},
+		"cleanse": {
+			nn:   []string{" ns1", "", "ns2", "ns1"},
+			lock: true,
+			e:    []string{"ns1", "ns2"},
+		},
+		"max": {
+			nn: []string{"ns1", "ns2", "ns3", "ns4", "ns5", "ns6", "ns7", "ns8", "ns9", "ns10"},
+			e:  []string{"ns1", "ns2", "ns3", "ns4", "ns5", "ns6", "ns7", "ns8", "ns9"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ns := data.NewNamespace()
+			ns.SetFavorites(u.nn, u.lock)
+			assert.Equal(t, u.e, ns.Favorites)
+			assert.Equal(t, u.lock, ns.LockFavorites)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package data
+
+// DefaultPromService tracks the Rancher Monitoring Prometheus service.
+const DefaultPromService = "cattle-monitoring-system/rancher-monitoring-prometheus:9090"
+
+// Prometheus tracks a context's Prometheus endpoint, either a URL or a
+// service reached through the API server proxy or a port-forward.
+type Prometheus struct {
+	Endpoint `yaml:",inline"`
+}
+
+// PromEndpoint returns the Prometheus endpoint or nil if none is set.
+func (p *Prometheus) PromEndpoint() *Endpoint {
+	if p == nil {
+		return nil
+	}
+
+	return &p.Endpoint
+}
@@ -3,11 +3,43 @@
 
 package data
 
+import (
+	"strings"
+	"sync"
+)
+
 const DefaultView = "po"
 
 // View tracks view configuration options.
 type View struct {
-	Active string `yaml:"active"`
+	Active string                `yaml:"active"`
+	States map[string]*ViewState `yaml:"states,omitempty"`
+	mx     sync.RWMutex
+}
+
+// ViewState tracks how a resource view was last left so it can be restored
+// across restarts.
+type ViewState struct {
+	// SortColumn specifies the sort column and direction, ie `AGE:desc`.
+	SortColumn string `yaml:"sortColumn,omitempty"`
+
+	// Filter specifies the view filter, ie `-l app=fred`.
+	Filter string `yaml:"filter,omitempty"`
+
+	// Namespace specifies the view namespace.
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// IsBlank checks if the state holds anything worth restoring.
+func (s ViewState) IsBlank() bool {
+	return s == ViewState{}
+}
+
+// SortCol returns the sort column name and direction.
+func (s ViewState) SortCol() (name string, asc, ok bool) {
+	name, dir, ok := strings.Cut(s.SortColumn, ":")
+
+	return name, dir == "asc", ok && name != ""
 }
 
 // NewView creates a new view configuration.
@@ -21,3 +53,31 @@ func (v *View) Validate() {
 		v.Active = DefaultView
 	}
 }
+
+// State returns the last state of a resource view.
+func (v *View) State(gvr string) (ViewState, bool) {
+	v.mx.RLock()
+	defer v.mx.RUnlock()
+
+	s, ok := v.States[gvr]
+	if !ok || s == nil {
+		return ViewState{}, false
+	}
+
+	return *s, true
+}
+
+// SetState records the state of a resource view. Blank states are dropped.
+func (v *View) SetState(gvr string, s ViewState) {
+	v.mx.Lock()
+	defer v.mx.Unlock()
+
+	if s.IsBlank() {
+		delete(v.States, gvr)
+		return
+	}
+	if v.States == nil {
+		v.States = make(map[string]*ViewState)
+	}
+	v.States[gvr] = &s
+}
@@ -26,3 +26,24 @@ func TestViewValidateBlank(t *testing.T) {
 	v.Validate()
 	assert.Equal(t, "po", v.Active)
 }
+
+func TestViewState(t *testing.T) {
+	v := data.NewView()
+
+	_, ok := v.State("v1/pods")
+	assert.False(t, ok)
+
+	v.SetState("v1/pods", data.ViewState{SortColumn: "AGE:desc", Filter: "-l app=fred", Namespace: "fred"})
+	s, ok := v.State("v1/pods")
+	assert.True(t, ok)
+	assert.Equal(t, data.ViewState{SortColumn: "AGE:desc", Filter: "-l app=fred", Namespace: "fred"}, s)
+	n, asc, ok := s.SortCol()
+	assert.True(t, ok)
+	assert.Equal(t, "AGE", n)
+	assert.False(t, asc)
+
+	v.SetState("v1/pods", data.ViewState{})
+	_, ok = v.State("v1/pods")
+	assert.False(t, ok)
+	assert.Empty(t, v.States)
+}
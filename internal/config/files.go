@@ -85,6 +85,12 @@ var (
 
 	// AppHotKeysFile tracks hotkeys config file.
 	AppHotKeysFile string
+
+	// AppMacrosFile tracks macros config file.
+	AppMacrosFile string
+
+	// AppSelectedContextsFile overrides the multi-context selection file.
+	AppSelectedContextsFile string
 )
 
 // InitLogLoc initializes K9s logs location.
@@ -164,6 +170,7 @@ func initK9sEnvLocs() error {
 
 	AppConfigFile = filepath.Join(AppConfigDir, data.MainConfigFile)
 	AppHotKeysFile = filepath.Join(AppConfigDir, "hotkeys.yaml")
+	AppMacrosFile = filepath.Join(AppConfigDir, "macros.yaml")
 	AppAliasesFile = filepath.Join(AppConfigDir, "aliases.yaml")
 	AppPluginsFile = filepath.Join(AppConfigDir, "plugins.yaml")
 	AppViewsFile = filepath.Join(AppConfigDir, "views.yaml")
@@ -185,6 +192,7 @@ func initXDGLocs() error {
 	}
 
 	AppHotKeysFile = filepath.Join(AppConfigDir, "hotkeys.yaml")
+	AppMacrosFile = filepath.Join(AppConfigDir, "macros.yaml")
 	AppAliasesFile = filepath.Join(AppConfigDir, "aliases.yaml")
 	AppPluginsFile = filepath.Join(AppConfigDir, "plugins.yaml")
 	AppViewsFile = filepath.Join(AppConfigDir, "views.yaml")
@@ -311,6 +319,9 @@ func SkinFileFromName(n string) string {
 
 // SelectedContextsPath returns the path for rk9s multi-context selection.
 func SelectedContextsPath() string {
+	if AppSelectedContextsFile != "" {
+		return AppSelectedContextsFile
+	}
 	path, err := xdg.ConfigFile(filepath.Join(AppName, "selected_contexts"))
 	if err != nil {
 		return filepath.Join(AppConfigDir, "selected_contexts")
@@ -318,6 +329,18 @@ func SelectedContextsPath() string {
 	return path
 }
 
+// WatchRecordingFile returns the path of a watch recording.
+func WatchRecordingFile(name string) (string, error) {
+	if filepath.IsAbs(name) {
+		return name, nil
+	}
+	if filepath.Ext(name) == "" {
+		name += ".jsonl"
+	}
+
+	return xdg.StateFile(filepath.Join(AppName, "recordings", name))
+}
+
 // LoadSelectedContexts reads the list of selected contexts (one per line).
 func LoadSelectedContexts() ([]string, error) {
 	path := SelectedContextsPath()
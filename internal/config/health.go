@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+// DefaultHealthAddress tracks the default health endpoint address.
+const DefaultHealthAddress = "127.0.0.1:9198"
+
+// Health tracks the local endpoint reporting the session health.
+type Health struct {
+	Enable  bool   `json:"enable" yaml:"enable"`
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+}
+
+// Addr returns the health endpoint address.
+func (h Health) Addr() string {
+	if h.Address == "" {
+		return DefaultHealthAddress
+	}
+
+	return h.Address
+}
@@ -4,10 +4,13 @@
 package config
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
 	"os/user"
 	"path/filepath"
+	"regexp"
+	"time"
 
 	"github.com/derailed/k9s/internal/slogs"
 )
@@ -26,6 +29,34 @@ func isStringSet(s *string) bool {
 	return s != nil && *s != ""
 }
 
+// defaultProductionContexts tracks the context patterns deemed production.
+var defaultProductionContexts = []string{`(?i)prod`}
+
+// matchContext checks if a context matches one of the given patterns. Kind
+// names the patterns in errors, ie `protected`.
+func matchContext(pp []string, context, kind string) (bool, error) {
+	for _, p := range pp {
+		ok, err := regexp.MatchString(p, context)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s context pattern %q: %w", kind, p, err)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// parseDurationOr parses a positive duration, ie `30s`, or returns dflt.
+func parseDurationOr(s string, dflt time.Duration) time.Duration {
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d
+	}
+
+	return dflt
+}
+
 func isYamlFile(file string) bool {
 	ext := filepath.Ext(file)
 
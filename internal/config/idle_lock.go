@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+import "time"
+
+// IdleLock tracks the lock of idle sessions, ie on shared bastions.
+type IdleLock struct {
+	// Timeout locks the session after no input for that long, ie `15m`.
+	// Disabled when blank.
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// Reauth runs the context login command to unlock the session.
+	Reauth bool `json:"reauth,omitempty" yaml:"reauth,omitempty"`
+
+	// DropCredentials drops the cluster clients and caches while locked.
+	DropCredentials bool `json:"dropCredentials,omitempty" yaml:"dropCredentials,omitempty"`
+}
+
+// IdleTimeout returns how long a session may stay idle before it locks or
+// zero when locking is disabled.
+func (l *IdleLock) IdleTimeout() time.Duration {
+	if l == nil {
+		return 0
+	}
+
+	return parseDurationOr(l.Timeout, 0)
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdleLockTimeout(t *testing.T) {
+	uu := map[string]struct {
+		l *config.IdleLock
+		e time.Duration
+	}{
+		"nil":      {},
+		"blank":    {l: &config.IdleLock{Reauth: true}},
+		"toast":    {l: &config.IdleLock{Timeout: "soon"}},
+		"negative": {l: &config.IdleLock{Timeout: "-5m"}},
+		"custom":   {l: &config.IdleLock{Timeout: "15m"}, e: 15 * time.Minute},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.l.IdleTimeout())
+		})
+	}
+}
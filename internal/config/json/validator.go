@@ -35,6 +35,9 @@ const (
 	// HotkeysSchema describes hotkeys schema.
 	HotkeysSchema = "hotkeys.json"
 
+	// MacrosSchema describes macros schema.
+	MacrosSchema = "macros.json"
+
 	// K9sSchema describes k9s config schema.
 	K9sSchema = "k9s.json"
 
@@ -72,6 +75,9 @@ var (
 
 	//go:embed schemas/skin.json
 	skinSchema string
+
+	//go:embed schemas/macros.json
+	macrosSchema string
 )
 
 // Validator tracks schemas validation.
@@ -93,6 +99,7 @@ func NewValidator() *Validator {
 			PluginMultiSchema: gojsonschema.NewStringLoader(pluginMultiSchema),
 			HotkeysSchema:     gojsonschema.NewStringLoader(hotkeysSchema),
 			SkinSchema:        gojsonschema.NewStringLoader(skinSchema),
+			MacrosSchema:      gojsonschema.NewStringLoader(macrosSchema),
 		},
 	}
 	v.register()
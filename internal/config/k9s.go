@@ -34,28 +34,50 @@ var defaultGPUVendors = gpuVendors{
 
 // K9s tracks K9s configuration options.
 type K9s struct {
-	LiveViewAutoRefresh bool       `json:"liveViewAutoRefresh" yaml:"liveViewAutoRefresh"`
-	GPUVendors          gpuVendors `json:"gpuVendors" yaml:"gpuVendors"`
-	ScreenDumpDir       string     `json:"screenDumpDir" yaml:"screenDumpDir,omitempty"`
-	RefreshRate         float32    `json:"refreshRate" yaml:"refreshRate"`
-	APIServerTimeout    string     `json:"apiServerTimeout" yaml:"apiServerTimeout"`
-	MaxConnRetry        int32      `json:"maxConnRetry" yaml:"maxConnRetry"`
-	ReadOnly            bool       `json:"readOnly" yaml:"readOnly"`
-	NoExitOnCtrlC       bool       `json:"noExitOnCtrlC" yaml:"noExitOnCtrlC"`
-	PortForwardAddress  string     `yaml:"portForwardAddress"`
-	UI                  UI         `json:"ui" yaml:"ui"`
-	SkipLatestRevCheck  bool       `json:"skipLatestRevCheck" yaml:"skipLatestRevCheck"`
-	DisablePodCounting  bool       `json:"disablePodCounting" yaml:"disablePodCounting"`
-	ShellPod            *ShellPod  `json:"shellPod" yaml:"shellPod"`
-	ImageScans          ImageScans `json:"imageScans" yaml:"imageScans"`
-	Logger              Logger     `json:"logger" yaml:"logger"`
-	Thresholds          Threshold  `json:"thresholds" yaml:"thresholds"`
-	DefaultView         string     `json:"defaultView" yaml:"defaultView"`
+	LiveViewAutoRefresh bool             `json:"liveViewAutoRefresh" yaml:"liveViewAutoRefresh"`
+	LiveViewRefreshRate int              `json:"liveViewRefreshRate" yaml:"liveViewRefreshRate,omitempty"`
+	GPUVendors          gpuVendors       `json:"gpuVendors" yaml:"gpuVendors"`
+	ScreenDumpDir       string           `json:"screenDumpDir" yaml:"screenDumpDir,omitempty"`
+	RefreshRate         float32          `json:"refreshRate" yaml:"refreshRate"`
+	APIServerTimeout    string           `json:"apiServerTimeout" yaml:"apiServerTimeout"`
+	MaxConnRetry        int32            `json:"maxConnRetry" yaml:"maxConnRetry"`
+	ReadOnly            bool             `json:"readOnly" yaml:"readOnly"`
+	NoExitOnCtrlC       bool             `json:"noExitOnCtrlC" yaml:"noExitOnCtrlC"`
+	PortForwardAddress  string           `yaml:"portForwardAddress"`
+	UI                  UI               `json:"ui" yaml:"ui"`
+	SkipLatestRevCheck  bool             `json:"skipLatestRevCheck" yaml:"skipLatestRevCheck"`
+	DisablePodCounting  bool             `json:"disablePodCounting" yaml:"disablePodCounting"`
+	ShellPod            *ShellPod        `json:"shellPod" yaml:"shellPod"`
+	ImageScans          ImageScans       `json:"imageScans" yaml:"imageScans"`
+	Logger              Logger           `json:"logger" yaml:"logger"`
+	Thresholds          Threshold        `json:"thresholds" yaml:"thresholds"`
+	DefaultView         string           `json:"defaultView" yaml:"defaultView"`
+	Rancher             *Rancher         `json:"rancher,omitempty" yaml:"rancher,omitempty"`
+	ImageRegistry       *ImageRegistry   `json:"imageRegistry,omitempty" yaml:"imageRegistry,omitempty"`
+	Checks              []Check          `json:"checks,omitempty" yaml:"checks,omitempty"`
+	Rules               []Rule           `json:"rules,omitempty" yaml:"rules,omitempty"`
+	Shell               Shell            `json:"shell,omitempty" yaml:"shell,omitempty"`
+	Sync                *Sync            `json:"sync,omitempty" yaml:"sync,omitempty"`
+	TeamNotes           *TeamNotes       `json:"teamNotes,omitempty" yaml:"teamNotes,omitempty"`
+	Metrics             *Metrics         `json:"metrics,omitempty" yaml:"metrics,omitempty"`
+	Health              *Health          `json:"health,omitempty" yaml:"health,omitempty"`
+	Chaos               *Chaos           `json:"chaos,omitempty" yaml:"chaos,omitempty"`
+	Kubectl             *Kubectl         `json:"kubectl,omitempty" yaml:"kubectl,omitempty"`
+	Warmup              *Warmup          `json:"warmup,omitempty" yaml:"warmup,omitempty"`
+	Accessibility       *Accessibility   `json:"accessibility,omitempty" yaml:"accessibility,omitempty"`
+	Preview             *Preview         `json:"preview,omitempty" yaml:"preview,omitempty"`
+	MultiContext        *MultiContext    `json:"multiContext,omitempty" yaml:"multiContext,omitempty"`
+	IdleLock            *IdleLock        `json:"idleLock,omitempty" yaml:"idleLock,omitempty"`
+	Redaction           *Redaction       `json:"redaction,omitempty" yaml:"redaction,omitempty"`
+	LowPower            *LowPower        `json:"lowPower,omitempty" yaml:"lowPower,omitempty"`
+	Prime               *Prime           `json:"prime,omitempty" yaml:"prime,omitempty"`
+	ServiceAccounts     *ServiceAccounts `json:"serviceAccounts,omitempty" yaml:"serviceAccounts,omitempty"`
 	manualRefreshRate   float32
 	manualReadOnly      *bool
 	manualCommand       *string
 	manualScreenDumpDir *string
 	refreshRateWarned   bool
+	lowPower            bool
 	dir                 *data.Dir
 	activeContextName   string
 	activeConfig        *data.Config
@@ -63,6 +85,7 @@ type K9s struct {
 	ks                  data.KubeSettings
 	mx                  sync.RWMutex
 	contextSwitch       bool
+	gated               bool
 }
 
 // NewK9s create a new K9s configuration.
@@ -136,6 +159,7 @@ func (k *K9s) Merge(k1 *K9s) {
 	}
 
 	k.LiveViewAutoRefresh = k1.LiveViewAutoRefresh
+	k.LiveViewRefreshRate = k1.LiveViewRefreshRate
 	k.DefaultView = k1.DefaultView
 	k.ScreenDumpDir = k1.ScreenDumpDir
 	k.RefreshRate = k1.RefreshRate
@@ -150,6 +174,26 @@ func (k *K9s) Merge(k1 *K9s) {
 	k.ShellPod = k1.ShellPod
 	k.Logger = k1.Logger
 	k.ImageScans = k1.ImageScans
+	k.Rancher = k1.Rancher
+	k.ImageRegistry = k1.ImageRegistry
+	k.Checks = k1.Checks
+	k.Rules = k1.Rules
+	k.Shell = k1.Shell
+	k.Sync = k1.Sync
+	k.TeamNotes = k1.TeamNotes
+	k.Metrics = k1.Metrics
+	k.Health = k1.Health
+	k.Chaos = k1.Chaos
+	k.Kubectl = k1.Kubectl
+	k.Warmup = k1.Warmup
+	k.Accessibility = k1.Accessibility
+	k.Preview = k1.Preview
+	k.MultiContext = k1.MultiContext
+	k.IdleLock = k1.IdleLock
+	k.Redaction = k1.Redaction
+	k.LowPower = k1.LowPower
+	k.Prime = k1.Prime
+	k.ServiceAccounts = k1.ServiceAccounts
 	if k1.Thresholds != nil {
 		k.Thresholds = k1.Thresholds
 	}
@@ -216,6 +260,81 @@ func (k *K9s) ActiveContext() (*data.Context, error) {
 	return ct, err
 }
 
+// ContextHooks returns the pre-flight hooks of a context without activating it.
+func (k *K9s) ContextHooks(contextName string) ([]data.Hook, error) {
+	ct, err := k.ks.GetContext(contextName)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := k.dir.Load(contextName, ct)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Context == nil {
+		return nil, nil
+	}
+
+	return cfg.Context.Hooks, nil
+}
+
+// ContextGates returns the checks gating mutations in a context.
+func (k *K9s) ContextGates(contextName string) ([]data.Gate, error) {
+	ct, err := k.ks.GetContext(contextName)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := k.dir.Load(contextName, ct)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Context == nil {
+		return nil, nil
+	}
+
+	return cfg.Context.Gates, nil
+}
+
+// IsContextReadOnly returns the readonly setting of a context without
+// activating it.
+func (k *K9s) IsContextReadOnly(contextName string) (bool, error) {
+	if contextName == k.ActiveContextName() {
+		return k.IsReadOnly(), nil
+	}
+	ct, err := k.ks.GetContext(contextName)
+	if err != nil {
+		return true, err
+	}
+	cfg, err := k.dir.Load(contextName, ct)
+	if err != nil {
+		return true, err
+	}
+	ro := k.ReadOnly
+	if cfg.Context != nil && cfg.Context.ReadOnly != nil {
+		ro = *cfg.Context.ReadOnly
+	}
+	if k.manualReadOnly != nil {
+		ro = *k.manualReadOnly
+	}
+
+	return ro, nil
+}
+
+// SetGated forces read-only mode until the context gates passed.
+func (k *K9s) SetGated(b bool) {
+	k.mx.Lock()
+	defer k.mx.Unlock()
+
+	k.gated = b
+}
+
+// IsGated returns true if mutations are held back by failing context gates.
+func (k *K9s) IsGated() bool {
+	k.mx.RLock()
+	defer k.mx.RUnlock()
+
+	return k.gated
+}
+
 func (k *K9s) setActiveConfig(c *data.Config) {
 	k.mx.Lock()
 	defer k.mx.Unlock()
@@ -235,6 +354,7 @@ func (k *K9s) setActiveContextName(n string) {
 	defer k.mx.Unlock()
 
 	k.activeContextName = n
+	slogs.SetActiveContext(n)
 }
 
 func (k *K9s) getActiveContextName() string {
@@ -365,7 +485,7 @@ func (k *K9s) IsCrumbsless() bool {
 
 // IsSplashless returns splashless setting.
 func (k *K9s) IsSplashless() bool {
-	if IsBoolSet(k.UI.manualSplashless) {
+	if IsBoolSet(k.UI.manualSplashless) || k.LowPower.IsEnabled() {
 		return true
 	}
 
@@ -404,7 +524,46 @@ func (k *K9s) GetRefreshRate() float32 {
 
 // RefreshDuration returns the refresh rate as a time.Duration.
 func (k *K9s) RefreshDuration() time.Duration {
-	return time.Duration(k.GetRefreshRate() * float32(time.Second))
+	return k.Stretch(time.Duration(k.GetRefreshRate() * float32(time.Second)))
+}
+
+// LiveViewRefreshDuration returns the describe/yaml views refresh interval or
+// zero to use the viewers default.
+func (k *K9s) LiveViewRefreshDuration() time.Duration {
+	rate := k.LiveViewRefreshRate
+	if rate <= 0 {
+		if !k.IsLowPower() {
+			return 0
+		}
+		rate = defaultLiveViewRefreshRate
+	}
+
+	return k.Stretch(time.Duration(rate) * time.Second)
+}
+
+// SetLowPower switches the low power mode.
+func (k *K9s) SetLowPower(b bool) {
+	k.mx.Lock()
+	defer k.mx.Unlock()
+
+	k.lowPower = b
+}
+
+// IsLowPower checks if low power mode is on.
+func (k *K9s) IsLowPower() bool {
+	k.mx.RLock()
+	defer k.mx.RUnlock()
+
+	return k.lowPower
+}
+
+// Stretch returns an interval stretched in low power mode.
+func (k *K9s) Stretch(d time.Duration) time.Duration {
+	if !k.IsLowPower() {
+		return d
+	}
+
+	return k.LowPower.Stretch(d)
 }
 
 // IsReadOnly returns the readonly setting.
@@ -417,7 +576,7 @@ func (k *K9s) IsReadOnly() bool {
 		ro = *k.manualReadOnly
 	}
 
-	return ro
+	return ro || k.IsGated()
 }
 
 // Validate the current configuration.
@@ -147,3 +147,15 @@ func TestAppScreenDumpDir(t *testing.T) {
 	require.NoError(t, cfg.Load("testdata/configs/k9s.yaml", true))
 	assert.Equal(t, "/tmp/k9s-test/screen-dumps", cfg.K9s.AppScreenDumpDir())
 }
+
+func TestK9sGated(t *testing.T) {
+	k := config.NewK9s(nil, nil)
+	assert.False(t, k.IsReadOnly())
+
+	k.SetGated(true)
+	assert.True(t, k.IsGated())
+	assert.True(t, k.IsReadOnly())
+
+	k.SetGated(false)
+	assert.False(t, k.IsReadOnly())
+}
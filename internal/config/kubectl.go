@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+import (
+	"regexp"
+	"sort"
+)
+
+// DefaultKubectlSkew tracks the minor version skew kubectl supports with an API server.
+const DefaultKubectlSkew = 1
+
+// Kubectl tracks the kubectl binaries used by multi-context runs.
+type Kubectl struct {
+	Binary        string            `json:"binary,omitempty" yaml:"binary,omitempty"`
+	Contexts      map[string]string `json:"contexts,omitempty" yaml:"contexts,omitempty"`
+	Dir           string            `json:"dir,omitempty" yaml:"dir,omitempty"`
+	MaxSkew       int               `json:"maxSkew,omitempty" yaml:"maxSkew,omitempty"`
+	SkipSkewCheck bool              `json:"skipSkewCheck,omitempty" yaml:"skipSkewCheck,omitempty"`
+}
+
+// BinaryFor returns the kubectl binary pinned for a context. Contexts are
+// matched by name first, then by pattern. Falls back to the default binary.
+func (k *Kubectl) BinaryFor(context string) string {
+	if k == nil {
+		return ""
+	}
+	if b, ok := k.Contexts[context]; ok {
+		return b
+	}
+	pp := make([]string, 0, len(k.Contexts))
+	for p := range k.Contexts {
+		pp = append(pp, p)
+	}
+	sort.Strings(pp)
+	for _, p := range pp {
+		if ok, err := regexp.MatchString(p, context); err == nil && ok {
+			return k.Contexts[p]
+		}
+	}
+
+	return k.Binary
+}
+
+// ManagedDir returns the directory holding versioned kubectl binaries.
+func (k *Kubectl) ManagedDir() string {
+	if k == nil {
+		return ""
+	}
+
+	return k.Dir
+}
+
+// SkewLimit returns the max kubectl/API server minor skew or 0 if unchecked.
+func (k *Kubectl) SkewLimit() int {
+	if k != nil && k.SkipSkewCheck {
+		return 0
+	}
+	if k == nil || k.MaxSkew <= 0 {
+		return DefaultKubectlSkew
+	}
+
+	return k.MaxSkew
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKubectlBinaryFor(t *testing.T) {
+	k := config.Kubectl{
+		Binary: "/usr/local/bin/kubectl",
+		Contexts: map[string]string{
+			"legacy":  "/opt/kubectl-1.26",
+			"^edge-":  "/opt/kubectl-1.29",
+			"edge-eu": "/opt/kubectl-1.30",
+		},
+	}
+
+	uu := map[string]struct {
+		k   *config.Kubectl
+		ctx string
+		e   string
+	}{
+		"none": {
+			ctx: "dev",
+		},
+		"default": {
+			k:   &k,
+			ctx: "dev",
+			e:   "/usr/local/bin/kubectl",
+		},
+		"name": {
+			k:   &k,
+			ctx: "edge-eu",
+			e:   "/opt/kubectl-1.30",
+		},
+		"pattern": {
+			k:   &k,
+			ctx: "edge-us",
+			e:   "/opt/kubectl-1.29",
+		},
+	}
+
+	for name, u := range uu {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, u.e, u.k.BinaryFor(u.ctx))
+		})
+	}
+}
+
+func TestKubectlSkewLimit(t *testing.T) {
+	uu := map[string]struct {
+		k *config.Kubectl
+		e int
+	}{
+		"none":    {e: config.DefaultKubectlSkew},
+		"default": {k: &config.Kubectl{}, e: config.DefaultKubectlSkew},
+		"custom":  {k: &config.Kubectl{MaxSkew: 2}, e: 2},
+		"skip":    {k: &config.Kubectl{MaxSkew: 2, SkipSkewCheck: true}, e: 0},
+	}
+
+	for name, u := range uu {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, u.e, u.k.SkewLimit())
+		})
+	}
+}
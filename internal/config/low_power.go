@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+import "time"
+
+// DefaultLowPowerFactor tracks how much refresh intervals stretch in low
+// power mode.
+const DefaultLowPowerFactor = 4
+
+// LowPower tracks the low power mode, ie for long on-call laptop sessions.
+type LowPower struct {
+	// Enable starts rk9s in low power mode.
+	Enable bool `json:"enable,omitempty" yaml:"enable,omitempty"`
+
+	// OnBattery switches to low power mode while running on battery.
+	OnBattery bool `json:"onBattery,omitempty" yaml:"onBattery,omitempty"`
+
+	// Factor stretches the refresh intervals. Defaults to 4.
+	Factor int `json:"factor,omitempty" yaml:"factor,omitempty"`
+}
+
+// IsEnabled checks if rk9s starts in low power mode.
+func (l *LowPower) IsEnabled() bool {
+	return l != nil && l.Enable
+}
+
+// IsOnBattery checks if low power mode follows the battery state.
+func (l *LowPower) IsOnBattery() bool {
+	return l != nil && l.OnBattery
+}
+
+// Stretch returns an interval stretched by the low power factor.
+func (l *LowPower) Stretch(d time.Duration) time.Duration {
+	f := DefaultLowPowerFactor
+	if l != nil && l.Factor > 1 {
+		f = l.Factor
+	}
+
+	return d * time.Duration(f)
+}
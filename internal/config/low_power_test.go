@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLowPowerStretch(t *testing.T) {
+	uu := map[string]struct {
+		l *config.LowPower
+		e time.Duration
+	}{
+		"nil":     {e: 8 * time.Second},
+		"default": {l: &config.LowPower{Enable: true}, e: 8 * time.Second},
+		"one":     {l: &config.LowPower{Factor: 1}, e: 8 * time.Second},
+		"custom":  {l: &config.LowPower{Factor: 10}, e: 20 * time.Second},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.l.Stretch(2*time.Second))
+		})
+	}
+}
+
+func TestK9sLowPower(t *testing.T) {
+	k := config.NewK9s(nil, nil)
+	k.RefreshRate = 2
+	assert.Equal(t, 2*time.Second, k.RefreshDuration())
+
+	k.SetLowPower(true)
+	assert.True(t, k.IsLowPower())
+	assert.Equal(t, 8*time.Second, k.RefreshDuration())
+	assert.Equal(t, 20*time.Second, k.LiveViewRefreshDuration())
+
+	k.SetLowPower(false)
+	assert.Equal(t, 2*time.Second, k.RefreshDuration())
+	assert.Equal(t, time.Duration(0), k.LiveViewRefreshDuration())
+}
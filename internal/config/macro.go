@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"sort"
+
+	"github.com/derailed/k9s/internal/config/data"
+	"github.com/derailed/k9s/internal/config/json"
+	"github.com/derailed/k9s/internal/slogs"
+	"gopkg.in/yaml.v3"
+)
+
+// Macros represents a collection of recorded macros.
+type Macros struct {
+	Macro map[string]Macro `yaml:"macros"`
+}
+
+// Macro describes a replayable sequence of commands and keys.
+type Macro struct {
+	Description string   `yaml:"description,omitempty"`
+	Startup     bool     `yaml:"startup,omitempty"`
+	Steps       []string `yaml:"steps"`
+}
+
+// NewMacros returns a new macros collection.
+func NewMacros() Macros {
+	return Macros{
+		Macro: make(map[string]Macro),
+	}
+}
+
+// Load loads macros from a given file.
+func (m Macros) Load(path string) error {
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	bb, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := data.JSONValidator.Validate(json.MacrosSchema, bb); err != nil {
+		slog.Warn("Validation failed. Please update your config and restart.",
+			slogs.Path, path,
+			slogs.Error, err,
+		)
+	}
+
+	var mm Macros
+	if err := yaml.Unmarshal(bb, &mm); err != nil {
+		return err
+	}
+	for k, v := range mm.Macro {
+		m.Macro[k] = v
+	}
+
+	return nil
+}
+
+// Save saves macros to a given file.
+func (m Macros) Save(path string) error {
+	if err := data.EnsureDirPath(path, data.DefaultDirMod); err != nil {
+		return err
+	}
+
+	return data.SaveYAML(path, m)
+}
+
+// StartupMacros returns the sorted names of the macros to replay at startup.
+func (m Macros) StartupMacros() []string {
+	nn := make([]string, 0, len(m.Macro))
+	for k, v := range m.Macro {
+		if v.Startup {
+			nn = append(nn, k)
+		}
+	}
+	sort.Strings(nn)
+
+	return nn
+}
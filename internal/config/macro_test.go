@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMacrosLoad(t *testing.T) {
+	m := config.NewMacros()
+	require.NoError(t, m.Load("testdata/macros/macros.yaml"))
+	assert.Len(t, m.Macro, 2)
+
+	c, ok := m.Macro["crashes"]
+	assert.True(t, ok)
+	assert.Equal(t, "Crashing pods across selected clusters", c.Description)
+	assert.Equal(t, []string{":pods /crash", "wait 2s", "Enter"}, c.Steps)
+	assert.Equal(t, []string{"nodes"}, m.StartupMacros())
+}
+
+func TestMacrosSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "macros.yaml")
+	m := config.NewMacros()
+	m.Macro["fred"] = config.Macro{Steps: []string{":pods", "L"}}
+	require.NoError(t, m.Save(path))
+
+	m1 := config.NewMacros()
+	require.NoError(t, m1.Load(path))
+	assert.Equal(t, m.Macro, m1.Macro)
+}
+
+func TestMacrosLoadMissing(t *testing.T) {
+	m := config.NewMacros()
+	require.NoError(t, m.Load("testdata/macros/blah.yaml"))
+	assert.Empty(t, m.Macro)
+}
@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+// DefaultMetricsAddress tracks the default metrics endpoint address.
+const DefaultMetricsAddress = "127.0.0.1:9199"
+
+// Metrics tracks the local endpoint exposing rk9s own usage metrics.
+type Metrics struct {
+	Enable  bool   `json:"enable" yaml:"enable"`
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+}
+
+// Addr returns the metrics endpoint address.
+func (m Metrics) Addr() string {
+	if m.Address == "" {
+		return DefaultMetricsAddress
+	}
+
+	return m.Address
+}
@@ -173,6 +173,9 @@ func (mockConnection) ServerVersion() (*version.Info, error) {
 func (mockConnection) CheckConnectivity() bool {
 	return false
 }
+func (mockConnection) ConnectionError() error {
+	return nil
+}
 func (m mockConnection) ActiveContext() string {
 	return m.ct
 }
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+import "time"
+
+const (
+	// DefaultMCMaxParallel tracks how many contexts are queried at once.
+	DefaultMCMaxParallel = 10
+
+	// DefaultMCContextTimeout tracks how long a single context may take to answer.
+	DefaultMCContextTimeout = 30 * time.Second
+
+	// DefaultMCDeadline tracks how long a multi-context operation may take overall.
+	DefaultMCDeadline = 2 * time.Minute
+
+	// DefaultMCMaxRows tracks how many rows a multi-context view may hold.
+	DefaultMCMaxRows = 10_000
+)
+
+// MultiContext tracks the limits of operations spanning several contexts.
+type MultiContext struct {
+	// MaxParallel limits the number of contexts queried at once. Defaults to 10.
+	MaxParallel int `json:"maxParallel,omitempty" yaml:"maxParallel,omitempty"`
+
+	// Timeout bounds each context request, ie `15s`. Defaults to 30s.
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// Deadline bounds a whole operation across contexts, ie `1m`. Defaults to 2m.
+	Deadline string `json:"deadline,omitempty" yaml:"deadline,omitempty"`
+
+	// MaxRows caps the rows aggregated by a multi-context view. Defaults to 10000.
+	MaxRows int `json:"maxRows,omitempty" yaml:"maxRows,omitempty"`
+
+	// KubectlTimeout bounds each `:mc` kubectl run, ie `5m`. Defaults to none
+	// so watches, drains and log follows run until cancelled.
+	KubectlTimeout string `json:"kubectlTimeout,omitempty" yaml:"kubectlTimeout,omitempty"`
+}
+
+// Parallelism returns the max number of contexts queried at once.
+func (m *MultiContext) Parallelism() int {
+	if m == nil || m.MaxParallel <= 0 {
+		return DefaultMCMaxParallel
+	}
+
+	return m.MaxParallel
+}
+
+// RowLimit returns the max number of rows of a multi-context view.
+func (m *MultiContext) RowLimit() int {
+	if m == nil || m.MaxRows <= 0 {
+		return DefaultMCMaxRows
+	}
+
+	return m.MaxRows
+}
+
+// ContextTimeout returns how long a single context may take to answer.
+func (m *MultiContext) ContextTimeout() time.Duration {
+	if m == nil {
+		return DefaultMCContextTimeout
+	}
+
+	return parseDurationOr(m.Timeout, DefaultMCContextTimeout)
+}
+
+// McTimeout returns how long a `:mc` kubectl run may take on a context, 0
+// meaning no timeout.
+func (m *MultiContext) McTimeout() time.Duration {
+	if m == nil {
+		return 0
+	}
+
+	return parseDurationOr(m.KubectlTimeout, 0)
+}
+
+// OpDeadline returns how long a multi-context operation may take overall. It
+// is never shorter than a single context timeout.
+func (m *MultiContext) OpDeadline() time.Duration {
+	d := DefaultMCDeadline
+	if m != nil {
+		d = parseDurationOr(m.Deadline, DefaultMCDeadline)
+	}
+
+	return max(d, m.ContextTimeout())
+}
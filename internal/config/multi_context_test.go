@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiContextLimits(t *testing.T) {
+	uu := map[string]struct {
+		mc       *config.MultiContext
+		parallel int
+		timeout  time.Duration
+		deadline time.Duration
+		rows     int
+		kubectl  time.Duration
+	}{
+		"nil": {
+			parallel: config.DefaultMCMaxParallel,
+			timeout:  config.DefaultMCContextTimeout,
+			deadline: config.DefaultMCDeadline,
+			rows:     config.DefaultMCMaxRows,
+		},
+		"toast": {
+			mc:       &config.MultiContext{MaxParallel: -1, Timeout: "blee", Deadline: "0s", MaxRows: -1},
+			parallel: config.DefaultMCMaxParallel,
+			timeout:  config.DefaultMCContextTimeout,
+			deadline: config.DefaultMCDeadline,
+			rows:     config.DefaultMCMaxRows,
+		},
+		"custom": {
+			mc:       &config.MultiContext{MaxParallel: 4, Timeout: "5s", Deadline: "20s", MaxRows: 500, KubectlTimeout: "5m"},
+			parallel: 4,
+			timeout:  5 * time.Second,
+			deadline: 20 * time.Second,
+			rows:     500,
+			kubectl:  5 * time.Minute,
+		},
+		"short-deadline": {
+			mc:       &config.MultiContext{Timeout: "1m", Deadline: "10s"},
+			parallel: config.DefaultMCMaxParallel,
+			timeout:  time.Minute,
+			deadline: time.Minute,
+			rows:     config.DefaultMCMaxRows,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.parallel, u.mc.Parallelism())
+			assert.Equal(t, u.timeout, u.mc.ContextTimeout())
+			assert.Equal(t, u.deadline, u.mc.OpDeadline())
+			assert.Equal(t, u.rows, u.mc.RowLimit())
+			assert.Equal(t, u.kubectl, u.mc.McTimeout())
+		})
+	}
+}
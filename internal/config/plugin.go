@@ -39,9 +39,11 @@ type Plugin struct {
 	Command         string   `yaml:"command"`
 	Confirm         bool     `yaml:"confirm"`
 	Background      bool     `yaml:"background"`
+	Shell           bool     `yaml:"shell"`
 	Dangerous       bool     `yaml:"dangerous"`
 	OverwriteOutput bool     `yaml:"overwriteOutput"`
 	InView          bool     `yaml:"inView"`
+	Terminal        bool     `yaml:"terminal"`
 }
 
 func (p Plugin) String() string {
@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+// Preview pane positions.
+const (
+	PreviewRight  = "right"
+	PreviewBottom = "bottom"
+)
+
+// Preview tracks the row preview pane settings.
+type Preview struct {
+	// Enable shows the preview pane on startup.
+	Enable bool `json:"enable" yaml:"enable"`
+
+	// Position places the pane either right or bottom. Defaults to right.
+	Position string `json:"position,omitempty" yaml:"position,omitempty"`
+}
+
+// Pos returns the preview pane position.
+func (p *Preview) Pos() string {
+	if p == nil || p.Position != PreviewBottom {
+		return PreviewRight
+	}
+
+	return PreviewBottom
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+import (
+	"fmt"
+	"path"
+)
+
+// DefaultPrimeRegistries tracks the registries serving SUSE Rancher Prime builds.
+var DefaultPrimeRegistries = []string{"registry.rancher.com"}
+
+// Prime tracks the SUSE Rancher Prime compliance settings.
+type Prime struct {
+	// Production lists the production contexts where community builds are
+	// flagged. Contexts may be glob patterns, ie `prod-*`.
+	Production []string `json:"production,omitempty" yaml:"production,omitempty"`
+
+	// Registries lists the registries, or their mirrors, serving Prime builds.
+	Registries []string `json:"registries,omitempty" yaml:"registries,omitempty"`
+}
+
+// IsProduction checks if a context is listed as production. No context is
+// deemed production unless listed.
+func (p *Prime) IsProduction(context string) (bool, error) {
+	if p == nil {
+		return false, nil
+	}
+	for _, g := range p.Production {
+		ok, err := path.Match(g, context)
+		if err != nil {
+			return false, fmt.Errorf("invalid production context %q: %w", g, err)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// HasProduction checks if production contexts are listed.
+func (p *Prime) HasProduction() bool {
+	return p != nil && len(p.Production) > 0
+}
+
+// PrimeRegistries returns the registries serving Prime builds.
+func (p *Prime) PrimeRegistries() []string {
+	if p == nil || len(p.Registries) == 0 {
+		return DefaultPrimeRegistries
+	}
+
+	return p.Registries
+}
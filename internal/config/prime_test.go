@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrimeIsProduction(t *testing.T) {
+	uu := map[string]struct {
+		p   *config.Prime
+		ctx string
+		e   bool
+		err bool
+	}{
+		"none-listed": {
+			ctx: "eu-prod-1",
+		},
+		"listed": {
+			p:   &config.Prime{Production: []string{"staging", "eu-live"}},
+			ctx: "eu-live",
+			e:   true,
+		},
+		"glob": {
+			p:   &config.Prime{Production: []string{"live-*"}},
+			ctx: "live-eu",
+			e:   true,
+		},
+		"not-listed": {
+			p:   &config.Prime{Production: []string{"live-*"}},
+			ctx: "prod-eu",
+		},
+		"no-substring": {
+			p:   &config.Prime{Production: []string{"prod"}},
+			ctx: "non-prod",
+		},
+		"invalid": {
+			p:   &config.Prime{Production: []string{"["}},
+			ctx: "prod",
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ok, err := u.p.IsProduction(u.ctx)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, ok)
+		})
+	}
+}
+
+func TestPrimeRegistries(t *testing.T) {
+	var p *config.Prime
+	assert.Equal(t, config.DefaultPrimeRegistries, p.PrimeRegistries())
+
+	p = &config.Prime{Registries: []string{"mirror.example.com/prime"}}
+	assert.Equal(t, []string{"mirror.example.com/prime"}, p.PrimeRegistries())
+}
@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adrg/xdg"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	defaultRancherTokenEnv = "RANCHER_TOKEN"
+
+	// DefaultDormantAfter represents how long a user may go without logging in before being flagged.
+	DefaultDormantAfter = 90 * 24 * time.Hour
+
+	// DefaultMaxTokenTTL represents the longest token lifetime not flagged by the hygiene report.
+	DefaultMaxTokenTTL = 90 * 24 * time.Hour
+
+	// RancherHopPrefix prefixes temporary contexts reaching downstream clusters via Rancher.
+	RancherHopPrefix = "rancher-hop-"
+)
+
+// Rancher tracks the Rancher API integration settings.
+type Rancher struct {
+	URL      string `json:"url" yaml:"url"`
+	TokenEnv string `json:"tokenEnv,omitempty" yaml:"tokenEnv,omitempty"`
+	Insecure bool   `json:"insecure,omitempty" yaml:"insecure,omitempty"`
+
+	// DormantAfter flags users not logged in for that long, ie `720h`. Defaults to 90 days.
+	DormantAfter string `json:"dormantAfter,omitempty" yaml:"dormantAfter,omitempty"`
+
+	// MaxTokenTTL flags tokens living longer than that, ie `720h`. Defaults to 90 days.
+	MaxTokenTTL string `json:"maxTokenTTL,omitempty" yaml:"maxTokenTTL,omitempty"`
+}
+
+// DormantThreshold returns how long a user may go without logging in.
+func (r *Rancher) DormantThreshold() time.Duration {
+	if r == nil {
+		return DefaultDormantAfter
+	}
+
+	return parseDurationOr(r.DormantAfter, DefaultDormantAfter)
+}
+
+// TokenTTLThreshold returns the longest token lifetime deemed acceptable.
+func (r *Rancher) TokenTTLThreshold() time.Duration {
+	if r == nil {
+		return DefaultMaxTokenTTL
+	}
+
+	return parseDurationOr(r.MaxTokenTTL, DefaultMaxTokenTTL)
+}
+
+// rancherCLIConfig represents the subset of ~/.rancher/cli2.json we care about.
+type rancherCLIConfig struct {
+	CurrentServer string `json:"CurrentServer"`
+	Servers       map[string]struct {
+		URL       string `json:"url"`
+		TokenKey  string `json:"tokenKey"`
+		AccessKey string `json:"accessKey"`
+		SecretKey string `json:"secretKey"`
+	} `json:"Servers"`
+}
+
+// RancherCLIConfigPath returns the rancher CLI config file location.
+func RancherCLIConfigPath() string {
+	if d := os.Getenv("RANCHER_CONFIG_DIR"); d != "" {
+		return filepath.Join(d, "cli2.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, ".rancher", "cli2.json")
+}
+
+// Credentials resolves the Rancher server URL and API token.
+// The token is read from the configured env var and falls back to the
+// rancher CLI login stored in cli2.json. With a configured URL, only a CLI
+// login to that same server is used.
+func (r *Rancher) Credentials() (string, string, error) {
+	var url, env string
+	if r != nil {
+		url, env = r.URL, r.TokenEnv
+	}
+	if env == "" {
+		env = defaultRancherTokenEnv
+	}
+	token := os.Getenv(env)
+	if url != "" && token != "" {
+		return strings.TrimSuffix(url, "/"), token, nil
+	}
+
+	cliURL, cliToken, err := loadRancherCLICreds(RancherCLIConfigPath(), url)
+	if err != nil && url == "" {
+		return "", "", errors.New("no rancher url configured. Set k9s.rancher.url or run `rancher login`")
+	}
+	if url == "" {
+		url = cliURL
+	}
+	if token == "" {
+		token = cliToken
+	}
+	if token == "" {
+		return "", "", fmt.Errorf("no rancher token found. Export %s or run `rancher login`", env)
+	}
+
+	return strings.TrimSuffix(url, "/"), token, nil
+}
+
+// loadRancherCLICreds returns the rancher CLI login to the given server, or to
+// the current CLI server when no url is given.
+func loadRancherCLICreds(path, url string) (string, string, error) {
+	if path == "" {
+		return "", "", errors.New("no rancher cli config path")
+	}
+	bb, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	var cfg rancherCLIConfig
+	if err := json.Unmarshal(bb, &cfg); err != nil {
+		return "", "", fmt.Errorf("rancher cli config %s: %w", path, err)
+	}
+	name := cfg.CurrentServer
+	if url != "" {
+		name = ""
+		for _, k := range slices.Sorted(maps.Keys(cfg.Servers)) {
+			if sameRancherURL(cfg.Servers[k].URL, url) {
+				name = k
+				break
+			}
+		}
+		if name == "" {
+			return "", "", fmt.Errorf("no rancher cli login for %s", url)
+		}
+	}
+	srv, ok := cfg.Servers[name]
+	if !ok {
+		return "", "", fmt.Errorf("rancher cli server %q not found", name)
+	}
+	token := srv.TokenKey
+	if token == "" && srv.AccessKey != "" {
+		token = srv.AccessKey + ":" + srv.SecretKey
+	}
+
+	return srv.URL, token, nil
+}
+
+func sameRancherURL(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "/"), strings.TrimSuffix(b, "/"))
+}
+
+// RancherHopPath returns the kubeconfig file holding temporary Rancher hop contexts.
+func RancherHopPath() string {
+	path, err := xdg.StateFile(filepath.Join(AppName, "rancher-hop.yaml"))
+	if err != nil {
+		return filepath.Join(AppConfigDir, "rancher-hop.yaml")
+	}
+
+	return path
+}
+
+// SaveRancherHop registers a context reaching a downstream cluster through the
+// Rancher cluster proxy endpoint and exposes it to the kubeconfig loader.
+func SaveRancherHop(path, ctxName, url, clusterID, token string, insecure bool) error {
+	cfg, err := clientcmd.LoadFromFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		cfg, err = api.NewConfig(), nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cl := api.NewCluster()
+	cl.Server = strings.TrimSuffix(url, "/") + "/k8s/clusters/" + clusterID
+	cl.InsecureSkipTLSVerify = insecure
+	cfg.Clusters[ctxName] = cl
+
+	auth := api.NewAuthInfo()
+	auth.Token = token
+	cfg.AuthInfos[ctxName] = auth
+
+	ct := api.NewContext()
+	ct.Cluster, ct.AuthInfo = ctxName, ctxName
+	cfg.Contexts[ctxName] = ct
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	if err := clientcmd.WriteToFile(*cfg, path); err != nil {
+		return err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		return err
+	}
+
+	return exportKubeconfig(path)
+}
+
+// ClearRancherHops removes all temporary Rancher hop contexts.
+func ClearRancherHops(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// exportKubeconfig appends path to KUBECONFIG so the current process and
+// any spawned kubectl/plugin commands can resolve the hop contexts.
+func exportKubeconfig(path string) error {
+	env := os.Getenv(clientcmd.RecommendedConfigPathEnvVar)
+	if env == "" {
+		env = clientcmd.RecommendedHomeFile
+	}
+	pp := filepath.SplitList(env)
+	if slices.Contains(pp, path) {
+		return nil
+	}
+
+	return os.Setenv(clientcmd.RecommendedConfigPathEnvVar, strings.Join(append(pp, path), string(filepath.ListSeparator)))
+}
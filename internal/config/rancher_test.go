@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestRancherCredentials(t *testing.T) {
+	dir := t.TempDir()
+	cli := `{"CurrentServer":"rancherDefault","Servers":{"rancherDefault":{"url":"https://rancher.cli/","tokenKey":"token-cli:xyz"},"cfg":{"url":"https://rancher.cfg/","tokenKey":"token-cfg:abc"}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cli2.json"), []byte(cli), 0600))
+
+	uu := map[string]struct {
+		cfg          *config.Rancher
+		env          string
+		url, token   string
+		err          bool
+		noCLIConfigs bool
+	}{
+		"cli-fallback": {
+			url:   "https://rancher.cli",
+			token: "token-cli:xyz",
+		},
+		"env-token": {
+			cfg:   &config.Rancher{URL: "https://rancher.cfg/"},
+			env:   "token-env",
+			url:   "https://rancher.cfg",
+			token: "token-env",
+		},
+		"cfg-url-cli-token": {
+			cfg:   &config.Rancher{URL: "https://rancher.cfg"},
+			url:   "https://rancher.cfg",
+			token: "token-cfg:abc",
+		},
+		"cfg-url-no-cli-login": {
+			cfg: &config.Rancher{URL: "https://rancher.other"},
+			err: true,
+		},
+		"none": {
+			noCLIConfigs: true,
+			err:          true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			t.Setenv("RANCHER_TOKEN", u.env)
+			if u.noCLIConfigs {
+				t.Setenv("RANCHER_CONFIG_DIR", t.TempDir())
+			} else {
+				t.Setenv("RANCHER_CONFIG_DIR", dir)
+			}
+			url, token, err := u.cfg.Credentials()
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.url, url)
+			assert.Equal(t, u.token, token)
+		})
+	}
+}
+
+func TestRancherHygieneThresholds(t *testing.T) {
+	uu := map[string]struct {
+		cfg          *config.Rancher
+		dormant, ttl time.Duration
+	}{
+		"nil": {
+			dormant: config.DefaultDormantAfter,
+			ttl:     config.DefaultMaxTokenTTL,
+		},
+		"custom": {
+			cfg:     &config.Rancher{DormantAfter: "720h", MaxTokenTTL: "24h"},
+			dormant: 720 * time.Hour,
+			ttl:     24 * time.Hour,
+		},
+		"invalid": {
+			cfg:     &config.Rancher{DormantAfter: "blee", MaxTokenTTL: "-1h"},
+			dormant: config.DefaultDormantAfter,
+			ttl:     config.DefaultMaxTokenTTL,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.dormant, u.cfg.DormantThreshold())
+			assert.Equal(t, u.ttl, u.cfg.TokenTTLThreshold())
+		})
+	}
+}
+
+func TestSaveRancherHop(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hop.yaml")
+	t.Setenv("KUBECONFIG", "/tmp/fred.yaml")
+
+	require.NoError(t, config.SaveRancherHop(path, "rancher-hop-blee", "https://rancher.io/", "c-m-1234", "token-1", false))
+	require.NoError(t, config.SaveRancherHop(path, "rancher-hop-duh", "https://rancher.io", "c-m-5678", "token-1", true))
+
+	cfg, err := clientcmd.LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Len(t, cfg.Contexts, 2)
+	assert.Equal(t, "https://rancher.io/k8s/clusters/c-m-1234", cfg.Clusters["rancher-hop-blee"].Server)
+	assert.True(t, cfg.Clusters["rancher-hop-duh"].InsecureSkipTLSVerify)
+	assert.Equal(t, "token-1", cfg.AuthInfos["rancher-hop-duh"].Token)
+	assert.Equal(t, "/tmp/fred.yaml"+string(filepath.ListSeparator)+path, os.Getenv("KUBECONFIG"))
+
+	require.NoError(t, config.ClearRancherHops(path))
+	require.NoError(t, config.ClearRancherHops(path))
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+// Redaction tracks how secrets are masked in dumps, exports and plugin env.
+type Redaction struct {
+	// Disable turns redaction off. Redaction is on by default.
+	Disable bool `json:"disable,omitempty" yaml:"disable,omitempty"`
+
+	// AllowAnnotations lists annotations kept in the clear, ie
+	// `field.cattle.io/*`. Globs are supported.
+	AllowAnnotations []string `json:"allowAnnotations,omitempty" yaml:"allowAnnotations,omitempty"`
+
+	// AllowSecretKeys lists Secret data keys kept in the clear, ie `ca.crt`.
+	// Globs are supported.
+	AllowSecretKeys []string `json:"allowSecretKeys,omitempty" yaml:"allowSecretKeys,omitempty"`
+}
+
+// Enabled checks if secrets should be redacted.
+func (r *Redaction) Enabled() bool {
+	return r == nil || !r.Disable
+}
+
+// Allowlists returns the annotations and Secret keys kept in the clear.
+func (r *Redaction) Allowlists() (annotations, keys []string) {
+	if r == nil {
+		return nil, nil
+	}
+
+	return r.AllowAnnotations, r.AllowSecretKeys
+}
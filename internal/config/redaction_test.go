@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactionEnabled(t *testing.T) {
+	uu := map[string]struct {
+		r *config.Redaction
+		e bool
+	}{
+		"nil":      {e: true},
+		"default":  {r: &config.Redaction{AllowSecretKeys: []string{"ca.crt"}}, e: true},
+		"disabled": {r: &config.Redaction{Disable: true}},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.r.Enabled())
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"time"
+)
+
+const (
+	// MinRuleInterval represents the shortest rule polling interval.
+	MinRuleInterval = 15 * time.Second
+
+	// DefaultRuleInterval represents the default rule polling interval.
+	DefaultRuleInterval = time.Minute
+)
+
+const (
+	// RulePlugin runs a plugin on each newly matching resource.
+	RulePlugin = "plugin"
+
+	// RuleNotify flashes and records a message for each newly matching resource.
+	RuleNotify = "notify"
+
+	// RuleExport writes each newly matching resource manifest to a file.
+	RuleExport = "export"
+
+	// RuleWait waits for each newly matching resource to satisfy a condition
+	// and notifies the outcome.
+	RuleWait = "wait"
+)
+
+// Rule represents an automation rule triggering an action when a watched
+// resource starts matching a condition.
+type Rule struct {
+	Name     string   `json:"name" yaml:"name"`
+	Watch    string   `json:"watch" yaml:"watch"`
+	When     string   `json:"when" yaml:"when"`
+	Contexts []string `json:"contexts,omitempty" yaml:"contexts,omitempty"`
+	Every    string   `json:"every,omitempty" yaml:"every,omitempty"`
+	Plugin   string   `json:"plugin,omitempty" yaml:"plugin,omitempty"`
+	Notify   string   `json:"notify,omitempty" yaml:"notify,omitempty"`
+	Export   string   `json:"export,omitempty" yaml:"export,omitempty"`
+	Wait     string   `json:"wait,omitempty" yaml:"wait,omitempty"`
+	Disabled bool     `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+// Interval returns the rule polling interval.
+func (r Rule) Interval() (time.Duration, error) {
+	if r.Every == "" {
+		return DefaultRuleInterval, nil
+	}
+	d, err := time.ParseDuration(r.Every)
+	if err != nil {
+		return 0, fmt.Errorf("rule %q invalid interval: %w", r.Name, err)
+	}
+	if d < MinRuleInterval {
+		return 0, fmt.Errorf("rule %q interval must be at least %s", r.Name, MinRuleInterval)
+	}
+
+	return d, nil
+}
+
+// Action returns the rule action type or an error if the rule is ambiguous.
+func (r Rule) Action() (string, error) {
+	var kk []string
+	if r.Plugin != "" {
+		kk = append(kk, RulePlugin)
+	}
+	if r.Notify != "" {
+		kk = append(kk, RuleNotify)
+	}
+	if r.Export != "" {
+		kk = append(kk, RuleExport)
+	}
+	if r.Wait != "" {
+		kk = append(kk, RuleWait)
+	}
+	switch len(kk) {
+	case 0:
+		return "", fmt.Errorf("rule %q needs one of plugin, notify, export or wait", r.Name)
+	case 1:
+		return kk[0], nil
+	default:
+		return "", fmt.Errorf("rule %q must set only one of plugin, notify, export or wait", r.Name)
+	}
+}
+
+// AppliesTo checks if the rule watches a given context. Contexts may be
+// glob patterns, ie `prod-*`. All contexts match when none are set.
+func (r Rule) AppliesTo(ctx string) bool {
+	if len(r.Contexts) == 0 {
+		return true
+	}
+	for _, p := range r.Contexts {
+		if ok, _ := path.Match(p, ctx); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks the rule settings.
+func (r Rule) Validate() error {
+	if r.Name == "" {
+		return errors.New("rule name is required")
+	}
+	if r.Watch == "" {
+		return fmt.Errorf("rule %q needs a watch command", r.Name)
+	}
+	if r.When == "" {
+		return fmt.Errorf("rule %q needs a when expression", r.Name)
+	}
+	if _, err := r.Interval(); err != nil {
+		return err
+	}
+	_, err := r.Action()
+
+	return err
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleValidate(t *testing.T) {
+	uu := map[string]struct {
+		r      config.Rule
+		action string
+		every  time.Duration
+		err    string
+	}{
+		"plugin": {
+			r:      config.Rule{Name: "drift", Watch: "bundles", When: "true", Plugin: "report"},
+			action: config.RulePlugin,
+			every:  config.DefaultRuleInterval,
+		},
+		"export": {
+			r:      config.Rule{Name: "drift", Watch: "bundles", When: "true", Every: "30s", Export: "/tmp/$NAME.yaml"},
+			action: config.RuleExport,
+			every:  30 * time.Second,
+		},
+		"wait": {
+			r:      config.Rule{Name: "rollout", Watch: "deploy", When: "true", Wait: "--for=condition=Available --timeout=10m"},
+			action: config.RuleWait,
+			every:  config.DefaultRuleInterval,
+		},
+		"no-name": {
+			r:   config.Rule{Watch: "bundles", When: "true", Notify: "drift"},
+			err: "rule name is required",
+		},
+		"no-watch": {
+			r:   config.Rule{Name: "drift", When: "true", Notify: "drift"},
+			err: `rule "drift" needs a watch command`,
+		},
+		"no-when": {
+			r:   config.Rule{Name: "drift", Watch: "bundles", Notify: "drift"},
+			err: `rule "drift" needs a when expression`,
+		},
+		"too-often": {
+			r:   config.Rule{Name: "drift", Watch: "bundles", When: "true", Every: "1s", Notify: "drift"},
+			err: `rule "drift" interval must be at least 15s`,
+		},
+		"none": {
+			r:   config.Rule{Name: "drift", Watch: "bundles", When: "true"},
+			err: `rule "drift" needs one of plugin, notify, export or wait`,
+		},
+		"ambiguous": {
+			r:   config.Rule{Name: "drift", Watch: "bundles", When: "true", Notify: "drift", Plugin: "report"},
+			err: `rule "drift" must set only one of plugin, notify, export or wait`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := u.r.Validate()
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			action, _ := u.r.Action()
+			assert.Equal(t, u.action, action)
+			every, _ := u.r.Interval()
+			assert.Equal(t, u.every, every)
+		})
+	}
+}
+
+func TestRuleAppliesTo(t *testing.T) {
+	uu := map[string]struct {
+		contexts []string
+		ctx      string
+		e        bool
+	}{
+		"all":      {ctx: "dev", e: true},
+		"exact":    {contexts: []string{"prod"}, ctx: "prod", e: true},
+		"glob":     {contexts: []string{"dev", "prod-*"}, ctx: "prod-eu", e: true},
+		"no-match": {contexts: []string{"prod-*"}, ctx: "dev", e: false},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, config.Rule{Contexts: u.contexts}.AppliesTo(u.ctx))
+		})
+	}
+}
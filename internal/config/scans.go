@@ -69,3 +69,19 @@ func (i ImageScans) ShouldExclude(ns string, ll map[string]string) bool {
 
 	return i.Exclusions.exclude(ns, ll)
 }
+
+// ImageRegistry tracks container registry lookup options.
+type ImageRegistry struct {
+	Lookup   bool `json:"lookup" yaml:"lookup"`
+	Insecure bool `json:"insecure,omitempty" yaml:"insecure,omitempty"`
+}
+
+// LookupEnabled checks if registry metadata lookups are allowed.
+func (i *ImageRegistry) LookupEnabled() bool {
+	return i != nil && i.Lookup
+}
+
+// IsInsecure checks if registries may be reached over plain http or without tls verification.
+func (i *ImageRegistry) IsInsecure() bool {
+	return i != nil && i.Insecure
+}
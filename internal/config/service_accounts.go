@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+import "time"
+
+// DefaultMaxSATokenTTL caps the expiry of minted service account tokens.
+const DefaultMaxSATokenTTL = 24 * time.Hour
+
+// ServiceAccounts tracks the service account helpers settings.
+type ServiceAccounts struct {
+	// MaxTokenTTL caps the expiry of minted tokens, ie `72h`. Defaults to 24h.
+	MaxTokenTTL string `json:"maxTokenTTL,omitempty" yaml:"maxTokenTTL,omitempty"`
+}
+
+// TokenTTLLimit returns the longest expiry a minted token may ask for.
+func (s *ServiceAccounts) TokenTTLLimit() time.Duration {
+	if s == nil {
+		return DefaultMaxSATokenTTL
+	}
+
+	return parseDurationOr(s.MaxTokenTTL, DefaultMaxSATokenTTL)
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenTTLLimit(t *testing.T) {
+	uu := map[string]struct {
+		s *config.ServiceAccounts
+		e time.Duration
+	}{
+		"nil":      {e: config.DefaultMaxSATokenTTL},
+		"blank":    {s: &config.ServiceAccounts{}, e: config.DefaultMaxSATokenTTL},
+		"toast":    {s: &config.ServiceAccounts{MaxTokenTTL: "forever"}, e: config.DefaultMaxSATokenTTL},
+		"negative": {s: &config.ServiceAccounts{MaxTokenTTL: "-1h"}, e: config.DefaultMaxSATokenTTL},
+		"custom":   {s: &config.ServiceAccounts{MaxTokenTTL: "72h"}, e: 72 * time.Hour},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.s.TokenTTLLimit())
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+const windowsOS = "windows"
+
+// Shell tracks the local shell command used to run plugin scripts per
+// platform, ie `[pwsh, -NoProfile, -Command]`. The script is appended last.
+type Shell struct {
+	Linux   []string `json:"linux,omitempty" yaml:"linux,omitempty"`
+	Darwin  []string `json:"darwin,omitempty" yaml:"darwin,omitempty"`
+	Windows []string `json:"windows,omitempty" yaml:"windows,omitempty"`
+}
+
+// Command returns the shell command for a platform, defaulting to bash or
+// PowerShell on Windows.
+func (s Shell) Command(goos string) []string {
+	var cc []string
+	switch goos {
+	case "linux":
+		cc = s.Linux
+	case "darwin":
+		cc = s.Darwin
+	case windowsOS:
+		cc = s.Windows
+	}
+	if len(cc) > 0 {
+		return cc
+	}
+	if goos == windowsOS {
+		return []string{"pwsh", "-NoProfile", "-Command"}
+	}
+
+	return []string{"bash", "-c"}
+}
+
+// ShellScript joins a command and its arguments into a script for a shell,
+// quoting each argument the way the shell expects.
+func ShellScript(shell, command string, args []string) string {
+	ss := make([]string, 0, len(args)+1)
+	ss = append(ss, command)
+	for _, a := range args {
+		ss = append(ss, QuoteArg(shell, a))
+	}
+
+	return strings.Join(ss, " ")
+}
+
+// QuoteArg quotes an argument for a given shell binary.
+func QuoteArg(shell, arg string) string {
+	switch shellName(shell) {
+	case "pwsh", "powershell":
+		return "'" + strings.ReplaceAll(arg, "'", "''") + "'"
+	case "cmd":
+		if arg != "" && !strings.ContainsAny(arg, " \t\"&|<>^%()") {
+			return arg
+		}
+		return `"` + strings.ReplaceAll(arg, `"`, `""`) + `"`
+	default:
+		return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+}
+
+func shellName(bin string) string {
+	n := strings.ToLower(filepath.Base(strings.ReplaceAll(bin, `\`, "/")))
+
+	return strings.TrimSuffix(n, ".exe")
+}
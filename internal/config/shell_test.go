@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShellCommand(t *testing.T) {
+	uu := map[string]struct {
+		s    config.Shell
+		goos string
+		e    []string
+	}{
+		"linux-default": {
+			goos: "linux",
+			e:    []string{"bash", "-c"},
+		},
+		"windows-default": {
+			goos: "windows",
+			e:    []string{"pwsh", "-NoProfile", "-Command"},
+		},
+		"windows-cmd": {
+			s:    config.Shell{Windows: []string{"cmd", "/C"}},
+			goos: "windows",
+			e:    []string{"cmd", "/C"},
+		},
+		"darwin-zsh": {
+			s:    config.Shell{Darwin: []string{"zsh", "-c"}, Linux: []string{"sh", "-c"}},
+			goos: "darwin",
+			e:    []string{"zsh", "-c"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.s.Command(u.goos))
+		})
+	}
+}
+
+func TestShellScript(t *testing.T) {
+	uu := map[string]struct {
+		shell string
+		args  []string
+		e     string
+	}{
+		"bash": {
+			shell: "bash",
+			args:  []string{"my pod", "it's"},
+			e:     `kubectl get 'my pod' 'it'\''s'`,
+		},
+		"pwsh": {
+			shell: `C:\Program Files\PowerShell\7\pwsh.exe`,
+			args:  []string{"my pod", "it's"},
+			e:     `kubectl get 'my pod' 'it''s'`,
+		},
+		"cmd": {
+			shell: "cmd.exe",
+			args:  []string{"fred", "a b", `say "hi"`, ""},
+			e:     `kubectl get fred "a b" "say ""hi""" ""`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, config.ShellScript(u.shell, "kubectl get", u.args))
+		})
+	}
+}
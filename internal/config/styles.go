@@ -167,9 +167,10 @@ type (
 
 	// Yaml tracks yaml styles.
 	Yaml struct {
-		KeyColor   Color `json:"keyColor" yaml:"keyColor"`
-		ValueColor Color `json:"valueColor" yaml:"valueColor"`
-		ColonColor Color `json:"colonColor" yaml:"colonColor"`
+		KeyColor     Color `json:"keyColor" yaml:"keyColor"`
+		ValueColor   Color `json:"valueColor" yaml:"valueColor"`
+		ColonColor   Color `json:"colonColor" yaml:"colonColor"`
+		ChangedColor Color `json:"changedColor" yaml:"changedColor"`
 	}
 
 	// Title tracks title styles.
@@ -387,9 +388,10 @@ func newLogIndicator() LogIndicator {
 
 func newYaml() Yaml {
 	return Yaml{
-		KeyColor:   "steelblue",
-		ColonColor: "white",
-		ValueColor: "papayawhip",
+		KeyColor:     "steelblue",
+		ColonColor:   "white",
+		ValueColor:   "papayawhip",
+		ChangedColor: "darkolivegreen",
 	}
 }
 
@@ -746,6 +748,7 @@ func (y *Yaml) Invert() {
 	y.KeyColor = y.KeyColor.InvertColor()
 	y.ValueColor = y.ValueColor.InvertColor()
 	y.ColonColor = y.ColonColor.InvertColor()
+	y.ChangedColor = y.ChangedColor.InvertColor()
 }
 
 // Invert inverts all colors in Picker.
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/derailed/k9s/internal/config/data"
+)
+
+const s3Scheme = "s3://"
+
+// Sync tracks a remote store sharing the user config across machines.
+type Sync struct {
+	Git         string `json:"git,omitempty" yaml:"git,omitempty"`
+	Branch      string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	S3          string `json:"s3,omitempty" yaml:"s3,omitempty"`
+	PullOnStart bool   `json:"pullOnStart" yaml:"pullOnStart"`
+}
+
+// Validate checks a single valid sync backend is configured.
+func (s *Sync) Validate() error {
+	switch {
+	case s.Git == "" && s.S3 == "":
+		return errors.New("sync requires a git repository or a s3 bucket")
+	case s.Git != "" && s.S3 != "":
+		return errors.New("sync takes either a git repository or a s3 bucket, not both")
+	case s.S3 != "" && !strings.HasPrefix(s.S3, s3Scheme):
+		return fmt.Errorf("sync bucket %q must start with %s", s.S3, s3Scheme)
+	}
+
+	return nil
+}
+
+// SyncDir returns the local working copy of the sync store.
+func SyncDir() string {
+	path, err := xdg.StateFile(filepath.Join(AppName, "sync"))
+	if err != nil {
+		return filepath.Join(AppConfigDir, "sync")
+	}
+
+	return path
+}
+
+// SyncItems returns the synced config paths keyed by their name in the store.
+func SyncItems() map[string]string {
+	return map[string]string{
+		"aliases.yaml":      AppAliasesFile,
+		"hotkeys.yaml":      AppHotKeysFile,
+		"views.yaml":        AppViewsFile,
+		"skins":             AppSkinsDir,
+		"selected_contexts": SelectedContextsPath(),
+	}
+}
+
+// SyncCopy copies the sync items to the store dir on push or from the store dir
+// on pull. Missing items are skipped. It returns the names of the copied items.
+func SyncCopy(items map[string]string, dir string, push bool) ([]string, error) {
+	var (
+		nn   []string
+		errs error
+	)
+	for name, local := range items {
+		src, dst := filepath.Join(dir, name), local
+		if push {
+			src, dst = local, filepath.Join(dir, name)
+		}
+		if _, err := os.Stat(src); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err := copyPath(src, dst); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("sync %s: %w", name, err))
+			continue
+		}
+		nn = append(nn, name)
+	}
+	sort.Strings(nn)
+
+	return nn, errs
+}
+
+func copyPath(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return data.EnsureFullPath(target, data.DefaultDirMod)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		bb, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := data.EnsureDirPath(target, data.DefaultDirMod); err != nil {
+			return err
+		}
+
+		return os.WriteFile(target, bb, data.DefaultFileMod)
+	})
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncValidate(t *testing.T) {
+	uu := map[string]struct {
+		s   config.Sync
+		err string
+	}{
+		"git": {
+			s: config.Sync{Git: "git@github.com:fred/rk9s-config.git"},
+		},
+		"s3": {
+			s: config.Sync{S3: "s3://bucket/rk9s"},
+		},
+		"none": {
+			err: "sync requires a git repository or a s3 bucket",
+		},
+		"both": {
+			s:   config.Sync{Git: "https://example.com/cfg.git", S3: "s3://bucket"},
+			err: "sync takes either a git repository or a s3 bucket, not both",
+		},
+		"bad-bucket": {
+			s:   config.Sync{S3: "bucket/rk9s"},
+			err: `sync bucket "bucket/rk9s" must start with s3://`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := u.s.Validate()
+			if u.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, u.err)
+		})
+	}
+}
+
+func TestSyncCopy(t *testing.T) {
+	local, store := t.TempDir(), t.TempDir()
+	items := map[string]string{
+		"aliases.yaml": filepath.Join(local, "aliases.yaml"),
+		"views.yaml":   filepath.Join(local, "views.yaml"),
+		"skins":        filepath.Join(local, "skins"),
+	}
+	require.NoError(t, os.WriteFile(items["aliases.yaml"], []byte("aliases: {}\n"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(items["skins"], "dark"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(items["skins"], "dark", "skin.yaml"), []byte("k9s: {}\n"), 0o600))
+
+	nn, err := config.SyncCopy(items, store, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aliases.yaml", "skins"}, nn)
+
+	bb, err := os.ReadFile(filepath.Join(store, "skins", "dark", "skin.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "k9s: {}\n", string(bb))
+
+	require.NoError(t, os.WriteFile(filepath.Join(store, "aliases.yaml"), []byte("aliases:\n  pp: v1/pods\n"), 0o600))
+	nn, err = config.SyncCopy(items, store, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aliases.yaml", "skins"}, nn)
+
+	bb, err = os.ReadFile(items["aliases.yaml"])
+	require.NoError(t, err)
+	assert.Equal(t, "aliases:\n  pp: v1/pods\n", string(bb))
+}
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+// DefaultTeamNotesConfigMap tracks the default team notes ConfigMap name.
+const DefaultTeamNotesConfigMap = "rk9s-team-notes"
+
+// TeamNotes tracks the ConfigMap holding the notes and saved filters shared
+// by a team.
+type TeamNotes struct {
+	Namespace string `json:"namespace" yaml:"namespace"`
+	ConfigMap string `json:"configMap,omitempty" yaml:"configMap,omitempty"`
+}
+
+// Name returns the team notes ConfigMap name.
+func (t TeamNotes) Name() string {
+	if t.ConfigMap == "" {
+		return DefaultTeamNotesConfigMap
+	}
+
+	return t.ConfigMap
+}
@@ -7,6 +7,9 @@ const (
 	defaultRefreshRate  = 2
 	defaultMaxConnRetry = 5
 
+	// defaultLiveViewRefreshRate matches the describe/yaml viewers default.
+	defaultLiveViewRefreshRate = 5
+
 	// CPU tracks cpu usage.
 	CPU = "cpu"
 
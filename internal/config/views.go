@@ -14,6 +14,7 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/config/data"
@@ -33,8 +34,107 @@ type ViewConfigListener interface {
 
 // ViewSetting represents a view configuration.
 type ViewSetting struct {
-	Columns    []string `yaml:"columns"`
-	SortColumn string   `yaml:"sortColumn"`
+	Columns     []string     `yaml:"columns"`
+	SortColumn  string       `yaml:"sortColumn"`
+	Charts      []ChartSpec  `yaml:"charts,omitempty"`
+	Colors      []ColorRule  `yaml:"colors,omitempty"`
+	PromColumns []PromColumn `yaml:"promColumns,omitempty"`
+	Summary     *Summary     `yaml:"summary,omitempty"`
+}
+
+// Summary represents a status column computed by CEL expressions evaluated
+// on each resource, available as `self`.
+type Summary struct {
+	// Column names the summary column, replaced when already rendered.
+	// Defaults to STATUS.
+	Column string `yaml:"column,omitempty"`
+
+	// Expr computes the column value, ie `self.status.phase`.
+	Expr string `yaml:"expr"`
+
+	// Color optionally computes the row color, either a color name or one of
+	// ok, pending, error, completed or highlight to use the skin colors.
+	Color string `yaml:"color,omitempty"`
+}
+
+// ColumnName returns the summary column header.
+func (s *Summary) ColumnName() string {
+	if s.Column == "" {
+		return "STATUS"
+	}
+
+	return strings.ToUpper(s.Column)
+}
+
+// DefaultPromColumnTTL tracks how long Prometheus column values are cached.
+const DefaultPromColumnTTL = 30 * time.Second
+
+// PromColumn represents a column valued by a PromQL query evaluated against
+// the context Prometheus.
+type PromColumn struct {
+	// Name is the column header. Reference it in columns to place it,
+	// otherwise the column is appended.
+	Name string `yaml:"name"`
+
+	// Query is a PromQL query templated with the row, ie `{{ .Namespace }}`,
+	// `{{ .Name }}` or `{{ index .Labels "app" }}`.
+	Query string `yaml:"query"`
+
+	// Unit formats values, either bytes, percent, seconds or blank for plain numbers.
+	Unit string `yaml:"unit,omitempty"`
+
+	// TTL specifies how long results are cached, ie `1m`. Defaults to 30s.
+	TTL string `yaml:"ttl,omitempty"`
+}
+
+// CacheTTL returns the column result cache duration.
+func (p PromColumn) CacheTTL() time.Duration {
+	if d, err := time.ParseDuration(p.TTL); err == nil && d > 0 {
+		return d
+	}
+
+	return DefaultPromColumnTTL
+}
+
+// ColorRule represents a user defined row color rule.
+type ColorRule struct {
+	// Match is a column expression, ie `RESTARTS > 5` or `ROBUSTNESS == faulted`.
+	Match string `yaml:"match"`
+
+	// Color specifies the row color.
+	Color string `yaml:"color"`
+
+	// Style optionally decorates the row, ie bold, dim, underline or blink.
+	Style string `yaml:"style,omitempty"`
+}
+
+// ChartSpec represents a chart panel rendered atop a resource details view.
+type ChartSpec struct {
+	// Title names the panel.
+	Title string `yaml:"title"`
+
+	// Type is either gauge or bars. Defaults to bars.
+	Type string `yaml:"type,omitempty"`
+
+	// Unit formats values, either bytes or blank for plain numbers.
+	Unit string `yaml:"unit,omitempty"`
+
+	// Max is a json path or a number. Defaults to the largest value.
+	Max string `yaml:"max,omitempty"`
+
+	// Values lists the json paths of the values to chart.
+	Values []ChartValue `yaml:"values"`
+}
+
+// ChartValue represents a labeled chart value.
+type ChartValue struct {
+	Label string `yaml:"label"`
+	Path  string `yaml:"path"`
+}
+
+// IsGauge returns true if the chart renders a single value against a max.
+func (c ChartSpec) IsGauge() bool {
+	return c.Type == "gauge"
 }
 
 func (v *ViewSetting) HasCols() bool {
@@ -42,7 +142,21 @@ func (v *ViewSetting) HasCols() bool {
 }
 
 func (v *ViewSetting) IsBlank() bool {
-	return v == nil || (len(v.Columns) == 0 && v.SortColumn == "")
+	return v == nil || (len(v.Columns) == 0 && v.SortColumn == "" && len(v.PromColumns) == 0)
+}
+
+// PromColumn returns the Prometheus column with the given name if any.
+func (v *ViewSetting) PromColumn(name string) (PromColumn, bool) {
+	if v == nil {
+		return PromColumn{}, false
+	}
+	for _, c := range v.PromColumns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+
+	return PromColumn{}, false
 }
 
 func (v *ViewSetting) SortCol() (name string, asc bool, err error) {
@@ -69,6 +183,15 @@ func (v *ViewSetting) Equals(vs *ViewSetting) bool {
 	if c := slices.Compare(v.Columns, vs.Columns); c != 0 {
 		return false
 	}
+	if !slices.Equal(v.Colors, vs.Colors) {
+		return false
+	}
+	if !slices.Equal(v.PromColumns, vs.PromColumns) {
+		return false
+	}
+	if (v.Summary == nil) != (vs.Summary == nil) || (v.Summary != nil && *v.Summary != *vs.Summary) {
+		return false
+	}
 
 	return cmp.Compare(v.SortColumn, vs.SortColumn) == 0
 }
@@ -174,6 +297,15 @@ func (v *CustomView) fireConfigChanged() {
 	}
 }
 
+// Charts returns the chart panels declared for a given resource.
+func (v *CustomView) Charts(gvr string) []ChartSpec {
+	if vs := v.getVS(gvr, ""); vs != nil {
+		return vs.Charts
+	}
+
+	return nil
+}
+
 func (v *CustomView) getVS(gvr, ns string) *ViewSetting {
 	if client.IsAllNamespaces(ns) {
 		ns = client.NamespaceAll
@@ -112,6 +112,16 @@ func TestViewSettingEquals(t *testing.T) {
 				Columns: []string{"B"},
 			},
 		},
+
+		"colors": {
+			v1: &config.ViewSetting{
+				Columns: []string{"A"},
+				Colors:  []config.ColorRule{{Match: "A > 1", Color: "red"}},
+			},
+			v2: &config.ViewSetting{
+				Columns: []string{"A"},
+			},
+		},
 	}
 
 	for k, u := range uu {
@@ -120,3 +130,35 @@ func TestViewSettingEquals(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomViewCharts(t *testing.T) {
+	uu := map[string]struct {
+		gvr string
+		e   []config.ChartSpec
+	}{
+		"charts": {
+			gvr: "fleet.cattle.io/v1alpha1/gitrepos",
+			e: []config.ChartSpec{
+				{
+					Title: "Bundles",
+					Max:   ".status.summary.desiredReady",
+					Values: []config.ChartValue{
+						{Label: "ready", Path: ".status.summary.ready"},
+						{Label: "modified", Path: ".status.summary.modified"},
+					},
+				},
+			},
+		},
+		"none": {
+			gvr: client.PodGVR.String(),
+		},
+	}
+
+	cfg := config.NewCustomView()
+	require.NoError(t, cfg.Load("testdata/views/charts.yaml"))
+	for k, u := range uu {
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, cfg.Charts(u.gvr))
+		})
+	}
+}
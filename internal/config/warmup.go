@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package config
+
+// Warmup tracks the startup warm up of the selected contexts.
+type Warmup struct {
+	// Disable skips warming up the selected contexts at startup.
+	Disable bool `json:"disable" yaml:"disable"`
+
+	// Prefetch also lists the namespaces and pods of each context.
+	Prefetch bool `json:"prefetch" yaml:"prefetch"`
+}
+
+// WarmupEnabled checks if the selected contexts should be warmed up at startup.
+func (k *K9s) WarmupEnabled() bool {
+	return k.Warmup == nil || !k.Warmup.Disable
+}
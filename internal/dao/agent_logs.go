@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// AgentLogTail tracks the number of log lines fetched per agent pod.
+const AgentLogTail int64 = 200
+
+// rancherLocalCluster names the Rancher management cluster itself.
+const rancherLocalCluster = "local"
+
+// Agent locates the pods of a Rancher agent on a downstream cluster.
+type Agent struct {
+	Name      string
+	Namespace string
+	Selector  string
+}
+
+var (
+	// CattleClusterAgent tracks the Rancher cluster agent.
+	CattleClusterAgent = Agent{
+		Name:      "cattle-cluster-agent",
+		Namespace: cattleAgentNamespace,
+		Selector:  cattleAgentSelector,
+	}
+
+	// FleetAgent tracks the Fleet agent.
+	FleetAgent = Agent{
+		Name:      "fleet-agent",
+		Namespace: "cattle-fleet-system",
+		Selector:  "app=fleet-agent",
+	}
+)
+
+// DownstreamContext returns the kubeconfig context serving a Rancher
+// downstream cluster, matching the cluster ID of Rancher proxied contexts
+// first, then the context name against the cluster display name or ID.
+// The active context serves the Rancher local cluster.
+func DownstreamContext(rawCfg api.Config, clusterID, display string) (string, bool) {
+	if clusterID == rancherLocalCluster {
+		return rawCfg.CurrentContext, rawCfg.CurrentContext != ""
+	}
+	nn := make([]string, 0, len(rawCfg.Contexts))
+	for n := range rawCfg.Contexts {
+		nn = append(nn, n)
+	}
+	sort.Strings(nn)
+
+	for _, n := range nn {
+		if id := proxiedCluster(rawCfg, n); id != "" && id == clusterID {
+			return n, true
+		}
+	}
+	for _, n := range nn {
+		if slices.Contains([]string{clusterID, display}, n) {
+			return n, true
+		}
+	}
+
+	return "", false
+}
+
+// FleetClusterRef returns the Rancher cluster ID and display name of a Fleet
+// cluster registration.
+func FleetClusterRef(u *unstructured.Unstructured) (string, string) {
+	id := u.GetLabels()[rancherClusterLabel]
+	if id == "" {
+		id = u.GetName()
+	}
+	display := u.GetLabels()[rancherDisplayLabel]
+	if display == "" {
+		display = u.GetName()
+	}
+
+	return id, display
+}
+
+// AgentLogs returns the last log lines of every pod of an agent on the
+// given context.
+func AgentLogs(ctx context.Context, rawCfg api.Config, ctxName string, a Agent, tail int64) (string, error) {
+	kc, err := kubeClientFor(rawCfg, ctxName)
+	if err != nil {
+		return "", err
+	}
+	pp, err := kc.CoreV1().Pods(a.Namespace).List(ctx, metav1.ListOptions{LabelSelector: a.Selector})
+	if err != nil {
+		return "", err
+	}
+	if len(pp.Items) == 0 {
+		return "", fmt.Errorf("no %s pods found in %s on context %q", a.Name, a.Namespace, ctxName)
+	}
+	sort.Slice(pp.Items, func(i, j int) bool {
+		return pp.Items[i].Name < pp.Items[j].Name
+	})
+
+	var b strings.Builder
+	for i := range pp.Items {
+		po := &pp.Items[i]
+		bb, err := kc.CoreV1().Pods(po.Namespace).GetLogs(po.Name, &v1.PodLogOptions{
+			Container: agentContainer(po, a.Name),
+			TailLines: &tail,
+		}).DoRaw(ctx)
+		if err != nil {
+			fmt.Fprintf(&b, "--- %s (%s) ---\n  (error) %s\n", po.Name, po.Status.Phase, err)
+			continue
+		}
+		fmt.Fprintf(&b, "--- %s (%s) ---\n%s\n", po.Name, po.Status.Phase, bb)
+	}
+
+	return b.String(), nil
+}
+
+// agentContainer picks the agent container, the first one otherwise.
+func agentContainer(po *v1.Pod, name string) string {
+	if hasContainer(po, name) || len(po.Spec.Containers) == 0 {
+		return name
+	}
+
+	return po.Spec.Containers[0].Name
+}
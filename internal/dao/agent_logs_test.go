@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestDownstreamContext(t *testing.T) {
+	cfg := api.Config{
+		CurrentContext: "mgmt",
+		Clusters: map[string]*api.Cluster{
+			"mgmt":  {Server: "https://rancher.example.com"},
+			"proxy": {Server: "https://rancher.example.com/k8s/clusters/c-m-abc12"},
+			"edge":  {Server: "https://10.0.0.1:6443"},
+		},
+		Contexts: map[string]*api.Context{
+			"mgmt":       {Cluster: "mgmt"},
+			"prod-proxy": {Cluster: "proxy"},
+			"edge":       {Cluster: "edge"},
+		},
+	}
+
+	uu := map[string]struct {
+		id, display string
+		ctx         string
+		ok          bool
+	}{
+		"local": {
+			id:  "local",
+			ctx: "mgmt",
+			ok:  true,
+		},
+		"proxied": {
+			id:      "c-m-abc12",
+			display: "prod",
+			ctx:     "prod-proxy",
+			ok:      true,
+		},
+		"display": {
+			id:      "c-m-zzz",
+			display: "edge",
+			ctx:     "edge",
+			ok:      true,
+		},
+		"unknown": {
+			id:      "c-m-nope",
+			display: "nope",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ctx, ok := dao.DownstreamContext(cfg, u.id, u.display)
+			assert.Equal(t, u.ok, ok)
+			assert.Equal(t, u.ctx, ctx)
+		})
+	}
+}
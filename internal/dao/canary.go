@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/render"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd/api"
+	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// canaryGVRs lists the workloads compared across contexts.
+var canaryGVRs = []*client.GVR{client.DpGVR, client.StsGVR, client.DsGVR}
+
+var _ Accessor = (*Canary)(nil)
+
+// CanaryPins represents the contexts pinned as baseline and canary.
+type CanaryPins struct {
+	Baseline, Canary string
+}
+
+// Validate checks the pins designate two distinct contexts.
+func (p CanaryPins) Validate() error {
+	if p.Baseline == "" || p.Canary == "" {
+		return errors.New("no contexts pinned. Use `compare <baseline-context> <canary-context>`")
+	}
+	if p.Baseline == p.Canary {
+		return fmt.Errorf("baseline and canary must differ, got %q twice", p.Baseline)
+	}
+
+	return nil
+}
+
+// Canary compares the workloads of a namespace across two contexts.
+type Canary struct {
+	NonResource
+}
+
+// List returns the workloads of the pinned contexts side by side.
+func (c *Canary) List(ctx context.Context, ns string) ([]runtime.Object, error) {
+	pins, _ := ctx.Value(internal.KeyCanary).(CanaryPins)
+	if err := pins.Validate(); err != nil {
+		return nil, err
+	}
+	rawCfg, err := c.getFactory().Client().Config().RawConfig()
+	if err != nil {
+		return nil, err
+	}
+	base, err := CanaryWorkloads(ctx, rawCfg, pins.Baseline, ns)
+	if err != nil {
+		return nil, fmt.Errorf("baseline %q: %w", pins.Baseline, err)
+	}
+	can, err := CanaryWorkloads(ctx, rawCfg, pins.Canary, ns)
+	if err != nil {
+		return nil, fmt.Errorf("canary %q: %w", pins.Canary, err)
+	}
+
+	return CanaryCompare(base, can), nil
+}
+
+// CanaryWorkload represents a workload state on a given context.
+type CanaryWorkload struct {
+	Namespace, Kind, Name string
+	render.CanarySide
+}
+
+func (w CanaryWorkload) key() string {
+	return w.Kind + "|" + client.FQN(w.Namespace, w.Name)
+}
+
+// CanaryWorkloads collects the version, replica health, restarts and usage of
+// the workloads of a namespace on a given context. Metrics are best effort.
+func CanaryWorkloads(ctx context.Context, rawCfg api.Config, ctxName, ns string) ([]CanaryWorkload, error) {
+	dc, err := dynClientFor(rawCfg, ctxName)
+	if err != nil {
+		return nil, err
+	}
+	pl, err := dc.Resource(client.PodGVR.GVR()).Namespace(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods := make([]*v1.Pod, 0, len(pl.Items))
+	for i := range pl.Items {
+		var po v1.Pod
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(pl.Items[i].Object, &po); err == nil {
+			pods = append(pods, &po)
+		}
+	}
+	mx := make(map[string]*mv1beta1.PodMetrics)
+	if ml, err := dc.Resource(client.PmxGVR.GVR()).Namespace(ns).List(ctx, metav1.ListOptions{}); err == nil {
+		for i := range ml.Items {
+			var m mv1beta1.PodMetrics
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(ml.Items[i].Object, &m); err == nil {
+				mx[client.FQN(m.Namespace, m.Name)] = &m
+			}
+		}
+	}
+
+	var ww []CanaryWorkload
+	for _, gvr := range canaryGVRs {
+		ll, err := dc.Resource(gvr.GVR()).Namespace(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range ll.Items {
+			ww = append(ww, NewCanaryWorkload(&ll.Items[i], pods, mx))
+		}
+	}
+
+	return ww, nil
+}
+
+// NewCanaryWorkload summarizes a deployment, statefulset or daemonset along
+// with the pods it selects.
+func NewCanaryWorkload(u *unstructured.Unstructured, pods []*v1.Pod, mx map[string]*mv1beta1.PodMetrics) CanaryWorkload {
+	w := CanaryWorkload{
+		Namespace: u.GetNamespace(),
+		Kind:      u.GetKind(),
+		Name:      u.GetName(),
+	}
+	w.Found = true
+
+	var ii []string
+	cc, _, _ := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "containers")
+	for _, c := range cc {
+		if m, ok := c.(map[string]any); ok {
+			if img, ok := m["image"].(string); ok {
+				ii = append(ii, imageVersion(img))
+			}
+		}
+	}
+	w.Version = strings.Join(ii, ",")
+
+	if w.Kind == "DaemonSet" {
+		w.Desired, _, _ = unstructured.NestedInt64(u.Object, "status", "desiredNumberScheduled")
+		w.Ready, _, _ = unstructured.NestedInt64(u.Object, "status", "numberReady")
+	} else {
+		w.Desired = 1
+		if r, ok, _ := unstructured.NestedInt64(u.Object, "spec", "replicas"); ok {
+			w.Desired = r
+		}
+		w.Ready, _, _ = unstructured.NestedInt64(u.Object, "status", "readyReplicas")
+	}
+
+	sm, _, _ := unstructured.NestedMap(u.Object, "spec", "selector")
+	var ls metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(sm, &ls); err != nil {
+		return w
+	}
+	sel, err := metav1.LabelSelectorAsSelector(&ls)
+	if err != nil || sel.Empty() {
+		return w
+	}
+	for _, po := range pods {
+		if po.Namespace != w.Namespace || !sel.Matches(labels.Set(po.Labels)) {
+			continue
+		}
+		for _, cs := range po.Status.ContainerStatuses {
+			w.Restarts += int64(cs.RestartCount)
+		}
+		m, ok := mx[client.FQN(po.Namespace, po.Name)]
+		if !ok {
+			continue
+		}
+		for _, c := range m.Containers {
+			w.CPU += c.Usage.Cpu().MilliValue()
+			w.MEM += c.Usage.Memory().Value()
+		}
+	}
+
+	return w
+}
+
+// CanaryCompare pairs up the baseline and canary workloads.
+func CanaryCompare(base, can []CanaryWorkload) []runtime.Object {
+	rr := make(map[string]*render.CanaryRes)
+	get := func(w CanaryWorkload) *render.CanaryRes {
+		r, ok := rr[w.key()]
+		if !ok {
+			r = &render.CanaryRes{Namespace: w.Namespace, Kind: w.Kind, Name: w.Name}
+			rr[w.key()] = r
+		}
+		return r
+	}
+	for _, w := range base {
+		get(w).Baseline = w.CanarySide
+	}
+	for _, w := range can {
+		get(w).Canary = w.CanarySide
+	}
+
+	kk := make([]string, 0, len(rr))
+	for k := range rr {
+		kk = append(kk, k)
+	}
+	sort.Strings(kk)
+	oo := make([]runtime.Object, 0, len(kk))
+	for _, k := range kk {
+		oo = append(oo, rr[k])
+	}
+
+	return oo
+}
+
+// imageVersion returns an image tag, or its digest when untagged.
+func imageVersion(img string) string {
+	if i := strings.Index(img, "@"); i >= 0 {
+		if j := strings.LastIndex(img[:i], ":"); j > strings.LastIndex(img[:i], "/") {
+			return img[j+1 : i]
+		}
+		return img[i+1:]
+	}
+	if i := strings.LastIndex(img, ":"); i > strings.LastIndex(img, "/") {
+		return img[i+1:]
+	}
+
+	return "latest"
+}
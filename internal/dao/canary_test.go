@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+func TestCanaryPinsValidate(t *testing.T) {
+	require.Error(t, dao.CanaryPins{}.Validate())
+	require.Error(t, dao.CanaryPins{Baseline: "c1", Canary: "c1"}.Validate())
+	require.NoError(t, dao.CanaryPins{Baseline: "c1", Canary: "c2"}.Validate())
+}
+
+func TestNewCanaryWorkload(t *testing.T) {
+	pods := []*v1.Pod{
+		makeCanaryPod("p1", "fred", 2),
+		makeCanaryPod("p2", "fred", 1),
+		makeCanaryPod("p3", "blee", 5),
+	}
+	mx := map[string]*mv1beta1.PodMetrics{
+		"ns1/p1": {
+			Containers: []mv1beta1.ContainerMetrics{
+				{Usage: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("100m"),
+					v1.ResourceMemory: resource.MustParse("10Mi"),
+				}},
+			},
+		},
+	}
+
+	w := dao.NewCanaryWorkload(makeCanaryDp("nginx:1.2@sha256:abc", 3, 2), pods, mx)
+	assert.Equal(t, "Deployment", w.Kind)
+	assert.Equal(t, "1.2", w.Version)
+	assert.True(t, w.Found)
+	assert.Equal(t, int64(3), w.Desired)
+	assert.Equal(t, int64(2), w.Ready)
+	assert.Equal(t, int64(3), w.Restarts)
+	assert.Equal(t, int64(100), w.CPU)
+	assert.Equal(t, int64(10*1024*1024), w.MEM)
+}
+
+func TestCanaryCompare(t *testing.T) {
+	side := func(v string, ready, restarts int64) render.CanarySide {
+		return render.CanarySide{Found: true, Version: v, Desired: 2, Ready: ready, Restarts: restarts}
+	}
+	base := []dao.CanaryWorkload{
+		{Namespace: "ns1", Kind: "Deployment", Name: "a", CanarySide: side("1.0", 2, 0)},
+		{Namespace: "ns1", Kind: "Deployment", Name: "b", CanarySide: side("1.0", 2, 0)},
+		{Namespace: "ns1", Kind: "Deployment", Name: "c", CanarySide: side("1.0", 2, 0)},
+		{Namespace: "ns1", Kind: "Deployment", Name: "d", CanarySide: side("1.0", 2, 0)},
+	}
+	can := []dao.CanaryWorkload{
+		{Namespace: "ns1", Kind: "Deployment", Name: "a", CanarySide: side("1.0", 2, 0)},
+		{Namespace: "ns1", Kind: "Deployment", Name: "b", CanarySide: side("1.1", 2, 0)},
+		{Namespace: "ns1", Kind: "Deployment", Name: "c", CanarySide: side("1.1", 1, 4)},
+		{Namespace: "ns1", Kind: "Deployment", Name: "e", CanarySide: side("1.1", 2, 0)},
+	}
+
+	oo := dao.CanaryCompare(base, can)
+	vv := make(map[string]string, len(oo))
+	for _, o := range oo {
+		r, ok := o.(*render.CanaryRes)
+		require.True(t, ok)
+		vv[r.Name] = r.Verdict()
+	}
+	assert.Equal(t, map[string]string{
+		"a": render.CanaryMatch,
+		"b": render.CanaryDiverged,
+		"c": render.CanaryDegraded,
+		"d": render.CanaryMissingCanary,
+		"e": render.CanaryMissingBaseline,
+	}, vv)
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func makeCanaryDp(img string, replicas, ready int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":      "d1",
+			"namespace": "ns1",
+		},
+		"spec": map[string]any{
+			"replicas": replicas,
+			"selector": map[string]any{
+				"matchLabels": map[string]any{"app": "fred"},
+			},
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{"name": "c1", "image": img},
+					},
+				},
+			},
+		},
+		"status": map[string]any{"readyReplicas": ready},
+	}}
+}
+
+func makeCanaryPod(n, app string, restarts int32) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      n,
+			Namespace: "ns1",
+			Labels:    map[string]string{"app": app},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{RestartCount: restarts}},
+		},
+	}
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const certRotateUnit = "rk9s-cert-rotate"
+
+var tokenRX = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+
+// CertRotateScript returns a shell script regenerating the certificates of a
+// server node. The service must be stopped while certificates are rotated, the
+// sequence runs as a transient systemd unit since it takes down the kubelet
+// attached to the calling pod.
+func CertRotateScript() string {
+	return DistroScript(fmt.Sprintf("systemd-run --collect --unit=%s sh -c \"systemctl stop $svc && $bin certificate rotate && systemctl start $svc\" "+
+		"&& echo \"rotating $svc certificates, follow with journalctl -u %[1]s\"", certRotateUnit))
+}
+
+// TokenRotateScript returns a shell script rotating the cluster join token
+// from a server node.
+func TokenRotateScript(token string) (string, error) {
+	if !tokenRX.MatchString(token) {
+		return "", fmt.Errorf("join token must be alphanumeric")
+	}
+
+	return DistroScript(fmt.Sprintf("$bin token rotate --token \"$(cat $data/server/token)\" --new-token %s && echo \"join token rotated\"", token)), nil
+}
+
+// TokenConfigScript returns a shell script updating the join token of a node
+// config, keeping a backup, and restarting the node service.
+func TokenConfigScript(token string) (string, error) {
+	if !tokenRX.MatchString(token) {
+		return "", fmt.Errorf("join token must be alphanumeric")
+	}
+
+	return DistroScript(fmt.Sprintf("if grep -q '^token:' $cfg 2>/dev/null; then sed -i.rk9s-bak 's/^token:.*/token: %s/' $cfg && echo \"updated token in $cfg\"; "+
+		"else echo \"no token set in $cfg, update token-file or env overrides manually\"; fi; %s", token, restartService)), nil
+}
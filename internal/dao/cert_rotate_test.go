@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenScripts(t *testing.T) {
+	uu := map[string]struct {
+		token string
+		e     []string
+		err   bool
+	}{
+		"happy": {
+			token: "b7xk2mq9",
+			e: []string{
+				"--new-token b7xk2mq9",
+				"s/^token:.*/token: b7xk2mq9/",
+			},
+		},
+		"injection": {
+			token: "x; rm -rf /",
+			err:   true,
+		},
+		"empty": {
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			rotate, err := dao.TokenRotateScript(u.token)
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			cfg, err := dao.TokenConfigScript(u.token)
+			require.NoError(t, err)
+			assert.Contains(t, rotate, u.e[0])
+			assert.Contains(t, cfg, u.e[1])
+		})
+	}
+}
+
+func TestCertRotateScript(t *testing.T) {
+	s := dao.CertRotateScript()
+
+	assert.Contains(t, s, "systemd-run --collect --unit=rk9s-cert-rotate")
+	assert.Contains(t, s, "$bin certificate rotate")
+}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/derailed/k9s/internal/client"
+	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// ChaosLabel tracks the label set on resources created by chaos actions.
+	ChaosLabel = "rk9s.io/chaos"
+
+	chaosDenyPrefix  = "rk9s-chaos-deny-"
+	controlPlaneRole = "node-role.kubernetes.io/control-plane"
+)
+
+// ChaosClient returns a client bound to a context so chaos actions are always
+// reverted on the context they ran on, even after a context switch.
+func ChaosClient(rawConfig api.Config, ctxName string) (kubernetes.Interface, error) {
+	return kubeClientFor(rawConfig, ctxName)
+}
+
+// DeleteRandomPod deletes a random pod of a deployment and returns its path.
+func DeleteRandomPod(ctx context.Context, dial kubernetes.Interface, fqn string) (string, error) {
+	ns, n := client.Namespaced(fqn)
+	dp, err := dial.AppsV1().Deployments(ns).Get(ctx, n, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	sel, err := metav1.LabelSelectorAsSelector(dp.Spec.Selector)
+	if err != nil {
+		return "", err
+	}
+	pp, err := dial.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{LabelSelector: sel.String()})
+	if err != nil {
+		return "", err
+	}
+	var nn []string
+	for i := range pp.Items {
+		if pp.Items[i].DeletionTimestamp == nil {
+			nn = append(nn, pp.Items[i].Name)
+		}
+	}
+	if len(nn) == 0 {
+		return "", fmt.Errorf("no running pods found for deployment %q", fqn)
+	}
+	pod := nn[rand.IntN(len(nn))]
+	if err := dial.CoreV1().Pods(ns).Delete(ctx, pod, metav1.DeleteOptions{}); err != nil {
+		return "", err
+	}
+
+	return client.FQN(ns, pod), nil
+}
+
+// ChaosNodes returns the nodes chaos actions may cordon, skipping control
+// planes and nodes that are already cordoned.
+func ChaosNodes(nn []v1.Node) []string {
+	ss := make([]string, 0, len(nn))
+	for i := range nn {
+		if nn[i].Spec.Unschedulable {
+			continue
+		}
+		if _, ok := nn[i].Labels[controlPlaneRole]; ok {
+			continue
+		}
+		ss = append(ss, nn[i].Name)
+	}
+	sort.Strings(ss)
+
+	return ss
+}
+
+// CordonRandomNode cordons a random worker node and returns its name.
+func CordonRandomNode(ctx context.Context, dial kubernetes.Interface) (string, error) {
+	nn, err := dial.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	ss := ChaosNodes(nn.Items)
+	if len(ss) == 0 {
+		return "", errors.New("no schedulable worker nodes found")
+	}
+	node := ss[rand.IntN(len(ss))]
+
+	return node, SetNodeUnschedulable(ctx, dial, node, true)
+}
+
+// SetNodeUnschedulable cordons or uncordons a node.
+func SetNodeUnschedulable(ctx context.Context, dial kubernetes.Interface, node string, cordon bool) error {
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, cordon)
+	_, err := dial.CoreV1().Nodes().Patch(ctx, node, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+
+	return err
+}
+
+// ChaosDenyPolicy returns a network policy denying all traffic to and from
+// the pods matching a selector.
+func ChaosDenyPolicy(ns string, sel *metav1.LabelSelector) *netv1.NetworkPolicy {
+	np := netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: chaosDenyPrefix,
+			Namespace:    ns,
+			Labels:       map[string]string{ChaosLabel: "true"},
+		},
+		Spec: netv1.NetworkPolicySpec{
+			PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress, netv1.PolicyTypeEgress},
+		},
+	}
+	if sel != nil {
+		np.Spec.PodSelector = *sel.DeepCopy()
+	}
+
+	return &np
+}
+
+// DenyNetwork injects a deny all network policy on the pods of a deployment
+// and returns the policy path.
+func DenyNetwork(ctx context.Context, dial kubernetes.Interface, fqn string) (string, error) {
+	ns, n := client.Namespaced(fqn)
+	dp, err := dial.AppsV1().Deployments(ns).Get(ctx, n, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if dp.Spec.Selector == nil || len(dp.Spec.Selector.MatchLabels)+len(dp.Spec.Selector.MatchExpressions) == 0 {
+		return "", fmt.Errorf("deployment %q has no pod selector", fqn)
+	}
+	np, err := dial.NetworkingV1().NetworkPolicies(ns).Create(ctx, ChaosDenyPolicy(ns, dp.Spec.Selector), metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return client.FQN(ns, np.Name), nil
+}
+
+// RemoveNetworkDeny deletes a network policy injected by DenyNetwork.
+func RemoveNetworkDeny(ctx context.Context, dial kubernetes.Interface, fqn string) error {
+	ns, n := client.Namespaced(fqn)
+
+	return dial.NetworkingV1().NetworkPolicies(ns).Delete(ctx, n, metav1.DeleteOptions{})
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestChaosNodes(t *testing.T) {
+	node := func(n string, cordoned bool, ll map[string]string) v1.Node {
+		return v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: n, Labels: ll},
+			Spec:       v1.NodeSpec{Unschedulable: cordoned},
+		}
+	}
+
+	uu := map[string]struct {
+		nn []v1.Node
+		e  []string
+	}{
+		"empty": {
+			e: []string{},
+		},
+		"workers": {
+			nn: []v1.Node{node("w2", false, nil), node("w1", false, nil)},
+			e:  []string{"w1", "w2"},
+		},
+		"skip": {
+			nn: []v1.Node{
+				node("cp1", false, map[string]string{"node-role.kubernetes.io/control-plane": "true"}),
+				node("w1", true, nil),
+				node("w2", false, nil),
+			},
+			e: []string{"w2"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.ChaosNodes(u.nn))
+		})
+	}
+}
+
+func TestChaosDenyPolicy(t *testing.T) {
+	sel := metav1.LabelSelector{MatchLabels: map[string]string{"app": "fred"}}
+	np := dao.ChaosDenyPolicy("ns1", &sel)
+
+	assert.Equal(t, "ns1", np.Namespace)
+	assert.Equal(t, "rk9s-chaos-deny-", np.GenerateName)
+	assert.Equal(t, "true", np.Labels[dao.ChaosLabel])
+	assert.Equal(t, sel, np.Spec.PodSelector)
+	assert.Equal(t, []netv1.PolicyType{netv1.PolicyTypeIngress, netv1.PolicyTypeEgress}, np.Spec.PolicyTypes)
+	assert.Empty(t, np.Spec.Ingress)
+	assert.Empty(t, np.Spec.Egress)
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// MatchCounts counts the objects of each context whose manifest matches a
+// case-insensitive filter. An empty filter matches all objects. Filters that
+// are not valid regexes are matched literally.
+func MatchCounts(oo []ContextObject, ctxs []string, filter string) map[string]int {
+	cc := make(map[string]int, len(ctxs))
+	for _, c := range ctxs {
+		cc[c] = 0
+	}
+	rx, err := regexp.Compile("(?i)" + filter)
+	if err != nil {
+		rx = regexp.MustCompile("(?i)" + regexp.QuoteMeta(filter))
+	}
+	for _, o := range oo {
+		if filter != "" {
+			raw, err := json.Marshal(o.Object)
+			if err != nil || !rx.Match(raw) {
+				continue
+			}
+		}
+		cc[o.Context]++
+	}
+
+	return cc
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMatchCounts(t *testing.T) {
+	pod := func(name, reason string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"kind":     "Pod",
+			"metadata": map[string]any{"name": name},
+			"status":   map[string]any{"reason": reason},
+		}}
+	}
+	oo := []dao.ContextObject{
+		{Context: "c1", Object: pod("p1", "CrashLoopBackOff")},
+		{Context: "c1", Object: pod("p2", "Running")},
+		{Context: "c2", Object: pod("p3", "crashloopbackoff")},
+	}
+
+	uu := map[string]struct {
+		filter string
+		e      map[string]int
+	}{
+		"all": {
+			e: map[string]int{"c1": 2, "c2": 1, "c3": 0},
+		},
+		"crash": {
+			filter: "crash",
+			e:      map[string]int{"c1": 1, "c2": 1, "c3": 0},
+		},
+		"regex": {
+			filter: "p[12]",
+			e:      map[string]int{"c1": 2, "c2": 0, "c3": 0},
+		},
+		"literal": {
+			filter: "crash(",
+			e:      map[string]int{"c1": 0, "c2": 0, "c3": 0},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.MatchCounts(oo, []string{"c1", "c2", "c3"}, u.filter))
+		})
+	}
+}
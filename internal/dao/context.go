@@ -44,7 +44,11 @@ func (c *Context) List(context.Context, string) ([]runtime.Object, error) {
 	}
 	cc := make([]runtime.Object, 0, len(ctxs))
 	for k, v := range ctxs {
-		cc = append(cc, render.NewNamedContext(c.config(), k, v))
+		nc := render.NewNamedContext(c.config(), k, v)
+		if w, ok := WarmupFor(k); ok {
+			nc.Warmup = w.Status()
+		}
+		cc = append(cc, nc)
 	}
 
 	return cc, nil
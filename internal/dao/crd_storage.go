@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/derailed/k9s/internal/client"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const crdMigrationPageSize = 500
+
+// CRDStorage represents the storage versions of a CRD.
+type CRDStorage struct {
+	Name, Group, Resource string
+	// Storage tracks the version new objects are stored at.
+	Storage string
+	// Stored tracks the versions objects may still be stored at.
+	Stored []string
+	// Deprecated tracks the served versions flagged as deprecated.
+	Deprecated []string
+}
+
+// NewCRDStorage returns the storage versions of a CRD.
+func NewCRDStorage(crd *apiext.CustomResourceDefinition) CRDStorage {
+	s := CRDStorage{
+		Name:     crd.Name,
+		Group:    crd.Spec.Group,
+		Resource: crd.Spec.Names.Plural,
+		Stored:   crd.Status.StoredVersions,
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			s.Storage = v.Name
+		}
+		if v.Deprecated {
+			s.Deprecated = append(s.Deprecated, v.Name)
+		}
+	}
+
+	return s
+}
+
+// Stale returns the stored versions other than the storage version.
+func (s CRDStorage) Stale() []string {
+	var vv []string
+	for _, v := range s.Stored {
+		if v != s.Storage {
+			vv = append(vv, v)
+		}
+	}
+
+	return vv
+}
+
+// Issues returns the storage version problems of a CRD.
+func (s CRDStorage) Issues() []string {
+	var ii []string
+	if vv := s.Stale(); len(vv) > 0 {
+		ii = append(ii, fmt.Sprintf("%s objects may still be stored at %v, migrate them to %s", s.Name, vv, s.Storage))
+	}
+	if slices.Contains(s.Deprecated, s.Storage) {
+		ii = append(ii, fmt.Sprintf("%s storage version %s is deprecated", s.Name, s.Storage))
+	}
+
+	return ii
+}
+
+// FetchCRDStorages returns the storage versions of all CRDs.
+func FetchCRDStorages(ctx context.Context, c client.Connection) ([]CRDStorage, error) {
+	dial, err := c.DynDial()
+	if err != nil {
+		return nil, err
+	}
+	ll, err := dial.Resource(client.CrdGVR.GVR()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ss := make([]CRDStorage, 0, len(ll.Items))
+	for i := range ll.Items {
+		var crd apiext.CustomResourceDefinition
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(ll.Items[i].Object, &crd); err != nil {
+			return nil, err
+		}
+		ss = append(ss, NewCRDStorage(&crd))
+	}
+	sort.Slice(ss, func(i, j int) bool {
+		return ss[i].Name < ss[j].Name
+	})
+
+	return ss, nil
+}
+
+// FetchCRDStorage returns the storage versions of a CRD.
+func FetchCRDStorage(ctx context.Context, c client.Connection, name string) (CRDStorage, error) {
+	dial, err := c.DynDial()
+	if err != nil {
+		return CRDStorage{}, err
+	}
+	u, err := dial.Resource(client.CrdGVR.GVR()).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return CRDStorage{}, err
+	}
+	var crd apiext.CustomResourceDefinition
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &crd); err != nil {
+		return CRDStorage{}, err
+	}
+
+	return NewCRDStorage(&crd), nil
+}
+
+// RewriteCRDObjects rewrites every object of a CRD with a no-op update so the
+// API server stores them at the CRD storage version.
+func RewriteCRDObjects(ctx context.Context, rawCfg api.Config, ctxName string, s CRDStorage, progress func(done int)) (int, error) {
+	dial, err := dynClientFor(rawCfg, ctxName)
+	if err != nil {
+		return 0, err
+	}
+	res := dial.Resource(schema.GroupVersionResource{Group: s.Group, Version: s.Storage, Resource: s.Resource})
+
+	var done int
+	opts := metav1.ListOptions{Limit: crdMigrationPageSize}
+	for {
+		ll, err := res.List(ctx, opts)
+		if err != nil {
+			return done, err
+		}
+		for i := range ll.Items {
+			if err := rewriteObject(ctx, res, ll.Items[i].GetNamespace(), ll.Items[i].GetName()); err != nil {
+				return done, err
+			}
+			done++
+		}
+		progress(done)
+		if opts.Continue = ll.GetContinue(); opts.Continue == "" {
+			return done, nil
+		}
+	}
+}
+
+func rewriteObject(ctx context.Context, res dynamic.NamespaceableResourceInterface, ns, n string) error {
+	var ri dynamic.ResourceInterface = res
+	if ns != "" {
+		ri = res.Namespace(ns)
+	}
+	for {
+		u, err := ri.Get(ctx, n, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		_, err = ri.Update(ctx, u, metav1.UpdateOptions{})
+		if kerrors.IsConflict(err) {
+			continue
+		}
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+}
+
+// TrimStoredVersions resets the CRD stored versions to its storage version.
+// Objects must be rewritten first.
+func TrimStoredVersions(ctx context.Context, rawCfg api.Config, ctxName, name string) error {
+	dial, err := dynClientFor(rawCfg, ctxName)
+	if err != nil {
+		return err
+	}
+	res := dial.Resource(client.CrdGVR.GVR())
+	u, err := res.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	var crd apiext.CustomResourceDefinition
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &crd); err != nil {
+		return err
+	}
+	s := NewCRDStorage(&crd)
+	if s.Storage == "" {
+		return fmt.Errorf("no storage version found on crd %s", name)
+	}
+	if err := unstructured.SetNestedStringSlice(u.Object, []string{s.Storage}, "status", "storedVersions"); err != nil {
+		return err
+	}
+	_, err = res.UpdateStatus(ctx, u, metav1.UpdateOptions{})
+
+	return err
+}
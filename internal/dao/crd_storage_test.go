@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCRDStorage(t *testing.T) {
+	crd := func(stored []string, vv ...apiext.CustomResourceDefinitionVersion) *apiext.CustomResourceDefinition {
+		return &apiext.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "clusters.fred.io"},
+			Spec: apiext.CustomResourceDefinitionSpec{
+				Group:    "fred.io",
+				Names:    apiext.CustomResourceDefinitionNames{Plural: "clusters"},
+				Versions: vv,
+			},
+			Status: apiext.CustomResourceDefinitionStatus{StoredVersions: stored},
+		}
+	}
+
+	uu := map[string]struct {
+		crd    *apiext.CustomResourceDefinition
+		stale  []string
+		issues []string
+	}{
+		"clean": {
+			crd: crd([]string{"v1"}, apiext.CustomResourceDefinitionVersion{Name: "v1", Storage: true}),
+		},
+		"stale": {
+			crd: crd([]string{"v1beta1", "v1"},
+				apiext.CustomResourceDefinitionVersion{Name: "v1beta1", Deprecated: true},
+				apiext.CustomResourceDefinitionVersion{Name: "v1", Storage: true},
+			),
+			stale:  []string{"v1beta1"},
+			issues: []string{"clusters.fred.io objects may still be stored at [v1beta1], migrate them to v1"},
+		},
+		"deprecated-storage": {
+			crd: crd([]string{"v1beta1"},
+				apiext.CustomResourceDefinitionVersion{Name: "v1beta1", Storage: true, Deprecated: true},
+				apiext.CustomResourceDefinitionVersion{Name: "v1"},
+			),
+			issues: []string{"clusters.fred.io storage version v1beta1 is deprecated"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s := dao.NewCRDStorage(u.crd)
+			assert.Equal(t, "fred.io", s.Group)
+			assert.Equal(t, "clusters", s.Resource)
+			assert.Equal(t, u.stale, s.Stale())
+			assert.Equal(t, u.issues, s.Issues())
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// Friday.
+	now := time.Date(2026, 1, 2, 10, 30, 15, 0, time.UTC)
+
+	uu := map[string]struct {
+		spec string
+		e    time.Time
+		err  bool
+	}{
+		"hourly": {
+			spec: "0 * * * *",
+			e:    time.Date(2026, 1, 2, 11, 0, 0, 0, time.UTC),
+		},
+		"macro": {
+			spec: "@daily",
+			e:    time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		"steps": {
+			spec: "*/20 * * * *",
+			e:    time.Date(2026, 1, 2, 10, 40, 0, 0, time.UTC),
+		},
+		"range-list": {
+			spec: "15 1,3-4 * * *",
+			e:    time.Date(2026, 1, 3, 1, 15, 0, 0, time.UTC),
+		},
+		"weekday": {
+			spec: "0 2 * * mon",
+			e:    time.Date(2026, 1, 5, 2, 0, 0, 0, time.UTC),
+		},
+		"sunday-7": {
+			spec: "0 2 * * 7",
+			e:    time.Date(2026, 1, 4, 2, 0, 0, 0, time.UTC),
+		},
+		"dom-or-dow": {
+			spec: "0 0 15 * sat",
+			e:    time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		"month": {
+			spec: "0 0 1 mar *",
+			e:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"every": {
+			spec: "@every 90m",
+			e:    now.Add(90 * time.Minute),
+		},
+		"never": {
+			spec: "0 0 31 2 *",
+		},
+		"fields": {
+			spec: "0 * * *",
+			err:  true,
+		},
+		"range": {
+			spec: "0 25 * * *",
+			err:  true,
+		},
+		"value": {
+			spec: "0 x * * *",
+			err:  true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s, err := dao.ParseCron(u.spec)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.e, s.Next(now))
+		})
+	}
+}
+
+func TestCronScheduleNextHalfHourZone(t *testing.T) {
+	ist := time.FixedZone("IST", 5*3600+30*60)
+	s, err := dao.ParseCron("0 * * * *")
+	require.NoError(t, err)
+
+	now := time.Date(2026, 1, 2, 10, 45, 0, 0, ist)
+	assert.True(t, time.Date(2026, 1, 2, 11, 0, 0, 0, ist).Equal(s.Next(now)))
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// ContextCleanup summarizes the helpers torn down for an inactive context.
+type ContextCleanup struct {
+	Context   string
+	Forwards  int
+	ShellPods int
+	Clients   int
+}
+
+// IsEmpty checks if nothing was cleaned up.
+func (c ContextCleanup) IsEmpty() bool {
+	return c.Forwards == 0 && c.ShellPods == 0 && c.Clients == 0
+}
+
+// String returns a cleanup summary, ie `fred: 2 port-forwards, 1 shell pod`.
+func (c ContextCleanup) String() string {
+	ss := make([]string, 0, 3)
+	for _, s := range []struct {
+		n    int
+		name string
+	}{
+		{c.Forwards, "port-forward"},
+		{c.ShellPods, "shell pod"},
+		{c.Clients, "cached client"},
+	} {
+		if s.n == 0 {
+			continue
+		}
+		name := s.name
+		if s.n > 1 {
+			name += "s"
+		}
+		ss = append(ss, fmt.Sprintf("%d %s", s.n, name))
+	}
+	if len(ss) == 0 {
+		return c.Context + ": nothing to clean"
+	}
+
+	return c.Context + ": " + strings.Join(ss, ", ")
+}
+
+// ReleaseContext tears down the monitoring port-forwards and cached clients of
+// a context that is no longer in use.
+func ReleaseContext(ctxName string) ContextCleanup {
+	c := ContextCleanup{
+		Context:  ctxName,
+		Forwards: stopMonitoringForwardsFor(ctxName),
+	}
+	if _, ok := dynClientCache.LoadAndDelete(ctxName); ok {
+		c.Clients++
+	}
+	if _, ok := discClientCache.LoadAndDelete(ctxName); ok {
+		c.Clients++
+	}
+	warmups.Delete(ctxName)
+
+	return c
+}
+
+// IsUnreachable checks if a context failed on connectivity, ie the API server
+// can't be dialed, its certificate is rejected or the exec auth plugin fails,
+// as opposed to a failed request on a reachable context.
+func IsUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var (
+		opErr   *net.OpError
+		dnsErr  *net.DNSError
+		certErr *tls.CertificateVerificationError
+		authErr x509.UnknownAuthorityError
+		hostErr x509.HostnameError
+		invErr  x509.CertificateInvalidError
+		recErr  tls.RecordHeaderError
+	)
+	switch {
+	case errors.As(err, &opErr), errors.As(err, &dnsErr):
+		return true
+	case errors.As(err, &certErr), errors.As(err, &authErr), errors.As(err, &hostErr), errors.As(err, &invErr), errors.As(err, &recErr):
+		return true
+	}
+
+	// Exec auth plugins errors are not wrapped.
+	return strings.Contains(err.Error(), "getting credentials")
+}
+
+// DeleteShellPod removes a node shell pod from a context. It returns false
+// when the pod is already gone.
+func DeleteShellPod(ctx context.Context, rawCfg api.Config, ctxName, ns, name string) (bool, error) {
+	kc, err := kubeClientFor(rawCfg, ctxName)
+	if err != nil {
+		return false, err
+	}
+	err = kc.CoreV1().Pods(ns).Delete(ctx, name, metav1.DeleteOptions{})
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextCleanupString(t *testing.T) {
+	uu := map[string]struct {
+		c dao.ContextCleanup
+		e string
+	}{
+		"empty": {
+			c: dao.ContextCleanup{Context: "fred"},
+			e: "fred: nothing to clean",
+		},
+		"singular": {
+			c: dao.ContextCleanup{Context: "fred", Forwards: 1, ShellPods: 1},
+			e: "fred: 1 port-forward, 1 shell pod",
+		},
+		"plural": {
+			c: dao.ContextCleanup{Context: "fred", Forwards: 2, Clients: 3},
+			e: "fred: 2 port-forwards, 3 cached clients",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.c.String())
+			assert.Equal(t, k == "empty", u.c.IsEmpty())
+		})
+	}
+}
+
+func TestReleaseContextUnknown(t *testing.T) {
+	c := dao.ReleaseContext("no-such-context")
+
+	assert.True(t, c.IsEmpty())
+	assert.Equal(t, "no-such-context", c.Context)
+}
+
+func TestIsUnreachable(t *testing.T) {
+	dial := &url.Error{Op: "Get", URL: "https://10.0.0.1:6443", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	uu := map[string]struct {
+		err error
+		e   bool
+	}{
+		"none": {},
+		"dial": {
+			err: dial,
+			e:   true,
+		},
+		"wrapped": {
+			err: fmt.Errorf("list pods: %w", dial),
+			e:   true,
+		},
+		"tls": {
+			err: &url.Error{Op: "Get", URL: "https://10.0.0.1:6443", Err: x509.UnknownAuthorityError{}},
+			e:   true,
+		},
+		"exec-auth": {
+			err: errors.New("getting credentials: exec: executable kubelogin not found"),
+			e:   true,
+		},
+		"forbidden": {
+			err: errors.New(`pods is forbidden: User "fred" cannot list resource "pods"`),
+		},
+		"timeout": {
+			err: fmt.Errorf("list pods: %w", context.DeadlineExceeded),
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.IsUnreachable(u.err))
+		})
+	}
+}
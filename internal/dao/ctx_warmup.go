@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// SwitchStage represents a context switch step.
+type SwitchStage int
+
+const (
+	// SwitchAuth authenticates to the target cluster.
+	SwitchAuth SwitchStage = iota
+
+	// SwitchDiscovery lists the target cluster APIs.
+	SwitchDiscovery
+
+	// SwitchInformers waits for the active view cache to sync.
+	SwitchInformers
+)
+
+func (s SwitchStage) String() string {
+	switch s {
+	case SwitchAuth:
+		return "auth"
+	case SwitchDiscovery:
+		return "discovery"
+	case SwitchInformers:
+		return "informer sync"
+	default:
+		return "unknown"
+	}
+}
+
+// SwitchStages lists all context switch steps in order.
+func SwitchStages() []SwitchStage {
+	return []SwitchStage{SwitchAuth, SwitchDiscovery, SwitchInformers}
+}
+
+// WarmUpContext authenticates to a context and runs its API discovery prior
+// to switching to it, reporting each stage as it starts. The warm up never
+// touches the active connection so it can be abandoned anytime by canceling
+// the given context.
+func WarmUpContext(ctx context.Context, rawCfg api.Config, ctxName string, stage func(SwitchStage)) error {
+	stage(SwitchAuth)
+	dial, err := kubeClientFor(rawCfg, ctxName)
+	if err != nil {
+		return err
+	}
+	sar := authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:     "list",
+				Resource: "namespaces",
+			},
+		},
+	}
+	if _, err := dial.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &sar, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("unable to authenticate to context %q: %w", ctxName, err)
+	}
+
+	stage(SwitchDiscovery)
+	for _, p := range []string{"/api", "/apis"} {
+		if _, err := dial.Discovery().RESTClient().Get().AbsPath(p).DoRaw(ctx); err != nil {
+			return fmt.Errorf("api discovery failed on context %q: %w", ctxName, err)
+		}
+	}
+
+	return nil
+}
+
+// Context warm up states.
+const (
+	WarmupPending   = "pending"
+	WarmupClients   = "clients"
+	WarmupDiscovery = "discovery"
+	WarmupPrefetch  = "prefetch"
+	WarmupReady     = "ready"
+	WarmupFailed    = "failed"
+)
+
+var warmups sync.Map // map[string]ContextWarmup
+
+// ContextWarmup tracks the warm up of a context.
+type ContextWarmup struct {
+	Context string
+	State   string
+	Err     error
+	Elapsed time.Duration
+}
+
+// Status returns a short warm up status.
+func (w ContextWarmup) Status() string {
+	switch w.State {
+	case WarmupReady, WarmupFailed:
+		return fmt.Sprintf("%s %s", w.State, w.Elapsed.Round(100*time.Millisecond))
+	default:
+		return w.State
+	}
+}
+
+// WarmupFor returns the last known warm up status of a context.
+func WarmupFor(ctxName string) (ContextWarmup, bool) {
+	w, ok := warmups.Load(ctxName)
+	if !ok {
+		return ContextWarmup{}, false
+	}
+
+	return w.(ContextWarmup), true
+}
+
+// WarmUpContexts builds the dynamic and discovery clients of the given
+// contexts in parallel so multi-context views start off warm. Prefetch also
+// lists each context namespaces and pods. Each state change is reported.
+func WarmUpContexts(ctx context.Context, rawCfg api.Config, ctxs []string, prefetch bool, report func(ContextWarmup)) []ContextWarmup {
+	set := func(w ContextWarmup) {
+		warmups.Store(w.Context, w)
+		if report != nil {
+			report(w)
+		}
+	}
+	for _, c := range ctxs {
+		set(ContextWarmup{Context: c, State: WarmupPending})
+	}
+
+	out := make([]ContextWarmup, len(ctxs))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, mcParallelism())
+	for i, ctxName := range ctxs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, ctxName string) {
+			defer func() { <-sem; wg.Done() }()
+			t := time.Now()
+			err := warmUp(ctx, rawCfg, ctxName, prefetch, func(state string) {
+				set(ContextWarmup{Context: ctxName, State: state, Elapsed: time.Since(t)})
+			})
+			w := ContextWarmup{Context: ctxName, State: WarmupReady, Elapsed: time.Since(t)}
+			if err != nil {
+				w.State, w.Err = WarmupFailed, err
+			}
+			set(w)
+			out[i] = w
+		}(i, ctxName)
+	}
+	wg.Wait()
+
+	return out
+}
+
+func warmUp(ctx context.Context, rawCfg api.Config, ctxName string, prefetch bool, state func(string)) error {
+	state(WarmupClients)
+	dc, err := dynClientFor(rawCfg, ctxName)
+	if err != nil {
+		return err
+	}
+
+	state(WarmupDiscovery)
+	disc, err := discoveryFor(rawCfg, ctxName)
+	if err != nil {
+		return err
+	}
+	if gg, _, err := disc.ServerGroupsAndResources(); len(gg) == 0 && err != nil {
+		return err
+	}
+	if !prefetch {
+		return nil
+	}
+
+	state(WarmupPrefetch)
+	for _, gvr := range []*client.GVR{client.NsGVR, client.PodGVR} {
+		if _, err := dc.Resource(gvr.GVR()).List(ctx, metav1.ListOptions{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextWarmupStatus(t *testing.T) {
+	uu := map[string]struct {
+		w dao.ContextWarmup
+		e string
+	}{
+		"pending": {
+			w: dao.ContextWarmup{State: dao.WarmupPending},
+			e: "pending",
+		},
+		"discovery": {
+			w: dao.ContextWarmup{State: dao.WarmupDiscovery, Elapsed: time.Second},
+			e: "discovery",
+		},
+		"ready": {
+			w: dao.ContextWarmup{State: dao.WarmupReady, Elapsed: 1234 * time.Millisecond},
+			e: "ready 1.2s",
+		},
+		"failed": {
+			w: dao.ContextWarmup{State: dao.WarmupFailed, Err: errors.New("boom"), Elapsed: 10 * time.Second},
+			e: "failed 10s",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.w.Status())
+		})
+	}
+}
+
+func TestSwitchStageString(t *testing.T) {
+	ss := make([]string, 0, len(dao.SwitchStages()))
+	for _, s := range dao.SwitchStages() {
+		ss = append(ss, s.String())
+	}
+
+	assert.Equal(t, []string{"auth", "discovery", "informer sync"}, ss)
+}
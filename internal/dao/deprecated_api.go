@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	deprecatedAPIMetric   = "apiserver_requested_deprecated_apis"
+	deprecatedAnnotation  = "k8s.io/deprecated"
+	removedAnnotation     = "k8s.io/removed-release"
+	auditResponseComplete = "ResponseComplete"
+)
+
+// DeprecatedAPI represents a deprecated API requested since the API server
+// last started.
+type DeprecatedAPI struct {
+	Context        string
+	Group, Version string
+	Resource       string
+	Subresource    string
+	RemovedRelease string
+}
+
+// GroupVersion returns the API group version.
+func (d DeprecatedAPI) GroupVersion() string {
+	if d.Group == "" {
+		return d.Version
+	}
+
+	return d.Group + "/" + d.Version
+}
+
+// FetchDeprecatedAPIs scrapes the API server metrics of a context for the
+// deprecated APIs clients requested.
+func FetchDeprecatedAPIs(ctx context.Context, rawCfg api.Config, ctxName string) ([]DeprecatedAPI, error) {
+	dial, err := kubeClientFor(rawCfg, ctxName)
+	if err != nil {
+		return nil, err
+	}
+	bb, err := dial.CoreV1().RESTClient().Get().AbsPath("/metrics").DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dd := DeprecatedAPIs(bb)
+	for i := range dd {
+		dd[i].Context = ctxName
+	}
+
+	return dd, nil
+}
+
+// DeprecatedAPIs extracts the requested deprecated APIs from API server metrics.
+func DeprecatedAPIs(bb []byte) []DeprecatedAPI {
+	var dd []DeprecatedAPI
+	scanner := bufio.NewScanner(bytes.NewReader(bb))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, deprecatedAPIMetric+"{") {
+			continue
+		}
+		if _, v, ok := metricSample(line); !ok || v == 0 {
+			continue
+		}
+		ll := metricLabels(line)
+		dd = append(dd, DeprecatedAPI{
+			Group:          ll["group"],
+			Version:        ll["version"],
+			Resource:       ll["resource"],
+			Subresource:    ll["subresource"],
+			RemovedRelease: ll["removed_release"],
+		})
+	}
+	sort.Slice(dd, func(i, j int) bool {
+		if dd[i].Group != dd[j].Group {
+			return dd[i].Group < dd[j].Group
+		}
+		if dd[i].Version != dd[j].Version {
+			return dd[i].Version < dd[j].Version
+		}
+		return dd[i].Resource < dd[j].Resource
+	})
+
+	return dd
+}
+
+// metricLabels parses the labels of a metric sample, ie `m{a="b",c="d"} 1`.
+func metricLabels(line string) map[string]string {
+	ll := make(map[string]string)
+	i := strings.IndexByte(line, '{')
+	if i < 0 {
+		return ll
+	}
+	s := line[i+1:]
+	for {
+		eq := strings.Index(s, `="`)
+		if eq < 0 {
+			return ll
+		}
+		k := strings.TrimLeft(s[:eq], ", ")
+		s = s[eq+2:]
+		var (
+			v   strings.Builder
+			esc bool
+			end = -1
+		)
+		for j, r := range s {
+			if esc {
+				v.WriteRune(r)
+				esc = false
+				continue
+			}
+			if r == '\\' {
+				esc = true
+				continue
+			}
+			if r == '"' {
+				end = j
+				break
+			}
+			v.WriteRune(r)
+		}
+		if end < 0 {
+			return ll
+		}
+		ll[k] = v.String()
+		s = s[end+1:]
+	}
+}
+
+// DeprecatedCaller represents a client calling deprecated APIs as recorded in
+// API server audit logs.
+type DeprecatedCaller struct {
+	User, UserAgent string
+	API             string
+	RemovedRelease  string
+	Count           int
+	Last            time.Time
+}
+
+type auditEvent struct {
+	Stage string `json:"stage"`
+	User  struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	UserAgent string `json:"userAgent"`
+	ObjectRef *struct {
+		Resource   string `json:"resource"`
+		APIGroup   string `json:"apiGroup"`
+		APIVersion string `json:"apiVersion"`
+	} `json:"objectRef"`
+	Annotations              map[string]string `json:"annotations"`
+	RequestReceivedTimestamp time.Time         `json:"requestReceivedTimestamp"`
+}
+
+// DeprecatedCallers aggregates the deprecated API calls of a JSON audit log
+// by user, user agent and API. Calls are sorted by most frequent first.
+func DeprecatedCallers(r io.Reader) ([]DeprecatedCaller, error) {
+	calls := make(map[string]*DeprecatedCaller)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var e auditEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if e.Annotations[deprecatedAnnotation] != "true" || e.ObjectRef == nil {
+			continue
+		}
+		if e.Stage != "" && e.Stage != auditResponseComplete {
+			continue
+		}
+		gv := e.ObjectRef.APIVersion
+		if e.ObjectRef.APIGroup != "" {
+			gv = e.ObjectRef.APIGroup + "/" + gv
+		}
+		api := gv + " " + e.ObjectRef.Resource
+		key := strings.Join([]string{e.User.Username, e.UserAgent, api}, "|")
+		c, ok := calls[key]
+		if !ok {
+			c = &DeprecatedCaller{
+				User:           e.User.Username,
+				UserAgent:      e.UserAgent,
+				API:            api,
+				RemovedRelease: e.Annotations[removedAnnotation],
+			}
+			calls[key] = c
+		}
+		c.Count++
+		if e.RequestReceivedTimestamp.After(c.Last) {
+			c.Last = e.RequestReceivedTimestamp
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	cc := make([]DeprecatedCaller, 0, len(calls))
+	for _, c := range calls {
+		cc = append(cc, *c)
+	}
+	sort.Slice(cc, func(i, j int) bool {
+		if cc[i].Count != cc[j].Count {
+			return cc[i].Count > cc[j].Count
+		}
+		return cc[i].User < cc[j].User
+	})
+
+	return cc, nil
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecatedAPIs(t *testing.T) {
+	metrics := `# HELP apiserver_requested_deprecated_apis [STABLE] Gauge of deprecated APIs that have been requested, broken out by API group, version, resource, subresource, and removed_release.
+# TYPE apiserver_requested_deprecated_apis gauge
+apiserver_requested_deprecated_apis{group="policy",removed_release="1.25",resource="podsecuritypolicies",subresource="",version="v1beta1"} 1
+apiserver_requested_deprecated_apis{group="",removed_release="",resource="componentstatuses",subresource="",version="v1"} 1
+apiserver_requested_deprecated_apis{group="flowcontrol.apiserver.k8s.io",removed_release="1.32",resource="flowschemas",subresource="status",version="v1beta3"} 0
+apiserver_request_total{code="200",group="policy",resource="podsecuritypolicies",verb="LIST",version="v1beta1"} 12
+`
+
+	assert.Equal(t, []dao.DeprecatedAPI{
+		{Version: "v1", Resource: "componentstatuses"},
+		{Group: "policy", Version: "v1beta1", Resource: "podsecuritypolicies", RemovedRelease: "1.25"},
+	}, dao.DeprecatedAPIs([]byte(metrics)))
+}
+
+func TestDeprecatedCallers(t *testing.T) {
+	log := strings.Join([]string{
+		`{"kind":"Event","stage":"ResponseComplete","user":{"username":"system:serviceaccount:cattle-system:rancher"},"userAgent":"rancher/v2","objectRef":{"resource":"flowschemas","apiGroup":"flowcontrol.apiserver.k8s.io","apiVersion":"v1beta3"},"annotations":{"k8s.io/deprecated":"true","k8s.io/removed-release":"1.32"},"requestReceivedTimestamp":"2024-05-01T10:00:00Z"}`,
+		`{"kind":"Event","stage":"ResponseStarted","user":{"username":"system:serviceaccount:cattle-system:rancher"},"userAgent":"rancher/v2","objectRef":{"resource":"flowschemas","apiGroup":"flowcontrol.apiserver.k8s.io","apiVersion":"v1beta3"},"annotations":{"k8s.io/deprecated":"true","k8s.io/removed-release":"1.32"},"requestReceivedTimestamp":"2024-05-01T10:00:00Z"}`,
+		`{"kind":"Event","stage":"ResponseComplete","user":{"username":"system:serviceaccount:cattle-system:rancher"},"userAgent":"rancher/v2","objectRef":{"resource":"flowschemas","apiGroup":"flowcontrol.apiserver.k8s.io","apiVersion":"v1beta3"},"annotations":{"k8s.io/deprecated":"true","k8s.io/removed-release":"1.32"},"requestReceivedTimestamp":"2024-05-01T11:00:00Z"}`,
+		`{"kind":"Event","stage":"ResponseComplete","user":{"username":"fred"},"userAgent":"kubectl/v1.24.0","objectRef":{"resource":"componentstatuses","apiVersion":"v1"},"annotations":{"k8s.io/deprecated":"true"},"requestReceivedTimestamp":"2024-05-01T09:00:00Z"}`,
+		`{"kind":"Event","stage":"ResponseComplete","user":{"username":"fred"},"userAgent":"kubectl/v1.30.0","objectRef":{"resource":"pods","apiVersion":"v1"},"requestReceivedTimestamp":"2024-05-01T09:00:00Z"}`,
+		`not json`,
+	}, "\n")
+
+	cc, err := dao.DeprecatedCallers(strings.NewReader(log))
+	require.NoError(t, err)
+	assert.Equal(t, []dao.DeprecatedCaller{
+		{
+			User:           "system:serviceaccount:cattle-system:rancher",
+			UserAgent:      "rancher/v2",
+			API:            "flowcontrol.apiserver.k8s.io/v1beta3 flowschemas",
+			RemovedRelease: "1.32",
+			Count:          2,
+			Last:           time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			User:      "fred",
+			UserAgent: "kubectl/v1.24.0",
+			API:       "v1 componentstatuses",
+			Count:     1,
+			Last:      time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+		},
+	}, cc)
+}
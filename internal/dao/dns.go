@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	dnsNamespace   = "kube-system"
+	dnsPodSelector = "k8s-app=kube-dns"
+	corefileKey    = "Corefile"
+	maxSearchPaths = 6
+	defaultNdots   = 1
+)
+
+var (
+	cmGVR = client.NewGVR("v1/configmaps")
+	dsGVR = client.NewGVR("apps/v1/daemonsets")
+)
+
+// DNSPod represents a cluster DNS pod status.
+type DNSPod struct {
+	Name, Node string
+	Ready      bool
+	Restarts   int32
+}
+
+// DNSReport represents the cluster DNS health of a given context.
+type DNSReport struct {
+	Context   string
+	Pods      []DNSPod
+	ConfigMap string
+	Upstreams []string
+	NodeLocal string
+	Issues    []string
+}
+
+// FetchDNSReport inspects the CoreDNS deployment, configuration and node
+// local cache of a given context.
+func FetchDNSReport(rawCfg api.Config, ctxName string) (*DNSReport, error) {
+	ctxs := []string{ctxName}
+	pp, err := MultiContextList(context.Background(), rawCfg, ctxs, client.PodGVR.GVR(), dnsNamespace, dnsPodSelector)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := MultiContextList(context.Background(), rawCfg, ctxs, cmGVR.GVR(), dnsNamespace, "")
+	if err != nil {
+		return nil, err
+	}
+	dd, err := MultiContextList(context.Background(), rawCfg, ctxs, dsGVR.GVR(), dnsNamespace, "")
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]*v1.Pod, 0, len(pp))
+	for _, co := range pp {
+		var po v1.Pod
+		if fromContextObject(co, &po) {
+			pods = append(pods, &po)
+		}
+	}
+	cms := make([]*v1.ConfigMap, 0, len(cc))
+	for _, co := range cc {
+		var cm v1.ConfigMap
+		if fromContextObject(co, &cm) {
+			cms = append(cms, &cm)
+		}
+	}
+	dss := make([]*appsv1.DaemonSet, 0, len(dd))
+	for _, co := range dd {
+		var ds appsv1.DaemonSet
+		if fromContextObject(co, &ds) {
+			dss = append(dss, &ds)
+		}
+	}
+
+	return AnalyzeDNS(ctxName, pods, cms, dss), nil
+}
+
+func fromContextObject(co ContextObject, o any) bool {
+	u, ok := co.Object.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, o) == nil
+}
+
+// AnalyzeDNS flags common cluster DNS issues.
+func AnalyzeDNS(ctxName string, pods []*v1.Pod, cms []*v1.ConfigMap, dss []*appsv1.DaemonSet) *DNSReport {
+	r := DNSReport{Context: ctxName}
+
+	var ready int
+	for _, po := range pods {
+		p := DNSPod{Name: po.Name, Node: po.Spec.NodeName}
+		for _, c := range po.Status.Conditions {
+			if c.Type == v1.PodReady {
+				p.Ready = c.Status == v1.ConditionTrue
+			}
+		}
+		for _, cs := range po.Status.ContainerStatuses {
+			p.Restarts += cs.RestartCount
+		}
+		if p.Ready {
+			ready++
+		} else {
+			r.Issues = append(r.Issues, fmt.Sprintf("dns pod %s is not ready", p.Name))
+		}
+		if p.Restarts > 0 {
+			r.Issues = append(r.Issues, fmt.Sprintf("dns pod %s restarted %d times", p.Name, p.Restarts))
+		}
+		r.Pods = append(r.Pods, p)
+	}
+	switch {
+	case len(pods) == 0:
+		r.Issues = append(r.Issues, fmt.Sprintf("no dns pods found in %s matching %s", dnsNamespace, dnsPodSelector))
+	case ready < 2:
+		r.Issues = append(r.Issues, fmt.Sprintf("only %d dns pod ready, no redundancy", ready))
+	}
+
+	for _, cm := range cms {
+		corefile, ok := cm.Data[corefileKey]
+		if !ok {
+			continue
+		}
+		r.ConfigMap = cm.Name
+		r.Upstreams = CorefileUpstreams(corefile)
+		if !strings.Contains(corefile, "cache") {
+			r.Issues = append(r.Issues, "Corefile has no cache plugin")
+		}
+		if !strings.Contains(corefile, "loop") {
+			r.Issues = append(r.Issues, "Corefile has no loop plugin, forwarding loops go undetected")
+		}
+		if len(r.Upstreams) == 0 {
+			r.Issues = append(r.Issues, "Corefile does not forward external queries")
+		}
+		break
+	}
+	if r.ConfigMap == "" {
+		r.Issues = append(r.Issues, "no Corefile config map found")
+	}
+
+	for _, ds := range dss {
+		if !strings.Contains(ds.Name, "node-local-dns") && !strings.Contains(ds.Name, "nodelocaldns") {
+			continue
+		}
+		st := ds.Status
+		r.NodeLocal = fmt.Sprintf("%s %d/%d ready, %d updated", ds.Name, st.NumberReady, st.DesiredNumberScheduled, st.UpdatedNumberScheduled)
+		if st.UpdatedNumberScheduled < st.DesiredNumberScheduled {
+			r.Issues = append(r.Issues, fmt.Sprintf("node-local cache stale on %d nodes (not running the latest spec)", st.DesiredNumberScheduled-st.UpdatedNumberScheduled))
+		}
+		if st.NumberReady < st.DesiredNumberScheduled {
+			r.Issues = append(r.Issues, fmt.Sprintf("node-local cache not ready on %d nodes", st.DesiredNumberScheduled-st.NumberReady))
+		}
+	}
+
+	return &r
+}
+
+// CorefileUpstreams returns the forward targets of the root zone.
+func CorefileUpstreams(corefile string) []string {
+	var uu []string
+	scanner := bufio.NewScanner(strings.NewReader(corefile))
+	for scanner.Scan() {
+		ff := strings.Fields(scanner.Text())
+		if len(ff) < 3 || ff[0] != "forward" || ff[1] != "." {
+			continue
+		}
+		for _, f := range ff[2:] {
+			if f == "{" {
+				break
+			}
+			if !slices.Contains(uu, f) {
+				uu = append(uu, f)
+			}
+		}
+	}
+
+	return uu
+}
+
+// ResolvConfIssues flags pod resolver settings known to hurt name resolution
+// for a given lookup name.
+func ResolvConfIssues(resolv, name string) []string {
+	var (
+		ndots  = defaultNdots
+		search []string
+		issues []string
+	)
+	scanner := bufio.NewScanner(strings.NewReader(resolv))
+	for scanner.Scan() {
+		ff := strings.Fields(scanner.Text())
+		if len(ff) == 0 {
+			continue
+		}
+		switch ff[0] {
+		case "search":
+			search = ff[1:]
+		case "options":
+			for _, o := range ff[1:] {
+				if v, ok := strings.CutPrefix(o, "ndots:"); ok {
+					if n, err := strconv.Atoi(v); err == nil {
+						ndots = n
+					}
+				}
+			}
+		}
+	}
+
+	if len(search) > maxSearchPaths {
+		issues = append(issues, fmt.Sprintf("%d search domains, some resolvers only honor %d", len(search), maxSearchPaths))
+	}
+	if name != "" && !strings.HasSuffix(name, ".") && strings.Count(name, ".") < ndots {
+		issues = append(issues, fmt.Sprintf("ndots:%d makes %q walk %d search domains before an absolute lookup, use %q", ndots, name, len(search), name+"."))
+	}
+
+	return issues
+}
+
+// DNSProbeScript returns a shell script dumping the pod resolver config and
+// resolving the given names.
+func DNSProbeScript(names ...string) string {
+	ss := []string{"echo '--- resolv.conf'", "cat /etc/resolv.conf"}
+	for _, n := range names {
+		ss = append(ss, fmt.Sprintf("echo '--- nslookup %s'", n), fmt.Sprintf("nslookup %s || echo 'LOOKUP FAILED'", n))
+	}
+
+	return strings.Join(ss, "; ")
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testCorefile = `.:53 {
+    errors
+    health
+    kubernetes cluster.local in-addr.arpa ip6.arpa {
+      pods insecure
+      fallthrough in-addr.arpa ip6.arpa
+    }
+    prometheus :9153
+    forward . 1.1.1.1 8.8.8.8 {
+      max_concurrent 1000
+    }
+    cache 30
+    reload
+}`
+
+func TestCorefileUpstreams(t *testing.T) {
+	uu := map[string]struct {
+		corefile string
+		e        []string
+	}{
+		"empty": {},
+		"multi": {
+			corefile: testCorefile,
+			e:        []string{"1.1.1.1", "8.8.8.8"},
+		},
+		"resolv": {
+			corefile: ".:53 {\n  forward . /etc/resolv.conf\n}",
+			e:        []string{"/etc/resolv.conf"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.CorefileUpstreams(u.corefile))
+		})
+	}
+}
+
+func TestResolvConfIssues(t *testing.T) {
+	uu := map[string]struct {
+		resolv, name string
+		e            int
+	}{
+		"default": {
+			resolv: "nameserver 10.43.0.10\n",
+			name:   "kubernetes.io",
+		},
+		"ndots": {
+			resolv: "search default.svc.cluster.local svc.cluster.local cluster.local\nnameserver 10.43.0.10\noptions ndots:5\n",
+			name:   "kubernetes.io",
+			e:      1,
+		},
+		"fqdn": {
+			resolv: "options ndots:5\n",
+			name:   "kubernetes.io.",
+		},
+		"search-overflow": {
+			resolv: "search a b c d e f g\noptions ndots:5\n",
+			name:   "kubernetes.io",
+			e:      2,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Len(t, dao.ResolvConfIssues(u.resolv, u.name), u.e)
+		})
+	}
+}
+
+func TestAnalyzeDNS(t *testing.T) {
+	ready := func(n string, ok bool, restarts int32) *v1.Pod {
+		st := v1.ConditionFalse
+		if ok {
+			st = v1.ConditionTrue
+		}
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: n},
+			Status: v1.PodStatus{
+				Conditions:        []v1.PodCondition{{Type: v1.PodReady, Status: st}},
+				ContainerStatuses: []v1.ContainerStatus{{RestartCount: restarts}},
+			},
+		}
+	}
+	cm := v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "rke2-coredns-rke2-coredns"},
+		Data:       map[string]string{"Corefile": testCorefile},
+	}
+	ds := appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-local-dns"},
+		Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 3, UpdatedNumberScheduled: 2},
+	}
+
+	r := dao.AnalyzeDNS("ct1", []*v1.Pod{ready("dns-1", true, 0), ready("dns-2", false, 2)}, []*v1.ConfigMap{&cm}, []*appsv1.DaemonSet{&ds})
+	assert.Equal(t, "rke2-coredns-rke2-coredns", r.ConfigMap)
+	assert.Equal(t, []string{"1.1.1.1", "8.8.8.8"}, r.Upstreams)
+	assert.Equal(t, "node-local-dns 3/3 ready, 2 updated", r.NodeLocal)
+	assert.Equal(t, []string{
+		"dns pod dns-2 is not ready",
+		"dns pod dns-2 restarted 2 times",
+		"only 1 dns pod ready, no redundancy",
+		"Corefile has no loop plugin, forwarding loops go undetected",
+		"node-local cache stale on 1 nodes (not running the latest spec)",
+	}, r.Issues)
+
+	r = dao.AnalyzeDNS("ct1", nil, nil, nil)
+	assert.Equal(t, []string{
+		"no dns pods found in kube-system matching k8s-app=kube-dns",
+		"no Corefile config map found",
+	}, r.Issues)
+}
@@ -0,0 +1,226 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// DaemonSet pods automatically tolerate node condition taints.
+const nodeConditionTaintPrefix = "node.kubernetes.io/"
+
+// DaemonSetGap represents a node without a ready DaemonSet pod.
+type DaemonSetGap struct {
+	Context, DaemonSet, Node string
+	// Excluded is set when the DaemonSet is not meant to run on the node.
+	Excluded bool
+	Reason   string
+}
+
+// FetchDaemonSetGaps lists the nodes of a context missing a ready pod of the
+// DaemonSets in a namespace. Filter restricts the report to the DaemonSet of
+// that exact name.
+func FetchDaemonSetGaps(rawCfg api.Config, ctxName, ns, filter string) ([]DaemonSetGap, error) {
+	ctxs := []string{ctxName}
+	oo, err := MultiContextList(context.Background(), rawCfg, ctxs, client.DsGVR.GVR(), ns, "")
+	if err != nil {
+		return nil, err
+	}
+	nn, err := MultiContextList(context.Background(), rawCfg, ctxs, client.NodeGVR.GVR(), client.BlankNamespace, "")
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]v1.Node, 0, len(nn))
+	for _, co := range nn {
+		var no v1.Node
+		if fromContextObject(co, &no) {
+			nodes = append(nodes, no)
+		}
+	}
+
+	var gg []DaemonSetGap
+	for _, co := range oo {
+		var ds appsv1.DaemonSet
+		if !fromContextObject(co, &ds) || (filter != "" && ds.Name != filter) {
+			continue
+		}
+		var sel string
+		if ds.Spec.Selector != nil {
+			sel = labels.SelectorFromSet(ds.Spec.Selector.MatchLabels).String()
+		}
+		pp, err := MultiContextList(context.Background(), rawCfg, ctxs, client.PodGVR.GVR(), ds.Namespace, sel)
+		if err != nil {
+			return nil, err
+		}
+		pods := make([]v1.Pod, 0, len(pp))
+		for _, co := range pp {
+			var po v1.Pod
+			if fromContextObject(co, &po) {
+				pods = append(pods, po)
+			}
+		}
+		gg = append(gg, DaemonSetGaps(ctxName, &ds, nodes, pods)...)
+	}
+
+	return gg, nil
+}
+
+// DaemonSetGaps returns the nodes missing a ready DaemonSet pod and why.
+func DaemonSetGaps(ctxName string, ds *appsv1.DaemonSet, nodes []v1.Node, pods []v1.Pod) []DaemonSetGap {
+	byNode := make(map[string]*v1.Pod, len(pods))
+	for i := range pods {
+		if !isOwnedBy(&pods[i], ds.UID) || pods[i].Spec.NodeName == "" {
+			continue
+		}
+		if p, ok := byNode[pods[i].Spec.NodeName]; ok && isPodReady(p) {
+			continue
+		}
+		byNode[pods[i].Spec.NodeName] = &pods[i]
+	}
+
+	fqn := client.FQN(ds.Namespace, ds.Name)
+	var gg []DaemonSetGap
+	for i := range nodes {
+		no := &nodes[i]
+		g := DaemonSetGap{Context: ctxName, DaemonSet: fqn, Node: no.Name}
+		if po, ok := byNode[no.Name]; ok {
+			if isPodReady(po) {
+				continue
+			}
+			g.Reason = fmt.Sprintf("pod %s not ready (%s)", po.Name, po.Status.Phase)
+			gg = append(gg, g)
+			continue
+		}
+		if reason := dsExclusion(&ds.Spec.Template.Spec, no); reason != "" {
+			g.Excluded, g.Reason = true, reason
+		} else {
+			g.Reason = "no pod scheduled"
+		}
+		gg = append(gg, g)
+	}
+	sort.Slice(gg, func(i, j int) bool {
+		if gg[i].DaemonSet != gg[j].DaemonSet {
+			return gg[i].DaemonSet < gg[j].DaemonSet
+		}
+		return gg[i].Node < gg[j].Node
+	})
+
+	return gg
+}
+
+// dsExclusion returns why a pod spec can not run on a node, if it can't.
+func dsExclusion(spec *v1.PodSpec, no *v1.Node) string {
+	for k, v := range spec.NodeSelector {
+		if no.Labels[k] != v {
+			return fmt.Sprintf("nodeSelector %s=%s does not match", k, v)
+		}
+	}
+	if a := spec.Affinity; a != nil && a.NodeAffinity != nil {
+		if req := a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; req != nil && !matchNodeSelectorTerms(req.NodeSelectorTerms, no) {
+			return "required node affinity does not match"
+		}
+	}
+	for i := range no.Spec.Taints {
+		t := &no.Spec.Taints[i]
+		if t.Effect == v1.TaintEffectPreferNoSchedule || strings.HasPrefix(t.Key, nodeConditionTaintPrefix) {
+			continue
+		}
+		if !toleratesTaint(spec.Tolerations, t) {
+			return fmt.Sprintf("untolerated taint %s", t.ToString())
+		}
+	}
+
+	return ""
+}
+
+func toleratesTaint(tt []v1.Toleration, t *v1.Taint) bool {
+	for _, to := range tt {
+		if to.Effect != "" && to.Effect != t.Effect {
+			continue
+		}
+		if to.Key != "" && to.Key != t.Key {
+			continue
+		}
+		switch to.Operator {
+		case v1.TolerationOpExists:
+			return true
+		case "", v1.TolerationOpEqual:
+			if to.Key != "" && to.Value == t.Value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchNodeSelectorTerms matches ORed node selector terms against the node
+// labels and its exact name, the only field node selectors support.
+func matchNodeSelectorTerms(tt []v1.NodeSelectorTerm, no *v1.Node) bool {
+	for _, t := range tt {
+		if len(t.MatchExpressions) == 0 && len(t.MatchFields) == 0 {
+			continue
+		}
+		sel, ok := nodeSelector(t.MatchExpressions)
+		if !ok || !sel.Matches(labels.Set(no.Labels)) {
+			continue
+		}
+		fsel, ok := nodeSelector(t.MatchFields)
+		if ok && fsel.Matches(labels.Set{nodeNameField: no.Name}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nodeNameField tracks the node name field of node selector terms.
+const nodeNameField = "metadata.name"
+
+var nodeSelectorOps = map[v1.NodeSelectorOperator]selection.Operator{
+	v1.NodeSelectorOpIn:           selection.In,
+	v1.NodeSelectorOpNotIn:        selection.NotIn,
+	v1.NodeSelectorOpExists:       selection.Exists,
+	v1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	v1.NodeSelectorOpGt:           selection.GreaterThan,
+	v1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+func nodeSelector(rr []v1.NodeSelectorRequirement) (labels.Selector, bool) {
+	sel := labels.NewSelector()
+	for _, r := range rr {
+		op, ok := nodeSelectorOps[r.Operator]
+		if !ok {
+			return nil, false
+		}
+		req, err := labels.NewRequirement(r.Key, op, r.Values)
+		if err != nil {
+			return nil, false
+		}
+		sel = sel.Add(*req)
+	}
+
+	return sel, true
+}
+
+func isOwnedBy(po *v1.Pod, uid types.UID) bool {
+	for _, r := range po.OwnerReferences {
+		if r.UID == uid {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDaemonSetGaps(t *testing.T) {
+	node := func(n string, ll map[string]string, tt ...v1.Taint) v1.Node {
+		return v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: n, Labels: ll},
+			Spec:       v1.NodeSpec{Taints: tt},
+		}
+	}
+	pod := func(n, node string, ready v1.ConditionStatus) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            n,
+				Namespace:       "kube-system",
+				OwnerReferences: []metav1.OwnerReference{{UID: "ds-1"}},
+			},
+			Spec: v1.PodSpec{NodeName: node},
+			Status: v1.PodStatus{
+				Phase:      v1.PodRunning,
+				Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: ready}},
+			},
+		}
+	}
+	ds := func(spec v1.PodSpec) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "cni", Namespace: "kube-system", UID: "ds-1"},
+			Spec:       appsv1.DaemonSetSpec{Template: v1.PodTemplateSpec{Spec: spec}},
+		}
+	}
+	cpTaint := v1.Taint{Key: "node-role.kubernetes.io/control-plane", Effect: v1.TaintEffectNoSchedule}
+
+	uu := map[string]struct {
+		ds    *appsv1.DaemonSet
+		nodes []v1.Node
+		pods  []v1.Pod
+		e     []dao.DaemonSetGap
+	}{
+		"covered": {
+			ds:    ds(v1.PodSpec{}),
+			nodes: []v1.Node{node("n1", nil), node("n2", nil)},
+			pods:  []v1.Pod{pod("cni-1", "n1", v1.ConditionTrue), pod("cni-2", "n2", v1.ConditionTrue)},
+		},
+		"missing": {
+			ds:    ds(v1.PodSpec{}),
+			nodes: []v1.Node{node("n2", nil), node("n1", nil)},
+			pods:  []v1.Pod{pod("cni-1", "n1", v1.ConditionFalse)},
+			e: []dao.DaemonSetGap{
+				{Context: "ct1", DaemonSet: "kube-system/cni", Node: "n1", Reason: "pod cni-1 not ready (Running)"},
+				{Context: "ct1", DaemonSet: "kube-system/cni", Node: "n2", Reason: "no pod scheduled"},
+			},
+		},
+		"taints": {
+			ds: ds(v1.PodSpec{}),
+			nodes: []v1.Node{
+				node("cp1", nil, cpTaint),
+				node("n1", nil, v1.Taint{Key: "node.kubernetes.io/not-ready", Effect: v1.TaintEffectNoExecute}),
+			},
+			e: []dao.DaemonSetGap{
+				{Context: "ct1", DaemonSet: "kube-system/cni", Node: "cp1", Excluded: true, Reason: "untolerated taint node-role.kubernetes.io/control-plane:NoSchedule"},
+				{Context: "ct1", DaemonSet: "kube-system/cni", Node: "n1", Reason: "no pod scheduled"},
+			},
+		},
+		"tolerated": {
+			ds:    ds(v1.PodSpec{Tolerations: []v1.Toleration{{Operator: v1.TolerationOpExists}}}),
+			nodes: []v1.Node{node("cp1", nil, cpTaint)},
+			pods:  []v1.Pod{pod("cni-1", "cp1", v1.ConditionTrue)},
+		},
+		"selectors": {
+			ds: ds(v1.PodSpec{
+				NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+				Affinity: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{{
+							MatchExpressions: []v1.NodeSelectorRequirement{{Key: "storage", Operator: v1.NodeSelectorOpExists}},
+						}},
+					},
+				}},
+			}),
+			nodes: []v1.Node{
+				node("w1", map[string]string{"kubernetes.io/os": "windows"}),
+				node("n1", map[string]string{"kubernetes.io/os": "linux"}),
+				node("n2", map[string]string{"kubernetes.io/os": "linux", "storage": "true"}),
+			},
+			e: []dao.DaemonSetGap{
+				{Context: "ct1", DaemonSet: "kube-system/cni", Node: "n1", Excluded: true, Reason: "required node affinity does not match"},
+				{Context: "ct1", DaemonSet: "kube-system/cni", Node: "n2", Reason: "no pod scheduled"},
+				{Context: "ct1", DaemonSet: "kube-system/cni", Node: "w1", Excluded: true, Reason: "nodeSelector kubernetes.io/os=linux does not match"},
+			},
+		},
+		"node-name": {
+			ds: ds(v1.PodSpec{
+				Affinity: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{{
+							MatchFields: []v1.NodeSelectorRequirement{{Key: "metadata.name", Operator: v1.NodeSelectorOpIn, Values: []string{"n1"}}},
+						}},
+					},
+				}},
+			}),
+			nodes: []v1.Node{node("n1", nil), node("n10", nil)},
+			e: []dao.DaemonSetGap{
+				{Context: "ct1", DaemonSet: "kube-system/cni", Node: "n1", Reason: "no pod scheduled"},
+				{Context: "ct1", DaemonSet: "kube-system/cni", Node: "n10", Excluded: true, Reason: "required node affinity does not match"},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.DaemonSetGaps("ct1", u.ds, u.nodes, u.pods))
+		})
+	}
+}
@@ -44,6 +44,7 @@ func (d *Dynamic) toTable(ctx context.Context, fqn string) ([]runtime.Object, er
 	if s, ok := ctx.Value(internal.KeyLabels).(labels.Selector); ok {
 		sel = s
 	}
+	fieldSel, _ := ctx.Value(internal.KeyFields).(string)
 
 	opts := []string{d.gvr.AsResourceName()}
 	ns, n := client.Namespaced(fqn)
@@ -57,7 +58,7 @@ func (d *Dynamic) toTable(ctx context.Context, fqn string) ([]runtime.Object, er
 		Unstructured().
 		NamespaceParam(ns).DefaultNamespace().AllNamespaces(allNS).
 		LabelSelectorParam(sel.String()).
-		FieldSelectorParam("").
+		FieldSelectorParam(fieldSel).
 		RequestChunksOf(0).
 		ResourceTypeOrNameArgs(true, opts...).
 		ContinueOnError().
@@ -0,0 +1,275 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/derailed/k9s/internal/slogs"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const (
+	etcdNamespace = "kube-system"
+	etcdSelector  = "component=etcd"
+)
+
+// etcdctlScript runs etcdctl against the local member with the client certs
+// of either an RKE2 or a kubeadm control plane.
+const etcdctlScript = `if [ -f /var/lib/rancher/rke2/server/tls/etcd/server-client.crt ]; then
+  d=/var/lib/rancher/rke2/server/tls/etcd
+  set -- --cacert $d/server-ca.crt --cert $d/server-client.crt --key $d/server-client.key "$@"
+else
+  d=/etc/kubernetes/pki/etcd
+  set -- --cacert $d/ca.crt --cert $d/server.crt --key $d/server.key "$@"
+fi
+ETCDCTL_API=3 exec etcdctl --endpoints https://127.0.0.1:2379 "$@"`
+
+var _ Accessor = (*EtcdMember)(nil)
+
+// EtcdMember tracks etcd cluster members.
+type EtcdMember struct {
+	NonResource
+}
+
+// List returns the etcd members of the active context, or of each selected
+// context when more than one is selected.
+func (e *EtcdMember) List(ctx context.Context, _ string) ([]runtime.Object, error) {
+	f := e.getFactory()
+	rawCfg, err := f.Client().Config().RawConfig()
+	if err != nil {
+		return nil, err
+	}
+	ctxs := []string{f.Client().ActiveContext()}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		ctxs = sel
+	}
+
+	var (
+		oo   []runtime.Object
+		errs []error
+	)
+	for _, c := range ctxs {
+		mm, err := EtcdMembers(ctx, rawCfg, c)
+		if err != nil {
+			if len(ctxs) == 1 {
+				return nil, err
+			}
+			slogs.CtxLog(c).Warn("Etcd members lookup failed", slogs.Error, err)
+			errs = append(errs, err)
+			continue
+		}
+		for _, m := range mm {
+			oo = append(oo, m)
+		}
+	}
+	if len(oo) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return oo, nil
+}
+
+// EtcdMembers lists the etcd members of a context from an etcd static pod.
+func EtcdMembers(ctx context.Context, rawCfg api.Config, ctxName string) ([]*render.EtcdMemberRes, error) {
+	pod, err := etcdPod(ctx, rawCfg, ctxName)
+	if err != nil {
+		return nil, err
+	}
+	members, err := Etcdctl(ctx, rawCfg, ctxName, pod, "member", "list", "-w", "json")
+	if err != nil {
+		return nil, err
+	}
+	status, err := Etcdctl(ctx, rawCfg, ctxName, pod, "endpoint", "status", "--cluster", "-w", "json")
+	if err != nil {
+		slogs.CtxLog(ctxName).Warn("Etcd endpoint status failed", slogs.Error, err)
+		status = ""
+	}
+
+	return ParseEtcdMembers(ctxName, pod, []byte(members), []byte(status))
+}
+
+// EtcdMoveLeader transfers the etcd leadership to the given member.
+func EtcdMoveLeader(ctx context.Context, rawCfg api.Config, ctxName, pod, id string) error {
+	members, err := Etcdctl(ctx, rawCfg, ctxName, pod, "member", "list", "-w", "json")
+	if err != nil {
+		return err
+	}
+	var ml etcdMemberList
+	if err := json.Unmarshal([]byte(members), &ml); err != nil {
+		return fmt.Errorf("invalid etcdctl member list output: %w", err)
+	}
+	var eps []string
+	for _, m := range ml.Members {
+		eps = append(eps, m.ClientURLs...)
+	}
+	// move-leader must reach the current leader, so target all members.
+	_, err = Etcdctl(ctx, rawCfg, ctxName, pod, "--endpoints", strings.Join(eps, ","), "move-leader", id)
+
+	return err
+}
+
+// EtcdRemoveMember removes a member from the etcd cluster.
+func EtcdRemoveMember(ctx context.Context, rawCfg api.Config, ctxName, pod, id string) error {
+	_, err := Etcdctl(ctx, rawCfg, ctxName, pod, "member", "remove", id)
+
+	return err
+}
+
+// Etcdctl runs etcdctl in an etcd pod, ie `kube-system/etcd-cp1`.
+func Etcdctl(ctx context.Context, rawCfg api.Config, ctxName, pod string, args ...string) (string, error) {
+	ns, n, ok := strings.Cut(pod, "/")
+	if !ok {
+		ns, n = etcdNamespace, pod
+	}
+	cmd := append([]string{"sh", "-c", etcdctlScript, "etcdctl"}, args...)
+
+	return execInPod(ctx, rawCfg, ctxName, ns, n, "", cmd)
+}
+
+func etcdPod(ctx context.Context, rawCfg api.Config, ctxName string) (string, error) {
+	kc, err := kubeClientFor(rawCfg, ctxName)
+	if err != nil {
+		return "", err
+	}
+	pp, err := kc.CoreV1().Pods(etcdNamespace).List(ctx, metav1.ListOptions{LabelSelector: etcdSelector})
+	if err != nil {
+		return "", err
+	}
+	if len(pp.Items) == 0 {
+		pp, err = kc.CoreV1().Pods(etcdNamespace).List(ctx, metav1.ListOptions{LabelSelector: "tier=control-plane"})
+		if err != nil {
+			return "", err
+		}
+	}
+	for i := range pp.Items {
+		po := &pp.Items[i]
+		if po.Status.Phase == v1.PodRunning && strings.Contains(po.Name, "etcd") {
+			return etcdNamespace + "/" + po.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("no running etcd pod found in context %q (embedded K3s or external etcd?)", ctxName)
+}
+
+func execInPod(ctx context.Context, rawCfg api.Config, ctxName, ns, pod, co string, cmd []string) (string, error) {
+	restCfg, err := restConfigFor(rawCfg, ctxName)
+	if err != nil {
+		return "", err
+	}
+	kc, err := kubeClientFor(rawCfg, ctxName)
+	if err != nil {
+		return "", err
+	}
+	req := kc.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(ns).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: co,
+			Command:   cmd,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	exec, err := remotecommand.NewSPDYExecutor(restCfg, "POST", req.URL())
+	if err != nil {
+		return "", err
+	}
+	var stdout, stderr bytes.Buffer
+	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr})
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %s", pod, msg)
+		}
+		return "", fmt.Errorf("%s: %w", pod, err)
+	}
+
+	return stdout.String(), nil
+}
+
+type etcdMemberList struct {
+	Members []struct {
+		ID         uint64   `json:"ID"`
+		Name       string   `json:"name"`
+		PeerURLs   []string `json:"peerURLs"`
+		ClientURLs []string `json:"clientURLs"`
+		IsLearner  bool     `json:"isLearner"`
+	} `json:"members"`
+}
+
+type etcdEndpointStatus struct {
+	Endpoint string `json:"Endpoint"`
+	Status   struct {
+		Header struct {
+			MemberID uint64 `json:"member_id"`
+		} `json:"header"`
+		Version     string   `json:"version"`
+		DBSize      int64    `json:"dbSize"`
+		DBSizeInUse int64    `json:"dbSizeInUse"`
+		Leader      uint64   `json:"leader"`
+		RaftIndex   uint64   `json:"raftIndex"`
+		RaftTerm    uint64   `json:"raftTerm"`
+		Errors      []string `json:"errors"`
+	} `json:"Status"`
+}
+
+// ParseEtcdMembers merges etcdctl `member list -w json` and
+// `endpoint status -w json` outputs into member rows. The status is optional.
+func ParseEtcdMembers(ctxName, pod string, members, status []byte) ([]*render.EtcdMemberRes, error) {
+	var ml etcdMemberList
+	if err := json.Unmarshal(members, &ml); err != nil {
+		return nil, fmt.Errorf("invalid etcdctl member list output: %w", err)
+	}
+	var ss []etcdEndpointStatus
+	if len(bytes.TrimSpace(status)) > 0 {
+		if err := json.Unmarshal(status, &ss); err != nil {
+			return nil, fmt.Errorf("invalid etcdctl endpoint status output: %w", err)
+		}
+	}
+	byID := make(map[uint64]etcdEndpointStatus, len(ss))
+	for _, s := range ss {
+		byID[s.Status.Header.MemberID] = s
+	}
+
+	rr := make([]*render.EtcdMemberRes, 0, len(ml.Members))
+	for _, m := range ml.Members {
+		r := render.EtcdMemberRes{
+			Context: ctxName,
+			Pod:     pod,
+			ID:      fmt.Sprintf("%x", m.ID),
+			Name:    m.Name,
+			Learner: m.IsLearner,
+		}
+		if len(m.PeerURLs) > 0 {
+			r.PeerURL = m.PeerURLs[0]
+		}
+		if len(m.ClientURLs) > 0 {
+			r.ClientURL = m.ClientURLs[0]
+		}
+		if s, ok := byID[m.ID]; ok {
+			r.Reachable = true
+			r.Leader = s.Status.Leader == m.ID
+			r.Version = s.Status.Version
+			r.DBSize, r.DBSizeInUse = s.Status.DBSize, s.Status.DBSizeInUse
+			r.RaftTerm, r.RaftIndex = s.Status.RaftTerm, s.Status.RaftIndex
+			r.Errors = s.Status.Errors
+		}
+		rr = append(rr, &r)
+	}
+
+	return rr, nil
+}
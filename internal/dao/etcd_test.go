@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	etcdMembersJSON = `{"header":{"cluster_id":14841639068965178418,"member_id":10276657743932975437,"raft_term":2},"members":[` +
+		`{"ID":10276657743932975437,"name":"cp1","peerURLs":["https://10.0.0.1:2380"],"clientURLs":["https://10.0.0.1:2379"]},` +
+		`{"ID":2,"name":"cp2","peerURLs":["https://10.0.0.2:2380"],"clientURLs":["https://10.0.0.2:2379"]},` +
+		`{"ID":3,"peerURLs":["https://10.0.0.3:2380"],"isLearner":true}]}`
+	etcdStatusJSON = `[` +
+		`{"Endpoint":"https://10.0.0.1:2379","Status":{"header":{"member_id":10276657743932975437},"version":"3.5.9","dbSize":20480,"leader":10276657743932975437,"raftIndex":4,"raftTerm":2,"dbSizeInUse":16384}},` +
+		`{"Endpoint":"https://10.0.0.2:2379","Status":{"header":{"member_id":2},"version":"3.5.9","dbSize":20480,"leader":10276657743932975437,"raftIndex":4,"raftTerm":2,"errors":["NOSPACE"]}}]`
+)
+
+func TestParseEtcdMembers(t *testing.T) {
+	mm, err := dao.ParseEtcdMembers("ct1", "kube-system/etcd-cp1", []byte(etcdMembersJSON), []byte(etcdStatusJSON))
+	require.NoError(t, err)
+	require.Len(t, mm, 3)
+
+	assert.Equal(t, "8e9e05c52164694d", mm[0].ID)
+	assert.Equal(t, "cp1", mm[0].Name)
+	assert.Equal(t, "https://10.0.0.1:2379", mm[0].ClientURL)
+	assert.Equal(t, "3.5.9", mm[0].Version)
+	assert.True(t, mm[0].Leader)
+	assert.True(t, mm[0].Reachable)
+	assert.Equal(t, int64(20480), mm[0].DBSize)
+	assert.Equal(t, uint64(2), mm[0].RaftTerm)
+
+	assert.Equal(t, "2", mm[1].ID)
+	assert.False(t, mm[1].Leader)
+	assert.Equal(t, []string{"NOSPACE"}, mm[1].Errors)
+
+	assert.Equal(t, "3", mm[2].ID)
+	assert.True(t, mm[2].Learner)
+	assert.False(t, mm[2].Reachable)
+	assert.Empty(t, mm[2].ClientURL)
+	assert.Equal(t, "ct1|kube-system/etcd-cp1|3|", mm[2].RowID())
+}
+
+func TestParseEtcdMembersNoStatus(t *testing.T) {
+	mm, err := dao.ParseEtcdMembers("ct1", "kube-system/etcd-cp1", []byte(etcdMembersJSON), nil)
+	require.NoError(t, err)
+	require.Len(t, mm, 3)
+	for _, m := range mm {
+		assert.False(t, m.Reachable)
+		assert.False(t, m.Leader)
+	}
+}
+
+func TestParseEtcdMembersInvalid(t *testing.T) {
+	_, err := dao.ParseEtcdMembers("ct1", "p", []byte("+------+"), nil)
+	assert.Error(t, err)
+
+	_, err = dao.ParseEtcdMembers("ct1", "p", []byte(etcdMembersJSON), []byte("{"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// metaFields tracks the field selectors supported by all resources.
+var metaFields = sets.New("metadata.name", "metadata.namespace")
+
+// informerFields tracks the resource specific field selectors informer
+// listings filter on their end, mirroring the API server ones.
+var informerFields = map[*client.GVR]sets.Set[string]{
+	client.PodGVR: sets.New(
+		"spec.nodeName",
+		"spec.restartPolicy",
+		"spec.schedulerName",
+		"spec.serviceAccountName",
+		"spec.hostNetwork",
+		"status.phase",
+		"status.podIP",
+		"status.nominatedNodeName",
+	),
+	client.NodeGVR: sets.New("spec.unschedulable"),
+	client.NsGVR:   sets.New("status.phase"),
+	client.SecGVR:  sets.New("type"),
+}
+
+// informerSelector parses a field selector informer listings can filter on
+// their end. It returns false when the selector needs the API server, ie an
+// invalid selector or fields only the API server knows about.
+func informerSelector(gvr *client.GVR, sel string) (fields.Selector, bool) {
+	fsel, err := fields.ParseSelector(sel)
+	if err != nil {
+		return nil, false
+	}
+	for _, r := range fsel.Requirements() {
+		if !metaFields.Has(r.Field) && !informerFields[gvr].Has(r.Field) {
+			return nil, false
+		}
+	}
+
+	return fsel, true
+}
+
+// filterFields keeps the resources matching a field selector. Missing fields
+// match as blank, like on the API server.
+func filterFields(oo []runtime.Object, sel fields.Selector) []runtime.Object {
+	res := make([]runtime.Object, 0, len(oo))
+	for _, o := range oo {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		ff := make(fields.Set, len(sel.Requirements()))
+		for _, r := range sel.Requirements() {
+			v, ok, _ := unstructured.NestedFieldNoCopy(u.Object, strings.Split(r.Field, ".")...)
+			if ok && v != nil {
+				ff[r.Field] = fmt.Sprint(v)
+				continue
+			}
+			ff[r.Field] = ""
+		}
+		if sel.Matches(ff) {
+			res = append(res, o)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestInformerSelector(t *testing.T) {
+	uu := map[string]struct {
+		gvr *client.GVR
+		sel string
+		ok  bool
+	}{
+		"node-name": {
+			gvr: client.PodGVR,
+			sel: "spec.nodeName=n1",
+			ok:  true,
+		},
+		"phase-and-name": {
+			gvr: client.PodGVR,
+			sel: "status.phase!=Succeeded,metadata.name=p1",
+			ok:  true,
+		},
+		"meta": {
+			gvr: client.DpGVR,
+			sel: "metadata.namespace=ns1",
+			ok:  true,
+		},
+		"unknown-field": {
+			gvr: client.DpGVR,
+			sel: "spec.replicas=1",
+		},
+		"other-resource": {
+			gvr: client.NodeGVR,
+			sel: "spec.nodeName=n1",
+		},
+		"invalid": {
+			gvr: client.PodGVR,
+			sel: "spec.nodeName",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			_, ok := informerSelector(u.gvr, u.sel)
+			assert.Equal(t, u.ok, ok)
+		})
+	}
+}
+
+func TestFilterFields(t *testing.T) {
+	oo := []runtime.Object{
+		makeFieldPod("p1", "n1", "Running", false),
+		makeFieldPod("p2", "n2", "Succeeded", false),
+		makeFieldPod("p3", "", "Pending", true),
+	}
+	uu := map[string]struct {
+		sel string
+		e   []string
+	}{
+		"node-name": {
+			sel: "spec.nodeName=n1",
+			e:   []string{"p1"},
+		},
+		"unscheduled": {
+			sel: "spec.nodeName=",
+			e:   []string{"p3"},
+		},
+		"phase": {
+			sel: "status.phase!=Succeeded",
+			e:   []string{"p1", "p3"},
+		},
+		"bool": {
+			sel: "spec.hostNetwork=true",
+			e:   []string{"p3"},
+		},
+		"and": {
+			sel: "status.phase!=Succeeded,metadata.name!=p1",
+			e:   []string{"p3"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			fsel, ok := informerSelector(client.PodGVR, u.sel)
+			require.True(t, ok)
+			nn := make([]string, 0, len(u.e))
+			for _, o := range filterFields(oo, fsel) {
+				nn = append(nn, o.(*unstructured.Unstructured).GetName())
+			}
+			assert.Equal(t, u.e, nn)
+		})
+	}
+}
+
+// Helpers...
+
+func makeFieldPod(name, node, phase string, hostNetwork bool) *unstructured.Unstructured {
+	spec := map[string]any{"hostNetwork": hostNetwork}
+	if node != "" {
+		spec["nodeName"] = node
+	}
+
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]any{"name": name, "namespace": "ns1"},
+		"spec":       spec,
+		"status":     map[string]any{"phase": phase},
+	}}
+}
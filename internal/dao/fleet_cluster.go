@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/render"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const fleetAgentBundlePrefix = "fleet-agent-"
+
+var (
+	_ Accessor = (*FleetCluster)(nil)
+
+	fleetAgentImageRX = regexp.MustCompile(`image:\s*["']?\S*fleet-agent:([\w.\-+]+)`)
+)
+
+// FleetCluster represents a Fleet downstream cluster registration.
+type FleetCluster struct {
+	Resource
+}
+
+// List returns a collection of fleet clusters along with their agent versions.
+func (f *FleetCluster) List(ctx context.Context, ns string) ([]runtime.Object, error) {
+	oo, err := f.Resource.List(ctx, ns)
+	if err != nil {
+		return oo, err
+	}
+	vv := f.agentVersions(ns)
+
+	res := make([]runtime.Object, 0, len(oo))
+	for _, o := range oo {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			return res, fmt.Errorf("expecting *unstructured.Unstructured but got `%T", o)
+		}
+		res = append(res, &render.FleetClusterWithAgent{
+			Raw:          u,
+			AgentVersion: vv[client.FQN(u.GetNamespace(), fleetAgentBundlePrefix+u.GetName())],
+		})
+	}
+
+	return res, nil
+}
+
+// agentVersions extracts the fleet-agent image tags from the agent bundles
+// fleet manages for each registered cluster.
+func (f *FleetCluster) agentVersions(ns string) map[string]string {
+	oo, err := f.getFactory().List(client.FleetBundleGVR, ns, false, labels.Everything())
+	if err != nil {
+		return nil
+	}
+
+	vv := make(map[string]string, len(oo))
+	for _, o := range oo {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		if v := FleetAgentVersion(u); v != "" {
+			vv[client.FQN(u.GetNamespace(), u.GetName())] = v
+		}
+	}
+
+	return vv
+}
+
+// FleetAgentVersion returns the fleet-agent image tag deployed by an agent bundle.
+func FleetAgentVersion(bundle *unstructured.Unstructured) string {
+	rr, _, _ := unstructured.NestedSlice(bundle.Object, "spec", "resources")
+	for _, r := range rr {
+		m, ok := r.(map[string]any)
+		if !ok {
+			continue
+		}
+		if enc, _ := m["encoding"].(string); enc != "" {
+			continue
+		}
+		content, _ := m["content"].(string)
+		if mm := fleetAgentImageRX.FindStringSubmatch(content); len(mm) == 2 {
+			return mm[1]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestFleetAgentVersion(t *testing.T) {
+	uu := map[string]struct {
+		rr []any
+		e  string
+	}{
+		"none": {},
+		"plain": {
+			rr: []any{
+				map[string]any{"name": "agent.yaml", "content": "kind: Deployment\nspec:\n  containers:\n  - image: rancher/fleet-agent:v0.12.2\n"},
+			},
+			e: "v0.12.2",
+		},
+		"quoted": {
+			rr: []any{
+				map[string]any{"content": `image: "registry.io/rancher/fleet-agent:v0.11.0-rc.1"`},
+			},
+			e: "v0.11.0-rc.1",
+		},
+		"encoded": {
+			rr: []any{
+				map[string]any{"encoding": "base64+gz", "content": "image: rancher/fleet-agent:v1"},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			o := unstructured.Unstructured{Object: map[string]any{
+				"spec": map[string]any{"resources": u.rr},
+			}}
+			assert.Equal(t, u.e, dao.FleetAgentVersion(&o))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/model1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GitRepoAction represents a Fleet GitRepo bulk action.
+type GitRepoAction string
+
+// Fleet GitRepo bulk actions.
+const (
+	GitRepoPause       GitRepoAction = "pause"
+	GitRepoResume      GitRepoAction = "resume"
+	GitRepoForceUpdate GitRepoAction = "force-update"
+)
+
+// GitRepoResult represents the outcome of a bulk action on a GitRepo.
+type GitRepoResult struct {
+	Path string
+	Err  error
+}
+
+// GitRepoPatch returns the merge patch applying an action to a GitRepo.
+// Force updates bump the GitRepo force sync generation.
+func GitRepoPatch(action GitRepoAction, o *unstructured.Unstructured) ([]byte, error) {
+	var spec map[string]any
+	switch action {
+	case GitRepoPause:
+		spec = map[string]any{"paused": true}
+	case GitRepoResume:
+		spec = map[string]any{"paused": false}
+	case GitRepoForceUpdate:
+		gen, _, _ := unstructured.NestedInt64(o.Object, "spec", "forceSyncGeneration")
+		spec = map[string]any{"forceSyncGeneration": gen + 1}
+	default:
+		return nil, fmt.Errorf("unsupported gitrepo action %q", action)
+	}
+
+	return json.Marshal(map[string]any{"spec": spec})
+}
+
+// ApplyGitRepoAction applies an action to GitRepos in turn, reporting each
+// outcome as it completes.
+func ApplyGitRepoAction(ctx context.Context, c client.Connection, action GitRepoAction, paths []string, report func(GitRepoResult)) []GitRepoResult {
+	rr := make([]GitRepoResult, 0, len(paths))
+	for _, p := range paths {
+		r := GitRepoResult{Path: p, Err: applyGitRepoAction(ctx, c, action, p)}
+		rr = append(rr, r)
+		if report != nil {
+			report(r)
+		}
+	}
+
+	return rr
+}
+
+func applyGitRepoAction(ctx context.Context, c client.Connection, action GitRepoAction, path string) error {
+	if ctxName, _ := model1.SplitMultiContextID(path); ctxName != "" {
+		return errors.New("gitrepo actions are only available on the active context")
+	}
+	dyn, err := c.DynDial()
+	if err != nil {
+		return err
+	}
+	ns, n := client.Namespaced(path)
+	res := dyn.Resource(client.FleetGitRepoGVR.GVR()).Namespace(ns)
+	o, err := res.Get(ctx, n, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	patch, err := GitRepoPatch(action, o)
+	if err != nil {
+		return err
+	}
+	_, err = res.Patch(ctx, n, types.MergePatchType, patch, metav1.PatchOptions{})
+
+	return err
+}
+
+// GitRepoSummary tallies the outcomes of a bulk action.
+func GitRepoSummary(rr []GitRepoResult) (ok, failed int) {
+	for _, r := range rr {
+		if r.Err != nil {
+			failed++
+			continue
+		}
+		ok++
+	}
+
+	return
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGitRepoPatch(t *testing.T) {
+	uu := map[string]struct {
+		action dao.GitRepoAction
+		spec   map[string]any
+		e      string
+		err    string
+	}{
+		"pause": {
+			action: dao.GitRepoPause,
+			e:      `{"spec":{"paused":true}}`,
+		},
+		"resume": {
+			action: dao.GitRepoResume,
+			spec:   map[string]any{"paused": true},
+			e:      `{"spec":{"paused":false}}`,
+		},
+		"force-first": {
+			action: dao.GitRepoForceUpdate,
+			e:      `{"spec":{"forceSyncGeneration":1}}`,
+		},
+		"force-bump": {
+			action: dao.GitRepoForceUpdate,
+			spec:   map[string]any{"forceSyncGeneration": int64(4)},
+			e:      `{"spec":{"forceSyncGeneration":5}}`,
+		},
+		"unknown": {
+			action: "blee",
+			err:    `unsupported gitrepo action "blee"`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			o := unstructured.Unstructured{Object: map[string]any{}}
+			if u.spec != nil {
+				o.Object["spec"] = u.spec
+			}
+			bb, err := dao.GitRepoPatch(u.action, &o)
+			if u.err != "" {
+				require.EqualError(t, err, u.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, u.e, string(bb))
+		})
+	}
+}
+
+func TestGitRepoSummary(t *testing.T) {
+	ok, failed := dao.GitRepoSummary([]dao.GitRepoResult{
+		{Path: "fleet-default/r1"},
+		{Path: "fleet-default/r2", Err: errors.New("boom")},
+		{Path: "fleet-local/r3"},
+	})
+
+	assert.Equal(t, 2, ok)
+	assert.Equal(t, 1, failed)
+}
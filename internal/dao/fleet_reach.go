@@ -0,0 +1,347 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// FleetHeartbeatTolerance tracks how old a Fleet agent check-in may get
+// before the management cluster is considered blind to it. Agents check in
+// every 15m by default.
+const FleetHeartbeatTolerance = 30 * time.Minute
+
+// Fleet agent reachability verdicts.
+const (
+	ReachOK           = "OK"
+	ReachAgentSide    = "AgentSide"
+	ReachMgmtSide     = "ManagementSide"
+	ReachNoAgent      = "NoAgent"
+	ReachUnregistered = "Unregistered"
+	ReachUnknown      = "Unknown"
+)
+
+const (
+	cattleAgentNamespace = "cattle-system"
+	cattleAgentSelector  = "app=cattle-cluster-agent"
+	cattleServerEnv      = "CATTLE_SERVER"
+	rancherClusterLabel  = "management.cattle.io/cluster-name"
+	rancherDisplayLabel  = "management.cattle.io/cluster-display-name"
+)
+
+// agentProbeScript checks the management URL from the agent pod with curl,
+// falling back to wget, and prints the HTTP status code.
+const agentProbeScript = `if command -v curl >/dev/null 2>&1; then
+  curl -sk -o /dev/null -w '%{http_code}' --max-time 10 "$1/ping"
+else
+  wget -q -T 10 --no-check-certificate -O /dev/null "$1/ping" && echo 200
+fi`
+
+var rancherProxyRX = regexp.MustCompile(`/k8s/clusters/([\w\-]+)`)
+
+// FleetHeartbeat represents what the management cluster knows about a
+// downstream cluster.
+type FleetHeartbeat struct {
+	Cluster   string
+	Display   string
+	LastSeen  time.Time
+	Connected *bool
+}
+
+// AgentProbe represents a management URL check run from a downstream agent pod.
+type AgentProbe struct {
+	Context string
+	Cluster string
+	Server  string
+	Pod     string
+	Probed  bool
+	Code    string
+	Err     error
+}
+
+// Reached checks if the agent got an answer from the management URL.
+func (p AgentProbe) Reached() bool {
+	return p.Probed && p.Err == nil && p.Code == "200"
+}
+
+// FleetReach represents the registration health of a downstream cluster seen
+// from both ends.
+type FleetReach struct {
+	Context   string
+	Cluster   string
+	Agent     string
+	Heartbeat string
+	Verdict   string
+	Hint      string
+}
+
+// FetchFleetHeartbeats returns the downstream clusters known to a management
+// context along with their last agent check-in and tunnel state.
+func FetchFleetHeartbeats(ctx context.Context, rawCfg api.Config, ctxName string) ([]FleetHeartbeat, error) {
+	dial, err := dynClientFor(rawCfg, ctxName)
+	if err != nil {
+		return nil, err
+	}
+	hh := make(map[string]*FleetHeartbeat)
+	get := func(id string) *FleetHeartbeat {
+		h, ok := hh[id]
+		if !ok {
+			h = &FleetHeartbeat{Cluster: id, Display: id}
+			hh[id] = h
+		}
+		return h
+	}
+
+	rl, rerr := dial.Resource(client.RancherClusterGVR.GVR()).List(ctx, metav1.ListOptions{})
+	if rerr == nil {
+		for i := range rl.Items {
+			u := &rl.Items[i]
+			h := get(u.GetName())
+			if d, _, _ := unstructured.NestedString(u.Object, "spec", "displayName"); d != "" {
+				h.Display = d
+			}
+			h.Connected = rancherConnected(u)
+		}
+	}
+	fl, ferr := dial.Resource(client.FleetClusterGVR.GVR()).Namespace(client.BlankNamespace).List(ctx, metav1.ListOptions{})
+	if ferr == nil {
+		for i := range fl.Items {
+			u := &fl.Items[i]
+			id := u.GetLabels()[rancherClusterLabel]
+			if id == "" {
+				id = u.GetName()
+			}
+			h := get(id)
+			if d := u.GetLabels()[rancherDisplayLabel]; d != "" && h.Display == id {
+				h.Display = d
+			}
+			if s, _, _ := unstructured.NestedString(u.Object, "status", "agent", "lastSeen"); s != "" {
+				if t, err := time.Parse(time.RFC3339, s); err == nil {
+					h.LastSeen = t
+				}
+			}
+		}
+	}
+	if rerr != nil && ferr != nil {
+		return nil, fmt.Errorf("context %q is not a Rancher or Fleet management cluster: %w", ctxName, ferr)
+	}
+
+	out := make([]FleetHeartbeat, 0, len(hh))
+	for _, h := range hh {
+		out = append(out, *h)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Display < out[j].Display
+	})
+
+	return out, nil
+}
+
+func rancherConnected(u *unstructured.Unstructured) *bool {
+	cc, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range cc {
+		m, ok := c.(map[string]any)
+		if !ok || m["type"] != "Connected" {
+			continue
+		}
+		b := m["status"] == string(metav1.ConditionTrue)
+		return &b
+	}
+
+	return nil
+}
+
+// ProbeAgent locates the Rancher cluster agent of a downstream context and,
+// when exec is allowed, checks it can reach the management URL.
+func ProbeAgent(ctx context.Context, rawCfg api.Config, ctxName string, exec bool) AgentProbe {
+	p := AgentProbe{Context: ctxName, Cluster: proxiedCluster(rawCfg, ctxName)}
+	kc, err := kubeClientFor(rawCfg, ctxName)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	pp, err := kc.CoreV1().Pods(cattleAgentNamespace).List(ctx, metav1.ListOptions{LabelSelector: cattleAgentSelector})
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	po := runningPod(pp.Items)
+	if po == nil {
+		return p
+	}
+	p.Pod, p.Server = client.FQN(po.Namespace, po.Name), podEnv(po, cattleServerEnv)
+	if !exec || p.Server == "" {
+		return p
+	}
+	out, err := execInPod(ctx, rawCfg, ctxName, po.Namespace, po.Name, "", []string{"sh", "-c", agentProbeScript, "probe", p.Server})
+	p.Probed, p.Code, p.Err = true, strings.TrimSpace(out), err
+
+	return p
+}
+
+// FleetReachability correlates the agent probes of the downstream contexts
+// with the management heartbeats to tell which side of a registration breaks.
+func FleetReachability(hh []FleetHeartbeat, pp []AgentProbe, now time.Time) []FleetReach {
+	byID := make(map[string]FleetHeartbeat, len(hh))
+	for _, h := range hh {
+		byID[h.Cluster] = h
+	}
+	seen := make(map[string]struct{}, len(pp))
+	rr := make([]FleetReach, 0, len(hh)+len(pp))
+	for _, p := range pp {
+		h, ok := matchHeartbeat(byID, hh, p)
+		r := FleetReach{Context: p.Context, Agent: p.status(), Heartbeat: client.NA}
+		if ok {
+			seen[h.Cluster] = struct{}{}
+			r.Cluster, r.Heartbeat = h.Display, h.age(now)
+		}
+		r.Verdict, r.Hint = reachVerdict(p, h, ok, now)
+		rr = append(rr, r)
+	}
+	for _, h := range hh {
+		if _, ok := seen[h.Cluster]; ok {
+			continue
+		}
+		r := FleetReach{Cluster: h.Display, Agent: client.NA, Heartbeat: h.age(now), Verdict: ReachUnknown}
+		if !h.fresh(now) {
+			r.Hint = "no recent heartbeat, select the downstream context to probe its agent"
+		}
+		rr = append(rr, r)
+	}
+
+	return rr
+}
+
+func reachVerdict(p AgentProbe, h FleetHeartbeat, registered bool, now time.Time) (string, string) {
+	switch {
+	case !registered:
+		return ReachUnregistered, "no matching cluster on the management context"
+	case p.Pod == "" && p.Err != nil:
+		return ReachUnknown, "unable to inspect the downstream agent"
+	case p.Pod == "":
+		return ReachNoAgent, "cattle-cluster-agent is not running, check its deployment and events"
+	case p.Probed && !p.Reached():
+		return ReachAgentSide, "agent cannot reach " + p.Server + ", check egress NetworkPolicies, proxies, DNS and firewalls"
+	case !h.fresh(now):
+		if p.Reached() {
+			return ReachMgmtSide, "agent reaches the management URL but no recent heartbeat, check the ingress, the tunnel and the fleet controller"
+		}
+		return ReachUnknown, "no recent heartbeat, agent probe unavailable"
+	case p.Reached() || !p.Probed:
+		return ReachOK, ""
+	default:
+		return ReachUnknown, ""
+	}
+}
+
+func matchHeartbeat(byID map[string]FleetHeartbeat, hh []FleetHeartbeat, p AgentProbe) (FleetHeartbeat, bool) {
+	if h, ok := byID[p.Cluster]; ok && p.Cluster != "" {
+		return h, true
+	}
+	for _, h := range hh {
+		if h.Display == p.Context || h.Cluster == p.Context {
+			return h, true
+		}
+	}
+
+	return FleetHeartbeat{}, false
+}
+
+func (p AgentProbe) status() string {
+	switch {
+	case p.Pod == "" && p.Err != nil:
+		return "error: " + p.Err.Error()
+	case p.Pod == "":
+		return "no agent"
+	case !p.Probed:
+		return "skipped"
+	case p.Err != nil:
+		return "unreachable: " + p.Err.Error()
+	case p.Code != "200":
+		return "unreachable: http " + p.Code
+	default:
+		return "reached"
+	}
+}
+
+func (h FleetHeartbeat) fresh(now time.Time) bool {
+	if h.Connected != nil && !*h.Connected {
+		return false
+	}
+	if h.LastSeen.IsZero() {
+		return h.Connected != nil
+	}
+
+	return now.Sub(h.LastSeen) <= FleetHeartbeatTolerance
+}
+
+func (h FleetHeartbeat) age(now time.Time) string {
+	var ss []string
+	if !h.LastSeen.IsZero() {
+		ss = append(ss, now.Sub(h.LastSeen).Round(time.Second).String())
+	}
+	if h.Connected != nil {
+		if *h.Connected {
+			ss = append(ss, "connected")
+		} else {
+			ss = append(ss, "disconnected")
+		}
+	}
+	if len(ss) == 0 {
+		return client.NA
+	}
+
+	return strings.Join(ss, ", ")
+}
+
+// proxiedCluster returns the Rancher cluster ID of a context served through
+// the Rancher proxy, ie `https://rancher/k8s/clusters/c-abc12`.
+func proxiedCluster(rawCfg api.Config, ctxName string) string {
+	ct, ok := rawCfg.Contexts[ctxName]
+	if !ok {
+		return ""
+	}
+	cl, ok := rawCfg.Clusters[ct.Cluster]
+	if !ok {
+		return ""
+	}
+	if mm := rancherProxyRX.FindStringSubmatch(cl.Server); len(mm) == 2 {
+		return mm[1]
+	}
+
+	return ""
+}
+
+func runningPod(pp []v1.Pod) *v1.Pod {
+	for i := range pp {
+		if pp[i].Status.Phase == v1.PodRunning && pp[i].DeletionTimestamp == nil {
+			return &pp[i]
+		}
+	}
+
+	return nil
+}
+
+func podEnv(po *v1.Pod, name string) string {
+	for _, co := range po.Spec.Containers {
+		for _, e := range co.Env {
+			if e.Name == name {
+				return e.Value
+			}
+		}
+	}
+
+	return ""
+}
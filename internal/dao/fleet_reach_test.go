@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFleetReachability(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	yes, no := true, false
+	hh := []dao.FleetHeartbeat{
+		{Cluster: "c-ok", Display: "prod", LastSeen: now.Add(-5 * time.Minute), Connected: &yes},
+		{Cluster: "c-stale", Display: "edge", LastSeen: now.Add(-2 * time.Hour)},
+		{Cluster: "c-blocked", Display: "dmz", LastSeen: now.Add(-3 * time.Hour), Connected: &no},
+		{Cluster: "c-gone", Display: "lab", LastSeen: now.Add(-time.Hour)},
+		{Cluster: "c-noagent", Display: "dev", Connected: &yes},
+	}
+	pp := []dao.AgentProbe{
+		{Context: "prod", Pod: "cattle-system/agent-1", Server: "https://rancher", Probed: true, Code: "200"},
+		{Context: "edge-ctx", Cluster: "c-stale", Pod: "cattle-system/agent-2", Server: "https://rancher", Probed: true, Code: "200"},
+		{Context: "dmz", Pod: "cattle-system/agent-3", Server: "https://rancher", Probed: true, Code: "000", Err: errors.New("exit 7")},
+		{Context: "dev"},
+		{Context: "stray", Pod: "cattle-system/agent-4"},
+	}
+
+	rr := dao.FleetReachability(hh, pp, now)
+	require.Len(t, rr, 6)
+
+	uu := []struct {
+		ctx, cluster, agent, verdict string
+	}{
+		{"prod", "prod", "reached", dao.ReachOK},
+		{"edge-ctx", "edge", "reached", dao.ReachMgmtSide},
+		{"dmz", "dmz", "unreachable: exit 7", dao.ReachAgentSide},
+		{"dev", "dev", "no agent", dao.ReachNoAgent},
+		{"stray", "", "skipped", dao.ReachUnregistered},
+		{"", "lab", "n/a", dao.ReachUnknown},
+	}
+	for i, u := range uu {
+		assert.Equal(t, u.ctx, rr[i].Context, i)
+		assert.Equal(t, u.cluster, rr[i].Cluster, i)
+		assert.Equal(t, u.agent, rr[i].Agent, i)
+		assert.Equal(t, u.verdict, rr[i].Verdict, i)
+	}
+	assert.Equal(t, "5m0s, connected", rr[0].Heartbeat)
+	assert.Empty(t, rr[0].Hint)
+	assert.NotEmpty(t, rr[5].Hint)
+}
@@ -52,7 +52,11 @@ func (g *Generic) List(ctx context.Context, ns string) ([]runtime.Object, error)
 		return nil, err
 	}
 
-	opts := metav1.ListOptions{LabelSelector: labelSel.String()}
+	fieldSel, _ := ctx.Value(internal.KeyFields).(string)
+	opts := metav1.ListOptions{
+		LabelSelector: labelSel.String(),
+		FieldSelector: fieldSel,
+	}
 	var ll *unstructured.UnstructuredList
 	if client.IsClusterScoped(ns) {
 		ll, err = dial.List(ctx, opts)
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	discoveryTimeout = 10 * time.Second
+	discoveryTTL     = 5 * time.Minute
+)
+
+// ServedVersions tracks the served versions of resources by `resource.group`
+// name, the preferred version first. Core resources are keyed by name.
+type ServedVersions map[string][]string
+
+// ContextServedVersions represents the resources served by a context.
+type ContextServedVersions struct {
+	Context string
+	Served  ServedVersions
+	Err     error
+}
+
+// FetchServedVersions discovers the resources served by each context in
+// parallel. Unreachable contexts are reported with their error.
+func FetchServedVersions(rawCfg api.Config, ctxs []string) []ContextServedVersions {
+	out := make([]ContextServedVersions, len(ctxs))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, mcParallelism())
+	for i, ctxName := range ctxs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, ctxName string) {
+			defer func() { <-sem; wg.Done() }()
+			out[i] = ContextServedVersions{Context: ctxName}
+			dc, err := discoveryFor(rawCfg, ctxName)
+			if err != nil {
+				out[i].Err = err
+				return
+			}
+			// Partial results are returned when some groups fail discovery.
+			gg, rr, err := dc.ServerGroupsAndResources()
+			if len(gg) == 0 && err != nil {
+				out[i].Err = err
+				return
+			}
+			out[i].Served = NewServedVersions(gg, rr)
+		}(i, ctxName)
+	}
+	wg.Wait()
+
+	return out
+}
+
+// NewServedVersions indexes discovered resources by name.
+func NewServedVersions(gg []*metav1.APIGroup, rr []*metav1.APIResourceList) ServedVersions {
+	order := make(map[string]int)
+	for _, g := range gg {
+		for i, v := range g.Versions {
+			order[v.GroupVersion] = i
+			if v.GroupVersion == g.PreferredVersion.GroupVersion {
+				order[v.GroupVersion] = -1
+			}
+		}
+	}
+	type served struct {
+		version string
+		rank    int
+	}
+	index := make(map[string][]served)
+	for _, l := range rr {
+		gv, err := schema.ParseGroupVersion(l.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range l.APIResources {
+			if strings.Contains(r.Name, "/") {
+				continue
+			}
+			k := r.Name
+			if gv.Group != "" {
+				k += "." + gv.Group
+			}
+			index[k] = append(index[k], served{version: gv.Version, rank: order[l.GroupVersion]})
+		}
+	}
+
+	sv := make(ServedVersions, len(index))
+	for k, ss := range index {
+		sort.SliceStable(ss, func(i, j int) bool {
+			return ss[i].rank < ss[j].rank
+		})
+		vv := make([]string, 0, len(ss))
+		for _, s := range ss {
+			vv = append(vv, s.version)
+		}
+		sv[k] = vv
+	}
+
+	return sv
+}
+
+// GVRMatrixIssues flags the resources missing on some contexts and those whose
+// preferred version differs between contexts.
+func GVRMatrixIssues(resources []string, cc []ContextServedVersions) []string {
+	var ii []string
+	for _, c := range cc {
+		if c.Err != nil {
+			ii = append(ii, fmt.Sprintf("%s: discovery failed: %s", c.Context, c.Err))
+		}
+	}
+	for _, res := range resources {
+		var (
+			missing, found []string
+			preferred      = make(map[string][]string)
+		)
+		for _, c := range cc {
+			if c.Err != nil {
+				continue
+			}
+			vv, ok := c.Served[res]
+			if !ok {
+				missing = append(missing, c.Context)
+				continue
+			}
+			found = append(found, c.Context)
+			preferred[vv[0]] = append(preferred[vv[0]], c.Context)
+		}
+		if len(missing) > 0 && len(found) > 0 {
+			ii = append(ii, fmt.Sprintf("%s missing on %s", res, strings.Join(missing, ", ")))
+		}
+		if len(preferred) > 1 {
+			vv := make([]string, 0, len(preferred))
+			for v, ctxs := range preferred {
+				vv = append(vv, fmt.Sprintf("%s on %s", v, strings.Join(ctxs, ", ")))
+			}
+			slices.Sort(vv)
+			ii = append(ii, fmt.Sprintf("%s preferred version differs: %s", res, strings.Join(vv, "; ")))
+		}
+	}
+
+	return ii
+}
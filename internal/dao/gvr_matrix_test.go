@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewServedVersions(t *testing.T) {
+	gg := []*metav1.APIGroup{
+		{
+			Name: "longhorn.io",
+			Versions: []metav1.GroupVersionForDiscovery{
+				{GroupVersion: "longhorn.io/v1beta1", Version: "v1beta1"},
+				{GroupVersion: "longhorn.io/v1beta2", Version: "v1beta2"},
+			},
+			PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "longhorn.io/v1beta2", Version: "v1beta2"},
+		},
+	}
+	rr := []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: "pods"}, {Name: "pods/log"}},
+		},
+		{
+			GroupVersion: "longhorn.io/v1beta1",
+			APIResources: []metav1.APIResource{{Name: "volumes"}},
+		},
+		{
+			GroupVersion: "longhorn.io/v1beta2",
+			APIResources: []metav1.APIResource{{Name: "volumes"}, {Name: "backups"}},
+		},
+	}
+
+	assert.Equal(t, dao.ServedVersions{
+		"pods":                {"v1"},
+		"volumes.longhorn.io": {"v1beta2", "v1beta1"},
+		"backups.longhorn.io": {"v1beta2"},
+	}, dao.NewServedVersions(gg, rr))
+}
+
+func TestGVRMatrixIssues(t *testing.T) {
+	cc := []dao.ContextServedVersions{
+		{Context: "c1", Served: dao.ServedVersions{
+			"volumes.longhorn.io":      {"v1beta2", "v1beta1"},
+			"gitrepos.fleet.cattle.io": {"v1alpha1"},
+		}},
+		{Context: "c2", Served: dao.ServedVersions{
+			"volumes.longhorn.io": {"v1beta1"},
+		}},
+		{Context: "c3", Err: errors.New("boom")},
+	}
+
+	assert.Equal(t, []string{
+		"c3: discovery failed: boom",
+		"volumes.longhorn.io preferred version differs: v1beta1 on c2; v1beta2 on c1",
+		"gitrepos.fleet.cattle.io missing on c2",
+	}, dao.GVRMatrixIssues([]string{"volumes.longhorn.io", "gitrepos.fleet.cattle.io", "virtualmachines.kubevirt.io"}, cc))
+}
@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/derailed/k9s/internal/client"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/strvals"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/yaml"
+)
+
+// Helm drift sources.
+const (
+	HelmSourceRelease = "release"
+	HelmSourceChart   = "helmchart"
+)
+
+var (
+	helmChartGVR       = client.NewGVR("helm.cattle.io/v1/helmcharts")
+	helmChartConfigGVR = client.NewGVR("helm.cattle.io/v1/helmchartconfigs")
+)
+
+// HelmValues represents the effective values and chart of a release on a
+// context.
+type HelmValues struct {
+	Context    string
+	Namespace  string
+	Source     string
+	Chart      string
+	Version    string
+	AppVersion string
+	Values     map[string]any
+	Err        error
+}
+
+// HelmDrift represents a value differing across contexts.
+type HelmDrift struct {
+	Key string
+	// Values tracks the value per context, blank when unset.
+	Values map[string]string
+}
+
+// FetchHelmValues returns the effective values of a Helm release, or of a
+// helm.cattle.io HelmChart when no release matches, on a context. A blank
+// namespace looks the release up across all namespaces.
+func FetchHelmValues(ctx context.Context, flags *genericclioptions.ConfigFlags, rawCfg api.Config, ctxName, ns, name string) HelmValues {
+	hv := HelmValues{Context: ctxName, Namespace: ns}
+	cfg, err := helmConfigFor(flags, ctxName, ns)
+	if err != nil {
+		hv.Err = err
+		return hv
+	}
+	list := action.NewList(cfg)
+	list.All, list.AllNamespaces = true, ns == client.BlankNamespace
+	list.Filter = "^" + regexp.QuoteMeta(name) + "$"
+	list.SetStateMask()
+	rr, err := list.Run()
+	if err == nil && len(rr) > 0 {
+		r := rr[0]
+		hv.Namespace, hv.Source = r.Namespace, HelmSourceRelease
+		if r.Chart != nil && r.Chart.Metadata != nil {
+			hv.Chart, hv.Version, hv.AppVersion = r.Chart.Metadata.Name, r.Chart.Metadata.Version, r.Chart.Metadata.AppVersion
+		}
+		if hv.Values, err = chartutil.CoalesceValues(r.Chart, r.Config); err != nil {
+			hv.Err = err
+		}
+		return hv
+	}
+
+	return fetchHelmChartValues(ctx, rawCfg, hv, name)
+}
+
+func fetchHelmChartValues(ctx context.Context, rawCfg api.Config, hv HelmValues, name string) HelmValues {
+	dyn, err := dynClientFor(rawCfg, hv.Context)
+	if err != nil {
+		hv.Err = err
+		return hv
+	}
+	ll, err := dyn.Resource(helmChartGVR.GVR()).Namespace(hv.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		hv.Err = fmt.Errorf("no release %q found: %w", name, err)
+		return hv
+	}
+	var hc *unstructured.Unstructured
+	for i := range ll.Items {
+		if ll.Items[i].GetName() == name {
+			hc = &ll.Items[i]
+			break
+		}
+	}
+	if hc == nil {
+		hv.Err = fmt.Errorf("no release nor HelmChart %q found", name)
+		return hv
+	}
+
+	hv.Namespace, hv.Source = hc.GetNamespace(), HelmSourceChart
+	hv.Chart, _, _ = unstructured.NestedString(hc.Object, "spec", "chart")
+	hv.Version, _, _ = unstructured.NestedString(hc.Object, "spec", "version")
+	var override string
+	if o, err := dyn.Resource(helmChartConfigGVR.GVR()).Namespace(hc.GetNamespace()).Get(ctx, name, metav1.GetOptions{}); err == nil {
+		override, _, _ = unstructured.NestedString(o.Object, "spec", "valuesContent")
+	}
+	hv.Values, hv.Err = HelmChartValues(hc, override)
+
+	return hv
+}
+
+// HelmChartValues returns the values of a helm.cattle.io HelmChart: its
+// values content overridden by the HelmChartConfig one, then by its set
+// values.
+func HelmChartValues(hc *unstructured.Unstructured, override string) (map[string]any, error) {
+	base, _, _ := unstructured.NestedString(hc.Object, "spec", "valuesContent")
+	vals := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(base), &vals); err != nil {
+		return nil, fmt.Errorf("invalid valuesContent: %w", err)
+	}
+	if override != "" {
+		over := make(map[string]any)
+		if err := yaml.Unmarshal([]byte(override), &over); err != nil {
+			return nil, fmt.Errorf("invalid HelmChartConfig valuesContent: %w", err)
+		}
+		vals = chartutil.CoalesceTables(over, vals)
+	}
+	set, _, _ := unstructured.NestedMap(hc.Object, "spec", "set")
+	kk := make([]string, 0, len(set))
+	for k := range set {
+		kk = append(kk, k)
+	}
+	sort.Strings(kk)
+	for _, k := range kk {
+		if err := strvals.ParseInto(fmt.Sprintf("%s=%v", k, set[k]), vals); err != nil {
+			return nil, fmt.Errorf("invalid set value %q: %w", k, err)
+		}
+	}
+
+	return vals, nil
+}
+
+// HelmValuesDrift returns the values differing across contexts, sorted by
+// key. Contexts that failed are left out.
+func HelmValuesDrift(hh []HelmValues) []HelmDrift {
+	flat := make(map[string]map[string]string, len(hh))
+	keys := make(map[string]struct{})
+	var ctxs []string
+	for _, h := range hh {
+		if h.Err != nil {
+			continue
+		}
+		ctxs = append(ctxs, h.Context)
+		ff := make(map[string]string)
+		flattenValues("", h.Values, ff)
+		flat[h.Context] = ff
+		for k := range ff {
+			keys[k] = struct{}{}
+		}
+	}
+
+	var dd []HelmDrift
+	for k := range keys {
+		d := HelmDrift{Key: k, Values: make(map[string]string, len(ctxs))}
+		var differ bool
+		for _, c := range ctxs {
+			d.Values[c] = flat[c][k]
+			differ = differ || d.Values[c] != d.Values[ctxs[0]]
+		}
+		if differ {
+			dd = append(dd, d)
+		}
+	}
+	sort.Slice(dd, func(i, j int) bool {
+		return dd[i].Key < dd[j].Key
+	})
+
+	return dd
+}
+
+// flattenValues maps dotted value paths to their JSON encoded leaves. Lists
+// are compared as a whole.
+func flattenValues(prefix string, m map[string]any, out map[string]string) {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch sub := v.(type) {
+		case map[string]any:
+			if len(sub) > 0 {
+				flattenValues(key, sub, out)
+				continue
+			}
+		case chartutil.Values:
+			if len(sub) > 0 {
+				flattenValues(key, sub, out)
+				continue
+			}
+		}
+		bb, err := json.Marshal(v)
+		if err != nil {
+			out[key] = fmt.Sprintf("%v", v)
+			continue
+		}
+		out[key] = string(bb)
+	}
+}
+
+// helmConfigFor returns a helm configuration targeting the given context.
+func helmConfigFor(flags *genericclioptions.ConfigFlags, ctxName, ns string) (*action.Configuration, error) {
+	fl := &genericclioptions.ConfigFlags{
+		KubeConfig:   flags.KubeConfig,
+		Context:      &ctxName,
+		WrapConfigFn: flags.WrapConfigFn,
+	}
+
+	return ensureHelmConfig(fl, ns)
+}
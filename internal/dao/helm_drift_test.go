@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestHelmChartValues(t *testing.T) {
+	hc := unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"chart": "rke2-ingress-nginx",
+			"valuesContent": `controller:
+  replicaCount: 1
+  config:
+    use-forwarded-headers: "false"
+`,
+			"set": map[string]any{
+				"controller.replicaCount": int64(3),
+			},
+		},
+	}}
+	vals, err := dao.HelmChartValues(&hc, "controller:\n  config:\n    use-forwarded-headers: \"true\"\n")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"controller": map[string]any{
+			"replicaCount": int64(3),
+			"config": map[string]any{
+				"use-forwarded-headers": "true",
+			},
+		},
+	}, vals)
+}
+
+func TestHelmValuesDrift(t *testing.T) {
+	hh := []dao.HelmValues{
+		{
+			Context: "prod",
+			Values: map[string]any{
+				"replicas": 3,
+				"image":    map[string]any{"tag": "v1.2.0", "pullPolicy": "IfNotPresent"},
+				"tolerations": []any{
+					map[string]any{"key": "gpu"},
+				},
+			},
+		},
+		{
+			Context: "staging",
+			Values: map[string]any{
+				"replicas": 3,
+				"image":    map[string]any{"tag": "v1.3.0", "pullPolicy": "IfNotPresent"},
+				"debug":    true,
+			},
+		},
+		{
+			Context: "edge",
+			Err:     errors.New("boom"),
+		},
+	}
+
+	dd := dao.HelmValuesDrift(hh)
+	require.Len(t, dd, 3)
+	assert.Equal(t, "debug", dd[0].Key)
+	assert.Equal(t, map[string]string{"prod": "", "staging": "true"}, dd[0].Values)
+	assert.Equal(t, "image.tag", dd[1].Key)
+	assert.Equal(t, map[string]string{"prod": `"v1.2.0"`, "staging": `"v1.3.0"`}, dd[1].Values)
+	assert.Equal(t, "tolerations", dd[2].Key)
+	assert.Equal(t, `[{"key":"gpu"}]`, dd[2].Values["prod"])
+}
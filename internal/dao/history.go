@@ -0,0 +1,339 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/config/data"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// DefaultLokiAuditSelector tracks the default LogQL selector of audit events.
+	DefaultLokiAuditSelector = `{job="kube-apiserver-audit"}`
+
+	historyQueryTimeout = 30 * time.Second
+	lokiQueryLimit      = 5000
+)
+
+var historyVerbs = map[string]struct{}{
+	"create": {},
+	"update": {},
+	"patch":  {},
+	"delete": {},
+}
+
+// HistoryRef identifies a resource in audit events.
+type HistoryRef struct {
+	Group, Resource, Namespace, Name string
+}
+
+func (r HistoryRef) String() string {
+	s := r.Resource
+	if r.Group != "" {
+		s += "." + r.Group
+	}
+	if r.Namespace != "" {
+		return s + " " + r.Namespace + "/" + r.Name
+	}
+
+	return s + " " + r.Name
+}
+
+// HistoryRevision represents a resource state recorded by an audit event.
+type HistoryRevision struct {
+	At   time.Time
+	User string
+	Verb string
+
+	// Object is the resource as written, nil when the resource was deleted.
+	Object *unstructured.Unstructured
+}
+
+type historyEvent struct {
+	Stage string `json:"stage"`
+	Verb  string `json:"verb"`
+	User  struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	ObjectRef *struct {
+		Resource    string `json:"resource"`
+		Namespace   string `json:"namespace"`
+		Name        string `json:"name"`
+		APIGroup    string `json:"apiGroup"`
+		Subresource string `json:"subresource"`
+	} `json:"objectRef"`
+	ResponseStatus *struct {
+		Code int `json:"code"`
+	} `json:"responseStatus"`
+	ResponseObject           json.RawMessage `json:"responseObject"`
+	RequestReceivedTimestamp time.Time       `json:"requestReceivedTimestamp"`
+	StageTimestamp           time.Time       `json:"stageTimestamp"`
+}
+
+func (e *historyEvent) matches(ref HistoryRef) bool {
+	if e.ObjectRef == nil {
+		return false
+	}
+	o := e.ObjectRef
+
+	return o.Resource == ref.Resource && o.APIGroup == ref.Group &&
+		o.Namespace == ref.Namespace && o.Name == ref.Name &&
+		(o.Subresource == "" || o.Subresource == "status")
+}
+
+func (e *historyEvent) at() time.Time {
+	if !e.StageTimestamp.IsZero() {
+		return e.StageTimestamp
+	}
+
+	return e.RequestReceivedTimestamp
+}
+
+// LatestRevision returns the last state of a resource recorded at or before a
+// given time in a JSON lines audit log. Only successful writes logged at the
+// RequestResponse level are considered.
+func LatestRevision(r io.Reader, ref HistoryRef, at time.Time) (HistoryRevision, bool, error) {
+	var (
+		rev   HistoryRevision
+		found bool
+	)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if !bytes.Contains(line, []byte(ref.Name)) {
+			continue
+		}
+		var e historyEvent
+		if err := json.Unmarshal(line, &e); err != nil {
+			continue
+		}
+		if _, ok := historyVerbs[e.Verb]; !ok || !e.matches(ref) {
+			continue
+		}
+		if e.Stage != "" && e.Stage != auditResponseComplete {
+			continue
+		}
+		if e.ResponseStatus == nil || e.ResponseStatus.Code < 200 || e.ResponseStatus.Code > 299 {
+			continue
+		}
+		t := e.at()
+		if t.After(at) || (found && !t.After(rev.At)) {
+			continue
+		}
+		var o unstructured.Unstructured
+		if len(e.ResponseObject) > 0 {
+			if err := o.UnmarshalJSON(e.ResponseObject); err != nil {
+				continue
+			}
+		}
+		switch {
+		case o.GetKind() == "Status":
+			if e.Verb != "delete" {
+				continue
+			}
+			rev = HistoryRevision{At: t, User: e.User.Username, Verb: e.Verb}
+		case o.Object == nil:
+			// Request level only, the written state was not recorded.
+			continue
+		default:
+			rev = HistoryRevision{At: t, User: e.User.Username, Verb: e.Verb, Object: &o}
+		}
+		found = true
+	}
+
+	return rev, found, scanner.Err()
+}
+
+// ResourceAt returns the state of a resource at a given time from a context
+// history backend.
+func ResourceAt(ctx context.Context, rawCfg api.Config, ctxName string, h *data.History, ref HistoryRef, at time.Time) (HistoryRevision, error) {
+	if h == nil || (h.Path == "" && h.Loki == nil) {
+		return HistoryRevision{}, errors.New("no resource history configured for this context")
+	}
+	ctx, cancel := context.WithTimeout(ctx, historyQueryTimeout)
+	defer cancel()
+
+	var (
+		revs []HistoryRevision
+		errs error
+	)
+	if h.Path != "" {
+		rev, ok, err := fileRevision(h.Path, ref, at)
+		if err != nil {
+			errs = errors.Join(errs, err)
+		}
+		if ok {
+			revs = append(revs, rev)
+		}
+	}
+	if h.Loki != nil {
+		rev, ok, err := lokiRevision(ctx, rawCfg, ctxName, h.Loki, ref, at, h.LookbackDuration())
+		if err != nil {
+			errs = errors.Join(errs, err)
+		}
+		if ok {
+			revs = append(revs, rev)
+		}
+	}
+	if len(revs) == 0 {
+		if errs != nil {
+			return HistoryRevision{}, errs
+		}
+		return HistoryRevision{}, fmt.Errorf("no recorded revision of %s at %s", ref, at.Format(time.RFC3339))
+	}
+	latest := revs[0]
+	for _, r := range revs[1:] {
+		if r.At.After(latest.At) {
+			latest = r
+		}
+	}
+
+	return latest, nil
+}
+
+func fileRevision(path string, ref HistoryRef, at time.Time) (HistoryRevision, bool, error) {
+	var (
+		latest HistoryRevision
+		found  bool
+	)
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rev, ok, err := auditFileRevision(p, ref, at)
+		if err != nil {
+			return fmt.Errorf("unable to read audit log %s: %w", p, err)
+		}
+		if ok && (!found || rev.At.After(latest.At)) {
+			latest, found = rev, true
+		}
+		return nil
+	})
+
+	return latest, found, err
+}
+
+func auditFileRevision(path string, ref HistoryRef, at time.Time) (HistoryRevision, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return HistoryRevision{}, false, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return HistoryRevision{}, false, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	return LatestRevision(r, ref, at)
+}
+
+func lokiRevision(ctx context.Context, rawCfg api.Config, ctxName string, l *data.LokiHistory, ref HistoryRef, at time.Time, lookback time.Duration) (HistoryRevision, bool, error) {
+	sel := l.Selector
+	if sel == "" {
+		sel = DefaultLokiAuditSelector
+	}
+	q := url.Values{
+		"query":     {sel + " |= " + strconv.Quote(`"name":"`+ref.Name+`"`)},
+		"start":     {strconv.FormatInt(at.Add(-lookback).UnixNano(), 10)},
+		"end":       {strconv.FormatInt(at.UnixNano(), 10)},
+		"limit":     {strconv.Itoa(lokiQueryLimit)},
+		"direction": {"backward"},
+	}
+	var hdr http.Header
+	if l.OrgID != "" {
+		hdr = http.Header{"X-Scope-OrgID": {l.OrgID}}
+	}
+	bb, err := monitoringGet(ctx, rawCfg, ctxName, &l.Endpoint, lokiMonitoring, "loki/api/v1/query_range", q, hdr)
+	if err != nil {
+		return HistoryRevision{}, false, err
+	}
+	lines, err := LokiLines(bb)
+	if err != nil {
+		return HistoryRevision{}, false, err
+	}
+
+	return LatestRevision(strings.NewReader(strings.Join(lines, "\n")), ref, at)
+}
+
+// LokiLines extracts the log lines of a Loki streams query response.
+func LokiLines(bb []byte) ([]string, error) {
+	var resp struct {
+		Status string `json:"status"`
+		Data   struct {
+			ResultType string `json:"resultType"`
+			Result     []struct {
+				Values [][2]string `json:"values"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(bb, &resp); err != nil {
+		return nil, fmt.Errorf("invalid loki response: %w", err)
+	}
+	if resp.Status != "success" {
+		return nil, fmt.Errorf("loki query failed: %s", resp.Status)
+	}
+	if resp.Data.ResultType != "streams" {
+		return nil, fmt.Errorf("unsupported loki result type %q", resp.Data.ResultType)
+	}
+	var ll []string
+	for _, r := range resp.Data.Result {
+		for _, v := range r.Values {
+			ll = append(ll, v[1])
+		}
+	}
+
+	return ll, nil
+}
+
+// ParseAsOf parses a past point in time, either a duration ago, ie `90m` or
+// `2d`, an RFC3339 timestamp or a local `2006-01-02 15:04` date.
+func ParseAsOf(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{time.DateTime, "2006-01-02 15:04", time.DateOnly} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q, expecting ie 2h, 3d or 2006-01-02 15:04", s)
+}
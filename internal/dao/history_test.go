@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const auditLog = `{"stage":"ResponseComplete","verb":"create","user":{"username":"alice"},"objectRef":{"resource":"deployments","namespace":"ns1","name":"web","apiGroup":"apps"},"responseStatus":{"code":201},"responseObject":{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"ns1"},"spec":{"replicas":1}},"stageTimestamp":"2026-01-01T10:00:00Z"}
+{"stage":"ResponseComplete","verb":"get","user":{"username":"bob"},"objectRef":{"resource":"deployments","namespace":"ns1","name":"web","apiGroup":"apps"},"responseStatus":{"code":200},"responseObject":{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"ns1"},"spec":{"replicas":1}},"stageTimestamp":"2026-01-01T10:30:00Z"}
+{"stage":"ResponseComplete","verb":"patch","user":{"username":"bob"},"objectRef":{"resource":"deployments","namespace":"ns1","name":"web","apiGroup":"apps"},"responseStatus":{"code":200},"responseObject":{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"ns1"},"spec":{"replicas":3}},"stageTimestamp":"2026-01-01T11:00:00Z"}
+{"stage":"ResponseComplete","verb":"patch","user":{"username":"eve"},"objectRef":{"resource":"deployments","namespace":"ns1","name":"web","apiGroup":"apps"},"responseStatus":{"code":403},"stageTimestamp":"2026-01-01T11:30:00Z"}
+{"stage":"ResponseComplete","verb":"update","user":{"username":"bob"},"objectRef":{"resource":"deployments","namespace":"ns1","name":"web","apiGroup":"apps","subresource":"scale"},"responseStatus":{"code":200},"responseObject":{"apiVersion":"autoscaling/v1","kind":"Scale","metadata":{"name":"web"}},"stageTimestamp":"2026-01-01T11:40:00Z"}
+{"stage":"ResponseComplete","verb":"update","user":{"username":"carol"},"objectRef":{"resource":"deployments","namespace":"ns2","name":"web","apiGroup":"apps"},"responseStatus":{"code":200},"responseObject":{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"ns2"},"spec":{"replicas":5}},"stageTimestamp":"2026-01-01T11:45:00Z"}
+{"stage":"ResponseComplete","verb":"delete","user":{"username":"dave"},"objectRef":{"resource":"deployments","namespace":"ns1","name":"web","apiGroup":"apps"},"responseStatus":{"code":200},"responseObject":{"apiVersion":"v1","kind":"Status","status":"Success"},"stageTimestamp":"2026-01-01T12:00:00Z"}
+`
+
+func TestLatestRevision(t *testing.T) {
+	ref := dao.HistoryRef{Group: "apps", Resource: "deployments", Namespace: "ns1", Name: "web"}
+	uu := map[string]struct {
+		at       string
+		ok       bool
+		user     string
+		replicas int64
+		deleted  bool
+	}{
+		"before": {
+			at: "2026-01-01T09:00:00Z",
+		},
+		"created": {
+			at:       "2026-01-01T10:45:00Z",
+			ok:       true,
+			user:     "alice",
+			replicas: 1,
+		},
+		"patched": {
+			at:       "2026-01-01T11:50:00Z",
+			ok:       true,
+			user:     "bob",
+			replicas: 3,
+		},
+		"deleted": {
+			at:      "2026-01-02T00:00:00Z",
+			ok:      true,
+			user:    "dave",
+			deleted: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			at, err := time.Parse(time.RFC3339, u.at)
+			require.NoError(t, err)
+			rev, ok, err := dao.LatestRevision(strings.NewReader(auditLog), ref, at)
+			require.NoError(t, err)
+			assert.Equal(t, u.ok, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, u.user, rev.User)
+			if u.deleted {
+				assert.Nil(t, rev.Object)
+				return
+			}
+			require.NotNil(t, rev.Object)
+			assert.Equal(t, u.replicas, rev.Object.Object["spec"].(map[string]any)["replicas"])
+		})
+	}
+}
+
+func TestLokiLines(t *testing.T) {
+	uu := map[string]struct {
+		raw string
+		e   []string
+		err bool
+	}{
+		"streams": {
+			raw: `{"status":"success","data":{"resultType":"streams","result":[{"stream":{"job":"audit"},"values":[["2","l2"],["1","l1"]]},{"stream":{},"values":[["3","l3"]]}]}}`,
+			e:   []string{"l2", "l1", "l3"},
+		},
+		"empty": {
+			raw: `{"status":"success","data":{"resultType":"streams","result":[]}}`,
+		},
+		"matrix": {
+			raw: `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			err: true,
+		},
+		"garbage": {
+			raw: `nope`,
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ll, err := dao.LokiLines([]byte(u.raw))
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.e, ll)
+		})
+	}
+}
+
+func TestParseAsOf(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	uu := map[string]struct {
+		s   string
+		e   time.Time
+		err bool
+	}{
+		"duration": {
+			s: "90m",
+			e: now.Add(-90 * time.Minute),
+		},
+		"days": {
+			s: "2d",
+			e: now.AddDate(0, 0, -2),
+		},
+		"rfc3339": {
+			s: "2026-03-01T08:00:00Z",
+			e: time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC),
+		},
+		"date-time": {
+			s: "2026-03-01 08:30",
+			e: time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC),
+		},
+		"date": {
+			s: " 2026-03-01 ",
+			e: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"negative": {
+			s:   "-1h",
+			err: true,
+		},
+		"garbage": {
+			s:   "yesterday",
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			at, err := dao.ParseAsOf(u.s, now)
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, u.e.Equal(at), "expected %s got %s", u.e, at)
+		})
+	}
+}
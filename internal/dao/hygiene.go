@@ -0,0 +1,332 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/render"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+const (
+	// rancherLastLoginLabel tracks a Rancher user last login as unix seconds.
+	rancherLastLoginLabel = "cattle.io/last-login"
+
+	rancherSystemPrincipal = "system://"
+)
+
+var rancherAdminRoles = map[string]struct{}{
+	"admin":            {},
+	"restricted-admin": {},
+}
+
+var _ Accessor = (*Hygiene)(nil)
+
+// Hygiene tracks Rancher dormant users, long-lived tokens and admin bindings.
+type Hygiene struct {
+	NonResource
+}
+
+// HygieneOpts represents the hygiene report thresholds.
+type HygieneOpts struct {
+	DormantAfter time.Duration
+	MaxTokenTTL  time.Duration
+}
+
+// List returns the hygiene findings of the active Rancher management cluster.
+func (h *Hygiene) List(ctx context.Context, _ string) ([]runtime.Object, error) {
+	r, _ := ctx.Value(internal.KeyRancher).(*config.Rancher)
+	opts := HygieneOpts{
+		DormantAfter: r.DormantThreshold(),
+		MaxTokenTTL:  r.TokenTTLThreshold(),
+	}
+
+	f := h.getFactory()
+	uu, err := f.List(client.RancherUserGVR, "", false, labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list rancher users: %w", err)
+	}
+	tt, err := f.List(client.RancherTokenGVR, "", false, labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list rancher tokens: %w", err)
+	}
+	bb, err := f.List(client.RancherGrbGVR, "", false, labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list rancher global role bindings: %w", err)
+	}
+
+	return HygieneFindings(toUnstructured(uu), toUnstructured(tt), toUnstructured(bb), time.Now(), opts), nil
+}
+
+// HygieneFindings flags dormant users, tokens outliving the max TTL and
+// global admin bindings. Bindings to missing or deactivated users are flagged
+// as orphaned.
+func HygieneFindings(users, tokens, grbs []*unstructured.Unstructured, now time.Time, opts HygieneOpts) []runtime.Object {
+	var oo []runtime.Object
+
+	names, active := make(map[string]string, len(users)), make(map[string]bool, len(users))
+	for _, u := range users {
+		names[u.GetName()] = rancherUserName(u)
+		active[u.GetName()] = rancherEnabled(u)
+		if !active[u.GetName()] || isRancherSystemUser(u) {
+			continue
+		}
+		if d, ok := dormantFor(u, now, opts.DormantAfter); ok {
+			oo = append(oo, &render.HygieneRes{
+				Kind:    render.HygieneUser,
+				Name:    u.GetName(),
+				UserID:  u.GetName(),
+				User:    names[u.GetName()],
+				Finding: render.FindingDormantUser,
+				Detail:  d,
+				Created: u.GetCreationTimestamp().Time,
+			})
+		}
+	}
+
+	for _, t := range tokens {
+		if !rancherEnabled(t) || tokenExpired(t, now) {
+			continue
+		}
+		ttl, _, _ := unstructured.NestedInt64(t.Object, "ttl")
+		var detail string
+		switch {
+		case ttl == 0:
+			detail = "never expires"
+		case time.Duration(ttl)*time.Millisecond > opts.MaxTokenTTL:
+			detail = "ttl " + duration.HumanDuration(time.Duration(ttl)*time.Millisecond)
+		default:
+			continue
+		}
+		if desc, _, _ := unstructured.NestedString(t.Object, "description"); desc != "" {
+			detail += " (" + desc + ")"
+		}
+		uid, _, _ := unstructured.NestedString(t.Object, "userId")
+		oo = append(oo, &render.HygieneRes{
+			Kind:    render.HygieneToken,
+			Name:    t.GetName(),
+			UserID:  uid,
+			User:    names[uid],
+			Finding: render.FindingLongLivedToken,
+			Detail:  detail,
+			Created: t.GetCreationTimestamp().Time,
+		})
+	}
+
+	admins := make([]*unstructured.Unstructured, 0, len(grbs))
+	for _, b := range grbs {
+		role, _, _ := unstructured.NestedString(b.Object, "globalRoleName")
+		if _, ok := rancherAdminRoles[role]; ok {
+			admins = append(admins, b)
+		}
+	}
+	for _, b := range admins {
+		role, _, _ := unstructured.NestedString(b.Object, "globalRoleName")
+		uid, _, _ := unstructured.NestedString(b.Object, "userName")
+		res := render.HygieneRes{
+			Kind:    render.HygieneBinding,
+			Name:    b.GetName(),
+			UserID:  uid,
+			User:    names[uid],
+			Finding: render.FindingAdminBinding,
+			Detail:  fmt.Sprintf("%s, 1 of %d admin bindings", role, len(admins)),
+			Created: b.GetCreationTimestamp().Time,
+		}
+		if uid == "" {
+			res.User, _, _ = unstructured.NestedString(b.Object, "groupPrincipalName")
+		}
+		_, known := names[uid]
+		switch {
+		case uid == "":
+		case !known:
+			res.Finding, res.Detail = render.FindingOrphanBinding, role+" bound to a missing user"
+		case !active[uid]:
+			res.Finding, res.Detail = render.FindingOrphanBinding, role+" bound to a deactivated user"
+		}
+		oo = append(oo, &res)
+	}
+
+	return oo
+}
+
+// RancherSession represents the Rancher token and user the session
+// authenticates with.
+type RancherSession struct {
+	Token string
+	User  string
+}
+
+// LockedOut checks whether disabling the given token or user would lock the
+// session out.
+func (s RancherSession) LockedOut(token, user string) error {
+	switch {
+	case token != "" && token == s.Token:
+		return fmt.Errorf("token %s authenticates this session, expiring it would lock you out", token)
+	case user != "" && user == s.User:
+		return fmt.Errorf("user %s is the one logged in, deactivating it would lock you out", user)
+	}
+
+	return nil
+}
+
+// rancherSession resolves the Rancher token the session authenticates with,
+// ie a kubeconfig bearer token in the form name:secret, and its owner.
+// Sessions not using a Rancher token resolve to an empty session.
+func rancherSession(ctx context.Context, c client.Connection) (RancherSession, error) {
+	cfg, err := c.RestConfig()
+	if err != nil {
+		return RancherSession{}, err
+	}
+	bearer := cfg.BearerToken
+	if bearer == "" && cfg.BearerTokenFile != "" {
+		bb, err := os.ReadFile(cfg.BearerTokenFile)
+		if err != nil {
+			return RancherSession{}, err
+		}
+		bearer = strings.TrimSpace(string(bb))
+	}
+	name, _, ok := strings.Cut(bearer, ":")
+	if !ok || name == "" {
+		return RancherSession{}, nil
+	}
+	dyn, err := c.DynDial()
+	if err != nil {
+		return RancherSession{}, err
+	}
+	t, err := dyn.Resource(client.RancherTokenGVR.GVR()).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return RancherSession{}, fmt.Errorf("unable to resolve the session rancher token: %w", err)
+	}
+	user, _, _ := unstructured.NestedString(t.Object, "userId")
+
+	return RancherSession{Token: name, User: user}, nil
+}
+
+// DeactivateRancherUser disables a Rancher user so it can no longer log in.
+// The user backing the session is never deactivated.
+func DeactivateRancherUser(ctx context.Context, c client.Connection, id string) error {
+	s, err := rancherSession(ctx, c)
+	if err != nil {
+		return err
+	}
+	if err := s.LockedOut("", id); err != nil {
+		return err
+	}
+
+	return disableRancherObject(ctx, c, client.RancherUserGVR, id)
+}
+
+// ExpireRancherToken disables a Rancher token so it can no longer authenticate.
+// The token backing the session is never expired.
+func ExpireRancherToken(ctx context.Context, c client.Connection, name string) error {
+	s, err := rancherSession(ctx, c)
+	if err != nil {
+		return err
+	}
+	if err := s.LockedOut(name, ""); err != nil {
+		return err
+	}
+
+	return disableRancherObject(ctx, c, client.RancherTokenGVR, name)
+}
+
+func disableRancherObject(ctx context.Context, c client.Connection, gvr *client.GVR, name string) error {
+	if name == "" {
+		return errors.New("no resource name given")
+	}
+	dyn, err := c.DynDial()
+	if err != nil {
+		return err
+	}
+	_, err = dyn.Resource(gvr.GVR()).Patch(ctx, name, types.MergePatchType, []byte(`{"enabled":false}`), metav1.PatchOptions{})
+
+	return err
+}
+
+func dormantFor(u *unstructured.Unstructured, now time.Time, after time.Duration) (string, bool) {
+	cutoff := now.Add(-after)
+	raw, ok := u.GetLabels()[rancherLastLoginLabel]
+	if !ok {
+		if u.GetCreationTimestamp().After(cutoff) {
+			return "", false
+		}
+		return "never logged in", true
+	}
+	secs, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return "", false
+	}
+	last := time.Unix(secs, 0)
+	if last.After(cutoff) {
+		return "", false
+	}
+
+	return "last login " + duration.HumanDuration(now.Sub(last)) + " ago", true
+}
+
+func tokenExpired(t *unstructured.Unstructured, now time.Time) bool {
+	if expired, _, _ := unstructured.NestedBool(t.Object, "expired"); expired {
+		return true
+	}
+	at, _, _ := unstructured.NestedString(t.Object, "expiresAt")
+	if at == "" {
+		return false
+	}
+	ts, err := time.Parse(time.RFC3339, at)
+
+	return err == nil && ts.Before(now)
+}
+
+// rancherEnabled checks the top level enabled flag, which defaults to true.
+func rancherEnabled(o *unstructured.Unstructured) bool {
+	enabled, ok, _ := unstructured.NestedBool(o.Object, "enabled")
+
+	return !ok || enabled
+}
+
+func rancherUserName(u *unstructured.Unstructured) string {
+	if n, _, _ := unstructured.NestedString(u.Object, "username"); n != "" {
+		return n
+	}
+	n, _, _ := unstructured.NestedString(u.Object, "displayName")
+
+	return n
+}
+
+func isRancherSystemUser(u *unstructured.Unstructured) bool {
+	pp, _, _ := unstructured.NestedStringSlice(u.Object, "principalIds")
+	for _, p := range pp {
+		if strings.HasPrefix(p, rancherSystemPrincipal) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toUnstructured(oo []runtime.Object) []*unstructured.Unstructured {
+	uu := make([]*unstructured.Unstructured, 0, len(oo))
+	for _, o := range oo {
+		if u, ok := o.(*unstructured.Unstructured); ok {
+			uu = append(uu, u)
+		}
+	}
+
+	return uu
+}
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestHygieneFindings(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	opts := dao.HygieneOpts{DormantAfter: 90 * day, MaxTokenTTL: 30 * day}
+
+	uu := map[string]struct {
+		users, tokens, grbs []*unstructured.Unstructured
+		e                   []string
+	}{
+		"empty": {},
+		"active-user": {
+			users: []*unstructured.Unstructured{
+				makeRancherUser("u-1", "fred", now.Add(-200*day), now.Add(-day), true),
+			},
+		},
+		"dormant-user": {
+			users: []*unstructured.Unstructured{
+				makeRancherUser("u-1", "fred", now.Add(-200*day), now.Add(-100*day), true),
+			},
+			e: []string{"User|u-1|fred|DormantUser|last login 100d ago"},
+		},
+		"never-logged-in": {
+			users: []*unstructured.Unstructured{
+				makeRancherUser("u-1", "fred", now.Add(-200*day), time.Time{}, true),
+				makeRancherUser("u-2", "blee", now.Add(-10*day), time.Time{}, true),
+			},
+			e: []string{"User|u-1|fred|DormantUser|never logged in"},
+		},
+		"deactivated-user": {
+			users: []*unstructured.Unstructured{
+				makeRancherUser("u-1", "fred", now.Add(-200*day), now.Add(-100*day), false),
+			},
+		},
+		"tokens": {
+			users: []*unstructured.Unstructured{
+				makeRancherUser("u-1", "fred", now.Add(-10*day), now, true),
+			},
+			tokens: []*unstructured.Unstructured{
+				makeRancherToken("t-1", "u-1", 0, nil),
+				makeRancherToken("t-2", "u-1", 365*day, nil),
+				makeRancherToken("t-3", "u-1", day, nil),
+				makeRancherToken("t-4", "u-1", 0, map[string]any{"enabled": false}),
+				makeRancherToken("t-5", "u-1", 0, map[string]any{"expired": true}),
+				makeRancherToken("t-6", "u-1", 0, map[string]any{"description": "ci"}),
+			},
+			e: []string{
+				"Token|t-1|fred|LongLivedToken|never expires",
+				"Token|t-2|fred|LongLivedToken|ttl 365d",
+				"Token|t-6|fred|LongLivedToken|never expires (ci)",
+			},
+		},
+		"admin-bindings": {
+			users: []*unstructured.Unstructured{
+				makeRancherUser("u-1", "fred", now.Add(-10*day), now, true),
+				makeRancherUser("u-2", "blee", now.Add(-10*day), now, false),
+			},
+			grbs: []*unstructured.Unstructured{
+				makeRancherGRB("grb-1", "admin", "u-1"),
+				makeRancherGRB("grb-2", "restricted-admin", "u-2"),
+				makeRancherGRB("grb-3", "admin", "u-3"),
+				makeRancherGRB("grb-4", "user", "u-1"),
+			},
+			e: []string{
+				"GlobalRoleBinding|grb-1|fred|AdminBinding|admin, 1 of 3 admin bindings",
+				"GlobalRoleBinding|grb-2|blee|OrphanedAdminBinding|restricted-admin bound to a deactivated user",
+				"GlobalRoleBinding|grb-3|<n/a>|OrphanedAdminBinding|admin bound to a missing user",
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			oo := dao.HygieneFindings(u.users, u.tokens, u.grbs, now, opts)
+			ss := make([]string, 0, len(oo))
+			for _, o := range oo {
+				f := o.(*render.HygieneRes)
+				user := f.User
+				if user == "" {
+					user = "<n/a>"
+				}
+				ss = append(ss, f.Kind+"|"+f.Name+"|"+user+"|"+f.Finding+"|"+f.Detail)
+			}
+			assert.ElementsMatch(t, u.e, ss)
+		})
+	}
+}
+
+// Helpers...
+
+func makeRancherUser(id, name string, created, lastLogin time.Time, enabled bool) *unstructured.Unstructured {
+	o := unstructured.Unstructured{Object: map[string]any{
+		"username": name,
+		"enabled":  enabled,
+	}}
+	o.SetName(id)
+	o.SetCreationTimestamp(metav1.NewTime(created))
+	if !lastLogin.IsZero() {
+		o.SetLabels(map[string]string{"cattle.io/last-login": strconv.FormatInt(lastLogin.Unix(), 10)})
+	}
+
+	return &o
+}
+
+func makeRancherToken(name, user string, ttl time.Duration, extra map[string]any) *unstructured.Unstructured {
+	o := unstructured.Unstructured{Object: map[string]any{
+		"userId": user,
+		"ttl":    ttl.Milliseconds(),
+	}}
+	for k, v := range extra {
+		o.Object[k] = v
+	}
+	o.SetName(name)
+
+	return &o
+}
+
+func makeRancherGRB(name, role, user string) *unstructured.Unstructured {
+	o := unstructured.Unstructured{Object: map[string]any{
+		"globalRoleName": role,
+		"userName":       user,
+	}}
+	o.SetName(name)
+
+	return &o
+}
+
+func TestRancherSessionLockedOut(t *testing.T) {
+	s := dao.RancherSession{Token: "kubeconfig-u-1", User: "u-1"}
+
+	uu := map[string]struct {
+		s           dao.RancherSession
+		token, user string
+		err         bool
+	}{
+		"other-token": {s: s, token: "kubeconfig-u-2"},
+		"own-token":   {s: s, token: "kubeconfig-u-1", err: true},
+		"other-user":  {s: s, user: "u-2"},
+		"own-user":    {s: s, user: "u-1", err: true},
+		"no-session":  {token: "kubeconfig-u-1", user: "u-1"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := u.s.LockedOut(u.token, u.user)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
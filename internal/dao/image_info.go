@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ImageInfo represents a container image along with its registry metadata.
+type ImageInfo struct {
+	Container string
+	Init      bool
+	Image     string
+	Registry  string
+	Repo      string
+	Tag       string
+	Digests   []string
+	Created   time.Time
+	Newer     []string
+	Err       error
+}
+
+// WorkloadImages lists the container images of a pod or workload along with
+// the digests currently running in its pods.
+func WorkloadImages(f Factory, gvr *client.GVR, path string) ([]ImageInfo, error) {
+	acc, err := AccessorFor(f, gvr)
+	if err != nil {
+		return nil, err
+	}
+	res, ok := acc.(ContainsPodSpec)
+	if !ok {
+		return nil, fmt.Errorf("no images available for %s", gvr)
+	}
+	spec, err := res.GetPodSpec(path)
+	if err != nil {
+		return nil, err
+	}
+	digests, err := runningDigests(f, gvr, path)
+	if err != nil {
+		return nil, err
+	}
+
+	ii := make([]ImageInfo, 0, len(spec.InitContainers)+len(spec.Containers))
+	for _, co := range spec.InitContainers {
+		ii = append(ii, newImageInfo(co.Name, co.Image, true, digests[co.Name]))
+	}
+	for _, co := range spec.Containers {
+		ii = append(ii, newImageInfo(co.Name, co.Image, false, digests[co.Name]))
+	}
+
+	return ii, nil
+}
+
+func newImageInfo(co, img string, init bool, digests []string) ImageInfo {
+	info := ImageInfo{Container: co, Init: init, Image: img, Digests: digests}
+	ref, err := name.ParseReference(img)
+	if err != nil {
+		info.Err = err
+		return info
+	}
+	info.Registry, info.Repo = ref.Context().RegistryStr(), ref.Context().RepositoryStr()
+	if t, ok := ref.(name.Tag); ok {
+		info.Tag = t.TagStr()
+	}
+
+	return info
+}
+
+// runningDigests collects the image digests reported by the pods backing a resource.
+func runningDigests(f Factory, gvr *client.GVR, path string) (map[string][]string, error) {
+	o, err := f.Get(gvr, path, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	oo := []runtime.Object{o}
+	if gvr != client.PodGVR {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("expecting *unstructured.Unstructured but got %T", o)
+		}
+		m, ok, _ := unstructured.NestedMap(u.Object, "spec", "selector")
+		if !ok {
+			return nil, nil
+		}
+		var ls metav1.LabelSelector
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &ls); err != nil {
+			return nil, err
+		}
+		sel, err := metav1.LabelSelectorAsSelector(&ls)
+		if err != nil {
+			return nil, err
+		}
+		if oo, err = f.List(client.PodGVR, u.GetNamespace(), true, sel); err != nil {
+			return nil, err
+		}
+	}
+
+	dd := make(map[string][]string)
+	for _, o := range oo {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		var po v1.Pod
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &po); err != nil {
+			return nil, err
+		}
+		for _, cs := range slices.Concat(po.Status.InitContainerStatuses, po.Status.ContainerStatuses) {
+			d := imageDigest(cs.ImageID)
+			if d != "" && !slices.Contains(dd[cs.Name], d) {
+				dd[cs.Name] = append(dd[cs.Name], d)
+			}
+		}
+	}
+
+	return dd, nil
+}
+
+func imageDigest(imageID string) string {
+	if _, d, ok := strings.Cut(imageID, "@"); ok {
+		return d
+	}
+
+	return ""
+}
+
+// RegistryLookup enriches images with their creation date and optionally newer
+// tags, using the docker credentials keychain to authenticate.
+func RegistryLookup(ctx context.Context, ii []ImageInfo, insecure, newer bool) {
+	var nopts []name.Option
+	if insecure {
+		nopts = append(nopts, name.Insecure)
+	}
+	ropts := []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(authn.DefaultKeychain),
+	}
+	for i := range ii {
+		if ii[i].Err != nil {
+			continue
+		}
+		ref, err := name.ParseReference(ii[i].Image, nopts...)
+		if err != nil {
+			ii[i].Err = err
+			continue
+		}
+		img, err := remote.Image(ref, ropts...)
+		if err != nil {
+			ii[i].Err = err
+			continue
+		}
+		cfg, err := img.ConfigFile()
+		if err != nil {
+			ii[i].Err = err
+			continue
+		}
+		ii[i].Created = cfg.Created.Time
+		if !newer || ii[i].Tag == "" {
+			continue
+		}
+		tags, err := remote.List(ref.Context(), ropts...)
+		if err != nil {
+			ii[i].Err = err
+			continue
+		}
+		ii[i].Newer = NewerTags(ii[i].Tag, tags)
+	}
+}
+
+// NewerTags returns the semver tags more recent than the current one, most recent first.
+// Pre-releases are skipped unless the current tag is one.
+func NewerTags(current string, tags []string) []string {
+	cv, err := semver.NewVersion(current)
+	if err != nil {
+		return nil
+	}
+
+	vv := make([]*semver.Version, 0, len(tags))
+	for _, t := range tags {
+		v, err := semver.NewVersion(t)
+		if err != nil {
+			continue
+		}
+		if v.Prerelease() != "" && cv.Prerelease() == "" {
+			continue
+		}
+		if v.GreaterThan(cv) {
+			vv = append(vv, v)
+		}
+	}
+	slices.SortFunc(vv, func(a, b *semver.Version) int {
+		return b.Compare(a)
+	})
+
+	out := make([]string, 0, len(vv))
+	for _, v := range vv {
+		out = append(out, v.Original())
+	}
+
+	return out
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewerTags(t *testing.T) {
+	tags := []string{"latest", "1.25", "1.26.1", "v1.27.0", "1.27.0-rc.1", "1.24.0", "stable"}
+
+	uu := map[string]struct {
+		current string
+		e       []string
+	}{
+		"not-semver": {current: "latest"},
+		"newest":     {current: "1.27.0"},
+		"older": {
+			current: "1.25.3",
+			e:       []string{"v1.27.0", "1.26.1"},
+		},
+		"prerelease": {
+			current: "1.27.0-beta.1",
+			e:       []string{"v1.27.0", "1.27.0-rc.1"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, emptyAsNil(dao.NewerTags(u.current, tags)))
+		})
+	}
+}
+
+func emptyAsNil(ss []string) []string {
+	if len(ss) == 0 {
+		return nil
+	}
+
+	return ss
+}
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// PromoteRolloutTimeout caps the wait for a context rollout to turn healthy.
+	PromoteRolloutTimeout = 5 * time.Minute
+
+	promotePollInterval = 2 * time.Second
+)
+
+// ImagePromotion represents a deployment container image update staged
+// across contexts.
+type ImagePromotion struct {
+	Path      string
+	Container string
+	Init      bool
+	Image     string
+	Contexts  []string
+	Timeout   time.Duration
+}
+
+// Promote updates the image on a given context and waits for its rollout to
+// turn healthy, reporting the pending rollout step.
+func (p ImagePromotion) Promote(ctx context.Context, rawCfg api.Config, ctxName string, progress func(string)) error {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = PromoteRolloutTimeout
+	}
+
+	return p.promote(ctx, rawCfg, ctxName, timeout, progress)
+}
+
+func (p ImagePromotion) promote(ctx context.Context, rawCfg api.Config, ctxName string, timeout time.Duration, progress func(string)) error {
+	dc, err := dynClientFor(rawCfg, ctxName)
+	if err != nil {
+		return err
+	}
+	ns, n := client.Namespaced(p.Path)
+	res := dc.Resource(client.DpGVR.GVR()).Namespace(ns)
+	o, err := res.Get(ctx, n, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if _, ok := ContainerImage(o, p.Container, p.Init); !ok {
+		return fmt.Errorf("no container %q in %s", p.Container, p.Path)
+	}
+	patch, err := ImagePatch(p.Container, p.Image, p.Init)
+	if err != nil {
+		return err
+	}
+	if _, err = res.Patch(ctx, n, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	t := time.NewTicker(promotePollInterval)
+	defer t.Stop()
+	for {
+		o, err := res.Get(ctx, n, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		done, msg, err := DeploymentRollout(o)
+		if err != nil || done {
+			return err
+		}
+		progress(msg)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("rollout not healthy after %s: %s", timeout, msg)
+		case <-t.C:
+		}
+	}
+}
+
+// DeploymentRollout checks whether a deployment rollout completed, akin to
+// kubectl rollout status. It returns the pending step otherwise.
+func DeploymentRollout(o *unstructured.Unstructured) (bool, string, error) {
+	var dp appsv1.Deployment
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(o.Object, &dp); err != nil {
+		return false, "", err
+	}
+	if dp.Generation > dp.Status.ObservedGeneration {
+		return false, "waiting for the new spec to be observed", nil
+	}
+	for _, c := range dp.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			return false, "", errors.New("rollout exceeded its progress deadline")
+		}
+	}
+	want := int32(1)
+	if dp.Spec.Replicas != nil {
+		want = *dp.Spec.Replicas
+	}
+	st := dp.Status
+	switch {
+	case st.UpdatedReplicas < want:
+		return false, fmt.Sprintf("%d of %d replicas updated", st.UpdatedReplicas, want), nil
+	case st.Replicas > st.UpdatedReplicas:
+		return false, fmt.Sprintf("%d old replicas pending termination", st.Replicas-st.UpdatedReplicas), nil
+	case st.AvailableReplicas < st.UpdatedReplicas:
+		return false, fmt.Sprintf("%d of %d updated replicas available", st.AvailableReplicas, st.UpdatedReplicas), nil
+	}
+
+	return true, "", nil
+}
+
+// ContainerImage returns the image of a workload container.
+func ContainerImage(o *unstructured.Unstructured, co string, init bool) (string, bool) {
+	key := "containers"
+	if init {
+		key = "initContainers"
+	}
+	cc, _, _ := unstructured.NestedSlice(o.Object, "spec", "template", "spec", key)
+	for _, c := range cc {
+		m, ok := c.(map[string]any)
+		if !ok || m["name"] != co {
+			continue
+		}
+		img, _ := m["image"].(string)
+		return img, true
+	}
+
+	return "", false
+}
+
+// ImagePatch returns a strategic merge patch setting a container image.
+func ImagePatch(co, image string, init bool) ([]byte, error) {
+	key := "containers"
+	if init {
+		key = "initContainers"
+	}
+
+	return json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					key: []map[string]any{{"name": co, "image": image}},
+				},
+			},
+		},
+	})
+}
+
+// WithTag swaps the tag of an image reference. Digests are dropped.
+func WithTag(image, tag string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+
+	return image + ":" + tag
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestWithTag(t *testing.T) {
+	uu := map[string]struct {
+		image, tag, e string
+	}{
+		"plain": {
+			image: "nginx:1.0",
+			tag:   "1.1",
+			e:     "nginx:1.1",
+		},
+		"no-tag": {
+			image: "nginx",
+			tag:   "1.1",
+			e:     "nginx:1.1",
+		},
+		"port": {
+			image: "reg.io:5000/team/app:v1",
+			tag:   "v2",
+			e:     "reg.io:5000/team/app:v2",
+		},
+		"port-no-tag": {
+			image: "reg.io:5000/team/app",
+			tag:   "v2",
+			e:     "reg.io:5000/team/app:v2",
+		},
+		"digest": {
+			image: "nginx:1.0@sha256:abc",
+			tag:   "1.1",
+			e:     "nginx:1.1",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.WithTag(u.image, u.tag))
+		})
+	}
+}
+
+func TestImagePatch(t *testing.T) {
+	bb, err := dao.ImagePatch("c1", "nginx:1.1", false)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"spec":{"template":{"spec":{"containers":[{"name":"c1","image":"nginx:1.1"}]}}}}`, string(bb))
+
+	bb, err = dao.ImagePatch("i1", "busybox:2", true)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"spec":{"template":{"spec":{"initContainers":[{"name":"i1","image":"busybox:2"}]}}}}`, string(bb))
+}
+
+func TestContainerImage(t *testing.T) {
+	o := makePromoteDp(1, 1, map[string]any{})
+
+	img, ok := dao.ContainerImage(o, "c1", false)
+	assert.True(t, ok)
+	assert.Equal(t, "nginx:1.0", img)
+
+	_, ok = dao.ContainerImage(o, "c1", true)
+	assert.False(t, ok)
+	_, ok = dao.ContainerImage(o, "c2", false)
+	assert.False(t, ok)
+}
+
+func TestDeploymentRollout(t *testing.T) {
+	uu := map[string]struct {
+		gen, observed int64
+		status        map[string]any
+		done          bool
+		msg           string
+		err           bool
+	}{
+		"done": {
+			gen: 2, observed: 2,
+			status: map[string]any{"replicas": int64(3), "updatedReplicas": int64(3), "availableReplicas": int64(3)},
+			done:   true,
+		},
+		"not-observed": {
+			gen: 3, observed: 2,
+			status: map[string]any{"replicas": int64(3), "updatedReplicas": int64(3), "availableReplicas": int64(3)},
+			msg:    "waiting for the new spec to be observed",
+		},
+		"updating": {
+			gen: 2, observed: 2,
+			status: map[string]any{"replicas": int64(4), "updatedReplicas": int64(1), "availableReplicas": int64(3)},
+			msg:    "1 of 3 replicas updated",
+		},
+		"terminating": {
+			gen: 2, observed: 2,
+			status: map[string]any{"replicas": int64(4), "updatedReplicas": int64(3), "availableReplicas": int64(3)},
+			msg:    "1 old replicas pending termination",
+		},
+		"unavailable": {
+			gen: 2, observed: 2,
+			status: map[string]any{"replicas": int64(3), "updatedReplicas": int64(3), "availableReplicas": int64(2)},
+			msg:    "2 of 3 updated replicas available",
+		},
+		"deadline": {
+			gen: 2, observed: 2,
+			status: map[string]any{
+				"replicas":        int64(3),
+				"updatedReplicas": int64(1),
+				"conditions": []any{
+					map[string]any{"type": "Progressing", "status": "False", "reason": "ProgressDeadlineExceeded"},
+				},
+			},
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			u.status["observedGeneration"] = u.observed
+			done, msg, err := dao.DeploymentRollout(makePromoteDp(u.gen, 3, u.status))
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.done, done)
+			assert.Equal(t, u.msg, msg)
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func makePromoteDp(gen, replicas int64, status map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":       "d1",
+			"namespace":  "ns1",
+			"generation": gen,
+		},
+		"spec": map[string]any{
+			"replicas": replicas,
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{"name": "c1", "image": "nginx:1.0"},
+					},
+				},
+			},
+		},
+		"status": status,
+	}}
+}
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/derailed/k9s/internal/slogs"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const nodeRolePrefix = "node-role.kubernetes.io/"
+
+// Inventory fields checked for drift.
+const (
+	DriftOS      = "os"
+	DriftKernel  = "kernel"
+	DriftRuntime = "runtime"
+	DriftKubelet = "kubelet"
+)
+
+var _ Accessor = (*Inventory)(nil)
+
+// Inventory tracks the node OS and component versions.
+type Inventory struct {
+	NonResource
+}
+
+// List returns the node inventory of the active context, or of all selected
+// contexts when more than one is selected.
+func (i *Inventory) List(ctx context.Context, _ string) ([]runtime.Object, error) {
+	f := i.getFactory()
+
+	sel, _ := config.LoadSelectedContexts()
+	if len(sel) < 2 {
+		oo, err := f.List(client.NodeGVR, client.ClusterScope, false, labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		rr := NodeInventory(f.Client().ActiveContext(), toNodes(oo))
+		MarkInventoryDrift(rr)
+		return inventoryObjects(rr), nil
+	}
+
+	rawCfg, err := f.Client().Config().RawConfig()
+	if err != nil {
+		return nil, err
+	}
+	nn, err := MultiContextList(ctx, rawCfg, sel, client.NodeGVR.GVR(), client.BlankNamespace, "")
+	failed := FailedContexts(err)
+	nodes := make(map[string][]runtime.Object)
+	for _, co := range nn {
+		nodes[co.Context] = append(nodes[co.Context], co.Object)
+	}
+	var rr []*render.InventoryRes
+	for _, c := range sel {
+		if _, ok := failed[c]; ok {
+			continue
+		}
+		rr = append(rr, NodeInventory(c, toNodes(nodes[c]))...)
+	}
+	MarkInventoryDrift(rr)
+
+	return inventoryObjects(rr), err
+}
+
+// NodeInventory extracts the OS and component versions of nodes.
+func NodeInventory(ctxName string, nn []*v1.Node) []*render.InventoryRes {
+	rr := make([]*render.InventoryRes, 0, len(nn))
+	for _, n := range nn {
+		info := n.Status.NodeInfo
+		rr = append(rr, &render.InventoryRes{
+			Context: ctxName,
+			Node:    n.Name,
+			Roles:   nodeRoles(n),
+			OSImage: info.OSImage,
+			Kernel:  info.KernelVersion,
+			Runtime: info.ContainerRuntimeVersion,
+			Kubelet: info.KubeletVersion,
+			Arch:    info.Architecture,
+		})
+	}
+	sort.Slice(rr, func(i, j int) bool {
+		return rr[i].Node < rr[j].Node
+	})
+
+	return rr
+}
+
+// MarkInventoryDrift flags the fields of each node departing from the most
+// common value across the inventory. Ties go to the highest value, assuming
+// it is the upgrade target.
+func MarkInventoryDrift(rr []*render.InventoryRes) {
+	fields := []struct {
+		name string
+		get  func(*render.InventoryRes) string
+	}{
+		{DriftOS, func(r *render.InventoryRes) string { return r.OSImage }},
+		{DriftKernel, func(r *render.InventoryRes) string { return r.Kernel }},
+		{DriftRuntime, func(r *render.InventoryRes) string { return r.Runtime }},
+		{DriftKubelet, func(r *render.InventoryRes) string { return r.Kubelet }},
+	}
+	for _, r := range rr {
+		r.Drift = nil
+	}
+	for _, f := range fields {
+		counts := make(map[string]int)
+		for _, r := range rr {
+			counts[f.get(r)]++
+		}
+		if len(counts) < 2 {
+			continue
+		}
+		var common string
+		for v, n := range counts {
+			if n > counts[common] || (n == counts[common] && v > common) {
+				common = v
+			}
+		}
+		for _, r := range rr {
+			if f.get(r) != common {
+				r.Drift = append(r.Drift, f.name)
+			}
+		}
+	}
+}
+
+func nodeRoles(n *v1.Node) string {
+	var rr []string
+	for k := range n.Labels {
+		if r, ok := strings.CutPrefix(k, nodeRolePrefix); ok && r != "" {
+			rr = append(rr, r)
+		}
+	}
+	sort.Strings(rr)
+
+	return strings.Join(rr, ",")
+}
+
+func toNodes(oo []runtime.Object) []*v1.Node {
+	nn := make([]*v1.Node, 0, len(oo))
+	for _, o := range oo {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		var n v1.Node
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &n); err != nil {
+			slog.Warn("Node conversion failed", slogs.Error, err)
+			continue
+		}
+		nn = append(nn, &n)
+	}
+
+	return nn
+}
+
+func inventoryObjects(rr []*render.InventoryRes) []runtime.Object {
+	oo := make([]runtime.Object, 0, len(rr))
+	for _, r := range rr {
+		oo = append(oo, r)
+	}
+
+	return oo
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeInventoryDrift(t *testing.T) {
+	rr := dao.NodeInventory("prod", []*v1.Node{
+		invNode("n3", "6.4.0-150600.23.25-default", "v1.30.4+rke2r1"),
+		invNode("n1", "6.4.0-150600.23.25-default", "v1.30.4+rke2r1"),
+		invNode("n2", "6.4.0-150600.23.30-default", "v1.30.4+rke2r1"),
+	})
+	rr = append(rr, dao.NodeInventory("edge", []*v1.Node{
+		invNode("e1", "6.4.0-150600.23.30-default", "v1.29.9+rke2r1"),
+	})...)
+	dao.MarkInventoryDrift(rr)
+
+	require.Len(t, rr, 4)
+	assert.Equal(t, "n1", rr[0].Node)
+	assert.Equal(t, "control-plane,etcd", rr[0].Roles)
+	// Kernels tie, the highest wins.
+	assert.Equal(t, []string{dao.DriftKernel}, rr[0].Drift)
+	assert.Empty(t, rr[1].Drift)
+	assert.Equal(t, []string{dao.DriftKernel}, rr[2].Drift)
+	assert.Equal(t, []string{dao.DriftKubelet}, rr[3].Drift)
+}
+
+// Helpers...
+
+func invNode(name, kernel, kubelet string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"node-role.kubernetes.io/etcd":          "true",
+				"node-role.kubernetes.io/control-plane": "true",
+			},
+		},
+		Status: v1.NodeStatus{
+			NodeInfo: v1.NodeSystemInfo{
+				OSImage:                 "SUSE Linux Enterprise Server 15 SP6",
+				KernelVersion:           kernel,
+				ContainerRuntimeVersion: "containerd://1.7.21-k3s2",
+				KubeletVersion:          kubelet,
+				Architecture:            "amd64",
+			},
+		},
+	}
+}
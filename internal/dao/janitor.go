@@ -0,0 +1,391 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/render"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// DefaultJanitorAge tracks how old resources must be to be cleaned up.
+	DefaultJanitorAge = 7 * 24 * time.Hour
+
+	revisionAnnotation    = "deployment.kubernetes.io/revision"
+	helmReleaseName       = "meta.helm.sh/release-name"
+	helmReleaseNamespace  = "meta.helm.sh/release-namespace"
+	helmManagedSelector   = "app.kubernetes.io/managed-by=Helm"
+	helmReleaseSelector   = "owner=helm"
+	tillerReleaseSelector = "OWNER=TILLER"
+)
+
+var _ Accessor = (*Janitor)(nil)
+
+// Janitor lists the cleanup candidates of the active or selected contexts.
+type Janitor struct {
+	NonResource
+}
+
+// List returns the resources older than the janitor age found in context.
+// Contexts failing to scan are skipped and reported.
+func (j *Janitor) List(ctx context.Context, ns string) ([]runtime.Object, error) {
+	age, ok := ctx.Value(internal.KeyJanitor).(time.Duration)
+	if !ok {
+		age = DefaultJanitorAge
+	}
+	f := j.getFactory()
+	rawCfg, err := f.Client().Config().RawConfig()
+	if err != nil {
+		return nil, err
+	}
+	ctxs := []string{f.Client().ActiveContext()}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		ctxs = sel
+	}
+
+	var (
+		oo []runtime.Object
+		rr = make([]ContextResult, 0, len(ctxs))
+	)
+	for _, c := range ctxs {
+		cc, err := JanitorScan(ctx, rawCfg, c, ns, age)
+		if err != nil && len(ctxs) == 1 {
+			return nil, err
+		}
+		rr = append(rr, ContextResult{Context: c, Count: len(cc), Err: err})
+		for _, c := range cc {
+			oo = append(oo, c.res())
+		}
+	}
+
+	return oo, NewMultiContextError(rr)
+}
+
+// JanitorCandidate represents a resource eligible for cleanup.
+type JanitorCandidate struct {
+	Context         string
+	GVR             *client.GVR
+	Path            string
+	UID             types.UID
+	ResourceVersion string
+	Reason          string
+}
+
+func (c JanitorCandidate) res() *render.JanitorRes {
+	ns, n := client.Namespaced(c.Path)
+
+	return &render.JanitorRes{
+		Context:         c.Context,
+		GVR:             c.GVR.String(),
+		Kind:            c.GVR.R(),
+		Namespace:       ns,
+		Name:            n,
+		UID:             string(c.UID),
+		ResourceVersion: c.ResourceVersion,
+		Reason:          c.Reason,
+	}
+}
+
+// JanitorScan lists the cleanup candidates of a context older than a given
+// age. The scan aborts if any list fails since candidates, ie orphaned Helm
+// ConfigMaps, can't be told apart from a partial view.
+func JanitorScan(ctx context.Context, rawCfg api.Config, ctxName, ns string, age time.Duration) ([]JanitorCandidate, error) {
+	now := time.Now()
+
+	var rss []appsv1.ReplicaSet
+	if err := listTyped(ctx, rawCfg, ctxName, client.RsGVR, ns, "", func(co ContextObject) {
+		var rs appsv1.ReplicaSet
+		if fromContextObject(co, &rs) {
+			rss = append(rss, rs)
+		}
+	}); err != nil {
+		return nil, err
+	}
+	var cms []v1.ConfigMap
+	for _, sel := range []string{helmManagedSelector, tillerReleaseSelector} {
+		if err := listTyped(ctx, rawCfg, ctxName, client.CmGVR, ns, sel, func(co ContextObject) {
+			var cm v1.ConfigMap
+			if fromContextObject(co, &cm) {
+				cms = append(cms, cm)
+			}
+		}); err != nil {
+			return nil, err
+		}
+	}
+	// Helm stores releases in Secrets or ConfigMaps depending on its driver.
+	releases := make(map[string]struct{})
+	for _, gvr := range []*client.GVR{client.SecGVR, client.CmGVR} {
+		if err := listTyped(ctx, rawCfg, ctxName, gvr, client.BlankNamespace, helmReleaseSelector, func(co ContextObject) {
+			if u, ok := co.Object.(*unstructured.Unstructured); ok {
+				releases[client.FQN(u.GetNamespace(), u.GetLabels()["name"])] = struct{}{}
+			}
+		}); err != nil {
+			return nil, err
+		}
+	}
+	var jobs []batchv1.Job
+	if err := listTyped(ctx, rawCfg, ctxName, client.JobGVR, ns, "", func(co ContextObject) {
+		var job batchv1.Job
+		if fromContextObject(co, &job) {
+			jobs = append(jobs, job)
+		}
+	}); err != nil {
+		return nil, err
+	}
+	var pods []v1.Pod
+	if err := listTyped(ctx, rawCfg, ctxName, client.PodGVR, ns, "", func(co ContextObject) {
+		var po v1.Pod
+		if fromContextObject(co, &po) {
+			pods = append(pods, po)
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	cc := StaleReplicaSets(rss, age, now)
+	cc = append(cc, OrphanedHelmConfigMaps(cms, releases)...)
+	cc = append(cc, ExpiredJobs(jobs, age, now)...)
+	cc = append(cc, FailedPods(pods, age, now)...)
+	for i := range cc {
+		cc[i].Context = ctxName
+	}
+
+	return cc, nil
+}
+
+func listTyped(ctx context.Context, rawCfg api.Config, ctxName string, gvr *client.GVR, ns, sel string, f func(ContextObject)) error {
+	oo, rr := MultiContextFetch(ctx, rawCfg, []string{ctxName}, gvr.GVR(), ns, sel, "")
+	for _, r := range rr {
+		if r.Err != nil {
+			return fmt.Errorf("list %s: %w", gvr.R(), r.Err)
+		}
+	}
+	for _, o := range oo {
+		f(o)
+	}
+
+	return nil
+}
+
+// StaleReplicaSets returns the scaled down ReplicaSets of older deployment
+// revisions. The latest revision of each deployment is always kept.
+func StaleReplicaSets(rss []appsv1.ReplicaSet, age time.Duration, now time.Time) []JanitorCandidate {
+	latest := make(map[string]int)
+	for i := range rss {
+		if o := metav1.GetControllerOf(&rss[i]); o != nil {
+			latest[string(o.UID)] = max(latest[string(o.UID)], rsRevision(&rss[i]))
+		}
+	}
+
+	var cc []JanitorCandidate
+	for i := range rss {
+		rs := &rss[i]
+		o := metav1.GetControllerOf(rs)
+		if o == nil || o.Kind != "Deployment" {
+			continue
+		}
+		if rs.Spec.Replicas == nil || *rs.Spec.Replicas != 0 || rs.Status.Replicas != 0 {
+			continue
+		}
+		rev := rsRevision(rs)
+		if rev >= latest[string(o.UID)] || now.Sub(rs.CreationTimestamp.Time) < age {
+			continue
+		}
+		cc = append(cc, JanitorCandidate{
+			GVR:             client.RsGVR,
+			Path:            client.FQN(rs.Namespace, rs.Name),
+			UID:             rs.UID,
+			ResourceVersion: rs.ResourceVersion,
+			Reason:          fmt.Sprintf("revision %d of deployment %s, scaled to zero", rev, o.Name),
+		})
+	}
+
+	return cc
+}
+
+func rsRevision(rs *appsv1.ReplicaSet) int {
+	n, _ := strconv.Atoi(rs.Annotations[revisionAnnotation])
+
+	return n
+}
+
+// OrphanedHelmConfigMaps returns the Helm managed ConfigMaps whose release is
+// gone, given the NS/NAME of the installed releases, and the Helm v2 release
+// records left by Tiller.
+func OrphanedHelmConfigMaps(cms []v1.ConfigMap, releases map[string]struct{}) []JanitorCandidate {
+	var cc []JanitorCandidate
+	for i := range cms {
+		cm := &cms[i]
+		path := client.FQN(cm.Namespace, cm.Name)
+		if cm.Labels["OWNER"] == "TILLER" {
+			cc = append(cc, JanitorCandidate{
+				GVR:             client.CmGVR,
+				Path:            path,
+				UID:             cm.UID,
+				ResourceVersion: cm.ResourceVersion,
+				Reason:          "helm v2 release record",
+			})
+			continue
+		}
+		rel := cm.Annotations[helmReleaseName]
+		if rel == "" {
+			continue
+		}
+		rns := cm.Annotations[helmReleaseNamespace]
+		if rns == "" {
+			rns = cm.Namespace
+		}
+		if _, ok := releases[client.FQN(rns, rel)]; ok {
+			continue
+		}
+		cc = append(cc, JanitorCandidate{
+			GVR:             client.CmGVR,
+			Path:            path,
+			UID:             cm.UID,
+			ResourceVersion: cm.ResourceVersion,
+			Reason:          fmt.Sprintf("helm release %s is gone", client.FQN(rns, rel)),
+		})
+	}
+
+	return cc
+}
+
+// ExpiredJobs returns the finished jobs past their TTL. Jobs without a TTL
+// are returned once finished for longer than a given age, unless a CronJob
+// owns them since it trims its own history.
+func ExpiredJobs(jobs []batchv1.Job, age time.Duration, now time.Time) []JanitorCandidate {
+	var cc []JanitorCandidate
+	for i := range jobs {
+		job := &jobs[i]
+		done, ok := jobFinished(job)
+		if !ok {
+			continue
+		}
+		var reason string
+		switch ttl := job.Spec.TTLSecondsAfterFinished; {
+		case ttl != nil:
+			d := time.Duration(*ttl) * time.Second
+			if now.Sub(done) <= d {
+				continue
+			}
+			reason = fmt.Sprintf("finished %s ago, past its %s TTL", duration.HumanDuration(now.Sub(done)), duration.HumanDuration(d))
+		case isCronJobOwned(job):
+			continue
+		default:
+			if now.Sub(done) < age {
+				continue
+			}
+			reason = fmt.Sprintf("finished %s ago, no TTL", duration.HumanDuration(now.Sub(done)))
+		}
+		cc = append(cc, JanitorCandidate{
+			GVR:             client.JobGVR,
+			Path:            client.FQN(job.Namespace, job.Name),
+			UID:             job.UID,
+			ResourceVersion: job.ResourceVersion,
+			Reason:          reason,
+		})
+	}
+
+	return cc
+}
+
+func jobFinished(job *batchv1.Job) (time.Time, bool) {
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == v1.ConditionTrue {
+			return c.LastTransitionTime.Time, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func isCronJobOwned(job *batchv1.Job) bool {
+	o := metav1.GetControllerOf(job)
+
+	return o != nil && o.Kind == "CronJob"
+}
+
+// FailedPods returns the failed pods older than a given age. Pods owned by a
+// job are left to the job cleanup.
+func FailedPods(pods []v1.Pod, age time.Duration, now time.Time) []JanitorCandidate {
+	var cc []JanitorCandidate
+	for i := range pods {
+		po := &pods[i]
+		if po.Status.Phase != v1.PodFailed {
+			continue
+		}
+		if o := metav1.GetControllerOf(po); o != nil && o.Kind == "Job" {
+			continue
+		}
+		since := po.CreationTimestamp.Time
+		if po.Status.StartTime != nil {
+			since = po.Status.StartTime.Time
+		}
+		if now.Sub(since) < age {
+			continue
+		}
+		reason := po.Status.Reason
+		if reason == "" {
+			reason = "Failed"
+		}
+		cc = append(cc, JanitorCandidate{
+			GVR:             client.PodGVR,
+			Path:            client.FQN(po.Namespace, po.Name),
+			UID:             po.UID,
+			ResourceVersion: po.ResourceVersion,
+			Reason:          fmt.Sprintf("%s %s ago", reason, duration.HumanDuration(now.Sub(since))),
+		})
+	}
+
+	return cc
+}
+
+// JanitorDelete deletes a cleanup candidate, server side only when dry is set.
+// The deletion is rejected if the resource was replaced or updated since the
+// scan.
+func JanitorDelete(ctx context.Context, rawCfg api.Config, c JanitorCandidate, dry bool) error {
+	dc, err := dynClientFor(rawCfg, c.Context)
+	if err != nil {
+		return err
+	}
+	p := metav1.DeletePropagationBackground
+	opts := metav1.DeleteOptions{
+		PropagationPolicy: &p,
+		Preconditions:     janitorPreconditions(c),
+	}
+	if dry {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	ns, n := client.Namespaced(c.Path)
+
+	return dc.Resource(c.GVR.GVR()).Namespace(ns).Delete(ctx, n, opts)
+}
+
+func janitorPreconditions(c JanitorCandidate) *metav1.Preconditions {
+	var p metav1.Preconditions
+	if c.UID != "" {
+		p.UID = &c.UID
+	}
+	if c.ResourceVersion != "" {
+		p.ResourceVersion = &c.ResourceVersion
+	}
+
+	return &p
+}
@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestStaleReplicaSets(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	rs := func(name, rev string, replicas int32, age time.Duration) appsv1.ReplicaSet {
+		ctrl := true
+		return appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "ns1",
+				Name:              name,
+				UID:               types.UID(name),
+				ResourceVersion:   rev,
+				Annotations:       map[string]string{"deployment.kubernetes.io/revision": rev},
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "Deployment", Name: "fred", UID: "u1", Controller: &ctrl},
+				},
+			},
+			Spec:   appsv1.ReplicaSetSpec{Replicas: &replicas},
+			Status: appsv1.ReplicaSetStatus{Replicas: replicas},
+		}
+	}
+	rss := []appsv1.ReplicaSet{
+		rs("fred-1", "1", 0, 30*24*time.Hour),
+		rs("fred-2", "2", 0, time.Hour),
+		rs("fred-3", "3", 0, 30*24*time.Hour),
+		rs("fred-4", "4", 0, time.Hour),
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "bare"}},
+	}
+	rss[3].Spec.Replicas, rss[3].Status.Replicas = nil, 1
+
+	assert.Equal(t, []dao.JanitorCandidate{
+		{GVR: client.RsGVR, Path: "ns1/fred-1", UID: "fred-1", ResourceVersion: "1", Reason: "revision 1 of deployment fred, scaled to zero"},
+		{GVR: client.RsGVR, Path: "ns1/fred-3", UID: "fred-3", ResourceVersion: "3", Reason: "revision 3 of deployment fred, scaled to zero"},
+	}, dao.StaleReplicaSets(rss, dao.DefaultJanitorAge, now))
+}
+
+func TestOrphanedHelmConfigMaps(t *testing.T) {
+	cms := []v1.ConfigMap{
+		{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns1",
+			Name:        "cm1",
+			Annotations: map[string]string{"meta.helm.sh/release-name": "r1", "meta.helm.sh/release-namespace": "ns1"},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns1",
+			Name:        "cm2",
+			Annotations: map[string]string{"meta.helm.sh/release-name": "r2"},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "kube-system",
+			Name:      "r3.v1",
+			Labels:    map[string]string{"OWNER": "TILLER"},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "cm3"}},
+	}
+
+	assert.Equal(t, []dao.JanitorCandidate{
+		{GVR: client.CmGVR, Path: "ns1/cm2", Reason: "helm release ns1/r2 is gone"},
+		{GVR: client.CmGVR, Path: "kube-system/r3.v1", Reason: "helm v2 release record"},
+	}, dao.OrphanedHelmConfigMaps(cms, map[string]struct{}{"ns1/r1": {}}))
+}
+
+func TestExpiredJobs(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	ctrl := true
+
+	uu := map[string]struct {
+		ttl   *int32
+		owner string
+		done  time.Duration
+		cond  batchv1.JobConditionType
+		e     string
+	}{
+		"running": {
+			done: 30 * 24 * time.Hour,
+		},
+		"within-ttl": {
+			ttl:  int32Ptr(3600),
+			done: 30 * time.Minute,
+			cond: batchv1.JobComplete,
+		},
+		"past-ttl": {
+			ttl:  int32Ptr(60),
+			done: 2 * time.Hour,
+			cond: batchv1.JobFailed,
+			e:    "finished 120m ago, past its 60s TTL",
+		},
+		"no-ttl": {
+			done: 8 * 24 * time.Hour,
+			cond: batchv1.JobComplete,
+			e:    "finished 8d ago, no TTL",
+		},
+		"no-ttl-recent": {
+			done: time.Hour,
+			cond: batchv1.JobComplete,
+		},
+		"cronjob": {
+			owner: "CronJob",
+			done:  30 * 24 * time.Hour,
+			cond:  batchv1.JobComplete,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			job := batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: k},
+				Spec:       batchv1.JobSpec{TTLSecondsAfterFinished: u.ttl},
+			}
+			if u.owner != "" {
+				job.OwnerReferences = []metav1.OwnerReference{{Kind: u.owner, Name: "c1", Controller: &ctrl}}
+			}
+			if u.cond != "" {
+				job.Status.Conditions = []batchv1.JobCondition{{
+					Type:               u.cond,
+					Status:             v1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(now.Add(-u.done)),
+				}}
+			}
+			cc := dao.ExpiredJobs([]batchv1.Job{job}, dao.DefaultJanitorAge, now)
+			if u.e == "" {
+				assert.Empty(t, cc)
+				return
+			}
+			assert.Equal(t, []dao.JanitorCandidate{{GVR: client.JobGVR, Path: "ns1/" + k, Reason: u.e}}, cc)
+		})
+	}
+}
+
+func TestFailedPods(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	ctrl := true
+	pods := []v1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "p1", CreationTimestamp: metav1.NewTime(now.Add(-10 * 24 * time.Hour))},
+			Status:     v1.PodStatus{Phase: v1.PodFailed, Reason: "Evicted"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "p2", CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+			Status:     v1.PodStatus{Phase: v1.PodFailed},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "ns1",
+				Name:              "p3",
+				CreationTimestamp: metav1.NewTime(now.Add(-10 * 24 * time.Hour)),
+				OwnerReferences:   []metav1.OwnerReference{{Kind: "Job", Name: "j1", Controller: &ctrl}},
+			},
+			Status: v1.PodStatus{Phase: v1.PodFailed},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "p4", CreationTimestamp: metav1.NewTime(now.Add(-10 * 24 * time.Hour))},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		},
+	}
+
+	assert.Equal(t, []dao.JanitorCandidate{
+		{GVR: client.PodGVR, Path: "ns1/p1", Reason: "Evicted 10d ago"},
+	}, dao.FailedPods(pods, dao.DefaultJanitorAge, now))
+}
+
+// Helpers...
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KubeletReport represents the kubelet health of a node as seen through the
+// API server node proxy.
+type KubeletReport struct {
+	Node           string
+	KubeletVersion string
+	RuntimeVersion string
+	KernelVersion  string
+	OSImage        string
+	Healthz        []string
+	HealthzErr     error
+	PLEGRelistAvg  time.Duration
+	PLEGLastSeen   time.Time
+	MetricsErr     error
+	Summary        *KubeletSummary
+	SummaryErr     error
+}
+
+// KubeletSummary represents the subset of the kubelet stats summary rk9s uses.
+type KubeletSummary struct {
+	Node struct {
+		Fs      *KubeletFsStats `json:"fs,omitempty"`
+		Runtime *struct {
+			ImageFs *KubeletFsStats `json:"imageFs,omitempty"`
+		} `json:"runtime,omitempty"`
+	} `json:"node"`
+	Pods []KubeletPodStats `json:"pods"`
+}
+
+// KubeletFsStats represents filesystem usage stats.
+type KubeletFsStats struct {
+	AvailableBytes *uint64 `json:"availableBytes,omitempty"`
+	CapacityBytes  *uint64 `json:"capacityBytes,omitempty"`
+	UsedBytes      *uint64 `json:"usedBytes,omitempty"`
+}
+
+// KubeletPodStats represents a pod stats summary.
+type KubeletPodStats struct {
+	PodRef struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"podRef"`
+	Containers []struct {
+		Name string `json:"name"`
+	} `json:"containers"`
+	Volumes []struct {
+		Name string `json:"name"`
+		KubeletFsStats
+	} `json:"volume,omitempty"`
+	EphemeralStorage *KubeletFsStats `json:"ephemeral-storage,omitempty"`
+}
+
+// FetchKubeletReport queries the kubelet healthz, metrics and stats endpoints of
+// a node via the API server proxy. Endpoint failures are reported individually.
+func FetchKubeletReport(ctx context.Context, c client.Connection, node string) (*KubeletReport, error) {
+	dial, err := c.Dial()
+	if err != nil {
+		return nil, err
+	}
+	no, err := dial.CoreV1().Nodes().Get(ctx, node, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	r := KubeletReport{
+		Node:           node,
+		KubeletVersion: no.Status.NodeInfo.KubeletVersion,
+		RuntimeVersion: no.Status.NodeInfo.ContainerRuntimeVersion,
+		KernelVersion:  no.Status.NodeInfo.KernelVersion,
+		OSImage:        no.Status.NodeInfo.OSImage,
+	}
+
+	proxy := func(path string, params ...string) ([]byte, error) {
+		req := dial.CoreV1().RESTClient().Get().AbsPath("/api/v1/nodes", node, "proxy", path)
+		for i := 0; i+1 < len(params); i += 2 {
+			req = req.Param(params[i], params[i+1])
+		}
+		return req.DoRaw(ctx)
+	}
+
+	// Kubelet answers with a non 200 code and the check list when unhealthy.
+	bb, err := proxy("healthz", "verbose", "true")
+	r.Healthz, r.HealthzErr = nonBlankLines(bb), err
+
+	if bb, err = proxy("metrics"); err != nil {
+		r.MetricsErr = err
+	} else {
+		r.PLEGRelistAvg, r.PLEGLastSeen = PLEGStats(bb)
+	}
+
+	if bb, err = proxy("stats/summary"); err != nil {
+		r.SummaryErr = err
+	} else {
+		var s KubeletSummary
+		if err := json.Unmarshal(bb, &s); err != nil {
+			r.SummaryErr = err
+		} else {
+			r.Summary = &s
+		}
+	}
+
+	return &r, nil
+}
+
+// PLEGStats extracts the average pod lifecycle event generator relist duration
+// and the time the PLEG was last seen active from kubelet metrics.
+func PLEGStats(bb []byte) (avg time.Duration, lastSeen time.Time) {
+	var sum, count float64
+	scanner := bufio.NewScanner(bytes.NewReader(bb))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, val, ok := metricSample(line)
+		if !ok {
+			continue
+		}
+		switch name {
+		case "kubelet_pleg_relist_duration_seconds_sum":
+			sum = val
+		case "kubelet_pleg_relist_duration_seconds_count":
+			count = val
+		case "kubelet_pleg_last_seen_seconds":
+			sec := int64(val)
+			lastSeen = time.Unix(sec, int64((val-float64(sec))*float64(time.Second)))
+		}
+	}
+	if count > 0 {
+		avg = time.Duration(sum / count * float64(time.Second))
+	}
+
+	return
+}
+
+func metricSample(line string) (string, float64, bool) {
+	i := strings.LastIndexByte(line, ' ')
+	if i < 0 {
+		return "", 0, false
+	}
+	name := line[:i]
+	if j := strings.IndexByte(name, '{'); j >= 0 {
+		name = name[:j]
+	}
+	v, err := strconv.ParseFloat(line[i+1:], 64)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return strings.TrimSpace(name), v, true
+}
+
+func nonBlankLines(bb []byte) []string {
+	var ss []string
+	for _, l := range strings.Split(string(bb), "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			ss = append(ss, l)
+		}
+	}
+
+	return ss
+}
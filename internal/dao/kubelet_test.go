@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPLEGStats(t *testing.T) {
+	uu := map[string]struct {
+		metrics  string
+		avg      time.Duration
+		lastSeen time.Time
+	}{
+		"empty": {},
+		"plain": {
+			metrics: `# HELP kubelet_pleg_relist_duration_seconds [ALPHA] Duration in seconds for relisting pods in PLEG.
+# TYPE kubelet_pleg_relist_duration_seconds histogram
+kubelet_pleg_relist_duration_seconds_bucket{le="0.005"} 10
+kubelet_pleg_relist_duration_seconds_sum 2
+kubelet_pleg_relist_duration_seconds_count 200
+# TYPE kubelet_pleg_last_seen_seconds gauge
+kubelet_pleg_last_seen_seconds 1.7e+09
+`,
+			avg:      10 * time.Millisecond,
+			lastSeen: time.Unix(1_700_000_000, 0),
+		},
+		"garbage": {
+			metrics: "kubelet_pleg_relist_duration_seconds_count fred\nblee\n",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			avg, lastSeen := dao.PLEGStats([]byte(u.metrics))
+			assert.Equal(t, u.avg, avg)
+			assert.True(t, u.lastSeen.Equal(lastSeen))
+		})
+	}
+}
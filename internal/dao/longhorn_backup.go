@@ -0,0 +1,281 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	longhornJobLabel      = "recurring-job.longhorn.io/"
+	longhornJobGroupLabel = "recurring-job-group.longhorn.io/"
+	longhornDefaultGroup  = "default"
+	longhornS3Endpoint    = "AWS_ENDPOINTS"
+)
+
+// longhornTargetProbeScript checks a backup target from inside the cluster.
+// Any HTTP answer, even an auth error, proves the object store is reachable.
+const longhornTargetProbeScript = `case "$1" in
+http)
+  out=$(wget -T 10 --spider --no-check-certificate "$2" 2>&1) && { echo reachable; exit 0; }
+  echo "$out" | grep -q "HTTP/" && echo reachable || echo "unreachable: $(echo "$out" | tail -n 1)"
+  ;;
+tcp)
+  nc -w 10 "$2" "$3" </dev/null >/dev/null 2>&1 && echo reachable || echo "unreachable: no answer on $2:$3"
+  ;;
+esac`
+
+// LonghornBackupTarget represents a Longhorn backup target.
+type LonghornBackupTarget struct {
+	Name        string
+	URL         string
+	Credentials string
+	Available   bool
+	LastSync    time.Time
+	Message     string
+}
+
+// Scheme returns the backup target protocol, ie s3, nfs, cifs or azblob.
+func (t LonghornBackupTarget) Scheme() string {
+	s, _, _ := strings.Cut(t.URL, "://")
+
+	return s
+}
+
+// LonghornRecurringJob represents a Longhorn recurring job and its next run.
+type LonghornRecurringJob struct {
+	Name        string
+	Task        string
+	Cron        string
+	Retain      int64
+	Concurrency int64
+	Groups      []string
+	Next        time.Time
+	Err         error
+}
+
+// IsBackup checks if the job backs volumes up to the backup target.
+func (j LonghornRecurringJob) IsBackup() bool {
+	return j.Task == "backup" || j.Task == "backup-force-create"
+}
+
+// LonghornUncoveredVolume represents a volume no recurring backup applies to.
+type LonghornUncoveredVolume struct {
+	Volume string
+	PVC    string
+	State  string
+}
+
+// LonghornBackupReport represents the Longhorn backup setup of a cluster.
+type LonghornBackupReport struct {
+	Targets   []LonghornBackupTarget
+	Jobs      []LonghornRecurringJob
+	Uncovered []LonghornUncoveredVolume
+}
+
+// FetchLonghornBackups collects the backup targets, the recurring jobs and
+// the volumes left out of recurring backups.
+func FetchLonghornBackups(ctx context.Context, c client.Connection, now time.Time) (*LonghornBackupReport, error) {
+	dyn, err := c.DynDial()
+	if err != nil {
+		return nil, err
+	}
+	tt, err := dyn.Resource(client.LonghornTargetGVR.GVR()).Namespace(LonghornNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list Longhorn backup targets: %w", err)
+	}
+	jj, err := dyn.Resource(client.LonghornJobGVR.GVR()).Namespace(LonghornNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list Longhorn recurring jobs: %w", err)
+	}
+	vv, err := dyn.Resource(client.LonghornVolumeGVR.GVR()).Namespace(LonghornNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list Longhorn volumes: %w", err)
+	}
+
+	jobs := LonghornRecurringJobs(jj.Items, now)
+
+	return &LonghornBackupReport{
+		Targets:   LonghornBackupTargets(tt.Items),
+		Jobs:      jobs,
+		Uncovered: LonghornUncoveredVolumes(vv.Items, jobs),
+	}, nil
+}
+
+// LonghornBackupTargets converts backup target resources.
+func LonghornBackupTargets(uu []unstructured.Unstructured) []LonghornBackupTarget {
+	tt := make([]LonghornBackupTarget, 0, len(uu))
+	for i := range uu {
+		u := &uu[i]
+		t := LonghornBackupTarget{Name: u.GetName()}
+		t.URL, _, _ = unstructured.NestedString(u.Object, "spec", "backupTargetURL")
+		t.Credentials, _, _ = unstructured.NestedString(u.Object, "spec", "credentialSecret")
+		t.Available, _, _ = unstructured.NestedBool(u.Object, "status", "available")
+		if s, _, _ := unstructured.NestedString(u.Object, "status", "lastSyncedAt"); s != "" {
+			t.LastSync, _ = time.Parse(time.RFC3339, s)
+		}
+		cc, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+		for _, c := range cc {
+			if m, ok := c.(map[string]any); ok && m["type"] == "Unavailable" && m["status"] == string(metav1.ConditionTrue) {
+				t.Message, _ = m["message"].(string)
+			}
+		}
+		tt = append(tt, t)
+	}
+	sort.Slice(tt, func(i, j int) bool {
+		return tt[i].Name < tt[j].Name
+	})
+
+	return tt
+}
+
+// LonghornRecurringJobs converts recurring job resources, computing their
+// next run from their cron schedule.
+func LonghornRecurringJobs(uu []unstructured.Unstructured, now time.Time) []LonghornRecurringJob {
+	jj := make([]LonghornRecurringJob, 0, len(uu))
+	for i := range uu {
+		u := &uu[i]
+		j := LonghornRecurringJob{Name: u.GetName()}
+		j.Task, _, _ = unstructured.NestedString(u.Object, "spec", "task")
+		j.Cron, _, _ = unstructured.NestedString(u.Object, "spec", "cron")
+		j.Retain, _, _ = unstructured.NestedInt64(u.Object, "spec", "retain")
+		j.Concurrency, _, _ = unstructured.NestedInt64(u.Object, "spec", "concurrency")
+		j.Groups, _, _ = unstructured.NestedStringSlice(u.Object, "spec", "groups")
+		if s, err := ParseCron(j.Cron); err != nil {
+			j.Err = err
+		} else {
+			j.Next = s.Next(now)
+		}
+		jj = append(jj, j)
+	}
+	sort.Slice(jj, func(i, j int) bool {
+		if jj[i].Next.Equal(jj[j].Next) {
+			return jj[i].Name < jj[j].Name
+		}
+		if jj[i].Next.IsZero() || jj[j].Next.IsZero() {
+			return !jj[i].Next.IsZero()
+		}
+		return jj[i].Next.Before(jj[j].Next)
+	})
+
+	return jj
+}
+
+// LonghornUncoveredVolumes returns the volumes no recurring backup job
+// applies to. Volumes without any recurring job label fall in the default
+// group, like Longhorn does.
+func LonghornUncoveredVolumes(vv []unstructured.Unstructured, jj []LonghornRecurringJob) []LonghornUncoveredVolume {
+	jobs, groups := make(map[string]struct{}), make(map[string]struct{})
+	for _, j := range jj {
+		if !j.IsBackup() {
+			continue
+		}
+		jobs[j.Name] = struct{}{}
+		for _, g := range j.Groups {
+			groups[g] = struct{}{}
+		}
+	}
+
+	var out []LonghornUncoveredVolume
+	for i := range vv {
+		u := &vv[i]
+		if longhornVolumeCovered(u.GetLabels(), jobs, groups) {
+			continue
+		}
+		v := LonghornUncoveredVolume{Volume: u.GetName()}
+		v.State, _, _ = unstructured.NestedString(u.Object, "status", "state")
+		ns, _, _ := unstructured.NestedString(u.Object, "status", "kubernetesStatus", "namespace")
+		if pvc, _, _ := unstructured.NestedString(u.Object, "status", "kubernetesStatus", "pvcName"); pvc != "" {
+			v.PVC = client.FQN(ns, pvc)
+		}
+		out = append(out, v)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Volume < out[j].Volume
+	})
+
+	return out
+}
+
+func longhornVolumeCovered(ll map[string]string, jobs, groups map[string]struct{}) bool {
+	var labeled bool
+	for k, v := range ll {
+		if j, ok := strings.CutPrefix(k, longhornJobLabel); ok {
+			labeled = true
+			if _, ok := jobs[j]; ok && v == "enabled" {
+				return true
+			}
+		}
+		if g, ok := strings.CutPrefix(k, longhornJobGroupLabel); ok {
+			labeled = true
+			if _, ok := groups[g]; ok && v == "enabled" {
+				return true
+			}
+		}
+	}
+	if labeled {
+		return false
+	}
+	_, ok := groups[longhornDefaultGroup]
+
+	return ok
+}
+
+// LonghornTargetProbe returns the command checking a backup target is
+// reachable from the cluster. S3 targets use the endpoint of their
+// credentials secret, AWS otherwise.
+func LonghornTargetProbe(t LonghornBackupTarget, creds map[string][]byte) ([]string, error) {
+	u, err := url.Parse(t.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup target url %q: %w", t.URL, err)
+	}
+	probe := func(args ...string) []string {
+		return append([]string{"sh", "-c", longhornTargetProbeScript, "probe"}, args...)
+	}
+	switch u.Scheme {
+	case "s3":
+		if ep := strings.TrimSpace(string(creds[longhornS3Endpoint])); ep != "" {
+			return probe("http", ep), nil
+		}
+		// s3://bucket@region/path
+		region := u.Hostname()
+		if region == "" {
+			return nil, fmt.Errorf("no region nor %s endpoint for %q", longhornS3Endpoint, t.URL)
+		}
+		return probe("http", "https://s3."+region+".amazonaws.com"), nil
+	case "nfs":
+		return probe("tcp", u.Hostname(), "2049"), nil
+	case "cifs":
+		return probe("tcp", u.Hostname(), "445"), nil
+	default:
+		return nil, fmt.Errorf("%s backup targets can not be probed", u.Scheme)
+	}
+}
+
+// LonghornTargetCredentials returns the credentials secret data of a
+// backup target.
+func LonghornTargetCredentials(ctx context.Context, c client.Connection, t LonghornBackupTarget) (map[string][]byte, error) {
+	if t.Credentials == "" {
+		return nil, nil
+	}
+	dial, err := c.Dial()
+	if err != nil {
+		return nil, err
+	}
+	sec, err := dial.CoreV1().Secrets(LonghornNamespace).Get(ctx, t.Credentials, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return sec.Data, nil
+}
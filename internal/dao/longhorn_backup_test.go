@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestLonghornUncoveredVolumes(t *testing.T) {
+	jj := dao.LonghornRecurringJobs([]unstructured.Unstructured{
+		lhJob("nightly", "backup", "0 2 * * *", "default"),
+		lhJob("weekly", "backup", "0 3 * * 0", "gold"),
+		lhJob("hourly", "snapshot", "0 * * * *", "silver"),
+	}, time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC))
+	require.Len(t, jj, 3)
+	assert.Equal(t, "hourly", jj[0].Name)
+	assert.Equal(t, time.Date(2026, 1, 2, 11, 0, 0, 0, time.UTC), jj[0].Next)
+
+	vv := []unstructured.Unstructured{
+		lhVolume("unlabeled", nil),
+		lhVolume("gold", map[string]string{"recurring-job-group.longhorn.io/gold": "enabled"}),
+		lhVolume("silver", map[string]string{"recurring-job-group.longhorn.io/silver": "enabled"}),
+		lhVolume("job", map[string]string{"recurring-job.longhorn.io/weekly": "enabled"}),
+		lhVolume("snap", map[string]string{"recurring-job.longhorn.io/hourly": "enabled"}),
+	}
+	uu := dao.LonghornUncoveredVolumes(vv, jj)
+	require.Len(t, uu, 2)
+	assert.Equal(t, "silver", uu[0].Volume)
+	assert.Equal(t, "app/data-silver", uu[0].PVC)
+	assert.Equal(t, "snap", uu[1].Volume)
+
+	assert.Len(t, dao.LonghornUncoveredVolumes(vv[:1], jj[:1]), 1)
+}
+
+func TestLonghornTargetProbe(t *testing.T) {
+	uu := map[string]struct {
+		url   string
+		creds map[string][]byte
+		e     []string
+		err   bool
+	}{
+		"s3-aws": {
+			url: "s3://backups@us-east-1/",
+			e:   []string{"http", "https://s3.us-east-1.amazonaws.com"},
+		},
+		"s3-minio": {
+			url:   "s3://backups@us-east-1/",
+			creds: map[string][]byte{"AWS_ENDPOINTS": []byte("https://minio.local:9000\n")},
+			e:     []string{"http", "https://minio.local:9000"},
+		},
+		"nfs": {
+			url: "nfs://nfs.example.com:/opt/backupstore",
+			e:   []string{"tcp", "nfs.example.com", "2049"},
+		},
+		"cifs": {
+			url: "cifs://smb.example.com/backups",
+			e:   []string{"tcp", "smb.example.com", "445"},
+		},
+		"azure": {
+			url: "azblob://backups@core.windows.net/",
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			args, err := dao.LonghornTargetProbe(dao.LonghornBackupTarget{URL: u.url}, u.creds)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.e, args[4:])
+		})
+	}
+}
+
+// Helpers...
+
+func lhJob(name, task, cron, group string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": name},
+		"spec": map[string]any{
+			"task":   task,
+			"cron":   cron,
+			"retain": int64(7),
+			"groups": []any{group},
+		},
+	}}
+}
+
+func lhVolume(name string, ll map[string]string) unstructured.Unstructured {
+	u := unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": name},
+		"status": map[string]any{
+			"state": "attached",
+			"kubernetesStatus": map[string]any{
+				"namespace": "app",
+				"pvcName":   "data-" + name,
+			},
+		},
+	}}
+	u.SetLabels(ll)
+
+	return u
+}
@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	longhornEvictPoll      = 5 * time.Second
+	longhornDegraded       = "degraded"
+	longhornFaulted        = "faulted"
+	longhornEvictionPatch  = `{"spec":{"evictionRequested":true}}`
+	longhornEvictionClear  = `{"spec":{"evictionRequested":false}}`
+	longhornClearTimeout   = 10 * time.Second
+	longhornLastReplicaMsg = "last healthy replica"
+)
+
+// LonghornVolumeRisk represents a Longhorn volume at risk when draining a node.
+type LonghornVolumeRisk struct {
+	Volume     string
+	Robustness string
+	Replicas   int
+	Healthy    int
+	OnNode     int
+}
+
+// Left returns the healthy replicas left once the node is drained.
+func (r LonghornVolumeRisk) Left() int {
+	return r.Healthy - r.OnNode
+}
+
+// Reason returns why the volume is at risk.
+func (r LonghornVolumeRisk) Reason() string {
+	if r.Left() <= 0 {
+		return longhornLastReplicaMsg
+	}
+
+	return fmt.Sprintf("%s, %d/%d healthy replicas left", r.Robustness, r.Left(), r.Replicas)
+}
+
+func (r LonghornVolumeRisk) String() string {
+	return r.Volume + " (" + r.Reason() + ")"
+}
+
+// LonghornDrainRisks returns the Longhorn volumes that would either lose their
+// last healthy replica or degrade further when draining a node.
+func LonghornDrainRisks(node string, vols, replicas []runtime.Object) []LonghornVolumeRisk {
+	type count struct{ healthy, onNode int }
+	counts := make(map[string]count)
+	for _, o := range replicas {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok || !longhornReplicaHealthy(u) {
+			continue
+		}
+		vol, _, _ := unstructured.NestedString(u.Object, "spec", "volumeName")
+		c := counts[vol]
+		c.healthy++
+		if n, _, _ := unstructured.NestedString(u.Object, "spec", "nodeID"); n == node {
+			c.onNode++
+		}
+		counts[vol] = c
+	}
+
+	var rr []LonghornVolumeRisk
+	for _, o := range vols {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		c := counts[u.GetName()]
+		if c.onNode == 0 {
+			continue
+		}
+		rob, _, _ := unstructured.NestedString(u.Object, "status", "robustness")
+		n, _, _ := unstructured.NestedInt64(u.Object, "spec", "numberOfReplicas")
+		r := LonghornVolumeRisk{
+			Volume:     u.GetName(),
+			Robustness: rob,
+			Replicas:   int(n),
+			Healthy:    c.healthy,
+			OnNode:     c.onNode,
+		}
+		if r.Left() > 0 && rob != longhornDegraded && rob != longhornFaulted {
+			continue
+		}
+		rr = append(rr, r)
+	}
+	sort.Slice(rr, func(i, j int) bool {
+		return rr[i].Volume < rr[j].Volume
+	})
+
+	return rr
+}
+
+// LonghornDrainCheck returns the Longhorn volumes at risk by node. It reports
+// false when Longhorn is not installed.
+func LonghornDrainCheck(f Factory, nodes []string) (map[string][]LonghornVolumeRisk, bool, error) {
+	if _, err := MetaAccess.MetaFor(client.LonghornVolumeGVR); err != nil {
+		return nil, false, nil
+	}
+	vols, err := f.List(client.LonghornVolumeGVR, LonghornNamespace, true, labels.Everything())
+	if err != nil {
+		return nil, true, err
+	}
+	replicas, err := f.List(client.LonghornReplicaGVR, LonghornNamespace, true, labels.Everything())
+	if err != nil {
+		return nil, true, err
+	}
+	risks := make(map[string][]LonghornVolumeRisk, len(nodes))
+	for _, n := range nodes {
+		if rr := LonghornDrainRisks(n, vols, replicas); len(rr) > 0 {
+			risks[n] = rr
+		}
+	}
+
+	return risks, true, nil
+}
+
+// EvictLonghornReplicas requests Longhorn to move the replicas off a node and
+// waits until none are left on it. The eviction request is withdrawn should
+// the eviction fail so the node keeps serving replicas.
+func EvictLonghornReplicas(ctx context.Context, f Factory, node string, w io.Writer) (err error) {
+	dial, err := f.Client().DynDial()
+	if err != nil {
+		return err
+	}
+	_, err = dial.Resource(client.LonghornNodeGVR.GVR()).
+		Namespace(LonghornNamespace).
+		Patch(ctx, node, types.MergePatchType, []byte(longhornEvictionPatch), metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("longhorn eviction request failed on node %s: %w", node, err)
+	}
+	fmt.Fprintf(w, "[%s] Longhorn replica eviction requested\n", node)
+	defer func() {
+		if err != nil {
+			ClearLonghornEviction(f, node, w)
+		}
+	}()
+
+	last := -1
+	for {
+		ll, err := dial.Resource(client.LonghornReplicaGVR.GVR()).
+			Namespace(LonghornNamespace).
+			List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		var left int
+		for i := range ll.Items {
+			if n, _, _ := unstructured.NestedString(ll.Items[i].Object, "spec", "nodeID"); n == node {
+				left++
+			}
+		}
+		if left == 0 {
+			fmt.Fprintf(w, "[%s] Longhorn replicas evicted. Unset nodes.longhorn.io %s spec.evictionRequested once maintenance is over\n", node, node)
+			return nil
+		}
+		if left != last {
+			fmt.Fprintf(w, "[%s] Waiting on %d Longhorn replicas to be rebuilt elsewhere...\n", node, left)
+			last = left
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("longhorn eviction timed out on node %s with %d replicas left: %w", node, left, ctx.Err())
+		case <-time.After(longhornEvictPoll):
+		}
+	}
+}
+
+// ClearLonghornEviction withdraws a node eviction request. It runs on failure
+// paths where the caller context may be done so a fresh one bounds the request.
+func ClearLonghornEviction(f Factory, node string, w io.Writer) {
+	ctx, cancel := context.WithTimeout(context.Background(), longhornClearTimeout)
+	defer cancel()
+
+	dial, err := f.Client().DynDial()
+	if err == nil {
+		_, err = dial.Resource(client.LonghornNodeGVR.GVR()).
+			Namespace(LonghornNamespace).
+			Patch(ctx, node, types.MergePatchType, []byte(longhornEvictionClear), metav1.PatchOptions{})
+	}
+	if err != nil {
+		fmt.Fprintf(w, "[%s] Unable to withdraw Longhorn eviction request, unset nodes.longhorn.io %s spec.evictionRequested manually: %s\n", node, node, err)
+		return
+	}
+	fmt.Fprintf(w, "[%s] Longhorn replica eviction request withdrawn\n", node)
+}
+
+func longhornReplicaHealthy(u *unstructured.Unstructured) bool {
+	failed, _, _ := unstructured.NestedString(u.Object, "spec", "failedAt")
+	healthy, _, _ := unstructured.NestedString(u.Object, "spec", "healthyAt")
+
+	return failed == "" && healthy != ""
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestLonghornDrainRisks(t *testing.T) {
+	uu := map[string]struct {
+		vols, replicas []runtime.Object
+		e              []dao.LonghornVolumeRisk
+	}{
+		"empty": {},
+		"safe": {
+			vols: []runtime.Object{makeLHVolume("v1", "healthy", 3)},
+			replicas: []runtime.Object{
+				makeLHReplica("v1", "n1", true),
+				makeLHReplica("v1", "n2", true),
+				makeLHReplica("v1", "n3", true),
+			},
+		},
+		"not-on-node": {
+			vols:     []runtime.Object{makeLHVolume("v1", "degraded", 3)},
+			replicas: []runtime.Object{makeLHReplica("v1", "n2", true)},
+		},
+		"last-replica": {
+			vols: []runtime.Object{makeLHVolume("v1", "degraded", 2)},
+			replicas: []runtime.Object{
+				makeLHReplica("v1", "n1", true),
+				makeLHReplica("v1", "n2", false),
+			},
+			e: []dao.LonghornVolumeRisk{
+				{Volume: "v1", Robustness: "degraded", Replicas: 2, Healthy: 1, OnNode: 1},
+			},
+		},
+		"single-replica": {
+			vols:     []runtime.Object{makeLHVolume("v1", "healthy", 1)},
+			replicas: []runtime.Object{makeLHReplica("v1", "n1", true)},
+			e: []dao.LonghornVolumeRisk{
+				{Volume: "v1", Robustness: "healthy", Replicas: 1, Healthy: 1, OnNode: 1},
+			},
+		},
+		"degraded": {
+			vols: []runtime.Object{
+				makeLHVolume("v2", "degraded", 3),
+				makeLHVolume("v1", "faulted", 3),
+			},
+			replicas: []runtime.Object{
+				makeLHReplica("v1", "n1", true),
+				makeLHReplica("v1", "n2", true),
+				makeLHReplica("v2", "n1", true),
+				makeLHReplica("v2", "n3", true),
+			},
+			e: []dao.LonghornVolumeRisk{
+				{Volume: "v1", Robustness: "faulted", Replicas: 3, Healthy: 2, OnNode: 1},
+				{Volume: "v2", Robustness: "degraded", Replicas: 3, Healthy: 2, OnNode: 1},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.LonghornDrainRisks("n1", u.vols, u.replicas))
+		})
+	}
+}
+
+func TestLonghornVolumeRiskReason(t *testing.T) {
+	uu := map[string]struct {
+		r dao.LonghornVolumeRisk
+		e string
+	}{
+		"last": {
+			r: dao.LonghornVolumeRisk{Volume: "v1", Robustness: "degraded", Replicas: 2, Healthy: 1, OnNode: 1},
+			e: "v1 (last healthy replica)",
+		},
+		"degraded": {
+			r: dao.LonghornVolumeRisk{Volume: "v1", Robustness: "degraded", Replicas: 3, Healthy: 2, OnNode: 1},
+			e: "v1 (degraded, 1/3 healthy replicas left)",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.r.String())
+		})
+	}
+}
+
+// Helpers...
+
+func makeLHVolume(name, robustness string, replicas int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": name, "namespace": "longhorn-system"},
+		"spec":     map[string]any{"numberOfReplicas": replicas},
+		"status":   map[string]any{"robustness": robustness},
+	}}
+}
+
+func makeLHReplica(vol, node string, healthy bool) *unstructured.Unstructured {
+	spec := map[string]any{"volumeName": vol, "nodeID": node, "healthyAt": "2026-01-01T00:00:00Z"}
+	if !healthy {
+		spec["failedAt"] = "2026-01-02T00:00:00Z"
+	}
+
+	return &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": vol + "-r-" + node, "namespace": "longhorn-system"},
+		"spec":     spec,
+	}}
+}
@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/derailed/k9s/internal/config/data"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// monitoringService tracks the defaults of a monitoring service.
+type monitoringService struct {
+	name    string
+	service string
+	port    int
+}
+
+var (
+	promMonitoring = monitoringService{name: "prometheus", service: data.DefaultPromService, port: 9090}
+	lokiMonitoring = monitoringService{name: "loki", port: 3100}
+)
+
+// endpointClients caches the http clients of endpoints using custom TLS.
+var endpointClients sync.Map
+
+type endpointTLS struct {
+	ca, cert, key string
+	insecure      bool
+}
+
+// monitoringGet sends a GET request to a monitoring endpoint of a context. The
+// endpoint URL is used when set, otherwise its service, or the default one,
+// is reached through a port-forward or the API server proxy.
+func monitoringGet(ctx context.Context, rawCfg api.Config, ctxName string, ep *data.Endpoint, svc monitoringService, path string, q url.Values, hdr http.Header) ([]byte, error) {
+	if ep != nil && ep.URL != "" {
+		return endpointGet(ctx, svc.name, ep.URL, ep.Auth, path, q, hdr)
+	}
+	if (ep == nil || ep.Service == "") && svc.service == "" {
+		return nil, fmt.Errorf("no %s url or service configured", svc.name)
+	}
+	ns, name, port, err := ep.ServiceRef(svc.service, svc.port)
+	if err != nil {
+		return nil, err
+	}
+	if ep != nil && ep.PortForward {
+		addr, err := forwardedAddr(ctx, rawCfg, ctxName, ns, name, port)
+		if err != nil {
+			return nil, err
+		}
+		return endpointGet(ctx, svc.name, ep.ServiceScheme()+"://"+addr, ep.Auth, path, q, hdr)
+	}
+
+	// The API server proxy authenticates with the context credentials.
+	kc, err := kubeClientFor(rawCfg, ctxName)
+	if err != nil {
+		return nil, err
+	}
+	req := kc.CoreV1().RESTClient().Get().
+		Namespace(ns).
+		Resource("services").
+		Name(utilnet.JoinSchemeNamePort(ep.ServiceScheme(), name, strconv.Itoa(port))).
+		SubResource("proxy").
+		Suffix(path)
+	for k, vv := range q {
+		for _, v := range vv {
+			req = req.Param(k, v)
+		}
+	}
+	for k, vv := range hdr {
+		req = req.SetHeader(k, vv...)
+	}
+
+	return req.DoRaw(ctx)
+}
+
+func endpointGet(ctx context.Context, kind, base string, auth *data.EndpointAuth, path string, q url.Values, hdr http.Header) ([]byte, error) {
+	u := strings.TrimSuffix(base, "/") + "/" + path
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	for k, vv := range hdr {
+		req.Header[k] = vv
+	}
+	if err := authorize(req, auth); err != nil {
+		return nil, err
+	}
+	c, err := endpointClient(auth)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	bb, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s query failed (%s): %s", kind, resp.Status, strings.TrimSpace(string(bb)))
+	}
+
+	return bb, nil
+}
+
+// authorize sets the bearer token or basic auth credentials of a request.
+// Credential files are read on each request so rotated secrets are picked up.
+func authorize(req *http.Request, a *data.EndpointAuth) error {
+	if a == nil {
+		return nil
+	}
+	token, err := secretValue(a.BearerToken, a.BearerTokenFile)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+	if a.Username == "" {
+		return nil
+	}
+	pwd, err := secretValue(a.Password, a.PasswordFile)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(a.Username, pwd)
+
+	return nil
+}
+
+func secretValue(v, file string) (string, error) {
+	if file == "" {
+		return v, nil
+	}
+	bb, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("unable to read credentials: %w", err)
+	}
+
+	return strings.TrimSpace(string(bb)), nil
+}
+
+// endpointClient returns an http client honoring the endpoint TLS settings.
+func endpointClient(a *data.EndpointAuth) (*http.Client, error) {
+	if a == nil || (a.CAFile == "" && a.CertFile == "" && !a.InsecureSkipVerify) {
+		return http.DefaultClient, nil
+	}
+	key := endpointTLS{ca: a.CAFile, cert: a.CertFile, key: a.KeyFile, insecure: a.InsecureSkipVerify}
+	if c, ok := endpointClients.Load(key); ok {
+		return c.(*http.Client), nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: a.InsecureSkipVerify} //nolint:gosec
+	if a.CAFile != "" {
+		pem, err := os.ReadFile(a.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificate found in " + a.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if a.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(a.CertFile, a.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = cfg
+	c := &http.Client{Transport: tr}
+	endpointClients.Store(key, c)
+
+	return c, nil
+}
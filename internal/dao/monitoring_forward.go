@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/slogs"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// monForward tracks a port-forward to a monitoring service.
+type monForward struct {
+	addr string
+	stop chan struct{}
+	done chan struct{}
+}
+
+// monForwards tracks the live port-forwards keyed by context and service.
+var monForwards = struct {
+	sync.Mutex
+	ff map[string]*monForward
+}{ff: make(map[string]*monForward)}
+
+// StopMonitoringForwards terminates the port-forwards to monitoring services.
+func StopMonitoringForwards() {
+	monForwards.Lock()
+	defer monForwards.Unlock()
+
+	for k, f := range monForwards.ff {
+		close(f.stop)
+		delete(monForwards.ff, k)
+	}
+}
+
+// stopMonitoringForwardsFor terminates the port-forwards to the monitoring
+// services of a context and returns how many were stopped.
+func stopMonitoringForwardsFor(ctxName string) int {
+	monForwards.Lock()
+	defer monForwards.Unlock()
+
+	var n int
+	for k, f := range monForwards.ff {
+		if !strings.HasPrefix(k, ctxName+"/") {
+			continue
+		}
+		close(f.stop)
+		delete(monForwards.ff, k)
+		n++
+	}
+
+	return n
+}
+
+// forwardedAddr returns the local address of a port-forward to a service,
+// starting one if needed. Forwards are kept until the pod goes away.
+func forwardedAddr(ctx context.Context, rawCfg api.Config, ctxName, ns, name string, port int) (string, error) {
+	key := ctxName + "/" + client.FQN(ns, name) + ":" + strconv.Itoa(port)
+	monForwards.Lock()
+	defer monForwards.Unlock()
+
+	if f, ok := monForwards.ff[key]; ok {
+		return f.addr, nil
+	}
+	f, err := startMonForward(ctx, rawCfg, ctxName, ns, name, port)
+	if err != nil {
+		return "", err
+	}
+	monForwards.ff[key] = f
+	go func() {
+		<-f.done
+		monForwards.Lock()
+		defer monForwards.Unlock()
+		if monForwards.ff[key] == f {
+			delete(monForwards.ff, key)
+		}
+	}()
+
+	return f.addr, nil
+}
+
+func startMonForward(ctx context.Context, rawCfg api.Config, ctxName, ns, name string, port int) (*monForward, error) {
+	kc, err := kubeClientFor(rawCfg, ctxName)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := kc.CoreV1().Services(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return nil, fmt.Errorf("service %s has no selector to port-forward to", client.FQN(ns, name))
+	}
+	pp, err := kc.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	po := runningPod(pp.Items)
+	if po == nil {
+		return nil, fmt.Errorf("no running pod backs service %s", client.FQN(ns, name))
+	}
+	target, err := servicePodPort(svc, po, port)
+	if err != nil {
+		return nil, err
+	}
+
+	restCfg, err := restConfigFor(rawCfg, ctxName)
+	if err != nil {
+		return nil, err
+	}
+	transport, upgrader, err := spdy.RoundTripperFor(restCfg)
+	if err != nil {
+		return nil, err
+	}
+	u := kc.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(ns).
+		Name(po.Name).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, u)
+	stop, ready := make(chan struct{}), make(chan struct{})
+	fw, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{"0:" + strconv.Itoa(target)}, stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+	errCh := make(chan error, 1)
+	go func() { errCh <- fw.ForwardPorts() }()
+	select {
+	case <-ready:
+	case err := <-errCh:
+		return nil, fmt.Errorf("port-forward to %s failed: %w", client.FQN(ns, po.Name), err)
+	case <-ctx.Done():
+		close(stop)
+		return nil, ctx.Err()
+	}
+	ports, err := fw.GetPorts()
+	if err != nil || len(ports) == 0 {
+		close(stop)
+		return nil, fmt.Errorf("port-forward to %s has no local port: %w", client.FQN(ns, po.Name), err)
+	}
+
+	f := monForward{
+		addr: "127.0.0.1:" + strconv.Itoa(int(ports[0].Local)),
+		stop: stop,
+		done: make(chan struct{}),
+	}
+	go func() {
+		if err := <-errCh; err != nil {
+			slog.Debug("Monitoring port-forward ended", slogs.FQN, client.FQN(ns, po.Name), slogs.Error, err)
+		}
+		close(f.done)
+	}()
+
+	return &f, nil
+}
+
+// servicePodPort returns the pod port a service port targets.
+func servicePodPort(svc *v1.Service, po *v1.Pod, port int) (int, error) {
+	for _, sp := range svc.Spec.Ports {
+		if int(sp.Port) != port {
+			continue
+		}
+		switch {
+		case sp.TargetPort.Type == intstr.String:
+			for _, co := range po.Spec.Containers {
+				for _, cp := range co.Ports {
+					if cp.Name == sp.TargetPort.StrVal {
+						return int(cp.ContainerPort), nil
+					}
+				}
+			}
+			return 0, fmt.Errorf("pod %s has no port named %q", client.FQN(po.Namespace, po.Name), sp.TargetPort.StrVal)
+		case sp.TargetPort.IntVal != 0:
+			return int(sp.TargetPort.IntVal), nil
+		default:
+			return port, nil
+		}
+	}
+
+	return 0, fmt.Errorf("service %s has no port %d", client.FQN(svc.Namespace, svc.Name), port)
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestAuthorize(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("t0k3n\n"), 0o600))
+
+	uu := map[string]struct {
+		auth *data.EndpointAuth
+		e    string
+		err  bool
+	}{
+		"none": {},
+		"bearer": {
+			auth: &data.EndpointAuth{BearerToken: "blee"},
+			e:    "Bearer blee",
+		},
+		"bearer-file": {
+			auth: &data.EndpointAuth{BearerTokenFile: tokenFile},
+			e:    "Bearer t0k3n",
+		},
+		"basic": {
+			auth: &data.EndpointAuth{Username: "fred", Password: "duh"},
+			e:    "Basic ZnJlZDpkdWg=",
+		},
+		"missing-file": {
+			auth: &data.EndpointAuth{BearerTokenFile: filepath.Join(t.TempDir(), "nope")},
+			err:  true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "http://localhost", http.NoBody)
+			require.NoError(t, err)
+			err = authorize(req, u.auth)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, req.Header.Get("Authorization"))
+		})
+	}
+}
+
+func TestServicePodPort(t *testing.T) {
+	svc := v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "prom"},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{Port: 9090, TargetPort: intstr.FromString("web")},
+				{Port: 8080, TargetPort: intstr.FromInt32(8081)},
+				{Port: 80},
+				{Port: 90, TargetPort: intstr.FromString("nope")},
+			},
+		},
+	}
+	po := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "prom-0"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "prometheus", Ports: []v1.ContainerPort{{Name: "web", ContainerPort: 9091}}},
+			},
+		},
+	}
+
+	uu := map[string]struct {
+		port, e int
+		err     string
+	}{
+		"named":    {port: 9090, e: 9091},
+		"number":   {port: 8080, e: 8081},
+		"same":     {port: 80, e: 80},
+		"no-name":  {port: 90, err: `pod monitoring/prom-0 has no port named "nope"`},
+		"no-match": {port: 443, err: "service monitoring/prom has no port 443"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			p, err := servicePodPort(&svc, &po, u.port)
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, p)
+		})
+	}
+}
+
+func TestStopMonitoringForwardsFor(t *testing.T) {
+	monForwards.Lock()
+	for _, k := range []string{"fred/monitoring/prometheus:9090", "fred/monitoring/loki:3100", "freddy/monitoring/loki:3100"} {
+		monForwards.ff[k] = &monForward{stop: make(chan struct{})}
+	}
+	monForwards.Unlock()
+	defer StopMonitoringForwards()
+
+	assert.Equal(t, 2, stopMonitoringForwardsFor("fred"))
+	assert.Equal(t, 0, stopMonitoringForwardsFor("fred"))
+	assert.Equal(t, 1, stopMonitoringForwardsFor("freddy"))
+}
+
+func TestPromCache(t *testing.T) {
+	ResetPromCache()
+	defer ResetPromCache()
+
+	ep := &data.Prometheus{Endpoint: data.Endpoint{URL: "https://prom.example.com"}}
+	now := time.Now()
+	promCache.Lock()
+	promCache.entries[promKey("c1", nil, "up")] = PromSample{Value: 1, OK: true, expires: now.Add(time.Minute)}
+	promCache.entries[promKey("c1", ep, "up")] = PromSample{Value: 2, OK: true, expires: now.Add(-time.Second)}
+	promCache.Unlock()
+
+	s, ok, fresh := CachedPromQuery("c1", nil, "up")
+	assert.True(t, ok)
+	assert.True(t, fresh)
+	assert.InDelta(t, 1, s.Value, 0.0001)
+
+	s, ok, fresh = CachedPromQuery("c1", ep, "up")
+	assert.True(t, ok)
+	assert.False(t, fresh)
+	assert.InDelta(t, 2, s.Value, 0.0001)
+
+	_, ok, _ = CachedPromQuery("c2", nil, "up")
+	assert.False(t, ok)
+
+	promCache.Lock()
+	sweepPromCache(now.Add(promCacheSweep))
+	promCache.Unlock()
+	_, ok, _ = CachedPromQuery("c1", ep, "up")
+	assert.False(t, ok)
+	_, ok, _ = CachedPromQuery("c1", nil, "up")
+	assert.True(t, ok)
+}
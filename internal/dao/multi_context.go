@@ -5,24 +5,59 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log/slog"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/metrics"
 	"github.com/derailed/k9s/internal/slogs"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 )
 
-const mcMaxParallel = 10
+// mcConfig tracks the limits of multi-context operations.
+var mcConfig atomic.Pointer[config.MultiContext]
+
+// SetMultiContextConfig sets the limits of multi-context operations. Defaults
+// apply when nil.
+func SetMultiContextConfig(m *config.MultiContext) {
+	mcConfig.Store(m)
+}
+
+// MultiContextRowLimit returns the max number of rows of a multi-context view.
+func MultiContextRowLimit() int {
+	return mcConfig.Load().RowLimit()
+}
+
+// ContextRowShare splits a row limit evenly across n contexts, so a
+// multi-context view lists about limit resources overall. A non positive
+// limit means no limit.
+func ContextRowShare(limit, n int) int {
+	if limit <= 0 || n <= 1 {
+		return limit
+	}
+
+	return max((limit+n-1)/n, 1)
+}
+
+// mcParallelism returns the max number of contexts queried at once.
+func mcParallelism() int {
+	return mcConfig.Load().Parallelism()
+}
 
 // ContextObject pairs a runtime.Object with the context it was fetched from.
 type ContextObject struct {
@@ -31,12 +66,51 @@ type ContextObject struct {
 }
 
 var (
-	dynClientCache sync.Map // map[string]dynamic.Interface
+	dynClientCache  sync.Map // map[string]dynamic.Interface
+	discClientCache sync.Map // map[string]*discCache
 )
 
-// ResetDynClientCache clears cached per-context dynamic clients.
+// discCache tracks a context discovery client and its last refresh.
+type discCache struct {
+	client discovery.CachedDiscoveryInterface
+	mx     sync.Mutex
+	at     time.Time
+}
+
+// ResetDynClientCache clears cached per-context dynamic and discovery clients.
 func ResetDynClientCache() {
 	dynClientCache = sync.Map{}
+	discClientCache = sync.Map{}
+}
+
+// discoveryFor returns a memory cached discovery client for a context. Cached
+// discovery is refreshed once older than discoveryTTL.
+func discoveryFor(rawConfig api.Config, ctxName string) (discovery.CachedDiscoveryInterface, error) {
+	var dc *discCache
+	if c, ok := discClientCache.Load(ctxName); ok {
+		dc = c.(*discCache)
+	} else {
+		restCfg, err := restConfigFor(rawConfig, ctxName)
+		if err != nil {
+			return nil, err
+		}
+		restCfg.Timeout = discoveryTimeout
+		c, err := discovery.NewDiscoveryClientForConfig(restCfg)
+		if err != nil {
+			return nil, fmt.Errorf("discovery client for context %q: %w", ctxName, err)
+		}
+		v, _ := discClientCache.LoadOrStore(ctxName, &discCache{client: memory.NewMemCacheClient(c)})
+		dc = v.(*discCache)
+	}
+
+	dc.mx.Lock()
+	defer dc.mx.Unlock()
+	if time.Since(dc.at) > discoveryTTL {
+		dc.client.Invalidate()
+		dc.at = time.Now()
+	}
+
+	return dc.client, nil
 }
 
 func dynClientFor(rawConfig api.Config, ctxName string) (dynamic.Interface, error) {
@@ -52,6 +126,7 @@ func dynClientFor(rawConfig api.Config, ctxName string) (dynamic.Interface, erro
 	}
 	restCfg.QPS = 50
 	restCfg.Burst = 100
+	restCfg.Wrap(metrics.InstrumentTransport(ctxName))
 
 	dc, err := dynamic.NewForConfig(restCfg)
 	if err != nil {
@@ -62,76 +137,286 @@ func dynClientFor(rawConfig api.Config, ctxName string) (dynamic.Interface, erro
 	return dc, nil
 }
 
+func kubeClientFor(rawConfig api.Config, ctxName string) (kubernetes.Interface, error) {
+	restCfg, err := restConfigFor(rawConfig, ctxName)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(restCfg)
+}
+
+func restConfigFor(rawConfig api.Config, ctxName string) (*rest.Config, error) {
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: ctxName}
+	restCfg, err := clientcmd.NewDefaultClientConfig(rawConfig, overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("rest config for context %q: %w", ctxName, err)
+	}
+
+	return restCfg, nil
+}
+
+// ContextResult tracks the outcome of a multi-context fetch for a context.
+type ContextResult struct {
+	Context   string
+	Count     int
+	Err       error
+	Truncated bool
+}
+
+// MultiContextError lists the contexts skipped by a multi-context fetch.
+type MultiContextError struct {
+	Failed []ContextResult
+}
+
+// NewMultiContextError returns the failed contexts of a fetch or nil when
+// all of them succeeded.
+func NewMultiContextError(rr []ContextResult) error {
+	var ff []ContextResult
+	for _, r := range rr {
+		if r.Err != nil {
+			ff = append(ff, r)
+		}
+	}
+	if len(ff) == 0 {
+		return nil
+	}
+
+	return &MultiContextError{Failed: ff}
+}
+
+// Error returns the error message.
+func (e *MultiContextError) Error() string {
+	ss := make([]string, 0, len(e.Failed))
+	for _, r := range e.Failed {
+		ss = append(ss, fmt.Sprintf("%s: %s", r.Context, r.Err))
+	}
+
+	return fmt.Sprintf("%d contexts skipped (%s)", len(e.Failed), strings.Join(ss, "; "))
+}
+
+// Contexts returns the names of the failed contexts.
+func (e *MultiContextError) Contexts() []string {
+	ss := make([]string, 0, len(e.Failed))
+	for _, r := range e.Failed {
+		ss = append(ss, r.Context)
+	}
+
+	return ss
+}
+
+// FailedContexts returns the fetch error of each context skipped by a
+// multi-context fetch, or nil when err does not list skipped contexts.
+func FailedContexts(err error) map[string]error {
+	var me *MultiContextError
+	if !errors.As(err, &me) {
+		return nil
+	}
+	ee := make(map[string]error, len(me.Failed))
+	for _, r := range me.Failed {
+		ee[r.Context] = r.Err
+	}
+
+	return ee
+}
+
+// JoinMultiContextErrors merges the skipped contexts of several fetches,
+// keeping the first error of each context. Any other error is returned as is.
+func JoinMultiContextErrors(errs ...error) error {
+	var (
+		rr   []ContextResult
+		seen = make(map[string]struct{})
+	)
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		var me *MultiContextError
+		if !errors.As(err, &me) {
+			return err
+		}
+		for _, r := range me.Failed {
+			if _, ok := seen[r.Context]; ok {
+				continue
+			}
+			seen[r.Context] = struct{}{}
+			rr = append(rr, r)
+		}
+	}
+
+	return NewMultiContextError(rr)
+}
+
 // MultiContextList fetches resources across multiple contexts in parallel
-// using Go dynamic clients. Unreachable contexts are logged and skipped.
+// using Go dynamic clients. The resources of the contexts that answered are
+// returned along with a MultiContextError listing the skipped ones.
 func MultiContextList(
+	ctx context.Context,
 	rawConfig api.Config,
 	contexts []string,
 	gvr schema.GroupVersionResource,
 	ns string,
 	labelSel string,
 ) ([]ContextObject, error) {
-	type result struct {
-		ctx     string
-		objects []*unstructured.Unstructured
-		err     error
+	oo, rr := MultiContextFetch(ctx, rawConfig, contexts, gvr, ns, labelSel, "")
+
+	return oo, NewMultiContextError(rr)
+}
+
+// MultiContextFetch fetches resources across multiple contexts like
+// MultiContextList, optionally filtered server side by a field selector, and
+// reports the outcome of each context, in the order the contexts were given.
+func MultiContextFetch(
+	ctx context.Context,
+	rawConfig api.Config,
+	contexts []string,
+	gvr schema.GroupVersionResource,
+	ns string,
+	labelSel string,
+	fieldSel string,
+) ([]ContextObject, []ContextResult) {
+	var out []ContextObject
+	byCtx := make(map[string]ContextResult, len(contexts))
+	MultiContextStream(ctx, rawConfig, contexts, gvr, ns, labelSel, fieldSel, 0, func(r ContextResult, oo []ContextObject) {
+		byCtx[r.Context] = r
+		out = append(out, oo...)
+	})
+	rr := make([]ContextResult, 0, len(contexts))
+	for _, c := range contexts {
+		rr = append(rr, byCtx[c])
 	}
 
-	ch := make(chan result, len(contexts))
-	sem := make(chan struct{}, mcMaxParallel)
+	return out, rr
+}
 
-	for _, ctxName := range contexts {
-		sem <- struct{}{}
-		go func(ctx string) {
-			defer func() { <-sem }()
+// ContextStreamFunc receives the outcome of a context as soon as it answers.
+type ContextStreamFunc func(ContextResult, []ContextObject)
 
-			dc, err := dynClientFor(rawConfig, ctx)
-			if err != nil {
-				ch <- result{ctx: ctx, err: err}
-				return
-			}
+// MultiContextStream fetches resources across multiple contexts in parallel
+// and hands over the results of each context as it answers, so fast contexts
+// need not wait on slow ones. Calls to fn are serialized and the function
+// returns once all contexts answered, timed out or ctx got cancelled. A
+// positive limit caps the resources listed across contexts, each context
+// lists at most its share of it and contexts holding more are flagged as
+// truncated.
+func MultiContextStream(
+	ctx context.Context,
+	rawConfig api.Config,
+	contexts []string,
+	gvr schema.GroupVersionResource,
+	ns string,
+	labelSel string,
+	fieldSel string,
+	limit int,
+	fn ContextStreamFunc,
+) {
+	type result struct {
+		ctx       string
+		objects   []*unstructured.Unstructured
+		err       error
+		truncated bool
+	}
 
-			opts := metav1.ListOptions{}
-			if labelSel != "" {
-				opts.LabelSelector = labelSel
-			}
+	cfg := mcConfig.Load()
+	ctx, cancel := context.WithTimeout(ctx, cfg.OpDeadline())
+	defer cancel()
 
-			var list *unstructured.UnstructuredList
-			if ns == "" || ns == client.ClusterScope || ns == client.NamespaceAll {
-				list, err = dc.Resource(gvr).List(context.Background(), opts)
-			} else {
-				list, err = dc.Resource(gvr).Namespace(ns).List(context.Background(), opts)
-			}
-			if err != nil {
-				ch <- result{ctx: ctx, err: err}
-				return
+	limit = ContextRowShare(limit, len(contexts))
+	ch := make(chan result, len(contexts))
+	go func() {
+		sem := make(chan struct{}, cfg.Parallelism())
+		for _, ctxName := range contexts {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				ch <- result{ctx: ctxName, err: ctx.Err()}
+				continue
 			}
+			go func(name string) {
+				defer func() { <-sem }()
 
-			objs := make([]*unstructured.Unstructured, len(list.Items))
-			for i := range list.Items {
-				objs[i] = &list.Items[i]
-			}
-			ch <- result{ctx: ctx, objects: objs}
-		}(ctxName)
-	}
+				dc, err := dynClientFor(rawConfig, name)
+				if err != nil {
+					ch <- result{ctx: name, err: err}
+					return
+				}
+
+				opts := metav1.ListOptions{
+					LabelSelector: labelSel,
+					FieldSelector: fieldSel,
+					Limit:         int64(max(limit, 0)),
+				}
+				lctx, lcancel := context.WithTimeout(ctx, cfg.ContextTimeout())
+				defer lcancel()
+
+				var list *unstructured.UnstructuredList
+				if ns == "" || ns == client.ClusterScope || ns == client.NamespaceAll {
+					list, err = dc.Resource(gvr).List(lctx, opts)
+				} else {
+					list, err = dc.Resource(gvr).Namespace(ns).List(lctx, opts)
+				}
+				if err != nil {
+					ch <- result{ctx: name, err: err}
+					return
+				}
+
+				// Not all resources support chunking, cap the list on our end too.
+				items, truncated := list.Items, list.GetContinue() != ""
+				if limit > 0 && len(items) > limit {
+					items, truncated = items[:limit], true
+				}
+				objs := make([]*unstructured.Unstructured, len(items))
+				for i := range items {
+					objs[i] = &items[i]
+				}
+				ch <- result{ctx: name, objects: objs, truncated: truncated}
+			}(ctxName)
+		}
+	}()
 
-	var out []ContextObject
 	for range contexts {
 		r := <-ch
 		if r.err != nil {
-			slog.Warn("Multi-context list skipped context",
+			slogs.CtxLog(r.ctx).Warn("Multi-context list skipped context",
 				slogs.Subsys, "mc",
-				"context", r.ctx,
 				slogs.Error, r.err,
 			)
-			continue
 		}
+		oo := make([]ContextObject, 0, len(r.objects))
 		for _, o := range r.objects {
-			out = append(out, ContextObject{Context: r.ctx, Object: o})
+			oo = append(oo, ContextObject{Context: r.ctx, Object: o})
+		}
+		fn(ContextResult{Context: r.ctx, Count: len(r.objects), Err: r.err, Truncated: r.truncated}, oo)
+	}
+}
+
+// MultiContextNamespaces returns the namespaces of the given contexts along
+// with the number of contexts holding each of them. Skipped contexts are
+// reported along with the namespaces of the others.
+func MultiContextNamespaces(ctx context.Context, rawConfig api.Config, contexts []string) (map[string]int, error) {
+	oo, err := MultiContextList(ctx, rawConfig, contexts, client.NsGVR.GVR(), client.BlankNamespace, "")
+
+	return NamespaceCounts(oo), err
+}
+
+// NamespaceCounts counts the contexts holding each namespace.
+func NamespaceCounts(oo []ContextObject) map[string]int {
+	seen := make(map[string]struct{}, len(oo))
+	counts := make(map[string]int)
+	for _, co := range oo {
+		u, ok := co.Object.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		k := co.Context + "/" + u.GetName()
+		if _, ok := seen[k]; ok {
+			continue
 		}
+		seen[k] = struct{}{}
+		counts[u.GetName()]++
 	}
 
-	return out, nil
+	return counts
 }
 
 // MultiContextServerVersions queries the /version endpoint for each context
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNamespaceCounts(t *testing.T) {
+	ns := func(ctx, name string) dao.ContextObject {
+		var u unstructured.Unstructured
+		u.SetName(name)
+		return dao.ContextObject{Context: ctx, Object: &u}
+	}
+	oo := []dao.ContextObject{
+		ns("c1", "default"),
+		ns("c2", "default"),
+		ns("c2", "default"),
+		ns("c1", "longhorn-system"),
+		ns("c3", "fleet-local"),
+	}
+
+	assert.Equal(t, map[string]int{
+		"default":         2,
+		"longhorn-system": 1,
+		"fleet-local":     1,
+	}, dao.NamespaceCounts(oo))
+}
+
+func TestNewMultiContextError(t *testing.T) {
+	uu := map[string]struct {
+		rr  []dao.ContextResult
+		ctx []string
+		e   string
+	}{
+		"empty": {},
+		"all-ok": {
+			rr: []dao.ContextResult{{Context: "c1", Count: 2}, {Context: "c2"}},
+		},
+		"failed": {
+			rr: []dao.ContextResult{
+				{Context: "c1", Count: 2},
+				{Context: "c2", Err: errors.New("boom")},
+				{Context: "c3", Err: errors.New("forbidden")},
+			},
+			ctx: []string{"c2", "c3"},
+			e:   "2 contexts skipped (c2: boom; c3: forbidden)",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := dao.NewMultiContextError(u.rr)
+			if u.e == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var me *dao.MultiContextError
+			assert.ErrorAs(t, err, &me)
+			assert.Equal(t, u.ctx, me.Contexts())
+			assert.Equal(t, u.e, err.Error())
+		})
+	}
+}
+
+func TestFailedContexts(t *testing.T) {
+	boom := errors.New("boom")
+	uu := map[string]struct {
+		err error
+		e   map[string]error
+	}{
+		"none": {},
+		"plain": {
+			err: boom,
+		},
+		"failed": {
+			err: dao.NewMultiContextError([]dao.ContextResult{{Context: "c1"}, {Context: "c2", Err: boom}}),
+			e:   map[string]error{"c2": boom},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.FailedContexts(u.err))
+		})
+	}
+}
+
+func TestJoinMultiContextErrors(t *testing.T) {
+	boom, fred := errors.New("boom"), errors.New("fred")
+	uu := map[string]struct {
+		ee []error
+		e  string
+	}{
+		"none": {
+			ee: []error{nil, nil},
+		},
+		"plain": {
+			ee: []error{nil, fred},
+			e:  "fred",
+		},
+		"merged": {
+			ee: []error{
+				dao.NewMultiContextError([]dao.ContextResult{{Context: "c1", Err: boom}}),
+				nil,
+				dao.NewMultiContextError([]dao.ContextResult{{Context: "c1", Err: fred}, {Context: "c2", Err: fred}}),
+			},
+			e: "2 contexts skipped (c1: boom; c2: fred)",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := dao.JoinMultiContextErrors(u.ee...)
+			if u.e == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, u.e, err.Error())
+		})
+	}
+}
+
+func TestContextRowShare(t *testing.T) {
+	uu := map[string]struct {
+		limit, n, e int
+	}{
+		"none":   {limit: 0, n: 3, e: 0},
+		"single": {limit: 100, n: 1, e: 100},
+		"even":   {limit: 100, n: 4, e: 25},
+		"uneven": {limit: 100, n: 3, e: 34},
+		"tiny":   {limit: 2, n: 5, e: 1},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.ContextRowShare(u.limit, u.n))
+		})
+	}
+}
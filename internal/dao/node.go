@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"time"
 
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
@@ -176,11 +177,13 @@ func (n *Node) List(ctx context.Context, ns string) ([]runtime.Object, error) {
 		}
 	}
 	res := make([]runtime.Object, 0, len(oo))
+	now, ctxName := time.Now(), n.Client().ActiveContext()
 	for _, o := range oo {
 		u, ok := o.(*unstructured.Unstructured)
 		if !ok {
 			return res, fmt.Errorf("expecting *unstructured.Unstructured but got `%T", o)
 		}
+		NodeConditions.Observe(ctxName, u, now)
 
 		fqn := extractFQN(o)
 		_, name := client.Namespaced(fqn)
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/derailed/k9s/internal/render"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const nodeHistorySize = 200
+
+// NodeConditions tracks the node condition transitions observed during the session.
+var NodeConditions = NewNodeHistory(nodeHistorySize)
+
+// NodeTransition represents a node condition status change.
+type NodeTransition struct {
+	At                   time.Time
+	Type, Status, Reason string
+}
+
+// NodeHistory keeps a rolling history of node condition transitions.
+type NodeHistory struct {
+	mx      sync.RWMutex
+	size    int
+	started map[string]time.Time
+	last    map[string]map[string]string
+	entries map[string][]NodeTransition
+}
+
+// NewNodeHistory returns a new history retaining at most size transitions per node.
+func NewNodeHistory(size int) *NodeHistory {
+	return &NodeHistory{
+		size:    size,
+		started: make(map[string]time.Time),
+		last:    make(map[string]map[string]string),
+		entries: make(map[string][]NodeTransition),
+	}
+}
+
+// Observe records the conditions of a node, keeping track of status changes
+// since the previous observation.
+func (h *NodeHistory) Observe(ctx string, u *unstructured.Unstructured, at time.Time) {
+	cc, ok, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	if !ok {
+		return
+	}
+	key := nodeKey(ctx, u.GetName())
+
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	if _, ok := h.started[key]; !ok {
+		h.started[key] = at
+		h.last[key] = make(map[string]string, len(cc))
+	}
+	last := h.last[key]
+	for _, c := range cc {
+		m, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		t, _, _ := unstructured.NestedString(m, "type")
+		s, _, _ := unstructured.NestedString(m, "status")
+		prev, seen := last[t]
+		if seen && prev == s {
+			continue
+		}
+		last[t] = s
+		tr := NodeTransition{At: at, Type: t, Status: s}
+		tr.Reason, _, _ = unstructured.NestedString(m, "reason")
+		// Flaps between refreshes are dated by the condition itself when available.
+		if ts, _, _ := unstructured.NestedString(m, "lastTransitionTime"); ts != "" {
+			if lt, err := time.Parse(time.RFC3339, ts); err == nil && lt.After(h.started[key]) {
+				tr.At = lt
+			}
+		}
+		h.entries[key] = append(h.entries[key], tr)
+	}
+	if n := len(h.entries[key]); n > h.size {
+		h.entries[key] = h.entries[key][n-h.size:]
+	}
+}
+
+// Transitions returns the transitions recorded for a node along with the
+// time the node was first observed.
+func (h *NodeHistory) Transitions(ctx, node string) ([]NodeTransition, time.Time) {
+	h.mx.RLock()
+	defer h.mx.RUnlock()
+
+	key := nodeKey(ctx, node)
+
+	return slices.Clone(h.entries[key]), h.started[key]
+}
+
+func nodeKey(ctx, node string) string {
+	return ctx + "/" + node
+}
+
+// NodeTimeline correlates node condition transitions with the pods scheduled
+// on or evicted from the node within the same window.
+func NodeTimeline(node string, tt []NodeTransition, pods []*v1.Pod, since time.Time) []TimelineEntry {
+	ee := make([]TimelineEntry, 0, len(tt))
+	for _, t := range tt {
+		ee = append(ee, TimelineEntry{
+			At:      t.At,
+			Source:  "node/" + node,
+			Message: fmt.Sprintf("%s=%s %s", t.Type, t.Status, t.Reason),
+			Warn:    isNodeConditionBad(v1.NodeCondition{Type: v1.NodeConditionType(t.Type), Status: v1.ConditionStatus(t.Status)}),
+		})
+	}
+	for _, po := range pods {
+		if po.Spec.NodeName != node {
+			continue
+		}
+		src := "pod/" + po.Namespace + "/" + po.Name
+		if at := scheduledAt(po); !at.Before(since) {
+			ee = append(ee, TimelineEntry{At: at, Source: src, Message: "scheduled"})
+		}
+		if po.Status.Reason == render.ReasonEvicted {
+			if at := evictedAt(po); !at.Before(since) {
+				ee = append(ee, TimelineEntry{At: at, Source: src, Message: "evicted - " + po.Status.Message, Warn: true})
+			}
+		}
+	}
+	slices.SortStableFunc(ee, func(a, b TimelineEntry) int {
+		return a.At.Compare(b.At)
+	})
+
+	return ee
+}
+
+func scheduledAt(po *v1.Pod) time.Time {
+	for _, c := range po.Status.Conditions {
+		if c.Type == v1.PodScheduled && c.Status == v1.ConditionTrue {
+			return c.LastTransitionTime.Time
+		}
+	}
+	if po.Status.StartTime != nil {
+		return po.Status.StartTime.Time
+	}
+
+	return po.CreationTimestamp.Time
+}
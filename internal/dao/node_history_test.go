@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func makeNode(name, ready, mem string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": name},
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": ready},
+				map[string]any{"type": "MemoryPressure", "status": mem, "reason": "KubeletHasInsufficientMemory"},
+			},
+		},
+	}}
+}
+
+func TestNodeHistoryObserve(t *testing.T) {
+	t0 := time.Now()
+	h := dao.NewNodeHistory(3)
+
+	h.Observe("ct1", makeNode("n1", "True", "False"), t0)
+	h.Observe("ct1", makeNode("n1", "True", "False"), t0.Add(time.Minute))
+	h.Observe("ct1", makeNode("n1", "True", "True"), t0.Add(2*time.Minute))
+
+	tt, since := h.Transitions("ct1", "n1")
+	assert.Equal(t, t0, since)
+	assert.Equal(t, []dao.NodeTransition{
+		{At: t0, Type: "Ready", Status: "True"},
+		{At: t0, Type: "MemoryPressure", Status: "False", Reason: "KubeletHasInsufficientMemory"},
+		{At: t0.Add(2 * time.Minute), Type: "MemoryPressure", Status: "True", Reason: "KubeletHasInsufficientMemory"},
+	}, tt)
+
+	h.Observe("ct1", makeNode("n1", "False", "True"), t0.Add(3*time.Minute))
+	tt, _ = h.Transitions("ct1", "n1")
+	assert.Len(t, tt, 3)
+	assert.Equal(t, "Ready", tt[2].Type)
+
+	tt, since = h.Transitions("ct2", "n1")
+	assert.Empty(t, tt)
+	assert.True(t, since.IsZero())
+}
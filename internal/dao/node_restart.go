@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// NodeRecoveredInContext checks whether a node of a given context came back
+// from a service restart issued at a given time. It returns the reason the
+// node is not back yet otherwise.
+func NodeRecoveredInContext(ctx context.Context, rawCfg api.Config, ctxName, node string, since time.Time) (bool, string) {
+	dial, err := kubeClientFor(rawCfg, ctxName)
+	if err != nil {
+		return false, err.Error()
+	}
+	if _, err := dial.Discovery().RESTClient().Get().AbsPath("/readyz").DoRaw(ctx); err != nil {
+		return false, "api server not ready: " + err.Error()
+	}
+	no, err := dial.CoreV1().Nodes().Get(ctx, node, metav1.GetOptions{})
+	if err != nil {
+		return false, err.Error()
+	}
+	var renew time.Time
+	if l, err := dial.CoordinationV1().Leases(nodeLeaseNamespace).Get(ctx, node, metav1.GetOptions{}); err == nil && l.Spec.RenewTime != nil {
+		renew = l.Spec.RenewTime.Time
+	}
+
+	return NodeRecoveryState(no, renew, since)
+}
+
+// NodeRecoveryState checks whether a node is Ready and its kubelet renewed
+// its lease since a given time.
+func NodeRecoveryState(no *v1.Node, renew, since time.Time) (bool, string) {
+	ready := false
+	for _, c := range no.Status.Conditions {
+		if c.Type == v1.NodeReady {
+			ready = c.Status == v1.ConditionTrue
+		}
+	}
+	switch {
+	case !ready:
+		return false, "node not ready"
+	case renew.IsZero():
+		return false, "no kubelet lease found"
+	case renew.Before(since):
+		return false, fmt.Sprintf("kubelet lease last renewed %s before restart", since.Sub(renew).Round(time.Second))
+	default:
+		return true, "node ready"
+	}
+}
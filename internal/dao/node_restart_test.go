@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestNodeRecoveryState(t *testing.T) {
+	since := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	node := func(s v1.ConditionStatus) *v1.Node {
+		return &v1.Node{Status: v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: s}}}}
+	}
+
+	uu := map[string]struct {
+		no    *v1.Node
+		renew time.Time
+		ok    bool
+		e     string
+	}{
+		"back": {
+			no:    node(v1.ConditionTrue),
+			renew: since.Add(5 * time.Second),
+			ok:    true,
+			e:     "node ready",
+		},
+		"not-ready": {
+			no:    node(v1.ConditionUnknown),
+			renew: since.Add(5 * time.Second),
+			e:     "node not ready",
+		},
+		"stale-lease": {
+			no:    node(v1.ConditionTrue),
+			renew: since.Add(-30 * time.Second),
+			e:     "kubelet lease last renewed 30s before restart",
+		},
+		"no-lease": {
+			no: node(v1.ConditionTrue),
+			e:  "no kubelet lease found",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ok, msg := dao.NodeRecoveryState(u.no, u.renew, since)
+			assert.Equal(t, u.ok, ok)
+			assert.Equal(t, u.e, msg)
+		})
+	}
+}
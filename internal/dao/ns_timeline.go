@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/slogs"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NamespaceTimelineWindow is the default namespace timeline lookback.
+const NamespaceTimelineWindow = 30 * time.Minute
+
+// FetchNamespaceTimeline assembles the recent history of a namespace from the
+// informer cache.
+func FetchNamespaceTimeline(f Factory, ns string, since time.Time) ([]TimelineEntry, error) {
+	ee, err := f.List(coreEvGVR, ns, true, labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list events: %w", err)
+	}
+	pp, err := f.List(client.PodGVR, ns, true, labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pods: %w", err)
+	}
+	rr, err := f.List(client.RsGVR, ns, true, labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list replicasets: %w", err)
+	}
+
+	return NamespaceTimeline(toEvents(ee), toPods(pp), toReplicaSets(rr), client.IsAllNamespaces(ns), since), nil
+}
+
+// NamespaceTimeline merges events, pod starts and stops and deployment
+// rollouts that happened since the given time into a chronological list.
+func NamespaceTimeline(ee []*v1.Event, pp []*v1.Pod, rr []*appsv1.ReplicaSet, allNS bool, since time.Time) []TimelineEntry {
+	var tt []TimelineEntry
+	add := func(t TimelineEntry) {
+		if !t.At.IsZero() && !t.At.Before(since) {
+			tt = append(tt, t)
+		}
+	}
+
+	for _, e := range ee {
+		o := e.InvolvedObject
+		msg := e.Reason + ": " + strings.TrimSpace(e.Message)
+		if e.Count > 1 {
+			msg += fmt.Sprintf(" (x%d)", e.Count)
+		}
+		add(TimelineEntry{
+			At:      eventTime(e),
+			Source:  timelineSource(o.Kind, o.Namespace, o.Name, allNS),
+			Message: msg,
+			Warn:    e.Type == v1.EventTypeWarning,
+		})
+	}
+
+	for _, po := range pp {
+		src := timelineSource("Pod", po.Namespace, po.Name, allNS)
+		add(TimelineEntry{At: po.CreationTimestamp.Time, Source: src, Message: "created"})
+		if po.DeletionTimestamp != nil {
+			add(TimelineEntry{At: po.DeletionTimestamp.Time, Source: src, Message: "terminating"})
+		}
+		for _, cs := range slices.Concat(po.Status.InitContainerStatuses, po.Status.ContainerStatuses) {
+			for _, t := range containerTimeline(&cs) {
+				t.Source, t.Message = src, cs.Name+" "+t.Message
+				add(t)
+			}
+		}
+	}
+
+	for _, rs := range rr {
+		ref := metav1.GetControllerOf(rs)
+		if ref == nil || ref.Kind != "Deployment" {
+			continue
+		}
+		msg := "rollout started, replicaset " + rs.Name
+		if rev := rs.Annotations[revisionAnnotation]; rev != "" {
+			msg = fmt.Sprintf("rollout to revision %s started, replicaset %s", rev, rs.Name)
+		}
+		add(TimelineEntry{
+			At:      rs.CreationTimestamp.Time,
+			Source:  timelineSource(ref.Kind, rs.Namespace, ref.Name, allNS),
+			Message: msg,
+		})
+	}
+
+	slices.SortStableFunc(tt, func(a, b TimelineEntry) int {
+		return a.At.Compare(b.At)
+	})
+
+	return tt
+}
+
+func timelineSource(kind, ns, n string, allNS bool) string {
+	src := strings.ToLower(kind) + "/" + n
+	if allNS && ns != "" {
+		src = ns + "/" + src
+	}
+
+	return src
+}
+
+func toReplicaSets(oo []runtime.Object) []*appsv1.ReplicaSet {
+	rr := make([]*appsv1.ReplicaSet, 0, len(oo))
+	for _, o := range oo {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		var rs appsv1.ReplicaSet
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &rs); err != nil {
+			slog.Warn("ReplicaSet conversion failed", slogs.Error, err)
+			continue
+		}
+		rr = append(rr, &rs)
+	}
+
+	return rr
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNamespaceTimeline(t *testing.T) {
+	t0 := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	at := func(m int) metav1.Time {
+		return metav1.NewTime(t0.Add(time.Duration(m) * time.Minute))
+	}
+	ctrl := true
+
+	ee := []*v1.Event{
+		{
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Namespace: "ns1", Name: "p1"},
+			Type:           v1.EventTypeWarning,
+			Reason:         "BackOff",
+			Message:        "restarting failed container",
+			Count:          3,
+			LastTimestamp:  at(20),
+		},
+		{
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Namespace: "ns1", Name: "p0"},
+			Reason:         "Scheduled",
+			LastTimestamp:  at(-60),
+		},
+	}
+	pp := []*v1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: "ns1", CreationTimestamp: at(5)},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						Name:  "c1",
+						State: v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: at(6)}},
+					},
+				},
+			},
+		},
+	}
+	rr := []*appsv1.ReplicaSet{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "d1-abc",
+				Namespace:         "ns1",
+				CreationTimestamp: at(4),
+				Annotations:       map[string]string{"deployment.kubernetes.io/revision": "7"},
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "Deployment", Name: "d1", Controller: &ctrl},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "orphan", Namespace: "ns1", CreationTimestamp: at(4)},
+		},
+	}
+
+	tt := dao.NamespaceTimeline(ee, pp, rr, false, t0)
+	assert.Equal(t, []dao.TimelineEntry{
+		{At: at(4).Time, Source: "deployment/d1", Message: "rollout to revision 7 started, replicaset d1-abc"},
+		{At: at(5).Time, Source: "pod/p1", Message: "created"},
+		{At: at(6).Time, Source: "pod/p1", Message: "c1 running"},
+		{At: at(20).Time, Source: "pod/p1", Message: "BackOff: restarting failed container (x3)", Warn: true},
+	}, tt)
+
+	tt = dao.NamespaceTimeline(ee[:1], nil, nil, true, t0)
+	assert.Equal(t, "ns1/pod/p1", tt[0].Source)
+}
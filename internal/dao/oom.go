@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/derailed/k9s/internal/slogs"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// OOMLookback represents how far back OOM kills and evictions are tracked.
+const OOMLookback = 24 * time.Hour
+
+var coreEvGVR = client.NewGVR("v1/events")
+
+var _ Accessor = (*OOM)(nil)
+
+// OOM tracks OOM killed containers and evicted pods.
+type OOM struct {
+	NonResource
+}
+
+// List returns the OOM kills and evictions seen during the lookback window,
+// across all selected contexts when more than one is selected.
+func (o *OOM) List(ctx context.Context, ns string) ([]runtime.Object, error) {
+	since := time.Now().Add(-OOMLookback)
+	f := o.getFactory()
+	current := f.Client().ActiveContext()
+
+	sel, _ := config.LoadSelectedContexts()
+	if len(sel) < 2 {
+		pp, err := f.List(client.PodGVR, ns, false, labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		ee, err := f.List(coreEvGVR, ns, false, labels.Everything())
+		if err != nil {
+			slog.Warn("OOM tracker unable to list events", slogs.Error, err)
+		}
+		return OOMRecords(current, toPods(pp), toEvents(ee), since), nil
+	}
+
+	rawCfg, err := f.Client().Config().RawConfig()
+	if err != nil {
+		return nil, err
+	}
+	pp, perr := MultiContextList(ctx, rawCfg, sel, client.PodGVR.GVR(), ns, "")
+	ee, eerr := MultiContextList(ctx, rawCfg, sel, coreEvGVR.GVR(), ns, "")
+	err = JoinMultiContextErrors(perr, eerr)
+	failed := FailedContexts(err)
+	pods, events := make(map[string][]runtime.Object), make(map[string][]runtime.Object)
+	for _, co := range pp {
+		pods[co.Context] = append(pods[co.Context], co.Object)
+	}
+	for _, co := range ee {
+		events[co.Context] = append(events[co.Context], co.Object)
+	}
+
+	var oo []runtime.Object
+	for _, c := range sel {
+		if _, ok := failed[c]; ok {
+			continue
+		}
+		oo = append(oo, OOMRecords(c, toPods(pods[c]), toEvents(events[c]), since)...)
+	}
+
+	return oo, err
+}
+
+// OOMRecords aggregates OOM kills and evictions per container from pod statuses
+// and events newer than since.
+func OOMRecords(ctxName string, pods []*v1.Pod, events []*v1.Event, since time.Time) []runtime.Object {
+	recs := make(map[string]*render.OOMRes)
+	var order []string
+	track := func(po *v1.Pod, ns, pod, co, reason, msg string, count int, at time.Time) {
+		if at.Before(since) {
+			return
+		}
+		r := &render.OOMRes{
+			Context:   ctxName,
+			Namespace: ns,
+			Pod:       pod,
+			Container: co,
+			Reason:    reason,
+			Message:   msg,
+		}
+		if po != nil {
+			r.WorkloadGVR, r.Workload = podWorkload(po)
+			r.MemRequest, r.MemLimit = podMemory(po, co)
+		}
+		id := r.ID()
+		if prev, ok := recs[id]; ok {
+			prev.Count += count
+			if at.After(prev.LastSeen) {
+				prev.LastSeen = at
+				if msg != "" {
+					prev.Message = msg
+				}
+			}
+			return
+		}
+		r.Count, r.LastSeen = count, at
+		recs[id] = r
+		order = append(order, id)
+	}
+
+	podsByFQN := make(map[string]*v1.Pod, len(pods))
+	for _, po := range pods {
+		podsByFQN[client.FQN(po.Namespace, po.Name)] = po
+		if po.Status.Reason == render.ReasonEvicted {
+			track(po, po.Namespace, po.Name, "", render.ReasonEvicted, po.Status.Message, 1, evictedAt(po))
+		}
+		for _, cs := range po.Status.ContainerStatuses {
+			for _, t := range []*v1.ContainerStateTerminated{cs.LastTerminationState.Terminated, cs.State.Terminated} {
+				if t != nil && t.Reason == render.ReasonOOMKilled {
+					track(po, po.Namespace, po.Name, cs.Name, render.ReasonOOMKilled, fmt.Sprintf("exit %d, %d restarts", t.ExitCode, cs.RestartCount), 1, t.FinishedAt.Time)
+				}
+			}
+		}
+	}
+
+	for _, e := range events {
+		// Evictions of pods still around are already tracked via their status.
+		if e.Reason != render.ReasonEvicted || e.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+		fqn := client.FQN(e.InvolvedObject.Namespace, e.InvolvedObject.Name)
+		if po, ok := podsByFQN[fqn]; ok && po.Status.Reason == render.ReasonEvicted {
+			continue
+		}
+		count := max(int(e.Count), 1)
+		track(podsByFQN[fqn], e.InvolvedObject.Namespace, e.InvolvedObject.Name, "", render.ReasonEvicted, e.Message, count, eventTime(e))
+	}
+
+	oo := make([]runtime.Object, 0, len(order))
+	for _, id := range order {
+		oo = append(oo, recs[id])
+	}
+
+	return oo
+}
+
+// podWorkload returns the gvr and name of the workload owning a pod.
+func podWorkload(po *v1.Pod) (gvr, name string) {
+	for _, ref := range po.OwnerReferences {
+		if ref.Controller == nil || !*ref.Controller {
+			continue
+		}
+		switch ref.Kind {
+		case "ReplicaSet":
+			if h, ok := po.Labels["pod-template-hash"]; ok {
+				return client.DpGVR.String(), strings.TrimSuffix(ref.Name, "-"+h)
+			}
+			return client.RsGVR.String(), ref.Name
+		case "StatefulSet":
+			return client.StsGVR.String(), ref.Name
+		case "DaemonSet":
+			return client.DsGVR.String(), ref.Name
+		case "Job":
+			return client.JobGVR.String(), ref.Name
+		}
+	}
+
+	return client.PodGVR.String(), po.Name
+}
+
+// podMemory returns the memory request and limit of a container or the sum
+// over all containers when none is given.
+func podMemory(po *v1.Pod, co string) (req, lim string) {
+	var rq, lq resource.Quantity
+	var hasLim bool
+	for _, c := range po.Spec.Containers {
+		if co != "" && c.Name != co {
+			continue
+		}
+		if q, ok := c.Resources.Requests[v1.ResourceMemory]; ok {
+			rq.Add(q)
+		}
+		if q, ok := c.Resources.Limits[v1.ResourceMemory]; ok {
+			lq.Add(q)
+			hasLim = true
+		}
+	}
+	if !rq.IsZero() {
+		req = rq.String()
+	}
+	if hasLim {
+		lim = lq.String()
+	}
+
+	return
+}
+
+func evictedAt(po *v1.Pod) time.Time {
+	at := po.CreationTimestamp.Time
+	for _, c := range po.Status.Conditions {
+		if c.LastTransitionTime.After(at) {
+			at = c.LastTransitionTime.Time
+		}
+	}
+
+	return at
+}
+
+func toPods(oo []runtime.Object) []*v1.Pod {
+	pp := make([]*v1.Pod, 0, len(oo))
+	for _, o := range oo {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		var po v1.Pod
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &po); err != nil {
+			slog.Warn("Pod conversion failed", slogs.Error, err)
+			continue
+		}
+		pp = append(pp, &po)
+	}
+
+	return pp
+}
+
+func toEvents(oo []runtime.Object) []*v1.Event {
+	ee := make([]*v1.Event, 0, len(oo))
+	for _, o := range oo {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		var e v1.Event
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &e); err != nil {
+			slog.Warn("Event conversion failed", slogs.Error, err)
+			continue
+		}
+		ee = append(ee, &e)
+	}
+
+	return ee
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOOMRecords(t *testing.T) {
+	now := time.Now()
+	since, ctrl := now.Add(-dao.OOMLookback), true
+
+	oomPod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "web-7d9f8-abcde",
+			Labels:    map[string]string{"pod-template-hash": "7d9f8"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "web-7d9f8", Controller: &ctrl},
+			},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: "c1",
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("64Mi")},
+						Limits:   v1.ResourceList{v1.ResourceMemory: resource.MustParse("128Mi")},
+					},
+				},
+			},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:         "c1",
+					RestartCount: 3,
+					LastTerminationState: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							Reason:     render.ReasonOOMKilled,
+							ExitCode:   137,
+							FinishedAt: metav1.NewTime(now.Add(-time.Hour)),
+						},
+					},
+				},
+			},
+		},
+	}
+	stalePod := oomPod
+	stalePod.Name = "web-7d9f8-old"
+	stalePod.Status = v1.PodStatus{
+		ContainerStatuses: []v1.ContainerStatus{
+			{
+				Name: "c1",
+				LastTerminationState: v1.ContainerState{
+					Terminated: &v1.ContainerStateTerminated{
+						Reason:     render.ReasonOOMKilled,
+						FinishedAt: metav1.NewTime(since.Add(-time.Hour)),
+					},
+				},
+			},
+		},
+	}
+	evicted := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "ns2",
+			Name:              "db-0",
+			CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)),
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "StatefulSet", Name: "db", Controller: &ctrl},
+			},
+		},
+		Status: v1.PodStatus{
+			Phase:   v1.PodFailed,
+			Reason:  render.ReasonEvicted,
+			Message: "The node was low on resource: memory.",
+		},
+	}
+	ev := v1.Event{
+		InvolvedObject: v1.ObjectReference{Kind: "Pod", Namespace: "ns2", Name: "gone-1"},
+		Reason:         render.ReasonEvicted,
+		Message:        "The node was low on resource: ephemeral-storage.",
+		Count:          2,
+		LastTimestamp:  metav1.NewTime(now.Add(-30 * time.Minute)),
+	}
+
+	oo := dao.OOMRecords("ct1", []*v1.Pod{&oomPod, &stalePod, &evicted}, []*v1.Event{&ev}, since)
+	require.Len(t, oo, 3)
+
+	uu := []render.OOMRes{
+		{
+			Context:     "ct1",
+			Namespace:   "ns1",
+			WorkloadGVR: "apps/v1/deployments",
+			Workload:    "web",
+			Pod:         "web-7d9f8-abcde",
+			Container:   "c1",
+			Reason:      render.ReasonOOMKilled,
+			Message:     "exit 137, 3 restarts",
+			MemRequest:  "64Mi",
+			MemLimit:    "128Mi",
+			Count:       1,
+			LastSeen:    now.Add(-time.Hour),
+		},
+		{
+			Context:     "ct1",
+			Namespace:   "ns2",
+			WorkloadGVR: "apps/v1/statefulsets",
+			Workload:    "db",
+			Pod:         "db-0",
+			Reason:      render.ReasonEvicted,
+			Message:     "The node was low on resource: memory.",
+			Count:       1,
+			LastSeen:    now.Add(-2 * time.Hour),
+		},
+		{
+			Context:   "ct1",
+			Namespace: "ns2",
+			Pod:       "gone-1",
+			Reason:    render.ReasonEvicted,
+			Message:   "The node was low on resource: ephemeral-storage.",
+			Count:     2,
+			LastSeen:  now.Add(-30 * time.Minute),
+		},
+	}
+	for i, u := range uu {
+		r, ok := oo[i].(*render.OOMRes)
+		require.True(t, ok)
+		assert.True(t, u.LastSeen.Equal(r.LastSeen))
+		r.LastSeen = u.LastSeen
+		assert.Equal(t, u, *r)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -118,11 +119,11 @@ func (p *Pod) List(ctx context.Context, ns string) ([]runtime.Object, error) {
 		pmx, _ = client.DialMetrics(p.Client()).FetchPodsMetricsMap(ctx, ns)
 	}
 	sel, _ := ctx.Value(internal.KeyFields).(string)
-	fsel, err := labels.ConvertSelectorToLabelsMap(sel)
+	fsel, err := fields.ParseSelector(sel)
 	if err != nil {
 		return nil, err
 	}
-	nodeName := fsel["spec.nodeName"]
+	nodeName, _ := fsel.RequiresExactMatch("spec.nodeName")
 
 	res := make([]runtime.Object, 0, len(oo))
 	for _, o := range oo {
@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const preemptedReason = "Preempted"
+
+// Preemption represents a pod preempted by the scheduler.
+type Preemption struct {
+	Namespace, Pod, Message string
+	Count                   int32
+	Last                    time.Time
+}
+
+// FetchPreemptions returns the preemption events of a namespace, newest first.
+func FetchPreemptions(ctx context.Context, c client.Connection, ns string) ([]Preemption, error) {
+	dial, err := c.Dial()
+	if err != nil {
+		return nil, err
+	}
+	ll, err := dial.CoreV1().Events(ns).List(ctx, metav1.ListOptions{FieldSelector: "reason=" + preemptedReason})
+	if err != nil {
+		return nil, err
+	}
+	pp := make([]Preemption, 0, len(ll.Items))
+	for i := range ll.Items {
+		ev := &ll.Items[i]
+		last := ev.LastTimestamp.Time
+		if last.IsZero() {
+			last = ev.EventTime.Time
+		}
+		pp = append(pp, Preemption{
+			Namespace: ev.InvolvedObject.Namespace,
+			Pod:       ev.InvolvedObject.Name,
+			Message:   ev.Message,
+			Count:     max(ev.Count, 1),
+			Last:      last,
+		})
+	}
+	sort.Slice(pp, func(i, j int) bool {
+		return pp[i].Last.After(pp[j].Last)
+	})
+
+	return pp, nil
+}
+
+// NodeFit represents how a pod would land on a node.
+type NodeFit struct {
+	Node string
+	// Fits is set when the pod fits on the node without preemption.
+	Fits bool
+	// Victims tracks the lower priority pods that would be preempted, if any.
+	Victims []string
+}
+
+// PreemptionWhatIf checks where a pod with the given requests and priority
+// would fit and which lower priority pods it would preempt otherwise. Only
+// cpu and memory requests are accounted for, scheduling constraints aren't.
+func PreemptionWhatIf(req v1.ResourceList, prio int32, nodes []v1.Node, pods []v1.Pod, prios map[string]int32) []NodeFit {
+	byNode := make(map[string][]*v1.Pod)
+	for i := range pods {
+		po := &pods[i]
+		if po.Spec.NodeName == "" || po.Status.Phase == v1.PodSucceeded || po.Status.Phase == v1.PodFailed {
+			continue
+		}
+		byNode[po.Spec.NodeName] = append(byNode[po.Spec.NodeName], po)
+	}
+
+	ff := make([]NodeFit, 0, len(nodes))
+	for i := range nodes {
+		no := &nodes[i]
+		if no.Spec.Unschedulable {
+			continue
+		}
+		free := no.Status.Allocatable.DeepCopy()
+		var candidates []*v1.Pod
+		for _, po := range byNode[no.Name] {
+			subRequests(free, PodRequests(&po.Spec))
+			if PodPriority(&po.Spec, prios) < prio {
+				candidates = append(candidates, po)
+			}
+		}
+		f := NodeFit{Node: no.Name, Fits: fitsRequests(req, free)}
+		if !f.Fits {
+			sort.SliceStable(candidates, func(i, j int) bool {
+				return PodPriority(&candidates[i].Spec, prios) < PodPriority(&candidates[j].Spec, prios)
+			})
+			for _, po := range candidates {
+				addRequests(free, PodRequests(&po.Spec))
+				f.Victims = append(f.Victims, client.FQN(po.Namespace, po.Name))
+				if fitsRequests(req, free) {
+					break
+				}
+			}
+			if !fitsRequests(req, free) {
+				f.Victims = nil
+			}
+		}
+		ff = append(ff, f)
+	}
+
+	return ff
+}
+
+// PodPriority returns a pod priority, resolving its priority class when the
+// priority isn't set yet, ie on a pod template.
+func PodPriority(spec *v1.PodSpec, prios map[string]int32) int32 {
+	if spec.Priority != nil {
+		return *spec.Priority
+	}
+
+	return prios[spec.PriorityClassName]
+}
+
+// PodRequests returns the cpu and memory requests of a pod spec.
+func PodRequests(spec *v1.PodSpec) v1.ResourceList {
+	rl := v1.ResourceList{}
+	for _, co := range spec.Containers {
+		addRequests(rl, co.Resources.Requests)
+	}
+	for _, co := range spec.InitContainers {
+		for _, n := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			if q, ok := co.Resources.Requests[n]; ok && q.Cmp(rl[n]) > 0 {
+				rl[n] = q.DeepCopy()
+			}
+		}
+	}
+
+	return rl
+}
+
+// FetchPriorities returns the priority class values by name. The global
+// default value is keyed by the empty name.
+func FetchPriorities(ctx context.Context, c client.Connection) (map[string]int32, error) {
+	dial, err := c.Dial()
+	if err != nil {
+		return nil, err
+	}
+	ll, err := dial.SchedulingV1().PriorityClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	prios := make(map[string]int32, len(ll.Items)+1)
+	for _, pc := range ll.Items {
+		prios[pc.Name] = pc.Value
+		if pc.GlobalDefault {
+			prios[""] = pc.Value
+		}
+	}
+
+	return prios, nil
+}
+
+// DeploymentWhatIf checks whether a new pod of a deployment would preempt
+// lower priority pods.
+func DeploymentWhatIf(ctx context.Context, c client.Connection, fqn string) (int32, []NodeFit, error) {
+	dial, err := c.Dial()
+	if err != nil {
+		return 0, nil, err
+	}
+	ns, n := client.Namespaced(fqn)
+	dp, err := dial.AppsV1().Deployments(ns).Get(ctx, n, metav1.GetOptions{})
+	if err != nil {
+		return 0, nil, err
+	}
+	prios, err := FetchPriorities(ctx, c)
+	if err != nil {
+		return 0, nil, err
+	}
+	spec := &dp.Spec.Template.Spec
+	if spec.PriorityClassName != "" {
+		if _, ok := prios[spec.PriorityClassName]; !ok {
+			return 0, nil, fmt.Errorf("priority class %q not found", spec.PriorityClassName)
+		}
+	}
+	if spec.PreemptionPolicy != nil && *spec.PreemptionPolicy == v1.PreemptNever {
+		return 0, nil, fmt.Errorf("deployment %s never preempts (preemptionPolicy: Never)", fqn)
+	}
+	nn, err := dial.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, nil, err
+	}
+	pp, err := dial.CoreV1().Pods(client.BlankNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, nil, err
+	}
+	prio := PodPriority(spec, prios)
+
+	return prio, PreemptionWhatIf(PodRequests(spec), prio, nn.Items, pp.Items, prios), nil
+}
+
+func fitsRequests(req, free v1.ResourceList) bool {
+	for n, q := range req {
+		f, ok := free[n]
+		if !ok || q.Cmp(f) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func addRequests(rl, delta v1.ResourceList) {
+	for _, n := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		if q, ok := delta[n]; ok {
+			sum := rl[n].DeepCopy()
+			sum.Add(q)
+			rl[n] = sum
+		}
+	}
+}
+
+func subRequests(rl, delta v1.ResourceList) {
+	for _, n := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		if q, ok := delta[n]; ok {
+			diff := rl[n].DeepCopy()
+			diff.Sub(q)
+			rl[n] = diff
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPreemptionWhatIf(t *testing.T) {
+	rl := func(cpu, mem string) v1.ResourceList {
+		return v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse(cpu),
+			v1.ResourceMemory: resource.MustParse(mem),
+		}
+	}
+	node := func(n, cpu, mem string) v1.Node {
+		return v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: n},
+			Status:     v1.NodeStatus{Allocatable: rl(cpu, mem)},
+		}
+	}
+	pod := func(n, node, class, cpu, mem string) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: n, Namespace: "ns1"},
+			Spec: v1.PodSpec{
+				NodeName:          node,
+				PriorityClassName: class,
+				Containers:        []v1.Container{{Resources: v1.ResourceRequirements{Requests: rl(cpu, mem)}}},
+			},
+		}
+	}
+	prios := map[string]int32{"": 0, "low": 100, "high": 1000}
+
+	uu := map[string]struct {
+		req   v1.ResourceList
+		prio  int32
+		nodes []v1.Node
+		pods  []v1.Pod
+		e     []dao.NodeFit
+	}{
+		"fits": {
+			req:   rl("500m", "512Mi"),
+			prio:  1000,
+			nodes: []v1.Node{node("n1", "2", "2Gi")},
+			pods:  []v1.Pod{pod("p1", "n1", "low", "1", "1Gi")},
+			e:     []dao.NodeFit{{Node: "n1", Fits: true}},
+		},
+		"preempts-lowest-first": {
+			req:   rl("1", "1Gi"),
+			prio:  1000,
+			nodes: []v1.Node{node("n1", "2", "2Gi")},
+			pods: []v1.Pod{
+				pod("p1", "n1", "low", "1", "1Gi"),
+				pod("p2", "n1", "", "1", "1Gi"),
+			},
+			e: []dao.NodeFit{{Node: "n1", Victims: []string{"ns1/p2"}}},
+		},
+		"no-victims": {
+			req:   rl("1", "1Gi"),
+			prio:  100,
+			nodes: []v1.Node{node("n1", "2", "2Gi")},
+			pods: []v1.Pod{
+				pod("p1", "n1", "high", "1", "1Gi"),
+				pod("p2", "n1", "low", "1", "1Gi"),
+			},
+			e: []dao.NodeFit{{Node: "n1"}},
+		},
+		"too-big": {
+			req:   rl("4", "1Gi"),
+			prio:  1000,
+			nodes: []v1.Node{node("n1", "2", "2Gi")},
+			pods:  []v1.Pod{pod("p1", "n1", "low", "1", "1Gi")},
+			e:     []dao.NodeFit{{Node: "n1"}},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.PreemptionWhatIf(u.req, u.prio, u.nodes, u.pods, prios))
+		})
+	}
+}
+
+func TestPodRequests(t *testing.T) {
+	spec := v1.PodSpec{
+		InitContainers: []v1.Container{
+			{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")}}},
+		},
+		Containers: []v1.Container{
+			{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m"), v1.ResourceMemory: resource.MustParse("1Gi")}}},
+			{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")}}},
+		},
+	}
+	rl := dao.PodRequests(&spec)
+
+	cpu, mem := rl[v1.ResourceCPU], rl[v1.ResourceMemory]
+	assert.Equal(t, "2", cpu.String())
+	assert.Equal(t, "1Gi", mem.String())
+}
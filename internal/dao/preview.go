@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// PreviewEventsMax caps the number of events shown in a preview.
+const PreviewEventsMax = 5
+
+// previewSkipFields lists status fields carrying no value in a preview.
+var previewSkipFields = []string{"observedGeneration"}
+
+// PreviewField represents a preview key status field.
+type PreviewField struct {
+	Name, Value string
+}
+
+// PreviewCondition represents a resource condition.
+type PreviewCondition struct {
+	Type, Status, Reason, Message string
+}
+
+// PreviewEvent represents a recent resource event.
+type PreviewEvent struct {
+	Type, Reason, Message string
+	Count                 int
+	At                    time.Time
+}
+
+// Preview represents a condensed summary of a resource.
+type Preview struct {
+	Kind, Namespace, Name string
+	Created               time.Time
+	Fields                []PreviewField
+	Conditions            []PreviewCondition
+	Events                []PreviewEvent
+}
+
+// ResourcePreview returns a summary of a cached resource and its recent events.
+func ResourcePreview(f Factory, gvr *client.GVR, path string) (Preview, error) {
+	o, err := f.Get(gvr, path, false, labels.Everything())
+	if err != nil {
+		return Preview{}, err
+	}
+	u, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return Preview{}, fmt.Errorf("expecting unstructured but got %T", o)
+	}
+	ns := u.GetNamespace()
+	if ns == "" {
+		ns = client.NamespaceAll
+	}
+	// Events are best effort, the preview stands without them.
+	ee, _ := f.List(coreEvGVR, ns, false, labels.Everything())
+
+	return BuildPreview(u, toEvents(ee)), nil
+}
+
+// BuildPreview summarizes a resource key status fields, conditions and
+// latest events.
+func BuildPreview(u *unstructured.Unstructured, events []*v1.Event) Preview {
+	p := Preview{
+		Kind:      u.GetKind(),
+		Namespace: u.GetNamespace(),
+		Name:      u.GetName(),
+		Created:   u.GetCreationTimestamp().Time,
+	}
+
+	if r, ok, _ := unstructured.NestedInt64(u.Object, "spec", "replicas"); ok {
+		ready, _, _ := unstructured.NestedInt64(u.Object, "status", "readyReplicas")
+		p.Fields = append(p.Fields, PreviewField{Name: "replicas", Value: fmt.Sprintf("%d/%d ready", ready, r)})
+	}
+	if n, _, _ := unstructured.NestedString(u.Object, "spec", "nodeName"); n != "" {
+		p.Fields = append(p.Fields, PreviewField{Name: "node", Value: n})
+	}
+	status, _, _ := unstructured.NestedMap(u.Object, "status")
+	kk := make([]string, 0, len(status))
+	for k := range status {
+		kk = append(kk, k)
+	}
+	sort.Strings(kk)
+	for _, k := range kk {
+		if slices.Contains(previewSkipFields, k) {
+			continue
+		}
+		switch v := status[k].(type) {
+		case string, bool, int64, float64:
+			p.Fields = append(p.Fields, PreviewField{Name: k, Value: fmt.Sprintf("%v", v)})
+		}
+	}
+	p.Fields = append(p.Fields, containerFields(u)...)
+
+	cc, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range cc {
+		m, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		var pc PreviewCondition
+		pc.Type, _, _ = unstructured.NestedString(m, "type")
+		pc.Status, _, _ = unstructured.NestedString(m, "status")
+		pc.Reason, _, _ = unstructured.NestedString(m, "reason")
+		pc.Message, _, _ = unstructured.NestedString(m, "message")
+		p.Conditions = append(p.Conditions, pc)
+	}
+
+	for _, e := range events {
+		if !involves(e, u) {
+			continue
+		}
+		p.Events = append(p.Events, PreviewEvent{
+			Type:    e.Type,
+			Reason:  e.Reason,
+			Message: strings.TrimSpace(e.Message),
+			Count:   max(int(e.Count), 1),
+			At:      eventTime(e),
+		})
+	}
+	sort.SliceStable(p.Events, func(i, j int) bool {
+		return p.Events[i].At.After(p.Events[j].At)
+	})
+	if len(p.Events) > PreviewEventsMax {
+		p.Events = p.Events[:PreviewEventsMax]
+	}
+
+	return p
+}
+
+// containerFields summarizes a pod containers state.
+func containerFields(u *unstructured.Unstructured) []PreviewField {
+	ss, _, _ := unstructured.NestedSlice(u.Object, "status", "containerStatuses")
+	ff := make([]PreviewField, 0, len(ss))
+	for _, s := range ss {
+		m, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(m, "name")
+		ready, _, _ := unstructured.NestedBool(m, "ready")
+		restarts, _, _ := unstructured.NestedInt64(m, "restartCount")
+		state, _, _ := unstructured.NestedMap(m, "state")
+		var st string
+		for k, v := range state {
+			st = k
+			if sm, ok := v.(map[string]any); ok {
+				if r, _, _ := unstructured.NestedString(sm, "reason"); r != "" {
+					st += " (" + r + ")"
+				}
+			}
+		}
+		ff = append(ff, PreviewField{
+			Name:  "container " + name,
+			Value: fmt.Sprintf("%s, ready=%t, restarts=%d", st, ready, restarts),
+		})
+	}
+
+	return ff
+}
+
+func involves(e *v1.Event, u *unstructured.Unstructured) bool {
+	if uid := u.GetUID(); uid != "" && e.InvolvedObject.UID != "" {
+		return e.InvolvedObject.UID == uid
+	}
+
+	return e.InvolvedObject.Kind == u.GetKind() &&
+		e.InvolvedObject.Name == u.GetName() &&
+		e.InvolvedObject.Namespace == u.GetNamespace()
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestBuildPreview(t *testing.T) {
+	now := time.Now()
+	o := unstructured.Unstructured{Object: map[string]any{
+		"kind": "Pod",
+		"metadata": map[string]any{
+			"name":      "p1",
+			"namespace": "ns1",
+			"uid":       "u1",
+		},
+		"spec": map[string]any{"nodeName": "n1"},
+		"status": map[string]any{
+			"phase":              "Running",
+			"observedGeneration": int64(2),
+			"podIP":              "10.0.0.1",
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": "False", "reason": "ContainersNotReady"},
+			},
+			"containerStatuses": []any{
+				map[string]any{
+					"name":         "c1",
+					"ready":        false,
+					"restartCount": int64(3),
+					"state": map[string]any{
+						"waiting": map[string]any{"reason": "CrashLoopBackOff"},
+					},
+				},
+			},
+		},
+	}}
+	ee := []*v1.Event{
+		makePreviewEvent("u1", "BackOff", now.Add(-time.Minute)),
+		makePreviewEvent("u2", "Scheduled", now),
+		makePreviewEvent("u1", "Pulled", now.Add(-time.Hour)),
+	}
+
+	p := dao.BuildPreview(&o, ee)
+	assert.Equal(t, "Pod", p.Kind)
+	assert.Equal(t, "ns1", p.Namespace)
+	assert.Equal(t, []dao.PreviewField{
+		{Name: "node", Value: "n1"},
+		{Name: "phase", Value: "Running"},
+		{Name: "podIP", Value: "10.0.0.1"},
+		{Name: "container c1", Value: "waiting (CrashLoopBackOff), ready=false, restarts=3"},
+	}, p.Fields)
+	assert.Equal(t, []dao.PreviewCondition{
+		{Type: "Ready", Status: "False", Reason: "ContainersNotReady"},
+	}, p.Conditions)
+	assert.Len(t, p.Events, 2)
+	assert.Equal(t, "BackOff", p.Events[0].Reason)
+	assert.Equal(t, "Pulled", p.Events[1].Reason)
+}
+
+func TestBuildPreviewReplicas(t *testing.T) {
+	o := unstructured.Unstructured{Object: map[string]any{
+		"kind":   "Deployment",
+		"spec":   map[string]any{"replicas": int64(3)},
+		"status": map[string]any{"readyReplicas": int64(2)},
+	}}
+
+	p := dao.BuildPreview(&o, nil)
+	assert.Equal(t, dao.PreviewField{Name: "replicas", Value: "2/3 ready"}, p.Fields[0])
+	assert.Empty(t, p.Events)
+}
+
+// Helpers...
+
+func makePreviewEvent(uid types.UID, reason string, at time.Time) *v1.Event {
+	return &v1.Event{
+		InvolvedObject: v1.ObjectReference{UID: uid},
+		Reason:         reason,
+		LastTimestamp:  metav1.NewTime(at),
+	}
+}
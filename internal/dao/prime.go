@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Component builds.
+const (
+	BuildPrime     = "prime"
+	BuildCommunity = "community"
+	BuildMirror    = "mirror"
+)
+
+const (
+	dockerHub          = "docker.io"
+	rancherPrimeConfig = "rancher-prime"
+)
+
+var (
+	// communityRegistries tracks the public registries serving community builds.
+	communityRegistries = []string{dockerHub, "index.docker.io", "registry-1.docker.io"}
+
+	rancherSettingGVR = client.NewGVR("management.cattle.io/v3/settings")
+)
+
+// PrimeComponent represents a SUSE Rancher Prime supported component.
+type PrimeComponent struct {
+	Name      string
+	Namespace string
+	Selector  string
+}
+
+// PrimeComponents tracks the components checked for Prime builds.
+var PrimeComponents = []PrimeComponent{
+	{Name: "Rancher", Namespace: "cattle-system", Selector: "app=rancher"},
+	{Name: "Fleet", Namespace: "cattle-fleet-system", Selector: "app=fleet-controller"},
+	{Name: "RKE2", Namespace: "kube-system", Selector: "component=kube-apiserver"},
+	{Name: "Longhorn", Namespace: "longhorn-system", Selector: "app=longhorn-manager"},
+	{Name: "NeuVector", Namespace: "cattle-neuvector-system", Selector: "app=neuvector-controller-pod"},
+	{Name: "Harvester", Namespace: "harvester-system", Selector: "app.kubernetes.io/name=harvester"},
+	{Name: "Kubewarden", Namespace: "kubewarden", Selector: "app.kubernetes.io/name=kubewarden-controller"},
+	{Name: "Monitoring", Namespace: "cattle-monitoring-system", Selector: "app=rancher-monitoring-operator"},
+}
+
+// PrimeStatus represents the build a component runs on a context.
+type PrimeStatus struct {
+	Context   string
+	Component string
+	Images    []string
+	Build     string
+}
+
+// PrimeReport represents the Prime builds and entitlement data of a context.
+type PrimeReport struct {
+	Context string
+	// RancherPrime tracks the rancher-prime setting, blank when not exposed.
+	RancherPrime string
+	Components   []PrimeStatus
+}
+
+// FetchPrimeReport checks which builds the Prime supported components of a
+// context run.
+func FetchPrimeReport(ctx context.Context, rawCfg api.Config, ctxName string, prime []string) (*PrimeReport, error) {
+	kc, err := kubeClientFor(rawCfg, ctxName)
+	if err != nil {
+		return nil, err
+	}
+	r := PrimeReport{Context: ctxName}
+	if dyn, err := dynClientFor(rawCfg, ctxName); err == nil {
+		if u, err := dyn.Resource(rancherSettingGVR.GVR()).Get(ctx, rancherPrimeConfig, metav1.GetOptions{}); err == nil {
+			r.RancherPrime = settingValue(u)
+		}
+	}
+	for _, c := range PrimeComponents {
+		pp, err := kc.CoreV1().Pods(c.Namespace).List(ctx, metav1.ListOptions{LabelSelector: c.Selector})
+		if err != nil {
+			if kerrors.IsNotFound(err) || kerrors.IsForbidden(err) {
+				continue
+			}
+			return nil, err
+		}
+		ii := podImages(pp.Items)
+		if len(ii) == 0 {
+			continue
+		}
+		r.Components = append(r.Components, PrimeStatus{
+			Context:   ctxName,
+			Component: c.Name,
+			Images:    ii,
+			Build:     ImagesBuild(ii, prime),
+		})
+	}
+
+	return &r, nil
+}
+
+// settingValue returns the value of a Rancher setting, its default when unset.
+func settingValue(u *unstructured.Unstructured) string {
+	v, _, _ := unstructured.NestedString(u.Object, "value")
+	if v == "" {
+		v, _, _ = unstructured.NestedString(u.Object, "default")
+	}
+
+	return v
+}
+
+// ImagesBuild classifies a set of images as Prime builds when all of them
+// come from a Prime registry, community builds when any comes from a public
+// registry and mirrored builds otherwise.
+func ImagesBuild(ii, prime []string) string {
+	build := BuildPrime
+	for _, i := range ii {
+		switch {
+		case fromRegistry(i, prime):
+		case slices.Contains(communityRegistries, ImageRegistry(i)):
+			return BuildCommunity
+		default:
+			build = BuildMirror
+		}
+	}
+
+	return build
+}
+
+// ImageRegistry returns the registry host of an image, Docker Hub when none.
+func ImageRegistry(img string) string {
+	host, _, ok := strings.Cut(img, "/")
+	if !ok || (!strings.ContainsAny(host, ".:") && host != "localhost") {
+		return dockerHub
+	}
+
+	return host
+}
+
+func fromRegistry(img string, rr []string) bool {
+	for _, r := range rr {
+		if r = strings.TrimSuffix(r, "/"); strings.HasPrefix(img, r+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func podImages(pp []v1.Pod) []string {
+	set := make(map[string]struct{})
+	for i := range pp {
+		for _, co := range pp[i].Spec.InitContainers {
+			set[co.Image] = struct{}{}
+		}
+		for _, co := range pp[i].Spec.Containers {
+			set[co.Image] = struct{}{}
+		}
+	}
+	ii := make([]string, 0, len(set))
+	for i := range set {
+		ii = append(ii, i)
+	}
+	sort.Strings(ii)
+
+	return ii
+}
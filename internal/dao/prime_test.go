@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageRegistry(t *testing.T) {
+	uu := map[string]string{
+		"nginx":                                   "docker.io",
+		"rancher/rancher:v2.9.3":                  "docker.io",
+		"docker.io/rancher/rancher:v2.9.3":        "docker.io",
+		"registry.rancher.com/rancher/rancher:v2": "registry.rancher.com",
+		"localhost/fred:1":                        "localhost",
+		"harbor.local:5000/rancher/fleet:v0.11":   "harbor.local:5000",
+	}
+
+	for img, e := range uu {
+		t.Run(img, func(t *testing.T) {
+			assert.Equal(t, e, dao.ImageRegistry(img))
+		})
+	}
+}
+
+func TestImagesBuild(t *testing.T) {
+	prime := []string{"registry.rancher.com", "harbor.local/prime/"}
+
+	uu := map[string]struct {
+		ii []string
+		e  string
+	}{
+		"prime": {
+			ii: []string{"registry.rancher.com/rancher/rancher:v2.9.3", "harbor.local/prime/rancher/shell:v0.2"},
+			e:  dao.BuildPrime,
+		},
+		"community": {
+			ii: []string{"registry.rancher.com/rancher/rancher:v2.9.3", "rancher/shell:v0.2"},
+			e:  dao.BuildCommunity,
+		},
+		"mirror": {
+			ii: []string{"registry.rancher.com/rancher/rancher:v2.9.3", "harbor.local/rancher/shell:v0.2"},
+			e:  dao.BuildMirror,
+		},
+		"community-wins": {
+			ii: []string{"harbor.local/rancher/shell:v0.2", "docker.io/rancher/rancher:v2.9.3"},
+			e:  dao.BuildCommunity,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.ImagesBuild(u.ii, prime))
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/derailed/k9s/internal/config/data"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	promQueryTimeout = 5 * time.Second
+	promCacheSweep   = time.Minute
+)
+
+// PromSample represents the outcome of a cached PromQL query.
+type PromSample struct {
+	Value   float64
+	OK      bool
+	Err     error
+	expires time.Time
+}
+
+var promCache = struct {
+	sync.Mutex
+	entries map[string]PromSample
+	swept   time.Time
+}{entries: make(map[string]PromSample)}
+
+// promPaused tracks whether Prometheus column queries are paused.
+var promPaused atomic.Bool
+
+// PausePromQueries pauses or resumes Prometheus column queries, ie in low
+// power mode. Cached values keep showing while paused.
+func PausePromQueries(b bool) {
+	promPaused.Store(b)
+}
+
+// PromQueriesPaused checks if Prometheus column queries are paused.
+func PromQueriesPaused() bool {
+	return promPaused.Load()
+}
+
+// ResetPromCache clears cached Prometheus query results.
+func ResetPromCache() {
+	promCache.Lock()
+	defer promCache.Unlock()
+
+	clear(promCache.entries)
+}
+
+// CachedPromQuery returns the cached sample of a query on a context
+// Prometheus if any and whether it is still fresh.
+func CachedPromQuery(ctxName string, ep *data.Prometheus, query string) (PromSample, bool, bool) {
+	promCache.Lock()
+	defer promCache.Unlock()
+
+	s, ok := promCache.entries[promKey(ctxName, ep, query)]
+
+	return s, ok, ok && time.Now().Before(s.expires)
+}
+
+// PromQuery evaluates an instant PromQL query on a context Prometheus and
+// returns its first sample. Results, failures included, are cached for ttl.
+func PromQuery(ctx context.Context, rawCfg api.Config, ctxName string, ep *data.Prometheus, query string, ttl time.Duration) PromSample {
+	key := promKey(ctxName, ep, query)
+	promCache.Lock()
+	if s, ok := promCache.entries[key]; ok && time.Now().Before(s.expires) {
+		promCache.Unlock()
+		return s
+	}
+	promCache.Unlock()
+
+	var s PromSample
+	bb, err := promGet(ctx, rawCfg, ctxName, ep, query)
+	if err != nil {
+		s.Err = err
+	} else {
+		s.Value, s.OK, s.Err = ParsePromValue(bb)
+	}
+	s.expires = time.Now().Add(ttl)
+
+	promCache.Lock()
+	promCache.entries[key] = s
+	sweepPromCache(time.Now())
+	promCache.Unlock()
+
+	return s
+}
+
+// sweepPromCache evicts expired results, at most once per sweep period.
+// The cache lock must be held.
+func sweepPromCache(now time.Time) {
+	if now.Sub(promCache.swept) < promCacheSweep {
+		return
+	}
+	promCache.swept = now
+	for k, s := range promCache.entries {
+		if now.After(s.expires) {
+			delete(promCache.entries, k)
+		}
+	}
+}
+
+// promKey returns the cache key of a query on a context endpoint.
+func promKey(ctxName string, ep *data.Prometheus, query string) string {
+	kk := []string{ctxName, query}
+	if e := ep.PromEndpoint(); e != nil {
+		kk = append(kk, e.URL, e.Service, e.Scheme, strconv.FormatBool(e.PortForward))
+	}
+
+	return strings.Join(kk, "\x00")
+}
+
+func promGet(ctx context.Context, rawCfg api.Config, ctxName string, ep *data.Prometheus, query string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, promQueryTimeout)
+	defer cancel()
+
+	return monitoringGet(ctx, rawCfg, ctxName, ep.PromEndpoint(), promMonitoring, "api/v1/query", url.Values{"query": {query}}, nil)
+}
+
+// ParsePromValue extracts the first sample value of a Prometheus instant
+// query response. Returns false when the query yields no sample.
+func ParsePromValue(bb []byte) (float64, bool, error) {
+	var resp struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			ResultType string          `json:"resultType"`
+			Result     json.RawMessage `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(bb, &resp); err != nil {
+		return 0, false, fmt.Errorf("invalid prometheus response: %w", err)
+	}
+	if resp.Status != "success" {
+		return 0, false, fmt.Errorf("prometheus query failed: %s", resp.Error)
+	}
+
+	var sample []any
+	switch resp.Data.ResultType {
+	case "vector":
+		var vv []struct {
+			Value []any `json:"value"`
+		}
+		if err := json.Unmarshal(resp.Data.Result, &vv); err != nil {
+			return 0, false, fmt.Errorf("invalid prometheus vector: %w", err)
+		}
+		if len(vv) == 0 {
+			return 0, false, nil
+		}
+		sample = vv[0].Value
+	case "scalar":
+		if err := json.Unmarshal(resp.Data.Result, &sample); err != nil {
+			return 0, false, fmt.Errorf("invalid prometheus scalar: %w", err)
+		}
+	default:
+		return 0, false, fmt.Errorf("unsupported prometheus result type %q", resp.Data.ResultType)
+	}
+	if len(sample) != 2 {
+		return 0, false, nil
+	}
+	raw, ok := sample[1].(string)
+	if !ok {
+		return 0, false, fmt.Errorf("invalid prometheus sample %v", sample[1])
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, false, nil
+	}
+
+	return v, true, nil
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePromValue(t *testing.T) {
+	uu := map[string]struct {
+		raw string
+		v   float64
+		ok  bool
+		err bool
+	}{
+		"vector": {
+			raw: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"pod":"p1"},"value":[1700000000.1,"0.25"]},{"metric":{},"value":[1700000000.1,"3"]}]}}`,
+			v:   0.25,
+			ok:  true,
+		},
+		"scalar": {
+			raw: `{"status":"success","data":{"resultType":"scalar","result":[1700000000.1,"42"]}}`,
+			v:   42,
+			ok:  true,
+		},
+		"empty": {
+			raw: `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		},
+		"nan": {
+			raw: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000.1,"NaN"]}]}}`,
+		},
+		"failed": {
+			raw: `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+			err: true,
+		},
+		"matrix": {
+			raw: `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			err: true,
+		},
+		"garbage": {
+			raw: `<html>`,
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			v, ok, err := dao.ParsePromValue([]byte(u.raw))
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.ok, ok)
+			assert.InDelta(t, u.v, v, 0.0001)
+		})
+	}
+}
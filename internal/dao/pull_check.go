@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Pull failure classes.
+const (
+	PullCredentials = "credentials"
+	PullNotFound    = "not-found"
+	PullNetwork     = "network"
+	PullRateLimited = "rate-limited"
+	PullOther       = "other"
+)
+
+// PullSecretCheck represents a pull secret registry auth verdict.
+type PullSecretCheck struct {
+	Namespace, Secret, Registry string
+	Err                         error
+}
+
+// PullFailure represents a container failing to pull its image.
+type PullFailure struct {
+	Namespace, Pod, Container, Image string
+	Reason, Class, Message           string
+	Secrets                          []string
+}
+
+type dockerConfig struct {
+	Auths map[string]authn.AuthConfig `json:"auths"`
+}
+
+// CheckPullSecrets pings the registries referenced by the docker pull secrets
+// of a namespace using the secret credentials.
+func CheckPullSecrets(ctx context.Context, c client.Connection, ns string, insecure bool) ([]PullSecretCheck, error) {
+	dial, err := c.Dial()
+	if err != nil {
+		return nil, err
+	}
+	ss, err := dial.CoreV1().Secrets(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var cc []PullSecretCheck
+	for i := range ss.Items {
+		sec := &ss.Items[i]
+		auths, err := PullSecretAuths(sec)
+		if err != nil {
+			cc = append(cc, PullSecretCheck{Namespace: sec.Namespace, Secret: sec.Name, Err: err})
+			continue
+		}
+		for host, cfg := range auths {
+			cc = append(cc, PullSecretCheck{
+				Namespace: sec.Namespace,
+				Secret:    sec.Name,
+				Registry:  host,
+				Err:       pingRegistry(ctx, host, cfg, insecure),
+			})
+		}
+	}
+	sort.Slice(cc, func(i, j int) bool {
+		if cc[i].Namespace != cc[j].Namespace {
+			return cc[i].Namespace < cc[j].Namespace
+		}
+		return cc[i].Secret+cc[i].Registry < cc[j].Secret+cc[j].Registry
+	})
+
+	return cc, nil
+}
+
+// PullSecretAuths extracts registry credentials from a docker pull secret.
+// Non docker secrets yield no credentials.
+func PullSecretAuths(sec *v1.Secret) (map[string]authn.AuthConfig, error) {
+	switch sec.Type {
+	case v1.SecretTypeDockerConfigJson:
+		var cfg dockerConfig
+		if err := json.Unmarshal(sec.Data[v1.DockerConfigJsonKey], &cfg); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", v1.DockerConfigJsonKey, err)
+		}
+		return normalizeAuths(cfg.Auths), nil
+	case v1.SecretTypeDockercfg:
+		var auths map[string]authn.AuthConfig
+		if err := json.Unmarshal(sec.Data[v1.DockerConfigKey], &auths); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", v1.DockerConfigKey, err)
+		}
+		return normalizeAuths(auths), nil
+	default:
+		return nil, nil
+	}
+}
+
+func normalizeAuths(aa map[string]authn.AuthConfig) map[string]authn.AuthConfig {
+	out := make(map[string]authn.AuthConfig, len(aa))
+	for k, v := range aa {
+		host := strings.TrimPrefix(strings.TrimPrefix(k, "https://"), "http://")
+		host, _, _ = strings.Cut(host, "/")
+		out[host] = v
+	}
+
+	return out
+}
+
+func pingRegistry(ctx context.Context, host string, cfg authn.AuthConfig, insecure bool) error {
+	var opts []name.Option
+	if insecure {
+		opts = append(opts, name.Insecure)
+	}
+	reg, err := name.NewRegistry(host, opts...)
+	if err != nil {
+		return err
+	}
+	tr, err := transport.NewWithContext(ctx, reg, authn.FromConfig(cfg), http.DefaultTransport, []string{"registry:catalog:*"})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s/v2/", reg.Scheme(), reg.RegistryStr()), http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := (&http.Client{Transport: tr}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return transport.CheckError(resp, http.StatusOK)
+}
+
+// PullFailures lists containers stuck pulling their images and classifies the failure.
+func PullFailures(ctx context.Context, c client.Connection, ns string) ([]PullFailure, error) {
+	dial, err := c.Dial()
+	if err != nil {
+		return nil, err
+	}
+	pods, err := dial.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var ff []PullFailure
+	for i := range pods.Items {
+		po := &pods.Items[i]
+		secrets := make([]string, 0, len(po.Spec.ImagePullSecrets))
+		for _, s := range po.Spec.ImagePullSecrets {
+			secrets = append(secrets, s.Name)
+		}
+		for _, cs := range append(po.Status.InitContainerStatuses, po.Status.ContainerStatuses...) {
+			w := cs.State.Waiting
+			if w == nil || (w.Reason != "ImagePullBackOff" && w.Reason != "ErrImagePull") {
+				continue
+			}
+			ff = append(ff, PullFailure{
+				Namespace: po.Namespace,
+				Pod:       po.Name,
+				Container: cs.Name,
+				Image:     cs.Image,
+				Reason:    w.Reason,
+				Class:     ClassifyPullError(w.Message),
+				Message:   w.Message,
+				Secrets:   secrets,
+			})
+		}
+	}
+
+	return ff, nil
+}
+
+// pullStatusRX matches HTTP status codes next to their status text or a
+// status label, so digests or sizes holding the same digits are left alone.
+var pullStatusRX = regexp.MustCompile(`\b(401|403|404|429)[\s:-]+(unauthorized|forbidden|not found|too many requests)\b|\bstatus(?: code)?:?\s*(401|403|404|429)\b`)
+
+// pullStatus returns the HTTP status code of a pull failure if any.
+func pullStatus(m string) string {
+	mm := pullStatusRX.FindStringSubmatch(m)
+	if mm == nil {
+		return ""
+	}
+	if mm[1] != "" {
+		return mm[1]
+	}
+
+	return mm[3]
+}
+
+// ClassifyPullError tells credential related image pull failures apart from
+// missing images and connectivity issues.
+func ClassifyPullError(msg string) string {
+	m := strings.ToLower(msg)
+	code := pullStatus(m)
+	switch {
+	case code == "429" || containsAny(m, "toomanyrequests", "too many requests", "rate limit"):
+		return PullRateLimited
+	case code == "401" || code == "403" || containsAny(m, "unauthorized", "authentication required", "authorization failed", "access denied", "denied", "forbidden", "no basic auth credentials"):
+		return PullCredentials
+	case code == "404" || containsAny(m, "not found", "manifest unknown", "name unknown"):
+		return PullNotFound
+	case containsAny(m, "no such host", "i/o timeout", "connection refused", "tls:", "x509:", "context deadline exceeded", "network is unreachable"):
+		return PullNetwork
+	default:
+		return PullOther
+	}
+}
+
+func containsAny(s string, subs ...string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestClassifyPullError(t *testing.T) {
+	uu := map[string]struct {
+		msg, e string
+	}{
+		"empty": {
+			e: dao.PullOther,
+		},
+		"unauthorized": {
+			msg: `failed to pull and unpack image "reg.example.com/app:1.0": failed to authorize: failed to fetch oauth token: unexpected status: 401 Unauthorized`,
+			e:   dao.PullCredentials,
+		},
+		"denied": {
+			msg: `pull access denied for app, repository does not exist or may require 'docker login'`,
+			e:   dao.PullCredentials,
+		},
+		"not-found": {
+			msg: `failed to resolve reference "docker.io/library/nginx:nope": docker.io/library/nginx:nope: not found`,
+			e:   dao.PullNotFound,
+		},
+		"manifest-unknown": {
+			msg: `manifest unknown: manifest unknown`,
+			e:   dao.PullNotFound,
+		},
+		"network": {
+			msg: `dial tcp: lookup reg.example.com on 10.43.0.10:53: no such host`,
+			e:   dao.PullNetwork,
+		},
+		"rate-limited": {
+			msg: `429 Too Many Requests - Server message: toomanyrequests: You have reached your pull rate limit`,
+			e:   dao.PullRateLimited,
+		},
+		"status-code": {
+			msg: `failed to fetch anonymous token: unexpected status code 401`,
+			e:   dao.PullCredentials,
+		},
+		"forbidden-status": {
+			msg: `pulling from host reg.example.com failed with status code [manifests 1.0]: 403 Forbidden`,
+			e:   dao.PullCredentials,
+		},
+		"digest-digits": {
+			msg: `failed to copy: httpReadSeeker: failed open: failed to do request: Get "https://reg.example.com/v2/app/blobs/sha256:4013ab401f": EOF`,
+			e:   dao.PullOther,
+		},
+		"size-digits": {
+			msg: `failed to extract layer sha256:9f0a: write /var/lib/containerd/403221: no space left on device`,
+			e:   dao.PullOther,
+		},
+		"other": {
+			msg: `Back-off pulling image "app:1.0"`,
+			e:   dao.PullOther,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.ClassifyPullError(u.msg))
+		})
+	}
+}
+
+func TestPullSecretAuths(t *testing.T) {
+	uu := map[string]struct {
+		sec   v1.Secret
+		hosts []string
+		err   bool
+	}{
+		"opaque": {
+			sec: v1.Secret{Type: v1.SecretTypeOpaque},
+		},
+		"config-json": {
+			sec: v1.Secret{
+				Type: v1.SecretTypeDockerConfigJson,
+				Data: map[string][]byte{
+					v1.DockerConfigJsonKey: []byte(`{"auths":{"https://index.docker.io/v1/":{"auth":"Zm9vOmJhcg=="},"reg.example.com":{"username":"foo","password":"bar"}}}`),
+				},
+			},
+			hosts: []string{"index.docker.io", "reg.example.com"},
+		},
+		"dockercfg": {
+			sec: v1.Secret{
+				Type: v1.SecretTypeDockercfg,
+				Data: map[string][]byte{
+					v1.DockerConfigKey: []byte(`{"reg.example.com:5000":{"auth":"Zm9vOmJhcg=="}}`),
+				},
+			},
+			hosts: []string{"reg.example.com:5000"},
+		},
+		"toast": {
+			sec: v1.Secret{
+				Type: v1.SecretTypeDockerConfigJson,
+				Data: map[string][]byte{v1.DockerConfigJsonKey: []byte(`{`)},
+			},
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			aa, err := dao.PullSecretAuths(&u.sec)
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			hh := make([]string, 0, len(aa))
+			for h := range aa {
+				hh = append(hh, h)
+			}
+			assert.ElementsMatch(t, u.hosts, hh)
+		})
+	}
+}
@@ -0,0 +1,290 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/model1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// quickPatchSkips lists read only or server managed field paths.
+var quickPatchSkips = []string{
+	"status",
+	"metadata.managedFields",
+	"metadata.uid",
+	"metadata.resourceVersion",
+	"metadata.generation",
+	"metadata.creationTimestamp",
+}
+
+// FieldPaths lists the dotted paths of all editable leaf fields of an object.
+// Keys holding a dot or a bracket are quoted, ie `metadata.labels["app.kubernetes.io/name"]`.
+func FieldPaths(o map[string]any) []string {
+	var pp []string
+	walkFields("", o, func(p string, _ any) {
+		pp = append(pp, p)
+	})
+	sort.Strings(pp)
+
+	return pp
+}
+
+func walkFields(prefix string, v any, leaf func(string, any)) {
+	for _, s := range quickPatchSkips {
+		if prefix == s {
+			return
+		}
+	}
+	switch t := v.(type) {
+	case map[string]any:
+		if len(t) == 0 && prefix != "" {
+			leaf(prefix, t)
+		}
+		for k, vv := range t {
+			walkFields(joinField(prefix, k), vv, leaf)
+		}
+	case []any:
+		if len(t) == 0 {
+			leaf(prefix, t)
+		}
+		for i, vv := range t {
+			walkFields(fmt.Sprintf("%s[%d]", prefix, i), vv, leaf)
+		}
+	default:
+		leaf(prefix, t)
+	}
+}
+
+func joinField(prefix, k string) string {
+	if strings.ContainsAny(k, `.[]"`) {
+		return fmt.Sprintf("%s[%q]", prefix, k)
+	}
+	if prefix == "" {
+		return k
+	}
+
+	return prefix + "." + k
+}
+
+// ParseFieldPath splits a dotted field path into its segments.
+// Array indices and quoted keys go in brackets, ie `spec.containers[0].image`.
+func ParseFieldPath(path string) ([]string, error) {
+	var (
+		ss  []string
+		seg strings.Builder
+	)
+	flush := func() {
+		if seg.Len() > 0 {
+			ss = append(ss, seg.String())
+			seg.Reset()
+		}
+	}
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; c {
+		case '.':
+			flush()
+		case '[':
+			flush()
+			end := strings.IndexByte(path[i:], ']')
+			if i+1 < len(path) && path[i+1] == '"' {
+				q, err := strconv.QuotedPrefix(path[i+1:])
+				if err != nil {
+					return nil, fmt.Errorf("invalid quoted key in %q", path)
+				}
+				k, _ := strconv.Unquote(q)
+				end = 1 + len(q)
+				if i+end >= len(path) || path[i+end] != ']' {
+					return nil, fmt.Errorf("missing ] in %q", path)
+				}
+				ss = append(ss, k)
+				i += end
+				continue
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("missing ] in %q", path)
+			}
+			idx := path[i+1 : i+end]
+			if _, err := strconv.Atoi(idx); err != nil {
+				return nil, fmt.Errorf("invalid index %q in %q", idx, path)
+			}
+			ss = append(ss, idx)
+			i += end
+		default:
+			seg.WriteByte(c)
+		}
+	}
+	flush()
+	if len(ss) == 0 {
+		return nil, errors.New("no field path given")
+	}
+
+	return ss, nil
+}
+
+// FieldValue returns the value at the given field path segments.
+func FieldValue(o map[string]any, ss []string) (any, bool) {
+	var v any = o
+	for _, s := range ss {
+		switch t := v.(type) {
+		case map[string]any:
+			vv, ok := t[s]
+			if !ok {
+				return nil, false
+			}
+			v = vv
+		case []any:
+			i, err := strconv.Atoi(s)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil, false
+			}
+			v = t[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return v, true
+}
+
+// FieldString renders a field value for editing.
+func FieldString(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case map[string]any, []any:
+		bb, _ := json.Marshal(t)
+		return string(bb)
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+// QuickPatch returns a JSON patch setting a single field. The new value keeps
+// the type of the current one. New fields are parsed as JSON and fall back to
+// strings. Existing fields are tested first to guard against concurrent edits.
+func QuickPatch(o map[string]any, field, raw string) ([]byte, error) {
+	ss, err := ParseFieldPath(field)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range quickPatchSkips {
+		if field == s || strings.HasPrefix(field, s+".") || strings.HasPrefix(field, s+"[") {
+			return nil, fmt.Errorf("field %q is not editable", field)
+		}
+	}
+	ptr := jsonPointer(ss)
+	cur, ok := FieldValue(o, ss)
+	if !ok {
+		if _, ok := FieldValue(o, ss[:len(ss)-1]); !ok && len(ss) > 1 {
+			return nil, fmt.Errorf("parent of field %q not found", field)
+		}
+		return json.Marshal([]map[string]any{
+			{"op": "add", "path": ptr, "value": parseFieldValue(raw)},
+		})
+	}
+	val, err := typedFieldValue(cur, raw)
+	if err != nil {
+		return nil, fmt.Errorf("field %q: %w", field, err)
+	}
+
+	return json.Marshal([]map[string]any{
+		{"op": "test", "path": ptr, "value": cur},
+		{"op": "replace", "path": ptr, "value": val},
+	})
+}
+
+// ApplyQuickPatch sets a single field on a resource.
+func ApplyQuickPatch(ctx context.Context, c client.Connection, gvr *client.GVR, path, field, raw string) error {
+	if ctxName, _ := model1.SplitMultiContextID(path); ctxName != "" {
+		return errors.New("quick patch is only available on the active context")
+	}
+	dyn, err := c.DynDial()
+	if err != nil {
+		return err
+	}
+	ns, n := client.Namespaced(path)
+	res := dyn.Resource(gvr.GVR()).Namespace(ns)
+	o, err := res.Get(ctx, n, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	patch, err := QuickPatch(o.Object, field, raw)
+	if err != nil {
+		return err
+	}
+	_, err = res.Patch(ctx, n, types.JSONPatchType, patch, metav1.PatchOptions{})
+
+	return err
+}
+
+// QuickPatchObject returns a resource current state to seed the field suggestions.
+func QuickPatchObject(f Factory, gvr *client.GVR, path string) (*unstructured.Unstructured, error) {
+	o, err := f.Get(gvr, path, false, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	u, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("expecting unstructured but got %T", o)
+	}
+
+	return u, nil
+}
+
+func typedFieldValue(cur any, raw string) (any, error) {
+	switch cur.(type) {
+	case string:
+		return raw, nil
+	case bool:
+		return strconv.ParseBool(raw)
+	case int64, int32, int:
+		return strconv.ParseInt(raw, 10, 64)
+	case float64, float32:
+		return strconv.ParseFloat(raw, 64)
+	case nil:
+		return parseFieldValue(raw), nil
+	case map[string]any, []any:
+		var v any
+		if err := json.Unmarshal([]byte(raw), &v); err != nil {
+			return nil, fmt.Errorf("expecting json: %w", err)
+		}
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unsupported field type %T", cur)
+	}
+}
+
+func parseFieldValue(raw string) any {
+	var v any
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		return raw
+	}
+
+	return v
+}
+
+// jsonPointer encodes path segments as a RFC 6901 pointer.
+func jsonPointer(ss []string) string {
+	var b strings.Builder
+	r := strings.NewReplacer("~", "~0", "/", "~1")
+	for _, s := range ss {
+		b.WriteString("/" + r.Replace(s))
+	}
+
+	return b.String()
+}
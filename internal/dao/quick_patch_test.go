@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldPaths(t *testing.T) {
+	o := map[string]any{
+		"metadata": map[string]any{
+			"name":            "d1",
+			"uid":             "u1",
+			"resourceVersion": "12",
+			"labels":          map[string]any{"app.kubernetes.io/name": "fred"},
+		},
+		"spec": map[string]any{
+			"replicas": int64(2),
+			"containers": []any{
+				map[string]any{"name": "c1", "image": "nginx:1.0"},
+			},
+		},
+		"status": map[string]any{"readyReplicas": int64(2)},
+	}
+
+	assert.Equal(t, []string{
+		`metadata.labels["app.kubernetes.io/name"]`,
+		"metadata.name",
+		"spec.containers[0].image",
+		"spec.containers[0].name",
+		"spec.replicas",
+	}, dao.FieldPaths(o))
+}
+
+func TestParseFieldPath(t *testing.T) {
+	uu := map[string]struct {
+		path string
+		ss   []string
+		err  bool
+	}{
+		"dotted": {
+			path: "spec.replicas",
+			ss:   []string{"spec", "replicas"},
+		},
+		"index": {
+			path: "spec.containers[1].image",
+			ss:   []string{"spec", "containers", "1", "image"},
+		},
+		"quoted": {
+			path: `metadata.labels["app.kubernetes.io/name"]`,
+			ss:   []string{"metadata", "labels", "app.kubernetes.io/name"},
+		},
+		"empty": {
+			err: true,
+		},
+		"bad-index": {
+			path: "spec.containers[x]",
+			err:  true,
+		},
+		"unclosed": {
+			path: "spec.containers[0",
+			err:  true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ss, err := dao.ParseFieldPath(u.path)
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.ss, ss)
+		})
+	}
+}
+
+func TestQuickPatch(t *testing.T) {
+	o := map[string]any{
+		"metadata": map[string]any{
+			"name":   "d1",
+			"labels": map[string]any{"app.kubernetes.io/name": "fred"},
+		},
+		"spec": map[string]any{
+			"replicas": int64(2),
+			"paused":   false,
+			"containers": []any{
+				map[string]any{"name": "c1", "image": "nginx:1.0"},
+			},
+		},
+		"status": map[string]any{"readyReplicas": int64(2)},
+	}
+
+	uu := map[string]struct {
+		field, value string
+		e            string
+		err          bool
+	}{
+		"int": {
+			field: "spec.replicas",
+			value: "3",
+			e:     `[{"op":"test","path":"/spec/replicas","value":2},{"op":"replace","path":"/spec/replicas","value":3}]`,
+		},
+		"bool": {
+			field: "spec.paused",
+			value: "true",
+			e:     `[{"op":"test","path":"/spec/paused","value":false},{"op":"replace","path":"/spec/paused","value":true}]`,
+		},
+		"string": {
+			field: "spec.containers[0].image",
+			value: "nginx:1.1",
+			e:     `[{"op":"test","path":"/spec/containers/0/image","value":"nginx:1.0"},{"op":"replace","path":"/spec/containers/0/image","value":"nginx:1.1"}]`,
+		},
+		"quoted": {
+			field: `metadata.labels["app.kubernetes.io/name"]`,
+			value: "blee",
+			e:     `[{"op":"test","path":"/metadata/labels/app.kubernetes.io~1name","value":"fred"},{"op":"replace","path":"/metadata/labels/app.kubernetes.io~1name","value":"blee"}]`,
+		},
+		"add": {
+			field: "spec.minReadySeconds",
+			value: "10",
+			e:     `[{"op":"add","path":"/spec/minReadySeconds","value":10}]`,
+		},
+		"add-string": {
+			field: "metadata.labels.tier",
+			value: "web",
+			e:     `[{"op":"add","path":"/metadata/labels/tier","value":"web"}]`,
+		},
+		"no-parent": {
+			field: "spec.strategy.type",
+			value: "Recreate",
+			err:   true,
+		},
+		"bad-int": {
+			field: "spec.replicas",
+			value: "many",
+			err:   true,
+		},
+		"status": {
+			field: "status.readyReplicas",
+			value: "3",
+			err:   true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			bb, err := dao.QuickPatch(o, u.field, u.value)
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, u.e, string(bb))
+		})
+	}
+}
@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	rancherAPITimeout     = 10 * time.Second
+	rancherAPIMaxPages    = 100
+	rancherNS             = "cattle-system"
+	rancherAuditContainer = "rancher-audit-log"
+)
+
+// RancherAPI talks to the Rancher (Norman) API for data not exposed as CRDs.
+type RancherAPI struct {
+	url, token string
+	http       *http.Client
+}
+
+// RancherNodeDriver represents a Rancher node driver.
+type RancherNodeDriver struct {
+	Name    string `json:"name"`
+	State   string `json:"state"`
+	Active  bool   `json:"active"`
+	Builtin bool   `json:"builtin"`
+	URL     string `json:"url"`
+}
+
+// RancherToken represents a Rancher API token or login session.
+type RancherToken struct {
+	Name         string `json:"name"`
+	UserID       string `json:"userId"`
+	AuthProvider string `json:"authProvider"`
+	Description  string `json:"description"`
+	Created      string `json:"created"`
+	ExpiresAt    string `json:"expiresAt"`
+	LastUsedAt   string `json:"lastUsedAt"`
+	IsDerived    bool   `json:"isDerived"`
+	Expired      bool   `json:"expired"`
+	ClusterID    string `json:"clusterId"`
+}
+
+// NewRancherAPI returns a new Rancher API client.
+func NewRancherAPI(url, token string, insecure bool) *RancherAPI {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	if insecure {
+		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+	}
+
+	return &RancherAPI{
+		url:   strings.TrimSuffix(url, "/"),
+		token: token,
+		http:  &http.Client{Timeout: rancherAPITimeout, Transport: tr},
+	}
+}
+
+// NodeDrivers lists the registered node drivers.
+func (r *RancherAPI) NodeDrivers(ctx context.Context) ([]RancherNodeDriver, error) {
+	var dd []RancherNodeDriver
+	if err := r.list(ctx, "/v3/nodedrivers", &dd); err != nil {
+		return nil, err
+	}
+	sort.Slice(dd, func(i, j int) bool { return dd[i].Name < dd[j].Name })
+
+	return dd, nil
+}
+
+// Sessions lists active user login sessions, i.e. non derived, non expired tokens.
+func (r *RancherAPI) Sessions(ctx context.Context) ([]RancherToken, error) {
+	var tt []RancherToken
+	if err := r.list(ctx, "/v3/tokens", &tt); err != nil {
+		return nil, err
+	}
+	out := make([]RancherToken, 0, len(tt))
+	for _, t := range tt {
+		if t.IsDerived || t.Expired {
+			continue
+		}
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Created > out[j].Created })
+
+	return out, nil
+}
+
+// list fetches a Norman collection, following its pagination links so large
+// collections are not cut short at the server page size.
+func (r *RancherAPI) list(ctx context.Context, path string, data any) error {
+	var all []json.RawMessage
+	next := r.url + path
+	for range rancherAPIMaxPages {
+		page, err := r.page(ctx, path, next)
+		if err != nil {
+			return err
+		}
+		all = append(all, page.Data...)
+		if page.Pagination.Next == "" {
+			bb, err := json.Marshal(all)
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(bb, data)
+		}
+		if !strings.HasPrefix(page.Pagination.Next, r.url+"/") {
+			return fmt.Errorf("rancher api %s: next page %q is not served by %s", path, page.Pagination.Next, r.url)
+		}
+		next = page.Pagination.Next
+	}
+
+	return fmt.Errorf("rancher api %s: more than %d pages", path, rancherAPIMaxPages)
+}
+
+// rancherPage represents a Norman collection page.
+type rancherPage struct {
+	Data       []json.RawMessage `json:"data"`
+	Pagination struct {
+		Next string `json:"next"`
+	} `json:"pagination"`
+}
+
+func (r *RancherAPI) page(ctx context.Context, path, url string) (rancherPage, error) {
+	var page rancherPage
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return page, err
+	}
+	req.Header.Set("Authorization", "Bearer "+r.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.http.Do(req)
+	if err != nil {
+		return page, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bb, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return page, fmt.Errorf("rancher api %s: %s %s", path, resp.Status, strings.TrimSpace(string(bb)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return page, fmt.Errorf("rancher api %s: %w", path, err)
+	}
+
+	return page, nil
+}
+
+// RancherAuditLog tails the API audit log sidecar of the Rancher server pods.
+func RancherAuditLog(ctx context.Context, c client.Connection, tail int64) (string, error) {
+	dial, err := c.Dial()
+	if err != nil {
+		return "", err
+	}
+	pods, err := dial.CoreV1().Pods(rancherNS).List(ctx, metav1.ListOptions{LabelSelector: "app=rancher"})
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for i := range pods.Items {
+		po := &pods.Items[i]
+		if !hasContainer(po, rancherAuditContainer) {
+			continue
+		}
+		req := dial.CoreV1().Pods(rancherNS).GetLogs(po.Name, &v1.PodLogOptions{
+			Container: rancherAuditContainer,
+			TailLines: &tail,
+		})
+		bb, err := req.DoRaw(ctx)
+		if err != nil {
+			fmt.Fprintf(&b, "--- %s ---\n  (error) %s\n", po.Name, err)
+			continue
+		}
+		fmt.Fprintf(&b, "--- %s ---\n%s\n", po.Name, bb)
+	}
+	if b.Len() == 0 {
+		return "", fmt.Errorf("no %s container found in %s. Enable auditLog.level in the rancher chart", rancherAuditContainer, rancherNS)
+	}
+
+	return b.String(), nil
+}
+
+func hasContainer(po *v1.Pod, name string) bool {
+	for _, co := range po.Spec.Containers {
+		if co.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
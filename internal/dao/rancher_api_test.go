@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRancherAPISessions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer fred", r.Header.Get("Authorization"))
+		assert.Equal(t, "/v3/tokens", r.URL.Path)
+		_, _ = w.Write([]byte(`{"data":[
+			{"name":"token-1","userId":"u-1","created":"2025-01-01T00:00:00Z"},
+			{"name":"token-2","userId":"u-2","isDerived":true},
+			{"name":"token-3","userId":"u-3","expired":true},
+			{"name":"token-4","userId":"u-4","created":"2025-02-01T00:00:00Z"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	tt, err := dao.NewRancherAPI(srv.URL, "fred", false).Sessions(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, tt, 2)
+	assert.Equal(t, "token-4", tt[0].Name)
+	assert.Equal(t, "token-1", tt[1].Name)
+}
+
+func TestRancherAPIPaginates(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer fred", r.Header.Get("Authorization"))
+		if r.URL.Query().Get("marker") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"name":"amazonec2"}],"pagination":{"next":"` + srv.URL + `/v3/nodedrivers?marker=m1"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"name":"aliyun"}],"pagination":{}}`))
+	}))
+	defer srv.Close()
+
+	dd, err := dao.NewRancherAPI(srv.URL, "fred", false).NodeDrivers(context.Background())
+	require.NoError(t, err)
+	require.Len(t, dd, 2)
+	assert.Equal(t, "aliyun", dd[0].Name)
+	assert.Equal(t, "amazonec2", dd[1].Name)
+}
+
+func TestRancherAPIForeignNextPage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[],"pagination":{"next":"https://evil.example/v3/tokens?marker=m1"}}`))
+	}))
+	defer srv.Close()
+
+	_, err := dao.NewRancherAPI(srv.URL, "fred", false).Sessions(context.Background())
+	require.Error(t, err)
+}
+
+func TestRancherAPIFailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "nope", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	_, err := dao.NewRancherAPI(srv.URL, "fred", false).NodeDrivers(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	rke2RegistriesPath = "/etc/rancher/rke2/registries.yaml"
+	k3sRegistriesPath  = "/etc/rancher/k3s/registries.yaml"
+	registriesMarker   = "--- "
+	registriesMissing  = "none"
+)
+
+// RegistriesConfig represents an RKE2/K3s registries.yaml.
+type RegistriesConfig struct {
+	Mirrors map[string]RegistryMirror `json:"mirrors,omitempty"`
+	Configs map[string]RegistryConfig `json:"configs,omitempty"`
+}
+
+// RegistryMirror represents the mirror endpoints and rewrites of a registry.
+type RegistryMirror struct {
+	Endpoints []string          `json:"endpoint,omitempty"`
+	Rewrites  map[string]string `json:"rewrite,omitempty"`
+}
+
+// RegistryConfig represents a registry auth and TLS config. Credentials are
+// only tracked for presence.
+type RegistryConfig struct {
+	Auth map[string]any `json:"auth,omitempty"`
+	TLS  *struct {
+		CAFile             string `json:"ca_file,omitempty"`
+		CertFile           string `json:"cert_file,omitempty"`
+		InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+	} `json:"tls,omitempty"`
+}
+
+// NodeRegistries represents the registry config found on a node.
+type NodeRegistries struct {
+	Context, Node string
+	Kubelet       string
+	Path          string
+	Config        *RegistriesConfig
+	Err           error
+}
+
+// ID returns the node fully qualified name.
+func (n NodeRegistries) ID() string {
+	return n.Context + "/" + n.Node
+}
+
+// RegistriesHostPath returns the registries.yaml path of an RKE2/K3s node
+// given its kubelet version.
+func RegistriesHostPath(kubelet string) (string, error) {
+	switch {
+	case strings.Contains(kubelet, "rke2"):
+		return rke2RegistriesPath, nil
+	case strings.Contains(kubelet, "k3s"):
+		return k3sRegistriesPath, nil
+	default:
+		return "", fmt.Errorf("kubelet %s is not an RKE2/K3s build", kubelet)
+	}
+}
+
+// RegistriesProbeScript returns a shell script printing the first registries.yaml
+// found on a node prefixed by its path.
+func RegistriesProbeScript() string {
+	ss := make([]string, 0, 3)
+	for _, p := range []string{rke2RegistriesPath, k3sRegistriesPath} {
+		ss = append(ss, fmt.Sprintf("if [ -f %[1]s ]; then echo '%[2]s%[1]s'; cat %[1]s; exit 0; fi", p, registriesMarker))
+	}
+	ss = append(ss, fmt.Sprintf("echo '%s%s'", registriesMarker, registriesMissing))
+
+	return strings.Join(ss, "; ")
+}
+
+// ParseRegistriesProbe extracts the config path and registries config from a
+// probe output. A nil config means the node has no registries.yaml.
+func ParseRegistriesProbe(out string) (string, *RegistriesConfig, error) {
+	i := strings.Index(out, registriesMarker)
+	if i < 0 {
+		return "", nil, fmt.Errorf("unexpected probe output %q", out)
+	}
+	path, raw, _ := strings.Cut(out[i+len(registriesMarker):], "\n")
+	if path = strings.TrimSpace(path); path == registriesMissing {
+		return "", nil, nil
+	}
+	var cfg RegistriesConfig
+	if err := yaml.Unmarshal([]byte(raw), &cfg); err != nil {
+		return path, nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return path, &cfg, nil
+}
+
+// RegistriesSettings flattens a registries config into sorted, comparable
+// settings. Credentials are redacted.
+func RegistriesSettings(cfg *RegistriesConfig) []string {
+	if cfg == nil {
+		return nil
+	}
+	ss := make([]string, 0, len(cfg.Mirrors)+len(cfg.Configs))
+	for reg, m := range cfg.Mirrors {
+		s := fmt.Sprintf("mirror %s -> %s", reg, strings.Join(m.Endpoints, ", "))
+		kk := make([]string, 0, len(m.Rewrites))
+		for k := range m.Rewrites {
+			kk = append(kk, k)
+		}
+		sort.Strings(kk)
+		for _, k := range kk {
+			s += fmt.Sprintf(" [rewrite %s => %s]", k, m.Rewrites[k])
+		}
+		ss = append(ss, s)
+	}
+	for reg, c := range cfg.Configs {
+		s := "config " + reg
+		if len(c.Auth) > 0 {
+			s += " auth=<redacted>"
+		}
+		if t := c.TLS; t != nil {
+			if t.CAFile != "" {
+				s += " ca=" + t.CAFile
+			}
+			if t.CertFile != "" {
+				s += " cert=" + t.CertFile
+			}
+			if t.InsecureSkipVerify {
+				s += " insecure-skip-verify"
+			}
+		}
+		ss = append(ss, s)
+	}
+	sort.Strings(ss)
+
+	return ss
+}
+
+// RegistriesDrifts flags the settings that are not configured identically
+// on all probed nodes. Nodes that could not be probed are ignored.
+func RegistriesDrifts(nn []NodeRegistries) []string {
+	var (
+		probed int
+		owners = make(map[string][]string)
+	)
+	for _, n := range nn {
+		if n.Err != nil {
+			continue
+		}
+		probed++
+		if n.Config == nil {
+			owners["(no registries.yaml)"] = append(owners["(no registries.yaml)"], n.ID())
+		}
+		for _, s := range RegistriesSettings(n.Config) {
+			owners[s] = append(owners[s], n.ID())
+		}
+	}
+
+	var dd []string
+	for s, ids := range owners {
+		if len(ids) == probed {
+			continue
+		}
+		slices.Sort(ids)
+		dd = append(dd, fmt.Sprintf("%s only on %d/%d nodes: %s", s, len(ids), probed, strings.Join(ids, ", ")))
+	}
+	sort.Strings(dd)
+
+	return dd
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const registriesProbe = `--- /etc/rancher/rke2/registries.yaml
+mirrors:
+  docker.io:
+    endpoint:
+      - "https://harbor.local"
+    rewrite:
+      "^rancher/(.*)": "mirror/rancher/$1"
+configs:
+  harbor.local:
+    auth:
+      username: fred
+      password: s3cr3t
+    tls:
+      ca_file: /etc/ssl/harbor.pem
+`
+
+func TestParseRegistriesProbe(t *testing.T) {
+	uu := map[string]struct {
+		out  string
+		path string
+		e    []string
+		err  bool
+	}{
+		"rke2": {
+			out:  "Defaulted container\n" + registriesProbe,
+			path: "/etc/rancher/rke2/registries.yaml",
+			e: []string{
+				"config harbor.local auth=<redacted> ca=/etc/ssl/harbor.pem",
+				"mirror docker.io -> https://harbor.local [rewrite ^rancher/(.*) => mirror/rancher/$1]",
+			},
+		},
+		"missing": {
+			out: "--- none\n",
+		},
+		"garbled": {
+			out:  "--- /etc/rancher/k3s/registries.yaml\nmirrors: [",
+			path: "/etc/rancher/k3s/registries.yaml",
+			err:  true,
+		},
+		"toast": {
+			out: "sh: not found",
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			path, cfg, err := dao.ParseRegistriesProbe(u.out)
+			assert.Equal(t, u.path, path)
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.e, dao.RegistriesSettings(cfg))
+		})
+	}
+}
+
+func TestRegistriesDrifts(t *testing.T) {
+	_, cfg, err := dao.ParseRegistriesProbe(registriesProbe)
+	require.NoError(t, err)
+	_, other, err := dao.ParseRegistriesProbe("--- /etc/rancher/rke2/registries.yaml\nmirrors:\n  docker.io:\n    endpoint: [\"https://harbor.local\"]\n")
+	require.NoError(t, err)
+
+	uu := map[string]struct {
+		nn []dao.NodeRegistries
+		e  []string
+	}{
+		"consistent": {
+			nn: []dao.NodeRegistries{
+				{Context: "c1", Node: "n1", Config: cfg},
+				{Context: "c1", Node: "n2", Config: cfg},
+				{Context: "c1", Node: "n3", Err: errors.New("boom")},
+			},
+		},
+		"drift": {
+			nn: []dao.NodeRegistries{
+				{Context: "c1", Node: "n1", Config: cfg},
+				{Context: "c1", Node: "n2", Config: other},
+				{Context: "c1", Node: "n3"},
+			},
+			e: []string{
+				"(no registries.yaml) only on 1/3 nodes: c1/n3",
+				"config harbor.local auth=<redacted> ca=/etc/ssl/harbor.pem only on 1/3 nodes: c1/n1",
+				"mirror docker.io -> https://harbor.local [rewrite ^rancher/(.*) => mirror/rancher/$1] only on 1/3 nodes: c1/n1",
+				"mirror docker.io -> https://harbor.local only on 1/3 nodes: c1/n2",
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.RegistriesDrifts(u.nn))
+		})
+	}
+}
+
+func TestRegistriesHostPath(t *testing.T) {
+	uu := map[string]struct {
+		kubelet, path string
+		err           bool
+	}{
+		"rke2": {
+			kubelet: "v1.31.4+rke2r1",
+			path:    "/etc/rancher/rke2/registries.yaml",
+		},
+		"k3s": {
+			kubelet: "v1.31.4+k3s1",
+			path:    "/etc/rancher/k3s/registries.yaml",
+		},
+		"upstream": {
+			kubelet: "v1.31.4",
+			err:     true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			path, err := dao.RegistriesHostPath(u.kubelet)
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.path, path)
+		})
+	}
+}
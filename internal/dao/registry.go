@@ -272,6 +272,69 @@ func loadK9s(m ResourceMetas) {
 		Verbs:        []string{},
 		Categories:   []string{k9sCat},
 	}
+	m[client.OomGVR] = &metav1.APIResource{
+		Name:         "ooms",
+		Kind:         "OOMs",
+		SingularName: "oom",
+		Namespaced:   true,
+		ShortNames:   []string{"oom", "evictions"},
+		Verbs:        []string{},
+		Categories:   []string{k9sCat},
+	}
+	m[client.EtcdGVR] = &metav1.APIResource{
+		Name:         "etcdmembers",
+		Kind:         "EtcdMembers",
+		SingularName: "etcdmember",
+		ShortNames:   []string{"etcdm"},
+		Verbs:        []string{},
+		Categories:   []string{k9sCat},
+	}
+	m[client.RuleGVR] = &metav1.APIResource{
+		Name:         "rules",
+		Kind:         "Rules",
+		SingularName: "rule",
+		Verbs:        []string{},
+		Categories:   []string{k9sCat},
+	}
+	m[client.HygGVR] = &metav1.APIResource{
+		Name:         "hygiene",
+		Kind:         "Hygiene",
+		SingularName: "hygiene",
+		ShortNames:   []string{"hyg"},
+		Verbs:        []string{},
+		Categories:   []string{k9sCat},
+	}
+	m[client.CnyGVR] = &metav1.APIResource{
+		Name:         "comparisons",
+		Kind:         "Comparisons",
+		SingularName: "comparison",
+		Namespaced:   true,
+		Verbs:        []string{},
+		Categories:   []string{k9sCat},
+	}
+	m[client.WchGVR] = &metav1.APIResource{
+		Name:         "watchers",
+		Kind:         "Watchers",
+		SingularName: "watcher",
+		Verbs:        []string{},
+		Categories:   []string{k9sCat},
+	}
+	m[client.InvGVR] = &metav1.APIResource{
+		Name:         "inventory",
+		Kind:         "Inventory",
+		SingularName: "inventory",
+		ShortNames:   []string{"inv", "nodeinv"},
+		Verbs:        []string{},
+		Categories:   []string{k9sCat},
+	}
+	m[client.JanGVR] = &metav1.APIResource{
+		Name:         "cleanups",
+		Kind:         "Cleanups",
+		SingularName: "cleanup",
+		Namespaced:   true,
+		Verbs:        []string{},
+		Categories:   []string{k9sCat},
+	}
 }
 
 func loadHelm(m ResourceMetas) {
@@ -0,0 +1,283 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/redact"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// ReproLogTail tracks the number of log lines bundled per container.
+const ReproLogTail int64 = 500
+
+// reproManifestFile names the bundle manifest.
+const reproManifestFile = "manifest.yaml"
+
+// reproGVRs lists the namespaced resources exported in a repro bundle.
+var reproGVRs = []*client.GVR{
+	client.DpGVR,
+	client.StsGVR,
+	client.DsGVR,
+	client.RsGVR,
+	client.JobGVR,
+	client.CjGVR,
+	client.PodGVR,
+	client.SvcGVR,
+	client.IngGVR,
+	client.CmGVR,
+	client.SecGVR,
+	client.PvcGVR,
+	client.HpaGVR,
+	client.PdbGVR,
+	client.NpGVR,
+	client.SaGVR,
+}
+
+// ReproManifest describes the content of a repro bundle.
+type ReproManifest struct {
+	Context   string         `json:"context"`
+	Namespace string         `json:"namespace"`
+	Version   string         `json:"rk9sVersion,omitempty"`
+	Created   time.Time      `json:"created"`
+	Redacted  bool           `json:"redacted"`
+	Resources map[string]int `json:"resources,omitempty"`
+	Files     []string       `json:"files"`
+	Errors    []string       `json:"errors,omitempty"`
+}
+
+// ReproFile represents a file of a repro bundle.
+type ReproFile struct {
+	Name string
+	Data []byte
+}
+
+// ReproBundle holds the workloads, configs, events and recent logs of a
+// namespace, small enough to attach to an upstream issue.
+type ReproBundle struct {
+	Manifest ReproManifest
+	Files    []ReproFile
+}
+
+// NewReproBundle returns an empty bundle.
+func NewReproBundle(ctxName, ns, version string, now time.Time) *ReproBundle {
+	return &ReproBundle{
+		Manifest: ReproManifest{
+			Context:   ctxName,
+			Namespace: ns,
+			Version:   version,
+			Created:   now.UTC(),
+			Redacted:  true,
+			Resources: make(map[string]int),
+		},
+	}
+}
+
+// Add adds a file to the bundle.
+func (b *ReproBundle) Add(name, raw string) {
+	b.Files = append(b.Files, ReproFile{Name: name, Data: []byte(raw)})
+	b.Manifest.Files = append(b.Manifest.Files, name)
+}
+
+// AddError records a collection failure in the manifest.
+func (b *ReproBundle) AddError(err error) {
+	b.Manifest.Errors = append(b.Manifest.Errors, err.Error())
+}
+
+// FileName returns the bundle archive name.
+func (b *ReproBundle) FileName() string {
+	return fmt.Sprintf("repro-%s-%s-%d.tar.gz", b.Manifest.Context, b.Manifest.Namespace, b.Manifest.Created.Unix())
+}
+
+// Write writes the bundle as a gzipped tarball rooted in a directory named
+// after the namespace, the manifest first.
+func (b *ReproBundle) Write(w io.Writer) error {
+	m, err := yaml.Marshal(b.Manifest)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	root := "repro-" + b.Manifest.Namespace
+	ff := append([]ReproFile{{Name: reproManifestFile, Data: m}}, b.Files...)
+	for _, f := range ff {
+		hdr := tar.Header{
+			Name:    path.Join(root, f.Name),
+			Mode:    0600,
+			Size:    int64(len(f.Data)),
+			ModTime: b.Manifest.Created,
+		}
+		if err := tw.WriteHeader(&hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(f.Data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+// FetchReproBundle collects the resources, events and recent logs of a
+// namespace. Secrets and sensitive values are always redacted, using the
+// given redactor allowlists when set.
+func FetchReproBundle(ctx context.Context, c client.Connection, ns, version string, r *redact.Redactor, tail int64) (*ReproBundle, error) {
+	if r == nil {
+		r = redact.New(nil, nil)
+	}
+	dyn, err := c.DynDial()
+	if err != nil {
+		return nil, err
+	}
+	kc, err := c.Dial()
+	if err != nil {
+		return nil, err
+	}
+	b := NewReproBundle(c.ActiveContext(), ns, version, time.Now())
+
+	var pods []*v1.Pod
+	for _, gvr := range reproGVRs {
+		ll, err := dyn.Resource(gvr.GVR()).Namespace(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			b.AddError(fmt.Errorf("unable to list %s: %w", gvr.R(), err))
+			continue
+		}
+		if len(ll.Items) == 0 {
+			continue
+		}
+		raw, err := reproYAML(ll.Items)
+		if err != nil {
+			b.AddError(fmt.Errorf("unable to encode %s: %w", gvr.R(), err))
+			continue
+		}
+		b.Manifest.Resources[gvr.R()] = len(ll.Items)
+		b.Add(path.Join("resources", gvr.R()+".yaml"), r.YAML(raw))
+		if gvr == client.PodGVR {
+			oo := make([]runtime.Object, 0, len(ll.Items))
+			for i := range ll.Items {
+				oo = append(oo, &ll.Items[i])
+			}
+			pods = toPods(oo)
+		}
+	}
+
+	ee, err := kc.CoreV1().Events(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		b.AddError(fmt.Errorf("unable to list events: %w", err))
+	} else {
+		b.Manifest.Resources["events"] = len(ee.Items)
+		b.Add("events.txt", reproEvents(ee.Items))
+	}
+
+	for _, po := range pods {
+		for _, co := range po.Spec.Containers {
+			for _, previous := range []bool{false, true} {
+				if previous && restartCount(po, co.Name) == 0 {
+					continue
+				}
+				bb, err := kc.CoreV1().Pods(ns).GetLogs(po.Name, &v1.PodLogOptions{
+					Container: co.Name,
+					TailLines: &tail,
+					Previous:  previous,
+				}).DoRaw(ctx)
+				if err != nil {
+					b.AddError(fmt.Errorf("unable to fetch logs %s/%s: %w", po.Name, co.Name, err))
+					continue
+				}
+				b.Add(reproLogFile(po.Name, co.Name, previous), r.Text(string(bb)))
+			}
+		}
+	}
+
+	return b, nil
+}
+
+// reproLogFile returns the bundle path of container logs.
+func reproLogFile(po, co string, previous bool) string {
+	n := co + ".log"
+	if previous {
+		n = co + ".previous.log"
+	}
+
+	return path.Join("logs", po, n)
+}
+
+// reproYAML encodes resources as a multi document YAML without the
+// server side noise.
+func reproYAML(uu []unstructured.Unstructured) (string, error) {
+	sort.Slice(uu, func(i, j int) bool {
+		return uu[i].GetName() < uu[j].GetName()
+	})
+	var b strings.Builder
+	for i := range uu {
+		u := uu[i].DeepCopy()
+		u.SetManagedFields(nil)
+		raw, err := yaml.Marshal(u.Object)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString("---\n")
+		b.Write(raw)
+	}
+
+	return b.String(), nil
+}
+
+// reproEvents renders events oldest first.
+func reproEvents(ee []v1.Event) string {
+	sort.SliceStable(ee, func(i, j int) bool {
+		return eventTime(&ee[i]).Before(eventTime(&ee[j]))
+	})
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LAST SEEN\tTYPE\tREASON\tOBJECT\tCOUNT\tMESSAGE")
+	for i := range ee {
+		e := &ee[i]
+		at := client.NA
+		if t := eventTime(e); !t.IsZero() {
+			at = t.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s/%s\t%d\t%s\n",
+			at,
+			e.Type,
+			e.Reason,
+			strings.ToLower(e.InvolvedObject.Kind),
+			e.InvolvedObject.Name,
+			e.Count,
+			strings.TrimSpace(e.Message),
+		)
+	}
+	_ = w.Flush()
+
+	return b.String()
+}
+
+// restartCount returns the restart count of a pod container.
+func restartCount(po *v1.Pod, co string) int32 {
+	for _, cs := range po.Status.ContainerStatuses {
+		if cs.Name == co {
+			return cs.RestartCount
+		}
+	}
+
+	return 0
+}
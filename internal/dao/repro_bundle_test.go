@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestReproBundleWrite(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	b := dao.NewReproBundle("prod", "shop", "v1.0.0", now)
+	b.Manifest.Resources["deployments"] = 1
+	b.Add("resources/deployments.yaml", "kind: Deployment\n")
+	b.Add("logs/checkout-1/app.log", "boom\n")
+	b.AddError(errors.New("unable to list ingresses: forbidden"))
+
+	assert.Equal(t, "repro-prod-shop-1792152000.tar.gz", b.FileName())
+
+	var buff bytes.Buffer
+	require.NoError(t, b.Write(&buff))
+
+	gz, err := gzip.NewReader(&buff)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	files := make(map[string]string)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		raw, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		names, files[hdr.Name] = append(names, hdr.Name), string(raw)
+	}
+
+	assert.Equal(t, []string{
+		"repro-shop/manifest.yaml",
+		"repro-shop/resources/deployments.yaml",
+		"repro-shop/logs/checkout-1/app.log",
+	}, names)
+	assert.Equal(t, "boom\n", files["repro-shop/logs/checkout-1/app.log"])
+
+	var m dao.ReproManifest
+	require.NoError(t, yaml.Unmarshal([]byte(files["repro-shop/manifest.yaml"]), &m))
+	assert.Equal(t, "prod", m.Context)
+	assert.Equal(t, "shop", m.Namespace)
+	assert.Equal(t, "v1.0.0", m.Version)
+	assert.True(t, m.Redacted)
+	assert.Equal(t, now, m.Created)
+	assert.Equal(t, map[string]int{"deployments": 1}, m.Resources)
+	assert.Equal(t, []string{"resources/deployments.yaml", "logs/checkout-1/app.log"}, m.Files)
+	assert.Equal(t, []string{"unable to list ingresses: forbidden"}, m.Errors)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/derailed/k9s/internal"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -23,14 +24,27 @@ type Resource struct {
 	Generic
 }
 
-// List returns a collection of resources.
+// List returns a collection of resources. Field selectors on fields the
+// informer cache can check, ie `spec.nodeName` on pods, are filtered from
+// the cache, others are listed server side.
 func (r *Resource) List(ctx context.Context, ns string) ([]runtime.Object, error) {
+	var fsel fields.Selector
+	if sel, _ := ctx.Value(internal.KeyFields).(string); sel != "" {
+		var ok bool
+		if fsel, ok = informerSelector(r.gvr, sel); !ok {
+			return r.Generic.List(ctx, ns)
+		}
+	}
 	lsel := labels.Everything()
 	if sel, ok := ctx.Value(internal.KeyLabels).(labels.Selector); ok {
 		lsel = sel
 	}
+	oo, err := r.getFactory().List(r.gvr, ns, false, lsel)
+	if err != nil || fsel == nil || fsel.Empty() {
+		return oo, err
+	}
 
-	return r.getFactory().List(r.gvr, ns, false, lsel)
+	return filterFields(oo, fsel), nil
 }
 
 // Get returns a resource instance if found, else an error.
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// TimelineEntry represents a dated fact contributing to a pod restart history.
+type TimelineEntry struct {
+	At      time.Time
+	Source  string
+	Message string
+	Warn    bool
+}
+
+// FetchRestartTimeline gathers the events and node conditions related to a pod
+// and assembles its restart timeline.
+func FetchRestartTimeline(ctx context.Context, c client.Connection, po *v1.Pod) ([]TimelineEntry, error) {
+	dial, err := c.Dial()
+	if err != nil {
+		return nil, err
+	}
+	sel := fields.Set{
+		"involvedObject.kind": "Pod",
+		"involvedObject.name": po.Name,
+	}.AsSelector().String()
+	ee, err := dial.CoreV1().Events(po.Namespace).List(ctx, metav1.ListOptions{FieldSelector: sel})
+	if err != nil {
+		return nil, err
+	}
+
+	var no *v1.Node
+	if po.Spec.NodeName != "" {
+		// Node conditions are best effort since node access is often restricted.
+		if n, err := dial.CoreV1().Nodes().Get(ctx, po.Spec.NodeName, metav1.GetOptions{}); err == nil {
+			no = n
+		}
+	}
+
+	return RestartTimeline(po, ee.Items, no), nil
+}
+
+// RestartTimeline assembles container terminations, pod events and node
+// condition transitions into a chronological list. Only the last termination of
+// each container is retained by the kubelet, earlier restarts only surface via events.
+func RestartTimeline(po *v1.Pod, ee []v1.Event, no *v1.Node) []TimelineEntry {
+	var tt []TimelineEntry
+	if !po.CreationTimestamp.IsZero() {
+		tt = append(tt, TimelineEntry{At: po.CreationTimestamp.Time, Source: "pod", Message: "created"})
+	}
+	for _, cs := range slices.Concat(po.Status.InitContainerStatuses, po.Status.ContainerStatuses) {
+		tt = append(tt, containerTimeline(&cs)...)
+	}
+
+	for i := range ee {
+		e := &ee[i]
+		at := eventTime(e)
+		if at.IsZero() {
+			continue
+		}
+		msg := e.Reason + ": " + e.Message
+		if e.Count > 1 {
+			msg += fmt.Sprintf(" (x%d)", e.Count)
+		}
+		tt = append(tt, TimelineEntry{
+			At:      at,
+			Source:  "event",
+			Message: msg,
+			Warn:    e.Type == v1.EventTypeWarning,
+		})
+	}
+
+	if no != nil {
+		for _, c := range no.Status.Conditions {
+			if c.LastTransitionTime.Before(&po.CreationTimestamp) {
+				continue
+			}
+			tt = append(tt, TimelineEntry{
+				At:      c.LastTransitionTime.Time,
+				Source:  "node/" + no.Name,
+				Message: fmt.Sprintf("%s=%s %s", c.Type, c.Status, c.Reason),
+				Warn:    isNodeConditionBad(c),
+			})
+		}
+	}
+
+	slices.SortStableFunc(tt, func(a, b TimelineEntry) int {
+		return a.At.Compare(b.At)
+	})
+
+	return tt
+}
+
+func containerTimeline(cs *v1.ContainerStatus) []TimelineEntry {
+	src := "container/" + cs.Name
+	var tt []TimelineEntry
+	if t := cs.LastTerminationState.Terminated; t != nil {
+		if !t.StartedAt.IsZero() {
+			tt = append(tt, TimelineEntry{At: t.StartedAt.Time, Source: src, Message: "started"})
+		}
+		tt = append(tt, TimelineEntry{
+			At:      t.FinishedAt.Time,
+			Source:  src,
+			Message: terminationMessage(t, cs.RestartCount),
+			Warn:    t.ExitCode != 0 || t.Reason == "OOMKilled",
+		})
+	}
+	switch {
+	case cs.State.Running != nil:
+		msg := "running"
+		if cs.LastTerminationState.Terminated != nil {
+			msg = "restarted, running"
+		}
+		tt = append(tt, TimelineEntry{At: cs.State.Running.StartedAt.Time, Source: src, Message: msg})
+	case cs.State.Terminated != nil:
+		t := cs.State.Terminated
+		tt = append(tt, TimelineEntry{
+			At:      t.FinishedAt.Time,
+			Source:  src,
+			Message: terminationMessage(t, cs.RestartCount),
+			Warn:    t.ExitCode != 0,
+		})
+	}
+
+	return tt
+}
+
+func terminationMessage(t *v1.ContainerStateTerminated, restarts int32) string {
+	msg := "terminated"
+	if t.Reason != "" {
+		msg += " " + t.Reason
+	}
+	msg += fmt.Sprintf(" (exit %d", t.ExitCode)
+	if sig := t.Signal; sig != 0 {
+		msg += fmt.Sprintf(", signal %d", sig)
+	}
+	msg += ")"
+	if !t.StartedAt.IsZero() && !t.FinishedAt.IsZero() {
+		msg += " after " + t.FinishedAt.Sub(t.StartedAt.Time).Round(time.Second).String()
+	}
+	if restarts > 0 {
+		msg += fmt.Sprintf(", %d restarts so far", restarts)
+	}
+	if t.Message != "" {
+		msg += " - " + t.Message
+	}
+
+	return msg
+}
+
+func eventTime(e *v1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.FirstTimestamp.Time
+	}
+}
+
+func isNodeConditionBad(c v1.NodeCondition) bool {
+	if c.Type == v1.NodeReady {
+		return c.Status != v1.ConditionTrue
+	}
+
+	return c.Status == v1.ConditionTrue
+}
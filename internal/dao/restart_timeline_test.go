@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRestartTimeline(t *testing.T) {
+	t0 := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	at := func(h int) metav1.Time {
+		return metav1.NewTime(t0.Add(time.Duration(h) * time.Hour))
+	}
+
+	po := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: "ns1", CreationTimestamp: at(0)},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:         "c1",
+					RestartCount: 1,
+					LastTerminationState: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							Reason:     "OOMKilled",
+							ExitCode:   137,
+							StartedAt:  at(1),
+							FinishedAt: at(3),
+						},
+					},
+					State: v1.ContainerState{
+						Running: &v1.ContainerStateRunning{StartedAt: at(4)},
+					},
+				},
+			},
+		},
+	}
+
+	uu := map[string]struct {
+		ee []v1.Event
+		no *v1.Node
+		e  []dao.TimelineEntry
+	}{
+		"container-only": {
+			e: []dao.TimelineEntry{
+				{At: at(0).Time, Source: "pod", Message: "created"},
+				{At: at(1).Time, Source: "container/c1", Message: "started"},
+				{At: at(3).Time, Source: "container/c1", Message: "terminated OOMKilled (exit 137) after 2h0m0s, 1 restarts so far", Warn: true},
+				{At: at(4).Time, Source: "container/c1", Message: "restarted, running"},
+			},
+		},
+		"events-and-node": {
+			ee: []v1.Event{
+				{Type: v1.EventTypeWarning, Reason: "BackOff", Message: "Back-off restarting", Count: 2, LastTimestamp: at(2)},
+			},
+			no: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "n1"},
+				Status: v1.NodeStatus{
+					Conditions: []v1.NodeCondition{
+						{Type: v1.NodeReady, Status: v1.ConditionTrue, Reason: "KubeletReady", LastTransitionTime: metav1.NewTime(t0.Add(-time.Hour))},
+						{Type: v1.NodeMemoryPressure, Status: v1.ConditionTrue, Reason: "KubeletHasInsufficientMemory", LastTransitionTime: at(3)},
+					},
+				},
+			},
+			e: []dao.TimelineEntry{
+				{At: at(0).Time, Source: "pod", Message: "created"},
+				{At: at(1).Time, Source: "container/c1", Message: "started"},
+				{At: at(2).Time, Source: "event", Message: "BackOff: Back-off restarting (x2)", Warn: true},
+				{At: at(3).Time, Source: "container/c1", Message: "terminated OOMKilled (exit 137) after 2h0m0s, 1 restarts so far", Warn: true},
+				{At: at(3).Time, Source: "node/n1", Message: "MemoryPressure=True KubeletHasInsufficientMemory", Warn: true},
+				{At: at(4).Time, Source: "container/c1", Message: "restarted, running"},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.RestartTimeline(&po, u.ee, u.no))
+		})
+	}
+}
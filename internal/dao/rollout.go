@@ -0,0 +1,311 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/polymorphichelpers"
+)
+
+const (
+	// RolloutComplete tracks a rollout with all replicas updated and available.
+	RolloutComplete = "Complete"
+
+	// RolloutProgressing tracks a rollout in flight.
+	RolloutProgressing = "Progressing"
+
+	// RolloutPaused tracks a paused rollout.
+	RolloutPaused = "Paused"
+
+	// RolloutFailed tracks a rollout past its progress deadline.
+	RolloutFailed = "Failed"
+)
+
+// pendingReasons lists container waiting reasons of pods starting normally.
+var pendingReasons = []string{"ContainerCreating", "PodInitializing"}
+
+// RolloutPod represents a pod of a rollout replicaset.
+type RolloutPod struct {
+	Name      string
+	Status    string
+	Ready     string
+	Restarts  int32
+	Failing   bool
+	LastEvent string
+}
+
+// RolloutRS represents a replicaset taking part in a rollout.
+type RolloutRS struct {
+	Name     string
+	Revision int
+	New      bool
+	Desired  int32
+	Ready    int32
+	Pods     []RolloutPod
+}
+
+// RolloutStatus represents the progress of a deployment rollout.
+type RolloutStatus struct {
+	Path           string
+	State          string
+	Revision       int
+	Message        string
+	Desired        int32
+	Updated        int32
+	Ready          int32
+	Available      int32
+	Unavailable    int32
+	Surge          int32
+	MaxSurge       int32
+	MaxUnavailable int32
+	ReplicaSets    []RolloutRS
+}
+
+// FetchRollout assembles the rollout status of a deployment from the
+// informer cache.
+func FetchRollout(f Factory, fqn string) (*RolloutStatus, error) {
+	var d Deployment
+	d.Init(f, client.DpGVR)
+	dp, err := d.GetInstance(fqn)
+	if err != nil {
+		return nil, err
+	}
+	rr, err := f.List(client.RsGVR, dp.Namespace, true, labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list replicasets: %w", err)
+	}
+	pp, err := f.List(client.PodGVR, dp.Namespace, true, labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pods: %w", err)
+	}
+	ee, err := f.List(coreEvGVR, dp.Namespace, true, labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list events: %w", err)
+	}
+
+	return Rollout(dp, toReplicaSets(rr), toPods(pp), toEvents(ee)), nil
+}
+
+// Rollout computes the rollout status of a deployment: surge and
+// unavailable counts, the new and old replicasets still holding pods and
+// the last event of failing pods.
+func Rollout(dp *appsv1.Deployment, rr []*appsv1.ReplicaSet, pp []*v1.Pod, ee []*v1.Event) *RolloutStatus {
+	desired := int32(1)
+	if dp.Spec.Replicas != nil {
+		desired = *dp.Spec.Replicas
+	}
+	st := RolloutStatus{
+		Path:        client.FQN(dp.Namespace, dp.Name),
+		Desired:     desired,
+		Updated:     dp.Status.UpdatedReplicas,
+		Ready:       dp.Status.ReadyReplicas,
+		Available:   dp.Status.AvailableReplicas,
+		Unavailable: dp.Status.UnavailableReplicas,
+		Surge:       max(dp.Status.Replicas-desired, 0),
+	}
+	st.Revision, _ = strconv.Atoi(dp.Annotations[revisionAnnotation])
+	st.MaxSurge, st.MaxUnavailable = rolloutBounds(dp, desired)
+	st.State, st.Message = rolloutState(dp, desired)
+
+	last := lastPodEvents(ee)
+	for _, rs := range rr {
+		if !metav1.IsControlledBy(rs, dp) {
+			continue
+		}
+		rev, _ := strconv.Atoi(rs.Annotations[revisionAnnotation])
+		r := RolloutRS{
+			Name:     rs.Name,
+			Revision: rev,
+			New:      rev == st.Revision,
+			Ready:    rs.Status.ReadyReplicas,
+		}
+		if rs.Spec.Replicas != nil {
+			r.Desired = *rs.Spec.Replicas
+		}
+		for _, po := range pp {
+			if metav1.IsControlledBy(po, rs) {
+				r.Pods = append(r.Pods, rolloutPod(po, last[po.Name]))
+			}
+		}
+		if !r.New && r.Desired == 0 && len(r.Pods) == 0 {
+			continue
+		}
+		sort.Slice(r.Pods, func(i, j int) bool {
+			return r.Pods[i].Name < r.Pods[j].Name
+		})
+		st.ReplicaSets = append(st.ReplicaSets, r)
+	}
+	sort.Slice(st.ReplicaSets, func(i, j int) bool {
+		return st.ReplicaSets[i].Revision > st.ReplicaSets[j].Revision
+	})
+
+	return &st
+}
+
+// rolloutBounds resolves the max surge and max unavailable pod counts.
+func rolloutBounds(dp *appsv1.Deployment, desired int32) (int32, int32) {
+	if dp.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType {
+		return 0, desired
+	}
+	surge, unavailable := intstr.FromString("25%"), intstr.FromString("25%")
+	if ru := dp.Spec.Strategy.RollingUpdate; ru != nil {
+		if ru.MaxSurge != nil {
+			surge = *ru.MaxSurge
+		}
+		if ru.MaxUnavailable != nil {
+			unavailable = *ru.MaxUnavailable
+		}
+	}
+	s, _ := intstr.GetScaledValueFromIntOrPercent(&surge, int(desired), true)
+	u, _ := intstr.GetScaledValueFromIntOrPercent(&unavailable, int(desired), false)
+	if s == 0 && u == 0 {
+		u = 1
+	}
+
+	return int32(s), int32(u)
+}
+
+// rolloutState mirrors kubectl rollout status.
+func rolloutState(dp *appsv1.Deployment, desired int32) (string, string) {
+	var msg string
+	for _, c := range dp.Status.Conditions {
+		if c.Type != appsv1.DeploymentProgressing {
+			continue
+		}
+		msg = c.Message
+		if c.Reason == "ProgressDeadlineExceeded" {
+			return RolloutFailed, msg
+		}
+	}
+	s := dp.Status
+	switch {
+	case dp.Spec.Paused:
+		return RolloutPaused, msg
+	case s.ObservedGeneration >= dp.Generation && s.UpdatedReplicas == desired && s.Replicas == s.UpdatedReplicas && s.AvailableReplicas == s.UpdatedReplicas:
+		return RolloutComplete, msg
+	default:
+		return RolloutProgressing, msg
+	}
+}
+
+// rolloutPod summarizes a pod state, flagging pods failing to start.
+func rolloutPod(po *v1.Pod, last string) RolloutPod {
+	p := RolloutPod{
+		Name:   po.Name,
+		Status: string(po.Status.Phase),
+	}
+	if po.DeletionTimestamp != nil {
+		p.Status = "Terminating"
+	}
+	var ready int
+	for _, cs := range po.Status.ContainerStatuses {
+		p.Restarts += cs.RestartCount
+		if cs.Ready {
+			ready++
+		}
+		switch {
+		case cs.State.Waiting != nil && cs.State.Waiting.Reason != "":
+			p.Status = cs.State.Waiting.Reason
+			p.Failing = p.Failing || !slices.Contains(pendingReasons, cs.State.Waiting.Reason)
+		case cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0:
+			p.Status, p.Failing = cs.State.Terminated.Reason, true
+		}
+	}
+	p.Ready = fmt.Sprintf("%d/%d", ready, len(po.Spec.Containers))
+	if po.Status.Phase == v1.PodFailed {
+		p.Failing = true
+	}
+	for _, c := range po.Status.Conditions {
+		if c.Type == v1.PodScheduled && c.Status == v1.ConditionFalse {
+			p.Status, p.Failing = c.Reason, true
+		}
+	}
+	if p.Failing {
+		p.LastEvent = last
+	}
+
+	return p
+}
+
+// lastPodEvents returns the last event of each pod.
+func lastPodEvents(ee []*v1.Event) map[string]string {
+	sort.SliceStable(ee, func(i, j int) bool {
+		return eventTime(ee[i]).Before(eventTime(ee[j]))
+	})
+	mm := make(map[string]string, len(ee))
+	for _, e := range ee {
+		if e.InvolvedObject.Kind == "Pod" {
+			mm[e.InvolvedObject.Name] = e.Reason + ": " + strings.TrimSpace(e.Message)
+		}
+	}
+
+	return mm
+}
+
+// Pause pauses or resumes a Deployment rollout.
+func (d *Deployment) Pause(ctx context.Context, path string, on bool) error {
+	ns, n := client.Namespaced(path)
+	auth, err := d.Client().CanI(ns, d.gvr, n, client.PatchAccess)
+	if err != nil {
+		return err
+	}
+	if !auth {
+		return fmt.Errorf("user is not authorized to patch deployment %s", path)
+	}
+	dial, err := d.Client().Dial()
+	if err != nil {
+		return err
+	}
+	_, err = dial.AppsV1().Deployments(ns).Patch(
+		ctx,
+		n,
+		types.StrategicMergePatchType,
+		[]byte(fmt.Sprintf(`{"spec":{"paused":%t}}`, on)),
+		metav1.PatchOptions{},
+	)
+
+	return err
+}
+
+// Undo rolls a Deployment back to its previous revision.
+func (d *Deployment) Undo(ctx context.Context, path string) error {
+	ns, n := client.Namespaced(path)
+	auth, err := d.Client().CanI(ns, d.gvr, n, client.PatchAccess)
+	if err != nil {
+		return err
+	}
+	if !auth {
+		return fmt.Errorf("user is not authorized to patch deployment %s", path)
+	}
+	dial, err := d.Client().Dial()
+	if err != nil {
+		return err
+	}
+	dp, err := dial.AppsV1().Deployments(ns).Get(ctx, n, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	rb, err := polymorphichelpers.RollbackerFor(schema.GroupKind{Group: "apps", Kind: "Deployment"}, dial)
+	if err != nil {
+		return err
+	}
+	_, err = rb.Rollback(dp, map[string]string{}, 0, cmdutil.DryRunNone)
+
+	return err
+}
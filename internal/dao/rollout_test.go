@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestRollout(t *testing.T) {
+	dp := makeRolloutDP("2")
+	dp.Status = appsv1.DeploymentStatus{
+		ObservedGeneration:  1,
+		Replicas:            5,
+		UpdatedReplicas:     1,
+		ReadyReplicas:       3,
+		AvailableReplicas:   3,
+		UnavailableReplicas: 2,
+		Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentProgressing, Reason: "ReplicaSetUpdated", Message: `ReplicaSet "web-2" is progressing.`},
+		},
+	}
+	rr := []*appsv1.ReplicaSet{
+		makeRolloutRS(dp, "web-1", "1", 3),
+		makeRolloutRS(dp, "web-2", "2", 2),
+		makeRolloutRS(dp, "web-0", "0", 0),
+		{ObjectMeta: metav1.ObjectMeta{Name: "other", UID: "other"}},
+	}
+	crash := makeRolloutPod(rr[1], "web-2-b")
+	crash.Status.ContainerStatuses[0].RestartCount = 3
+	crash.Status.ContainerStatuses[0].State.Waiting = &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}
+	creating := makeRolloutPod(rr[1], "web-2-a")
+	creating.Status.ContainerStatuses[0].State.Waiting = &v1.ContainerStateWaiting{Reason: "ContainerCreating"}
+	old := makeRolloutPod(rr[0], "web-1-a")
+	old.Status.ContainerStatuses[0].Ready = true
+	now := time.Now()
+	ee := []*v1.Event{
+		makeRolloutEvent("web-2-b", "BackOff", "Back-off restarting failed container", now),
+		makeRolloutEvent("web-2-b", "Pulled", "Container image pulled", now.Add(-time.Minute)),
+		makeRolloutEvent("web-2-a", "Scheduled", "Assigned to node-1", now),
+	}
+
+	st := dao.Rollout(dp, rr, []*v1.Pod{crash, creating, old}, ee)
+
+	assert.Equal(t, "default/web", st.Path)
+	assert.Equal(t, dao.RolloutProgressing, st.State)
+	assert.Equal(t, `ReplicaSet "web-2" is progressing.`, st.Message)
+	assert.Equal(t, 2, st.Revision)
+	assert.Equal(t, int32(4), st.Desired)
+	assert.Equal(t, int32(1), st.Surge)
+	assert.Equal(t, int32(1), st.MaxSurge)
+	assert.Equal(t, int32(1), st.MaxUnavailable)
+	require.Len(t, st.ReplicaSets, 2)
+
+	nrs := st.ReplicaSets[0]
+	assert.Equal(t, "web-2", nrs.Name)
+	assert.True(t, nrs.New)
+	assert.Equal(t, []dao.RolloutPod{
+		{Name: "web-2-a", Status: "ContainerCreating", Ready: "0/1"},
+		{Name: "web-2-b", Status: "CrashLoopBackOff", Ready: "0/1", Restarts: 3, Failing: true, LastEvent: "BackOff: Back-off restarting failed container"},
+	}, nrs.Pods)
+
+	ors := st.ReplicaSets[1]
+	assert.Equal(t, "web-1", ors.Name)
+	assert.False(t, ors.New)
+	assert.Equal(t, []dao.RolloutPod{{Name: "web-1-a", Status: "Running", Ready: "1/1"}}, ors.Pods)
+}
+
+func TestRolloutState(t *testing.T) {
+	uu := map[string]struct {
+		paused bool
+		status appsv1.DeploymentStatus
+		e      string
+	}{
+		"complete": {
+			status: appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 4, UpdatedReplicas: 4, AvailableReplicas: 4},
+			e:      dao.RolloutComplete,
+		},
+		"stale-generation": {
+			status: appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 4, AvailableReplicas: 4},
+			e:      dao.RolloutProgressing,
+		},
+		"paused": {
+			paused: true,
+			status: appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 4, UpdatedReplicas: 2},
+			e:      dao.RolloutPaused,
+		},
+		"failed": {
+			status: appsv1.DeploymentStatus{
+				ObservedGeneration: 1,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentProgressing, Reason: "ProgressDeadlineExceeded"},
+				},
+			},
+			e: dao.RolloutFailed,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			dp := makeRolloutDP("1")
+			dp.Spec.Paused, dp.Status = u.paused, u.status
+			assert.Equal(t, u.e, dao.Rollout(dp, nil, nil, nil).State)
+		})
+	}
+}
+
+// Helpers...
+
+func makeRolloutDP(rev string) *appsv1.Deployment {
+	surge := intstr.FromString("25%")
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "web",
+			UID:         "web",
+			Generation:  1,
+			Annotations: map[string]string{"deployment.kubernetes.io/revision": rev},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(4)),
+			Strategy: appsv1.DeploymentStrategy{
+				Type:          appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &surge, MaxUnavailable: &surge},
+			},
+		},
+	}
+}
+
+func makeRolloutRS(dp *appsv1.Deployment, name, rev string, replicas int32) *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       dp.Namespace,
+			Name:            name,
+			UID:             types.UID(name),
+			Annotations:     map[string]string{"deployment.kubernetes.io/revision": rev},
+			OwnerReferences: []metav1.OwnerReference{controllerRef("Deployment", dp.Name, dp.UID)},
+		},
+		Spec: appsv1.ReplicaSetSpec{Replicas: ptr.To(replicas)},
+	}
+}
+
+func makeRolloutPod(rs *appsv1.ReplicaSet, name string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       rs.Namespace,
+			Name:            name,
+			OwnerReferences: []metav1.OwnerReference{controllerRef("ReplicaSet", rs.Name, rs.UID)},
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app"}}},
+		Status: v1.PodStatus{
+			Phase:             v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{Name: "app"}},
+		},
+	}
+}
+
+func makeRolloutEvent(po, reason, msg string, at time.Time) *v1.Event {
+	return &v1.Event{
+		InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: po},
+		Reason:         reason,
+		Message:        msg,
+		LastTimestamp:  metav1.NewTime(at),
+	}
+}
+
+func controllerRef(kind, name string, uid types.UID) metav1.OwnerReference {
+	return metav1.OwnerReference{Kind: kind, Name: name, UID: uid, Controller: ptr.To(true)}
+}
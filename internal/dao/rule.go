@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/render"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ Accessor = (*Rule)(nil)
+
+// Rule represents the configured automation rules.
+type Rule struct {
+	NonResource
+}
+
+// List returns the automation rules and their state.
+func (*Rule) List(ctx context.Context, _ string) ([]runtime.Object, error) {
+	rb, ok := ctx.Value(internal.KeyRules).(*RuleBook)
+	if !ok {
+		return nil, errors.New("no rule book found in context")
+	}
+	ss := rb.States()
+	oo := make([]runtime.Object, 0, len(ss))
+	for _, s := range ss {
+		action, _ := s.Action()
+		oo = append(oo, &render.AutoRuleRes{
+			Name:      s.Name,
+			Watch:     s.Watch,
+			When:      s.When,
+			Action:    action,
+			Enabled:   s.Enabled,
+			Matches:   s.Matches,
+			Fired:     s.Fired,
+			LastFired: s.LastFired,
+			Error:     s.Err,
+		})
+	}
+
+	return oo, nil
+}
+
+// RuleMatch represents a resource newly matching a rule.
+type RuleMatch struct {
+	Context string
+	Object  *unstructured.Unstructured
+}
+
+// RuleState tracks an automation rule toggle and activity.
+type RuleState struct {
+	config.Rule
+
+	Enabled   bool
+	Matches   int
+	Fired     int
+	LastFired time.Time
+	Err       string
+
+	seen      map[string]struct{}
+	baselined map[string]struct{}
+}
+
+// RuleBook tracks the state of the automation rules.
+type RuleBook struct {
+	states []*RuleState
+	mx     sync.RWMutex
+}
+
+// NewRuleBook returns a new instance.
+func NewRuleBook() *RuleBook {
+	return &RuleBook{}
+}
+
+// Sync aligns the book with the configured rules. Toggles and matches of
+// unchanged rules are preserved.
+func (b *RuleBook) Sync(rr []config.Rule) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	prev := make(map[string]*RuleState, len(b.states))
+	for _, s := range b.states {
+		prev[s.Name] = s
+	}
+	ss := make([]*RuleState, 0, len(rr))
+	for _, r := range rr {
+		if s, ok := prev[r.Name]; ok && s.Watch == r.Watch && s.When == r.When {
+			s.Rule = r
+			ss = append(ss, s)
+			continue
+		}
+		ss = append(ss, &RuleState{Rule: r, Enabled: !r.Disabled})
+	}
+	b.states = ss
+}
+
+// States returns a snapshot of the rules state.
+func (b *RuleBook) States() []RuleState {
+	b.mx.RLock()
+	defer b.mx.RUnlock()
+
+	ss := make([]RuleState, 0, len(b.states))
+	for _, s := range b.states {
+		c := *s
+		c.seen = nil
+		ss = append(ss, c)
+	}
+
+	return ss
+}
+
+// Toggle enables or disables a rule and returns its new state.
+func (b *RuleBook) Toggle(name string) (bool, error) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	s, ok := b.lookup(name)
+	if !ok {
+		return false, fmt.Errorf("no rule named %q", name)
+	}
+	s.Enabled = !s.Enabled
+	if !s.Enabled {
+		s.seen, s.baselined, s.Matches = nil, nil, 0
+	}
+
+	return s.Enabled, nil
+}
+
+// Observe evaluates a rule against the resources of the watched contexts and
+// returns the ones that started matching since the last evaluation. The first
+// evaluation of a context only records its matches as a baseline. The matches
+// of the skipped contexts are kept so they don't fire again once reachable.
+func (b *RuleBook) Observe(name string, ctxs []string, oo []ContextObject, skipped map[string]error) ([]RuleMatch, error) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	s, ok := b.lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("no rule named %q", name)
+	}
+	var (
+		mm   []RuleMatch
+		errs int
+		err  error
+		seen = make(map[string]struct{})
+	)
+	for k := range s.seen {
+		if _, ok := skipped[strings.SplitN(k, "|", 2)[0]]; ok {
+			seen[k] = struct{}{}
+		}
+	}
+	baselined := make(map[string]struct{}, len(ctxs))
+	for _, ct := range ctxs {
+		if _, ok := s.baselined[ct]; ok {
+			baselined[ct] = struct{}{}
+		}
+	}
+	for _, co := range oo {
+		u, ok := co.Object.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		match, e := render.CELMatch(s.When, u.Object)
+		if e != nil {
+			errs, err = errs+1, e
+			continue
+		}
+		if !match {
+			continue
+		}
+		k := co.Context + "|" + client.FQN(u.GetNamespace(), u.GetName())
+		seen[k] = struct{}{}
+		if _, ok := baselined[co.Context]; !ok {
+			continue
+		}
+		if _, ok := s.seen[k]; !ok {
+			mm = append(mm, RuleMatch{Context: co.Context, Object: u})
+		}
+	}
+	for _, ct := range ctxs {
+		if _, ok := skipped[ct]; !ok {
+			baselined[ct] = struct{}{}
+		}
+	}
+	s.seen, s.baselined, s.Matches = seen, baselined, len(seen)
+	// Missing fields fail evaluation, only report rules failing on all resources.
+	if len(oo) == 0 || errs < len(oo) {
+		err = nil
+	}
+
+	return mm, err
+}
+
+// Record tracks a rule evaluation outcome.
+func (b *RuleBook) Record(name string, fired int, err error) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	s, ok := b.lookup(name)
+	if !ok {
+		return
+	}
+	if fired > 0 {
+		s.Fired += fired
+		s.LastFired = time.Now()
+	}
+	s.Err = ""
+	if err != nil {
+		s.Err = err.Error()
+	}
+}
+
+func (b *RuleBook) lookup(name string) (*RuleState, bool) {
+	for _, s := range b.states {
+		if s.Name == name {
+			return s, true
+		}
+	}
+
+	return nil, false
+}
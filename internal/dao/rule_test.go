@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRuleBookObserve(t *testing.T) {
+	b := dao.NewRuleBook()
+	b.Sync([]config.Rule{{
+		Name:   "drift",
+		Watch:  "bundles",
+		When:   `self.status.summary.modified > 0`,
+		Notify: "$NAME drifted",
+	}})
+
+	prod, both := []string{"prod"}, []string{"prod", "dev"}
+
+	// The first pass only records a baseline.
+	mm, err := b.Observe("drift", prod, []dao.ContextObject{
+		makeBundle("prod", "b1", 1),
+		makeBundle("prod", "b2", 0),
+	}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, mm)
+	assert.Equal(t, 1, b.States()[0].Matches)
+
+	mm, err = b.Observe("drift", prod, []dao.ContextObject{
+		makeBundle("prod", "b1", 2),
+		makeBundle("prod", "b2", 1),
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, mm, 1)
+	assert.Equal(t, "prod", mm[0].Context)
+	assert.Equal(t, "b2", mm[0].Object.GetName())
+
+	// A newly watched context is baselined too.
+	mm, err = b.Observe("drift", both, []dao.ContextObject{
+		makeBundle("prod", "b1", 2),
+		makeBundle("prod", "b2", 1),
+		makeBundle("dev", "b1", 1),
+	}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, mm)
+	assert.Equal(t, 3, b.States()[0].Matches)
+
+	mm, err = b.Observe("drift", both, []dao.ContextObject{
+		makeBundle("prod", "b1", 2),
+		makeBundle("prod", "b2", 1),
+		makeBundle("dev", "b1", 1),
+		makeBundle("dev", "b2", 1),
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, mm, 1)
+	assert.Equal(t, "dev", mm[0].Context)
+	assert.Equal(t, "b2", mm[0].Object.GetName())
+
+	// A recovered resource fires again when it drifts anew.
+	_, err = b.Observe("drift", prod, []dao.ContextObject{makeBundle("prod", "b1", 0)}, nil)
+	require.NoError(t, err)
+	mm, err = b.Observe("drift", prod, []dao.ContextObject{makeBundle("prod", "b1", 1)}, nil)
+	require.NoError(t, err)
+	assert.Len(t, mm, 1)
+
+	// Matches on a skipped context don't fire again once it is reachable.
+	_, err = b.Observe("drift", both, []dao.ContextObject{
+		makeBundle("prod", "b1", 1),
+		makeBundle("dev", "b1", 1),
+	}, nil)
+	require.NoError(t, err)
+	_, err = b.Observe("drift", both, []dao.ContextObject{
+		makeBundle("prod", "b1", 1),
+		makeBundle("dev", "b3", 1),
+	}, nil)
+	require.NoError(t, err)
+	_, err = b.Observe("drift", both, []dao.ContextObject{makeBundle("prod", "b1", 1)}, map[string]error{"dev": errors.New("boom")})
+	require.NoError(t, err)
+	assert.Equal(t, 2, b.States()[0].Matches)
+	mm, err = b.Observe("drift", both, []dao.ContextObject{
+		makeBundle("prod", "b1", 1),
+		makeBundle("dev", "b3", 1),
+	}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, mm)
+
+	// A toggled rule records a new baseline.
+	_, err = b.Toggle("drift")
+	require.NoError(t, err)
+	_, err = b.Toggle("drift")
+	require.NoError(t, err)
+	mm, err = b.Observe("drift", both, []dao.ContextObject{
+		makeBundle("prod", "b1", 1),
+		makeBundle("dev", "b4", 1),
+	}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, mm)
+}
+
+func TestRuleBookObserveErrors(t *testing.T) {
+	b := dao.NewRuleBook()
+	b.Sync([]config.Rule{
+		{Name: "bad", Watch: "bundles", When: `self.status.`, Notify: "x"},
+		{Name: "partial", Watch: "bundles", When: `self.status.summary.modified > 0`, Notify: "x"},
+	})
+
+	_, err := b.Observe("bad", []string{"prod"}, []dao.ContextObject{makeBundle("prod", "b1", 1)}, nil)
+	require.Error(t, err)
+
+	_, err = b.Observe("partial", []string{"prod"}, nil, nil)
+	require.NoError(t, err)
+	mm, err := b.Observe("partial", []string{"prod"}, []dao.ContextObject{
+		makeBundle("prod", "b1", 1),
+		{Context: "prod", Object: &unstructured.Unstructured{Object: map[string]any{}}},
+	}, nil)
+	require.NoError(t, err)
+	assert.Len(t, mm, 1)
+
+	_, err = b.Observe("none", nil, nil, nil)
+	assert.EqualError(t, err, `no rule named "none"`)
+}
+
+func TestRuleBookToggle(t *testing.T) {
+	b := dao.NewRuleBook()
+	rr := []config.Rule{
+		{Name: "r1", Watch: "bundles", When: "true", Notify: "x"},
+		{Name: "r2", Watch: "bundles", When: "true", Notify: "x", Disabled: true},
+	}
+	b.Sync(rr)
+	ss := b.States()
+	require.Len(t, ss, 2)
+	assert.True(t, ss[0].Enabled)
+	assert.False(t, ss[1].Enabled)
+
+	on, err := b.Toggle("r1")
+	require.NoError(t, err)
+	assert.False(t, on)
+
+	// Toggles survive a config reload unless the rule changed.
+	rr[1].When = "false"
+	b.Sync(rr)
+	ss = b.States()
+	assert.False(t, ss[0].Enabled)
+	assert.False(t, ss[1].Enabled)
+
+	b.Sync(rr[1:])
+	assert.Len(t, b.States(), 1)
+
+	_, err = b.Toggle("r1")
+	assert.EqualError(t, err, `no rule named "r1"`)
+}
+
+// Helpers...
+
+func makeBundle(ctx, n string, modified int64) dao.ContextObject {
+	return dao.ContextObject{
+		Context: ctx,
+		Object: &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "fleet.cattle.io/v1alpha1",
+			"kind":       "Bundle",
+			"metadata":   map[string]any{"namespace": "fleet-default", "name": n},
+			"status": map[string]any{
+				"summary": map[string]any{"modified": modified},
+			},
+		}},
+	}
+}
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	authv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	saGroup       = "system:serviceaccounts"
+	authenticated = "system:authenticated"
+)
+
+// SABinding represents a binding granting a role to a service account.
+type SABinding struct {
+	// Kind is either ClusterRoleBinding or RoleBinding.
+	Kind, Namespace, Name string
+	Role                  string
+	// Via tracks the group granting the role, blank for direct bindings.
+	Via string
+}
+
+// SAUsage represents the bindings and pods of a service account.
+type SAUsage struct {
+	Bindings []SABinding
+	Pods     []string
+}
+
+// SAGroups returns the groups a service account token belongs to.
+func SAGroups(ns string) []string {
+	return []string{saGroup, saGroup + ":" + ns, authenticated}
+}
+
+// SABindings returns the bindings granting roles to a service account,
+// directly or via its groups.
+func SABindings(ns, name string, crbs []rbacv1.ClusterRoleBinding, rbs []rbacv1.RoleBinding) []SABinding {
+	var bb []SABinding
+	for i := range crbs {
+		if via, ok := saSubject(ns, name, "", crbs[i].Subjects); ok {
+			bb = append(bb, SABinding{
+				Kind: "ClusterRoleBinding",
+				Name: crbs[i].Name,
+				Role: crbs[i].RoleRef.Kind + "/" + crbs[i].RoleRef.Name,
+				Via:  via,
+			})
+		}
+	}
+	for i := range rbs {
+		if via, ok := saSubject(ns, name, rbs[i].Namespace, rbs[i].Subjects); ok {
+			bb = append(bb, SABinding{
+				Kind:      "RoleBinding",
+				Namespace: rbs[i].Namespace,
+				Name:      rbs[i].Name,
+				Role:      rbs[i].RoleRef.Kind + "/" + rbs[i].RoleRef.Name,
+				Via:       via,
+			})
+		}
+	}
+	sort.SliceStable(bb, func(i, j int) bool {
+		return bb[i].Via < bb[j].Via
+	})
+
+	return bb
+}
+
+func saSubject(ns, name, bns string, ss []rbacv1.Subject) (string, bool) {
+	var via string
+	for _, s := range ss {
+		switch s.Kind {
+		case rbacv1.ServiceAccountKind:
+			sns := s.Namespace
+			if sns == "" {
+				sns = bns
+			}
+			if sns == ns && s.Name == name {
+				return "", true
+			}
+		case rbacv1.GroupKind:
+			for _, g := range SAGroups(ns) {
+				if s.Name == g && via == "" {
+					via = g
+				}
+			}
+		}
+	}
+
+	return via, via != ""
+}
+
+// FetchSAUsage returns the bindings of a service account and the pods
+// running as it.
+func FetchSAUsage(ctx context.Context, c client.Connection, fqn string) (*SAUsage, error) {
+	dial, err := c.Dial()
+	if err != nil {
+		return nil, err
+	}
+	ns, n := client.Namespaced(fqn)
+	crbs, err := dial.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	rbs, err := dial.RbacV1().RoleBindings(client.BlankNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pp, err := dial.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	u := SAUsage{Bindings: SABindings(ns, n, crbs.Items, rbs.Items)}
+	for i := range pp.Items {
+		spec := &pp.Items[i].Spec
+		sa := spec.ServiceAccountName
+		if sa == "" {
+			sa = "default"
+		}
+		if sa != n {
+			continue
+		}
+		po := pp.Items[i].Name
+		if spec.AutomountServiceAccountToken != nil && !*spec.AutomountServiceAccountToken {
+			po += " (token not mounted)"
+		}
+		u.Pods = append(u.Pods, po)
+	}
+	sort.Strings(u.Pods)
+
+	return &u, nil
+}
+
+// MintSAToken requests a short lived token for a service account.
+func MintSAToken(ctx context.Context, c client.Connection, fqn string, audiences []string, ttl time.Duration) (*authv1.TokenRequestStatus, error) {
+	dial, err := c.Dial()
+	if err != nil {
+		return nil, err
+	}
+	ns, n := client.Namespaced(fqn)
+	secs := int64(ttl.Seconds())
+	req := authv1.TokenRequest{
+		Spec: authv1.TokenRequestSpec{
+			Audiences:         audiences,
+			ExpirationSeconds: &secs,
+		},
+	}
+	tr, err := dial.CoreV1().ServiceAccounts(ns).CreateToken(ctx, n, &req, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tr.Status, nil
+}
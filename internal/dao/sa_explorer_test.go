@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSABindings(t *testing.T) {
+	crbs := []rbacv1.ClusterRoleBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "crb1"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+			Subjects:   []rbacv1.Subject{{Kind: "ServiceAccount", Namespace: "ns1", Name: "fred"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "crb2"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "discovery"},
+			Subjects:   []rbacv1.Subject{{Kind: "Group", Name: "system:authenticated"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "crb3"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "admin"},
+			Subjects:   []rbacv1.Subject{{Kind: "ServiceAccount", Namespace: "ns2", Name: "fred"}},
+		},
+	}
+	rbs := []rbacv1.RoleBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rb1", Namespace: "ns1"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "edit"},
+			Subjects:   []rbacv1.Subject{{Kind: "ServiceAccount", Name: "fred"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rb2", Namespace: "ns1"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+			Subjects:   []rbacv1.Subject{{Kind: "Group", Name: "system:serviceaccounts:ns1"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rb3", Namespace: "ns2"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "edit"},
+			Subjects:   []rbacv1.Subject{{Kind: "ServiceAccount", Name: "fred"}},
+		},
+	}
+
+	assert.Equal(t, []dao.SABinding{
+		{Kind: "ClusterRoleBinding", Name: "crb1", Role: "ClusterRole/view"},
+		{Kind: "RoleBinding", Namespace: "ns1", Name: "rb1", Role: "Role/edit"},
+		{Kind: "ClusterRoleBinding", Name: "crb2", Role: "ClusterRole/discovery", Via: "system:authenticated"},
+		{Kind: "RoleBinding", Namespace: "ns1", Name: "rb2", Role: "ClusterRole/view", Via: "system:serviceaccounts:ns1"},
+	}, dao.SABindings("ns1", "fred", crbs, rbs))
+}
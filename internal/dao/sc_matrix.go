@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	defaultSCAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	betaDefaultSCAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+	scParamPrefix           = "parameters."
+)
+
+// StorageClassCaps represents the capabilities of a storage class on a context.
+type StorageClassCaps struct {
+	Context, Name string
+	// Attrs tracks the capabilities by name, ie provisioner or
+	// parameters.numberOfReplicas.
+	Attrs map[string]string
+}
+
+// NewStorageClassCaps returns the capabilities of a storage class.
+func NewStorageClassCaps(ctxName string, sc *storagev1.StorageClass) StorageClassCaps {
+	expansion := sc.AllowVolumeExpansion != nil && *sc.AllowVolumeExpansion
+	reclaim := v1.PersistentVolumeReclaimDelete
+	if sc.ReclaimPolicy != nil {
+		reclaim = *sc.ReclaimPolicy
+	}
+	binding := storagev1.VolumeBindingImmediate
+	if sc.VolumeBindingMode != nil {
+		binding = *sc.VolumeBindingMode
+	}
+
+	c := StorageClassCaps{
+		Context: ctxName,
+		Name:    sc.Name,
+		Attrs: map[string]string{
+			"provisioner":          sc.Provisioner,
+			"default":              strconv.FormatBool(IsDefaultStorageClass(sc)),
+			"allowVolumeExpansion": strconv.FormatBool(expansion),
+			"reclaimPolicy":        string(reclaim),
+			"volumeBindingMode":    string(binding),
+		},
+	}
+	if len(sc.MountOptions) > 0 {
+		c.Attrs["mountOptions"] = strings.Join(sc.MountOptions, ",")
+	}
+	for k, v := range sc.Parameters {
+		c.Attrs[scParamPrefix+k] = v
+	}
+
+	return c
+}
+
+// IsDefaultStorageClass returns true if a storage class is flagged as default.
+func IsDefaultStorageClass(sc *storagev1.StorageClass) bool {
+	return sc.Annotations[defaultSCAnnotation] == "true" || sc.Annotations[betaDefaultSCAnnotation] == "true"
+}
+
+// FetchStorageClassCaps lists the storage classes of the given contexts.
+// Skipped contexts are reported along with the classes of the others.
+func FetchStorageClassCaps(rawCfg api.Config, ctxs []string) ([]StorageClassCaps, error) {
+	oo, err := MultiContextList(context.Background(), rawCfg, ctxs, client.ScGVR.GVR(), client.BlankNamespace, "")
+	cc := make([]StorageClassCaps, 0, len(oo))
+	for _, co := range oo {
+		var sc storagev1.StorageClass
+		if fromContextObject(co, &sc) {
+			cc = append(cc, NewStorageClassCaps(co.Context, &sc))
+		}
+	}
+	sort.Slice(cc, func(i, j int) bool {
+		if cc[i].Name != cc[j].Name {
+			return cc[i].Name < cc[j].Name
+		}
+		return cc[i].Context < cc[j].Context
+	})
+
+	return cc, err
+}
+
+// StorageClassAttrs returns the sorted capability names of storage classes,
+// the well known ones first then the parameters.
+func StorageClassAttrs(cc []StorageClassCaps) []string {
+	aa := []string{"provisioner", "default", "allowVolumeExpansion", "reclaimPolicy", "volumeBindingMode", "mountOptions"}
+	var pp []string
+	seen := make(map[string]struct{})
+	for _, c := range cc {
+		for k := range c.Attrs {
+			if _, ok := seen[k]; ok || !strings.HasPrefix(k, scParamPrefix) {
+				continue
+			}
+			seen[k] = struct{}{}
+			pp = append(pp, k)
+		}
+	}
+	sort.Strings(pp)
+
+	return append(aa, pp...)
+}
+
+// StorageClassDrifts flags the storage classes missing or configured
+// differently on some contexts and contexts disagreeing on the default class.
+func StorageClassDrifts(ctxs []string, cc []StorageClassCaps) []string {
+	if len(ctxs) < 2 {
+		return nil
+	}
+	byName := make(map[string]map[string]StorageClassCaps)
+	defaults := make(map[string][]string, len(ctxs))
+	for _, c := range cc {
+		if byName[c.Name] == nil {
+			byName[c.Name] = make(map[string]StorageClassCaps)
+		}
+		byName[c.Name][c.Context] = c
+		if c.Attrs["default"] == "true" {
+			defaults[c.Context] = append(defaults[c.Context], c.Name)
+		}
+	}
+
+	var dd []string
+	for name, perCtx := range byName {
+		var missing []string
+		for _, ctx := range ctxs {
+			if _, ok := perCtx[ctx]; !ok {
+				missing = append(missing, ctx)
+			}
+		}
+		if len(missing) > 0 {
+			dd = append(dd, fmt.Sprintf("%s missing on %s", name, strings.Join(missing, ", ")))
+		}
+		for _, attr := range StorageClassAttrs(slices.Collect(maps.Values(perCtx))) {
+			var (
+				vv   []string
+				vals = make(map[string]struct{})
+			)
+			for _, ctx := range ctxs {
+				c, ok := perCtx[ctx]
+				if !ok {
+					continue
+				}
+				v := c.Attrs[attr]
+				vals[v] = struct{}{}
+				vv = append(vv, fmt.Sprintf("%s=%s", ctx, orNone(v)))
+			}
+			if len(vals) > 1 {
+				dd = append(dd, fmt.Sprintf("%s %s differs: %s", name, attr, strings.Join(vv, ", ")))
+			}
+		}
+	}
+
+	var (
+		dv   []string
+		vals = make(map[string]struct{})
+	)
+	for _, ctx := range ctxs {
+		d := strings.Join(defaults[ctx], ",")
+		vals[d] = struct{}{}
+		dv = append(dv, fmt.Sprintf("%s=%s", ctx, orNone(d)))
+	}
+	if len(vals) > 1 {
+		dd = append(dd, "default storage class differs: "+strings.Join(dv, ", "))
+	}
+	sort.Strings(dd)
+
+	return dd
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+
+	return s
+}
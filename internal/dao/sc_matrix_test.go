@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewStorageClassCaps(t *testing.T) {
+	expand, retain := true, v1.PersistentVolumeReclaimRetain
+	sc := storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "longhorn",
+			Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"},
+		},
+		Provisioner:          "driver.longhorn.io",
+		AllowVolumeExpansion: &expand,
+		ReclaimPolicy:        &retain,
+		Parameters:           map[string]string{"numberOfReplicas": "3"},
+	}
+
+	assert.Equal(t, dao.StorageClassCaps{
+		Context: "c1",
+		Name:    "longhorn",
+		Attrs: map[string]string{
+			"provisioner":                 "driver.longhorn.io",
+			"default":                     "true",
+			"allowVolumeExpansion":        "true",
+			"reclaimPolicy":               "Retain",
+			"volumeBindingMode":           "Immediate",
+			"parameters.numberOfReplicas": "3",
+		},
+	}, dao.NewStorageClassCaps("c1", &sc))
+}
+
+func TestStorageClassDrifts(t *testing.T) {
+	cc := []dao.StorageClassCaps{
+		{Context: "c1", Name: "longhorn", Attrs: map[string]string{
+			"provisioner":                 "driver.longhorn.io",
+			"default":                     "true",
+			"parameters.numberOfReplicas": "3",
+		}},
+		{Context: "c2", Name: "longhorn", Attrs: map[string]string{
+			"provisioner":                 "driver.longhorn.io",
+			"default":                     "false",
+			"parameters.numberOfReplicas": "2",
+		}},
+		{Context: "c2", Name: "local-path", Attrs: map[string]string{
+			"provisioner": "rancher.io/local-path",
+			"default":     "true",
+		}},
+	}
+
+	uu := map[string]struct {
+		ctxs []string
+		e    []string
+	}{
+		"single": {
+			ctxs: []string{"c1"},
+		},
+		"multi": {
+			ctxs: []string{"c1", "c2"},
+			e: []string{
+				"default storage class differs: c1=longhorn, c2=local-path",
+				"local-path missing on c1",
+				"longhorn default differs: c1=true, c2=false",
+				"longhorn parameters.numberOfReplicas differs: c1=3, c2=2",
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.StorageClassDrifts(u.ctxs, cc))
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// EncryptionStageStart represents a secrets encryption config at rest.
+	EncryptionStageStart = "start"
+
+	// EncryptionStageFinished represents a completed key rotation.
+	EncryptionStageFinished = "reencrypt_finished"
+
+	// ControlPlaneSelector selects the RKE2/K3s server nodes.
+	ControlPlaneSelector = "node-role.kubernetes.io/control-plane=true"
+
+	encryptionEnabled = "Enabled"
+	hashesMatch       = "All hashes match"
+	distroPath        = "/usr/local/bin:/opt/rke2/bin:/var/lib/rancher/rke2/bin"
+	restartService    = "systemctl --no-block restart $svc && echo \"restarting $svc\""
+)
+
+// EncryptionKey represents a secrets encryption key.
+type EncryptionKey struct {
+	Type, Name string
+	Active     bool
+}
+
+// EncryptionStatus represents the secrets encryption status reported by a server node.
+type EncryptionStatus struct {
+	Context, Node string
+	Status        string
+	Stage         string
+	Hashes        string
+	Keys          []EncryptionKey
+	Err           error
+}
+
+// ID returns the node fully qualified name.
+func (s EncryptionStatus) ID() string {
+	return s.Context + "/" + s.Node
+}
+
+// DistroScript returns a shell script running body on a RKE2/K3s host with
+// $bin set to the distribution binary, $svc to its systemd service, $data to
+// its data dir and $cfg to its config file.
+func DistroScript(body string) string {
+	return fmt.Sprintf("PATH=$PATH:%s; bin=$(command -v rke2 || command -v k3s); "+
+		"[ -n \"$bin\" ] || { echo 'neither rke2 nor k3s found on host'; exit 1; }; "+
+		"d=$(basename $bin); data=/var/lib/rancher/$d; cfg=/etc/rancher/$d/config.yaml; "+
+		"for s in rke2-server k3s rke2-agent k3s-agent; do systemctl is-enabled -q $s 2>/dev/null && svc=$s && break; done; "+
+		"[ -n \"$svc\" ] || { echo \"no $d service enabled on host\"; exit 1; }; %s", distroPath, body)
+}
+
+// DistroServiceScript returns a shell script restarting the rke2 or k3s
+// service without waiting for it, since the restart takes down the kubelet
+// attached to the calling pod.
+func DistroServiceScript() string {
+	return DistroScript(restartService)
+}
+
+// ParseEncryptionStatus parses a `secrets-encrypt status` output.
+func ParseEncryptionStatus(out string) (EncryptionStatus, error) {
+	var (
+		s      EncryptionStatus
+		inKeys bool
+	)
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "---") {
+			inKeys = true
+			continue
+		}
+		if inKeys {
+			if k, ok := parseEncryptionKey(line); ok {
+				s.Keys = append(s.Keys, k)
+			}
+			continue
+		}
+		k, v, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch v = strings.TrimSpace(v); strings.TrimSpace(k) {
+		case "Encryption Status":
+			s.Status = v
+		case "Current Rotation Stage":
+			s.Stage = v
+		case "Server Encryption Hashes":
+			s.Hashes = v
+		}
+	}
+	if s.Status == "" {
+		return s, fmt.Errorf("unexpected secrets-encrypt output %q", strings.TrimSpace(out))
+	}
+
+	return s, nil
+}
+
+func parseEncryptionKey(line string) (EncryptionKey, bool) {
+	ff := strings.Fields(line)
+	var k EncryptionKey
+	if len(ff) > 0 && ff[0] == "*" {
+		k.Active, ff = true, ff[1:]
+	}
+	if len(ff) < 2 {
+		return k, false
+	}
+	k.Type, k.Name = ff[0], ff[len(ff)-1]
+
+	return k, true
+}
+
+// EncryptionIssues flags servers with encryption disabled, mismatched configs
+// or a rotation in flight.
+func EncryptionIssues(ss []EncryptionStatus) []string {
+	var (
+		ii     []string
+		stages = make(map[string]map[string]struct{})
+	)
+	for _, s := range ss {
+		if s.Err != nil {
+			ii = append(ii, fmt.Sprintf("%s status unavailable: %s", s.ID(), s.Err))
+			continue
+		}
+		if s.Status != encryptionEnabled {
+			ii = append(ii, fmt.Sprintf("%s secrets encryption is %s", s.ID(), s.Status))
+			continue
+		}
+		if s.Hashes != "" && !strings.HasPrefix(s.Hashes, hashesMatch) {
+			ii = append(ii, fmt.Sprintf("%s servers disagree on the encryption config: %s", s.ID(), s.Hashes))
+		}
+		if s.Stage != EncryptionStageStart && s.Stage != EncryptionStageFinished {
+			ii = append(ii, fmt.Sprintf("%s key rotation in progress (stage %s)", s.ID(), s.Stage))
+		}
+		var active bool
+		for _, k := range s.Keys {
+			active = active || k.Active
+		}
+		if !active {
+			ii = append(ii, fmt.Sprintf("%s has no active encryption key", s.ID()))
+		}
+		if stages[s.Context] == nil {
+			stages[s.Context] = make(map[string]struct{})
+		}
+		stages[s.Context][s.Stage] = struct{}{}
+	}
+	for ctx, st := range stages {
+		if len(st) < 2 {
+			continue
+		}
+		vv := make([]string, 0, len(st))
+		for k := range st {
+			vv = append(vv, k)
+		}
+		sort.Strings(vv)
+		ii = append(ii, fmt.Sprintf("%s servers report different rotation stages: %s", ctx, strings.Join(vv, ", ")))
+	}
+
+	return ii
+}
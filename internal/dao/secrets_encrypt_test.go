@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const encryptStatus = `Encryption Status: Enabled
+Current Rotation Stage: start
+Server Encryption Hashes: All hashes match
+
+Active  Key Type  Name
+------  --------  ----
+ *      AES-CBC   aescbckey-2024-05-01T10:00:00Z
+        AES-CBC   aescbckey
+`
+
+func TestParseEncryptionStatus(t *testing.T) {
+	uu := map[string]struct {
+		out string
+		e   dao.EncryptionStatus
+		err bool
+	}{
+		"enabled": {
+			out: encryptStatus,
+			e: dao.EncryptionStatus{
+				Status: "Enabled",
+				Stage:  "start",
+				Hashes: "All hashes match",
+				Keys: []dao.EncryptionKey{
+					{Type: "AES-CBC", Name: "aescbckey-2024-05-01T10:00:00Z", Active: true},
+					{Type: "AES-CBC", Name: "aescbckey"},
+				},
+			},
+		},
+		"disabled": {
+			out: "Encryption Status: Disabled, no configuration file found\n",
+			e:   dao.EncryptionStatus{Status: "Disabled, no configuration file found"},
+		},
+		"toast": {
+			out: "neither rke2 nor k3s found on host",
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s, err := dao.ParseEncryptionStatus(u.out)
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.e, s)
+		})
+	}
+}
+
+func TestEncryptionIssues(t *testing.T) {
+	ok, err := dao.ParseEncryptionStatus(encryptStatus)
+	require.NoError(t, err)
+	ok.Context = "c1"
+	s1, s2 := ok, ok
+	s1.Node, s2.Node = "s1", "s2"
+
+	uu := map[string]struct {
+		ss []dao.EncryptionStatus
+		e  []string
+	}{
+		"healthy": {
+			ss: []dao.EncryptionStatus{s1, s2},
+		},
+		"rotating": {
+			ss: []dao.EncryptionStatus{s1, func() dao.EncryptionStatus {
+				s := s2
+				s.Stage, s.Hashes = "rotate_keys", "hash does not match between s1 and s2"
+				return s
+			}()},
+			e: []string{
+				"c1/s2 servers disagree on the encryption config: hash does not match between s1 and s2",
+				"c1/s2 key rotation in progress (stage rotate_keys)",
+				"c1 servers report different rotation stages: rotate_keys, start",
+			},
+		},
+		"disabled": {
+			ss: []dao.EncryptionStatus{
+				{Context: "c1", Node: "s1", Status: "Disabled"},
+				{Context: "c1", Node: "s2", Err: errors.New("boom")},
+			},
+			e: []string{
+				"c1/s1 secrets encryption is Disabled",
+				"c1/s2 status unavailable: boom",
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.EncryptionIssues(u.ss))
+		})
+	}
+}
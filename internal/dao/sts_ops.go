@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	stsReadyPoll    = 2 * time.Second
+	stsRetainPolicy = "Retain"
+)
+
+// StsClaim represents the claim of a StatefulSet replica volume.
+type StsClaim struct {
+	Ordinal     int
+	Template    string
+	Claim       string
+	Status      string
+	Orphan      bool
+	WhenScaled  string
+	WhenDeleted string
+}
+
+// SetPartition sets the rolling update partition of a StatefulSet. Only the
+// replicas with an ordinal greater or equal to the partition are updated.
+func (s *StatefulSet) SetPartition(ctx context.Context, path string, partition int32) error {
+	if partition < 0 {
+		return fmt.Errorf("invalid partition %d", partition)
+	}
+	dial, err := s.Client().Dial()
+	if err != nil {
+		return err
+	}
+	ns, n := client.Namespaced(path)
+	patch := fmt.Sprintf(`{"spec":{"updateStrategy":{"type":"RollingUpdate","rollingUpdate":{"partition":%d}}}}`, partition)
+	_, err = dial.AppsV1().StatefulSets(ns).Patch(ctx, n, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+
+	return err
+}
+
+// RestartOrdered restarts the StatefulSet replicas one at a time from the
+// highest ordinal down, waiting for each replacement pod to be ready.
+func (s *StatefulSet) RestartOrdered(ctx context.Context, path string, progress func(string)) error {
+	sts, err := s.getStatefulSet(path)
+	if err != nil {
+		return err
+	}
+	dial, err := s.Client().Dial()
+	if err != nil {
+		return err
+	}
+	var replicas int32 = 1
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	pods := dial.CoreV1().Pods(sts.Namespace)
+	for i := replicas - 1; i >= 0; i-- {
+		name := fmt.Sprintf("%s-%d", sts.Name, i)
+		po, err := pods.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		progress(fmt.Sprintf("Restarting %s (%d/%d)...", name, replicas-i, replicas))
+		if err := pods.Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+		err = wait.PollUntilContextCancel(ctx, stsReadyPoll, false, func(ctx context.Context) (bool, error) {
+			p, err := pods.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return false, nil
+			}
+			return p.UID != po.UID && isPodReady(p), nil
+		})
+		if err != nil {
+			return fmt.Errorf("pod %s not ready: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Claims returns the volume claims of each StatefulSet replica.
+func (s *StatefulSet) Claims(ctx context.Context, path string) ([]StsClaim, error) {
+	sts, err := s.getStatefulSet(path)
+	if err != nil {
+		return nil, err
+	}
+	dial, err := s.Client().Dial()
+	if err != nil {
+		return nil, err
+	}
+	ll, err := dial.CoreV1().PersistentVolumeClaims(sts.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return StsClaims(sts, ll.Items), nil
+}
+
+// StsClaims matches the claims created from a StatefulSet volume claim
+// templates to its replicas, including claims left behind by a scale down.
+func StsClaims(sts *appsv1.StatefulSet, pp []v1.PersistentVolumeClaim) []StsClaim {
+	var replicas int32 = 1
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	scaled, deleted := stsRetainPolicy, stsRetainPolicy
+	if p := sts.Spec.PersistentVolumeClaimRetentionPolicy; p != nil {
+		if p.WhenScaled != "" {
+			scaled = string(p.WhenScaled)
+		}
+		if p.WhenDeleted != "" {
+			deleted = string(p.WhenDeleted)
+		}
+	}
+	pvcs := make(map[string]*v1.PersistentVolumeClaim, len(pp))
+	for i := range pp {
+		pvcs[pp[i].Name] = &pp[i]
+	}
+
+	var cc []StsClaim
+	for _, t := range sts.Spec.VolumeClaimTemplates {
+		prefix := t.Name + "-" + sts.Name + "-"
+		maxOrdinal := int(replicas) - 1
+		for n := range pvcs {
+			if o, ok := claimOrdinal(n, prefix); ok {
+				maxOrdinal = max(maxOrdinal, o)
+			}
+		}
+		for o := 0; o <= maxOrdinal; o++ {
+			c := StsClaim{
+				Ordinal:     o,
+				Template:    t.Name,
+				Claim:       prefix + strconv.Itoa(o),
+				Status:      "Missing",
+				Orphan:      o >= int(replicas),
+				WhenScaled:  scaled,
+				WhenDeleted: deleted,
+			}
+			if pvc, ok := pvcs[c.Claim]; ok {
+				c.Status = string(pvc.Status.Phase)
+			} else if c.Orphan {
+				continue
+			}
+			cc = append(cc, c)
+		}
+	}
+
+	return cc
+}
+
+func claimOrdinal(name, prefix string) (int, bool) {
+	if !strings.HasPrefix(name, prefix) {
+		return 0, false
+	}
+	o, err := strconv.Atoi(strings.TrimPrefix(name, prefix))
+
+	return o, err == nil && o >= 0
+}
+
+func isPodReady(p *v1.Pod) bool {
+	for _, c := range p.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
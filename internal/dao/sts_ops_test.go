@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStsClaims(t *testing.T) {
+	sts := func(replicas int32, p *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "ns1"},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas:                             &replicas,
+				PersistentVolumeClaimRetentionPolicy: p,
+				VolumeClaimTemplates: []v1.PersistentVolumeClaim{
+					{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+				},
+			},
+		}
+	}
+	pvc := func(n string, phase v1.PersistentVolumeClaimPhase) v1.PersistentVolumeClaim {
+		return v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: n, Namespace: "ns1"},
+			Status:     v1.PersistentVolumeClaimStatus{Phase: phase},
+		}
+	}
+
+	uu := map[string]struct {
+		sts *appsv1.StatefulSet
+		pp  []v1.PersistentVolumeClaim
+		e   []dao.StsClaim
+	}{
+		"bound": {
+			sts: sts(2, nil),
+			pp:  []v1.PersistentVolumeClaim{pvc("data-db-0", v1.ClaimBound), pvc("data-db-1", v1.ClaimPending), pvc("logs-db-0", v1.ClaimBound)},
+			e: []dao.StsClaim{
+				{Ordinal: 0, Template: "data", Claim: "data-db-0", Status: "Bound", WhenScaled: "Retain", WhenDeleted: "Retain"},
+				{Ordinal: 1, Template: "data", Claim: "data-db-1", Status: "Pending", WhenScaled: "Retain", WhenDeleted: "Retain"},
+			},
+		},
+		"missing": {
+			sts: sts(1, nil),
+			e: []dao.StsClaim{
+				{Ordinal: 0, Template: "data", Claim: "data-db-0", Status: "Missing", WhenScaled: "Retain", WhenDeleted: "Retain"},
+			},
+		},
+		"orphans": {
+			sts: sts(1, &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType}),
+			pp:  []v1.PersistentVolumeClaim{pvc("data-db-0", v1.ClaimBound), pvc("data-db-2", v1.ClaimBound)},
+			e: []dao.StsClaim{
+				{Ordinal: 0, Template: "data", Claim: "data-db-0", Status: "Bound", WhenScaled: "Retain", WhenDeleted: "Delete"},
+				{Ordinal: 2, Template: "data", Claim: "data-db-2", Status: "Bound", Orphan: true, WhenScaled: "Retain", WhenDeleted: "Delete"},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.StsClaims(u.sts, u.pp))
+		})
+	}
+}
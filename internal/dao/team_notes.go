@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// TeamNotesKey tracks the ConfigMap key holding the team notes.
+const TeamNotesKey = "notes.yaml"
+
+// TeamNote represents a note shared by a team on matching resources.
+type TeamNote struct {
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Note      string `json:"note"`
+	Starred   bool   `json:"starred,omitempty"`
+}
+
+// TeamNotes represents notes and saved filters shared through a ConfigMap.
+type TeamNotes struct {
+	Notes   []TeamNote        `json:"notes,omitempty"`
+	Filters map[string]string `json:"filters,omitempty"`
+}
+
+// ParseTeamNotes parses team notes.
+func ParseTeamNotes(raw string) (*TeamNotes, error) {
+	var tn TeamNotes
+	if err := yaml.Unmarshal([]byte(raw), &tn); err != nil {
+		return nil, fmt.Errorf("invalid team notes: %w", err)
+	}
+
+	return &tn, nil
+}
+
+// FetchTeamNotes reads the team notes from a ConfigMap.
+func FetchTeamNotes(ctx context.Context, c client.Connection, ns, name string) (*TeamNotes, error) {
+	dial, err := c.Dial()
+	if err != nil {
+		return nil, err
+	}
+	cm, err := dial.CoreV1().ConfigMaps(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseTeamNotes(cm.Data[TeamNotesKey])
+}
+
+// For returns the notes on a resource. Note names and namespaces may be globs.
+func (t *TeamNotes) For(gvr *client.GVR, fqn string) []TeamNote {
+	if t == nil {
+		return nil
+	}
+	ns, n := client.Namespaced(fqn)
+	var nn []TeamNote
+	for _, note := range t.Notes {
+		if !strings.EqualFold(note.Resource, gvr.R()) && !strings.EqualFold(note.Resource, gvr.String()) {
+			continue
+		}
+		if note.Namespace != "" && !globMatch(note.Namespace, ns) {
+			continue
+		}
+		if globMatch(note.Name, n) {
+			nn = append(nn, note)
+		}
+	}
+
+	return nn
+}
+
+func globMatch(pattern, s string) bool {
+	ok, err := path.Match(pattern, s)
+
+	return err == nil && ok
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const teamNotes = `
+notes:
+  - resource: pods
+    namespace: kube-system
+    name: etcd-*
+    note: Do not touch, owned by the platform team
+    starred: true
+  - resource: apps/v1/deployments
+    name: payments
+    note: Scale through the HPA only
+filters:
+  crashing: pods /CrashLoop -A
+`
+
+func TestTeamNotesFor(t *testing.T) {
+	tn, err := dao.ParseTeamNotes(teamNotes)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"crashing": "pods /CrashLoop -A"}, tn.Filters)
+
+	uu := map[string]struct {
+		gvr *client.GVR
+		fqn string
+		e   []string
+	}{
+		"glob": {
+			gvr: client.PodGVR,
+			fqn: "kube-system/etcd-node-1",
+			e:   []string{"Do not touch, owned by the platform team"},
+		},
+		"other-ns": {
+			gvr: client.PodGVR,
+			fqn: "default/etcd-node-1",
+		},
+		"any-ns": {
+			gvr: client.DpGVR,
+			fqn: "prod/payments",
+			e:   []string{"Scale through the HPA only"},
+		},
+		"other-resource": {
+			gvr: client.StsGVR,
+			fqn: "prod/payments",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			var nn []string
+			for _, n := range tn.For(u.gvr, u.fqn) {
+				nn = append(nn, n.Note)
+			}
+			assert.Equal(t, u.e, nn)
+		})
+	}
+}
+
+func TestParseTeamNotesInvalid(t *testing.T) {
+	_, err := dao.ParseTeamNotes("notes: nope")
+	assert.Error(t, err)
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// ClockSkewTolerance represents the clock drift etcd starts complaining about.
+const ClockSkewTolerance = time.Second
+
+const nodeLeaseNamespace = "kube-node-lease"
+
+// NodeClock represents what is known about a node clock.
+type NodeClock struct {
+	Context, Node string
+	ControlPlane  bool
+	Heartbeat     time.Time
+	Probed        bool
+	Offset        time.Duration
+	ProbeErr      error
+}
+
+// FetchNodeClocks collects the kubelet heartbeats of all nodes in a context.
+// Heartbeats are stamped with the node clock, the lease renew time is used
+// when available since it is refreshed more often than node conditions.
+func FetchNodeClocks(rawCfg api.Config, ctxName string) ([]NodeClock, error) {
+	ctxs := []string{ctxName}
+	nn, err := MultiContextList(context.Background(), rawCfg, ctxs, client.NodeGVR.GVR(), client.BlankNamespace, "")
+	if err != nil {
+		return nil, err
+	}
+	ll, err := MultiContextList(context.Background(), rawCfg, ctxs, client.LeaseGVR.GVR(), nodeLeaseNamespace, "")
+	if err != nil {
+		return nil, err
+	}
+	renews := make(map[string]time.Time, len(ll))
+	for _, co := range ll {
+		u, ok := co.Object.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		if s, _, _ := unstructured.NestedString(u.Object, "spec", "renewTime"); s != "" {
+			if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+				renews[u.GetName()] = t
+			}
+		}
+	}
+
+	cc := make([]NodeClock, 0, len(nn))
+	for _, co := range nn {
+		var no v1.Node
+		if !fromContextObject(co, &no) {
+			continue
+		}
+		c := NodeClock{Context: ctxName, Node: no.Name, Heartbeat: renews[no.Name]}
+		_, c.ControlPlane = no.Labels["node-role.kubernetes.io/control-plane"]
+		if c.Heartbeat.IsZero() {
+			for _, cond := range no.Status.Conditions {
+				if cond.Type == v1.NodeReady {
+					c.Heartbeat = cond.LastHeartbeatTime.Time
+				}
+			}
+		}
+		cc = append(cc, c)
+	}
+	sort.Slice(cc, func(i, j int) bool {
+		return cc[i].Node < cc[j].Node
+	})
+
+	return cc, nil
+}
+
+// ProbeOffset computes a node clock offset from a `date +%s` probe run between
+// start and end on the local clock. Only drift outside that window can be
+// asserted, so the offset is a lower bound.
+func ProbeOffset(out string, start, end time.Time) (time.Duration, error) {
+	ff := strings.Fields(out)
+	if len(ff) == 0 {
+		return 0, fmt.Errorf("no probe output")
+	}
+	sec, err := strconv.ParseInt(ff[len(ff)-1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected probe output %q", out)
+	}
+	remote := time.Unix(sec, 0)
+	switch lo := start.Truncate(time.Second); {
+	case remote.Before(lo):
+		return remote.Sub(lo), nil
+	case remote.After(end):
+		return remote.Sub(end), nil
+	default:
+		return 0, nil
+	}
+}
+
+// ClockSkewIssues flags nodes whose clock drifts from the local clock or whose
+// heartbeat is stamped in the future.
+func ClockSkewIssues(cc []NodeClock, now time.Time) []string {
+	var ii []string
+	for _, c := range cc {
+		id := c.Context + "/" + c.Node
+		if c.ControlPlane {
+			id += " (control-plane)"
+		}
+		if ahead := c.Heartbeat.Sub(now); ahead > ClockSkewTolerance {
+			ii = append(ii, fmt.Sprintf("%s heartbeat stamped %s in the future, node clock is ahead", id, ahead.Round(time.Second)))
+		}
+		if c.Probed && c.ProbeErr == nil && c.Offset.Abs() > ClockSkewTolerance {
+			ii = append(ii, fmt.Sprintf("%s clock off by at least %s", id, c.Offset))
+		}
+	}
+
+	return ii
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeOffset(t *testing.T) {
+	start := time.Unix(1_700_000_000, 500_000_000)
+	end := start.Add(3 * time.Second)
+	stamp := func(d time.Duration) string {
+		return strconv.FormatInt(start.Add(d).Unix(), 10) + "\n"
+	}
+
+	uu := map[string]struct {
+		out string
+		e   time.Duration
+		err bool
+	}{
+		"in-window": {
+			out: stamp(time.Second),
+		},
+		"window-start": {
+			out: stamp(0),
+		},
+		"behind": {
+			out: stamp(-5 * time.Second),
+			e:   -5 * time.Second,
+		},
+		"ahead": {
+			out: stamp(10 * time.Second),
+			e:   6500 * time.Millisecond,
+		},
+		"empty": {
+			err: true,
+		},
+		"toast": {
+			out: "sh: date: not found",
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			off, err := dao.ProbeOffset(u.out, start, end)
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.e, off)
+		})
+	}
+}
+
+func TestClockSkewIssues(t *testing.T) {
+	now := time.Now()
+	cc := []dao.NodeClock{
+		{Context: "ct1", Node: "n1", Heartbeat: now.Add(-5 * time.Second), Probed: true},
+		{Context: "ct1", Node: "n2", ControlPlane: true, Heartbeat: now.Add(30 * time.Second), Probed: true, Offset: 30 * time.Second},
+		{Context: "ct2", Node: "n1", Heartbeat: now, Probed: true, Offset: -2 * time.Second},
+		{Context: "ct2", Node: "n2", Heartbeat: now, Probed: true, Offset: time.Minute, ProbeErr: errors.New("boom")},
+	}
+
+	assert.Equal(t, []string{
+		"ct1/n2 (control-plane) heartbeat stamped 30s in the future, node clock is ahead",
+		"ct1/n2 (control-plane) clock off by at least 30s",
+		"ct2/n1 clock off by at least -2s",
+	}, dao.ClockSkewIssues(cc, now))
+}
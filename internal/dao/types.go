@@ -86,6 +86,9 @@ type DrainOptions struct {
 	DeleteEmptyDirData  bool
 	Force               bool
 	DisableEviction     bool
+
+	// EvictLonghornReplicas moves Longhorn replicas off the node prior to draining.
+	EvictLonghornReplicas bool
 }
 
 // NodeMaintainer performs node maintenance operations.
@@ -0,0 +1,291 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/render"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// LonghornNamespace tracks the Longhorn system namespace.
+const LonghornNamespace = "longhorn-system"
+
+const (
+	longhornBackupScheme  = "bak://"
+	longhornSnapScheme    = "snap://"
+	snapshotAPIGroup      = "snapshot.storage.k8s.io"
+	defaultSnapClassAnnot = "snapshot.storage.kubernetes.io/is-default-class"
+)
+
+var _ Accessor = (*VolumeSnapshot)(nil)
+
+// VolumeSnapshot represents a CSI volume snapshot.
+type VolumeSnapshot struct {
+	Resource
+}
+
+// List returns a collection of volume snapshots along with their Longhorn
+// backup state when taken by the Longhorn CSI driver.
+func (v *VolumeSnapshot) List(ctx context.Context, ns string) ([]runtime.Object, error) {
+	oo, err := v.Resource.List(ctx, ns)
+	if err != nil {
+		return oo, err
+	}
+	handles := v.snapshotHandles()
+	backups := v.longhornBackups()
+
+	res := make([]runtime.Object, 0, len(oo))
+	for _, o := range oo {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			return res, fmt.Errorf("expecting *unstructured.Unstructured but got `%T", o)
+		}
+		content, _, _ := unstructured.NestedString(u.Object, "status", "boundVolumeSnapshotContentName")
+		res = append(res, &render.VolumeSnapshotWithBackup{
+			Raw:      u,
+			Longhorn: LonghornSnapshotState(handles[content], backups),
+		})
+	}
+
+	return res, nil
+}
+
+func (v *VolumeSnapshot) snapshotHandles() map[string]string {
+	oo, err := v.getFactory().List(client.VolumeSnapshotContentGVR, client.ClusterScope, false, labels.Everything())
+	if err != nil {
+		return nil
+	}
+	hh := make(map[string]string, len(oo))
+	for _, o := range oo {
+		if u, ok := o.(*unstructured.Unstructured); ok {
+			hh[u.GetName()], _, _ = unstructured.NestedString(u.Object, "status", "snapshotHandle")
+		}
+	}
+
+	return hh
+}
+
+// longhornBackups returns the Longhorn backups by name, if Longhorn is installed.
+func (v *VolumeSnapshot) longhornBackups() map[string]*unstructured.Unstructured {
+	if _, err := MetaAccess.MetaFor(client.LonghornBackupGVR); err != nil {
+		return nil
+	}
+	oo, err := v.getFactory().List(client.LonghornBackupGVR, LonghornNamespace, false, labels.Everything())
+	if err != nil {
+		return nil
+	}
+	bb := make(map[string]*unstructured.Unstructured, len(oo))
+	for _, o := range oo {
+		if u, ok := o.(*unstructured.Unstructured); ok {
+			bb[u.GetName()] = u
+		}
+	}
+
+	return bb
+}
+
+// LonghornSnapshotState returns the Longhorn state of a CSI snapshot handle,
+// ie `bak://pvc-xxx/backup-yyy`, or blank if Longhorn did not take it.
+func LonghornSnapshotState(handle string, backups map[string]*unstructured.Unstructured) string {
+	switch {
+	case strings.HasPrefix(handle, longhornSnapScheme):
+		return "snapshot"
+	case !strings.HasPrefix(handle, longhornBackupScheme):
+		return ""
+	}
+	_, name, ok := strings.Cut(strings.TrimPrefix(handle, longhornBackupScheme), "/")
+	if !ok || name == "" {
+		return ""
+	}
+	b, ok := backups[name]
+	if !ok {
+		return "backup missing"
+	}
+	state, _, _ := unstructured.NestedString(b.Object, "status", "state")
+	switch state {
+	case "":
+		return "backup Pending"
+	case "InProgress":
+		progress, _, _ := unstructured.NestedInt64(b.Object, "status", "progress")
+		return fmt.Sprintf("backup InProgress %d%%", progress)
+	case "Error":
+		if msg, _, _ := unstructured.NestedString(b.Object, "status", "error"); msg != "" {
+			return "backup Error: " + msg
+		}
+	}
+
+	return "backup " + state
+}
+
+// CreateVolumeSnapshot snapshots a PVC. The default snapshot class of the PVC
+// CSI driver is used when no class is given.
+func CreateVolumeSnapshot(ctx context.Context, c client.Connection, pvcPath, class, name string) error {
+	dial, err := c.Dial()
+	if err != nil {
+		return err
+	}
+	ns, n := client.Namespaced(pvcPath)
+	pvc, err := dial.CoreV1().PersistentVolumeClaims(ns).Get(ctx, n, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if class == "" {
+		if class, err = defaultSnapshotClass(ctx, c, pvc); err != nil {
+			return err
+		}
+	}
+
+	snap := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": client.VolumeSnapshotGVR.GV().String(),
+		"kind":       "VolumeSnapshot",
+		"metadata": map[string]any{
+			"namespace": ns,
+			"name":      name,
+		},
+		"spec": map[string]any{
+			"volumeSnapshotClassName": class,
+			"source": map[string]any{
+				"persistentVolumeClaimName": n,
+			},
+		},
+	}}
+	dyn, err := c.DynDial()
+	if err != nil {
+		return err
+	}
+	_, err = dyn.Resource(client.VolumeSnapshotGVR.GVR()).Namespace(ns).Create(ctx, &snap, metav1.CreateOptions{})
+
+	return err
+}
+
+func defaultSnapshotClass(ctx context.Context, c client.Connection, pvc *v1.PersistentVolumeClaim) (string, error) {
+	dial, err := c.Dial()
+	if err != nil {
+		return "", err
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return "", fmt.Errorf("pvc %s has no storage class, pick a snapshot class", pvc.Name)
+	}
+	sc, err := dial.StorageV1().StorageClasses().Get(ctx, *pvc.Spec.StorageClassName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	dyn, err := c.DynDial()
+	if err != nil {
+		return "", err
+	}
+	ll, err := dyn.Resource(client.VolumeSnapshotClassGVR.GVR()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return DefaultSnapshotClass(ll.Items, sc.Provisioner)
+}
+
+// DefaultSnapshotClass picks the snapshot class of a CSI driver, the one
+// flagged as default first.
+func DefaultSnapshotClass(cc []unstructured.Unstructured, driver string) (string, error) {
+	var first string
+	for i := range cc {
+		if d, _, _ := unstructured.NestedString(cc[i].Object, "driver"); d != driver {
+			continue
+		}
+		if cc[i].GetAnnotations()[defaultSnapClassAnnot] == "true" {
+			return cc[i].GetName(), nil
+		}
+		if first == "" {
+			first = cc[i].GetName()
+		}
+	}
+	if first == "" {
+		return "", fmt.Errorf("no snapshot class found for driver %s", driver)
+	}
+
+	return first, nil
+}
+
+// RestoreVolumeSnapshot creates a new PVC from a volume snapshot. The PVC
+// mirrors the snapshot source PVC when it still exists.
+func RestoreVolumeSnapshot(ctx context.Context, c client.Connection, snapPath, pvcName string) error {
+	dyn, err := c.DynDial()
+	if err != nil {
+		return err
+	}
+	ns, n := client.Namespaced(snapPath)
+	snap, err := dyn.Resource(client.VolumeSnapshotGVR.GVR()).Namespace(ns).Get(ctx, n, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if ready, _, _ := unstructured.NestedBool(snap.Object, "status", "readyToUse"); !ready {
+		return fmt.Errorf("volume snapshot %s is not ready to use", snapPath)
+	}
+	size, _, _ := unstructured.NestedString(snap.Object, "status", "restoreSize")
+	src, _, _ := unstructured.NestedString(snap.Object, "spec", "source", "persistentVolumeClaimName")
+
+	dial, err := c.Dial()
+	if err != nil {
+		return err
+	}
+	var source *v1.PersistentVolumeClaim
+	if src != "" {
+		source, _ = dial.CoreV1().PersistentVolumeClaims(ns).Get(ctx, src, metav1.GetOptions{})
+	}
+	pvc, err := RestoredPVC(ns, pvcName, n, size, source)
+	if err != nil {
+		return err
+	}
+	_, err = dial.CoreV1().PersistentVolumeClaims(ns).Create(ctx, pvc, metav1.CreateOptions{})
+
+	return err
+}
+
+// RestoredPVC returns a PVC restoring a volume snapshot, mirroring the
+// storage class, access and volume modes of the snapshot source if known.
+func RestoredPVC(ns, name, snap, size string, source *v1.PersistentVolumeClaim) (*v1.PersistentVolumeClaim, error) {
+	if size == "" && source != nil {
+		if q, ok := source.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+			size = q.String()
+		}
+	}
+	if size == "" {
+		return nil, errors.New("unable to figure out the restore size")
+	}
+	q, err := resource.ParseQuantity(size)
+	if err != nil {
+		return nil, err
+	}
+	group := snapshotAPIGroup
+	pvc := v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			DataSource: &v1.TypedLocalObjectReference{
+				APIGroup: &group,
+				Kind:     "VolumeSnapshot",
+				Name:     snap,
+			},
+			Resources: v1.VolumeResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: q},
+			},
+		},
+	}
+	if source != nil {
+		pvc.Spec.AccessModes = source.Spec.AccessModes
+		pvc.Spec.StorageClassName = source.Spec.StorageClassName
+		pvc.Spec.VolumeMode = source.Spec.VolumeMode
+	}
+
+	return &pvc, nil
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestLonghornSnapshotState(t *testing.T) {
+	backup := func(status map[string]any) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{"status": status}}
+	}
+	bb := map[string]*unstructured.Unstructured{
+		"b1": backup(map[string]any{"state": "Completed"}),
+		"b2": backup(map[string]any{"state": "InProgress", "progress": int64(45)}),
+		"b3": backup(map[string]any{"state": "Error", "error": "target unreachable"}),
+		"b4": backup(map[string]any{}),
+	}
+
+	uu := map[string]struct {
+		handle, e string
+	}{
+		"not-longhorn": {handle: "snap-0123", e: ""},
+		"snapshot":     {handle: "snap://pvc-1/snapshot-1", e: "snapshot"},
+		"completed":    {handle: "bak://pvc-1/b1", e: "backup Completed"},
+		"in-progress":  {handle: "bak://pvc-1/b2", e: "backup InProgress 45%"},
+		"error":        {handle: "bak://pvc-1/b3", e: "backup Error: target unreachable"},
+		"pending":      {handle: "bak://pvc-1/b4", e: "backup Pending"},
+		"missing":      {handle: "bak://pvc-1/b5", e: "backup missing"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, dao.LonghornSnapshotState(u.handle, bb))
+		})
+	}
+}
+
+func TestDefaultSnapshotClass(t *testing.T) {
+	class := func(name, driver string, def bool) unstructured.Unstructured {
+		u := unstructured.Unstructured{Object: map[string]any{"driver": driver}}
+		u.SetName(name)
+		if def {
+			u.SetAnnotations(map[string]string{"snapshot.storage.kubernetes.io/is-default-class": "true"})
+		}
+		return u
+	}
+	cc := []unstructured.Unstructured{
+		class("ebs", "ebs.csi.aws.com", true),
+		class("longhorn-snap", "driver.longhorn.io", false),
+		class("longhorn-backup", "driver.longhorn.io", true),
+	}
+
+	n, err := dao.DefaultSnapshotClass(cc, "driver.longhorn.io")
+	require.NoError(t, err)
+	assert.Equal(t, "longhorn-backup", n)
+
+	n, err = dao.DefaultSnapshotClass(cc[:2], "driver.longhorn.io")
+	require.NoError(t, err)
+	assert.Equal(t, "longhorn-snap", n)
+
+	_, err = dao.DefaultSnapshotClass(cc, "rancher.io/local-path")
+	require.Error(t, err)
+}
+
+func TestRestoredPVC(t *testing.T) {
+	sc, block := "longhorn", v1.PersistentVolumeBlock
+	source := v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "data"},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteMany},
+			StorageClassName: &sc,
+			VolumeMode:       &block,
+			Resources: v1.VolumeResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+
+	pvc, err := dao.RestoredPVC("ns1", "data-restore", "snap1", "", &source)
+	require.NoError(t, err)
+	assert.Equal(t, "snap1", pvc.Spec.DataSource.Name)
+	assert.Equal(t, "VolumeSnapshot", pvc.Spec.DataSource.Kind)
+	assert.Equal(t, []v1.PersistentVolumeAccessMode{v1.ReadWriteMany}, pvc.Spec.AccessModes)
+	assert.Equal(t, &sc, pvc.Spec.StorageClassName)
+	q := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+	assert.Equal(t, "1Gi", q.String())
+
+	pvc, err = dao.RestoredPVC("ns1", "data-restore", "snap1", "2Gi", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, pvc.Spec.AccessModes)
+	assert.Nil(t, pvc.Spec.StorageClassName)
+
+	_, err = dao.RestoredPVC("ns1", "data-restore", "snap1", "", nil)
+	require.Error(t, err)
+}
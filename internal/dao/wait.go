@@ -0,0 +1,352 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/util/jsonpath"
+	"k8s.io/kubectl/pkg/cmd/get"
+)
+
+const (
+	// DefaultWaitTimeout tracks how long to wait when no timeout is given.
+	DefaultWaitTimeout = 30 * time.Second
+
+	// WaitPollInterval tracks how often a waited resource is checked.
+	WaitPollInterval = time.Second
+
+	// WaitCondition waits for a status condition.
+	WaitCondition = "condition"
+
+	// WaitJSONPath waits for a field value.
+	WaitJSONPath = "jsonpath"
+
+	// WaitDelete waits for a resource deletion.
+	WaitDelete = "delete"
+
+	waitForFlag     = "--for="
+	waitTimeoutFlag = "--timeout="
+	waitNSFlag      = "-n"
+	waitNSLongFlag  = "--namespace="
+)
+
+// WaitFor represents a condition to wait for, ie `condition=Ready`,
+// `condition=Available=False`, `jsonpath={.status.phase}=Running` or
+// `delete`.
+type WaitFor struct {
+	Kind  string
+	Path  string
+	Value string
+}
+
+// ParseWaitFor parses a kubectl wait `--for` expression.
+func ParseWaitFor(s string) (WaitFor, error) {
+	s = strings.TrimSpace(s)
+	if s == WaitDelete {
+		return WaitFor{Kind: WaitDelete}, nil
+	}
+	kind, spec, ok := strings.Cut(s, "=")
+	if !ok || spec == "" {
+		return WaitFor{}, fmt.Errorf("invalid wait condition %q", s)
+	}
+	switch strings.ToLower(kind) {
+	case WaitCondition:
+		t, v, ok := strings.Cut(spec, "=")
+		if !ok {
+			v = string(metav1.ConditionTrue)
+		}
+		return WaitFor{Kind: WaitCondition, Path: t, Value: v}, nil
+	case WaitJSONPath:
+		p, v := splitWaitJSONPath(unquote(spec))
+		if p == "" {
+			return WaitFor{}, fmt.Errorf("invalid wait jsonpath %q", spec)
+		}
+		if _, err := get.RelaxedJSONPathExpression(p); err != nil {
+			return WaitFor{}, fmt.Errorf("invalid wait jsonpath %q: %w", p, err)
+		}
+		return WaitFor{Kind: WaitJSONPath, Path: p, Value: v}, nil
+	default:
+		return WaitFor{}, fmt.Errorf("unsupported wait condition %q, use condition=, jsonpath= or delete", s)
+	}
+}
+
+// splitWaitJSONPath splits a jsonpath wait spec into its path and value,
+// either of them possibly quoted, ie `'{.status.phase}'=Running`.
+func splitWaitJSONPath(spec string) (string, string) {
+	if spec != "" && (spec[0] == '\'' || spec[0] == '"') {
+		if i := strings.IndexByte(spec[1:], spec[0]); i >= 0 {
+			return spec[1 : i+1], unquote(strings.TrimPrefix(spec[i+2:], "="))
+		}
+	}
+	p, v := spec, ""
+	if i := strings.LastIndex(spec, "}"); i >= 0 {
+		p, v = spec[:i+1], strings.TrimPrefix(spec[i+1:], "=")
+	} else if i := strings.LastIndex(spec, "="); i >= 0 {
+		p, v = spec[:i], spec[i+1:]
+	}
+
+	return p, unquote(v)
+}
+
+// unquote trims a matching pair of outer quotes.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
+
+// String returns the `--for` expression.
+func (w WaitFor) String() string {
+	switch w.Kind {
+	case WaitDelete:
+		return WaitDelete
+	case WaitJSONPath:
+		if w.Value == "" {
+			return WaitJSONPath + "=" + w.Path
+		}
+		return WaitJSONPath + "=" + w.Path + "=" + w.Value
+	default:
+		return WaitCondition + "=" + w.Path + "=" + w.Value
+	}
+}
+
+// Met checks if a resource satisfies the condition and returns its
+// observed state. A nil resource has been deleted.
+func (w WaitFor) Met(u *unstructured.Unstructured) (bool, string, error) {
+	if u == nil {
+		return w.Kind == WaitDelete, "not found", nil
+	}
+	switch w.Kind {
+	case WaitDelete:
+		return false, "present", nil
+	case WaitJSONPath:
+		return w.jsonPathMet(u)
+	default:
+		return w.conditionMet(u)
+	}
+}
+
+func (w WaitFor) conditionMet(u *unstructured.Unstructured) (bool, string, error) {
+	cc, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range cc {
+		m, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		t, _ := m["type"].(string)
+		if !strings.EqualFold(t, w.Path) {
+			continue
+		}
+		s, _ := m["status"].(string)
+		state := t + "=" + s
+		if g, ok, _ := unstructured.NestedInt64(m, "observedGeneration"); ok && g < u.GetGeneration() {
+			return false, state + " (stale)", nil
+		}
+		return strings.EqualFold(s, w.Value), state, nil
+	}
+
+	return false, w.Path + " not reported", nil
+}
+
+func (w WaitFor) jsonPathMet(u *unstructured.Unstructured) (bool, string, error) {
+	spec, err := get.RelaxedJSONPathExpression(w.Path)
+	if err != nil {
+		return false, "", err
+	}
+	jp := jsonpath.New("wait").AllowMissingKeys(true)
+	if err := jp.Parse(spec); err != nil {
+		return false, "", err
+	}
+	rr, err := jp.FindResults(u.Object)
+	if err != nil {
+		return false, "", err
+	}
+	if len(rr) == 0 || len(rr[0]) == 0 {
+		return false, "<none>", nil
+	}
+	v := fmt.Sprint(rr[0][0].Interface())
+	if w.Value == "" {
+		return true, v, nil
+	}
+
+	return v == w.Value, v, nil
+}
+
+// WaitSpec represents a wait request on a resource.
+type WaitSpec struct {
+	Resource  string
+	Name      string
+	Namespace string
+	For       WaitFor
+	Timeout   time.Duration
+}
+
+// ParseWait parses `<resource>/<name> [-n <namespace>] --for=<condition>
+// [--timeout=<duration>]`.
+func ParseWait(args string) (WaitSpec, error) {
+	var (
+		spec  WaitSpec
+		flags []string
+	)
+	ff := strings.Fields(args)
+	for i := 0; i < len(ff); i++ {
+		switch a := ff[i]; {
+		case a == waitNSFlag:
+			if i++; i < len(ff) {
+				spec.Namespace = ff[i]
+			}
+		case strings.HasPrefix(a, waitNSLongFlag):
+			spec.Namespace = strings.TrimPrefix(a, waitNSLongFlag)
+		case strings.HasPrefix(a, "-"):
+			flags = append(flags, a)
+		case spec.Resource == "":
+			r, n, ok := strings.Cut(a, "/")
+			if !ok || r == "" || n == "" {
+				return WaitSpec{}, fmt.Errorf("invalid wait target %q, use <resource>/<name>", a)
+			}
+			spec.Resource, spec.Name = r, n
+		default:
+			return WaitSpec{}, fmt.Errorf("unexpected wait argument %q", a)
+		}
+	}
+	if spec.Resource == "" {
+		return WaitSpec{}, errors.New("missing wait target, use <resource>/<name>")
+	}
+	var err error
+	spec.For, spec.Timeout, err = ParseWaitFlags(strings.Join(flags, " "))
+
+	return spec, err
+}
+
+// ParseWaitFlags parses the `--for` and `--timeout` wait flags.
+func ParseWaitFlags(s string) (WaitFor, time.Duration, error) {
+	var (
+		w       WaitFor
+		timeout = DefaultWaitTimeout
+		err     error
+	)
+	for _, f := range strings.Fields(s) {
+		switch {
+		case strings.HasPrefix(f, waitForFlag):
+			if w, err = ParseWaitFor(strings.TrimPrefix(f, waitForFlag)); err != nil {
+				return WaitFor{}, 0, err
+			}
+		case strings.HasPrefix(f, waitTimeoutFlag):
+			if timeout, err = time.ParseDuration(strings.TrimPrefix(f, waitTimeoutFlag)); err != nil || timeout <= 0 {
+				return WaitFor{}, 0, fmt.Errorf("invalid wait timeout %q", f)
+			}
+		default:
+			return WaitFor{}, 0, fmt.Errorf("unsupported wait flag %q", f)
+		}
+	}
+	if w.Kind == "" {
+		return WaitFor{}, 0, errors.New("missing wait condition, use --for=condition=<type>, --for=jsonpath=<path>=<value> or --for=delete")
+	}
+
+	return w, timeout, nil
+}
+
+// Wait polls a resource until it satisfies a condition, reporting its
+// observed state on each check. Missing resources are waited for unless
+// waiting for a deletion. Transient API errors are retried until timeout.
+func Wait(ctx context.Context, dyn dynamic.Interface, gvr schema.GroupVersionResource, ns, name string, w WaitFor, timeout time.Duration, progress func(string)) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var (
+		state   string
+		lastErr error
+	)
+	for {
+		u, err := dyn.Resource(gvr).Namespace(ns).Get(ctx, name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			u, err = nil, nil
+		}
+		switch {
+		case err == nil:
+			lastErr = nil
+		case ctx.Err() != nil:
+		case isRetryable(err):
+			lastErr = err
+		default:
+			return err
+		}
+		if err == nil {
+			ok, s, err := w.Met(u)
+			if err != nil {
+				return err
+			}
+			if ok {
+				return nil
+			}
+			state = s
+			if progress != nil {
+				progress(state)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return ctx.Err()
+			}
+			if lastErr != nil {
+				return fmt.Errorf("timed out after %s waiting for %s (%s), last error: %w", timeout, w, state, lastErr)
+			}
+			return fmt.Errorf("timed out after %s waiting for %s (%s)", timeout, w, state)
+		case <-time.After(WaitPollInterval):
+		}
+	}
+}
+
+// isRetryable checks if an API error is worth retrying, ie conflicts, server
+// side errors, timeouts and network errors while the API server restarts.
+func isRetryable(err error) bool {
+	if kerrors.IsConflict(err) || kerrors.IsTimeout(err) || kerrors.IsServerTimeout(err) || kerrors.IsTooManyRequests(err) {
+		return true
+	}
+	var st kerrors.APIStatus
+	if errors.As(err, &st) {
+		return st.Status().Code >= 500
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	if utilnet.IsConnectionRefused(err) || utilnet.IsConnectionReset(err) || utilnet.IsProbableEOF(err) || utilnet.IsHTTP2ConnectionLost(err) {
+		return true
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+	var oe *net.OpError
+
+	return errors.As(err, &oe)
+}
+
+// WaitInContext waits for a resource of a given kubeconfig context to
+// satisfy a condition.
+func WaitInContext(ctx context.Context, rawCfg api.Config, ctxName string, gvr schema.GroupVersionResource, ns, name string, w WaitFor, timeout time.Duration) error {
+	dyn, err := dynClientFor(rawCfg, ctxName)
+	if err != nil {
+		return err
+	}
+
+	return Wait(ctx, dyn, gvr, ns, name, w, timeout, nil)
+}
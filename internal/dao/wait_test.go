@@ -0,0 +1,343 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func TestParseWait(t *testing.T) {
+	uu := map[string]struct {
+		args string
+		e    dao.WaitSpec
+		err  string
+	}{
+		"condition": {
+			args: "deploy/web -n shop --for=condition=Available --timeout=5m",
+			e: dao.WaitSpec{
+				Resource:  "deploy",
+				Name:      "web",
+				Namespace: "shop",
+				For:       dao.WaitFor{Kind: dao.WaitCondition, Path: "Available", Value: "True"},
+				Timeout:   5 * time.Minute,
+			},
+		},
+		"condition-status": {
+			args: "po/web-1 --for=condition=Ready=False",
+			e: dao.WaitSpec{
+				Resource: "po",
+				Name:     "web-1",
+				For:      dao.WaitFor{Kind: dao.WaitCondition, Path: "Ready", Value: "False"},
+				Timeout:  dao.DefaultWaitTimeout,
+			},
+		},
+		"jsonpath": {
+			args: "--namespace=shop po/web-1 --for=jsonpath='{.status.phase}'=Running",
+			e: dao.WaitSpec{
+				Resource:  "po",
+				Name:      "web-1",
+				Namespace: "shop",
+				For:       dao.WaitFor{Kind: dao.WaitJSONPath, Path: "{.status.phase}", Value: "Running"},
+				Timeout:   dao.DefaultWaitTimeout,
+			},
+		},
+		"jsonpath-quoted": {
+			args: "po/web-1 --for=jsonpath='{.status.phase}=Running'",
+			e: dao.WaitSpec{
+				Resource: "po",
+				Name:     "web-1",
+				For:      dao.WaitFor{Kind: dao.WaitJSONPath, Path: "{.status.phase}", Value: "Running"},
+				Timeout:  dao.DefaultWaitTimeout,
+			},
+		},
+		"jsonpath-quoted-value": {
+			args: `po/web-1 --for=jsonpath={.metadata.labels.owner}="o'neil"`,
+			e: dao.WaitSpec{
+				Resource: "po",
+				Name:     "web-1",
+				For:      dao.WaitFor{Kind: dao.WaitJSONPath, Path: "{.metadata.labels.owner}", Value: "o'neil"},
+				Timeout:  dao.DefaultWaitTimeout,
+			},
+		},
+		"jsonpath-apostrophe": {
+			args: "po/web-1 --for=jsonpath={.metadata.labels.owner}=o'neil",
+			e: dao.WaitSpec{
+				Resource: "po",
+				Name:     "web-1",
+				For:      dao.WaitFor{Kind: dao.WaitJSONPath, Path: "{.metadata.labels.owner}", Value: "o'neil"},
+				Timeout:  dao.DefaultWaitTimeout,
+			},
+		},
+		"jsonpath-relaxed": {
+			args: "po/web-1 --for=jsonpath=.status.phase=Running",
+			e: dao.WaitSpec{
+				Resource: "po",
+				Name:     "web-1",
+				For:      dao.WaitFor{Kind: dao.WaitJSONPath, Path: ".status.phase", Value: "Running"},
+				Timeout:  dao.DefaultWaitTimeout,
+			},
+		},
+		"delete": {
+			args: "job/migrate --for=delete --timeout=1m",
+			e: dao.WaitSpec{
+				Resource: "job",
+				Name:     "migrate",
+				For:      dao.WaitFor{Kind: dao.WaitDelete},
+				Timeout:  time.Minute,
+			},
+		},
+		"no-target": {
+			args: "--for=delete",
+			err:  "missing wait target, use <resource>/<name>",
+		},
+		"bad-target": {
+			args: "deploy --for=delete",
+			err:  `invalid wait target "deploy", use <resource>/<name>`,
+		},
+		"no-condition": {
+			args: "deploy/web",
+			err:  "missing wait condition, use --for=condition=<type>, --for=jsonpath=<path>=<value> or --for=delete",
+		},
+		"bad-condition": {
+			args: "deploy/web --for=ready",
+			err:  `invalid wait condition "ready"`,
+		},
+		"bad-jsonpath": {
+			args: "po/web-1 --for=jsonpath=''=Running",
+			err:  `invalid wait jsonpath "''=Running"`,
+		},
+		"bad-timeout": {
+			args: "deploy/web --for=delete --timeout=soon",
+			err:  `invalid wait timeout "--timeout=soon"`,
+		},
+		"bad-flag": {
+			args: "deploy/web --for=delete --all",
+			err:  `unsupported wait flag "--all"`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			spec, err := dao.ParseWait(u.args)
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.e, spec)
+		})
+	}
+}
+
+func TestWaitForMet(t *testing.T) {
+	po := makeWaitPod("Running", "True", 2, 2)
+	uu := map[string]struct {
+		w     string
+		u     *unstructured.Unstructured
+		ok    bool
+		state string
+	}{
+		"condition": {
+			w:     "condition=Ready",
+			u:     po,
+			ok:    true,
+			state: "Ready=True",
+		},
+		"condition-case": {
+			w:     "condition=ready=true",
+			u:     po,
+			ok:    true,
+			state: "Ready=True",
+		},
+		"condition-unmet": {
+			w:     "condition=Ready",
+			u:     makeWaitPod("Pending", "False", 1, 1),
+			state: "Ready=False",
+		},
+		"condition-stale": {
+			w:     "condition=Ready",
+			u:     makeWaitPod("Running", "True", 2, 1),
+			state: "Ready=True (stale)",
+		},
+		"condition-missing": {
+			w:     "condition=Complete",
+			u:     po,
+			state: "Complete not reported",
+		},
+		"jsonpath": {
+			w:     "jsonpath={.status.phase}=Running",
+			u:     po,
+			ok:    true,
+			state: "Running",
+		},
+		"jsonpath-unmet": {
+			w:     "jsonpath={.status.phase}=Succeeded",
+			u:     po,
+			state: "Running",
+		},
+		"jsonpath-exists": {
+			w:     "jsonpath={.status.phase}",
+			u:     po,
+			ok:    true,
+			state: "Running",
+		},
+		"jsonpath-missing": {
+			w:     "jsonpath={.status.podIP}",
+			u:     po,
+			state: "<none>",
+		},
+		"delete-present": {
+			w:     "delete",
+			u:     po,
+			state: "present",
+		},
+		"delete-gone": {
+			w:     "delete",
+			ok:    true,
+			state: "not found",
+		},
+		"condition-gone": {
+			w:     "condition=Ready",
+			state: "not found",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			w, err := dao.ParseWaitFor(u.w)
+			require.NoError(t, err)
+			ok, state, err := w.Met(u.u)
+			require.NoError(t, err)
+			assert.Equal(t, u.ok, ok)
+			assert.Equal(t, u.state, state)
+		})
+	}
+}
+
+func TestWait(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	dyn := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "PodList"},
+		makeWaitPod("Running", "True", 1, 1),
+	)
+	ctx := context.Background()
+
+	w, err := dao.ParseWaitFor("condition=Ready")
+	require.NoError(t, err)
+	require.NoError(t, dao.Wait(ctx, dyn, gvr, "default", "web", w, time.Second, nil))
+
+	w, err = dao.ParseWaitFor("jsonpath={.status.phase}=Succeeded")
+	require.NoError(t, err)
+	var states []string
+	err = dao.Wait(ctx, dyn, gvr, "default", "web", w, 10*time.Millisecond, func(s string) {
+		states = append(states, s)
+	})
+	assert.EqualError(t, err, "timed out after 10ms waiting for jsonpath={.status.phase}=Succeeded (Running)")
+	assert.Equal(t, []string{"Running"}, states)
+
+	w, err = dao.ParseWaitFor("delete")
+	require.NoError(t, err)
+	require.NoError(t, dao.Wait(ctx, dyn, gvr, "default", "gone", w, time.Second, nil))
+}
+
+func TestWaitRetries(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	uu := map[string]struct {
+		errs    []error
+		timeout time.Duration
+		err     string
+	}{
+		"retried": {
+			errs:    []error{kerrors.NewInternalError(errors.New("etcd hiccup"))},
+			timeout: 5 * time.Second,
+		},
+		"timed-out": {
+			errs: []error{
+				kerrors.NewServiceUnavailable("apiserver restarting"),
+				kerrors.NewServiceUnavailable("apiserver restarting"),
+			},
+			timeout: 10 * time.Millisecond,
+			err:     "timed out after 10ms waiting for condition=Ready=True (), last error: apiserver restarting",
+		},
+		"connection-refused": {
+			errs:    []error{&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}},
+			timeout: 5 * time.Second,
+		},
+		"eof": {
+			errs:    []error{fmt.Errorf("get pods: %w", io.ErrUnexpectedEOF)},
+			timeout: 5 * time.Second,
+		},
+		"fatal": {
+			errs:    []error{kerrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "web", errors.New("rbac"))},
+			timeout: 5 * time.Second,
+			err:     `pods "web" is forbidden: rbac`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			dyn := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{gvr: "PodList"},
+				makeWaitPod("Running", "True", 1, 1),
+			)
+			errs := u.errs
+			dyn.PrependReactor("get", "pods", func(ktesting.Action) (bool, runtime.Object, error) {
+				if len(errs) == 0 {
+					return false, nil, nil
+				}
+				err := errs[0]
+				errs = errs[1:]
+				return true, nil, err
+			})
+			w, err := dao.ParseWaitFor("condition=Ready")
+			require.NoError(t, err)
+			err = dao.Wait(context.Background(), dyn, gvr, "default", "web", w, u.timeout, nil)
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// Helpers...
+
+func makeWaitPod(phase, ready string, gen, observed int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]any{
+			"namespace":  "default",
+			"name":       "web",
+			"generation": gen,
+		},
+		"status": map[string]any{
+			"phase": phase,
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": ready, "observedGeneration": observed},
+			},
+		},
+	}}
+}
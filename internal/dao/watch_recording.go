@@ -0,0 +1,306 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/redact"
+	"github.com/derailed/k9s/internal/slogs"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	maxRecordingLine = 16 * 1024 * 1024
+
+	// recordRewatchDelay tracks the initial delay before re-establishing a
+	// closed recording watch.
+	recordRewatchDelay = time.Second
+
+	// recordRewatchMaxDelay caps the delay between re-watch attempts.
+	recordRewatchMaxDelay = 30 * time.Second
+)
+
+// WatchEvent represents a recorded watch event.
+type WatchEvent struct {
+	At     time.Time                  `json:"at"`
+	Type   watch.EventType            `json:"type"`
+	GVR    string                     `json:"gvr"`
+	Object *unstructured.Unstructured `json:"object"`
+}
+
+// Path returns the event object path.
+func (e WatchEvent) Path() string {
+	return client.FQN(e.Object.GetNamespace(), e.Object.GetName())
+}
+
+// Subject returns the event object path, or the error message of an Error
+// event.
+func (e WatchEvent) Subject() string {
+	if e.Type != watch.Error {
+		return e.Path()
+	}
+	msg, _, _ := unstructured.NestedString(e.Object.Object, "message")
+
+	return msg
+}
+
+// RecordWatch writes the watch events of the given resources, one JSON event
+// per line, until the context is canceled. Watches closed by the server are
+// re-established from the last seen resource version and watch errors are
+// recorded as Error events. Secrets and sensitive values are always
+// redacted, using the given redactor allowlists when set.
+func RecordWatch(ctx context.Context, dial dynamic.Interface, gvrs []*client.GVR, ns string, r *redact.Redactor, w io.Writer) error {
+	if client.IsAllNamespaces(ns) {
+		ns = client.BlankNamespace
+	}
+	if r == nil {
+		r = redact.New(nil, nil)
+	}
+	var (
+		mx   sync.Mutex
+		enc  = json.NewEncoder(w)
+		wg   sync.WaitGroup
+		errs error
+	)
+	emit := func(e WatchEvent) error {
+		mx.Lock()
+		defer mx.Unlock()
+
+		return enc.Encode(e)
+	}
+	for _, gvr := range gvrs {
+		ri := dial.Resource(gvr.GVR()).Namespace(ns)
+		wi, err := ri.Watch(ctx, metav1.ListOptions{AllowWatchBookmarks: true})
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("watch %s: %w", gvr, err))
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recordResource(ctx, ri, wi, gvr, r, emit)
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// recordResource records the events of a resource watch, re-establishing it
+// with a growing delay until the context is canceled.
+func recordResource(ctx context.Context, ri dynamic.ResourceInterface, wi watch.Interface, gvr *client.GVR, r *redact.Redactor, emit func(WatchEvent) error) {
+	var (
+		rv    string
+		delay = recordRewatchDelay
+	)
+	for {
+		var (
+			prev = rv
+			err  error
+		)
+		rv, err = recordEvents(ctx, wi, gvr, rv, r, emit)
+		if err != nil || ctx.Err() != nil {
+			return
+		}
+		if rv != prev {
+			delay = recordRewatchDelay
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			delay = min(2*delay, recordRewatchMaxDelay)
+			wi, err = ri.Watch(ctx, metav1.ListOptions{ResourceVersion: rv, AllowWatchBookmarks: true})
+			if err == nil {
+				break
+			}
+			if kerrors.IsResourceExpired(err) || kerrors.IsGone(err) {
+				rv = ""
+			}
+			slog.Warn("Watch recording re-watch failed", slogs.GVR, gvr, slogs.Error, err)
+			if err := emit(watchErrorEvent(gvr, err)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// recordEvents records the events of a watch until it closes and returns the
+// resource version to resume from, blank when it expired.
+func recordEvents(ctx context.Context, wi watch.Interface, gvr *client.GVR, rv string, r *redact.Redactor, emit func(WatchEvent) error) (string, error) {
+	defer wi.Stop()
+
+	for {
+		var (
+			evt watch.Event
+			ok  bool
+		)
+		select {
+		case <-ctx.Done():
+			return rv, nil
+		case evt, ok = <-wi.ResultChan():
+		}
+		if !ok {
+			return rv, nil
+		}
+		if evt.Type == watch.Error {
+			err := kerrors.FromObject(evt.Object)
+			if kerrors.IsResourceExpired(err) || kerrors.IsGone(err) {
+				rv = ""
+			}
+			if err := emit(watchErrorEvent(gvr, err)); err != nil {
+				return rv, err
+			}
+			continue
+		}
+		u, ok := evt.Object.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		if v := u.GetResourceVersion(); v != "" {
+			rv = v
+		}
+		if evt.Type == watch.Bookmark {
+			continue
+		}
+		o, err := redactObject(r, u)
+		if err != nil {
+			slog.Warn("Watch event redaction failed, skipping", slogs.GVR, gvr, slogs.Error, err)
+			continue
+		}
+		if err := emit(WatchEvent{At: time.Now(), Type: evt.Type, GVR: gvr.String(), Object: o}); err != nil {
+			return rv, err
+		}
+	}
+}
+
+// watchErrorEvent returns an Error event holding the status of a watch error.
+func watchErrorEvent(gvr *client.GVR, err error) WatchEvent {
+	st := metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+	var se kerrors.APIStatus
+	if errors.As(err, &se) {
+		st = se.Status()
+	}
+	st.Kind, st.APIVersion = "Status", "v1"
+	o, cerr := runtime.DefaultUnstructuredConverter.ToUnstructured(&st)
+	if cerr != nil {
+		o = map[string]any{"kind": "Status", "apiVersion": "v1", "message": err.Error()}
+	}
+
+	return WatchEvent{At: time.Now(), Type: watch.Error, GVR: gvr.String(), Object: &unstructured.Unstructured{Object: o}}
+}
+
+// redactObject masks the Secret data and sensitive values of a resource.
+func redactObject(r *redact.Redactor, o *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	raw, err := yaml.Marshal(o.Object)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := yaml.Unmarshal([]byte(r.YAML(string(raw))), &m); err != nil {
+		return nil, err
+	}
+
+	return &unstructured.Unstructured{Object: m}, nil
+}
+
+// LoadRecording reads recorded watch events in time order.
+func LoadRecording(r io.Reader) ([]WatchEvent, error) {
+	var ee []WatchEvent
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), maxRecordingLine)
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var e WatchEvent
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("invalid recording line %d: %w", line, err)
+		}
+		if e.Object == nil {
+			return nil, fmt.Errorf("invalid recording line %d: missing object", line)
+		}
+		ee = append(ee, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(ee, func(i, j int) bool {
+		return ee[i].At.Before(ee[j].At)
+	})
+
+	return ee, nil
+}
+
+// ReplayState tracks the resources as of the last replayed event.
+type ReplayState struct {
+	objects map[string]map[string]*unstructured.Unstructured
+}
+
+// NewReplayState returns a new replay state.
+func NewReplayState() *ReplayState {
+	return &ReplayState{objects: make(map[string]map[string]*unstructured.Unstructured)}
+}
+
+// Apply applies a watch event.
+func (s *ReplayState) Apply(e WatchEvent) {
+	oo, ok := s.objects[e.GVR]
+	if !ok {
+		oo = make(map[string]*unstructured.Unstructured)
+		s.objects[e.GVR] = oo
+	}
+	switch e.Type {
+	case watch.Deleted:
+		delete(oo, e.Path())
+	case watch.Added, watch.Modified:
+		oo[e.Path()] = e.Object
+	}
+}
+
+// Lines returns the resources by GVR with their phase if any.
+func (s *ReplayState) Lines() []string {
+	gg := make([]string, 0, len(s.objects))
+	for g := range s.objects {
+		gg = append(gg, g)
+	}
+	sort.Strings(gg)
+
+	var ll []string
+	for _, g := range gg {
+		pp := make([]string, 0, len(s.objects[g]))
+		for p := range s.objects[g] {
+			pp = append(pp, p)
+		}
+		sort.Strings(pp)
+		ll = append(ll, fmt.Sprintf("%s (%d)", g, len(pp)))
+		for _, p := range pp {
+			l := "  " + p
+			if phase, ok, _ := unstructured.NestedString(s.objects[g][p].Object, "status", "phase"); ok {
+				l += " " + phase
+			}
+			ll = append(ll, l)
+		}
+	}
+
+	return ll
+}
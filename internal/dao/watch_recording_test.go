@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+const recording = `{"at":"2026-01-02T10:00:02Z","type":"MODIFIED","gvr":"v1/pods","object":{"apiVersion":"v1","kind":"Pod","metadata":{"name":"p1","namespace":"ns1"},"status":{"phase":"Running"}}}
+{"at":"2026-01-02T10:00:00Z","type":"ADDED","gvr":"v1/pods","object":{"apiVersion":"v1","kind":"Pod","metadata":{"name":"p1","namespace":"ns1"},"status":{"phase":"Pending"}}}
+{"at":"2026-01-02T10:00:01Z","type":"ADDED","gvr":"v1/pods","object":{"apiVersion":"v1","kind":"Pod","metadata":{"name":"p2","namespace":"ns1"},"status":{"phase":"Pending"}}}
+
+{"at":"2026-01-02T10:00:03Z","type":"DELETED","gvr":"v1/pods","object":{"apiVersion":"v1","kind":"Pod","metadata":{"name":"p2","namespace":"ns1"}}}
+`
+
+func TestReplay(t *testing.T) {
+	ee, err := dao.LoadRecording(strings.NewReader(recording))
+	require.NoError(t, err)
+	require.Len(t, ee, 4)
+	assert.Equal(t, "ns1/p1", ee[0].Path())
+
+	s := dao.NewReplayState()
+	for _, e := range ee[:3] {
+		s.Apply(e)
+	}
+	assert.Equal(t, []string{"v1/pods (2)", "  ns1/p1 Running", "  ns1/p2 Pending"}, s.Lines())
+
+	s.Apply(ee[3])
+	assert.Equal(t, []string{"v1/pods (1)", "  ns1/p1 Running"}, s.Lines())
+}
+
+func TestLoadRecordingInvalid(t *testing.T) {
+	_, err := dao.LoadRecording(strings.NewReader("{\"type\":\"ADDED\"}\n"))
+	assert.EqualError(t, err, "invalid recording line 1: missing object")
+}
+
+func TestRecordWatchRedacts(t *testing.T) {
+	gvr := client.SecGVR
+	dyn := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr.GVR(): "SecretList"},
+	)
+	fw := watch.NewFake()
+	dyn.PrependWatchReactor("secrets", ktesting.DefaultWatchReactor(fw, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var b bytes.Buffer
+	done := make(chan error)
+	go func() {
+		done <- dao.RecordWatch(ctx, dyn, []*client.GVR{gvr}, "ns1", nil, &b)
+	}()
+	fw.Add(&unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]any{"name": "tls", "namespace": "ns1"},
+		"data":       map[string]any{"tls.key": "LS0tLS1CRUdJTg=="},
+	}})
+	fw.Stop()
+	cancel()
+	require.NoError(t, <-done)
+
+	ee, err := dao.LoadRecording(&b)
+	require.NoError(t, err)
+	require.Len(t, ee, 1)
+	v, _, _ := unstructured.NestedString(ee[0].Object.Object, "data", "tls.key")
+	assert.Equal(t, redact.Mask, v)
+}
+
+func TestRecordWatchResumes(t *testing.T) {
+	gvr := client.PodGVR
+	dyn := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr.GVR(): "PodList"},
+	)
+	var (
+		fw1, fw2 = watch.NewFake(), watch.NewFake()
+		rvs      = make(chan string, 2)
+	)
+	dyn.PrependWatchReactor("pods", func(a ktesting.Action) (bool, watch.Interface, error) {
+		rv := a.(ktesting.WatchActionImpl).WatchRestrictions.ResourceVersion
+		rvs <- rv
+		if rv == "" {
+			return true, fw1, nil
+		}
+		return true, fw2, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var b bytes.Buffer
+	done := make(chan error)
+	go func() {
+		done <- dao.RecordWatch(ctx, dyn, []*client.GVR{gvr}, "ns1", nil, &b)
+	}()
+	fw1.Add(makeRecordedPod("p1", "5"))
+	fw1.Error(&metav1.Status{Status: metav1.StatusFailure, Code: 500, Message: "etcd hiccup"})
+	fw1.Stop()
+	fw2.Modify(makeRecordedPod("p1", "6"))
+	fw2.Stop()
+	cancel()
+	require.NoError(t, <-done)
+
+	assert.Equal(t, "", <-rvs)
+	assert.Equal(t, "5", <-rvs)
+	ee, err := dao.LoadRecording(&b)
+	require.NoError(t, err)
+	require.Len(t, ee, 3)
+	assert.Equal(t, []watch.EventType{watch.Added, watch.Error, watch.Modified}, []watch.EventType{ee[0].Type, ee[1].Type, ee[2].Type})
+	assert.Equal(t, "etcd hiccup", ee[1].Subject())
+	assert.Equal(t, "ns1/p1", ee[2].Subject())
+}
+
+// Helpers...
+
+func makeRecordedPod(name, rv string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]any{"name": name, "namespace": "ns1", "resourceVersion": rv},
+	}}
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/derailed/k9s/internal/watch"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ Accessor = (*Watcher)(nil)
+
+// InformerInspector represents a factory reporting on its informers.
+type InformerInspector interface {
+	// Informers returns the health of all informers.
+	Informers() []watch.InformerStats
+
+	// StopInformer stops a resource informer.
+	StopInformer(ns string, gvr *client.GVR) error
+
+	// RestartInformer replaces a resource informer with a fresh one.
+	RestartInformer(ns string, gvr *client.GVR) error
+}
+
+// Watcher lists the active informers.
+type Watcher struct {
+	NonResource
+}
+
+// List returns the active informers.
+func (w *Watcher) List(context.Context, string) ([]runtime.Object, error) {
+	ii, err := w.inspector()
+	if err != nil {
+		return nil, err
+	}
+	ss := ii.Informers()
+	oo := make([]runtime.Object, 0, len(ss))
+	for _, s := range ss {
+		oo = append(oo, &render.WatcherRes{InformerStats: s})
+	}
+
+	return oo, nil
+}
+
+// Stop stops the informer at the given path.
+func (w *Watcher) Stop(path string) error {
+	ii, err := w.inspector()
+	if err != nil {
+		return err
+	}
+	ns, gvr, err := ParseWatcherID(path)
+	if err != nil {
+		return err
+	}
+
+	return ii.StopInformer(ns, gvr)
+}
+
+// Restart replaces the informer at the given path with a fresh one.
+func (w *Watcher) Restart(path string) error {
+	ii, err := w.inspector()
+	if err != nil {
+		return err
+	}
+	ns, gvr, err := ParseWatcherID(path)
+	if err != nil {
+		return err
+	}
+
+	return ii.RestartInformer(ns, gvr)
+}
+
+func (w *Watcher) inspector() (InformerInspector, error) {
+	ii, ok := w.getFactory().(InformerInspector)
+	if !ok {
+		return nil, errors.New("informers are not inspectable")
+	}
+
+	return ii, nil
+}
+
+// ParseWatcherID returns the namespace and resource of an informer row.
+func ParseWatcherID(id string) (string, *client.GVR, error) {
+	ns, gvr, ok := strings.Cut(id, "|")
+	if !ok || gvr == "" {
+		return "", nil, fmt.Errorf("invalid informer id %q", id)
+	}
+
+	return ns, client.NewGVR(gvr), nil
+}
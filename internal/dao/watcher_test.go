@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWatcherID(t *testing.T) {
+	uu := map[string]struct {
+		id      string
+		ns, gvr string
+		err     string
+	}{
+		"namespaced": {
+			id:  "ns1|apps/v1/deployments",
+			ns:  "ns1",
+			gvr: "apps/v1/deployments",
+		},
+		"cluster-wide": {
+			id:  "|v1/pods",
+			gvr: "v1/pods",
+		},
+		"bad": {
+			id:  "v1/pods",
+			err: `invalid informer id "v1/pods"`,
+		},
+		"blank-gvr": {
+			id:  "ns1|",
+			err: `invalid informer id "ns1|"`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ns, gvr, err := dao.ParseWatcherID(u.id)
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.ns, ns)
+			assert.Equal(t, u.gvr, gvr.String())
+		})
+	}
+}
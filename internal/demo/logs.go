@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package demo
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// logLines lists the canned log messages of demo pods.
+var logLines = []string{
+	`level=info msg="starting" version=v2.10.1`,
+	`level=info msg="connected to api server"`,
+	`level=info msg="cache synced" resources=42`,
+	`level=info msg="reconciled" duration=12ms`,
+	`level=debug msg="health probe ok"`,
+	`level=info msg="reconciled" duration=9ms`,
+}
+
+var crashLines = []string{
+	`level=error msg="connection refused" target=10.43.0.10:5432`,
+	`level=error msg="unable to open database" retries=5`,
+	`panic: runtime error: invalid memory address or nil pointer dereference`,
+}
+
+// writeLogLine writes the i-th log line of a pod. Crash looping pods log
+// errors.
+func writeLogLine(w io.Writer, po *unstructured.Unstructured, i int, crash bool, at time.Time, stamps bool) {
+	line := logLines[i%len(logLines)]
+	if crash {
+		line = crashLines[i%len(crashLines)]
+	}
+	if stamps {
+		line = at.UTC().Format(time.RFC3339Nano) + " " + line
+	}
+	_, _ = fmt.Fprintf(w, "%s pod=%s\n", line, po.GetName())
+}
+
+// podCrashing checks if a pod crash loops.
+func podCrashing(po *unstructured.Unstructured) bool {
+	cc, _, _ := unstructured.NestedSlice(po.Object, "status", "containerStatuses")
+	for _, c := range cc {
+		m, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		if n, ok := m["restartCount"].(int64); ok && n > 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package demo
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// resource describes a resource served by the demo clusters.
+type resource struct {
+	group, version string
+	name, kind     string
+	singular       string
+	namespaced     bool
+	shortNames     []string
+	columns        []column
+}
+
+// column represents a CRD printer column.
+type column struct {
+	name, kind, path string
+}
+
+// value returns the column value of an object or blank when missing.
+func (c column) value(o *unstructured.Unstructured) string {
+	jp := jsonpath.New(c.name).AllowMissingKeys(true)
+	if err := jp.Parse(fmt.Sprintf("{%s}", c.path)); err != nil {
+		return ""
+	}
+	var b bytes.Buffer
+	if err := jp.Execute(&b, o.Object); err != nil {
+		return ""
+	}
+
+	return b.String()
+}
+
+func (r resource) groupVersion() string {
+	if r.group == "" {
+		return r.version
+	}
+
+	return r.group + "/" + r.version
+}
+
+// builtins lists the standard resources served even without fixtures so
+// views show empty tables instead of errors.
+var builtins = []resource{
+	{version: "v1", name: "namespaces", kind: "Namespace", shortNames: []string{"ns"}},
+	{version: "v1", name: "nodes", kind: "Node", shortNames: []string{"no"}},
+	{version: "v1", name: "pods", kind: "Pod", namespaced: true, shortNames: []string{"po"}},
+	{version: "v1", name: "services", kind: "Service", namespaced: true, shortNames: []string{"svc"}},
+	{version: "v1", name: "configmaps", kind: "ConfigMap", namespaced: true, shortNames: []string{"cm"}},
+	{version: "v1", name: "secrets", kind: "Secret", namespaced: true},
+	{version: "v1", name: "serviceaccounts", kind: "ServiceAccount", namespaced: true, shortNames: []string{"sa"}},
+	{version: "v1", name: "events", kind: "Event", namespaced: true, shortNames: []string{"ev"}},
+	{version: "v1", name: "persistentvolumes", kind: "PersistentVolume", shortNames: []string{"pv"}},
+	{version: "v1", name: "persistentvolumeclaims", kind: "PersistentVolumeClaim", namespaced: true, shortNames: []string{"pvc"}},
+	{group: "apps", version: "v1", name: "deployments", kind: "Deployment", namespaced: true, shortNames: []string{"deploy"}},
+	{group: "apps", version: "v1", name: "replicasets", kind: "ReplicaSet", namespaced: true, shortNames: []string{"rs"}},
+	{group: "apps", version: "v1", name: "statefulsets", kind: "StatefulSet", namespaced: true, shortNames: []string{"sts"}},
+	{group: "apps", version: "v1", name: "daemonsets", kind: "DaemonSet", namespaced: true, shortNames: []string{"ds"}},
+	{group: "batch", version: "v1", name: "jobs", kind: "Job", namespaced: true},
+	{group: "batch", version: "v1", name: "cronjobs", kind: "CronJob", namespaced: true, shortNames: []string{"cj"}},
+	{group: "storage.k8s.io", version: "v1", name: "storageclasses", kind: "StorageClass", shortNames: []string{"sc"}},
+	{group: "networking.k8s.io", version: "v1", name: "ingresses", kind: "Ingress", namespaced: true, shortNames: []string{"ing"}},
+	{group: "rbac.authorization.k8s.io", version: "v1", name: "clusterroles", kind: "ClusterRole"},
+	{group: "rbac.authorization.k8s.io", version: "v1", name: "clusterrolebindings", kind: "ClusterRoleBinding"},
+	{group: "rbac.authorization.k8s.io", version: "v1", name: "roles", kind: "Role", namespaced: true},
+	{group: "rbac.authorization.k8s.io", version: "v1", name: "rolebindings", kind: "RoleBinding", namespaced: true},
+	{group: "apiextensions.k8s.io", version: "v1", name: "customresourcedefinitions", kind: "CustomResourceDefinition", shortNames: []string{"crd", "crds"}},
+}
+
+// registry tracks the resources served by a demo cluster.
+type registry []resource
+
+func newRegistry() registry {
+	return slices.Clone(builtins)
+}
+
+// addCRD registers the resource defined by a CRD fixture.
+func (r *registry) addCRD(u *unstructured.Unstructured) {
+	group, _, _ := unstructured.NestedString(u.Object, "spec", "group")
+	plural, _, _ := unstructured.NestedString(u.Object, "spec", "names", "plural")
+	kind, _, _ := unstructured.NestedString(u.Object, "spec", "names", "kind")
+	singular, _, _ := unstructured.NestedString(u.Object, "spec", "names", "singular")
+	shorts, _, _ := unstructured.NestedStringSlice(u.Object, "spec", "names", "shortNames")
+	scope, _, _ := unstructured.NestedString(u.Object, "spec", "scope")
+	vv, _, _ := unstructured.NestedSlice(u.Object, "spec", "versions")
+	for _, v := range vv {
+		m, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		if served, ok := m["served"].(bool); ok && !served {
+			continue
+		}
+		name, _ := m["name"].(string)
+		*r = append(*r, resource{
+			group:      group,
+			version:    name,
+			name:       plural,
+			kind:       kind,
+			singular:   singular,
+			namespaced: scope == "Namespaced",
+			shortNames: shorts,
+			columns:    printerColumns(m),
+		})
+	}
+}
+
+func printerColumns(version map[string]any) []column {
+	cc, _, _ := unstructured.NestedSlice(version, "additionalPrinterColumns")
+	out := make([]column, 0, len(cc))
+	for _, c := range cc {
+		m, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := m["name"].(string)
+		path, _ := m["jsonPath"].(string)
+		kind, _ := m["type"].(string)
+		if name == "" || path == "" || name == "Age" {
+			continue
+		}
+		out = append(out, column{name: name, kind: kind, path: path})
+	}
+
+	return out
+}
+
+// forKind returns the resource of a given apiVersion and kind.
+func (r registry) forKind(apiVersion, kind string) (resource, bool) {
+	for _, res := range r {
+		if res.groupVersion() == apiVersion && res.kind == kind {
+			return res, true
+		}
+	}
+
+	return resource{}, false
+}
+
+// find returns the resource served under a group version.
+func (r registry) find(gv, name string) (resource, bool) {
+	for _, res := range r {
+		if res.groupVersion() == gv && res.name == name {
+			return res, true
+		}
+	}
+
+	return resource{}, false
+}
+
+// groups returns the API groups, core excluded.
+func (r registry) groups() metav1.APIGroupList {
+	gl := metav1.APIGroupList{TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"}}
+	idx := make(map[string]int)
+	for _, res := range r {
+		if res.group == "" {
+			continue
+		}
+		gv := metav1.GroupVersionForDiscovery{GroupVersion: res.groupVersion(), Version: res.version}
+		i, ok := idx[res.group]
+		if !ok {
+			idx[res.group] = len(gl.Groups)
+			gl.Groups = append(gl.Groups, metav1.APIGroup{Name: res.group, PreferredVersion: gv})
+			i = len(gl.Groups) - 1
+		}
+		if !slices.Contains(gl.Groups[i].Versions, gv) {
+			gl.Groups[i].Versions = append(gl.Groups[i].Versions, gv)
+		}
+	}
+
+	return gl
+}
+
+// resources returns the resources served under a group version.
+func (r registry) resources(gv string) (metav1.APIResourceList, bool) {
+	rl := metav1.APIResourceList{TypeMeta: metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"}, GroupVersion: gv}
+	for _, res := range r {
+		if res.groupVersion() != gv {
+			continue
+		}
+		singular := res.singular
+		if singular == "" {
+			singular = strings.ToLower(res.kind)
+		}
+		rl.APIResources = append(rl.APIResources, metav1.APIResource{
+			Name:         res.name,
+			SingularName: singular,
+			Namespaced:   res.namespaced,
+			Kind:         res.kind,
+			Verbs:        metav1.Verbs{"get", "list", "watch"},
+			ShortNames:   res.shortNames,
+		})
+		if res.name == "pods" {
+			rl.APIResources = append(rl.APIResources, metav1.APIResource{
+				Name:       "pods/log",
+				Namespaced: true,
+				Kind:       "Pod",
+				Verbs:      metav1.Verbs{"get"},
+			})
+		}
+	}
+
+	return rl, len(rl.APIResources) > 0
+}
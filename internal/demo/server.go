@@ -0,0 +1,447 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package demo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/slogs"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	clusterPrefix = "/clusters/"
+	demoUser      = "demo"
+	logTick       = 2 * time.Second
+	readOnlyMsg   = "demo clusters are read-only"
+)
+
+// DefaultContext is the context demo mode starts on.
+const DefaultContext = "demo-rancher"
+
+// cluster describes a synthetic demo context.
+type cluster struct {
+	name, version, fixtures string
+}
+
+// clusters lists the demo contexts along with their fixtures.
+var clusters = []cluster{
+	{name: DefaultContext, version: "v1.31.4+rke2r1", fixtures: "rancher.yaml"},
+	{name: "demo-longhorn", version: "v1.30.8+rke2r1", fixtures: "longhorn.yaml"},
+	{name: "demo-edge", version: "v1.31.4+k3s1", fixtures: "edge.yaml"},
+}
+
+var readVerbs = []string{"get", "list", "watch"}
+
+// Server serves the demo clusters from a local fake API server. Each cluster
+// lives under its own path so they show up as distinct contexts.
+type Server struct {
+	URL    string
+	stores map[string]*store
+	http   *http.Server
+}
+
+// Start loads the fixtures and serves the demo clusters on a local port.
+func Start() (*Server, error) {
+	now := time.Now()
+	ss := make(map[string]*store, len(clusters))
+	for _, c := range clusters {
+		s, err := loadStore(c, now)
+		if err != nil {
+			return nil, fmt.Errorf("demo cluster %q load failed: %w", c.name, err)
+		}
+		ss[c.name] = s
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := Server{
+		URL:    "http://" + l.Addr().String(),
+		stores: ss,
+	}
+	s.http = &http.Server{Handler: &s, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := s.http.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Warn("Demo server failed", slogs.Error, err)
+		}
+	}()
+
+	return &s, nil
+}
+
+// Close stops the demo server.
+func (s *Server) Close() error {
+	return s.http.Close()
+}
+
+// Contexts returns the demo context names.
+func Contexts() []string {
+	cc := make([]string, 0, len(clusters))
+	for _, c := range clusters {
+		cc = append(cc, c.name)
+	}
+
+	return cc
+}
+
+// Kubeconfig returns a kubeconfig holding a context per demo cluster.
+func (s *Server) Kubeconfig() *api.Config {
+	cfg := api.NewConfig()
+	cfg.AuthInfos[demoUser] = &api.AuthInfo{Token: demoUser}
+	for _, c := range clusters {
+		cfg.Clusters[c.name] = &api.Cluster{Server: s.URL + clusterPrefix + c.name}
+		cfg.Contexts[c.name] = &api.Context{Cluster: c.name, AuthInfo: demoUser, Namespace: "default"}
+	}
+	cfg.CurrentContext = DefaultContext
+
+	return cfg
+}
+
+// WriteKubeconfig saves the demo kubeconfig to a file.
+func (s *Server) WriteKubeconfig(path string) error {
+	return clientcmd.WriteToFile(*s.Kubeconfig(), path)
+}
+
+// ServeHTTP serves the API of a demo cluster.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name, p, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, clusterPrefix), "/")
+	st, ok := s.stores[name]
+	if !ok || !strings.HasPrefix(r.URL.Path, clusterPrefix) {
+		writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, "no demo cluster at "+r.URL.Path)
+		return
+	}
+	p = "/" + p
+
+	switch {
+	case p == "/version":
+		writeJSON(w, http.StatusOK, st.versionInfo())
+	case p == "/api":
+		writeJSON(w, http.StatusOK, metav1.APIVersions{
+			TypeMeta: metav1.TypeMeta{Kind: "APIVersions"},
+			Versions: []string{"v1"},
+			ServerAddressByClientCIDRs: []metav1.ServerAddressByClientCIDR{
+				{ClientCIDR: "0.0.0.0/0", ServerAddress: r.Host},
+			},
+		})
+	case p == "/apis":
+		writeJSON(w, http.StatusOK, st.reg.groups())
+	case r.Method == http.MethodPost && strings.HasSuffix(p, "/selfsubjectaccessreviews"):
+		accessReview(w, r)
+	case r.Method == http.MethodPost && strings.HasSuffix(p, "/selfsubjectrulesreviews"):
+		rulesReview(w, r)
+	case r.Method != http.MethodGet:
+		writeStatus(w, http.StatusForbidden, metav1.StatusReasonForbidden, readOnlyMsg)
+	default:
+		st.serve(w, r, p)
+	}
+}
+
+func (st *store) versionInfo() version.Info {
+	v := strings.TrimPrefix(st.version, "v")
+	major, minor, _ := strings.Cut(v, ".")
+	minor, _, _ = strings.Cut(minor, ".")
+
+	return version.Info{
+		Major:      major,
+		Minor:      minor,
+		GitVersion: st.version,
+		Platform:   "linux/amd64",
+	}
+}
+
+func (st *store) serve(w http.ResponseWriter, r *http.Request, p string) {
+	var (
+		gv   string
+		segs []string
+	)
+	switch {
+	case strings.HasPrefix(p, "/api/"):
+		ss := strings.Split(strings.Trim(p[len("/api/"):], "/"), "/")
+		gv, segs = ss[0], ss[1:]
+	case strings.HasPrefix(p, "/apis/"):
+		ss := strings.Split(strings.Trim(p[len("/apis/"):], "/"), "/")
+		if len(ss) < 2 {
+			writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, "unknown group "+p)
+			return
+		}
+		gv, segs = ss[0]+"/"+ss[1], ss[2:]
+	default:
+		writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, "unknown path "+p)
+		return
+	}
+	if len(segs) == 0 {
+		rl, ok := st.reg.resources(gv)
+		if !ok {
+			writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, "unknown group version "+gv)
+			return
+		}
+		writeJSON(w, http.StatusOK, rl)
+		return
+	}
+
+	var ns string
+	if len(segs) >= 3 && segs[0] == "namespaces" {
+		ns, segs = segs[1], segs[2:]
+	}
+	res, ok := st.reg.find(gv, segs[0])
+	if !ok {
+		writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, fmt.Sprintf("the server could not find the requested resource %s/%s", gv, segs[0]))
+		return
+	}
+	switch {
+	case len(segs) == 1:
+		st.serveList(w, r, res, ns)
+	case len(segs) == 2:
+		st.serveGet(w, r, res, ns, segs[1])
+	case len(segs) == 3 && res.name == "pods" && segs[2] == "log":
+		st.serveLogs(w, r, ns, segs[1])
+	default:
+		writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, "unknown subresource "+p)
+	}
+}
+
+func (st *store) serveList(w http.ResponseWriter, r *http.Request, res resource, ns string) {
+	q := r.URL.Query()
+	if v := q.Get("watch"); v == "true" || v == "1" {
+		serveWatch(w, r)
+		return
+	}
+	lsel, err := labels.Parse(q.Get("labelSelector"))
+	if err != nil {
+		writeStatus(w, http.StatusBadRequest, metav1.StatusReasonBadRequest, err.Error())
+		return
+	}
+	fsel, err := fields.ParseSelector(q.Get("fieldSelector"))
+	if err != nil {
+		writeStatus(w, http.StatusBadRequest, metav1.StatusReasonBadRequest, err.Error())
+		return
+	}
+	oo := make([]*unstructured.Unstructured, 0, len(st.list(res)))
+	for _, o := range st.list(res) {
+		if ns != "" && o.GetNamespace() != ns {
+			continue
+		}
+		if !lsel.Matches(labels.Set(o.GetLabels())) || !fsel.Matches(objectFields(o)) {
+			continue
+		}
+		oo = append(oo, o)
+	}
+	if wantsTable(r) {
+		writeJSON(w, http.StatusOK, toTable(res, oo, q.Get("includeObject")))
+		return
+	}
+	items := make([]any, 0, len(oo))
+	for _, o := range oo {
+		items = append(items, o.Object)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"apiVersion": res.groupVersion(),
+		"kind":       res.kind + "List",
+		"metadata":   map[string]any{"resourceVersion": "1"},
+		"items":      items,
+	})
+}
+
+func (st *store) serveGet(w http.ResponseWriter, r *http.Request, res resource, ns, n string) {
+	o, ok := st.get(res, ns, n)
+	if !ok {
+		writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, fmt.Sprintf("%s %q not found", res.name, n))
+		return
+	}
+	if wantsTable(r) {
+		writeJSON(w, http.StatusOK, toTable(res, []*unstructured.Unstructured{o}, r.URL.Query().Get("includeObject")))
+		return
+	}
+	writeJSON(w, http.StatusOK, o.Object)
+}
+
+func (st *store) get(res resource, ns, n string) (*unstructured.Unstructured, bool) {
+	for _, o := range st.list(res) {
+		if o.GetName() == n && (!res.namespaced || o.GetNamespace() == ns) {
+			return o, true
+		}
+	}
+
+	return nil, false
+}
+
+// toTable renders objects as a server side table, honoring the printer
+// columns of CRDs.
+func toTable(res resource, oo []*unstructured.Unstructured, include string) *metav1.Table {
+	t := metav1.Table{
+		TypeMeta: metav1.TypeMeta{Kind: "Table", APIVersion: "meta.k8s.io/v1"},
+		ListMeta: metav1.ListMeta{ResourceVersion: "1"},
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string", Format: "name"},
+		},
+	}
+	for _, c := range res.columns {
+		t.ColumnDefinitions = append(t.ColumnDefinitions, metav1.TableColumnDefinition{Name: c.name, Type: c.kind})
+	}
+	t.ColumnDefinitions = append(t.ColumnDefinitions, metav1.TableColumnDefinition{Name: "Age", Type: "date"})
+	for _, o := range oo {
+		cells := make([]any, 0, len(t.ColumnDefinitions))
+		cells = append(cells, o.GetName())
+		for _, c := range res.columns {
+			cells = append(cells, c.value(o))
+		}
+		cells = append(cells, o.GetCreationTimestamp().UTC().Format(time.RFC3339))
+		row := metav1.TableRow{Cells: cells}
+		if include != "None" {
+			if raw, err := o.MarshalJSON(); err == nil {
+				row.Object = runtime.RawExtension{Raw: raw}
+			}
+		}
+		t.Rows = append(t.Rows, row)
+	}
+
+	return &t
+}
+
+// serveLogs streams canned pod logs, a new line every tick when following.
+func (st *store) serveLogs(w http.ResponseWriter, r *http.Request, ns, n string) {
+	res, _ := st.reg.find("v1", "pods")
+	po, ok := st.get(res, ns, n)
+	if !ok {
+		writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, fmt.Sprintf("pods %q not found", n))
+		return
+	}
+	q := r.URL.Query()
+	stamps := q.Get("timestamps") == "true"
+	crash := podCrashing(po)
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	start := time.Now().Add(-time.Duration(len(logLines)) * logTick)
+	for i := range logLines {
+		writeLogLine(w, po, i, crash, start.Add(time.Duration(i)*logTick), stamps)
+	}
+	if q.Get("follow") != "true" {
+		return
+	}
+	for i := len(logLines); ; i++ {
+		flush(w)
+		select {
+		case <-r.Context().Done():
+			return
+		case t := <-time.After(logTick):
+			writeLogLine(w, po, i, crash, t, stamps)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func serveWatch(w http.ResponseWriter, r *http.Request) {
+	timeout := 5 * time.Minute
+	if s, err := strconv.Atoi(r.URL.Query().Get("timeoutSeconds")); err == nil && s > 0 {
+		timeout = time.Duration(s) * time.Second
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flush(w)
+	select {
+	case <-r.Context().Done():
+	case <-time.After(timeout):
+	}
+}
+
+func accessReview(w http.ResponseWriter, r *http.Request) {
+	var sar authorizationv1.SelfSubjectAccessReview
+	if err := json.NewDecoder(r.Body).Decode(&sar); err != nil {
+		writeStatus(w, http.StatusBadRequest, metav1.StatusReasonBadRequest, err.Error())
+		return
+	}
+	var verb string
+	if a := sar.Spec.ResourceAttributes; a != nil {
+		verb = a.Verb
+	}
+	sar.Status.Allowed = slices.Contains(readVerbs, verb)
+	if !sar.Status.Allowed {
+		sar.Status.Reason = readOnlyMsg
+	}
+	sar.Kind, sar.APIVersion = "SelfSubjectAccessReview", authorizationv1.SchemeGroupVersion.String()
+	writeJSON(w, http.StatusCreated, sar)
+}
+
+func rulesReview(w http.ResponseWriter, r *http.Request) {
+	var ssrr authorizationv1.SelfSubjectRulesReview
+	if err := json.NewDecoder(r.Body).Decode(&ssrr); err != nil {
+		writeStatus(w, http.StatusBadRequest, metav1.StatusReasonBadRequest, err.Error())
+		return
+	}
+	ssrr.Status.ResourceRules = []authorizationv1.ResourceRule{
+		{Verbs: readVerbs, APIGroups: []string{"*"}, Resources: []string{"*"}},
+	}
+	ssrr.Kind, ssrr.APIVersion = "SelfSubjectRulesReview", authorizationv1.SchemeGroupVersion.String()
+	writeJSON(w, http.StatusCreated, ssrr)
+}
+
+func wantsTable(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "as=Table")
+}
+
+func objectFields(o *unstructured.Unstructured) fields.Set {
+	ff := fields.Set{
+		"metadata.name":      o.GetName(),
+		"metadata.namespace": o.GetNamespace(),
+	}
+	for _, p := range [][]string{{"spec", "nodeName"}, {"status", "phase"}, {"involvedObject", "name"}, {"involvedObject", "kind"}} {
+		if v, ok, _ := unstructured.NestedString(o.Object, p...); ok {
+			ff[strings.Join(p, ".")] = v
+		}
+	}
+
+	return ff
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		writeStatus(w, http.StatusInternalServerError, metav1.StatusReasonInternalError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err := w.Write(raw); err != nil {
+		slog.Debug("Demo server write failed", slogs.Error, err)
+	}
+}
+
+func writeStatus(w http.ResponseWriter, code int, reason metav1.StatusReason, msg string) {
+	raw, _ := json.Marshal(metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  msg,
+		Reason:   reason,
+		Code:     int32(code),
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(raw)
+}
+
+func flush(w http.ResponseWriter) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package demo_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/derailed/k9s/internal/demo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestServerContexts(t *testing.T) {
+	s, err := demo.Start()
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+
+	cfg := s.Kubeconfig()
+	assert.Equal(t, demo.DefaultContext, cfg.CurrentContext)
+	assert.Len(t, cfg.Contexts, len(demo.Contexts()))
+	for _, ct := range demo.Contexts() {
+		assert.Contains(t, cfg.Contexts, ct)
+	}
+}
+
+func TestServerDiscovery(t *testing.T) {
+	uu := map[string]struct {
+		context string
+		gv, res string
+		ok      bool
+	}{
+		"longhorn": {
+			context: "demo-longhorn",
+			gv:      "longhorn.io/v1beta2",
+			res:     "recurringjobs",
+			ok:      true,
+		},
+		"fleet": {
+			context: demo.DefaultContext,
+			gv:      "fleet.cattle.io/v1alpha1",
+			res:     "gitrepos",
+			ok:      true,
+		},
+		"kubevirt": {
+			context: "demo-edge",
+			gv:      "kubevirt.io/v1",
+			res:     "virtualmachines",
+			ok:      true,
+		},
+		"missing": {
+			context: "demo-edge",
+			gv:      "longhorn.io/v1beta2",
+			res:     "volumes",
+		},
+	}
+
+	s, err := demo.Start()
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			dc, err := discovery.NewDiscoveryClientForConfig(restConfig(t, s, u.context))
+			require.NoError(t, err)
+			rl, err := dc.ServerResourcesForGroupVersion(u.gv)
+			if !u.ok {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var found bool
+			for _, r := range rl.APIResources {
+				found = found || r.Name == u.res
+			}
+			assert.True(t, found)
+		})
+	}
+}
+
+func TestServerList(t *testing.T) {
+	s, err := demo.Start()
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+
+	ctx := context.Background()
+	cs, err := kubernetes.NewForConfig(restConfig(t, s, demo.DefaultContext))
+	require.NoError(t, err)
+
+	nn, err := cs.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, nn.Items, 3)
+
+	pp, err := cs.CoreV1().Pods("shop").List(ctx, metav1.ListOptions{LabelSelector: "app=checkout"})
+	require.NoError(t, err)
+	require.Len(t, pp.Items, 2)
+	var restarts int32
+	for _, po := range pp.Items {
+		assert.NotEmpty(t, po.Spec.NodeName)
+		assert.NotEmpty(t, po.OwnerReferences)
+		for _, cs := range po.Status.ContainerStatuses {
+			restarts += cs.RestartCount
+		}
+	}
+	assert.Positive(t, restarts)
+
+	dc, err := dynamic.NewForConfig(restConfig(t, s, "demo-edge"))
+	require.NoError(t, err)
+	vms, err := dc.Resource(schema.GroupVersionResource{Group: "kubevirt.io", Version: "v1", Resource: "virtualmachines"}).
+		Namespace("vms").
+		List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, vms.Items, 2)
+}
+
+func TestServerLogs(t *testing.T) {
+	s, err := demo.Start()
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+
+	ctx := context.Background()
+	cs, err := kubernetes.NewForConfig(restConfig(t, s, demo.DefaultContext))
+	require.NoError(t, err)
+	pp, err := cs.CoreV1().Pods("cattle-system").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, pp.Items)
+
+	rc, err := cs.CoreV1().Pods("cattle-system").GetLogs(pp.Items[0].Name, &v1.PodLogOptions{}).Stream(ctx)
+	require.NoError(t, err)
+	defer func() { _ = rc.Close() }()
+	raw, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "pod="+pp.Items[0].Name)
+}
+
+func TestServerReadOnly(t *testing.T) {
+	s, err := demo.Start()
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+
+	cs, err := kubernetes.NewForConfig(restConfig(t, s, demo.DefaultContext))
+	require.NoError(t, err)
+	err = cs.CoreV1().Namespaces().Delete(context.Background(), "shop", metav1.DeleteOptions{})
+	assert.Error(t, err)
+}
+
+// Helpers...
+
+func restConfig(t *testing.T, s *demo.Server, context string) *rest.Config {
+	t.Helper()
+	cfg, err := clientcmd.NewNonInteractiveClientConfig(*s.Kubeconfig(), context, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
+	require.NoError(t, err)
+
+	return cfg
+}
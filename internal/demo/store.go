@@ -0,0 +1,359 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package demo
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	fixturesDir      = "fixtures"
+	commonFixtures   = "common.yaml"
+	crashLoopAnnot   = "demo.rk9s.io/crashloop"
+	crashLoopRestart = 12
+)
+
+//go:embed fixtures/*.yaml
+var fixtures embed.FS
+
+// store tracks the objects of a demo cluster.
+type store struct {
+	name    string
+	version string
+	reg     registry
+	objs    map[string][]*unstructured.Unstructured
+	now     time.Time
+}
+
+func loadStore(c cluster, now time.Time) (*store, error) {
+	s := store{
+		name:    c.name,
+		version: c.version,
+		reg:     newRegistry(),
+		objs:    make(map[string][]*unstructured.Unstructured),
+		now:     now,
+	}
+	var oo []*unstructured.Unstructured
+	for _, f := range []string{commonFixtures, c.fixtures} {
+		ff, err := readFixtures(f)
+		if err != nil {
+			return nil, err
+		}
+		oo = append(oo, ff...)
+	}
+	for _, o := range oo {
+		if o.GetKind() == "CustomResourceDefinition" {
+			s.reg.addCRD(o)
+		}
+	}
+	for _, o := range oo {
+		if err := s.add(o); err != nil {
+			return nil, fmt.Errorf("%s: %w", c.fixtures, err)
+		}
+	}
+	s.synthesize()
+	for _, oo := range s.objs {
+		sort.Slice(oo, func(i, j int) bool {
+			if oo[i].GetNamespace() != oo[j].GetNamespace() {
+				return oo[i].GetNamespace() < oo[j].GetNamespace()
+			}
+			return oo[i].GetName() < oo[j].GetName()
+		})
+	}
+
+	return &s, nil
+}
+
+func readFixtures(name string) ([]*unstructured.Unstructured, error) {
+	raw, err := fixtures.ReadFile(path.Join(fixturesDir, name))
+	if err != nil {
+		return nil, err
+	}
+	dec := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(raw), 4096)
+	var oo []*unstructured.Unstructured
+	for {
+		var doc json.RawMessage
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return oo, nil
+			}
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 || string(doc) == "null" {
+			continue
+		}
+		var u unstructured.Unstructured
+		if err := u.UnmarshalJSON(doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		oo = append(oo, &u)
+	}
+}
+
+// add stores an object, filling in its server side metadata.
+func (s *store) add(o *unstructured.Unstructured) error {
+	res, ok := s.reg.forKind(o.GetAPIVersion(), o.GetKind())
+	if !ok {
+		return fmt.Errorf("no resource for %s %s", o.GetAPIVersion(), o.GetKind())
+	}
+	if !res.namespaced {
+		o.SetNamespace("")
+	} else if o.GetNamespace() == "" {
+		o.SetNamespace("default")
+	}
+	id := o.GetNamespace() + "/" + o.GetName()
+	if o.GetUID() == "" {
+		o.SetUID(types.UID(fmt.Sprintf("%08x-demo-%s", hash(s.name+id), hashStr(id, 12))))
+	}
+	o.SetResourceVersion("1")
+	if ts := o.GetCreationTimestamp(); ts.IsZero() {
+		o.SetCreationTimestamp(metav1.NewTime(s.now.Add(-s.age(id))))
+	}
+	key := res.groupVersion() + "/" + res.name
+	s.objs[key] = append(s.objs[key], o)
+
+	return nil
+}
+
+// age returns a stable object age between 1h and 30 days.
+func (s *store) age(id string) time.Duration {
+	return time.Hour + time.Duration(hash(s.name+id)%(30*24*60))*time.Minute
+}
+
+func (s *store) list(res resource) []*unstructured.Unstructured {
+	return s.objs[res.groupVersion()+"/"+res.name]
+}
+
+func (s *store) kind(apiVersion, kind string) []*unstructured.Unstructured {
+	res, ok := s.reg.forKind(apiVersion, kind)
+	if !ok {
+		return nil
+	}
+
+	return s.list(res)
+}
+
+// synthesize derives the status of nodes and namespaces along with the
+// replicasets and pods of the workload fixtures.
+func (s *store) synthesize() {
+	nodes := s.kind("v1", "Node")
+	for i, n := range nodes {
+		s.fillNode(n, i)
+	}
+	for _, ns := range s.kind("v1", "Namespace") {
+		_ = unstructured.SetNestedField(ns.Object, "Active", "status", "phase")
+	}
+	for _, d := range s.kind("apps/v1", "Deployment") {
+		replicas := replicasOf(d)
+		rs := s.workloadChild(d, "ReplicaSet", d.GetName()+"-"+hashStr(d.GetName(), 10))
+		_ = unstructured.SetNestedField(rs.Object, replicas, "spec", "replicas")
+		tmpl, _, _ := unstructured.NestedMap(d.Object, "spec", "template")
+		_ = unstructured.SetNestedMap(rs.Object, tmpl, "spec", "template")
+		ready := s.workloadPods(d, rs, nodes, int(replicas), func(i int) string {
+			return rs.GetName() + "-" + hashStr(rs.GetName()+strconv.Itoa(i), 5)
+		})
+		setStatus(rs, map[string]any{"replicas": replicas, "readyReplicas": ready, "availableReplicas": ready, "fullyLabeledReplicas": replicas, "observedGeneration": int64(1)})
+		setStatus(d, map[string]any{"replicas": replicas, "readyReplicas": ready, "availableReplicas": ready, "updatedReplicas": replicas, "observedGeneration": int64(1)})
+	}
+	for _, sts := range s.kind("apps/v1", "StatefulSet") {
+		replicas := replicasOf(sts)
+		ready := s.workloadPods(sts, sts, nodes, int(replicas), func(i int) string {
+			return sts.GetName() + "-" + strconv.Itoa(i)
+		})
+		setStatus(sts, map[string]any{"replicas": replicas, "readyReplicas": ready, "availableReplicas": ready, "currentReplicas": replicas, "updatedReplicas": replicas, "observedGeneration": int64(1)})
+	}
+	for _, ds := range s.kind("apps/v1", "DaemonSet") {
+		n := int64(len(nodes))
+		ready := s.workloadPods(ds, ds, nodes, len(nodes), func(i int) string {
+			return ds.GetName() + "-" + hashStr(ds.GetName()+strconv.Itoa(i), 5)
+		})
+		setStatus(ds, map[string]any{"desiredNumberScheduled": n, "currentNumberScheduled": n, "numberReady": ready, "numberAvailable": ready, "updatedNumberScheduled": n, "observedGeneration": int64(1)})
+	}
+}
+
+// workloadChild adds an object owned by a workload.
+func (s *store) workloadChild(owner *unstructured.Unstructured, kind, name string) *unstructured.Unstructured {
+	apiVersion := "apps/v1"
+	if kind == "Pod" {
+		apiVersion = "v1"
+	}
+	o := &unstructured.Unstructured{Object: map[string]any{}}
+	o.SetAPIVersion(apiVersion)
+	o.SetKind(kind)
+	o.SetName(name)
+	o.SetNamespace(owner.GetNamespace())
+	lbls, _, _ := unstructured.NestedStringMap(owner.Object, "spec", "template", "metadata", "labels")
+	o.SetLabels(lbls)
+	o.SetCreationTimestamp(metav1.NewTime(owner.GetCreationTimestamp().Add(time.Minute)))
+	ctrl := true
+	o.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: owner.GetAPIVersion(),
+		Kind:       owner.GetKind(),
+		Name:       owner.GetName(),
+		UID:        owner.GetUID(),
+		Controller: &ctrl,
+	}})
+	if sel, ok, _ := unstructured.NestedMap(owner.Object, "spec", "selector"); ok && kind != "Pod" {
+		_ = unstructured.SetNestedMap(o.Object, sel, "spec", "selector")
+	}
+	_ = s.add(o)
+
+	return o
+}
+
+// workloadPods adds the pods of a workload spread across the nodes and
+// returns how many are ready. The last pod crash loops when the workload is
+// annotated so.
+func (s *store) workloadPods(wk, owner *unstructured.Unstructured, nodes []*unstructured.Unstructured, count int, name func(int) string) int64 {
+	spec, _, _ := unstructured.NestedMap(wk.Object, "spec", "template", "spec")
+	broken := wk.GetAnnotations()[crashLoopAnnot] == "true"
+	var ready int64
+	for i := range count {
+		po := s.workloadChild(owner, "Pod", name(i))
+		podSpec := deepCopyMap(spec)
+		if len(nodes) > 0 {
+			node := nodes[(int(hash(wk.GetName()))+i)%len(nodes)]
+			podSpec["nodeName"] = node.GetName()
+		}
+		po.Object["spec"] = podSpec
+		crash := broken && i == count-1
+		if !crash {
+			ready++
+		}
+		po.Object["status"] = podStatus(podSpec, po.GetCreationTimestamp().Time, crash, i)
+	}
+
+	return ready
+}
+
+func podStatus(spec map[string]any, started time.Time, crash bool, i int) map[string]any {
+	var cc []any
+	containers, _, _ := unstructured.NestedSlice(spec, "containers")
+	for _, c := range containers {
+		m, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		cs := map[string]any{
+			"name":         m["name"],
+			"image":        m["image"],
+			"imageID":      m["image"],
+			"ready":        !crash,
+			"started":      !crash,
+			"restartCount": int64(0),
+			"state":        map[string]any{"running": map[string]any{"startedAt": started.UTC().Format(time.RFC3339)}},
+		}
+		if crash {
+			cs["restartCount"] = int64(crashLoopRestart)
+			cs["state"] = map[string]any{"waiting": map[string]any{"reason": "CrashLoopBackOff", "message": "back-off 5m0s restarting failed container"}}
+			cs["lastState"] = map[string]any{"terminated": map[string]any{"exitCode": int64(1), "reason": "Error"}}
+		}
+		cc = append(cc, cs)
+	}
+
+	return map[string]any{
+		"phase":     "Running",
+		"podIP":     fmt.Sprintf("10.42.%d.%d", i%4, 10+i),
+		"startTime": started.UTC().Format(time.RFC3339),
+		"conditions": []any{
+			map[string]any{"type": "Ready", "status": condStatus(!crash)},
+			map[string]any{"type": "ContainersReady", "status": condStatus(!crash)},
+			map[string]any{"type": "PodScheduled", "status": "True"},
+		},
+		"containerStatuses": cc,
+	}
+}
+
+func (s *store) fillNode(n *unstructured.Unstructured, i int) {
+	if _, ok := n.Object["status"]; ok {
+		return
+	}
+	n.Object["status"] = map[string]any{
+		"capacity":    map[string]any{"cpu": "8", "memory": "32Gi", "pods": "110", "ephemeral-storage": "200Gi"},
+		"allocatable": map[string]any{"cpu": "8", "memory": "31Gi", "pods": "110", "ephemeral-storage": "190Gi"},
+		"addresses": []any{
+			map[string]any{"type": "InternalIP", "address": fmt.Sprintf("10.0.0.%d", 10+i)},
+			map[string]any{"type": "Hostname", "address": n.GetName()},
+		},
+		"conditions": []any{
+			map[string]any{"type": "Ready", "status": "True", "reason": "KubeletReady"},
+		},
+		"nodeInfo": map[string]any{
+			"kubeletVersion":          s.version,
+			"kubeProxyVersion":        s.version,
+			"osImage":                 "SUSE Linux Enterprise Micro 6.0",
+			"kernelVersion":           "6.4.0-17-default",
+			"containerRuntimeVersion": "containerd://1.7.23-k3s2",
+			"operatingSystem":         "linux",
+			"architecture":            "amd64",
+		},
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func replicasOf(o *unstructured.Unstructured) int64 {
+	if n, ok, _ := unstructured.NestedInt64(o.Object, "spec", "replicas"); ok {
+		return n
+	}
+
+	return 1
+}
+
+func setStatus(o *unstructured.Unstructured, st map[string]any) {
+	o.Object["status"] = st
+}
+
+func deepCopyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+
+	return (&unstructured.Unstructured{Object: m}).DeepCopy().Object
+}
+
+func condStatus(b bool) string {
+	if b {
+		return "True"
+	}
+
+	return "False"
+}
+
+func hash(s string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s))
+
+	return h.Sum32()
+}
+
+// hashStr returns a stable pseudo random suffix, ie the pod template hash of
+// a replicaset.
+func hashStr(s string, n int) string {
+	const alphabet = "bcdfghjklmnpqrstvwxz2456789"
+	out := make([]byte, n)
+	h := hash(s)
+	for i := range out {
+		out[i] = alphabet[h%uint32(len(alphabet))]
+		h = h*31 + uint32(i) + 7
+	}
+
+	return string(out)
+}
@@ -13,6 +13,7 @@ import (
 var (
 	fuzzyRx = regexp.MustCompile(`\A-f\s?([\w-]+)\b`)
 	labelRx = regexp.MustCompile(`\A\-l`)
+	fieldRx = regexp.MustCompile(`\A\-F`)
 )
 
 // Helpers...
@@ -30,10 +31,19 @@ func IsLabelSelector(s string) bool {
 	if labelRx.MatchString(s) {
 		return true
 	}
+	if IsFieldSelector(s) {
+		return false
+	}
 
 	return !strings.Contains(s, " ") && cmd.ToLabels(s) != nil
 }
 
+// IsFieldSelector checks if query is a server side field query, ie
+// `-F status.phase!=Succeeded`.
+func IsFieldSelector(s string) bool {
+	return fieldRx.MatchString(s)
+}
+
 // IsFuzzySelector checks if query is fuzzy.
 func IsFuzzySelector(s string) (string, bool) {
 	mm := fuzzyRx.FindStringSubmatch(s)
@@ -22,6 +22,7 @@ func TestIsLabelSelector(t *testing.T) {
 		"wrong-flag":  {s: "-f app=fred,env=blee"},
 		"missing-key": {s: "=fred"},
 		"missing-val": {s: "fred="},
+		"field-flag":  {s: "-Fspec.nodeName=n1"},
 	}
 
 	for k := range uu {
@@ -31,3 +32,24 @@ func TestIsLabelSelector(t *testing.T) {
 		})
 	}
 }
+
+func TestIsFieldSelector(t *testing.T) {
+	uu := map[string]struct {
+		s  string
+		ok bool
+	}{
+		"empty":    {s: ""},
+		"cool":     {s: "-F status.phase!=Succeeded", ok: true},
+		"no-space": {s: "-Fspec.nodeName=n1", ok: true},
+		"label":    {s: "-l app=fred"},
+		"fuzzy":    {s: "-f fred"},
+		"no-flag":  {s: "spec.nodeName=n1"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.ok, internal.IsFieldSelector(u.s))
+		})
+	}
+}
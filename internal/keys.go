@@ -36,4 +36,9 @@ const (
 	KeyWait          ContextKey = "wait"
 	KeyPodCounting   ContextKey = "podCounting"
 	KeyEnableImgScan ContextKey = "vulScan"
+	KeyPrometheus    ContextKey = "prometheus"
+	KeyRules         ContextKey = "rules"
+	KeyRancher       ContextKey = "rancher"
+	KeyCanary        ContextKey = "canary"
+	KeyJanitor       ContextKey = "janitor"
 )
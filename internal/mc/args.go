@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package mc
+
+import (
+	"errors"
+	"strings"
+)
+
+// SplitArgs splits a command line into words the way a POSIX shell does,
+// honoring single and double quotes and backslash escapes, ie
+// `get po -l 'app in (a,b)'`. Nothing gets expanded.
+func SplitArgs(s string) ([]string, error) {
+	var (
+		aa     []string
+		b      strings.Builder
+		inWord bool
+		quote  rune
+		escape bool
+	)
+	for _, r := range s {
+		switch {
+		case escape:
+			if quote == '"' && !strings.ContainsRune(`"\$`+"`", r) {
+				b.WriteRune('\\')
+			}
+			b.WriteRune(r)
+			escape = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+				continue
+			}
+			b.WriteRune(r)
+		case r == '\\' && quote != '\'':
+			escape, inWord = true, true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+				continue
+			}
+			b.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				aa = append(aa, b.String())
+				b.Reset()
+				inWord = false
+			}
+		default:
+			b.WriteRune(r)
+			inWord = true
+		}
+	}
+	if escape {
+		return nil, errors.New("trailing backslash")
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inWord {
+		aa = append(aa, b.String())
+	}
+
+	return aa, nil
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package mc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitArgs(t *testing.T) {
+	uu := map[string]struct {
+		s   string
+		e   []string
+		err string
+	}{
+		"plain": {
+			s: "get  nodes -o wide",
+			e: []string{"get", "nodes", "-o", "wide"},
+		},
+		"single": {
+			s: "get po -l 'app in (x,y)'",
+			e: []string{"get", "po", "-l", "app in (x,y)"},
+		},
+		"jsonpath": {
+			s: `get po -o jsonpath='{.items[*].metadata.name}{"\n"}'`,
+			e: []string{"get", "po", "-o", `jsonpath={.items[*].metadata.name}{"\n"}`},
+		},
+		"double": {
+			s: `annotate po web note="it's \"fine\""`,
+			e: []string{"annotate", "po", "web", `note=it's "fine"`},
+		},
+		"escape": {
+			s: `get po a\ b`,
+			e: []string{"get", "po", "a b"},
+		},
+		"empty-quotes": {
+			s: `label po web app=''`,
+			e: []string{"label", "po", "web", "app="},
+		},
+		"blank": {
+			s: "  ",
+		},
+		"unterminated": {
+			s:   "get po -l 'app",
+			err: "unterminated quote",
+		},
+		"trailing": {
+			s:   `get po \`,
+			err: "trailing backslash",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			aa, err := SplitArgs(u.s)
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.e, aa)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package mc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const defaultKubectl = "kubectl"
+
+var (
+	versionRX = regexp.MustCompile(`^v?(\d+)\.(\d+)(?:\.(\d+))?`)
+	managedRX = regexp.MustCompile(`^kubectl-?(v?\d+\.\d+(?:\.\d+)?)(?:\.exe)?$`)
+)
+
+// Version represents a kubectl client or an API server version.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// ParseVersion parses a version, ie `v1.30.2+rke2r1` or `1.30`.
+func ParseVersion(s string) (Version, error) {
+	mm := versionRX.FindStringSubmatch(strings.TrimSpace(s))
+	if len(mm) == 0 {
+		return Version{}, fmt.Errorf("invalid version %q", s)
+	}
+	var v Version
+	v.Major, _ = strconv.Atoi(mm[1])
+	v.Minor, _ = strconv.Atoi(mm[2])
+	if mm[3] != "" {
+		v.Patch, _ = strconv.Atoi(mm[3])
+	}
+	return v, nil
+}
+
+// String returns the version minor, ie `v1.30`.
+func (v Version) String() string {
+	return fmt.Sprintf("v%d.%d", v.Major, v.Minor)
+}
+
+// Skew returns the minor version skew between two versions.
+func (v Version) Skew(v1 Version) int {
+	if v.Major != v1.Major {
+		return 100
+	}
+	d := v.Minor - v1.Minor
+	if d < 0 {
+		d = -d
+	}
+	return d
+}
+
+// Versions returns the kubectl client and the API server versions of a context.
+func Versions(ctx context.Context, bin, kctx string) (client, server Version, err error) {
+	cmd := exec.CommandContext(ctx, bin, "version", "-o", "json", "--context", kctx)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr, cmd.WaitDelay = &stdout, &stderr, waitDelay
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return client, server, fmt.Errorf("%s version: %s", bin, msg)
+	}
+
+	return parseVersions(stdout.Bytes())
+}
+
+func parseVersions(bb []byte) (client, server Version, err error) {
+	var v struct {
+		Client struct {
+			GitVersion string `json:"gitVersion"`
+		} `json:"clientVersion"`
+		Server *struct {
+			GitVersion string `json:"gitVersion"`
+		} `json:"serverVersion"`
+	}
+	if err = json.Unmarshal(bb, &v); err != nil {
+		return client, server, fmt.Errorf("invalid kubectl version output: %w", err)
+	}
+	if v.Server == nil {
+		return client, server, fmt.Errorf("no server version reported")
+	}
+	if client, err = ParseVersion(v.Client.GitVersion); err != nil {
+		return client, server, err
+	}
+	server, err = ParseVersion(v.Server.GitVersion)
+
+	return client, server, err
+}
+
+// ManagedBinary returns the kubectl binary from a directory matching a server
+// minor version, picking the latest patch. Binaries are named after their
+// version, ie `kubectl-v1.30.2` or `kubectl-1.30`.
+func ManagedBinary(dir string, server Version) (string, Version, error) {
+	ee, err := os.ReadDir(dir)
+	if err != nil {
+		return "", Version{}, err
+	}
+	var (
+		bin   string
+		found Version
+	)
+	for _, e := range ee {
+		if e.IsDir() {
+			continue
+		}
+		mm := managedRX.FindStringSubmatch(e.Name())
+		if len(mm) == 0 {
+			continue
+		}
+		v, err := ParseVersion(mm[1])
+		if err != nil || v.Skew(server) != 0 {
+			continue
+		}
+		if bin == "" || v.Patch > found.Patch {
+			bin, found = filepath.Join(dir, e.Name()), v
+		}
+	}
+	if bin == "" {
+		return "", Version{}, fmt.Errorf("no kubectl %s found in %s", server, dir)
+	}
+
+	return bin, found, nil
+}
+
+// resolve returns the kubectl binary to run on a context and checks its skew
+// with the API server.
+func (r Runner) resolve(ctx context.Context, kctx string) (string, error) {
+	bin, pinned := defaultKubectl, false
+	if r.Binary != nil {
+		if b := r.Binary(kctx); b != "" {
+			bin, pinned = b, true
+		}
+	}
+	if (pinned || r.Dir == "") && r.MaxSkew <= 0 {
+		return bin, nil
+	}
+
+	client, server, err := Versions(ctx, bin, kctx)
+	if err != nil {
+		return "", err
+	}
+	if !pinned && r.Dir != "" {
+		if b, v, err := ManagedBinary(r.Dir, server); err == nil {
+			bin, client = b, v
+		}
+	}
+	if r.MaxSkew > 0 && client.Skew(server) > r.MaxSkew {
+		return "", fmt.Errorf("kubectl %s is too far from server %s (max skew %d), pin a matching kubectl for this context", client, server, r.MaxSkew)
+	}
+
+	return bin, nil
+}
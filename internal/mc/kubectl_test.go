@@ -0,0 +1,92 @@
+package mc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	uu := map[string]struct {
+		s   string
+		e   Version
+		err bool
+	}{
+		"full":    {s: "v1.30.2", e: Version{Major: 1, Minor: 30, Patch: 2}},
+		"rke2":    {s: "v1.31.4+rke2r1", e: Version{Major: 1, Minor: 31, Patch: 4}},
+		"minor":   {s: "1.29", e: Version{Major: 1, Minor: 29}},
+		"invalid": {s: "latest", err: true},
+	}
+
+	for name, tc := range uu {
+		t.Run(name, func(t *testing.T) {
+			v, err := ParseVersion(tc.s)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.e, v)
+		})
+	}
+}
+
+func TestVersionSkew(t *testing.T) {
+	v := Version{Major: 1, Minor: 30}
+	assert.Equal(t, 0, v.Skew(Version{Major: 1, Minor: 30, Patch: 5}))
+	assert.Equal(t, 2, v.Skew(Version{Major: 1, Minor: 28}))
+	assert.Equal(t, 1, v.Skew(Version{Major: 1, Minor: 31}))
+	assert.Equal(t, 100, v.Skew(Version{Major: 2, Minor: 30}))
+}
+
+func TestParseVersions(t *testing.T) {
+	c, s, err := parseVersions([]byte(`{
+  "clientVersion": {"major": "1", "minor": "30", "gitVersion": "v1.30.2"},
+  "serverVersion": {"major": "1", "minor": "28+", "gitVersion": "v1.28.9+k3s1"}
+}`))
+	require.NoError(t, err)
+	assert.Equal(t, Version{Major: 1, Minor: 30, Patch: 2}, c)
+	assert.Equal(t, Version{Major: 1, Minor: 28, Patch: 9}, s)
+
+	_, _, err = parseVersions([]byte(`{"clientVersion": {"gitVersion": "v1.30.2"}}`))
+	assert.Error(t, err)
+}
+
+func TestManagedBinary(t *testing.T) {
+	dir := t.TempDir()
+	for _, n := range []string{"kubectl-v1.30.1", "kubectl-v1.30.4", "kubectl-1.29", "kubectl.bak", "README"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, n), nil, 0o600))
+	}
+
+	bin, v, err := ManagedBinary(dir, Version{Major: 1, Minor: 30, Patch: 9})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "kubectl-v1.30.4"), bin)
+	assert.Equal(t, Version{Major: 1, Minor: 30, Patch: 4}, v)
+
+	bin, _, err = ManagedBinary(dir, Version{Major: 1, Minor: 29})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "kubectl-1.29"), bin)
+
+	_, _, err = ManagedBinary(dir, Version{Major: 1, Minor: 31})
+	assert.EqualError(t, err, "no kubectl v1.31 found in "+dir)
+}
+
+func TestRunnerResolve(t *testing.T) {
+	bin, err := Runner{}.resolve(context.Background(), "c1")
+	require.NoError(t, err)
+	assert.Equal(t, "kubectl", bin)
+
+	r := Runner{Binary: func(ctx string) string {
+		if ctx == "c1" {
+			return "/opt/kubectl-1.28"
+		}
+		return ""
+	}}
+	bin, err = r.resolve(context.Background(), "c1")
+	require.NoError(t, err)
+	assert.Equal(t, "/opt/kubectl-1.28", bin)
+}
@@ -6,81 +6,259 @@ package mc
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
 	"sync"
+	"time"
 )
 
-const defaultMaxProc = 10
+const (
+	defaultMaxProc = 10
+
+	// waitDelay bounds the wait on children, ie auth plugins, holding on to
+	// kubectl output once it is killed.
+	waitDelay = time.Second
+)
 
 // Result holds the output from a single context execution.
 type Result struct {
-	Context string
-	Output  string
-	Err     error
+	Context  string
+	Output   string
+	Err      error
+	ExitCode int
+	Duration time.Duration
+}
+
+// Failed returns true if the execution failed.
+func (r Result) Failed() bool {
+	return r.Err != nil
+}
+
+// Runner runs kubectl commands across contexts.
+type Runner struct {
+	// MaxProc limits concurrent runs (0 = default 10).
+	MaxProc int
+	// Binary returns the kubectl binary pinned for a context, if any.
+	Binary func(context string) string
+	// Dir holds kubectl binaries named after their version. The binary
+	// matching the context API server is used unless one is pinned.
+	Dir string
+	// MaxSkew tracks the max minor skew between kubectl and the API server
+	// (0 = no check).
+	MaxSkew int
+	// Timeout bounds each context run (0 = no timeout).
+	Timeout time.Duration
+	// Deadline bounds the whole run across contexts (0 = no deadline).
+	Deadline time.Duration
+	// Check vetoes a context before kubectl runs on it, if set.
+	Check func(context string) error
 }
 
 // RunParallel executes kubectl with the given args across all contexts in parallel.
 // maxProc limits concurrent goroutines (0 = default 10).
 // Returns results in the same order as the input contexts.
 func RunParallel(contexts []string, args []string, maxProc int) []Result {
+	return Runner{MaxProc: maxProc}.Run(contexts, args)
+}
+
+// Retry re-runs kubectl on the failed contexts only and returns the results
+// with the failed entries replaced, in the same order.
+func Retry(results []Result, args []string, maxProc int) []Result {
+	return Runner{MaxProc: maxProc}.Retry(results, args)
+}
+
+// Run executes kubectl with the given args across all contexts in parallel.
+// Returns results in the same order as the input contexts.
+func (r Runner) Run(contexts []string, args []string) []Result {
+	return r.RunContext(context.Background(), contexts, args)
+}
+
+// RunContext executes kubectl with the given args across all contexts in
+// parallel until ctx is done. Returns results in the same order as the input
+// contexts.
+func (r Runner) RunContext(ctx context.Context, contexts []string, args []string) []Result {
+	maxProc := r.MaxProc
 	if maxProc <= 0 {
 		maxProc = defaultMaxProc
 	}
 
+	ctx, cancel := withTimeout(ctx, r.Deadline)
+	defer cancel()
+
 	results := make([]Result, len(contexts))
 	sem := make(chan struct{}, maxProc)
 	var wg sync.WaitGroup
 
-	for i, ctx := range contexts {
+	for i, c := range contexts {
 		wg.Add(1)
 		sem <- struct{}{}
-		go func(idx int, context string) {
+		go func(idx int, kctx string) {
 			defer wg.Done()
 			defer func() { <-sem }()
 
-			localArgs := injectContext(args, context)
-			cmd := exec.Command("kubectl", localArgs...)
-			var stdout, stderr bytes.Buffer
-			cmd.Stdout = &stdout
-			cmd.Stderr = &stderr
-
-			err := cmd.Run()
-			r := Result{Context: context}
-			if err != nil {
-				errMsg := strings.TrimSpace(stderr.String())
-				if errMsg == "" {
-					errMsg = err.Error()
-				}
-				r.Err = fmt.Errorf("%s", errMsg)
-				r.Output = errMsg
-			} else {
-				r.Output = stdout.String()
-			}
-			results[idx] = r
-		}(i, ctx)
+			results[idx] = r.run(ctx, kctx, args)
+		}(i, c)
 	}
 	wg.Wait()
 
 	return results
 }
 
-// FormatResults produces a human-readable multi-context output like kubectl-mc.
-func FormatResults(results []Result) string {
-	var b strings.Builder
+// Retry re-runs kubectl on the failed contexts only and returns the results
+// with the failed entries replaced, in the same order.
+func (r Runner) Retry(results []Result, args []string) []Result {
+	return r.RetryContext(context.Background(), results, args)
+}
+
+// RetryContext re-runs kubectl on the failed contexts only until ctx is done
+// and returns the results with the failed entries replaced, in the same order.
+func (r Runner) RetryContext(ctx context.Context, results []Result, args []string) []Result {
+	failed := FailedContexts(results)
+	if len(failed) == 0 {
+		return results
+	}
+	retried := make(map[string]Result, len(failed))
+	for _, res := range r.RunContext(ctx, failed, args) {
+		retried[res.Context] = res
+	}
+
+	rr := make([]Result, 0, len(results))
+	for _, res := range results {
+		if rt, ok := retried[res.Context]; ok && res.Failed() {
+			res = rt
+		}
+		rr = append(rr, res)
+	}
+
+	return rr
+}
+
+// FailedContexts returns the contexts whose execution failed.
+func FailedContexts(results []Result) []string {
+	var cc []string
+	for _, r := range results {
+		if r.Failed() {
+			cc = append(cc, r.Context)
+		}
+	}
+	return cc
+}
+
+// Summary tracks the outcome of a multi-context run.
+type Summary struct {
+	OK, Failed int
+	Slowest    string
+	Longest    time.Duration
+}
+
+// Summarize returns the summary of a multi-context run.
+func Summarize(results []Result) Summary {
+	var s Summary
 	for _, r := range results {
-		header := r.Context
-		b.WriteString(fmt.Sprintf("\n%s\n%s\n", header, strings.Repeat("-", len(header))))
-		if r.Err != nil {
-			b.WriteString(fmt.Sprintf("  (error) %s\n", r.Output))
+		if r.Failed() {
+			s.Failed++
 		} else {
-			b.WriteString(r.Output)
+			s.OK++
+		}
+		if s.Slowest == "" || r.Duration > s.Longest {
+			s.Slowest, s.Longest = r.Context, r.Duration
 		}
 	}
+	return s
+}
+
+// String returns a one line summary, ie `2 ok / 1 failed, slowest c1 (1.2s)`.
+func (s Summary) String() string {
+	msg := fmt.Sprintf("%d ok / %d failed", s.OK, s.Failed)
+	if s.Slowest != "" {
+		msg += fmt.Sprintf(", slowest %s (%s)", s.Slowest, s.Longest.Round(100*time.Millisecond))
+	}
+	return msg
+}
+
+// withTimeout bounds ctx when d is set.
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, d)
+}
+
+func (r Runner) run(ctx context.Context, kctx string, args []string) Result {
+	t := time.Now()
+	if r.Check != nil {
+		if err := r.Check(kctx); err != nil {
+			return Result{Context: kctx, Err: err, Output: err.Error(), ExitCode: -1, Duration: time.Since(t)}
+		}
+	}
+	ctx, cancel := withTimeout(ctx, r.Timeout)
+	defer cancel()
+
+	bin, err := r.resolve(ctx, kctx)
+	if err != nil {
+		return Result{Context: kctx, Err: err, Output: err.Error(), ExitCode: -1, Duration: time.Since(t)}
+	}
+
+	localArgs := injectContext(args, kctx)
+	cmd := exec.CommandContext(ctx, bin, localArgs...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = waitDelay
+
+	err = cmd.Run()
+	res := Result{Context: kctx, Duration: time.Since(t)}
+	if err != nil {
+		errMsg := strings.TrimSpace(stderr.String())
+		if ctx.Err() != nil {
+			errMsg = fmt.Sprintf("kubectl timed out after %s", res.Duration.Round(time.Millisecond))
+		}
+		if errMsg == "" {
+			errMsg = err.Error()
+		}
+		res.Err = fmt.Errorf("%s", errMsg)
+		res.Output = errMsg
+		res.ExitCode = exitCode(err)
+	} else {
+		res.Output = stdout.String()
+	}
+
+	return res
+}
+
+// exitCode returns the process exit code or -1 if kubectl did not run.
+func exitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// FormatResults produces a human-readable multi-context output like kubectl-mc.
+func FormatResults(results []Result) string {
+	var b strings.Builder
+	_ = TextRenderer{}.Render(&b, results)
 	return b.String()
 }
 
+// Format renders the results in the given output format.
+func Format(results []Result, format string) (string, error) {
+	r, err := NewRenderer(format)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := r.Render(&b, results); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
 // injectContext adds --context <ctx> to the kubectl args.
 // If args contain "--", inject before it.
 func injectContext(args []string, context string) []string {
@@ -1,9 +1,15 @@
 package mc
 
 import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInjectContext(t *testing.T) {
@@ -48,3 +54,77 @@ func TestFormatResults(t *testing.T) {
 	assert.Contains(t, out, "ctx-2")
 	assert.Contains(t, out, "(error) connection refused")
 }
+
+func TestSummarize(t *testing.T) {
+	uu := map[string]struct {
+		results []Result
+		e       string
+	}{
+		"empty": {
+			e: "0 ok / 0 failed",
+		},
+		"mixed": {
+			results: []Result{
+				{Context: "c1", Duration: 300 * time.Millisecond},
+				{Context: "c2", Err: assert.AnError, ExitCode: 1, Duration: 2120 * time.Millisecond},
+				{Context: "c3", Duration: time.Second},
+			},
+			e: "2 ok / 1 failed, slowest c2 (2.1s)",
+		},
+	}
+
+	for name, tc := range uu {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.e, Summarize(tc.results).String())
+		})
+	}
+}
+
+func TestFailedContexts(t *testing.T) {
+	results := []Result{
+		{Context: "c1"},
+		{Context: "c2", Err: assert.AnError},
+		{Context: "c3", Err: assert.AnError},
+	}
+	assert.Equal(t, []string{"c2", "c3"}, FailedContexts(results))
+	assert.Empty(t, FailedContexts(results[:1]))
+}
+
+func TestRetryNoFailures(t *testing.T) {
+	results := []Result{{Context: "c1", Output: "ok"}}
+	assert.Equal(t, results, Retry(results, []string{"get", "nodes"}, 0))
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, -1, exitCode(assert.AnError))
+
+	err := exec.Command("sh", "-c", "exit 3").Run()
+	assert.Equal(t, 3, exitCode(err))
+}
+
+func TestRunnerTimeout(t *testing.T) {
+	bin := filepath.Join(t.TempDir(), "kubectl")
+	require.NoError(t, os.WriteFile(bin, []byte("#!/bin/sh\nsleep 5\n"), 0o700))
+	r := Runner{
+		Binary:  func(string) string { return bin },
+		Timeout: 100 * time.Millisecond,
+	}
+
+	t0 := time.Now()
+	rr := r.Run([]string{"c1"}, []string{"get", "nodes"})
+	assert.Less(t, time.Since(t0), 5*time.Second)
+	require.Len(t, rr, 1)
+	assert.True(t, rr[0].Failed())
+	assert.Contains(t, rr[0].Output, "kubectl timed out")
+}
+
+func TestRunnerCheck(t *testing.T) {
+	r := Runner{Check: func(kctx string) error {
+		return fmt.Errorf("context %s is read-only", kctx)
+	}}
+	rr := r.Run([]string{"prod"}, []string{"delete", "po", "web"})
+
+	require.Len(t, rr, 1)
+	assert.EqualError(t, rr[0].Err, "context prod is read-only")
+	assert.Equal(t, -1, rr[0].ExitCode)
+}
@@ -0,0 +1,249 @@
+package mc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats supported by the renderers.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+	FormatTable = "table"
+)
+
+// Renderer renders multi-context results.
+type Renderer interface {
+	// Render writes the results to w.
+	Render(w io.Writer, results []Result) error
+}
+
+// NewRenderer returns the renderer for an output format.
+func NewRenderer(format string) (Renderer, error) {
+	switch strings.ToLower(format) {
+	case "", FormatText:
+		return TextRenderer{}, nil
+	case FormatJSON:
+		return JSONRenderer{}, nil
+	case FormatYAML:
+		return YAMLRenderer{}, nil
+	case FormatTable:
+		return TableRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q, expecting text, json, yaml or table", format)
+	}
+}
+
+// NeedsJSON returns true if the format renders parsed `kubectl -o json` output.
+func NeedsJSON(format string) bool {
+	f := strings.ToLower(format)
+	return f == FormatJSON || f == FormatYAML || f == FormatTable
+}
+
+// JSONArgs forces kubectl to output json, replacing any output flag.
+func JSONArgs(args []string) []string {
+	local := make([]string, 0, len(args)+2)
+	injected := false
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" && !injected {
+			local = append(local, "-o", "json")
+			injected = true
+		}
+		if injected {
+			local = append(local, a)
+			continue
+		}
+		switch {
+		case a == "-o" || a == "--output":
+			i++
+			continue
+		case strings.HasPrefix(a, "-o=") || strings.HasPrefix(a, "--output="):
+			continue
+		case strings.HasPrefix(a, "-o") && len(a) > 2:
+			continue
+		}
+		local = append(local, a)
+	}
+	if !injected {
+		local = append(local, "-o", "json")
+	}
+	return local
+}
+
+// ContextItems holds the objects returned by a context.
+type ContextItems struct {
+	Context  string           `json:"context"`
+	Items    []map[string]any `json:"items,omitempty"`
+	Error    string           `json:"error,omitempty"`
+	ExitCode int              `json:"exitCode,omitempty"`
+}
+
+// Decode parses the `kubectl -o json` output of each context. Lists are
+// flattened to their items. Contexts failing to run or parse carry an error.
+func Decode(results []Result) []ContextItems {
+	cc := make([]ContextItems, 0, len(results))
+	for _, r := range results {
+		c := ContextItems{Context: r.Context}
+		if r.Err != nil {
+			c.Error, c.ExitCode = r.Err.Error(), r.ExitCode
+			cc = append(cc, c)
+			continue
+		}
+		ii, err := parseItems(r.Output)
+		if err != nil {
+			c.Error = err.Error()
+		}
+		c.Items = ii
+		cc = append(cc, c)
+	}
+	return cc
+}
+
+func parseItems(out string) ([]map[string]any, error) {
+	if strings.TrimSpace(out) == "" {
+		return nil, nil
+	}
+	var o map[string]any
+	if err := json.Unmarshal([]byte(out), &o); err != nil {
+		return nil, fmt.Errorf("invalid json output: %w", err)
+	}
+	raw, ok := o["items"]
+	if !ok {
+		return []map[string]any{o}, nil
+	}
+	ll, ok := raw.([]any)
+	if !ok {
+		return nil, errors.New("invalid json output: items is not a list")
+	}
+	ii := make([]map[string]any, 0, len(ll))
+	for _, l := range ll {
+		if m, ok := l.(map[string]any); ok {
+			ii = append(ii, m)
+		}
+	}
+	return ii, nil
+}
+
+// TextRenderer renders the raw output of each context like kubectl-mc.
+type TextRenderer struct{}
+
+// Render writes each context output under a context header.
+func (TextRenderer) Render(w io.Writer, results []Result) error {
+	for _, r := range results {
+		header := r.Context
+		if _, err := fmt.Fprintf(w, "\n%s\n%s\n", header, strings.Repeat("-", len(header))); err != nil {
+			return err
+		}
+		var err error
+		if r.Err != nil {
+			_, err = fmt.Fprintf(w, "  (error) %s\n", r.Output)
+		} else {
+			_, err = io.WriteString(w, r.Output)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// JSONRenderer renders the decoded objects of each context as json.
+type JSONRenderer struct{}
+
+// Render writes the decoded results as a json list.
+func (JSONRenderer) Render(w io.Writer, results []Result) error {
+	bb, err := json.MarshalIndent(Decode(results), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", bb)
+	return err
+}
+
+// YAMLRenderer renders the decoded objects of each context as yaml.
+type YAMLRenderer struct{}
+
+// Render writes the decoded results as a yaml list.
+func (YAMLRenderer) Render(w io.Writer, results []Result) error {
+	bb, err := yaml.Marshal(Decode(results))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(bb)
+	return err
+}
+
+// TableRenderer renders one row per object across all contexts.
+type TableRenderer struct {
+	now func() time.Time
+}
+
+// Render writes the decoded objects as a table, failed contexts are listed last.
+func (t TableRenderer) Render(w io.Writer, results []Result) error {
+	now := time.Now()
+	if t.now != nil {
+		now = t.now()
+	}
+	cc := Decode(results)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CONTEXT\tNAMESPACE\tKIND\tNAME\tAGE")
+	for _, c := range cc {
+		for _, o := range c.Items {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+				c.Context,
+				orDash(metaField(o, "namespace")),
+				orDash(stringField(o, "kind")),
+				orDash(metaField(o, "name")),
+				age(metaField(o, "creationTimestamp"), now),
+			)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, c := range cc {
+		if c.Error == "" {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s: (error) %s\n", c.Context, c.Error); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func stringField(o map[string]any, k string) string {
+	s, _ := o[k].(string)
+	return s
+}
+
+func metaField(o map[string]any, k string) string {
+	m, _ := o["metadata"].(map[string]any)
+	return stringField(m, k)
+}
+
+func age(ts string, now time.Time) string {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return "-"
+	}
+	return duration.HumanDuration(now.Sub(t))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,150 @@
+package mc
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	podListJSON = `{"kind":"List","items":[
+  {"kind":"Pod","metadata":{"namespace":"default","name":"p1","creationTimestamp":"2026-01-01T10:00:00Z"}},
+  {"kind":"Pod","metadata":{"namespace":"kube-system","name":"p2","creationTimestamp":"2026-01-01T11:00:00Z"}}
+]}`
+	nodeJSON = `{"kind":"Node","metadata":{"name":"n1","creationTimestamp":"2026-01-01T11:30:00Z"}}`
+)
+
+func TestNewRenderer(t *testing.T) {
+	uu := map[string]struct {
+		format string
+		e      Renderer
+		err    bool
+	}{
+		"blank":   {e: TextRenderer{}},
+		"text":    {format: "text", e: TextRenderer{}},
+		"json":    {format: "JSON", e: JSONRenderer{}},
+		"yaml":    {format: "yaml", e: YAMLRenderer{}},
+		"table":   {format: "table", e: TableRenderer{}},
+		"unknown": {format: "wide", err: true},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			r, err := NewRenderer(u.format)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.e, r)
+		})
+	}
+}
+
+func TestJSONArgs(t *testing.T) {
+	uu := map[string]struct {
+		args, e []string
+	}{
+		"plain": {
+			args: []string{"get", "pods"},
+			e:    []string{"get", "pods", "-o", "json"},
+		},
+		"output": {
+			args: []string{"get", "pods", "-o", "wide", "-A"},
+			e:    []string{"get", "pods", "-A", "-o", "json"},
+		},
+		"long-output": {
+			args: []string{"get", "--output=yaml", "pods"},
+			e:    []string{"get", "pods", "-o", "json"},
+		},
+		"short-output": {
+			args: []string{"get", "-oyaml", "pods"},
+			e:    []string{"get", "pods", "-o", "json"},
+		},
+		"double-dash": {
+			args: []string{"get", "pods", "--", "-o", "wide"},
+			e:    []string{"get", "pods", "-o", "json", "--", "-o", "wide"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, JSONArgs(u.args))
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	cc := Decode([]Result{
+		{Context: "c1", Output: podListJSON},
+		{Context: "c2", Output: nodeJSON},
+		{Context: "c3", Err: errors.New("connection refused"), Output: "connection refused"},
+		{Context: "c4", Output: "NAME READY"},
+		{Context: "c5"},
+	})
+
+	require.Len(t, cc, 5)
+	assert.Len(t, cc[0].Items, 2)
+	assert.Empty(t, cc[0].Error)
+	assert.Len(t, cc[1].Items, 1)
+	assert.Equal(t, "Node", cc[1].Items[0]["kind"])
+	assert.Equal(t, "connection refused", cc[2].Error)
+	assert.Contains(t, cc[3].Error, "invalid json output")
+	assert.Empty(t, cc[4].Items)
+	assert.Empty(t, cc[4].Error)
+}
+
+func TestJSONRenderer(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, JSONRenderer{}.Render(&b, []Result{
+		{Context: "c1", Output: nodeJSON},
+		{Context: "c2", Err: errors.New("boom")},
+	}))
+
+	assert.Contains(t, b.String(), `"context": "c1"`)
+	assert.Contains(t, b.String(), `"name": "n1"`)
+	assert.Contains(t, b.String(), `"error": "boom"`)
+}
+
+func TestYAMLRenderer(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, YAMLRenderer{}.Render(&b, []Result{{Context: "c1", Output: nodeJSON}}))
+
+	assert.Contains(t, b.String(), "- context: c1\n")
+	assert.Contains(t, b.String(), "name: n1\n")
+}
+
+func TestTableRenderer(t *testing.T) {
+	now, _ := time.Parse(time.RFC3339, "2026-01-01T12:00:00Z")
+	r := TableRenderer{now: func() time.Time { return now }}
+
+	var b strings.Builder
+	require.NoError(t, r.Render(&b, []Result{
+		{Context: "c1", Output: podListJSON},
+		{Context: "c2", Output: nodeJSON},
+		{Context: "c3", Err: errors.New("boom")},
+	}))
+
+	e := `CONTEXT  NAMESPACE    KIND  NAME  AGE
+c1       default      Pod   p1    120m
+c1       kube-system  Pod   p2    60m
+c2       -            Node  n1    30m
+c3: (error) boom
+`
+	assert.Equal(t, e, b.String())
+}
+
+func TestFormat(t *testing.T) {
+	out, err := Format([]Result{{Context: "c1", Output: "n1 Ready\n"}}, "")
+	require.NoError(t, err)
+	assert.Equal(t, FormatResults([]Result{{Context: "c1", Output: "n1 Ready\n"}}), out)
+
+	_, err = Format(nil, "wide")
+	assert.Error(t, err)
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Session health states.
+const (
+	HealthOK           = "ok"
+	HealthSuspended    = "suspended"
+	HealthDisconnected = "disconnected"
+	HealthUnresponsive = "unresponsive"
+)
+
+var (
+	// Contacts tracks the last successful API server call by context.
+	Contacts = NewStamps()
+
+	// Refreshes tracks the last view refresh by resource.
+	Refreshes = NewStamps()
+)
+
+// Stamps tracks the last occurrence of events by key.
+type Stamps struct {
+	at map[string]time.Time
+	mx sync.Mutex
+}
+
+// NewStamps returns a new instance.
+func NewStamps() *Stamps {
+	return &Stamps{at: make(map[string]time.Time)}
+}
+
+// Touch records an event occurrence now.
+func (s *Stamps) Touch(k string) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.at[k] = time.Now()
+}
+
+// Get returns the last occurrence of an event if any.
+func (s *Stamps) Get(k string) (time.Time, bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	t, ok := s.at[k]
+
+	return t, ok
+}
+
+// Snapshot returns a copy of all the events last occurrences.
+func (s *Stamps) Snapshot() map[string]time.Time {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	m := make(map[string]time.Time, len(s.at))
+	for k, t := range s.at {
+		m[k] = t
+	}
+
+	return m
+}
+
+// Health represents an rk9s session health.
+type Health struct {
+	Status        string          `json:"status"`
+	PID           int             `json:"pid"`
+	Version       string          `json:"version"`
+	StartedAt     time.Time       `json:"startedAt"`
+	Uptime        float64         `json:"uptimeSeconds"`
+	ActiveContext string          `json:"activeContext"`
+	Contexts      []ContextHealth `json:"contexts"`
+	Views         []ViewHealth    `json:"views"`
+}
+
+// ContextHealth represents a connected context health.
+type ContextHealth struct {
+	Name           string     `json:"name"`
+	Active         bool       `json:"active"`
+	LastContact    *time.Time `json:"lastContact,omitempty"`
+	LastContactAge *float64   `json:"lastContactAgeSeconds,omitempty"`
+}
+
+// ViewHealth represents a resource view last refresh.
+type ViewHealth struct {
+	Resource       string    `json:"resource"`
+	LastRefresh    time.Time `json:"lastRefresh"`
+	LastRefreshAge float64   `json:"lastRefreshAgeSeconds"`
+}
+
+// NewContextHealth returns the health of the given contexts as of now.
+func NewContextHealth(active string, ctxs []string, now time.Time) []ContextHealth {
+	hh := make([]ContextHealth, 0, len(ctxs))
+	for _, c := range ctxs {
+		h := ContextHealth{Name: c, Active: c == active}
+		if t, ok := Contacts.Get(c); ok {
+			age := ageOf(t, now)
+			h.LastContact, h.LastContactAge = &t, &age
+		}
+		hh = append(hh, h)
+	}
+
+	return hh
+}
+
+// NewViewHealth returns the views last refreshes as of now.
+func NewViewHealth(now time.Time) []ViewHealth {
+	snap := Refreshes.Snapshot()
+	vv := make([]ViewHealth, 0, len(snap))
+	for res, t := range snap {
+		vv = append(vv, ViewHealth{Resource: res, LastRefresh: t, LastRefreshAge: ageOf(t, now)})
+	}
+	sort.Slice(vv, func(i, j int) bool {
+		return vv[i].Resource < vv[j].Resource
+	})
+
+	return vv
+}
+
+// HealthHandler returns a handler serving the session health as JSON. An
+// unresponsive session is reported with a 503 status code. The request
+// context bounds the health check.
+func HealthHandler(health func(context.Context) Health) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := health(r.Context())
+		w.Header().Set("Content-Type", "application/json")
+		if h.Status == HealthUnresponsive {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(h)
+	})
+}
+
+func ageOf(t, now time.Time) float64 {
+	return math.Round(now.Sub(t).Seconds()*10) / 10
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package metrics_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentTransportContacts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := http.Client{Transport: metrics.InstrumentTransport("blee")(http.DefaultTransport)}
+	resp, err := c.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	at, ok := metrics.Contacts.Get("blee")
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now(), at, time.Minute)
+}
+
+func TestNewContextHealth(t *testing.T) {
+	metrics.Contacts.Touch("c1")
+	now := time.Now().Add(10 * time.Second)
+
+	hh := metrics.NewContextHealth("c1", []string{"c1", "c-none"}, now)
+	require.Len(t, hh, 2)
+
+	assert.Equal(t, "c1", hh[0].Name)
+	assert.True(t, hh[0].Active)
+	require.NotNil(t, hh[0].LastContactAge)
+	assert.InDelta(t, 10, *hh[0].LastContactAge, 1)
+
+	assert.Equal(t, "c-none", hh[1].Name)
+	assert.False(t, hh[1].Active)
+	assert.Nil(t, hh[1].LastContact)
+	assert.Nil(t, hh[1].LastContactAge)
+}
+
+func TestHealthHandler(t *testing.T) {
+	uu := map[string]struct {
+		status string
+		code   int
+	}{
+		"ok": {
+			status: metrics.HealthOK,
+			code:   http.StatusOK,
+		},
+		"disconnected": {
+			status: metrics.HealthDisconnected,
+			code:   http.StatusOK,
+		},
+		"unresponsive": {
+			status: metrics.HealthUnresponsive,
+			code:   http.StatusServiceUnavailable,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			h := metrics.HealthHandler(func(context.Context) metrics.Health {
+				return metrics.Health{Status: u.status, ActiveContext: "fred"}
+			})
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", http.NoBody))
+
+			assert.Equal(t, u.code, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			var out metrics.Health
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+			assert.Equal(t, u.status, out.Status)
+			assert.Equal(t, "fred", out.ActiveContext)
+		})
+	}
+}
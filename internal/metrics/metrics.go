@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+// Package metrics tracks rk9s own usage metrics and exposes them in the
+// Prometheus text format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const labelSep = "\xff"
+
+var (
+	// APICalls tracks the API server calls by context, method and status code.
+	APICalls = NewCounter("rk9s_api_calls_total", "API server calls by context, method and status code.", "context", "method", "code")
+
+	// RefreshDuration tracks the view refresh durations by resource.
+	RefreshDuration = NewSummary("rk9s_refresh_duration_seconds", "View refresh durations by resource.", "resource")
+
+	// Informers tracks the active informers.
+	Informers = NewGauge("rk9s_informers", "Active resource informers.")
+
+	// InformerEvents tracks the watch events by context, resource and namespace.
+	InformerEvents = NewCounter("rk9s_informer_events_total", "Informer watch events by context, resource and namespace.", "context", "resource", "namespace")
+
+	// InformerErrors tracks the watch failures by context, resource and namespace.
+	InformerErrors = NewCounter("rk9s_informer_errors_total", "Informer watch failures by context, resource and namespace.", "context", "resource", "namespace")
+
+	// PluginRuns tracks the plugin executions by plugin and status.
+	PluginRuns = NewCounter("rk9s_plugin_executions_total", "Plugin executions by plugin and status.", "plugin", "status")
+
+	// Errors tracks the errors reported to the user.
+	Errors = NewCounter("rk9s_errors_total", "Errors reported to the user.")
+)
+
+type collector interface {
+	write(w io.Writer)
+}
+
+var registry = []collector{APICalls, RefreshDuration, Informers, InformerEvents, InformerErrors, PluginRuns, Errors}
+
+// Counter represents a monotonic counter partitioned by labels.
+type Counter struct {
+	name, help string
+	labels     []string
+	vals       map[string]float64
+	mx         sync.Mutex
+}
+
+// NewCounter returns a new counter.
+func NewCounter(name, help string, labels ...string) *Counter {
+	return &Counter{name: name, help: help, labels: labels, vals: make(map[string]float64)}
+}
+
+// Inc increments the counter for the given label values.
+func (c *Counter) Inc(vv ...string) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.vals[strings.Join(vv, labelSep)]++
+}
+
+// Value returns the counter value for the given label values.
+func (c *Counter) Value(vv ...string) float64 {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return c.vals[strings.Join(vv, labelSep)]
+}
+
+func (c *Counter) write(w io.Writer) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	writeHeader(w, c.name, c.help, "counter")
+	for _, k := range sortedKeys(c.vals) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, fmtLabels(c.labels, k), fmtFloat(c.vals[k]))
+	}
+}
+
+// Summary represents observation counts and sums partitioned by labels.
+type Summary struct {
+	name, help string
+	labels     []string
+	sums       map[string]float64
+	counts     map[string]float64
+	mx         sync.Mutex
+}
+
+// NewSummary returns a new summary.
+func NewSummary(name, help string, labels ...string) *Summary {
+	return &Summary{
+		name:   name,
+		help:   help,
+		labels: labels,
+		sums:   make(map[string]float64),
+		counts: make(map[string]float64),
+	}
+}
+
+// Observe records an observation for the given label values.
+func (s *Summary) Observe(v float64, vv ...string) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	k := strings.Join(vv, labelSep)
+	s.sums[k] += v
+	s.counts[k]++
+}
+
+func (s *Summary) write(w io.Writer) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	writeHeader(w, s.name, s.help, "summary")
+	for _, k := range sortedKeys(s.counts) {
+		ll := fmtLabels(s.labels, k)
+		fmt.Fprintf(w, "%s_sum%s %s\n", s.name, ll, fmtFloat(s.sums[k]))
+		fmt.Fprintf(w, "%s_count%s %s\n", s.name, ll, fmtFloat(s.counts[k]))
+	}
+}
+
+// Gauge represents a value that goes up and down.
+type Gauge struct {
+	name, help string
+	val        float64
+	mx         sync.Mutex
+}
+
+// NewGauge returns a new gauge.
+func NewGauge(name, help string) *Gauge {
+	return &Gauge{name: name, help: help}
+}
+
+// Set sets the gauge value.
+func (g *Gauge) Set(v float64) {
+	g.mx.Lock()
+	defer g.mx.Unlock()
+
+	g.val = v
+}
+
+func (g *Gauge) write(w io.Writer) {
+	g.mx.Lock()
+	defer g.mx.Unlock()
+
+	writeHeader(w, g.name, g.help, "gauge")
+	fmt.Fprintf(w, "%s %s\n", g.name, fmtFloat(g.val))
+}
+
+// Write writes all metrics in the Prometheus text exposition format.
+func Write(w io.Writer) {
+	for _, c := range registry {
+		c.write(w)
+	}
+}
+
+// Handler returns a handler serving the metrics.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		Write(w)
+	})
+}
+
+// InstrumentTransport returns a transport wrapper counting the API calls of a context.
+func InstrumentTransport(context string) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return roundTripper{context: context, next: rt}
+	}
+}
+
+type roundTripper struct {
+	context string
+	next    http.RoundTripper
+}
+
+// RoundTrip counts the request once it completes.
+func (r roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+		if resp.StatusCode < http.StatusInternalServerError {
+			Contacts.Touch(r.context)
+		}
+	}
+	APICalls.Inc(r.context, req.Method, code)
+
+	return resp, err
+}
+
+func writeHeader(w io.Writer, name, help, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func fmtLabels(names []string, key string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	vv := strings.Split(key, labelSep)
+	ll := make([]string, 0, len(names))
+	for i, n := range names {
+		var v string
+		if i < len(vv) {
+			v = vv[i]
+		}
+		ll = append(ll, fmt.Sprintf("%s=%q", n, v))
+	}
+
+	return "{" + strings.Join(ll, ",") + "}"
+}
+
+func fmtFloat(v float64) string {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return "NaN"
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func sortedKeys(m map[string]float64) []string {
+	kk := make([]string, 0, len(m))
+	for k := range m {
+		kk = append(kk, k)
+	}
+	sort.Strings(kk)
+
+	return kk
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package metrics_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/derailed/k9s/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	metrics.PluginRuns.Inc("dive", "ok")
+	metrics.PluginRuns.Inc("dive", "ok")
+	metrics.PluginRuns.Inc(`say "hi"`, "failed")
+	metrics.RefreshDuration.Observe(0.5, "v1/pods")
+	metrics.RefreshDuration.Observe(0.25, "v1/pods")
+	metrics.Informers.Set(3)
+
+	var b bytes.Buffer
+	metrics.Write(&b)
+
+	out := b.String()
+	assert.Contains(t, out, "# TYPE rk9s_plugin_executions_total counter\n")
+	assert.Contains(t, out, `rk9s_plugin_executions_total{plugin="dive",status="ok"} 2`+"\n")
+	assert.Contains(t, out, `rk9s_plugin_executions_total{plugin="say \"hi\"",status="failed"} 1`+"\n")
+	assert.Contains(t, out, `rk9s_refresh_duration_seconds_sum{resource="v1/pods"} 0.75`+"\n")
+	assert.Contains(t, out, `rk9s_refresh_duration_seconds_count{resource="v1/pods"} 2`+"\n")
+	assert.Contains(t, out, "rk9s_informers 3\n")
+}
+
+func TestInstrumentTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	before := metrics.APICalls.Value("fred", http.MethodGet, "403")
+	c := http.Client{Transport: metrics.InstrumentTransport("fred")(http.DefaultTransport)}
+	resp, err := c.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.InDelta(t, before+1, metrics.APICalls.Value("fred", http.MethodGet, "403"), 0)
+}
@@ -139,7 +139,11 @@ func (c *ClusterInfo) Refresh() {
 		ctx, cancel := context.WithTimeout(context.Background(), c.cluster.factory.Client().Config().CallTimeout())
 		defer cancel()
 		var mx client.ClusterMetrics
-		if err := c.cluster.Metrics(ctx, &mx); err == nil {
+		if c.cfg.IsLowPower() {
+			c.mx.RLock()
+			data.Cpu, data.Mem, data.Ephemeral = c.data.Cpu, c.data.Mem, c.data.Ephemeral
+			c.mx.RUnlock()
+		} else if err := c.cluster.Metrics(ctx, &mx); err == nil {
 			data.Cpu, data.Mem, data.Ephemeral = mx.PercCPU, mx.PercMEM, mx.PercEphemeral
 		}
 	}
@@ -15,9 +15,13 @@ import (
 	backoff "github.com/cenkalti/backoff/v4"
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/render"
 	"github.com/derailed/k9s/internal/slogs"
 	"github.com/sahilm/fuzzy"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // Describe tracks describable resources.
@@ -30,6 +34,7 @@ type Describe struct {
 	refreshRate time.Duration
 	listeners   []ResourceViewerListener
 	decode      bool
+	stale       atomic.Bool
 }
 
 // NewDescribe returns a new describe resource model.
@@ -51,6 +56,13 @@ func (d *Describe) GetPath() string {
 	return d.path
 }
 
+// SetRefreshRate sets the describe refresh interval.
+func (d *Describe) SetRefreshRate(r time.Duration) {
+	if r > 0 {
+		d.refreshRate = r
+	}
+}
+
 // SetOptions toggle model options.
 func (*Describe) SetOptions(context.Context, ViewerToggleOpts) {}
 
@@ -104,8 +116,23 @@ func (d *Describe) Refresh(ctx context.Context) error {
 	return d.refresh(ctx)
 }
 
+// IsStale returns true if the current content came from an outdated cache entry.
+func (d *Describe) IsStale() bool {
+	return d.stale.Load()
+}
+
 // Watch watches for describe data changes.
 func (d *Describe) Watch(ctx context.Context) error {
+	if key, ok := d.fromCache(ctx); ok {
+		go func() {
+			// refresh reports the failure to the listeners.
+			if err := d.refresh(ctx); err != nil {
+				dropCached(key, &d.stale, err)
+			}
+			d.updater(ctx)
+		}()
+		return nil
+	}
 	if err := d.refresh(ctx); err != nil {
 		return err
 	}
@@ -113,11 +140,35 @@ func (d *Describe) Watch(ctx context.Context) error {
 	return nil
 }
 
+func (d *Describe) cacheKind() string {
+	if d.decode {
+		return "describe-decoded"
+	}
+
+	return "describe"
+}
+
+func (d *Describe) fromCache(ctx context.Context) (string, bool) {
+	key, rv, ok := detailKey(ctx, d.cacheKind(), d.gvr, d.path)
+	if !ok {
+		return "", false
+	}
+	lines, stale, ok := detailCache.Get(key, rv)
+	if !ok {
+		return "", false
+	}
+	d.lines = lines
+	d.stale.Store(stale)
+	d.fireResourceChanged(d.lines, d.filter(d.query, d.lines))
+
+	return key, true
+}
+
 func (d *Describe) updater(ctx context.Context) {
 	defer slog.Debug("Describe canceled", slogs.GVR, d.gvr)
 
-	backOff := NewExpBackOff(ctx, defaultReaderRefreshRate, maxReaderRetryInterval)
-	delay := defaultReaderRefreshRate
+	backOff := NewExpBackOff(ctx, d.refreshRate, maxReaderRetryInterval)
+	delay := d.refreshRate
 	for {
 		select {
 		case <-ctx.Done():
@@ -131,7 +182,7 @@ func (d *Describe) updater(ctx context.Context) {
 				}
 			} else {
 				backOff.Reset()
-				delay = defaultReaderRefreshRate
+				delay = d.refreshRate
 			}
 		}
 	}
@@ -162,7 +213,11 @@ func (d *Describe) reconcile(ctx context.Context) error {
 		return err
 	}
 	lines := strings.Split(s, "\n")
-	if reflect.DeepEqual(lines, d.lines) {
+	if key, rv, ok := detailKey(ctx, d.cacheKind(), d.gvr, d.path); ok {
+		detailCache.Put(key, rv, lines)
+	}
+	wasStale := d.stale.Swap(false)
+	if reflect.DeepEqual(lines, d.lines) && !wasStale {
 		return nil
 	}
 	d.lines = lines
@@ -185,7 +240,39 @@ func (d *Describe) describe(ctx context.Context, gvr *client.GVR, path string) (
 		desc.SetDecodeData(d.decode)
 	}
 
-	return desc.Describe(path)
+	s, err := desc.Describe(path)
+	if err != nil {
+		return "", err
+	}
+
+	return describeCharts(ctx, gvr, path) + s, nil
+}
+
+// describeCharts renders the chart panels declared in views.yaml for a resource.
+func describeCharts(ctx context.Context, gvr *client.GVR, path string) string {
+	cv, ok := ctx.Value(internal.KeyViewConfig).(*config.CustomView)
+	if !ok {
+		return ""
+	}
+	cc := cv.Charts(gvr.String())
+	if len(cc) == 0 {
+		return ""
+	}
+	f, ok := ctx.Value(internal.KeyFactory).(dao.Factory)
+	if !ok {
+		return ""
+	}
+	o, err := f.Get(gvr, path, true, labels.Everything())
+	if err != nil {
+		slog.Warn("Unable to fetch resource for charts", slogs.GVR, gvr, slogs.Error, err)
+		return ""
+	}
+	u, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return ""
+	}
+
+	return strings.Join(render.RenderCharts(u.Object, cc), "\n") + "\n\n"
 }
 
 // AddListener adds a new model listener.
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package model
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/slogs"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const detailCacheSize = 50
+
+// detailCache keeps the last rendered describe/yaml buffers for the session.
+var detailCache = NewDetailCache(detailCacheSize)
+
+// DetailCache tracks rendered detail buffers keyed by object uid and resource version.
+type DetailCache struct {
+	mx      sync.Mutex
+	size    int
+	entries []*detailEntry
+}
+
+type detailEntry struct {
+	key, rv string
+	lines   []string
+}
+
+// NewDetailCache returns a new cache holding at most size entries.
+func NewDetailCache(size int) *DetailCache {
+	return &DetailCache{size: size}
+}
+
+// Get returns the cached lines for a given key. The entry is flagged stale when
+// it was rendered for a different resource version.
+func (c *DetailCache) Get(key, rv string) (lines []string, stale, ok bool) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	for i, e := range c.entries {
+		if e.key != key {
+			continue
+		}
+		c.touch(i)
+		return e.lines, e.rv != rv, true
+	}
+
+	return nil, false, false
+}
+
+// Put adds or refreshes a cache entry, evicting the least recently used one if needed.
+func (c *DetailCache) Put(key, rv string, lines []string) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	for i, e := range c.entries {
+		if e.key == key {
+			e.rv, e.lines = rv, lines
+			c.touch(i)
+			return
+		}
+	}
+	c.entries = append([]*detailEntry{{key: key, rv: rv, lines: lines}}, c.entries...)
+	if len(c.entries) > c.size {
+		c.entries = c.entries[:c.size]
+	}
+}
+
+// Drop removes a cache entry.
+func (c *DetailCache) Drop(key string) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.entries = slices.DeleteFunc(c.entries, func(e *detailEntry) bool {
+		return e.key == key
+	})
+}
+
+// Len returns the number of cached entries.
+func (c *DetailCache) Len() int {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return len(c.entries)
+}
+
+func (c *DetailCache) touch(i int) {
+	e := c.entries[i]
+	copy(c.entries[1:i+1], c.entries[:i])
+	c.entries[0] = e
+}
+
+// dropCached evicts a cached buffer whose refresh failed so the next open
+// fetches afresh and surfaces the error, and flags the shown content stale.
+func dropCached(key string, stale *atomic.Bool, err error) {
+	slog.Warn("Cached detail refresh failed", slogs.Key, key, slogs.Error, err)
+	detailCache.Drop(key)
+	stale.Store(true)
+}
+
+// detailKey computes the cache key and resource version for a resource
+// using the informer cache, so no extra API round trip is incurred.
+func detailKey(ctx context.Context, kind string, gvr *client.GVR, path string) (key, rv string, ok bool) {
+	if m, err := dao.MetaAccess.MetaFor(gvr); err != nil || !dao.IsK8sMeta(m) {
+		return "", "", false
+	}
+	factory, isFactory := ctx.Value(internal.KeyFactory).(dao.Factory)
+	if !isFactory {
+		return "", "", false
+	}
+	o, err := factory.Get(gvr, path, false, labels.Everything())
+	if err != nil {
+		return "", "", false
+	}
+	mo, err := meta.Accessor(o)
+	if err != nil || mo.GetUID() == "" {
+		return "", "", false
+	}
+
+	return kind + ":" + gvr.String() + ":" + string(mo.GetUID()), mo.GetResourceVersion(), true
+}
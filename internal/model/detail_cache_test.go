@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetailCacheGet(t *testing.T) {
+	c := model.NewDetailCache(2)
+	c.Put("k1", "1", []string{"fred"})
+
+	uu := map[string]struct {
+		key, rv   string
+		ok, stale bool
+	}{
+		"hit":   {key: "k1", rv: "1", ok: true},
+		"stale": {key: "k1", rv: "2", ok: true, stale: true},
+		"miss":  {key: "k2", rv: "1"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ll, stale, ok := c.Get(u.key, u.rv)
+			assert.Equal(t, u.ok, ok)
+			assert.Equal(t, u.stale, stale)
+			if u.ok {
+				assert.Equal(t, []string{"fred"}, ll)
+			}
+		})
+	}
+}
+
+func TestDetailCacheEvict(t *testing.T) {
+	c := model.NewDetailCache(2)
+	c.Put("k1", "1", []string{"a"})
+	c.Put("k2", "1", []string{"b"})
+	_, _, _ = c.Get("k1", "1")
+	c.Put("k3", "1", []string{"c"})
+
+	assert.Equal(t, 2, c.Len())
+	_, _, ok := c.Get("k2", "1")
+	assert.False(t, ok)
+	_, _, ok = c.Get("k1", "1")
+	assert.True(t, ok)
+
+	c.Put("k1", "2", []string{"d"})
+	ll, stale, _ := c.Get("k1", "2")
+	assert.False(t, stale)
+	assert.Equal(t, []string{"d"}, ll)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestDetailCacheDrop(t *testing.T) {
+	c := model.NewDetailCache(2)
+	c.Put("k1", "1", []string{"a"})
+	c.Put("k2", "1", []string{"b"})
+	c.Drop("k1")
+	c.Drop("k3")
+
+	assert.Equal(t, 1, c.Len())
+	_, _, ok := c.Get("k1", "1")
+	assert.False(t, ok)
+	_, _, ok = c.Get("k2", "1")
+	assert.True(t, ok)
+}
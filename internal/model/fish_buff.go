@@ -3,7 +3,13 @@
 
 package model
 
-import "sort"
+import (
+	"sort"
+	"strings"
+)
+
+// SuggestionNoteSep separates a suggestion from its note, ie `kube-system  (2/3)`.
+const SuggestionNoteSep = "  ("
 
 // SuggestionListener listens for suggestions.
 type SuggestionListener interface {
@@ -13,14 +19,27 @@ type SuggestionListener interface {
 	SuggestionChanged(text, sugg string)
 }
 
+// StripSuggestionNote returns a suggestion without its note.
+func StripSuggestionNote(s string) string {
+	if i := strings.Index(s, SuggestionNoteSep); i >= 0 {
+		return s[:i]
+	}
+
+	return s
+}
+
 // SuggestionFunc produces suggestions.
 type SuggestionFunc func(text string) sort.StringSlice
 
+// HintFunc describes what a command resolves to.
+type HintFunc func(text string) string
+
 // FishBuff represents a suggestion buffer.
 type FishBuff struct {
 	*CmdBuff
 
 	suggestionFn    SuggestionFunc
+	hintFn          HintFunc
 	suggestions     []string
 	suggestionIndex int
 }
@@ -104,6 +123,20 @@ func (f *FishBuff) SetSuggestionFn(fn SuggestionFunc) {
 	f.suggestionFn = fn
 }
 
+// SetHintFn sets up command hints.
+func (f *FishBuff) SetHintFn(fn HintFunc) {
+	f.hintFn = fn
+}
+
+// Hint returns what a command resolves to if known.
+func (f *FishBuff) Hint(text string) string {
+	if f.hintFn == nil {
+		return ""
+	}
+
+	return f.hintFn(text)
+}
+
 // Notify publish suggestions to all listeners.
 func (f *FishBuff) Notify(_ bool) {
 	if f.suggestionFn == nil {
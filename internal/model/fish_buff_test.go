@@ -70,6 +70,23 @@ func TestFishDelete(t *testing.T) {
 	assert.Equal(t, "blee", c)
 }
 
+func TestStripSuggestionNote(t *testing.T) {
+	uu := map[string]struct {
+		s, e string
+	}{
+		"plain": {s: "ube-system", e: "ube-system"},
+		"note":  {s: "ube-system  (2/3)", e: "ube-system"},
+		"ctx":   {s: " develop", e: " develop"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, model.StripSuggestionNote(u.s))
+		})
+	}
+}
+
 // Helpers...
 
 type mockSuggestionListener struct {
@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/derailed/k9s/internal/metrics"
 	"github.com/derailed/k9s/internal/slogs"
 )
 
@@ -99,6 +100,7 @@ func (f *Flash) Warnf(fmat string, args ...any) {
 // Err displays an error flash message.
 func (f *Flash) Err(err error) {
 	slog.Error("Flash error", slogs.Error, err)
+	metrics.Errors.Inc()
 	f.SetMessage(FlashErr, err.Error())
 }
 
@@ -114,6 +116,7 @@ func (f *Flash) Errf(fmat string, args ...any) {
 		slogs.Error, err,
 		slogs.Message, fmt.Sprintf(fmat, args...),
 	)
+	metrics.Errors.Inc()
 	f.SetMessage(FlashErr, fmt.Sprintf(fmat, args...))
 }
 
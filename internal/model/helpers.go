@@ -67,7 +67,8 @@ func NewExpBackOff(ctx context.Context, start, maxVal time.Duration) backoff.Bac
 func rxFilter(q string, lines []string) fuzzy.Matches {
 	rx, err := regexp.Compile(`(?i)` + q)
 	if err != nil {
-		return nil
+		// Not a valid regex, ie `foo(`, search for the literal text instead.
+		rx = regexp.MustCompile(`(?i)` + regexp.QuoteMeta(q))
 	}
 
 	matches := make(fuzzy.Matches, 0, len(lines))
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package model
+
+import (
+	"sync"
+	"time"
+)
+
+// MaxInbox tracks the max number of check results kept in the inbox.
+const MaxInbox = 200
+
+// CheckResult represents the outcome of a scheduled check on a context.
+type CheckResult struct {
+	Check   string
+	Context string
+	At      time.Time
+	Failed  bool
+	Summary string
+	Output  string
+	Unread  bool
+}
+
+// ID returns the check result identifier.
+func (r CheckResult) ID() string {
+	return r.Check + "@" + r.Context
+}
+
+// Inbox collects scheduled check results, newest first.
+type Inbox struct {
+	results []CheckResult
+	last    map[string]CheckResult
+	limit   int
+	mx      sync.RWMutex
+}
+
+// NewInbox returns a new instance.
+func NewInbox(limit int) *Inbox {
+	return &Inbox{
+		last:  make(map[string]CheckResult),
+		limit: limit,
+	}
+}
+
+// Add stores a result when its check starts failing, fails differently or
+// recovers. It returns true when the result was stored as unread.
+func (i *Inbox) Add(r CheckResult) bool {
+	i.mx.Lock()
+	defer i.mx.Unlock()
+
+	prev, seen := i.last[r.ID()]
+	i.last[r.ID()] = r
+	changed := !seen || prev.Failed != r.Failed || prev.Summary != r.Summary
+	if !changed || (!r.Failed && !prev.Failed) {
+		return false
+	}
+	i.push(r)
+
+	return true
+}
+
+// Push stores a result as unread regardless of the previous outcomes, ie an
+// automation rule firing.
+func (i *Inbox) Push(r CheckResult) {
+	i.mx.Lock()
+	defer i.mx.Unlock()
+
+	i.last[r.ID()] = r
+	i.push(r)
+}
+
+func (i *Inbox) push(r CheckResult) {
+	r.Unread = true
+	i.results = append([]CheckResult{r}, i.results...)
+	if len(i.results) > i.limit {
+		i.results = i.results[:i.limit]
+	}
+}
+
+// Results returns the stored results, newest first.
+func (i *Inbox) Results() []CheckResult {
+	i.mx.RLock()
+	defer i.mx.RUnlock()
+
+	rr := make([]CheckResult, len(i.results))
+	copy(rr, i.results)
+
+	return rr
+}
+
+// Unread returns the number of unread results.
+func (i *Inbox) Unread() int {
+	i.mx.RLock()
+	defer i.mx.RUnlock()
+
+	var n int
+	for _, r := range i.results {
+		if r.Unread {
+			n++
+		}
+	}
+
+	return n
+}
+
+// MarkRead flags all results as read.
+func (i *Inbox) MarkRead() {
+	i.mx.Lock()
+	defer i.mx.Unlock()
+
+	for k := range i.results {
+		i.results[k].Unread = false
+	}
+}
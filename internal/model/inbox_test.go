@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInboxAdd(t *testing.T) {
+	uu := map[string]struct {
+		rr []model.CheckResult
+		e  []bool
+	}{
+		"ok": {
+			rr: []model.CheckResult{
+				{Check: "c1", Context: "ct1", Summary: "0 matches"},
+				{Check: "c1", Context: "ct1", Summary: "0 matches"},
+			},
+			e: []bool{false, false},
+		},
+		"failing": {
+			rr: []model.CheckResult{
+				{Check: "c1", Context: "ct1", Summary: "2 matches", Failed: true},
+				{Check: "c1", Context: "ct1", Summary: "2 matches", Failed: true},
+				{Check: "c1", Context: "ct1", Summary: "3 matches", Failed: true},
+			},
+			e: []bool{true, false, true},
+		},
+		"recovered": {
+			rr: []model.CheckResult{
+				{Check: "c1", Context: "ct1", Summary: "2 matches", Failed: true},
+				{Check: "c1", Context: "ct1", Summary: "0 matches"},
+				{Check: "c1", Context: "ct1", Summary: "0 matches"},
+			},
+			e: []bool{true, true, false},
+		},
+		"contexts": {
+			rr: []model.CheckResult{
+				{Check: "c1", Context: "ct1", Summary: "2 matches", Failed: true},
+				{Check: "c1", Context: "ct2", Summary: "2 matches", Failed: true},
+			},
+			e: []bool{true, true},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			i := model.NewInbox(model.MaxInbox)
+			for j, r := range u.rr {
+				assert.Equal(t, u.e[j], i.Add(r), "result %d", j)
+			}
+		})
+	}
+}
+
+func TestInboxUnread(t *testing.T) {
+	i := model.NewInbox(2)
+	i.Add(model.CheckResult{Check: "c1", Summary: "s1", Failed: true})
+	i.Add(model.CheckResult{Check: "c2", Summary: "s2", Failed: true})
+	i.Add(model.CheckResult{Check: "c3", Summary: "s3", Failed: true})
+
+	rr := i.Results()
+	assert.Len(t, rr, 2)
+	assert.Equal(t, "c3", rr[0].Check)
+	assert.Equal(t, 2, i.Unread())
+
+	i.MarkRead()
+	assert.Equal(t, 0, i.Unread())
+}
+
+func TestInboxPush(t *testing.T) {
+	i := model.NewInbox(model.MaxInbox)
+	i.Push(model.CheckResult{Check: "r1", Context: "ct1", Summary: "fired"})
+	i.Push(model.CheckResult{Check: "r1", Context: "ct1", Summary: "fired"})
+
+	assert.Len(t, i.Results(), 2)
+	assert.Equal(t, 2, i.Unread())
+	assert.False(t, i.Add(model.CheckResult{Check: "r1", Context: "ct1", Summary: "fired"}))
+}
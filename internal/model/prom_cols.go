@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config/data"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/derailed/k9s/internal/slogs"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const promMaxParallel = 8
+
+// promObjects flattens server side tables into their row objects.
+func promObjects(oo []runtime.Object) []runtime.Object {
+	rr := make([]runtime.Object, 0, len(oo))
+	for _, o := range oo {
+		table, ok := o.(*metav1.Table)
+		if !ok {
+			rr = append(rr, o)
+			continue
+		}
+		for _, row := range table.Rows {
+			if row.Object.Object != nil {
+				rr = append(rr, row.Object.Object)
+				continue
+			}
+			var m metav1.PartialObjectMetadata
+			if err := json.Unmarshal(row.Object.Raw, &m); err == nil {
+				rr = append(rr, &m)
+			}
+		}
+	}
+
+	return rr
+}
+
+// promRowOf returns the Prometheus query attributes of a resource.
+func promRowOf(ctxName string, o runtime.Object) (string, render.PromRow, bool) {
+	m, err := meta.Accessor(o)
+	if err != nil {
+		return "", render.PromRow{}, false
+	}
+
+	return client.FQN(m.GetNamespace(), m.GetName()), render.PromRow{
+		Context:   ctxName,
+		Namespace: m.GetNamespace(),
+		Name:      m.GetName(),
+		Labels:    m.GetLabels(),
+	}, true
+}
+
+// promQuery represents a Prometheus column query of a row.
+type promQuery struct {
+	col, fqn, ctxName, query string
+	ep                       *data.Prometheus
+	ttl                      time.Duration
+}
+
+// injectPromColumns sets the cached values of the view Prometheus columns on
+// the table data. Missing or stale values are queried in one background batch
+// per refresh, off the reconcile path, and show up on a later refresh. Queries
+// are skipped while paused, ie in low power mode. Only the
+// active context may override its Prometheus endpoint, other contexts use the
+// Rancher monitoring default.
+func (t *Table) injectPromColumns(ctx context.Context, rawCfg api.Config, activeCtx string, rows map[string]render.PromRow) {
+	t.mx.RLock()
+	vs := t.vs
+	t.mx.RUnlock()
+	if vs == nil || len(vs.PromColumns) == 0 {
+		return
+	}
+	ep, _ := ctx.Value(internal.KeyPrometheus).(*data.Prometheus)
+
+	var batch []promQuery
+	for _, col := range vs.PromColumns {
+		values := make(map[string]string, len(rows))
+		for id, r := range rows {
+			q, err := render.PromQueryFor(col.Query, r)
+			if err != nil {
+				slog.Warn("Prometheus column query failed",
+					slogs.Name, col.Name,
+					slogs.Error, err,
+				)
+				break
+			}
+			var cep *data.Prometheus
+			if r.Context == activeCtx {
+				cep = ep
+			}
+			s, ok, fresh := dao.CachedPromQuery(r.Context, cep, q)
+			if !fresh {
+				batch = append(batch, promQuery{col: col.Name, fqn: id, ctxName: r.Context, query: q, ep: cep, ttl: col.CacheTTL()})
+			}
+			if ok && s.OK {
+				values[id] = render.PromValue(s.Value, col.Unit)
+			}
+		}
+		t.data.SetColumn(render.PromHeader(col.Name), values)
+	}
+	if len(batch) == 0 || dao.PromQueriesPaused() || !t.promBusy.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer t.promBusy.Store(false)
+		runPromQueries(context.WithoutCancel(ctx), rawCfg, batch)
+	}()
+}
+
+// runPromQueries evaluates a batch of Prometheus column queries, caching
+// their results.
+func runPromQueries(ctx context.Context, rawCfg api.Config, qq []promQuery) {
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, promMaxParallel)
+	)
+	for _, q := range qq {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			if s := dao.PromQuery(ctx, rawCfg, q.ctxName, q.ep, q.query, q.ttl); s.Err != nil {
+				slog.Debug("Prometheus column sample failed",
+					slogs.Name, q.col,
+					slogs.FQN, q.fqn,
+					slogs.Error, s.Err,
+				)
+			}
+		}()
+	}
+	wg.Wait()
+}
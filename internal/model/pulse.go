@@ -3,6 +3,7 @@ package model
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
@@ -28,6 +29,7 @@ type Pulse struct {
 	namespace string
 	listeners []PulseListener
 	health    *PulseHealth
+	rate      time.Duration
 }
 
 // NewPulse returns a new pulse.
@@ -47,6 +49,9 @@ func (p *Pulse) Watch(ctx context.Context) (HealthChan, dao.MetricsChan, error)
 	}
 	if p.health == nil {
 		p.health = NewPulseHealth(f)
+		if p.rate > 0 {
+			p.health.rate = p.rate
+		}
 	}
 
 	healthChan := p.health.Watch(ctx, p.namespace)
@@ -55,6 +60,11 @@ func (p *Pulse) Watch(ctx context.Context) (HealthChan, dao.MetricsChan, error)
 	return healthChan, metricsChan, nil
 }
 
+// SetRefreshRate sets the health check interval.
+func (p *Pulse) SetRefreshRate(d time.Duration) {
+	p.rate = d
+}
+
 // Refresh update the model now.
 func (*Pulse) Refresh(context.Context) {}
 
@@ -14,7 +14,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-const pulseRate = 10 * time.Second
+// PulseRate tracks the pulses health check interval.
+const PulseRate = 10 * time.Second
 
 type HealthPoint struct {
 	GVR           *client.GVR
@@ -66,11 +67,12 @@ func (g GVRs) Index(gvr *client.GVR) int {
 // PulseHealth tracks resources health.
 type PulseHealth struct {
 	factory dao.Factory
+	rate    time.Duration
 }
 
 // NewPulseHealth returns a new instance.
 func NewPulseHealth(f dao.Factory) *PulseHealth {
-	return &PulseHealth{factory: f}
+	return &PulseHealth{factory: f, rate: PulseRate}
 }
 
 func (h *PulseHealth) Watch(ctx context.Context, ns string) HealthChan {
@@ -86,7 +88,7 @@ func (h *PulseHealth) Watch(ctx context.Context, ns string) HealthChan {
 			case <-ctx.Done():
 				close(c)
 				return
-			case <-time.After(pulseRate):
+			case <-time.After(h.rate):
 				if err := h.checkPulse(ctx, ns, c); err != nil {
 					slog.Error("Pulse check failed", slogs.Error, err)
 				}
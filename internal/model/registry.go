@@ -47,6 +47,38 @@ var Registry = map[*client.GVR]ResourceMeta{
 		DAO:      new(dao.ImageScan),
 		Renderer: new(render.ImageScan),
 	},
+	client.OomGVR: {
+		DAO:      new(dao.OOM),
+		Renderer: new(render.OOM),
+	},
+	client.InvGVR: {
+		DAO:      new(dao.Inventory),
+		Renderer: new(render.Inventory),
+	},
+	client.EtcdGVR: {
+		DAO:      new(dao.EtcdMember),
+		Renderer: new(render.EtcdMember),
+	},
+	client.RuleGVR: {
+		DAO:      new(dao.Rule),
+		Renderer: new(render.Rule),
+	},
+	client.HygGVR: {
+		DAO:      new(dao.Hygiene),
+		Renderer: new(render.Hygiene),
+	},
+	client.CnyGVR: {
+		DAO:      new(dao.Canary),
+		Renderer: new(render.Canary),
+	},
+	client.JanGVR: {
+		DAO:      new(dao.Janitor),
+		Renderer: new(render.Janitor),
+	},
+	client.WchGVR: {
+		DAO:      new(dao.Watcher),
+		Renderer: new(render.Watcher),
+	},
 	client.CtGVR: {
 		DAO:      new(dao.Context),
 		Renderer: new(render.Context),
@@ -180,6 +212,11 @@ var Registry = map[*client.GVR]ResourceMeta{
 		Renderer: &render.StorageClass{},
 	},
 
+	// Coordination...
+	client.LeaseGVR: {
+		Renderer: new(render.Lease),
+	},
+
 	// Policy...
 	client.PdbGVR: {
 		Renderer: &render.PodDisruptionBudget{},
@@ -199,4 +236,16 @@ var Registry = map[*client.GVR]ResourceMeta{
 	client.RobGVR: {
 		Renderer: new(render.RoleBinding),
 	},
+
+	// Snapshots...
+	client.VolumeSnapshotGVR: {
+		DAO:      new(dao.VolumeSnapshot),
+		Renderer: new(render.VolumeSnapshot),
+	},
+
+	// Rancher...
+	client.FleetClusterGVR: {
+		DAO:      new(dao.FleetCluster),
+		Renderer: new(render.FleetCluster),
+	},
 }
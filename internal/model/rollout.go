@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	backoff "github.com/cenkalti/backoff/v4"
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/sahilm/fuzzy"
+)
+
+// defaultRolloutRefreshRate tracks how often a rollout is refreshed.
+const defaultRolloutRefreshRate = 1 * time.Second
+
+// Rollout tracks a deployment rollout progress.
+type Rollout struct {
+	inUpdate    int32
+	path        string
+	query       string
+	lines       []string
+	refreshRate time.Duration
+	listeners   []ResourceViewerListener
+}
+
+// NewRollout returns a new rollout model.
+func NewRollout(path string) *Rollout {
+	return &Rollout{
+		path:        path,
+		refreshRate: defaultRolloutRefreshRate,
+	}
+}
+
+// GVR returns the resource gvr.
+func (*Rollout) GVR() *client.GVR {
+	return client.DpGVR
+}
+
+// GetPath returns the active resource path.
+func (r *Rollout) GetPath() string {
+	return r.path
+}
+
+// SetRefreshRate sets the rollout refresh interval.
+func (r *Rollout) SetRefreshRate(d time.Duration) {
+	if d > 0 {
+		r.refreshRate = d
+	}
+}
+
+// SetOptions toggle model options.
+func (*Rollout) SetOptions(context.Context, ViewerToggleOpts) {}
+
+// Filter filters the model.
+func (r *Rollout) Filter(q string) {
+	r.query = q
+	r.fireResourceChanged(r.lines, r.filter(q, r.lines))
+}
+
+func (*Rollout) filter(q string, lines []string) fuzzy.Matches {
+	if q == "" {
+		return nil
+	}
+	if f, ok := internal.IsFuzzySelector(q); ok {
+		return fuzzy.Find(strings.TrimSpace(f), lines)
+	}
+
+	return rxFilter(q, lines)
+}
+
+// ClearFilter clear out the filter.
+func (*Rollout) ClearFilter() {}
+
+// Peek returns current model state.
+func (r *Rollout) Peek() []string {
+	return r.lines
+}
+
+// Refresh updates model data.
+func (r *Rollout) Refresh(ctx context.Context) error {
+	return r.refresh(ctx)
+}
+
+// Watch watches for rollout changes.
+func (r *Rollout) Watch(ctx context.Context) error {
+	if err := r.refresh(ctx); err != nil {
+		return err
+	}
+	go r.updater(ctx)
+
+	return nil
+}
+
+func (r *Rollout) updater(ctx context.Context) {
+	defer slog.Debug("Rollout watch canceled", slogs.FQN, r.path)
+
+	backOff := NewExpBackOff(ctx, r.refreshRate, maxReaderRetryInterval)
+	delay := r.refreshRate
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+			if err := r.refresh(ctx); err != nil {
+				if delay = backOff.NextBackOff(); delay == backoff.Stop {
+					slog.Error("Rollout watch gave up!", slogs.Error, err)
+					return
+				}
+			} else {
+				backOff.Reset()
+				delay = r.refreshRate
+			}
+		}
+	}
+}
+
+func (r *Rollout) refresh(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&r.inUpdate, 0, 1) {
+		return nil
+	}
+	defer atomic.StoreInt32(&r.inUpdate, 0)
+
+	f, ok := ctx.Value(internal.KeyFactory).(dao.Factory)
+	if !ok {
+		return errors.New("expecting a factory in context")
+	}
+	st, err := dao.FetchRollout(f, r.path)
+	if err != nil {
+		r.fireResourceFailed(err)
+		return err
+	}
+	lines := RolloutLines(st)
+	if reflect.DeepEqual(lines, r.lines) {
+		return nil
+	}
+	r.lines = lines
+	r.fireResourceChanged(r.lines, r.filter(r.query, r.lines))
+
+	return nil
+}
+
+// RolloutLines renders a rollout status.
+func RolloutLines(st *dao.RolloutStatus) []string {
+	ll := []string{
+		"deployment: " + st.Path,
+		fmt.Sprintf("revision: %d", st.Revision),
+		"status: " + st.State,
+	}
+	if st.Message != "" {
+		ll = append(ll, "message: "+st.Message)
+	}
+	ll = append(ll,
+		fmt.Sprintf("replicas: %d desired | %d updated | %d ready | %d available", st.Desired, st.Updated, st.Ready, st.Available),
+		fmt.Sprintf("surge: %d/%d", st.Surge, st.MaxSurge),
+		fmt.Sprintf("unavailable: %d/%d", st.Unavailable, st.MaxUnavailable),
+	)
+	for _, rs := range st.ReplicaSets {
+		kind := "old"
+		if rs.New {
+			kind = "new"
+		}
+		ll = append(ll, "", fmt.Sprintf("%s replicaset: %s (revision %d, %d/%d ready)", kind, rs.Name, rs.Revision, rs.Ready, rs.Desired))
+		for _, p := range rs.Pods {
+			ll = append(ll, fmt.Sprintf("  - %s  %s  %s ready  %d restarts", p.Name, p.Status, p.Ready, p.Restarts))
+			if p.LastEvent != "" {
+				ll = append(ll, "    last event: "+p.LastEvent)
+			}
+		}
+	}
+
+	return ll
+}
+
+func (r *Rollout) fireResourceChanged(lines []string, matches fuzzy.Matches) {
+	for _, l := range r.listeners {
+		l.ResourceChanged(lines, matches)
+	}
+}
+
+func (r *Rollout) fireResourceFailed(err error) {
+	for _, l := range r.listeners {
+		l.ResourceFailed(err)
+	}
+}
+
+// AddListener adds a new model listener.
+func (r *Rollout) AddListener(l ResourceViewerListener) {
+	r.listeners = append(r.listeners, l)
+}
+
+// RemoveListener delete a listener from the list.
+func (r *Rollout) RemoveListener(l ResourceViewerListener) {
+	for i, lis := range r.listeners {
+		if lis == l {
+			r.listeners = append(r.listeners[:i], r.listeners[i+1:]...)
+			return
+		}
+	}
+}
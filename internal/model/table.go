@@ -5,8 +5,10 @@ package model
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/metrics"
 	"github.com/derailed/k9s/internal/model1"
 	"github.com/derailed/k9s/internal/render"
 	"github.com/derailed/k9s/internal/slogs"
@@ -48,11 +51,18 @@ type Table struct {
 	refreshRate   time.Duration
 	instance      string
 	labelSelector labels.Selector
+	fieldSelector string
 	mx            sync.RWMutex
 	vs            *config.ViewSetting
 
 	multiCtxs []string
 	rawConfig *api.Config
+	ctxStatus map[string]model1.ContextStatus
+	ctxObjs   map[string][]dao.ContextObject
+	rowLimit  int
+	truncated bool
+	skipped   []model1.ContextStatus
+	promBusy  atomic.Bool
 }
 
 // NewTable returns a new table model.
@@ -92,6 +102,22 @@ func (t *Table) GetLabelSelector() labels.Selector {
 	return t.labelSelector
 }
 
+// SetFieldSelector sets the server side field selector.
+func (t *Table) SetFieldSelector(sel string) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.fieldSelector = sel
+}
+
+// GetFieldSelector returns the server side field selector.
+func (t *Table) GetFieldSelector() string {
+	t.mx.RLock()
+	defer t.mx.RUnlock()
+
+	return t.fieldSelector
+}
+
 // SetInstance sets a single entry table.
 func (t *Table) SetInstance(path string) {
 	t.instance = path
@@ -212,6 +238,9 @@ func (t *Table) SetMultiContexts(ctxs []string, rawCfg api.Config) {
 
 	t.multiCtxs = ctxs
 	t.rawConfig = &rawCfg
+	t.ctxStatus = make(map[string]model1.ContextStatus, len(ctxs))
+	t.ctxObjs = make(map[string][]dao.ContextObject, len(ctxs))
+	t.rowLimit, t.truncated = 0, false
 }
 
 // ClearMultiContexts disables multi-context mode.
@@ -221,6 +250,158 @@ func (t *Table) ClearMultiContexts() {
 
 	t.multiCtxs = nil
 	t.rawConfig = nil
+	t.ctxStatus = nil
+	t.ctxObjs = nil
+	t.rowLimit, t.truncated = 0, false
+}
+
+// MultiContexts returns the contexts in use when in multi-context mode.
+func (t *Table) MultiContexts() []string {
+	t.mx.RLock()
+	defer t.mx.RUnlock()
+
+	return slices.Clone(t.multiCtxs)
+}
+
+// ContextStatuses returns the last fetch outcome of each context when in
+// multi-context mode, or the contexts skipped by a multi-context resource.
+func (t *Table) ContextStatuses() []model1.ContextStatus {
+	t.mx.RLock()
+	defer t.mx.RUnlock()
+
+	if len(t.multiCtxs) == 0 {
+		return slices.Clone(t.skipped)
+	}
+	ss := make([]model1.ContextStatus, 0, len(t.multiCtxs))
+	for _, ctx := range t.multiCtxs {
+		s, ok := t.ctxStatus[ctx]
+		if !ok {
+			s.Context = ctx
+		}
+		ss = append(ss, s)
+	}
+
+	return ss
+}
+
+func (t *Table) setSkipped(me *dao.MultiContextError) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.skipped = nil
+	if me == nil {
+		return
+	}
+	for _, r := range me.Failed {
+		t.skipped = append(t.skipped, model1.ContextStatus{Context: r.Context, Err: r.Err})
+	}
+}
+
+func (t *Table) recordContextResults(rr []dao.ContextResult, now time.Time) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if t.ctxStatus == nil {
+		return
+	}
+	for _, r := range rr {
+		s := t.ctxStatus[r.Context]
+		s.Context, s.Err, s.Loading = r.Context, r.Err, false
+		if r.Err == nil {
+			s.Count, s.FetchedAt, s.Truncated = r.Count, now, r.Truncated
+		}
+		t.ctxStatus[r.Context] = s
+	}
+}
+
+// markContextsLoading flags the contexts as being fetched. It returns true
+// when some contexts never answered, ie the table is drawn for the first time.
+func (t *Table) markContextsLoading(ctxs []string) bool {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if t.ctxStatus == nil {
+		return false
+	}
+	var first bool
+	for _, ctx := range ctxs {
+		s := t.ctxStatus[ctx]
+		s.Context, s.Loading = ctx, true
+		t.ctxStatus[ctx] = s
+		if _, ok := t.ctxObjs[ctx]; !ok {
+			first = true
+		}
+	}
+
+	return first
+}
+
+// cacheContextObjects keeps the last resources of a context. A failed context
+// contributes no rows.
+func (t *Table) cacheContextObjects(r dao.ContextResult, oo []dao.ContextObject) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if t.ctxObjs == nil {
+		return
+	}
+	if r.Err != nil {
+		oo = nil
+	}
+	t.ctxObjs[r.Context] = oo
+}
+
+// contextsTruncated checks if some contexts hold more resources than listed.
+func (t *Table) contextsTruncated(ctxs []string) bool {
+	t.mx.RLock()
+	defer t.mx.RUnlock()
+
+	for _, ctx := range ctxs {
+		if s, ok := t.ctxStatus[ctx]; ok && s.Err == nil && s.Truncated {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contextObjects returns the cached resources of the given contexts. Contexts
+// still loading keep their previous rows.
+func (t *Table) contextObjects(ctxs []string) []dao.ContextObject {
+	t.mx.RLock()
+	defer t.mx.RUnlock()
+
+	var oo []dao.ContextObject
+	for _, ctx := range ctxs {
+		oo = append(oo, t.ctxObjs[ctx]...)
+	}
+
+	return oo
+}
+
+// Truncated returns the max number of rows of a multi-context view and
+// whether some resources were left out to honor it.
+func (t *Table) Truncated() (int, bool) {
+	t.mx.RLock()
+	defer t.mx.RUnlock()
+
+	return t.rowLimit, t.truncated
+}
+
+func (t *Table) setTruncated(limit int, truncated bool) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.rowLimit, t.truncated = limit, truncated
+}
+
+// limitContextObjects caps the aggregated resources of a multi-context view.
+func limitContextObjects(oo []dao.ContextObject, limit int) ([]dao.ContextObject, bool) {
+	if limit <= 0 || len(oo) <= limit {
+		return oo, false
+	}
+
+	return oo[:limit], true
 }
 
 // IsMultiContext returns true if the model is in multi-context mode.
@@ -264,9 +445,12 @@ func (t *Table) refresh(ctx context.Context) error {
 	}
 	defer atomic.StoreInt32(&t.inUpdate, 0)
 
+	start := time.Now()
 	if err := t.reconcile(ctx); err != nil {
 		return err
 	}
+	metrics.RefreshDuration.Observe(time.Since(start).Seconds(), t.gvr.String())
+	metrics.Refreshes.Touch(t.gvr.String())
 	data := t.Peek()
 	if data.RowCount() == 0 {
 		t.fireNoData(data)
@@ -286,6 +470,7 @@ func (t *Table) list(ctx context.Context, a dao.Accessor) ([]runtime.Object, err
 
 	t.mx.RLock()
 	ctx = context.WithValue(ctx, internal.KeyLabels, t.labelSelector)
+	ctx = withFieldSelector(ctx, t.fieldSelector)
 	t.mx.RUnlock()
 
 	ns := client.CleanseNamespace(t.data.GetNamespace())
@@ -296,6 +481,18 @@ func (t *Table) list(ctx context.Context, a dao.Accessor) ([]runtime.Object, err
 	return a.List(ctx, ns)
 }
 
+// withFieldSelector adds a field selector to the ones already in context.
+func withFieldSelector(ctx context.Context, sel string) context.Context {
+	if sel == "" {
+		return ctx
+	}
+	if prev, _ := ctx.Value(internal.KeyFields).(string); prev != "" {
+		sel = prev + "," + sel
+	}
+
+	return context.WithValue(ctx, internal.KeyFields, sel)
+}
+
 func (t *Table) reconcile(ctx context.Context) error {
 	t.mx.RLock()
 	mc := len(t.multiCtxs) > 0
@@ -320,13 +517,46 @@ func (t *Table) reconcile(ctx context.Context) error {
 		o, e := t.Get(ctx, t.instance)
 		oo, err = []runtime.Object{o}, e
 	}
+	// Resources spanning contexts still render the contexts that answered.
+	var me *dao.MultiContextError
+	if errors.As(err, &me) {
+		err = nil
+	}
 	if err != nil {
 		return err
 	}
+	t.setSkipped(me)
 	r := meta.Renderer
 	r.SetViewSetting(t.vs)
 
-	return t.data.Render(ctx, meta.Renderer, oo)
+	if err := t.data.Render(ctx, meta.Renderer, oo); err != nil {
+		return err
+	}
+	if t.vs != nil && len(t.vs.PromColumns) > 0 {
+		t.promColumns(ctx, oo)
+	}
+
+	return nil
+}
+
+func (t *Table) promColumns(ctx context.Context, oo []runtime.Object) {
+	factory, ok := ctx.Value(internal.KeyFactory).(dao.Factory)
+	if !ok {
+		return
+	}
+	rawCfg, err := factory.Client().Config().RawConfig()
+	if err != nil {
+		slog.Warn("Prometheus columns skipped", slogs.Error, err)
+		return
+	}
+	activeCtx := factory.Client().ActiveContext()
+	rows := make(map[string]render.PromRow, len(oo))
+	for _, o := range promObjects(oo) {
+		if id, r, ok := promRowOf(activeCtx, o); ok {
+			rows[id] = r
+		}
+	}
+	t.injectPromColumns(ctx, rawCfg, activeCtx, rows)
 }
 
 func (t *Table) multiContextReconcile(ctx context.Context) error {
@@ -349,13 +579,36 @@ func (t *Table) multiContextReconcile(ctx context.Context) error {
 	if t.labelSelector != nil {
 		labelSel = t.labelSelector.String()
 	}
+	fieldSel := t.fieldSelector
 	t.mx.RUnlock()
 
-	results, err := dao.MultiContextList(rawCfg, ctxs, t.gvr.GVR(), ns, labelSel)
-	if err != nil {
-		return err
-	}
+	first, pending := t.markContextsLoading(ctxs), len(ctxs)
+	limit := dao.MultiContextRowLimit()
+	dao.MultiContextStream(ctx, rawCfg, ctxs, t.gvr.GVR(), ns, labelSel, fieldSel, limit, func(r dao.ContextResult, oo []dao.ContextObject) {
+		pending--
+		t.recordContextResults([]dao.ContextResult{r}, time.Now())
+		t.cacheContextObjects(r, oo)
+		// Draw fast contexts right away while slow ones fill in.
+		if !first || pending == 0 {
+			return
+		}
+		if err := t.renderContexts(ctx, rawCfg, ctxs, limit); err != nil {
+			slog.Warn("Multi-context render failed", slogs.GVR, t.gvr, slogs.Error, err)
+			return
+		}
+		if data := t.Peek(); data.RowCount() > 0 {
+			t.fireTableChanged(data)
+		}
+	})
+
+	return t.renderContexts(ctx, rawCfg, ctxs, limit)
+}
 
+// renderContexts renders the cached resources of the given contexts, up to
+// limit rows.
+func (t *Table) renderContexts(ctx context.Context, rawCfg api.Config, ctxs []string, limit int) error {
+	results, capped := limitContextObjects(t.contextObjects(ctxs), limit)
+	t.setTruncated(limit, capped || t.contextsTruncated(ctxs))
 	oo := make([]runtime.Object, 0, len(results))
 	ctxByRowID := make(map[string]string, len(results))
 	for _, co := range results {
@@ -379,6 +632,19 @@ func (t *Table) multiContextReconcile(ctx context.Context) error {
 		return true
 	})
 
+	if t.vs != nil && len(t.vs.PromColumns) > 0 {
+		rows := make(map[string]render.PromRow, len(results))
+		for _, co := range results {
+			if id, r, ok := promRowOf(co.Context, co.Object); ok {
+				rows[id] = r
+			}
+		}
+		var activeCtx string
+		if factory, ok := ctx.Value(internal.KeyFactory).(dao.Factory); ok {
+			activeCtx = factory.Client().ActiveContext()
+		}
+		t.injectPromColumns(ctx, rawCfg, activeCtx, rows)
+	}
 	t.data.InjectClusterColumn(ctxByRowID)
 
 	return nil
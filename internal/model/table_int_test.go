@@ -6,9 +6,11 @@ package model
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
@@ -22,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/clientcmd/api"
 )
 
 func TestTableReconcile(t *testing.T) {
@@ -36,11 +39,101 @@ func TestTableReconcile(t *testing.T) {
 	err := ta.reconcile(ctx)
 	require.NoError(t, err)
 	data := ta.Peek()
-	assert.Equal(t, 26, data.HeaderCount())
+	assert.Equal(t, 27, data.HeaderCount())
 	assert.Equal(t, 1, data.RowCount())
 	assert.Equal(t, client.NamespaceAll, data.GetNamespace())
 }
 
+func TestTableContextStatuses(t *testing.T) {
+	ta := NewTable(client.PodGVR)
+	assert.Nil(t, ta.ContextStatuses())
+
+	ta.SetMultiContexts([]string{"c1", "c2"}, api.Config{})
+	t0 := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	ta.recordContextResults([]dao.ContextResult{
+		{Context: "c1", Count: 3},
+		{Context: "c2", Count: 2},
+	}, t0)
+	ta.recordContextResults([]dao.ContextResult{
+		{Context: "c1", Count: 4},
+		{Context: "c2", Err: errors.New("boom")},
+	}, t0.Add(time.Minute))
+
+	assert.Equal(t, []model1.ContextStatus{
+		{Context: "c1", Count: 4, FetchedAt: t0.Add(time.Minute)},
+		{Context: "c2", Count: 2, FetchedAt: t0, Err: errors.New("boom")},
+	}, ta.ContextStatuses())
+
+	ta.ClearMultiContexts()
+	assert.Nil(t, ta.ContextStatuses())
+
+	ta.setSkipped(&dao.MultiContextError{Failed: []dao.ContextResult{{Context: "c2", Err: errors.New("boom")}}})
+	assert.Equal(t, []model1.ContextStatus{
+		{Context: "c2", Err: errors.New("boom")},
+	}, ta.ContextStatuses())
+	ta.setSkipped(nil)
+	assert.Nil(t, ta.ContextStatuses())
+}
+
+func TestTableContextObjects(t *testing.T) {
+	ta := NewTable(client.PodGVR)
+	ta.SetMultiContexts([]string{"c1", "c2"}, api.Config{})
+	ctxs := []string{"c1", "c2"}
+	o := func(ctx string) dao.ContextObject {
+		return dao.ContextObject{Context: ctx, Object: new(unstructured.Unstructured)}
+	}
+
+	assert.True(t, ta.markContextsLoading(ctxs))
+	ta.cacheContextObjects(dao.ContextResult{Context: "c2", Count: 2}, []dao.ContextObject{o("c2"), o("c2")})
+	assert.Len(t, ta.contextObjects(ctxs), 2)
+	ta.cacheContextObjects(dao.ContextResult{Context: "c1", Count: 1}, []dao.ContextObject{o("c1")})
+	assert.Len(t, ta.contextObjects(ctxs), 3)
+
+	assert.False(t, ta.markContextsLoading(ctxs))
+	for _, s := range ta.ContextStatuses() {
+		assert.True(t, s.Loading)
+	}
+	ta.cacheContextObjects(dao.ContextResult{Context: "c2", Err: errors.New("boom")}, nil)
+	assert.Equal(t, []dao.ContextObject{o("c1")}, ta.contextObjects(ctxs))
+}
+
+func TestLimitContextObjects(t *testing.T) {
+	oo := []dao.ContextObject{{Context: "c1"}, {Context: "c1"}, {Context: "c2"}}
+	uu := map[string]struct {
+		limit     int
+		count     int
+		truncated bool
+	}{
+		"unlimited": {count: 3},
+		"under":     {limit: 5, count: 3},
+		"at":        {limit: 3, count: 3},
+		"over":      {limit: 2, count: 2, truncated: true},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			rr, truncated := limitContextObjects(oo, u.limit)
+			assert.Len(t, rr, u.count)
+			assert.Equal(t, u.truncated, truncated)
+		})
+	}
+}
+
+func TestTableContextsTruncated(t *testing.T) {
+	ta := NewTable(client.PodGVR)
+	ctxs := []string{"c1", "c2"}
+	ta.SetMultiContexts(ctxs, api.Config{})
+	now := time.Now()
+
+	ta.recordContextResults([]dao.ContextResult{{Context: "c1", Count: 2}, {Context: "c2", Count: 2}}, now)
+	assert.False(t, ta.contextsTruncated(ctxs))
+	ta.recordContextResults([]dao.ContextResult{{Context: "c2", Count: 2, Truncated: true}}, now)
+	assert.True(t, ta.contextsTruncated(ctxs))
+	ta.recordContextResults([]dao.ContextResult{{Context: "c2", Err: errors.New("boom")}}, now)
+	assert.False(t, ta.contextsTruncated(ctxs))
+}
+
 func TestTableList(t *testing.T) {
 	ta := NewTable(client.PodGVR)
 	ta.SetNamespace("blee")
@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package model
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// MaxTermLines tracks the terminal scrollback size.
+	MaxTermLines = 2_000
+	termTabWidth = 8
+)
+
+type termState int
+
+const (
+	termText termState = iota
+	termEsc
+	termCSI
+	termOSC
+)
+
+// TermBuffer represents the screen of a minimal terminal emulator. It handles
+// the cursor moves and erase sequences used by line oriented interactive CLIs.
+// Colors and other sequences are dropped.
+type TermBuffer struct {
+	lines      [][]rune
+	row, col   int
+	rows, cols int
+	state      termState
+	params     []byte
+	partial    []byte
+	mx         sync.RWMutex
+}
+
+// NewTermBuffer returns a new terminal screen.
+func NewTermBuffer(rows, cols int) *TermBuffer {
+	return &TermBuffer{
+		lines: [][]rune{{}},
+		rows:  rows,
+		cols:  cols,
+	}
+}
+
+// SetSize sets the screen size.
+func (t *TermBuffer) SetSize(rows, cols int) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.rows, t.cols = rows, cols
+}
+
+// Size returns the screen size.
+func (t *TermBuffer) Size() (rows, cols int) {
+	t.mx.RLock()
+	defer t.mx.RUnlock()
+
+	return t.rows, t.cols
+}
+
+// String returns the screen content.
+func (t *TermBuffer) String() string {
+	t.mx.RLock()
+	defer t.mx.RUnlock()
+
+	ll := make([]string, 0, len(t.lines))
+	for _, l := range t.lines {
+		ll = append(ll, strings.TrimRight(string(l), " "))
+	}
+
+	return strings.Join(ll, "\n")
+}
+
+// Write feeds terminal output to the screen.
+func (t *TermBuffer) Write(p []byte) (int, error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	bb := append(t.partial, p...)
+	t.partial = nil
+	for len(bb) > 0 {
+		r, n := utf8.DecodeRune(bb)
+		if r == utf8.RuneError && n <= 1 && !utf8.FullRune(bb) {
+			t.partial = append([]byte(nil), bb...)
+			break
+		}
+		bb = bb[n:]
+		t.feed(r)
+	}
+
+	return len(p), nil
+}
+
+func (t *TermBuffer) feed(r rune) {
+	switch t.state {
+	case termEsc:
+		switch r {
+		case '[':
+			t.state, t.params = termCSI, t.params[:0]
+		case ']':
+			t.state = termOSC
+		default:
+			t.state = termText
+		}
+	case termCSI:
+		if r >= 0x40 && r <= 0x7e {
+			t.csi(r, string(t.params))
+			t.state = termText
+			return
+		}
+		t.params = append(t.params, byte(r))
+	case termOSC:
+		switch r {
+		case 0x07:
+			t.state = termText
+		case 0x1b:
+			t.state = termEsc
+		}
+	default:
+		t.text(r)
+	}
+}
+
+func (t *TermBuffer) text(r rune) {
+	switch r {
+	case 0x1b:
+		t.state = termEsc
+	case '\r':
+		t.col = 0
+	case '\n':
+		t.lineFeed()
+	case '\b':
+		t.col = max(t.col-1, 0)
+	case '\t':
+		t.col += termTabWidth - t.col%termTabWidth
+	case 0x07, 0x00:
+	default:
+		if r < 0x20 || r == 0x7f {
+			return
+		}
+		t.put(r)
+	}
+}
+
+func (t *TermBuffer) put(r rune) {
+	if t.cols > 0 && t.col >= t.cols {
+		t.col = 0
+		t.lineFeed()
+	}
+	l := t.lines[t.row]
+	for len(l) < t.col {
+		l = append(l, ' ')
+	}
+	if t.col < len(l) {
+		l[t.col] = r
+	} else {
+		l = append(l, r)
+	}
+	t.lines[t.row] = l
+	t.col++
+}
+
+func (t *TermBuffer) lineFeed() {
+	t.row++
+	if t.row == len(t.lines) {
+		t.lines = append(t.lines, []rune{})
+	}
+	if n := len(t.lines) - MaxTermLines; n > 0 {
+		t.lines = t.lines[n:]
+		t.row -= n
+	}
+}
+
+// top returns the first line of the visible screen.
+func (t *TermBuffer) top() int {
+	if t.rows <= 0 {
+		return 0
+	}
+
+	return max(len(t.lines)-t.rows, 0)
+}
+
+func (t *TermBuffer) csi(final rune, params string) {
+	params = strings.TrimLeft(params, "?>=")
+	pp := strings.Split(params, ";")
+	arg := func(i, def int) int {
+		if i >= len(pp) || pp[i] == "" {
+			return def
+		}
+		n, err := strconv.Atoi(pp[i])
+		if err != nil {
+			return def
+		}
+		return n
+	}
+
+	switch final {
+	case 'A':
+		t.row = max(t.row-arg(0, 1), t.top())
+	case 'B':
+		t.moveTo(t.row+arg(0, 1), t.col)
+	case 'C':
+		t.col += arg(0, 1)
+	case 'D':
+		t.col = max(t.col-arg(0, 1), 0)
+	case 'G':
+		t.col = max(arg(0, 1)-1, 0)
+	case 'H', 'f':
+		t.moveTo(t.top()+max(arg(0, 1)-1, 0), max(arg(1, 1)-1, 0))
+	case 'K':
+		t.eraseLine(arg(0, 0))
+	case 'J':
+		t.eraseScreen(arg(0, 0))
+	}
+}
+
+func (t *TermBuffer) moveTo(row, col int) {
+	for row >= len(t.lines) {
+		t.lines = append(t.lines, []rune{})
+	}
+	t.row, t.col = row, col
+}
+
+func (t *TermBuffer) eraseLine(mode int) {
+	l := t.lines[t.row]
+	switch mode {
+	case 0:
+		if t.col < len(l) {
+			t.lines[t.row] = l[:t.col]
+		}
+	case 1:
+		for i := 0; i <= t.col && i < len(l); i++ {
+			l[i] = ' '
+		}
+	default:
+		t.lines[t.row] = []rune{}
+	}
+}
+
+func (t *TermBuffer) eraseScreen(mode int) {
+	switch mode {
+	case 0:
+		t.eraseLine(0)
+		t.lines = t.lines[:t.row+1]
+	case 1:
+		for i := t.top(); i < t.row; i++ {
+			t.lines[i] = []rune{}
+		}
+		t.eraseLine(1)
+	default:
+		t.lines, t.row, t.col = [][]rune{{}}, 0, 0
+	}
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/derailed/k9s/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTermBufferWrite(t *testing.T) {
+	uu := map[string]struct {
+		in []string
+		e  string
+	}{
+		"plain": {
+			in: []string{"hello\r\nworld\r\n"},
+			e:  "hello\nworld\n",
+		},
+		"carriage-return": {
+			in: []string{"50%\r100%"},
+			e:  "100%",
+		},
+		"backspace-erase": {
+			in: []string{"pasz\b \bs"},
+			e:  "pass",
+		},
+		"colors": {
+			in: []string{"\x1b[1;32mOK\x1b[0m done"},
+			e:  "OK done",
+		},
+		"erase-line": {
+			in: []string{"Downloading 10%\r\x1b[KDone"},
+			e:  "Done",
+		},
+		"clear-screen": {
+			in: []string{"old\r\nstuff\x1b[H\x1b[2Jnew"},
+			e:  "new",
+		},
+		"title": {
+			in: []string{"\x1b]0;my title\x07prompt> "},
+			e:  "prompt>",
+		},
+		"split-utf8": {
+			in: []string{"caf\xc3", "\xa9"},
+			e:  "café",
+		},
+		"split-sequence": {
+			in: []string{"a\x1b[3", "1mb"},
+			e:  "ab",
+		},
+		"tab": {
+			in: []string{"a\tb"},
+			e:  "a       b",
+		},
+		"cursor-up": {
+			in: []string{"one\r\ntwo\x1b[A\rONE"},
+			e:  "ONE\ntwo",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			b := model.NewTermBuffer(24, 80)
+			for _, s := range u.in {
+				n, err := b.Write([]byte(s))
+				assert.NoError(t, err)
+				assert.Equal(t, len(s), n)
+			}
+			assert.Equal(t, u.e, b.String())
+		})
+	}
+}
+
+func TestTermBufferWrap(t *testing.T) {
+	b := model.NewTermBuffer(24, 4)
+	_, _ = b.Write([]byte("abcdef"))
+
+	assert.Equal(t, "abcd\nef", b.String())
+}
+
+func TestTermBufferScrollback(t *testing.T) {
+	b := model.NewTermBuffer(24, 80)
+	_, _ = b.Write([]byte(strings.Repeat("x\n", model.MaxTermLines+10)))
+
+	assert.Len(t, strings.Split(b.String(), "\n"), model.MaxTermLines)
+}
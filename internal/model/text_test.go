@@ -73,6 +73,20 @@ func TestTextFilterNoMatch(t *testing.T) {
 	assert.Equal(t, 0, lis.index)
 }
 
+func TestTextFilterLiteralFallback(t *testing.T) {
+	m := model.NewText()
+
+	lis := textLis{}
+	m.AddListener(&lis)
+
+	m.SetText("Hello World\ncall foo(bar) now")
+	m.Filter("foo(")
+
+	assert.Equal(t, 1, lis.filtered)
+	assert.Equal(t, 1, lis.matches)
+	assert.Equal(t, 5, lis.index)
+}
+
 // Helpers...
 
 type textLis struct {
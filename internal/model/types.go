@@ -53,6 +53,18 @@ type EncDecResourceViewer interface {
 	Toggle()
 }
 
+// RefreshRater represents a viewer with a configurable refresh interval.
+type RefreshRater interface {
+	// SetRefreshRate sets the viewer refresh interval.
+	SetRefreshRate(time.Duration)
+}
+
+// Staler represents a viewer whose content may be served from an outdated cache.
+type Staler interface {
+	// IsStale returns true if the content is outdated.
+	IsStale() bool
+}
+
 // Igniter represents a runnable view.
 type Igniter interface {
 	// Start starts a component.
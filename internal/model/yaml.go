@@ -25,21 +25,24 @@ const ManagedFieldsOpts = "ManagedFields"
 
 // YAML tracks yaml resource representations.
 type YAML struct {
-	gvr       *client.GVR
-	inUpdate  int32
-	path      string
-	query     string
-	lines     []string
-	listeners []ResourceViewerListener
-	options   ViewerToggleOpts
-	decode    bool
+	gvr         *client.GVR
+	inUpdate    int32
+	path        string
+	query       string
+	lines       []string
+	refreshRate time.Duration
+	listeners   []ResourceViewerListener
+	options     ViewerToggleOpts
+	decode      bool
+	stale       atomic.Bool
 }
 
 // NewYAML return a new yaml resource model.
 func NewYAML(gvr *client.GVR, path string) *YAML {
 	return &YAML{
-		gvr:  gvr,
-		path: path,
+		gvr:         gvr,
+		path:        path,
+		refreshRate: defaultReaderRefreshRate,
 	}
 }
 
@@ -53,6 +56,13 @@ func (y *YAML) GetPath() string {
 	return y.path
 }
 
+// SetRefreshRate sets the yaml refresh interval.
+func (y *YAML) SetRefreshRate(r time.Duration) {
+	if r > 0 {
+		y.refreshRate = r
+	}
+}
+
 // SetOptions toggle model options.
 func (y *YAML) SetOptions(ctx context.Context, opts ViewerToggleOpts) {
 	y.options = opts
@@ -114,6 +124,16 @@ func (y *YAML) Refresh(ctx context.Context) error {
 
 // Watch watches for YAML changes.
 func (y *YAML) Watch(ctx context.Context) error {
+	if key, ok := y.fromCache(ctx); ok {
+		go func() {
+			if err := y.refresh(ctx); err != nil {
+				dropCached(key, &y.stale, err)
+				y.fireResourceFailed(err)
+			}
+			y.updater(ctx)
+		}()
+		return nil
+	}
 	if err := y.refresh(ctx); err != nil {
 		return err
 	}
@@ -122,11 +142,44 @@ func (y *YAML) Watch(ctx context.Context) error {
 	return nil
 }
 
+// IsStale returns true if the current content came from an outdated cache entry.
+func (y *YAML) IsStale() bool {
+	return y.stale.Load()
+}
+
+func (y *YAML) cacheKind() string {
+	kind := "yaml"
+	if y.options[ManagedFieldsOpts] {
+		kind += "-managed"
+	}
+	if y.decode {
+		kind += "-decoded"
+	}
+
+	return kind
+}
+
+func (y *YAML) fromCache(ctx context.Context) (string, bool) {
+	key, rv, ok := detailKey(ctx, y.cacheKind(), y.gvr, y.path)
+	if !ok {
+		return "", false
+	}
+	lines, stale, ok := detailCache.Get(key, rv)
+	if !ok {
+		return "", false
+	}
+	y.lines = lines
+	y.stale.Store(stale)
+	y.fireResourceChanged(y.lines, y.filter(y.query, y.lines))
+
+	return key, true
+}
+
 func (y *YAML) updater(ctx context.Context) {
 	defer slog.Debug("YAML canceled", slogs.GVR, y.gvr)
 
-	backOff := NewExpBackOff(ctx, defaultReaderRefreshRate, maxReaderRetryInterval)
-	delay := defaultReaderRefreshRate
+	backOff := NewExpBackOff(ctx, y.refreshRate, maxReaderRetryInterval)
+	delay := y.refreshRate
 	for {
 		select {
 		case <-ctx.Done():
@@ -140,7 +193,7 @@ func (y *YAML) updater(ctx context.Context) {
 				}
 			} else {
 				backOff.Reset()
-				delay = defaultReaderRefreshRate
+				delay = y.refreshRate
 			}
 		}
 	}
@@ -166,7 +219,11 @@ func (y *YAML) reconcile(ctx context.Context) error {
 		return err
 	}
 	lines := strings.Split(s, "\n")
-	if reflect.DeepEqual(lines, y.lines) {
+	if key, rv, ok := detailKey(ctx, y.cacheKind(), y.gvr, y.path); ok {
+		detailCache.Put(key, rv, lines)
+	}
+	wasStale := y.stale.Swap(false)
+	if reflect.DeepEqual(lines, y.lines) && !wasStale {
 		return nil
 	}
 	y.lines = lines
@@ -3,7 +3,15 @@
 
 package model1
 
-import "github.com/derailed/tcell/v2"
+import (
+	"strings"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/tcell/v2"
+)
+
+// SummaryColorCol tracks the hidden column holding a computed row color.
+const SummaryColorCol = "SUMMARY-COLOR"
 
 var (
 	// ModColor row modified color.
@@ -36,6 +44,9 @@ func DefaultColorer(ns string, h Header, re *RowEvent) tcell.Color {
 	if !IsValid(ns, h, re.Row) {
 		return ErrColor
 	}
+	if c, ok := summaryColor(h, re.Row); ok {
+		return c
+	}
 
 	//nolint:exhaustive
 	switch re.Kind {
@@ -49,3 +60,27 @@ func DefaultColorer(ns string, h Header, re *RowEvent) tcell.Color {
 		return StdColor
 	}
 }
+
+// summaryColor returns the row color computed by a view summary if any.
+// Skin colors are referenced by state, other values are color names.
+func summaryColor(h Header, r Row) (tcell.Color, bool) {
+	idx, ok := h.IndexOf(SummaryColorCol, true)
+	if !ok || idx >= len(r.Fields) || r.Fields[idx] == "" {
+		return tcell.ColorDefault, false
+	}
+	switch c := strings.ToLower(strings.TrimSpace(r.Fields[idx])); c {
+	case "ok":
+		return StdColor, true
+	case "pending":
+		return PendingColor, true
+	case "error":
+		return ErrColor, true
+	case "completed":
+		return CompletedColor, true
+	case "highlight":
+		return HighlightColor, true
+	default:
+		color := config.NewColor(c).Color()
+		return color, color != tcell.ColorDefault
+	}
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package model1
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/tcell/v2"
+)
+
+var colorRuleRX = regexp.MustCompile(`^\s*([^\s=!<>~]+)\s*(==|!=|>=|<=|=~|!~|>|<)\s*(.*?)\s*$`)
+
+var colorRuleStyles = map[string]tcell.AttrMask{
+	"":          tcell.AttrNone,
+	"bold":      tcell.AttrBold,
+	"dim":       tcell.AttrDim,
+	"underline": tcell.AttrUnderline,
+	"blink":     tcell.AttrBlink,
+	"reverse":   tcell.AttrReverse,
+}
+
+// ColorRule represents a compiled row color rule.
+type ColorRule struct {
+	col, op, val string
+	num          float64
+	rx           *regexp.Regexp
+	color        tcell.Color
+	attrs        tcell.AttrMask
+}
+
+// ColorRules represents a collection of row color rules. First match wins.
+type ColorRules []ColorRule
+
+// NewColorRules compiles user defined color rules. Invalid rules are skipped
+// and reported.
+func NewColorRules(cc []config.ColorRule) (ColorRules, error) {
+	var (
+		rr   = make(ColorRules, 0, len(cc))
+		errs error
+	)
+	for _, c := range cc {
+		r, err := newColorRule(c)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		rr = append(rr, r)
+	}
+
+	return rr, errs
+}
+
+func newColorRule(c config.ColorRule) (ColorRule, error) {
+	mm := colorRuleRX.FindStringSubmatch(c.Match)
+	if len(mm) != 4 {
+		return ColorRule{}, fmt.Errorf("invalid color rule %q, expecting COLUMN OP VALUE", c.Match)
+	}
+	attrs, ok := colorRuleStyles[c.Style]
+	if !ok {
+		return ColorRule{}, fmt.Errorf("invalid color rule style %q", c.Style)
+	}
+	r := ColorRule{
+		col:   strings.ToUpper(mm[1]),
+		op:    mm[2],
+		val:   strings.Trim(mm[3], `"'`),
+		color: config.NewColor(c.Color).Color(),
+		attrs: attrs,
+	}
+	switch r.op {
+	case "=~", "!~":
+		rx, err := regexp.Compile(r.val)
+		if err != nil {
+			return ColorRule{}, fmt.Errorf("invalid color rule regex %q: %w", r.val, err)
+		}
+		r.rx = rx
+	case ">", ">=", "<", "<=":
+		n, err := strconv.ParseFloat(r.val, 64)
+		if err != nil {
+			return ColorRule{}, fmt.Errorf("color rule %q expects a number", c.Match)
+		}
+		r.num = n
+	}
+
+	return r, nil
+}
+
+// Match returns the color and style of the first rule matching a row.
+func (rr ColorRules) Match(h Header, r Row) (tcell.Color, tcell.AttrMask, bool) {
+	for _, rule := range rr {
+		idx, ok := h.IndexOf(rule.col, true)
+		if !ok || idx >= len(r.Fields) {
+			continue
+		}
+		if rule.matches(r.Fields[idx]) {
+			return rule.color, rule.attrs, true
+		}
+	}
+
+	return tcell.ColorDefault, tcell.AttrNone, false
+}
+
+func (r ColorRule) matches(field string) bool {
+	field = strings.TrimSpace(field)
+	switch r.op {
+	case "==":
+		return strings.EqualFold(field, r.val)
+	case "!=":
+		return !strings.EqualFold(field, r.val)
+	case "=~":
+		return r.rx.MatchString(field)
+	case "!~":
+		return !r.rx.MatchString(field)
+	}
+
+	// Numeric fields may carry a suffix, ie `3 (2m ago)` or `80%`.
+	ff := strings.Fields(field)
+	if len(ff) == 0 {
+		return false
+	}
+	n, err := strconv.ParseFloat(strings.TrimSuffix(ff[0], "%"), 64)
+	if err != nil {
+		return false
+	}
+	switch r.op {
+	case ">":
+		return n > r.num
+	case ">=":
+		return n >= r.num
+	case "<":
+		return n < r.num
+	default:
+		return n <= r.num
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package model1_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColorRulesMatch(t *testing.T) {
+	rr, err := model1.NewColorRules([]config.ColorRule{
+		{Match: "ROBUSTNESS == faulted", Color: "red", Style: "bold"},
+		{Match: "restarts>5", Color: "yellow"},
+		{Match: "%CPU >= 90", Color: "orange"},
+		{Match: "NAME =~ ^canary-", Color: "aqua"},
+	})
+	require.NoError(t, err)
+
+	h := model1.Header{
+		{Name: "NAME"},
+		{Name: "ROBUSTNESS"},
+		{Name: "RESTARTS"},
+		{Name: "%CPU"},
+	}
+	uu := map[string]struct {
+		ff    []string
+		ok    bool
+		e     tcell.Color
+		attrs tcell.AttrMask
+	}{
+		"eq": {
+			ff:    []string{"vol-1", "Faulted", "0", "10"},
+			ok:    true,
+			e:     tcell.ColorRed.TrueColor(),
+			attrs: tcell.AttrBold,
+		},
+		"gt": {
+			ff: []string{"fred", "healthy", "7 (2m ago)", "10"},
+			ok: true,
+			e:  tcell.ColorYellow.TrueColor(),
+		},
+		"perc": {
+			ff: []string{"fred", "healthy", "0", "95%"},
+			ok: true,
+			e:  tcell.ColorOrange.TrueColor(),
+		},
+		"regex": {
+			ff: []string{"canary-1", "healthy", "0", "10"},
+			ok: true,
+			e:  tcell.ColorAqua.TrueColor(),
+		},
+		"no-match": {
+			ff: []string{"fred", "healthy", "5", "n/a"},
+		},
+		"short-row": {
+			ff: []string{"fred"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			c, attrs, ok := rr.Match(h, model1.Row{Fields: u.ff})
+			assert.Equal(t, u.ok, ok)
+			if ok {
+				assert.Equal(t, u.e, c)
+				assert.Equal(t, u.attrs, attrs)
+			}
+		})
+	}
+}
+
+func TestNewColorRulesInvalid(t *testing.T) {
+	uu := map[string]config.ColorRule{
+		"no-op":     {Match: "RESTARTS", Color: "red"},
+		"nan":       {Match: "RESTARTS > lots", Color: "red"},
+		"bad-rx":    {Match: "NAME =~ [", Color: "red"},
+		"bad-style": {Match: "NAME == fred", Color: "red", Style: "sparkly"},
+	}
+
+	for k, u := range uu {
+		t.Run(k, func(t *testing.T) {
+			rr, err := model1.NewColorRules([]config.ColorRule{u, {Match: "NAME == fred", Color: "red"}})
+			require.Error(t, err)
+			assert.Len(t, rr, 1)
+		})
+	}
+}
@@ -64,3 +64,43 @@ func TestDefaultColorer(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultColorerSummary(t *testing.T) {
+	uu := map[string]struct {
+		color string
+		e     tcell.Color
+	}{
+		"none": {
+			e: model1.AddColor,
+		},
+		"named": {
+			color: "red",
+			e:     tcell.ColorRed.TrueColor(),
+		},
+		"skin": {
+			color: "Error",
+			e:     model1.ErrColor,
+		},
+		"unknown": {
+			color: "blee",
+			e:     model1.AddColor,
+		},
+	}
+
+	h := model1.Header{
+		model1.HeaderColumn{Name: "NAME"},
+		model1.HeaderColumn{Name: "STATUS"},
+		model1.HeaderColumn{Name: model1.SummaryColorCol, Attrs: model1.Attrs{Hide: true}},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			re := model1.RowEvent{
+				Kind: model1.EventAdd,
+				Row:  model1.Row{Fields: model1.Fields{"fred", "Ready", u.color}},
+			}
+			assert.Equal(t, u.e, model1.DefaultColorer("", h, &re))
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package model1
+
+import "time"
+
+// ContextStatus tracks the fetches of a context in a multi-context view.
+type ContextStatus struct {
+	// Context is the context name.
+	Context string
+
+	// Count is the number of resources of the last successful fetch.
+	Count int
+
+	// FetchedAt is the time of the last successful fetch.
+	FetchedAt time.Time
+
+	// Err is the last fetch error or nil if it succeeded.
+	Err error
+
+	// Truncated indicates the context holds more resources than listed.
+	Truncated bool
+
+	// Loading indicates a fetch is in flight and the context did not answer yet.
+	Loading bool
+}
@@ -146,7 +146,7 @@ func (t *TableData) Filter(f FilterOpts) *TableData {
 	if f.Toast {
 		td.rowEvents = t.filterToast()
 	}
-	if f.Filter == "" || internal.IsLabelSelector(f.Filter) {
+	if f.Filter == "" || internal.IsLabelSelector(f.Filter) || internal.IsFieldSelector(f.Filter) {
 		return td
 	}
 	if f, ok := internal.IsFuzzySelector(f.Filter); ok {
@@ -405,6 +405,24 @@ func (t *TableData) ColumnNames(w bool) []string {
 	return t.header.ColumnNames(w)
 }
 
+// Records returns the table rows as column name to value records.
+func (t *TableData) Records() []map[string]string {
+	h := t.GetHeader()
+	rr := make([]map[string]string, 0, t.RowCount())
+	t.RowsRange(func(_ int, re RowEvent) bool {
+		r := make(map[string]string, len(h))
+		for i, c := range h {
+			if i < len(re.Row.Fields) {
+				r[c.Name] = re.Row.Fields[i]
+			}
+		}
+		rr = append(rr, r)
+		return true
+	})
+
+	return rr
+}
+
 // GetHeader returns table header.
 func (t *TableData) GetHeader() Header {
 	t.mx.RLock()
@@ -500,6 +518,32 @@ func (t *TableData) InjectClusterColumn(ctxByRowID map[string]string) {
 	})
 }
 
+// SetColumn sets the values of a column keyed by row ID, appending the column
+// when not already in the header. Rows without a value are marked n/a.
+func (t *TableData) SetColumn(col HeaderColumn, values map[string]string) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	idx, ok := t.header.IndexOf(col.Name, true)
+	if !ok {
+		idx = len(t.header)
+		t.header = append(t.header, col)
+	}
+
+	t.rowEvents.Range(func(i int, re RowEvent) bool {
+		v, ok := values[re.Row.ID]
+		if !ok {
+			v = NAValue
+		}
+		ff := make(Fields, max(len(re.Row.Fields), idx+1))
+		copy(ff, re.Row.Fields)
+		ff[idx] = v
+		re.Row.Fields = ff
+		t.rowEvents.Set(i, re)
+		return true
+	})
+}
+
 // Diff checks if two tables are equal.
 func (t *TableData) Diff(t2 *TableData) bool {
 	if t2 == nil || t.namespace != t2.namespace || t.header.Diff(t2.header) {
@@ -369,3 +369,88 @@ func TestTableDataDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestTableDataRecords(t *testing.T) {
+	uu := map[string]struct {
+		t1 *TableData
+		e  []map[string]string
+	}{
+		"empty": {
+			t1: NewTableDataWithRows(
+				client.NewGVR("test"),
+				Header{HeaderColumn{Name: "A"}},
+				NewRowEventsWithEvts(),
+			),
+			e: []map[string]string{},
+		},
+		"rows": {
+			t1: NewTableDataWithRows(
+				client.NewGVR("test"),
+				Header{
+					HeaderColumn{Name: "NAME"},
+					HeaderColumn{Name: "STATUS"},
+					HeaderColumn{Name: "AGE", Attrs: Attrs{Wide: true}},
+				},
+				NewRowEventsWithEvts(
+					RowEvent{Row: Row{ID: "a", Fields: Fields{"a", "Running", "1m"}}},
+					RowEvent{Row: Row{ID: "b", Fields: Fields{"b", "CrashLoopBackOff"}}},
+				),
+			),
+			e: []map[string]string{
+				{"NAME": "a", "STATUS": "Running", "AGE": "1m"},
+				{"NAME": "b", "STATUS": "CrashLoopBackOff"},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.t1.Records())
+		})
+	}
+}
+
+func TestTableDataSetColumn(t *testing.T) {
+	uu := map[string]struct {
+		col    string
+		values map[string]string
+		e      []map[string]string
+	}{
+		"append": {
+			col:    "CPU",
+			values: map[string]string{"a": "12"},
+			e: []map[string]string{
+				{"NAME": "a", "LAT": "", "CPU": "12"},
+				{"NAME": "b", "LAT": "", "CPU": NAValue},
+			},
+		},
+		"existing": {
+			col:    "LAT",
+			values: map[string]string{"a": "5ms", "b": "2.50s"},
+			e: []map[string]string{
+				{"NAME": "a", "LAT": "5ms"},
+				{"NAME": "b", "LAT": "2.50s"},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			td := NewTableDataWithRows(
+				client.NewGVR("test"),
+				Header{
+					HeaderColumn{Name: "NAME"},
+					HeaderColumn{Name: "LAT"},
+				},
+				NewRowEventsWithEvts(
+					RowEvent{Row: Row{ID: "a", Fields: Fields{"a", ""}}},
+					RowEvent{Row: Row{ID: "b", Fields: Fields{"b"}}},
+				),
+			)
+			td.SetColumn(HeaderColumn{Name: u.col}, u.values)
+			assert.Equal(t, u.e, td.Records())
+		})
+	}
+}
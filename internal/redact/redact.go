@@ -0,0 +1,285 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package redact
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mask replaces redacted values.
+const Mask = "<redacted>"
+
+var (
+	sensitiveRX = regexp.MustCompile(`(?i)(passw(or)?d|secret|token|credential|private[-_.]?key|api[-_.]?key|last-applied-configuration)`)
+	lineRX      = regexp.MustCompile(`^([\s│]*(?:[A-Z][A-Za-z ]*:\s+)?)([\w.\-/]+)(:\s*|=)(.*)$`)
+)
+
+// frameChars tracks the characters framing a captured screen line.
+const frameChars = " \t│"
+
+// Redactor masks Secret data and sensitive values. A nil Redactor leaves
+// everything in the clear.
+type Redactor struct {
+	annotations []string
+	keys        []string
+}
+
+// New returns a redactor keeping the given annotations and Secret keys in
+// the clear. Globs are supported.
+func New(annotations, keys []string) *Redactor {
+	return &Redactor{annotations: annotations, keys: keys}
+}
+
+// Sensitive checks if a key, a column or an annotation names a sensitive value.
+func Sensitive(k string) bool {
+	return sensitiveRX.MatchString(k)
+}
+
+// Value masks a value when its key is sensitive and not allowed.
+func (r *Redactor) Value(k, v string) string {
+	if r == nil || v == "" || !Sensitive(k) || allowed(r.annotations, k) {
+		return v
+	}
+
+	return Mask
+}
+
+// YAML masks the data of Secrets, the sensitive keys of ConfigMaps, the values
+// of sensitive env vars and sensitive annotations of the resources in a YAML
+// document. Content not holding resources is redacted as text.
+func (r *Redactor) YAML(raw string) string {
+	if r == nil {
+		return raw
+	}
+	dd, err := decode(raw)
+	if err != nil || !hasObject(dd) {
+		return r.Text(raw)
+	}
+	for _, d := range dd {
+		r.redactNode(d)
+	}
+	out, err := encode(dd)
+	if err != nil {
+		return r.Text(raw)
+	}
+
+	return out
+}
+
+// Data masks all values of a decoded Secret but the allowed keys. Content
+// not holding a map is masked as a whole.
+func (r *Redactor) Data(raw string) string {
+	if r == nil {
+		return raw
+	}
+	dd, err := decode(raw)
+	if err != nil || len(dd) != 1 || len(dd[0].Content) != 1 || dd[0].Content[0].Kind != yaml.MappingNode {
+		return Mask + "\n"
+	}
+	r.maskValues(dd[0].Content[0], r.keys)
+	out, err := encode(dd)
+	if err != nil {
+		return Mask + "\n"
+	}
+
+	return out
+}
+
+// Text masks `key: value` or `key=value` lines with a sensitive key, ie
+// describe output. Values continued on more indented lines are masked too,
+// as are all values under the data of a Secret.
+func (r *Redactor) Text(raw string) string {
+	if r == nil {
+		return raw
+	}
+
+	return r.text(raw, isSecretText(raw))
+}
+
+// SecretText masks text known to hold a Secret, ie a Secret YAML view
+// scrolled past its kind.
+func (r *Redactor) SecretText(raw string) string {
+	if r == nil {
+		return raw
+	}
+
+	return r.text(raw, true)
+}
+
+func (r *Redactor) text(raw string, secret bool) string {
+	var (
+		ll     = strings.Split(raw, "\n")
+		indent = -1
+		data   bool
+	)
+	for i, l := range ll {
+		if indent >= 0 {
+			if n := len(l) - len(strings.TrimLeft(l, frameChars)); n > indent && strings.Trim(l, frameChars) != "" {
+				mm := lineRX.FindStringSubmatch(l)
+				switch {
+				case data && mm != nil && allowed(r.keys, mm[2]):
+				case data && mm != nil:
+					ll[i] = mm[1] + mm[2] + mm[3] + Mask
+				default:
+					ll[i] = l[:n] + Mask
+				}
+				continue
+			}
+			indent, data = -1, false
+		}
+		mm := lineRX.FindStringSubmatch(l)
+		if mm == nil {
+			continue
+		}
+		blank := strings.Trim(mm[4], frameChars) == ""
+		if secret && blank && (mm[2] == "data" || mm[2] == "stringData") {
+			indent, data = len(mm[1]), true
+			continue
+		}
+		if !Sensitive(mm[2]) || allowed(r.annotations, mm[2]) {
+			continue
+		}
+		if blank {
+			indent = len(mm[1])
+			continue
+		}
+		ll[i] = mm[1] + mm[2] + mm[3] + Mask
+	}
+
+	return strings.Join(ll, "\n")
+}
+
+// isSecretText checks if a text holds a Secret manifest.
+func isSecretText(raw string) bool {
+	for _, l := range strings.Split(raw, "\n") {
+		if mm := lineRX.FindStringSubmatch(l); mm != nil && mm[2] == "kind" && strings.Trim(mm[4], frameChars) == "Secret" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Redactor) redactNode(n *yaml.Node) {
+	switch n.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, c := range n.Content {
+			r.redactNode(c)
+		}
+	case yaml.MappingNode:
+		kind, env := scalar(n, "kind"), scalar(n, "name")
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i].Value, n.Content[i+1]
+			switch {
+			case kind == "Secret" && (k == "data" || k == "stringData"):
+				r.maskValues(v, r.keys)
+			case kind == "ConfigMap" && (k == "data" || k == "binaryData") && v.Kind == yaml.MappingNode:
+				maskSensitive(v, r.keys)
+			case k == "annotations" && v.Kind == yaml.MappingNode:
+				maskSensitive(v, r.annotations)
+			case k == "value" && v.Kind == yaml.ScalarNode && Sensitive(env) && !allowed(r.keys, env):
+				mask(v)
+			default:
+				r.redactNode(v)
+			}
+		}
+	}
+}
+
+func maskSensitive(n *yaml.Node, allow []string) {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k := n.Content[i].Value
+		if Sensitive(k) && !allowed(allow, k) {
+			mask(n.Content[i+1])
+		}
+	}
+}
+
+func (*Redactor) maskValues(n *yaml.Node, allow []string) {
+	if n.Kind != yaml.MappingNode {
+		mask(n)
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if !allowed(allow, n.Content[i].Value) {
+			mask(n.Content[i+1])
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func mask(n *yaml.Node) {
+	n.Kind, n.Tag, n.Style, n.Value, n.Content = yaml.ScalarNode, "!!str", 0, Mask, nil
+}
+
+func allowed(globs []string, k string) bool {
+	for _, g := range globs {
+		if ok, _ := path.Match(g, k); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func scalar(n *yaml.Node, k string) string {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == k && n.Content[i+1].Kind == yaml.ScalarNode {
+			return n.Content[i+1].Value
+		}
+	}
+
+	return ""
+}
+
+// hasObject checks if a document holds a resource or a list of resources.
+func hasObject(dd []*yaml.Node) bool {
+	for _, d := range dd {
+		if len(d.Content) == 1 && d.Content[0].Kind == yaml.MappingNode && scalar(d.Content[0], "kind") != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func decode(raw string) ([]*yaml.Node, error) {
+	dec := yaml.NewDecoder(strings.NewReader(raw))
+	var dd []*yaml.Node
+	for {
+		var d yaml.Node
+		if err := dec.Decode(&d); err != nil {
+			if errors.Is(err, io.EOF) {
+				return dd, nil
+			}
+			return nil, err
+		}
+		dd = append(dd, &d)
+	}
+}
+
+func encode(dd []*yaml.Node) (string, error) {
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	for _, d := range dd {
+		if err := enc.Encode(d); err != nil {
+			return "", err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
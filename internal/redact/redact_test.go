@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package redact_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/redact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactYAML(t *testing.T) {
+	uu := map[string]struct {
+		r      *redact.Redactor
+		raw, e string
+	}{
+		"nil": {
+			raw: "kind: Secret\ndata:\n  password: Zm9v\n",
+			e:   "kind: Secret\ndata:\n  password: Zm9v\n",
+		},
+		"secret": {
+			r:   redact.New(nil, []string{"ca.crt"}),
+			raw: "apiVersion: v1\nkind: Secret\ndata:\n  ca.crt: Y2E=\n  password: Zm9v\nstringData:\n  token: bar\n",
+			e:   "apiVersion: v1\nkind: Secret\ndata:\n  ca.crt: Y2E=\n  password: <redacted>\nstringData:\n  token: <redacted>\n",
+		},
+		"annotations": {
+			r:   redact.New([]string{"fred.io/*"}, nil),
+			raw: "kind: Pod\nmetadata:\n  annotations:\n    app: blee\n    fred.io/token: t1\n    vault.io/secret: s1\n",
+			e:   "kind: Pod\nmetadata:\n  annotations:\n    app: blee\n    fred.io/token: t1\n    vault.io/secret: <redacted>\n",
+		},
+		"list": {
+			r:   redact.New(nil, nil),
+			raw: "kind: List\nitems:\n  - kind: Secret\n    data:\n      key: Zm9v\n  - kind: ConfigMap\n    data:\n      key: bar\n",
+			e:   "kind: List\nitems:\n  - kind: Secret\n    data:\n      key: <redacted>\n  - kind: ConfigMap\n    data:\n      key: bar\n",
+		},
+		"configmap": {
+			r:   redact.New(nil, []string{"API_KEY_ID"}),
+			raw: "kind: ConfigMap\ndata:\n  API_KEY_ID: k1\n  DB_HOST: db\n  DB_PASSWORD: s3cr3t\n",
+			e:   "kind: ConfigMap\ndata:\n  API_KEY_ID: k1\n  DB_HOST: db\n  DB_PASSWORD: <redacted>\n",
+		},
+		"env": {
+			r:   redact.New(nil, nil),
+			raw: "kind: Pod\nspec:\n  containers:\n    - name: fred\n      env:\n        - name: DB_HOST\n          value: db\n        - name: DB_PASSWORD\n          value: s3cr3t\n        - name: API_TOKEN\n          valueFrom:\n            secretKeyRef:\n              key: token\n              name: creds\n",
+			e:   "kind: Pod\nspec:\n  containers:\n    - name: fred\n      env:\n        - name: DB_HOST\n          value: db\n        - name: DB_PASSWORD\n          value: <redacted>\n        - name: API_TOKEN\n          valueFrom:\n            secretKeyRef:\n              key: token\n              name: creds\n",
+		},
+		"text": {
+			r:   redact.New(nil, nil),
+			raw: "Name:  fred\nAnnotations:  kubectl.kubernetes.io/last-applied-configuration:\n                {\"kind\":\"Secret\"}\n              app: blee\nPASSWORD=foo",
+			e:   "Name:  fred\nAnnotations:  kubectl.kubernetes.io/last-applied-configuration:\n                <redacted>\n              app: blee\nPASSWORD=<redacted>",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.r.YAML(u.raw))
+		})
+	}
+}
+
+func TestRedactText(t *testing.T) {
+	uu := map[string]struct {
+		raw, e string
+		secret bool
+	}{
+		"secret": {
+			raw: "apiVersion: v1\ndata:\n  .dockerconfigjson: eyJhdXRocyI6e319\n  ca.crt: Y2E=\n  tls.key: LS0tLS1CRUdJTg==\nkind: Secret\n",
+			e:   "apiVersion: v1\ndata:\n  .dockerconfigjson: <redacted>\n  ca.crt: Y2E=\n  tls.key: <redacted>\nkind: Secret\n",
+		},
+		"screen": {
+			raw: "│ data:                          │\n│   tls.key: LS0tLS1CRUdJTg==    │\n│ kind: Secret                   │\n│ metadata:                      │\n│   name: fred                   │",
+			e:   "│ data:                          │\n│   tls.key: <redacted>\n│ kind: Secret                   │\n│ metadata:                      │\n│   name: fred                   │",
+		},
+		"scrolled": {
+			raw:    "data:\n  tls.key: LS0tLS1CRUdJTg==\n",
+			e:      "data:\n  tls.key: <redacted>\n",
+			secret: true,
+		},
+		"block": {
+			raw: "kind: Secret\nstringData:\n  config.yaml: |\n    user: fred\n    pwd-hint: blee\nmetadata:\n  name: fred\n",
+			e:   "kind: Secret\nstringData:\n  config.yaml: <redacted>\n    user: <redacted>\n    pwd-hint: <redacted>\nmetadata:\n  name: fred\n",
+		},
+		"configmap": {
+			raw: "kind: ConfigMap\ndata:\n  tls.key: blee\n",
+			e:   "kind: ConfigMap\ndata:\n  tls.key: blee\n",
+		},
+	}
+
+	r := redact.New(nil, []string{"*.crt"})
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			if u.secret {
+				assert.Equal(t, u.e, r.SecretText(u.raw))
+				return
+			}
+			assert.Equal(t, u.e, r.Text(u.raw))
+		})
+	}
+}
+
+func TestRedactData(t *testing.T) {
+	uu := map[string]struct {
+		raw, e string
+	}{
+		"map": {
+			raw: "ca.crt: ca\npassword: foo\n",
+			e:   "ca.crt: ca\npassword: <redacted>\n",
+		},
+		"text": {
+			raw: "Expires: now\n\ntok:en\n- blee",
+			e:   "<redacted>\n",
+		},
+	}
+
+	r := redact.New(nil, []string{"*.crt"})
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, r.Data(u.raw))
+		})
+	}
+}
+
+func TestRedactValue(t *testing.T) {
+	uu := map[string]struct {
+		r    *redact.Redactor
+		k, v string
+		e    string
+	}{
+		"nil":     {k: "TOKEN", v: "t1", e: "t1"},
+		"plain":   {r: redact.New(nil, nil), k: "NAME", v: "fred", e: "fred"},
+		"blank":   {r: redact.New(nil, nil), k: "TOKEN", e: ""},
+		"token":   {r: redact.New(nil, nil), k: "TOKEN", v: "t1", e: redact.Mask},
+		"api-key": {r: redact.New(nil, nil), k: "COL-API_KEY", v: "k1", e: redact.Mask},
+		"allowed": {r: redact.New([]string{"TOKEN"}, nil), k: "TOKEN", v: "t1", e: "t1"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.r.Value(u.k, u.v))
+		})
+	}
+}
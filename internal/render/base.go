@@ -10,6 +10,7 @@ import (
 	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/model1"
 	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/tview"
 )
 
 // DecoratorFunc decorates a string.
@@ -21,6 +22,7 @@ var AgeDecorator = toAgeHuman
 type Base struct {
 	vs         *config.ViewSetting
 	specs      ColumnSpecs
+	summary    *Summarizer
 	includeObj bool
 }
 
@@ -44,15 +46,28 @@ func (b *Base) doHeader(dh model1.Header) model1.Header {
 // SetViewSetting sets custom view settings if any.
 func (b *Base) SetViewSetting(vs *config.ViewSetting) {
 	var cols []string
-	b.vs = vs
+	b.vs, b.summary = vs, nil
 	if vs != nil {
 		cols = vs.Columns
 	}
+	if vs != nil && vs.Summary != nil {
+		sm, err := NewSummarizer(vs.Summary)
+		if err != nil {
+			slog.Warn("Unable to compile view summary", slogs.Error, err)
+		}
+		b.summary = sm
+	}
 	specs, err := NewColsSpecs(cols...).parseSpecs()
 	if err != nil {
 		slog.Error("Unable to grok custom columns", slogs.Error, err)
 		return
 	}
+	for i := range specs {
+		if _, ok := vs.PromColumn(specs[i].Header.Name); ok {
+			specs[i].Deferred = true
+			specs[i].Header.Align = tview.AlignRight
+		}
+	}
 	b.specs = specs
 }
 
@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Canary comparison verdicts.
+const (
+	CanaryMatch           = "Match"
+	CanaryDiverged        = "Diverged"
+	CanaryDegraded        = "Degraded"
+	CanaryMissingBaseline = "MissingBaseline"
+	CanaryMissingCanary   = "MissingCanary"
+)
+
+var defaultCanaryHeader = model1.Header{
+	model1.HeaderColumn{Name: "NAMESPACE"},
+	model1.HeaderColumn{Name: "KIND"},
+	model1.HeaderColumn{Name: "NAME"},
+	model1.HeaderColumn{Name: "BASELINE"},
+	model1.HeaderColumn{Name: "CANARY"},
+	model1.HeaderColumn{Name: "B-READY", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "C-READY", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "B-RESTARTS", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "C-RESTARTS", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "B-CPU", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "C-CPU", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "B-MEM", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "C-MEM", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "VERDICT"},
+}
+
+// Canary renders a baseline versus canary workload comparison to screen.
+type Canary struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (Canary) ColorerFunc() model1.ColorerFunc {
+	return func(ns string, h model1.Header, re *model1.RowEvent) tcell.Color {
+		c := model1.DefaultColorer(ns, h, re)
+
+		idx, ok := h.IndexOf("VERDICT", true)
+		if !ok {
+			return c
+		}
+		switch strings.TrimSpace(re.Row.Fields[idx]) {
+		case CanaryDegraded:
+			c = model1.ErrColor
+		case CanaryMissingBaseline, CanaryMissingCanary:
+			c = model1.PendingColor
+		case CanaryDiverged:
+			c = model1.HighlightColor
+		}
+
+		return c
+	}
+}
+
+// Header returns a header row.
+func (Canary) Header(string) model1.Header {
+	return defaultCanaryHeader
+}
+
+// Render renders a K8s resource to screen.
+func (Canary) Render(o any, _ string, r *model1.Row) error {
+	res, ok := o.(*CanaryRes)
+	if !ok {
+		return fmt.Errorf("expected CanaryRes but got %T", o)
+	}
+
+	r.ID = res.ID()
+	r.Fields = model1.Fields{
+		res.Namespace,
+		res.Kind,
+		res.Name,
+		na(res.Baseline.Version),
+		na(res.Canary.Version),
+		res.Baseline.ready(),
+		res.Canary.ready(),
+		res.Baseline.restarts(),
+		res.Canary.restarts(),
+		res.Baseline.cpu(),
+		res.Canary.cpu(),
+		res.Baseline.mem(),
+		res.Canary.mem(),
+		res.Verdict(),
+	}
+
+	return nil
+}
+
+// CanarySide represents a workload state on one of the compared contexts.
+type CanarySide struct {
+	Found          bool
+	Version        string
+	Desired, Ready int64
+	Restarts       int64
+	CPU, MEM       int64
+}
+
+// Healthy checks if all desired replicas are ready.
+func (s CanarySide) Healthy() bool {
+	return s.Found && s.Ready >= s.Desired
+}
+
+func (s CanarySide) ready() string {
+	if !s.Found {
+		return NAValue
+	}
+	return strconv.FormatInt(s.Ready, 10) + "/" + strconv.FormatInt(s.Desired, 10)
+}
+
+func (s CanarySide) restarts() string {
+	if !s.Found {
+		return NAValue
+	}
+	return strconv.FormatInt(s.Restarts, 10)
+}
+
+func (s CanarySide) cpu() string {
+	if !s.Found {
+		return NAValue
+	}
+	return toMc(s.CPU)
+}
+
+func (s CanarySide) mem() string {
+	if !s.Found {
+		return NAValue
+	}
+	return toMi(s.MEM)
+}
+
+// CanaryRes represents a workload compared across the baseline and canary contexts.
+type CanaryRes struct {
+	Namespace, Kind, Name string
+	Baseline, Canary      CanarySide
+}
+
+// ID returns the workload identifier.
+func (c *CanaryRes) ID() string {
+	return c.Kind + "|" + client.FQN(c.Namespace, c.Name)
+}
+
+// Verdict summarizes how the canary compares to the baseline.
+func (c *CanaryRes) Verdict() string {
+	switch {
+	case !c.Baseline.Found:
+		return CanaryMissingBaseline
+	case !c.Canary.Found:
+		return CanaryMissingCanary
+	case !c.Canary.Healthy() && c.Baseline.Healthy(),
+		c.Canary.Restarts > c.Baseline.Restarts:
+		return CanaryDegraded
+	case c.Baseline.Version != c.Canary.Version:
+		return CanaryDiverged
+	default:
+		return CanaryMatch
+	}
+}
+
+// GetObjectKind returns a schema object.
+func (*CanaryRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (c *CanaryRes) DeepCopyObject() runtime.Object {
+	return c
+}
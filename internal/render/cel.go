@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+)
+
+// celPrograms caches compiled CEL programs by expression.
+var celPrograms sync.Map
+
+type celProgram struct {
+	prg cel.Program
+	err error
+}
+
+// CELMatch evaluates a CEL predicate against a resource bound to `self`.
+func CELMatch(expr string, o map[string]any) (bool, error) {
+	prg, err := compileCEL(expr)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := prg.Eval(map[string]any{"self": o})
+	if err != nil {
+		return false, err
+	}
+	b, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q must return a bool but got %T", expr, out.Value())
+	}
+
+	return b, nil
+}
+
+func compileCEL(expr string) (cel.Program, error) {
+	if p, ok := celPrograms.Load(expr); ok {
+		cp := p.(celProgram)
+		return cp.prg, cp.err
+	}
+	var cp celProgram
+	env, err := cel.NewEnv(
+		cel.Variable("self", cel.DynType),
+		ext.Strings(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ast, iss := env.Compile(expr)
+	if err := iss.Err(); err != nil {
+		cp.err = fmt.Errorf("invalid expression %q: %w", expr, err)
+	} else {
+		cp.prg, cp.err = env.Program(ast)
+	}
+	celPrograms.Store(expr, cp)
+
+	return cp.prg, cp.err
+}
+
+func evalCEL(prg cel.Program, vars map[string]any) (string, error) {
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := out.Value().(string); ok {
+		return s, nil
+	}
+
+	return fmt.Sprintf("%v", out.Value()), nil
+}
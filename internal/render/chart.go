@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/config"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/util/jsonpath"
+	"k8s.io/kubectl/pkg/cmd/get"
+)
+
+const (
+	chartWidth = 30
+	chartFull  = "█"
+	chartEmpty = "░"
+	chartBytes = "bytes"
+)
+
+// RenderCharts renders the chart panels declared for a resource as text lines.
+func RenderCharts(o map[string]any, cc []config.ChartSpec) []string {
+	ll := make([]string, 0, 2*len(cc))
+	for _, c := range cc {
+		vv, ok := make([]float64, len(c.Values)), make([]bool, len(c.Values))
+		for i, v := range c.Values {
+			vv[i], ok[i] = chartValue(o, v.Path)
+		}
+		mx := chartMax(o, c.Max, vv)
+		ll = append(ll, c.Title)
+		if c.IsGauge() {
+			if len(vv) == 0 || !ok[0] {
+				ll = append(ll, "  "+NAValue)
+				continue
+			}
+			ll = append(ll, fmt.Sprintf("  %s %3d%% %s/%s", chartBar(vv[0], mx), chartPerc(vv[0], mx), fmtChartValue(vv[0], c.Unit), fmtChartValue(mx, c.Unit)))
+			continue
+		}
+		var w int
+		for _, v := range c.Values {
+			w = max(w, len(chartLabel(v)))
+		}
+		for i, v := range c.Values {
+			val := NAValue
+			if ok[i] {
+				val = fmtChartValue(vv[i], c.Unit)
+			}
+			ll = append(ll, fmt.Sprintf("  %-*s %s %s", w, chartLabel(v), chartBar(vv[i], mx), val))
+		}
+	}
+
+	return ll
+}
+
+func chartLabel(v config.ChartValue) string {
+	if v.Label != "" {
+		return v.Label
+	}
+
+	return v.Path
+}
+
+func chartMax(o map[string]any, spec string, vv []float64) float64 {
+	if spec != "" {
+		if f, err := strconv.ParseFloat(spec, 64); err == nil {
+			return f
+		}
+		if f, ok := chartValue(o, spec); ok {
+			return f
+		}
+	}
+	var mx float64
+	for _, v := range vv {
+		mx = max(mx, v)
+	}
+
+	return mx
+}
+
+func chartValue(o map[string]any, path string) (float64, bool) {
+	spec, err := get.RelaxedJSONPathExpression(path)
+	if err != nil {
+		return 0, false
+	}
+	jp := jsonpath.New("chart").AllowMissingKeys(true)
+	if err := jp.Parse(spec); err != nil {
+		return 0, false
+	}
+	rr, err := jp.FindResults(o)
+	if err != nil || len(rr) == 0 || len(rr[0]) == 0 {
+		return 0, false
+	}
+
+	switch v := rr[0][0].Interface().(type) {
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f, true
+		}
+		if q, err := resource.ParseQuantity(v); err == nil {
+			return q.AsApproximateFloat64(), true
+		}
+	}
+
+	return 0, false
+}
+
+// chartPerc returns the percentage of a value clamped to [0, 100]. Non
+// finite values count as 0.
+func chartPerc(v, mx float64) int {
+	if mx <= 0 || !isFinite(v) || !isFinite(mx) {
+		return 0
+	}
+
+	return int(max(min(v/mx, 1), 0) * 100)
+}
+
+func chartBar(v, mx float64) string {
+	n := max(min(chartPerc(v, mx)*chartWidth/100, chartWidth), 0)
+
+	return strings.Repeat(chartFull, n) + strings.Repeat(chartEmpty, chartWidth-n)
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+func fmtChartValue(v float64, unit string) string {
+	if unit != chartBytes {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	units := []string{"B", "Ki", "Mi", "Gi", "Ti", "Pi"}
+	i := 0
+	for ; v >= 1024 && i < len(units)-1; i++ {
+		v /= 1024
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f%s", v, units[i])
+	}
+
+	return fmt.Sprintf("%.1f%s", v, units[i])
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render_test
+
+import (
+	"strings"
+	"testing"
+
+	cfg "github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderCharts(t *testing.T) {
+	bar := func(full int) string {
+		return strings.Repeat("█", full) + strings.Repeat("░", 30-full)
+	}
+	o := map[string]any{
+		"spec": map[string]any{"size": "10737418240"},
+		"status": map[string]any{
+			"actualSize": "5368709120",
+			"memory":     "512Mi",
+			"summary": map[string]any{
+				"desiredReady": int64(4),
+				"ready":        int64(3),
+				"modified":     int64(1),
+				"overcommit":   int64(9),
+				"negative":     int64(-2),
+				"nan":          "NaN",
+				"inf":          "+Inf",
+				"zero":         int64(0),
+			},
+		},
+	}
+
+	uu := map[string]struct {
+		cc []cfg.ChartSpec
+		e  []string
+	}{
+		"gauge": {
+			cc: []cfg.ChartSpec{{
+				Title:  "Size",
+				Type:   "gauge",
+				Unit:   "bytes",
+				Max:    ".spec.size",
+				Values: []cfg.ChartValue{{Path: ".status.actualSize"}},
+			}},
+			e: []string{"Size", "  " + bar(15) + "  50% 5.0Gi/10.0Gi"},
+		},
+		"gauge-literal-max": {
+			cc: []cfg.ChartSpec{{
+				Title:  "Memory",
+				Type:   "gauge",
+				Unit:   "bytes",
+				Max:    "1073741824",
+				Values: []cfg.ChartValue{{Path: "{.status.memory}"}},
+			}},
+			e: []string{"Memory", "  " + bar(15) + "  50% 512.0Mi/1.0Gi"},
+		},
+		"gauge-missing": {
+			cc: []cfg.ChartSpec{{
+				Title:  "Size",
+				Type:   "gauge",
+				Values: []cfg.ChartValue{{Path: ".status.bozo"}},
+			}},
+			e: []string{"Size", "  n/a"},
+		},
+		"bars": {
+			cc: []cfg.ChartSpec{{
+				Title: "Bundles",
+				Max:   ".status.summary.desiredReady",
+				Values: []cfg.ChartValue{
+					{Label: "ready", Path: ".status.summary.ready"},
+					{Label: "modified", Path: ".status.summary.modified"},
+					{Label: "bozo", Path: ".status.summary.bozo"},
+				},
+			}},
+			e: []string{
+				"Bundles",
+				"  ready    " + bar(22) + " 3",
+				"  modified " + bar(7) + " 1",
+				"  bozo     " + bar(0) + " n/a",
+			},
+		},
+		"clamped": {
+			cc: []cfg.ChartSpec{{
+				Title: "Clamped",
+				Max:   ".status.summary.desiredReady",
+				Values: []cfg.ChartValue{
+					{Label: "over", Path: ".status.summary.overcommit"},
+					{Label: "neg", Path: ".status.summary.negative"},
+					{Label: "nan", Path: ".status.summary.nan"},
+					{Label: "inf", Path: ".status.summary.inf"},
+				},
+			}},
+			e: []string{
+				"Clamped",
+				"  over " + bar(30) + " 9",
+				"  neg  " + bar(0) + " -2",
+				"  nan  " + bar(0) + " NaN",
+				"  inf  " + bar(0) + " +Inf",
+			},
+		},
+		"zero-max": {
+			cc: []cfg.ChartSpec{{
+				Title:  "Size",
+				Type:   "gauge",
+				Max:    ".status.summary.zero",
+				Values: []cfg.ChartValue{{Path: ".status.summary.overcommit"}},
+			}},
+			e: []string{"Size", "  " + bar(0) + "   0% 9/0"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, render.RenderCharts(o, u.cc))
+		})
+	}
+}
@@ -43,6 +43,7 @@ func (Context) Header(string) model1.Header {
 		model1.HeaderColumn{Name: "CLUSTER"},
 		model1.HeaderColumn{Name: "AUTHINFO"},
 		model1.HeaderColumn{Name: "NAMESPACE"},
+		model1.HeaderColumn{Name: "WARMUP"},
 	}
 }
 
@@ -71,6 +72,7 @@ func (Context) Render(o any, _ string, r *model1.Row) error {
 		ctx.Context.Cluster,
 		ctx.Context.AuthInfo,
 		ctx.Context.Namespace,
+		ctx.Warmup,
 	}
 
 	return nil
@@ -83,6 +85,9 @@ type NamedContext struct {
 	Name    string
 	Context *api.Context
 	Config  ContextNamer
+
+	// Warmup tracks the context startup warm up status if any.
+	Warmup string
 }
 
 // ContextNamer represents a named context.
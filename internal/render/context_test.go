@@ -16,7 +16,7 @@ import (
 func TestContextHeader(t *testing.T) {
 	var c render.Context
 
-	assert.Len(t, c.Header(""), 5)
+	assert.Len(t, c.Header(""), 6)
 }
 
 func TestContextRender(t *testing.T) {
@@ -37,7 +37,22 @@ func TestContextRender(t *testing.T) {
 			},
 			e: model1.Row{
 				ID:     "c1",
-				Fields: model1.Fields{"c1", "", "c1", "u1", "ns1"},
+				Fields: model1.Fields{"c1", "", "c1", "u1", "ns1", ""},
+			},
+		},
+		"warmed": {
+			ctx: &render.NamedContext{
+				Name: "c2",
+				Context: &api.Context{
+					Cluster:  "c2",
+					AuthInfo: "u2",
+				},
+				Config: &config{},
+				Warmup: "ready 1.2s",
+			},
+			e: model1.Row{
+				ID:     "c2",
+				Fields: model1.Fields{"c2", "", "c2", "u2", "", "ready 1.2s"},
 			},
 		},
 	}
@@ -82,6 +82,9 @@ func (r RenderedCol) has(n string) bool {
 type ColumnSpec struct {
 	Header model1.HeaderColumn
 	Spec   string
+
+	// Deferred indicates the column values are filled in after rendering.
+	Deferred bool
 }
 
 // ColumnSpecs tracks a collection of column specs.
@@ -156,6 +159,9 @@ func hydrate(o runtime.Object, cc ColumnSpecs, parsers []*jsonpath.JSONPath, rh
 					Header: cc[idx].Header,
 					Value:  NAValue,
 				}
+				if cc[idx].Deferred {
+					continue
+				}
 				slog.Warn("Unable to find custom column", slogs.Name, cc[idx].Header.Name)
 				continue
 			}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var defaultEtcdMemberHeader = model1.Header{
+	model1.HeaderColumn{Name: "CONTEXT"},
+	model1.HeaderColumn{Name: "NAME"},
+	model1.HeaderColumn{Name: "ID"},
+	model1.HeaderColumn{Name: "LEADER"},
+	model1.HeaderColumn{Name: "LEARNER"},
+	model1.HeaderColumn{Name: "VERSION"},
+	model1.HeaderColumn{Name: "DB-SIZE", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "DB-IN-USE", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "RAFT-TERM", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "RAFT-INDEX", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "CLIENT-URL"},
+	model1.HeaderColumn{Name: "PEER-URL", Attrs: model1.Attrs{Wide: true}},
+	model1.HeaderColumn{Name: "POD", Attrs: model1.Attrs{Wide: true}},
+	model1.HeaderColumn{Name: "ERRORS"},
+}
+
+// EtcdMember renders etcd members to screen.
+type EtcdMember struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (EtcdMember) ColorerFunc() model1.ColorerFunc {
+	return func(ns string, h model1.Header, re *model1.RowEvent) tcell.Color {
+		c := model1.DefaultColorer(ns, h, re)
+
+		if idx, ok := h.IndexOf("ERRORS", true); ok && strings.TrimSpace(re.Row.Fields[idx]) != "" {
+			return model1.ErrColor
+		}
+		if idx, ok := h.IndexOf("VERSION", true); ok && strings.TrimSpace(re.Row.Fields[idx]) == NAValue {
+			return model1.ErrColor
+		}
+		if idx, ok := h.IndexOf("LEADER", true); ok && strings.TrimSpace(re.Row.Fields[idx]) == "true" {
+			return model1.HighlightColor
+		}
+		if idx, ok := h.IndexOf("LEARNER", true); ok && strings.TrimSpace(re.Row.Fields[idx]) == "true" {
+			return model1.PendingColor
+		}
+
+		return c
+	}
+}
+
+// Header returns a header row.
+func (EtcdMember) Header(string) model1.Header {
+	return defaultEtcdMemberHeader
+}
+
+// Render renders a K8s resource to screen.
+func (EtcdMember) Render(o any, _ string, r *model1.Row) error {
+	res, ok := o.(*EtcdMemberRes)
+	if !ok {
+		return fmt.Errorf("expected EtcdMemberRes but got %T", o)
+	}
+
+	r.ID = res.RowID()
+	r.Fields = model1.Fields{
+		res.Context,
+		na(res.Name),
+		res.ID,
+		boolToStr(res.Leader),
+		boolToStr(res.Learner),
+		na(res.Version),
+		toEtcdSize(res.Reachable, res.DBSize),
+		toEtcdSize(res.Reachable, res.DBSizeInUse),
+		toEtcdCount(res.Reachable, res.RaftTerm),
+		toEtcdCount(res.Reachable, res.RaftIndex),
+		na(res.ClientURL),
+		na(res.PeerURL),
+		res.Pod,
+		strings.Join(res.Errors, ","),
+	}
+
+	return nil
+}
+
+func toEtcdSize(ok bool, v int64) string {
+	if !ok {
+		return NAValue
+	}
+
+	return strconv.FormatInt(client.ToMB(v), 10) + "Mi"
+}
+
+func toEtcdCount(ok bool, v uint64) string {
+	if !ok {
+		return NAValue
+	}
+
+	return strconv.FormatUint(v, 10)
+}
+
+// EtcdMemberRes represents an etcd cluster member.
+type EtcdMemberRes struct {
+	Context, Pod       string
+	ID, Name           string
+	PeerURL, ClientURL string
+	Version            string
+	Leader, Learner    bool
+	Reachable          bool
+	DBSize             int64
+	DBSizeInUse        int64
+	RaftTerm           uint64
+	RaftIndex          uint64
+	Errors             []string
+}
+
+// RowID returns the member identifier, carrying the context and the etcd pod
+// used to run member actions.
+func (e *EtcdMemberRes) RowID() string {
+	return strings.Join([]string{e.Context, e.Pod, e.ID, e.Name}, "|")
+}
+
+// GetObjectKind returns a schema object.
+func (*EtcdMemberRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (e *EtcdMemberRes) DeepCopyObject() runtime.Object {
+	return e
+}
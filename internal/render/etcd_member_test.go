@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEtcdMemberRender(t *testing.T) {
+	c := render.EtcdMember{}
+	r := model1.NewRow(14)
+
+	o := render.EtcdMemberRes{
+		Context:   "ct1",
+		Pod:       "kube-system/etcd-cp1",
+		ID:        "8e9e05c52164694d",
+		Name:      "cp1",
+		ClientURL: "https://10.0.0.1:2379",
+		Version:   "3.5.9",
+		Leader:    true,
+		Reachable: true,
+		DBSize:    20 * 1024 * 1024,
+		RaftTerm:  2,
+		RaftIndex: 4,
+	}
+	require.NoError(t, c.Render(&o, "", &r))
+	assert.Equal(t, "ct1|kube-system/etcd-cp1|8e9e05c52164694d|cp1", r.ID)
+	assert.Equal(t, model1.Fields{"ct1", "cp1", "8e9e05c52164694d", "true", "false", "3.5.9", "20Mi", "0Mi", "2", "4", "https://10.0.0.1:2379"}, r.Fields[:11])
+}
+
+func TestEtcdMemberRenderUnreachable(t *testing.T) {
+	c := render.EtcdMember{}
+	r := model1.NewRow(14)
+
+	require.NoError(t, c.Render(&render.EtcdMemberRes{Context: "ct1", ID: "a1"}, "", &r))
+	assert.Equal(t, model1.Fields{render.NAValue, render.NAValue, render.NAValue, render.NAValue, render.NAValue}, r.Fields[5:10])
+}
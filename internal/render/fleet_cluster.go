@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tview"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// FleetAgentStaleAfter tracks how long an agent may stay silent before being flagged.
+// Fleet agents check in every 15m by default.
+const FleetAgentStaleAfter = 30 * time.Minute
+
+var defaultFleetClusterHeader = model1.Header{
+	model1.HeaderColumn{Name: "NAMESPACE"},
+	model1.HeaderColumn{Name: "NAME"},
+	model1.HeaderColumn{Name: "STATE"},
+	model1.HeaderColumn{Name: "BUNDLES", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "NODES", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "AGENT-VERSION"},
+	model1.HeaderColumn{Name: "LAST-SEEN", Attrs: model1.Attrs{Time: true}},
+	model1.HeaderColumn{Name: "LABELS", Attrs: model1.Attrs{Wide: true}},
+	model1.HeaderColumn{Name: "VALID", Attrs: model1.Attrs{Wide: true}},
+	model1.HeaderColumn{Name: "AGE", Attrs: model1.Attrs{Time: true}},
+}
+
+// FleetCluster renders a Fleet cluster registration to screen.
+type FleetCluster struct {
+	Base
+}
+
+// Header returns a header row.
+func (f FleetCluster) Header(_ string) model1.Header {
+	return f.doHeader(defaultFleetClusterHeader)
+}
+
+// Render renders a K8s resource to screen.
+func (f FleetCluster) Render(o any, _ string, row *model1.Row) error {
+	var (
+		raw     *unstructured.Unstructured
+		version string
+	)
+	switch t := o.(type) {
+	case *FleetClusterWithAgent:
+		raw, version = t.Raw, t.AgentVersion
+	case *unstructured.Unstructured:
+		raw = t
+	default:
+		return fmt.Errorf("expected FleetClusterWithAgent, but got %T", o)
+	}
+	if err := f.defaultRow(raw, version, row); err != nil {
+		return err
+	}
+	if f.specs.isEmpty() {
+		return nil
+	}
+	cols, err := f.specs.realize(raw, defaultFleetClusterHeader, row)
+	if err != nil {
+		return err
+	}
+	cols.hydrateRow(row)
+
+	return nil
+}
+
+func (f FleetCluster) defaultRow(raw *unstructured.Unstructured, version string, r *model1.Row) error {
+	state, _, _ := unstructured.NestedString(raw.Object, "status", "display", "state")
+	ready, _, _ := unstructured.NestedInt64(raw.Object, "status", "summary", "ready")
+	desired, _, _ := unstructured.NestedInt64(raw.Object, "status", "summary", "desiredReady")
+	readyNodes, _, _ := unstructured.NestedInt64(raw.Object, "status", "agent", "readyNodes")
+	nonReadyNodes, _, _ := unstructured.NestedInt64(raw.Object, "status", "agent", "nonReadyNodes")
+	ls, _, _ := unstructured.NestedString(raw.Object, "status", "agent", "lastSeen")
+
+	var lastSeen metav1.Time
+	if ls != "" {
+		if err := lastSeen.UnmarshalQueryParameter(ls); err != nil {
+			return err
+		}
+	}
+
+	r.ID = client.FQN(raw.GetNamespace(), raw.GetName())
+	r.Fields = model1.Fields{
+		raw.GetNamespace(),
+		raw.GetName(),
+		missing(state),
+		strconv.FormatInt(ready, 10) + "/" + strconv.FormatInt(desired, 10),
+		strconv.FormatInt(readyNodes, 10) + "/" + strconv.FormatInt(readyNodes+nonReadyNodes, 10),
+		na(version),
+		ToAge(lastSeen),
+		mapToStr(raw.GetLabels()),
+		AsStatus(f.diagnose(lastSeen, ready, desired)),
+		ToAge(raw.GetCreationTimestamp()),
+	}
+
+	return nil
+}
+
+func (FleetCluster) diagnose(lastSeen metav1.Time, ready, desired int64) error {
+	if lastSeen.IsZero() {
+		return errors.New("agent never reported")
+	}
+	if time.Since(lastSeen.Time) > FleetAgentStaleAfter {
+		return fmt.Errorf("agent stopped reporting %s ago", ToAge(lastSeen))
+	}
+	if ready < desired {
+		return fmt.Errorf("bundles ready %d/%d", ready, desired)
+	}
+
+	return nil
+}
+
+// FleetClusterWithAgent represents a Fleet cluster and its deployed agent version.
+type FleetClusterWithAgent struct {
+	Raw          *unstructured.Unstructured
+	AgentVersion string
+}
+
+// GetObjectKind returns a schema object.
+func (*FleetClusterWithAgent) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (f *FleetClusterWithAgent) DeepCopyObject() runtime.Object {
+	return f
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFleetClusterRender(t *testing.T) {
+	c := render.FleetCluster{}
+	r := model1.NewRow(10)
+
+	o := render.FleetClusterWithAgent{Raw: load(t, "fleet_cluster"), AgentVersion: "v0.12.2"}
+	require.NoError(t, c.Render(&o, "", &r))
+	assert.Equal(t, "fleet-default/c-m-fred", r.ID)
+	assert.Equal(t, model1.Fields{"fleet-default", "c-m-fred", "WaitCheckIn", "3/4", "2/3", "v0.12.2"}, r.Fields[:6])
+	assert.True(t, strings.HasPrefix(r.Fields[8], "agent stopped reporting"))
+}
+
+func TestFleetClusterRenderRaw(t *testing.T) {
+	c := render.FleetCluster{}
+	r := model1.NewRow(10)
+
+	require.NoError(t, c.Render(load(t, "fleet_cluster"), "", &r))
+	assert.Equal(t, render.NAValue, r.Fields[5])
+}
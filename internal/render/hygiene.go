@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tcell/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Rancher hygiene findings.
+const (
+	FindingDormantUser    = "DormantUser"
+	FindingLongLivedToken = "LongLivedToken"
+	FindingAdminBinding   = "AdminBinding"
+	FindingOrphanBinding  = "OrphanedAdminBinding"
+)
+
+// Rancher hygiene finding kinds.
+const (
+	HygieneUser    = "User"
+	HygieneToken   = "Token"
+	HygieneBinding = "GlobalRoleBinding"
+)
+
+var defaultHygieneHeader = model1.Header{
+	model1.HeaderColumn{Name: "KIND"},
+	model1.HeaderColumn{Name: "NAME"},
+	model1.HeaderColumn{Name: "USER"},
+	model1.HeaderColumn{Name: "FINDING"},
+	model1.HeaderColumn{Name: "DETAIL", Attrs: model1.Attrs{Wide: true}},
+	model1.HeaderColumn{Name: "AGE", Attrs: model1.Attrs{Time: true}},
+}
+
+// Hygiene renders Rancher user and token hygiene findings to screen.
+type Hygiene struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (Hygiene) ColorerFunc() model1.ColorerFunc {
+	return func(ns string, h model1.Header, re *model1.RowEvent) tcell.Color {
+		c := model1.DefaultColorer(ns, h, re)
+
+		idx, ok := h.IndexOf("FINDING", true)
+		if !ok {
+			return c
+		}
+		switch strings.TrimSpace(re.Row.Fields[idx]) {
+		case FindingOrphanBinding:
+			c = model1.ErrColor
+		case FindingDormantUser, FindingLongLivedToken:
+			c = model1.PendingColor
+		}
+
+		return c
+	}
+}
+
+// Header returns a header row.
+func (Hygiene) Header(string) model1.Header {
+	return defaultHygieneHeader
+}
+
+// Render renders a K8s resource to screen.
+func (Hygiene) Render(o any, _ string, r *model1.Row) error {
+	res, ok := o.(*HygieneRes)
+	if !ok {
+		return fmt.Errorf("expected HygieneRes but got %T", o)
+	}
+
+	r.ID = res.ID()
+	r.Fields = model1.Fields{
+		res.Kind,
+		res.Name,
+		na(res.User),
+		res.Finding,
+		res.Detail,
+		ToAge(metav1.NewTime(res.Created)),
+	}
+
+	return nil
+}
+
+// HygieneRes represents a Rancher user, token or admin binding hygiene finding.
+type HygieneRes struct {
+	Kind, Name   string
+	UserID, User string
+	Finding      string
+	Detail       string
+	Created      time.Time
+}
+
+// ID returns the finding identifier, carrying the resource and user it applies to.
+func (h *HygieneRes) ID() string {
+	return strings.Join([]string{h.Kind, h.Name, h.UserID, h.Finding}, "|")
+}
+
+// GetObjectKind returns a schema object.
+func (*HygieneRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (h *HygieneRes) DeepCopyObject() runtime.Object {
+	return h
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tcell/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var defaultInventoryHeader = model1.Header{
+	model1.HeaderColumn{Name: "CONTEXT"},
+	model1.HeaderColumn{Name: "NODE"},
+	model1.HeaderColumn{Name: "ROLES"},
+	model1.HeaderColumn{Name: "OS-IMAGE"},
+	model1.HeaderColumn{Name: "KERNEL"},
+	model1.HeaderColumn{Name: "RUNTIME"},
+	model1.HeaderColumn{Name: "KUBELET"},
+	model1.HeaderColumn{Name: "ARCH"},
+	model1.HeaderColumn{Name: "DRIFT"},
+}
+
+// Inventory renders node OS and component versions to screen.
+type Inventory struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (Inventory) ColorerFunc() model1.ColorerFunc {
+	return func(ns string, h model1.Header, re *model1.RowEvent) tcell.Color {
+		c := model1.DefaultColorer(ns, h, re)
+
+		idx, ok := h.IndexOf("DRIFT", true)
+		if !ok {
+			return c
+		}
+		if d := strings.TrimSpace(re.Row.Fields[idx]); d != "" && d != client.NA {
+			c = model1.PendingColor
+		}
+
+		return c
+	}
+}
+
+// Header returns a header row.
+func (Inventory) Header(string) model1.Header {
+	return defaultInventoryHeader
+}
+
+// Render renders a K8s resource to screen.
+func (Inventory) Render(o any, _ string, r *model1.Row) error {
+	res, ok := o.(*InventoryRes)
+	if !ok {
+		return fmt.Errorf("expected InventoryRes but got %T", o)
+	}
+
+	r.ID = client.FQN(res.Context, res.Node)
+	r.Fields = model1.Fields{
+		res.Context,
+		res.Node,
+		na(res.Roles),
+		na(res.OSImage),
+		na(res.Kernel),
+		na(res.Runtime),
+		na(res.Kubelet),
+		na(res.Arch),
+		strings.Join(res.Drift, ","),
+	}
+
+	return nil
+}
+
+// InventoryRes represents the OS and component versions of a node.
+type InventoryRes struct {
+	Context, Node string
+	Roles         string
+	OSImage       string
+	Kernel        string
+	Runtime       string
+	Kubelet       string
+	Arch          string
+	Drift         []string
+}
+
+// GetObjectKind returns a schema object.
+func (*InventoryRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (i *InventoryRes) DeepCopyObject() runtime.Object {
+	return i
+}
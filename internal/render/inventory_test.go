@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventoryRender(t *testing.T) {
+	c := render.Inventory{}
+	r := model1.NewRow(9)
+
+	o := render.InventoryRes{
+		Context: "prod",
+		Node:    "n1",
+		OSImage: "SUSE Linux Enterprise Server 15 SP6",
+		Kernel:  "6.4.0-150600.23.25-default",
+		Runtime: "containerd://1.7.21-k3s2",
+		Kubelet: "v1.30.4+rke2r1",
+		Arch:    "amd64",
+		Drift:   []string{"kernel", "kubelet"},
+	}
+	require.NoError(t, c.Render(&o, "", &r))
+	assert.Equal(t, "prod/n1", r.ID)
+	assert.Equal(t, model1.Fields{
+		"prod",
+		"n1",
+		render.NAValue,
+		"SUSE Linux Enterprise Server 15 SP6",
+		"6.4.0-150600.23.25-default",
+		"containerd://1.7.21-k3s2",
+		"v1.30.4+rke2r1",
+		"amd64",
+		"kernel,kubelet",
+	}, r.Fields)
+}
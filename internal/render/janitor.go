@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/derailed/k9s/internal/model1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var defaultJanitorHeader = model1.Header{
+	model1.HeaderColumn{Name: "CONTEXT"},
+	model1.HeaderColumn{Name: "KIND"},
+	model1.HeaderColumn{Name: "NAMESPACE"},
+	model1.HeaderColumn{Name: "NAME"},
+	model1.HeaderColumn{Name: "REASON"},
+}
+
+// Janitor renders cleanup candidates to screen.
+type Janitor struct {
+	Base
+}
+
+// Header returns a header row.
+func (Janitor) Header(string) model1.Header {
+	return defaultJanitorHeader
+}
+
+// Render renders a K8s resource to screen.
+func (Janitor) Render(o any, _ string, r *model1.Row) error {
+	res, ok := o.(*JanitorRes)
+	if !ok {
+		return fmt.Errorf("expected JanitorRes but got %T", o)
+	}
+
+	r.ID = res.ID()
+	r.Fields = model1.Fields{
+		res.Context,
+		res.Kind,
+		res.Namespace,
+		res.Name,
+		res.Reason,
+	}
+
+	return nil
+}
+
+// JanitorRes represents a resource eligible for cleanup.
+type JanitorRes struct {
+	Context         string
+	GVR, Kind       string
+	Namespace, Name string
+	UID             string
+	ResourceVersion string
+	Reason          string
+}
+
+// ID returns the candidate identifier, carrying the revision it was found at.
+func (j *JanitorRes) ID() string {
+	return strings.Join([]string{j.Context, j.GVR, j.Namespace + "/" + j.Name, j.UID, j.ResourceVersion}, "|")
+}
+
+// GetObjectKind returns a schema object.
+func (*JanitorRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (j *JanitorRes) DeepCopyObject() runtime.Object {
+	return j
+}
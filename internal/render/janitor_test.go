@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJanitorRender(t *testing.T) {
+	c := render.Janitor{}
+	r := model1.NewRow(5)
+
+	o := render.JanitorRes{
+		Context:         "prod",
+		GVR:             "apps/v1/replicasets",
+		Kind:            "replicasets",
+		Namespace:       "ns1",
+		Name:            "fred-1",
+		UID:             "u1",
+		ResourceVersion: "42",
+		Reason:          "revision 1 of deployment fred, scaled to zero",
+	}
+	require.NoError(t, c.Render(&o, "", &r))
+	assert.Equal(t, "prod|apps/v1/replicasets|ns1/fred-1|u1|42", r.ID)
+	assert.Equal(t, model1.Fields{
+		"prod",
+		"replicasets",
+		"ns1",
+		"fred-1",
+		"revision 1 of deployment fred, scaled to zero",
+	}, r.Fields)
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tview"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// LeaseFlapWindow tracks how recent a leader change must be to count as flapping.
+	LeaseFlapWindow = 10 * time.Minute
+
+	// LeaseFlapTransitions tracks how many leader transitions count as flapping.
+	LeaseFlapTransitions = 3
+)
+
+var defaultLeaseHeader = model1.Header{
+	model1.HeaderColumn{Name: "NAMESPACE"},
+	model1.HeaderColumn{Name: "NAME"},
+	model1.HeaderColumn{Name: "LEADER"},
+	model1.HeaderColumn{Name: "RENEWED", Attrs: model1.Attrs{Time: true}},
+	model1.HeaderColumn{Name: "DURATION", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "TRANSITIONS", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "ACQUIRED", Attrs: model1.Attrs{Time: true}},
+	model1.HeaderColumn{Name: "HOLDER", Attrs: model1.Attrs{Wide: true}},
+	model1.HeaderColumn{Name: "LABELS", Attrs: model1.Attrs{Wide: true}},
+	model1.HeaderColumn{Name: "VALID", Attrs: model1.Attrs{Wide: true}},
+	model1.HeaderColumn{Name: "AGE", Attrs: model1.Attrs{Time: true}},
+}
+
+// Lease renders a coordination lease to screen.
+type Lease struct {
+	Base
+}
+
+// Header returns a header row.
+func (l Lease) Header(_ string) model1.Header {
+	return l.doHeader(defaultLeaseHeader)
+}
+
+// Render renders a K8s resource to screen.
+func (l Lease) Render(o any, _ string, row *model1.Row) error {
+	raw, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("expected Unstructured, but got %T", o)
+	}
+	if err := l.defaultRow(raw, row); err != nil {
+		return err
+	}
+	if l.specs.isEmpty() {
+		return nil
+	}
+	cols, err := l.specs.realize(raw, defaultLeaseHeader, row)
+	if err != nil {
+		return err
+	}
+	cols.hydrateRow(row)
+
+	return nil
+}
+
+func (l Lease) defaultRow(raw *unstructured.Unstructured, r *model1.Row) error {
+	var le coordinationv1.Lease
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw.Object, &le); err != nil {
+		return err
+	}
+
+	var holder string
+	if le.Spec.HolderIdentity != nil {
+		holder = *le.Spec.HolderIdentity
+	}
+	duration := NAValue
+	if le.Spec.LeaseDurationSeconds != nil {
+		duration = (time.Duration(*le.Spec.LeaseDurationSeconds) * time.Second).String()
+	}
+	var transitions int32
+	if le.Spec.LeaseTransitions != nil {
+		transitions = *le.Spec.LeaseTransitions
+	}
+
+	r.ID = client.MetaFQN(&le.ObjectMeta)
+	r.Fields = model1.Fields{
+		le.Namespace,
+		le.Name,
+		na(LeaseLeader(holder)),
+		microAge(le.Spec.RenewTime),
+		duration,
+		strconv.Itoa(int(transitions)),
+		microAge(le.Spec.AcquireTime),
+		na(holder),
+		mapToStr(le.Labels),
+		AsStatus(LeaseIssue(&le.Spec, time.Now())),
+		ToAge(le.GetCreationTimestamp()),
+	}
+
+	return nil
+}
+
+// LeaseLeader returns the leader of a lease holder identity, dropping the
+// unique suffix leader elections append to the holder, ie `node1_<uuid>`.
+func LeaseLeader(holder string) string {
+	if i := strings.LastIndex(holder, "_"); i > 0 {
+		return holder[:i]
+	}
+
+	return holder
+}
+
+// LeaseIssue returns why a lease needs attention: no holder, a leader that
+// stopped renewing or a leader that keeps changing.
+func LeaseIssue(spec *coordinationv1.LeaseSpec, now time.Time) error {
+	if spec.HolderIdentity == nil || *spec.HolderIdentity == "" {
+		return errors.New("no leader")
+	}
+	if spec.RenewTime != nil && spec.LeaseDurationSeconds != nil {
+		d := time.Duration(*spec.LeaseDurationSeconds) * time.Second
+		if age := now.Sub(spec.RenewTime.Time); age > d {
+			return fmt.Errorf("leader stopped renewing %s ago", age.Round(time.Second))
+		}
+	}
+	if spec.LeaseTransitions != nil && *spec.LeaseTransitions >= LeaseFlapTransitions &&
+		spec.AcquireTime != nil && now.Sub(spec.AcquireTime.Time) < LeaseFlapWindow {
+		return fmt.Errorf("leader flapping, %d transitions and changed %s ago", *spec.LeaseTransitions, now.Sub(spec.AcquireTime.Time).Round(time.Second))
+	}
+
+	return nil
+}
+
+func microAge(t *metav1.MicroTime) string {
+	if t == nil {
+		return NAValue
+	}
+
+	return ToAge(metav1.NewTime(t.Time))
+}
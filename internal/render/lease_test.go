@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLeaseRender(t *testing.T) {
+	c := render.Lease{}
+	r := model1.NewRow(11)
+
+	require.NoError(t, c.Render(load(t, "lease"), "", &r))
+	assert.Equal(t, "kube-system/kube-controller-manager", r.ID)
+	assert.Equal(t, model1.Fields{"kube-system", "kube-controller-manager", "rke2-server-1"}, r.Fields[:3])
+	assert.Equal(t, model1.Fields{"15s", "2"}, r.Fields[4:6])
+	assert.Equal(t, "rke2-server-1_3f0b1c1e-8a0d-4d55-9a4e-2f2b7b0d8c11", r.Fields[7])
+	assert.True(t, strings.HasPrefix(r.Fields[9], "leader stopped renewing"))
+}
+
+func TestLeaseLeader(t *testing.T) {
+	uu := map[string]struct {
+		holder, e string
+	}{
+		"empty":      {},
+		"plain":      {holder: "node-1", e: "node-1"},
+		"election":   {holder: "cp1_5b4f", e: "cp1"},
+		"underscore": {holder: "my_host_5b4f", e: "my_host"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, render.LeaseLeader(u.holder))
+		})
+	}
+}
+
+func TestLeaseIssue(t *testing.T) {
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *metav1.MicroTime {
+		t := metav1.NewMicroTime(now.Add(-d))
+		return &t
+	}
+
+	uu := map[string]struct {
+		spec coordinationv1.LeaseSpec
+		err  string
+	}{
+		"healthy": {
+			spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       strPtr("cp1_x"),
+				LeaseDurationSeconds: int32Ptr(15),
+				RenewTime:            ago(5 * time.Second),
+				AcquireTime:          ago(time.Hour),
+				LeaseTransitions:     int32Ptr(5),
+			},
+		},
+		"no-leader": {
+			spec: coordinationv1.LeaseSpec{HolderIdentity: strPtr("")},
+			err:  "no leader",
+		},
+		"expired": {
+			spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       strPtr("cp1_x"),
+				LeaseDurationSeconds: int32Ptr(15),
+				RenewTime:            ago(time.Minute),
+			},
+			err: "leader stopped renewing 1m0s ago",
+		},
+		"flapping": {
+			spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       strPtr("cp1_x"),
+				LeaseDurationSeconds: int32Ptr(15),
+				RenewTime:            ago(time.Second),
+				AcquireTime:          ago(2 * time.Minute),
+				LeaseTransitions:     int32Ptr(3),
+			},
+			err: "leader flapping, 3 transitions and changed 2m0s ago",
+		},
+		"single-change": {
+			spec: coordinationv1.LeaseSpec{
+				HolderIdentity:   strPtr("cp1_x"),
+				AcquireTime:      ago(2 * time.Minute),
+				LeaseTransitions: int32Ptr(1),
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := render.LeaseIssue(&u.spec, now)
+			if u.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, u.err)
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// OOM failure reasons.
+const (
+	ReasonOOMKilled = "OOMKilled"
+	ReasonEvicted   = "Evicted"
+)
+
+var defaultOOMHeader = model1.Header{
+	model1.HeaderColumn{Name: "CONTEXT"},
+	model1.HeaderColumn{Name: "NAMESPACE"},
+	model1.HeaderColumn{Name: "WORKLOAD"},
+	model1.HeaderColumn{Name: "POD"},
+	model1.HeaderColumn{Name: "CONTAINER"},
+	model1.HeaderColumn{Name: "REASON"},
+	model1.HeaderColumn{Name: "COUNT", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "MEM-REQ", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "MEM-LIM", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "MESSAGE", Attrs: model1.Attrs{Wide: true}},
+	model1.HeaderColumn{Name: "LAST-SEEN", Attrs: model1.Attrs{Time: true}},
+}
+
+// OOM renders OOM kills and evictions to screen.
+type OOM struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (OOM) ColorerFunc() model1.ColorerFunc {
+	return func(ns string, h model1.Header, re *model1.RowEvent) tcell.Color {
+		c := model1.DefaultColorer(ns, h, re)
+
+		idx, ok := h.IndexOf("REASON", true)
+		if !ok {
+			return c
+		}
+		switch strings.TrimSpace(re.Row.Fields[idx]) {
+		case ReasonOOMKilled:
+			c = model1.ErrColor
+		case ReasonEvicted:
+			c = model1.PendingColor
+		}
+
+		return c
+	}
+}
+
+// Header returns a header row.
+func (OOM) Header(string) model1.Header {
+	return defaultOOMHeader
+}
+
+// Render renders a K8s resource to screen.
+func (OOM) Render(o any, _ string, r *model1.Row) error {
+	res, ok := o.(*OOMRes)
+	if !ok {
+		return fmt.Errorf("expected OOMRes but got %T", o)
+	}
+
+	r.ID = res.ID()
+	r.Fields = model1.Fields{
+		res.Context,
+		res.Namespace,
+		na(res.Workload),
+		res.Pod,
+		na(res.Container),
+		res.Reason,
+		IntToStr(res.Count),
+		na(res.MemRequest),
+		na(res.MemLimit),
+		res.Message,
+		ToAge(metav1.NewTime(res.LastSeen)),
+	}
+
+	return nil
+}
+
+// OOMRes represents an OOM kill or an eviction observed within the lookback window.
+type OOMRes struct {
+	Context, Namespace   string
+	WorkloadGVR          string
+	Workload             string
+	Pod, Container       string
+	Reason, Message      string
+	MemRequest, MemLimit string
+	Count                int
+	LastSeen             time.Time
+}
+
+// ID returns the record identifier, carrying what is needed to navigate to the workload.
+func (o *OOMRes) ID() string {
+	return strings.Join([]string{o.Context, o.WorkloadGVR, o.Namespace, o.Workload, o.Pod, o.Container, o.Reason}, "|")
+}
+
+// GetObjectKind returns a schema object.
+func (*OOMRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (o *OOMRes) DeepCopyObject() runtime.Object {
+	return o
+}
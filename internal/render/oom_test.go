@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOOMRender(t *testing.T) {
+	c := render.OOM{}
+	r := model1.NewRow(11)
+
+	o := render.OOMRes{
+		Context:     "ct1",
+		Namespace:   "ns1",
+		WorkloadGVR: "apps/v1/deployments",
+		Workload:    "web",
+		Pod:         "web-7d9f8-abcde",
+		Container:   "c1",
+		Reason:      render.ReasonOOMKilled,
+		MemLimit:    "128Mi",
+		Count:       2,
+		LastSeen:    time.Now().Add(-time.Minute),
+	}
+	require.NoError(t, c.Render(&o, "", &r))
+	assert.Equal(t, "ct1|apps/v1/deployments|ns1|web|web-7d9f8-abcde|c1|OOMKilled", r.ID)
+	assert.Equal(t, model1.Fields{"ct1", "ns1", "web", "web-7d9f8-abcde", "c1", "OOMKilled", "2", render.NAValue, "128Mi", ""}, r.Fields[:10])
+}
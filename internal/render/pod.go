@@ -75,6 +75,7 @@ var defaultPodHeader = model1.Header{
 	model1.HeaderColumn{Name: "NOMINATED NODE", Attrs: model1.Attrs{Wide: true}},
 	model1.HeaderColumn{Name: "READINESS GATES", Attrs: model1.Attrs{Wide: true}},
 	model1.HeaderColumn{Name: "QOS", Attrs: model1.Attrs{Wide: true}},
+	model1.HeaderColumn{Name: "PRIORITY", Attrs: model1.Attrs{Wide: true}},
 	model1.HeaderColumn{Name: "LABELS", Attrs: model1.Attrs{Wide: true}},
 	model1.HeaderColumn{Name: "VALID", Attrs: model1.Attrs{Wide: true}},
 	model1.HeaderColumn{Name: "AGE", Attrs: model1.Attrs{Time: true}},
@@ -202,6 +203,7 @@ func (p *Pod) defaultRow(pwm *PodWithMetrics, row *model1.Row) error {
 		asNominated(st.NominatedNodeName),
 		asReadinessGate(spec, &st),
 		p.mapQOS(st.QOSClass),
+		asPriority(spec),
 		mapToStr(pwm.Raw.GetLabels()),
 		AsStatus(p.diagnose(phase, cReady, allCounts, ready, rgr, rgt)),
 		ToAge(pwm.Raw.GetCreationTimestamp()),
@@ -272,6 +274,17 @@ func asNominated(n string) string {
 	return n
 }
 
+func asPriority(spec *v1.PodSpec) string {
+	switch {
+	case spec.Priority == nil:
+		return missing(spec.PriorityClassName)
+	case spec.PriorityClassName == "":
+		return strconv.Itoa(int(*spec.Priority))
+	default:
+		return spec.PriorityClassName + ":" + strconv.Itoa(int(*spec.Priority))
+	}
+}
+
 func asReadinessGate(spec *v1.PodSpec, st *v1.PodStatus) string {
 	if len(spec.ReadinessGates) == 0 {
 		return MissingValue
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tview"
+)
+
+// promTemplates tracks the parsed Prometheus column query templates.
+var promTemplates sync.Map
+
+// PromRow represents the row attributes available to a Prometheus column
+// query template.
+type PromRow struct {
+	Context   string
+	Namespace string
+	Name      string
+	Labels    map[string]string
+}
+
+// PromHeader returns the header of a Prometheus column.
+func PromHeader(name string) model1.HeaderColumn {
+	return model1.HeaderColumn{
+		Name:  name,
+		Attrs: model1.Attrs{Align: tview.AlignRight},
+	}
+}
+
+// PromQueryFor expands a PromQL query template for a given row. Templates
+// are only parsed once.
+func PromQueryFor(tpl string, r PromRow) (string, error) {
+	t, err := promTemplate(tpl)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, r); err != nil {
+		return "", fmt.Errorf("unable to expand prometheus query: %w", err)
+	}
+
+	return b.String(), nil
+}
+
+func promTemplate(tpl string) (*template.Template, error) {
+	if t, ok := promTemplates.Load(tpl); ok {
+		return t.(*template.Template), nil
+	}
+	t, err := template.New("query").Option("missingkey=zero").Parse(tpl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prometheus query template: %w", err)
+	}
+	promTemplates.Store(tpl, t)
+
+	return t, nil
+}
+
+// PromValue humanizes a Prometheus sample value for a given unit.
+func PromValue(v float64, unit string) string {
+	switch unit {
+	case "bytes":
+		return promBytes(v)
+	case "percent":
+		return strconv.FormatFloat(v*100, 'f', 1, 64) + "%"
+	case "seconds":
+		if v < 1 {
+			return trimFloat(v*1_000) + "ms"
+		}
+		return strconv.FormatFloat(v, 'f', 2, 64) + "s"
+	default:
+		return trimFloat(v)
+	}
+}
+
+func promBytes(v float64) string {
+	const unit = 1024.0
+	if v < unit {
+		return trimFloat(v)
+	}
+	suffixes := []string{"Ki", "Mi", "Gi", "Ti", "Pi"}
+	i := -1
+	for v >= unit && i < len(suffixes)-1 {
+		v /= unit
+		i++
+	}
+
+	return trimFloat(v) + suffixes[i]
+}
+
+func trimFloat(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 3, 64)
+	s = strings.TrimRight(s, "0")
+
+	return strings.TrimSuffix(s, ".")
+}
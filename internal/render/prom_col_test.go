@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromQueryFor(t *testing.T) {
+	r := render.PromRow{
+		Context:   "c1",
+		Namespace: "ns1",
+		Name:      "svc1",
+		Labels:    map[string]string{"app": "web"},
+	}
+	uu := map[string]struct {
+		tpl, e string
+		err    bool
+	}{
+		"plain": {
+			tpl: `up`,
+			e:   `up`,
+		},
+		"row": {
+			tpl: `sum(rate(container_cpu_usage_seconds_total{namespace="{{.Namespace}}",pod="{{.Name}}"}[5m]))`,
+			e:   `sum(rate(container_cpu_usage_seconds_total{namespace="ns1",pod="svc1"}[5m]))`,
+		},
+		"labels": {
+			tpl: `x{app="{{index .Labels "app"}}",tier="{{index .Labels "tier"}}"}`,
+			e:   `x{app="web",tier=""}`,
+		},
+		"invalid": {
+			tpl: `x{{.Name`,
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			q, err := render.PromQueryFor(u.tpl, r)
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.e, q)
+		})
+	}
+}
+
+func TestPromValue(t *testing.T) {
+	uu := map[string]struct {
+		v    float64
+		unit string
+		e    string
+	}{
+		"int":      {v: 42, e: "42"},
+		"float":    {v: 0.12345, e: "0.123"},
+		"bytes":    {v: 512, unit: "bytes", e: "512"},
+		"kib":      {v: 1536, unit: "bytes", e: "1.5Ki"},
+		"gib":      {v: 3 * 1024 * 1024 * 1024, unit: "bytes", e: "3Gi"},
+		"percent":  {v: 0.4567, unit: "percent", e: "45.7%"},
+		"ms":       {v: 0.0125, unit: "seconds", e: "12.5ms"},
+		"seconds":  {v: 2.5, unit: "seconds", e: "2.50s"},
+		"negative": {v: -1, e: "-1"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, render.PromValue(u.v, u.unit))
+		})
+	}
+}
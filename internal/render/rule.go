@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var defaultRuleHeader = model1.Header{
+	model1.HeaderColumn{Name: "NAME"},
+	model1.HeaderColumn{Name: "WATCH"},
+	model1.HeaderColumn{Name: "WHEN"},
+	model1.HeaderColumn{Name: "ACTION"},
+	model1.HeaderColumn{Name: "ENABLED"},
+	model1.HeaderColumn{Name: "MATCHES", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "FIRED", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "ERROR", Attrs: model1.Attrs{Wide: true}},
+	model1.HeaderColumn{Name: "LAST-FIRED", Attrs: model1.Attrs{Time: true}},
+}
+
+// Rule renders automation rules to screen.
+type Rule struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (Rule) ColorerFunc() model1.ColorerFunc {
+	return func(ns string, h model1.Header, re *model1.RowEvent) tcell.Color {
+		c := model1.DefaultColorer(ns, h, re)
+
+		if idx, ok := h.IndexOf("ERROR", true); ok && strings.TrimSpace(re.Row.Fields[idx]) != "" {
+			return model1.ErrColor
+		}
+		if idx, ok := h.IndexOf("ENABLED", true); ok && strings.TrimSpace(re.Row.Fields[idx]) != "true" {
+			return model1.CompletedColor
+		}
+
+		return c
+	}
+}
+
+// Header returns a header row.
+func (Rule) Header(string) model1.Header {
+	return defaultRuleHeader
+}
+
+// Render renders a K8s resource to screen.
+func (Rule) Render(o any, _ string, r *model1.Row) error {
+	res, ok := o.(*AutoRuleRes)
+	if !ok {
+		return fmt.Errorf("expected AutoRuleRes but got %T", o)
+	}
+
+	r.ID = res.Name
+	r.Fields = model1.Fields{
+		res.Name,
+		res.Watch,
+		res.When,
+		na(res.Action),
+		boolToStr(res.Enabled),
+		IntToStr(res.Matches),
+		IntToStr(res.Fired),
+		res.Error,
+		ToAge(metav1.NewTime(res.LastFired)),
+	}
+
+	return nil
+}
+
+// AutoRuleRes represents an automation rule and its activity.
+type AutoRuleRes struct {
+	Name, Watch, When string
+	Action            string
+	Enabled           bool
+	Matches, Fired    int
+	LastFired         time.Time
+	Error             string
+}
+
+// GetObjectKind returns a schema object.
+func (*AutoRuleRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (r *AutoRuleRes) DeepCopyObject() runtime.Object {
+	return r
+}
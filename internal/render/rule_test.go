@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleRender(t *testing.T) {
+	c := render.Rule{}
+	r := model1.NewRow(9)
+
+	o := render.AutoRuleRes{
+		Name:    "drift",
+		Watch:   "bundles",
+		When:    "self.status.summary.modified > 0",
+		Action:  "plugin",
+		Enabled: true,
+		Matches: 2,
+		Fired:   3,
+	}
+	require.NoError(t, c.Render(&o, "", &r))
+	assert.Equal(t, "drift", r.ID)
+	assert.Equal(t, model1.Fields{"drift", "bundles", "self.status.summary.modified > 0", "plugin", "true", "2", "3", ""}, r.Fields[:8])
+}
+
+func TestCELMatch(t *testing.T) {
+	o := map[string]any{
+		"metadata": map[string]any{"name": "b1"},
+		"status":   map[string]any{"summary": map[string]any{"modified": int64(1)}},
+	}
+	uu := map[string]struct {
+		expr string
+		e    bool
+		err  bool
+	}{
+		"match":    {expr: `self.status.summary.modified > 0`, e: true},
+		"no-match": {expr: `self.metadata.name == "b2"`},
+		"missing":  {expr: `self.spec.paused`, err: true},
+		"not-bool": {expr: `self.metadata.name`, err: true},
+		"invalid":  {expr: `self.`, err: true},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ok, err := render.CELMatch(u.expr, o)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.e, ok)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/google/cel-go/cel"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Summarizer computes a status summary from CEL expressions.
+type Summarizer struct {
+	column     string
+	text, tint cel.Program
+}
+
+// NewSummarizer compiles a view summary.
+func NewSummarizer(s *config.Summary) (*Summarizer, error) {
+	if s == nil || s.Expr == "" {
+		return nil, errors.New("summary expression is required")
+	}
+	text, err := compileCEL(s.Expr)
+	if err != nil {
+		return nil, err
+	}
+	sm := Summarizer{column: s.ColumnName(), text: text}
+	if s.Color != "" {
+		if sm.tint, err = compileCEL(s.Color); err != nil {
+			return nil, err
+		}
+	}
+
+	return &sm, nil
+}
+
+// Column returns the summary column name.
+func (s *Summarizer) Column() string {
+	return s.column
+}
+
+// Eval returns the summary text and color of a resource. The text is n/a
+// when the expression fails, ie a missing field.
+func (s *Summarizer) Eval(o map[string]any) (text, color string) {
+	vars := map[string]any{"self": o}
+	text = NAValue
+	if v, err := evalCEL(s.text, vars); err == nil {
+		text = v
+	} else {
+		slog.Debug("Summary expression failed", slogs.Error, err)
+	}
+	if s.tint != nil {
+		color, _ = evalCEL(s.tint, vars)
+	}
+
+	return
+}
+
+// SummaryObject converts a rendered resource to its fields map.
+func SummaryObject(o runtime.Object, raw []byte) (map[string]any, error) {
+	if o != nil {
+		return runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	}
+	if len(raw) == 0 {
+		return nil, errors.New("no resource to summarize")
+	}
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// summarize sets the summary columns of a table row.
+func (s *Summarizer) summarize(row *metav1.TableRow, h model1.Header, r *model1.Row) {
+	var text, color string
+	if o, err := SummaryObject(row.Object.Object, row.Object.Raw); err == nil {
+		text, color = s.Eval(o)
+	} else {
+		text = NAValue
+	}
+	setField(h, r, s.column, text)
+	setField(h, r, model1.SummaryColorCol, color)
+}
+
+func setField(h model1.Header, r *model1.Row, col, v string) {
+	idx, ok := h.IndexOf(col, true)
+	if !ok {
+		return
+	}
+	for len(r.Fields) <= idx {
+		r.Fields = append(r.Fields, "")
+	}
+	r.Fields[idx] = v
+}
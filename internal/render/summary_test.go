@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render_test
+
+import (
+	"testing"
+
+	cfg "github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSummarizer(t *testing.T) {
+	uu := map[string]struct {
+		s   *cfg.Summary
+		col string
+		err bool
+	}{
+		"default": {
+			s:   &cfg.Summary{Expr: `self.status.phase`},
+			col: "STATUS",
+		},
+		"column": {
+			s:   &cfg.Summary{Column: "ready", Expr: `self.status.ready`, Color: `"red"`},
+			col: "READY",
+		},
+		"blank": {
+			s:   &cfg.Summary{},
+			err: true,
+		},
+		"bad-expr": {
+			s:   &cfg.Summary{Expr: `self.status.`},
+			err: true,
+		},
+		"bad-color": {
+			s:   &cfg.Summary{Expr: `self.status.phase`, Color: `self.(`},
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s, err := render.NewSummarizer(u.s)
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, u.col, s.Column())
+		})
+	}
+}
+
+func TestSummarizerEval(t *testing.T) {
+	o := map[string]any{
+		"spec": map[string]any{"replicas": int64(3)},
+		"status": map[string]any{
+			"readyReplicas": int64(2),
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": "False"},
+			},
+		},
+	}
+	uu := map[string]struct {
+		s           cfg.Summary
+		text, color string
+	}{
+		"ratio": {
+			s:    cfg.Summary{Expr: `string(self.status.readyReplicas) + "/" + string(self.spec.replicas)`},
+			text: "2/3",
+		},
+		"conditions": {
+			s: cfg.Summary{
+				Expr:  `self.status.conditions.exists(c, c.type == "Ready" && c.status == "True") ? "Ready" : "NotReady"`,
+				Color: `self.status.readyReplicas < self.spec.replicas ? "pending" : "ok"`,
+			},
+			text:  "NotReady",
+			color: "pending",
+		},
+		"non-string": {
+			s:    cfg.Summary{Expr: `self.status.readyReplicas == self.spec.replicas`},
+			text: "false",
+		},
+		"missing": {
+			s:    cfg.Summary{Expr: `self.status.phase`},
+			text: render.NAValue,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s, err := render.NewSummarizer(&u.s)
+			require.NoError(t, err)
+			text, color := s.Eval(o)
+			assert.Equal(t, u.text, text)
+			assert.Equal(t, u.color, color)
+		})
+	}
+}
+
+func TestGenericSummaryRender(t *testing.T) {
+	uu := map[string]struct {
+		s       cfg.Summary
+		eFields model1.Fields
+		eHeader model1.Header
+	}{
+		"replace": {
+			s: cfg.Summary{
+				Column: "b",
+				Expr:   `self.metadata.name.upperAscii()`,
+				Color:  `self.kind == "fred" ? "ok" : "error"`,
+			},
+			eFields: model1.Fields{"ns1", "c1", "FRED", "c3", "ok"},
+			eHeader: model1.Header{
+				model1.HeaderColumn{Name: "NAMESPACE"},
+				model1.HeaderColumn{Name: "A"},
+				model1.HeaderColumn{Name: "B"},
+				model1.HeaderColumn{Name: "C"},
+				model1.HeaderColumn{Name: model1.SummaryColorCol, Attrs: model1.Attrs{Hide: true}},
+			},
+		},
+		"append": {
+			s: cfg.Summary{
+				Expr: `has(self.status) ? self.status.phase : "Pending"`,
+			},
+			eFields: model1.Fields{"ns1", "c1", "c2", "c3", "Pending", ""},
+			eHeader: model1.Header{
+				model1.HeaderColumn{Name: "NAMESPACE"},
+				model1.HeaderColumn{Name: "A"},
+				model1.HeaderColumn{Name: "B"},
+				model1.HeaderColumn{Name: "C"},
+				model1.HeaderColumn{Name: "STATUS"},
+				model1.HeaderColumn{Name: model1.SummaryColorCol, Attrs: model1.Attrs{Hide: true}},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			var (
+				re render.Table
+				r  model1.Row
+			)
+			re.SetViewSetting(&cfg.ViewSetting{Summary: &u.s})
+			table := makeNSGeneric()
+			re.SetTable("ns1", table)
+
+			assert.Equal(t, u.eHeader, re.Header("ns1"))
+			require.NoError(t, re.Render(table.Rows[0], "ns1", &r))
+			assert.Equal(t, u.eFields, r.Fields)
+		})
+	}
+}
@@ -59,7 +59,15 @@ func (*Table) ColorerFunc() model1.ColorerFunc {
 
 // Header returns a header row.
 func (t *Table) Header(string) model1.Header {
-	return t.doHeader(t.defaultHeader())
+	h := t.doHeader(t.defaultHeader())
+	if t.summary == nil {
+		return h
+	}
+	if _, ok := h.IndexOf(t.summary.Column(), true); !ok {
+		h = append(h, model1.HeaderColumn{Name: t.summary.Column()})
+	}
+
+	return append(h, model1.HeaderColumn{Name: model1.SummaryColorCol, Attrs: model1.Attrs{Hide: true}})
 }
 
 // Header returns a header row.
@@ -92,19 +100,20 @@ func (t *Table) Render(o any, ns string, r *model1.Row) error {
 	if err := t.defaultRow(&row, ns, r); err != nil {
 		return err
 	}
-	if t.specs.isEmpty() {
-		return nil
-	}
-
-	obj := row.Object.Object
-	if obj != nil {
-		obj = obj.DeepCopyObject()
+	if !t.specs.isEmpty() {
+		obj := row.Object.Object
+		if obj != nil {
+			obj = obj.DeepCopyObject()
+		}
+		cols, err := t.specs.realize(obj, t.defaultHeader(), r)
+		if err != nil {
+			return err
+		}
+		cols.hydrateRow(r)
 	}
-	cols, err := t.specs.realize(obj, t.defaultHeader(), r)
-	if err != nil {
-		return err
+	if t.summary != nil {
+		t.summary.summarize(&row, t.header, r)
 	}
-	cols.hydrateRow(r)
 
 	return nil
 }
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/model1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var defaultVolumeSnapshotHeader = model1.Header{
+	model1.HeaderColumn{Name: "NAMESPACE"},
+	model1.HeaderColumn{Name: "NAME"},
+	model1.HeaderColumn{Name: "READY"},
+	model1.HeaderColumn{Name: "SOURCE"},
+	model1.HeaderColumn{Name: "SIZE", Attrs: model1.Attrs{Capacity: true}},
+	model1.HeaderColumn{Name: "CLASS"},
+	model1.HeaderColumn{Name: "CONTENT", Attrs: model1.Attrs{Wide: true}},
+	model1.HeaderColumn{Name: "LONGHORN"},
+	model1.HeaderColumn{Name: "LABELS", Attrs: model1.Attrs{Wide: true}},
+	model1.HeaderColumn{Name: "VALID", Attrs: model1.Attrs{Wide: true}},
+	model1.HeaderColumn{Name: "AGE", Attrs: model1.Attrs{Time: true}},
+}
+
+// VolumeSnapshot renders a CSI volume snapshot to screen.
+type VolumeSnapshot struct {
+	Base
+}
+
+// Header returns a header row.
+func (v VolumeSnapshot) Header(_ string) model1.Header {
+	return v.doHeader(defaultVolumeSnapshotHeader)
+}
+
+// Render renders a K8s resource to screen.
+func (v VolumeSnapshot) Render(o any, _ string, row *model1.Row) error {
+	var (
+		raw      *unstructured.Unstructured
+		longhorn string
+	)
+	switch t := o.(type) {
+	case *VolumeSnapshotWithBackup:
+		raw, longhorn = t.Raw, t.Longhorn
+	case *unstructured.Unstructured:
+		raw = t
+	default:
+		return fmt.Errorf("expected VolumeSnapshotWithBackup, but got %T", o)
+	}
+	v.defaultRow(raw, longhorn, row)
+	if v.specs.isEmpty() {
+		return nil
+	}
+	cols, err := v.specs.realize(raw, defaultVolumeSnapshotHeader, row)
+	if err != nil {
+		return err
+	}
+	cols.hydrateRow(row)
+
+	return nil
+}
+
+func (v VolumeSnapshot) defaultRow(raw *unstructured.Unstructured, longhorn string, r *model1.Row) {
+	ready, _, _ := unstructured.NestedBool(raw.Object, "status", "readyToUse")
+	source, _, _ := unstructured.NestedString(raw.Object, "spec", "source", "persistentVolumeClaimName")
+	if source == "" {
+		if c, _, _ := unstructured.NestedString(raw.Object, "spec", "source", "volumeSnapshotContentName"); c != "" {
+			source = "content:" + c
+		}
+	}
+	size, _, _ := unstructured.NestedString(raw.Object, "status", "restoreSize")
+	class, _, _ := unstructured.NestedString(raw.Object, "spec", "volumeSnapshotClassName")
+	content, _, _ := unstructured.NestedString(raw.Object, "status", "boundVolumeSnapshotContentName")
+	msg, _, _ := unstructured.NestedString(raw.Object, "status", "error", "message")
+
+	r.ID = client.FQN(raw.GetNamespace(), raw.GetName())
+	r.Fields = model1.Fields{
+		raw.GetNamespace(),
+		raw.GetName(),
+		strconv.FormatBool(ready),
+		na(source),
+		na(size),
+		na(class),
+		na(content),
+		na(longhorn),
+		mapToStr(raw.GetLabels()),
+		AsStatus(v.diagnose(msg, longhorn)),
+		ToAge(raw.GetCreationTimestamp()),
+	}
+}
+
+func (VolumeSnapshot) diagnose(msg, longhorn string) error {
+	if msg != "" {
+		return errors.New(msg)
+	}
+	if strings.HasPrefix(longhorn, "backup Error") || longhorn == "backup missing" {
+		return errors.New(longhorn)
+	}
+
+	return nil
+}
+
+// VolumeSnapshotWithBackup represents a volume snapshot and its Longhorn state.
+type VolumeSnapshotWithBackup struct {
+	Raw      *unstructured.Unstructured
+	Longhorn string
+}
+
+// GetObjectKind returns a schema object.
+func (*VolumeSnapshotWithBackup) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (v *VolumeSnapshotWithBackup) DeepCopyObject() runtime.Object {
+	return v
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeSnapshotRender(t *testing.T) {
+	v := render.VolumeSnapshot{}
+	r := model1.NewRow(11)
+
+	o := render.VolumeSnapshotWithBackup{Raw: load(t, "volume_snapshot"), Longhorn: "backup Completed"}
+	require.NoError(t, v.Render(&o, "", &r))
+	assert.Equal(t, "default/data-snap", r.ID)
+	assert.Equal(t, model1.Fields{"default", "data-snap", "true", "data", "2Gi", "longhorn-backup", "snapcontent-1234", "backup Completed"}, r.Fields[:8])
+	assert.Empty(t, r.Fields[9])
+}
+
+func TestVolumeSnapshotRenderBackupError(t *testing.T) {
+	v := render.VolumeSnapshot{}
+	r := model1.NewRow(11)
+
+	o := render.VolumeSnapshotWithBackup{Raw: load(t, "volume_snapshot"), Longhorn: "backup Error: target unreachable"}
+	require.NoError(t, v.Render(&o, "", &r))
+	assert.Equal(t, "backup Error: target unreachable", r.Fields[9])
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/watch"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// WatcherFailingWindow tracks how long a watch error flags an informer as failing.
+const WatcherFailingWindow = time.Minute
+
+// Informer states.
+const (
+	WatcherWatching = "Watching"
+	WatcherSyncing  = "Syncing"
+	WatcherFailing  = "Failing"
+	WatcherStopped  = "Stopped"
+)
+
+var defaultWatcherHeader = model1.Header{
+	model1.HeaderColumn{Name: "CONTEXT"},
+	model1.HeaderColumn{Name: "NAMESPACE"},
+	model1.HeaderColumn{Name: "RESOURCE"},
+	model1.HeaderColumn{Name: "STATE"},
+	model1.HeaderColumn{Name: "ITEMS", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "EVENTS", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "RATE/S", Attrs: model1.Attrs{Align: tview.AlignRight}},
+	model1.HeaderColumn{Name: "LAST ERROR"},
+	model1.HeaderColumn{Name: "AGE", Attrs: model1.Attrs{Time: true}},
+}
+
+// Watcher renders the active informers to screen.
+type Watcher struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (Watcher) ColorerFunc() model1.ColorerFunc {
+	return func(ns string, h model1.Header, re *model1.RowEvent) tcell.Color {
+		c := model1.DefaultColorer(ns, h, re)
+
+		idx, ok := h.IndexOf("STATE", true)
+		if !ok {
+			return c
+		}
+		switch strings.TrimSpace(re.Row.Fields[idx]) {
+		case WatcherFailing:
+			c = model1.ErrColor
+		case WatcherSyncing:
+			c = model1.PendingColor
+		case WatcherStopped:
+			c = model1.HighlightColor
+		}
+
+		return c
+	}
+}
+
+// Header returns a header row.
+func (Watcher) Header(string) model1.Header {
+	return defaultWatcherHeader
+}
+
+// Render renders a K8s resource to screen.
+func (Watcher) Render(o any, _ string, r *model1.Row) error {
+	res, ok := o.(*WatcherRes)
+	if !ok {
+		return fmt.Errorf("expected WatcherRes but got %T", o)
+	}
+
+	ns := res.Namespace
+	if client.IsClusterWide(ns) {
+		ns = client.NamespaceAll
+	}
+	r.ID = res.ID()
+	r.Fields = model1.Fields{
+		res.Context,
+		ns,
+		res.GVR,
+		res.State(time.Now()),
+		strconv.Itoa(res.Items),
+		strconv.FormatInt(res.Events, 10),
+		strconv.FormatFloat(res.Rate, 'f', 2, 64),
+		na(res.LastError),
+		ToAge(metav1.NewTime(res.StartedAt)),
+	}
+
+	return nil
+}
+
+// WatcherRes represents an informer.
+type WatcherRes struct {
+	watch.InformerStats
+}
+
+// ID returns the informer identifier.
+func (w *WatcherRes) ID() string {
+	return w.Namespace + "|" + w.GVR
+}
+
+// State returns the informer state.
+func (w *WatcherRes) State(now time.Time) string {
+	switch {
+	case w.Stopped:
+		return WatcherStopped
+	case w.LastError != "" && now.Sub(w.LastErrorAt) < WatcherFailingWindow:
+		return WatcherFailing
+	case !w.Synced:
+		return WatcherSyncing
+	default:
+		return WatcherWatching
+	}
+}
+
+// GetObjectKind returns a schema object.
+func (*WatcherRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (w *WatcherRes) DeepCopyObject() runtime.Object {
+	return w
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+var activeContext atomic.Value
+
+// SetActiveContext sets the context records are tagged with by default.
+func SetActiveContext(n string) {
+	activeContext.Store(n)
+}
+
+// ActiveContext returns the context records are tagged with by default.
+func ActiveContext() string {
+	n, _ := activeContext.Load().(string)
+
+	return n
+}
+
+// CtxLog returns a child logger tagged with the given context.
+func CtxLog(ctxName string) *slog.Logger {
+	return slog.With(Context, ctxName)
+}
+
+// ContextHandler tags records lacking a context attribute with the active
+// context so every log line can be traced back to a cluster.
+type ContextHandler struct {
+	slog.Handler
+	tagged bool
+}
+
+// NewContextHandler returns a handler tagging records with the active context.
+func NewContextHandler(h slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: h}
+}
+
+// Handle tags the record unless it already carries a context.
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.tagged {
+		return h.Handler.Handle(ctx, r)
+	}
+	var found bool
+	r.Attrs(func(a slog.Attr) bool {
+		found = a.Key == Context
+		return !found
+	})
+	if n := ActiveContext(); !found && n != "" {
+		r = r.Clone()
+		r.AddAttrs(slog.String(Context, n))
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a handler with the given attributes.
+func (h *ContextHandler) WithAttrs(aa []slog.Attr) slog.Handler {
+	tagged := h.tagged
+	for _, a := range aa {
+		tagged = tagged || a.Key == Context
+	}
+
+	return &ContextHandler{Handler: h.Handler.WithAttrs(aa), tagged: tagged}
+}
+
+// WithGroup returns a handler nesting attributes under the given group.
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name), tagged: h.tagged}
+}
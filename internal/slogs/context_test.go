@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package slogs_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextHandler(t *testing.T) {
+	uu := map[string]struct {
+		active string
+		log    func(*slog.Logger)
+		e      string
+	}{
+		"untagged": {
+			active: "fred",
+			log:    func(l *slog.Logger) { l.Info("hello") },
+			e:      "level=INFO msg=hello context=fred",
+		},
+		"tagged": {
+			active: "fred",
+			log:    func(l *slog.Logger) { l.Info("hello", slogs.Context, "blee") },
+			e:      "level=INFO msg=hello context=blee",
+		},
+		"with": {
+			active: "fred",
+			log:    func(l *slog.Logger) { l.With(slogs.Context, "blee").Warn("hello") },
+			e:      "level=WARN msg=hello context=blee",
+		},
+		"no-active": {
+			log: func(l *slog.Logger) { l.Info("hello") },
+			e:   "level=INFO msg=hello",
+		},
+	}
+
+	defer slogs.SetActiveContext("")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			slogs.SetActiveContext(u.active)
+			var b bytes.Buffer
+			h := slog.NewTextHandler(&b, &slog.HandlerOptions{
+				ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+					if a.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+					return a
+				},
+			})
+			u.log(slog.New(slogs.NewContextHandler(h)))
+			assert.Equal(t, u.e, strings.TrimSpace(b.String()))
+		})
+	}
+}
+
+func TestCtxLog(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	defer slogs.SetActiveContext("")
+
+	slogs.SetActiveContext("fred")
+	var b bytes.Buffer
+	h := slog.NewTextHandler(&b, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	slog.SetDefault(slog.New(slogs.NewContextHandler(h)))
+	slogs.CtxLog("blee").Warn("hello")
+
+	assert.Equal(t, "level=WARN msg=hello context=blee", strings.TrimSpace(b.String()))
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package ui
+
+import (
+	"regexp"
+	"strings"
+)
+
+var colorTagRX = regexp.MustCompile(`\[([a-zA-Z]+|#[0-9a-zA-Z]{6}|-)?:([a-zA-Z]+|#[0-9a-zA-Z]{6}|-)?:([lbdru]+|-)?\]`)
+
+// A11yTable represents the semantic content of a table, free of styling.
+type A11yTable struct {
+	// Columns lists the visible column names.
+	Columns []string
+
+	// Focused lists the focused row values, in column order.
+	Focused []string
+
+	// Row tracks the 1 based focused row index or 0 when no row is focused.
+	Row int
+
+	// Rows tracks the number of rows.
+	Rows int
+}
+
+// A11y returns the visible columns and the focused row of the table.
+func (t *Table) A11y() A11yTable {
+	a := A11yTable{
+		Row:  t.GetSelectedRowIndex(),
+		Rows: max(t.GetRowCount()-1, 0),
+	}
+	if a.Rows == 0 {
+		a.Row = 0
+	}
+	for c := range t.GetColumnCount() {
+		col := StripTags(TrimCell(t.SelectTable, 0, c))
+		a.Columns = append(a.Columns, strings.TrimSpace(strings.TrimRight(col, ascIndicator+descIndicator)))
+		if a.Row > 0 {
+			a.Focused = append(a.Focused, StripTags(TrimCell(t.SelectTable, a.Row, c)))
+		}
+	}
+
+	return a
+}
+
+// StripTags removes color tags and unescapes brackets from a styled text.
+func StripTags(s string) string {
+	s = colorTagRX.ReplaceAllString(s, "")
+
+	return strings.TrimSpace(strings.ReplaceAll(s, "[]", "]"))
+}
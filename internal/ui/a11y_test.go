@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripTags(t *testing.T) {
+	uu := map[string]struct {
+		s, e string
+	}{
+		"empty": {},
+		"plain": {
+			s: "fred",
+			e: "fred",
+		},
+		"title": {
+			s: " [aqua:-:b]pods([fuchsia:-:b]default[aqua:-:-])[aqua:-:-][[papayawhip:-:b]12[aqua:-:-]][aqua:-:-] ",
+			e: "pods(default)[12]",
+		},
+		"hex": {
+			s: "[#ff0000::b]NAME[::]",
+			e: "NAME",
+		},
+		"escaped": {
+			s: "blee[fred[]",
+			e: "blee[fred]",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, ui.StripTags(u.s))
+		})
+	}
+}
@@ -27,6 +27,7 @@ type App struct {
 	views   map[string]tview.Primitive
 	cmdBuff *model.FishBuff
 	running bool
+	screen  tcell.Screen
 	mx      sync.RWMutex
 }
 
@@ -47,6 +48,7 @@ func NewApp(cfg *config.Config, _ string) *App {
 		"prompt": NewPrompt(&a, a.Config.K9s.UI.NoIcons, a.Styles),
 		"crumbs": NewCrumbs(a.Styles),
 	}
+	a.SetAfterDrawFunc(a.trackScreen)
 
 	return &a
 }
@@ -151,6 +153,21 @@ func (a *App) bindKeys() {
 	})
 }
 
+// Screen returns the screen the app last drew on.
+func (a *App) Screen() tcell.Screen {
+	a.mx.RLock()
+	defer a.mx.RUnlock()
+
+	return a.screen
+}
+
+func (a *App) trackScreen(s tcell.Screen) {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	a.screen = s
+}
+
 // BailOut exits the application.
 func (a *App) BailOut(exitCode int) {
 	if err := a.Config.Save(true); err != nil {
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package dialog
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextErrorsDialog(t *testing.T) {
+	p := ui.NewPages()
+
+	var retried bool
+	ShowContextErrors(new(config.Dialog), p, "prod-2: boom", func() { retried = true })
+
+	d := p.GetPrimitive(dialogKey).(*tview.ModalForm)
+	assert.NotNil(t, d)
+	assert.True(t, p.IsTopDialog())
+	dismiss(p)
+	assert.Nil(t, p.GetPrimitive(dialogKey))
+	assert.False(t, retried)
+}
@@ -30,3 +30,22 @@ func ShowSelection(styles *config.Dialog, pages *ui.Pages, title string, options
 	pages.AddPage(dialogKey, modal, false, false)
 	pages.ShowPage(dialogKey)
 }
+
+// ShowPicker pops a selection dialog filtered as the user types.
+func ShowPicker(styles *config.Dialog, pages *ui.Pages, title string, options []string, action SelectAction) {
+	list := tview.NewList()
+	list.ShowSecondaryText(false)
+	list.SetSelectedTextColor(styles.ButtonFocusFgColor.Color())
+	list.SetSelectedBackgroundColor(styles.ButtonFocusBgColor.Color())
+
+	modal := ui.NewModalPicker("<"+title+">", options, list)
+	modal.SetDoneFunc(func(i int, _ string) {
+		dismiss(pages)
+		if i >= 0 {
+			action(i)
+		}
+	})
+
+	pages.AddPage(dialogKey, modal, false, false)
+	pages.ShowPage(dialogKey)
+}
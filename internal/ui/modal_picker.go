@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package ui
+
+import (
+	"strings"
+
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+const (
+	pickerMinWidth  = 40
+	pickerMaxHeight = 20
+)
+
+// ModalPicker represents a modal list filtered by a search field.
+type ModalPicker struct {
+	*tview.Box
+
+	input   *tview.InputField
+	list    *tview.List
+	frame   *tview.Frame
+	options []string
+	matches []int
+	done    func(int, string)
+}
+
+// NewModalPicker returns a new picker.
+func NewModalPicker(title string, options []string, list *tview.List) *ModalPicker {
+	m := ModalPicker{
+		Box:     tview.NewBox(),
+		input:   tview.NewInputField(),
+		list:    list,
+		options: options,
+	}
+
+	m.input.SetLabel("> ").
+		SetFieldBackgroundColor(tview.Styles.ContrastBackgroundColor).
+		SetBackgroundColor(tview.Styles.ContrastBackgroundColor)
+	m.input.SetChangedFunc(m.filter)
+	m.input.SetInputCapture(m.keyboard)
+	m.list.SetBackgroundColor(tview.Styles.ContrastBackgroundColor).SetBorderPadding(0, 0, 0, 0)
+	m.list.SetSelectedFunc(func(i int, main string, _ string, _ rune) {
+		m.pick(i, main)
+	})
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(m.input, 1, 0, true).
+		AddItem(m.list, 0, 1, false)
+	m.frame = tview.NewFrame(flex).SetBorders(0, 0, 1, 0, 0, 0)
+	m.frame.SetBorder(true).
+		SetBackgroundColor(tview.Styles.ContrastBackgroundColor).
+		SetBorderPadding(1, 1, 1, 1)
+	m.frame.SetTitle(title)
+	m.frame.SetTitleColor(tcell.ColorAqua)
+	m.filter("")
+
+	return &m
+}
+
+// SetDoneFunc sets the callback for a picked option. The index is -1 when
+// the picker was dismissed.
+func (m *ModalPicker) SetDoneFunc(handler func(int, string)) *ModalPicker {
+	m.done = handler
+	return m
+}
+
+func (m *ModalPicker) filter(q string) {
+	m.matches = FilterOptions(m.options, q)
+	m.list.Clear()
+	for _, i := range m.matches {
+		m.list.AddItem(m.options[i], "", 0, nil)
+	}
+}
+
+func (m *ModalPicker) pick(i int, main string) {
+	if m.done == nil {
+		return
+	}
+	if i < 0 || i >= len(m.matches) {
+		m.done(-1, "")
+		return
+	}
+	m.done(m.matches[i], main)
+}
+
+func (m *ModalPicker) keyboard(evt *tcell.EventKey) *tcell.EventKey {
+	switch evt.Key() {
+	case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+		if h := m.list.InputHandler(); h != nil {
+			h(evt, func(tview.Primitive) {})
+		}
+		return nil
+	case tcell.KeyEnter:
+		if m.list.GetItemCount() == 0 {
+			return nil
+		}
+		i := m.list.GetCurrentItem()
+		main, _ := m.list.GetItemText(i)
+		m.pick(i, main)
+		return nil
+	case tcell.KeyEscape:
+		m.pick(-1, "")
+		return nil
+	}
+
+	return evt
+}
+
+// FilterOptions returns the indexes of the options matching all the words of
+// a query, ignoring case.
+func FilterOptions(options []string, q string) []int {
+	ww := strings.Fields(strings.ToLower(q))
+	ii := make([]int, 0, len(options))
+	for i, o := range options {
+		o = strings.ToLower(o)
+		match := true
+		for _, w := range ww {
+			if !strings.Contains(o, w) {
+				match = false
+				break
+			}
+		}
+		if match {
+			ii = append(ii, i)
+		}
+	}
+
+	return ii
+}
+
+// Draw draws this primitive onto the screen.
+func (m *ModalPicker) Draw(screen tcell.Screen) {
+	width := pickerMinWidth
+	for _, o := range m.options {
+		width = max(width, len(o)+2)
+	}
+	screenWidth, screenHeight := screen.Size()
+	width = min(width+2, screenWidth)
+	height := min(len(m.options), pickerMaxHeight, max(screenHeight-10, 1)) + 5
+	x := (screenWidth - width) / 2
+	y := (screenHeight - height) / 2
+	m.SetRect(x, y, width, height)
+
+	m.frame.SetRect(x, y, width, height)
+	m.frame.Draw(screen)
+}
+
+// Focus is called when this primitive receives focus.
+func (m *ModalPicker) Focus(delegate func(p tview.Primitive)) {
+	delegate(m.input)
+}
+
+// HasFocus returns whether this primitive has focus.
+func (m *ModalPicker) HasFocus() bool {
+	return m.input.HasFocus()
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (m *ModalPicker) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return m.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		consumed, capture = m.list.MouseHandler()(action, event, func(tview.Primitive) {})
+		if !consumed && m.InRect(event.Position()) {
+			consumed = true
+		}
+		setFocus(m.input)
+		return
+	})
+}
+
+// InputHandler returns the handler for this primitive.
+func (m *ModalPicker) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return m.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if h := m.input.InputHandler(); h != nil {
+			h(event, setFocus)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterOptions(t *testing.T) {
+	oo := []string{"Shift-L  Move Leader", "Ctrl-D   Delete", "s        Shell  (plugin)"}
+
+	uu := map[string]struct {
+		q string
+		e []int
+	}{
+		"empty": {
+			e: []int{0, 1, 2},
+		},
+		"case": {
+			q: "delete",
+			e: []int{1},
+		},
+		"words": {
+			q: "sh plugin",
+			e: []int{2},
+		},
+		"key": {
+			q: "ctrl-",
+			e: []int{1},
+		},
+		"none": {
+			q: "zorg",
+			e: []int{},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, ui.FilterOptions(oo, u.q))
+		})
+	}
+}
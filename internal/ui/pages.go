@@ -33,7 +33,7 @@ func NewPages() *Pages {
 func (p *Pages) IsTopDialog() bool {
 	_, pa := p.GetFrontPage()
 	switch pa.(type) {
-	case *tview.ModalForm, *ModalList:
+	case *tview.ModalForm, *ModalList, *ModalPicker:
 		return true
 	default:
 		return false
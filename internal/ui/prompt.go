@@ -75,6 +75,12 @@ type PromptModel interface {
 	Delete()
 }
 
+// Hinter describes what a command resolves to.
+type Hinter interface {
+	// Hint returns a command expansion if any.
+	Hint(text string) string
+}
+
 // Prompt captures users free from command input.
 type Prompt struct {
 	*tview.TextView
@@ -184,7 +190,7 @@ func (p *Prompt) keyboard(evt *tcell.EventKey) *tcell.EventKey {
 
 	case tcell.KeyTab, tcell.KeyRight, tcell.KeyCtrlF:
 		if s, ok := m.CurrentSuggestion(); ok {
-			p.model.SetText(p.model.GetText()+s, "", true)
+			p.model.SetText(p.model.GetText()+model.StripSuggestionNote(s), "", true)
 			m.ClearSuggestions()
 		}
 	}
@@ -238,13 +244,35 @@ func (p *Prompt) write(text, suggest string) {
 	defer p.mx.Unlock()
 
 	p.SetCursorIndex(p.spacer + len(text))
+	hint := p.hintFor(text + model.StripSuggestionNote(suggest))
 	if suggest != "" {
 		text += fmt.Sprintf("[%s::-]%s", p.styles.Prompt().SuggestColor, suggest)
 	}
+	if hint != "" {
+		text += fmt.Sprintf("[%s::d]%s%s", p.styles.Prompt().SuggestColor, p.hintSep(), tview.Escape(hint))
+	}
 	p.StylesChanged(p.styles)
 	_, _ = fmt.Fprintf(p, defaultPrompt, p.icon, p.prefix, text)
 }
 
+// hintFor returns what the active command resolves to.
+func (p *Prompt) hintFor(text string) string {
+	h, ok := p.model.(Hinter)
+	if !ok || text == "" || !p.model.IsActive() {
+		return ""
+	}
+
+	return h.Hint(text)
+}
+
+func (p *Prompt) hintSep() string {
+	if p.noIcons {
+		return "  -> "
+	}
+
+	return "  ⇢ "
+}
+
 // ----------------------------------------------------------------------------
 // Event Listener protocol...
 
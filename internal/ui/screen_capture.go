@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/derailed/tcell/v2"
+)
+
+var sgrAttrs = []struct {
+	attr tcell.AttrMask
+	code string
+}{
+	{tcell.AttrBold, "1"},
+	{tcell.AttrDim, "2"},
+	{tcell.AttrItalic, "3"},
+	{tcell.AttrUnderline, "4"},
+	{tcell.AttrReverse, "7"},
+	{tcell.AttrStrikeThrough, "9"},
+}
+
+// ScreenText returns the content of a screen one row per line. Plain text
+// rows are stripped of trailing blanks, ANSI rows keep the cells colors and
+// attributes.
+func ScreenText(s tcell.Screen, ansi bool) string {
+	w, h := s.Size()
+	ll := make([]string, 0, h)
+	for y := range h {
+		var (
+			b    strings.Builder
+			last tcell.Style
+		)
+		for x := 0; x < w; {
+			mainc, combc, st, cw := s.GetContent(x, y)
+			if ansi && (x == 0 || st != last) {
+				b.WriteString(sgr(st))
+				last = st
+			}
+			if mainc == 0 {
+				mainc = ' '
+			}
+			b.WriteRune(mainc)
+			for _, r := range combc {
+				b.WriteRune(r)
+			}
+			x += max(cw, 1)
+		}
+		if ansi {
+			ll = append(ll, b.String()+"\x1b[0m")
+			continue
+		}
+		ll = append(ll, strings.TrimRight(b.String(), " "))
+	}
+	if !ansi {
+		for len(ll) > 0 && ll[len(ll)-1] == "" {
+			ll = ll[:len(ll)-1]
+		}
+	}
+
+	return strings.Join(ll, "\n") + "\n"
+}
+
+// sgr returns the ANSI escape sequence selecting a cell style.
+func sgr(st tcell.Style) string {
+	fg, bg, attrs := st.Decompose()
+	cc := []string{"0"}
+	for _, a := range sgrAttrs {
+		if attrs&a.attr != 0 {
+			cc = append(cc, a.code)
+		}
+	}
+	if r, g, b := fg.RGB(); fg != tcell.ColorDefault && r >= 0 {
+		cc = append(cc, "38;2;"+rgb(r, g, b))
+	}
+	if r, g, b := bg.RGB(); bg != tcell.ColorDefault && r >= 0 {
+		cc = append(cc, "48;2;"+rgb(r, g, b))
+	}
+
+	return "\x1b[" + strings.Join(cc, ";") + "m"
+}
+
+func rgb(r, g, b int32) string {
+	return strconv.Itoa(int(r)) + ";" + strconv.Itoa(int(g)) + ";" + strconv.Itoa(int(b))
+}
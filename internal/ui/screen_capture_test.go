@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScreenText(t *testing.T) {
+	s := tcell.NewSimulationScreen("UTF-8")
+	require.NoError(t, s.Init())
+	defer s.Fini()
+	s.SetSize(6, 3)
+
+	red := tcell.StyleDefault.Foreground(tcell.NewRGBColor(255, 0, 0)).Bold(true)
+	for i, r := range "pod" {
+		s.SetContent(i, 0, r, nil, red)
+	}
+	s.SetContent(0, 1, 'x', nil, tcell.StyleDefault)
+
+	assert.Equal(t, "pod\nx\n", ui.ScreenText(s, false))
+	assert.Equal(t,
+		"\x1b[0;1;38;2;255;0;0mpod\x1b[0m   \x1b[0m\n"+
+			"\x1b[0mx     \x1b[0m\n"+
+			"\x1b[0m      \x1b[0m\n",
+		ui.ScreenText(s, true),
+	)
+}
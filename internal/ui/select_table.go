@@ -14,11 +14,17 @@ type SelectTable struct {
 
 	model      Tabular
 	selectedFn func(string) string
+	rowFns     []SelectedRowFunc
 	marks      map[string]struct{}
 	selFgColor tcell.Color
 	selBgColor tcell.Color
 }
 
+// AddSelectedRowFn registers a callback fired when the cursor moves.
+func (s *SelectTable) AddSelectedRowFn(f SelectedRowFunc) {
+	s.rowFns = append(s.rowFns, f)
+}
+
 // SetModel sets the table model.
 func (s *SelectTable) SetModel(m Tabular) {
 	s.model = m
@@ -70,6 +76,32 @@ func (s *SelectTable) GetRowID(index int) (string, bool) {
 	return id, ok
 }
 
+// GetSelectedRowID returns the row id at the cursor.
+func (s *SelectTable) GetSelectedRowID() (string, bool) {
+	if s.GetSelectedRowIndex() <= 0 {
+		return "", false
+	}
+
+	return s.GetRowID(s.GetSelectedRowIndex())
+}
+
+// selectRowID moves the cursor to the row with the given id and reports
+// whether it was found.
+func (s *SelectTable) selectRowID(id string, broadcast bool) bool {
+	if id == "" {
+		return false
+	}
+	for r := 1; r < s.GetRowCount(); r++ {
+		if rid, ok := s.GetRowID(r); ok && rid == id {
+			_, c := s.GetSelection()
+			s.SelectRow(r, c, broadcast)
+			return true
+		}
+	}
+
+	return false
+}
+
 // GetSelectedItem returns the currently selected item name.
 func (s *SelectTable) GetSelectedItem() string {
 	if s.GetSelectedRowIndex() == 0 || s.model.Empty() {
@@ -129,6 +161,9 @@ func (s *SelectTable) selectionChanged(r, c int) {
 			tcell.StyleDefault.Foreground(s.selFgColor).
 				Background(cell.Color).Attributes(tcell.AttrBold))
 	}
+	for _, f := range s.rowFns {
+		f(r)
+	}
 }
 
 // ClearMarks delete all marked items.
@@ -145,12 +180,12 @@ func (s *SelectTable) DeleteMark(k string) {
 
 // ToggleMark toggles marked row.
 func (s *SelectTable) ToggleMark() {
-	sel := s.GetSelectedItem()
-	if sel == "" {
+	sel, ok := s.GetSelectedRowID()
+	if !ok || sel == "" || s.model.Empty() {
 		return
 	}
 	if _, ok := s.marks[sel]; ok {
-		delete(s.marks, s.GetSelectedItem())
+		delete(s.marks, sel)
 	} else {
 		s.marks[sel] = struct{}{}
 	}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
@@ -48,6 +49,7 @@ type Table struct {
 	styles         *config.Styles
 	viewSetting    *config.ViewSetting
 	colorerFn      model1.ColorerFunc
+	colorRules     model1.ColorRules
 	decorateFn     DecorateFunc
 	wide           bool
 	toast          bool
@@ -57,6 +59,8 @@ type Table struct {
 	readOnly       bool
 	noIcon         bool
 	fullGVR        bool
+	paused         bool
+	queued         int
 	TabHint        string
 }
 
@@ -84,6 +88,15 @@ func (t *Table) SetFullGVR(b bool) {
 	t.fullGVR = b
 }
 
+// SetPaused flags the table updates as paused along with the number of
+// changes queued since.
+func (t *Table) SetPaused(paused bool, queued int) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.paused, t.queued = paused, queued
+}
+
 // SetNoIcon toggles no icon mode.
 func (t *Table) SetNoIcon(b bool) {
 	t.mx.Lock()
@@ -274,6 +287,13 @@ func (t *Table) SetViewSetting(vs *config.ViewSetting) bool {
 	if !t.viewSetting.Equals(vs) {
 		t.viewSetting = vs
 		slog.Debug("Updating custom view setting", slogs.GVR, t.gvr, slogs.ViewSetting, vs)
+		t.colorRules = nil
+		if vs != nil {
+			var err error
+			if t.colorRules, err = model1.NewColorRules(vs.Colors); err != nil {
+				slog.Warn("Invalid color rules", slogs.GVR, t.gvr, slogs.Error, err)
+			}
+		}
 		t.model.SetViewSetting(t.ctx, vs)
 		return true
 	}
@@ -289,6 +309,13 @@ func (t *Table) GetViewSetting() *config.ViewSetting {
 	return t.viewSetting
 }
 
+func (t *Table) getColorRules() model1.ColorRules {
+	t.mx.RLock()
+	defer t.mx.RUnlock()
+
+	return t.colorRules
+}
+
 func (t *Table) GetContext() context.Context {
 	return t.ctx
 }
@@ -469,7 +496,10 @@ func (t *Table) shouldExcludeColumn(h model1.HeaderColumn) bool {
 		(h.VS && vul.ImgScanner == nil)
 }
 
+// UpdateUI renders the table data, keeping the cursor on the selected
+// resource when it moved due to sorting or updates.
 func (t *Table) UpdateUI(cdata, data *model1.TableData) {
+	selID, _ := t.GetSelectedRowID()
 	t.Clear()
 	fg := t.styles.Table().Header.FgColor.Color()
 	bg := t.styles.Table().Header.BgColor.Color()
@@ -500,7 +530,9 @@ func (t *Table) UpdateUI(cdata, data *model1.TableData) {
 		return true
 	})
 
-	t.updateSelection(true)
+	if !t.selectRowID(selID, true) {
+		t.updateSelection(true)
+	}
 	t.UpdateTitle()
 }
 
@@ -511,6 +543,7 @@ func (t *Table) buildRow(r int, re, ore model1.RowEvent, h model1.Header, pads M
 	}
 
 	marked := t.IsMarked(re.Row.ID)
+	ruleColor, ruleAttrs, ruled := t.getColorRules().Match(h, re.Row)
 	var col int
 	ns := t.GetModel().GetNamespace()
 	for c, field := range re.Row.Fields {
@@ -548,6 +581,10 @@ func (t *Table) buildRow(r int, re, ore model1.RowEvent, h model1.Header, pads M
 		cell.SetExpansion(1)
 		cell.SetAlign(h[c].Align)
 		fgColor := color(ns, h, &re)
+		if ruled && re.Kind != model1.EventDelete {
+			fgColor = ruleColor
+			cell.SetAttributes(ruleAttrs)
+		}
 		cell.SetTextColor(fgColor)
 		if marked {
 			cell.SetTextColor(t.styles.Table().MarkColor.Color())
@@ -560,6 +597,17 @@ func (t *Table) buildRow(r int, re, ore model1.RowEvent, h model1.Header, pads M
 	}
 }
 
+// SortCol returns the sort column and whether the user picked it.
+func (t *Table) SortCol() (model1.SortColumn, bool) {
+	return t.getSortCol(), t.getMSort()
+}
+
+// RestoreSortCol sets a sort column as if picked by the user.
+func (t *Table) RestoreSortCol(name string, asc bool) {
+	t.setSortCol(model1.SortColumn{Name: name, ASC: asc})
+	t.setMSort(true)
+}
+
 // SortColCmd designates a sorted column.
 func (t *Table) SortColCmd(name string, asc bool) func(evt *tcell.EventKey) *tcell.EventKey {
 	return func(*tcell.EventKey) *tcell.EventKey {
@@ -667,6 +715,17 @@ func (t *Table) UpdateTitle() {
 	t.SetTitle(t.styleTitle())
 }
 
+func (t *Table) pausedBadge() (string, bool) {
+	t.mx.RLock()
+	defer t.mx.RUnlock()
+
+	if !t.paused || t.queued == 0 {
+		return "", t.paused
+	}
+
+	return fmt.Sprintf(" %d queued", t.queued), true
+}
+
 func (t *Table) styleTitle() string {
 	rc := int64(t.GetRowCount())
 	if rc > 0 {
@@ -704,12 +763,29 @@ func (t *Table) styleTitle() string {
 	} else {
 		title = SkinTitle(fmt.Sprintf(NSTitleFmt, resource, ns, render.AsThousands(rc)), &styles)
 	}
+	if badge, ok := t.pausedBadge(); ok {
+		title += SkinTitle(fmt.Sprintf(PausedFmt, badge), &styles)
+	}
+	if tr, ok := t.GetModel().(Truncater); ok {
+		if limit, truncated := tr.Truncated(); truncated {
+			title += SkinTitle(fmt.Sprintf(TruncatedFmt, render.AsThousands(int64(limit))), &styles)
+		}
+	}
+	if cs, ok := t.GetModel().(ContextStatuser); ok {
+		if s := ContextStatusTitle(cs.ContextStatuses(), time.Now()); s != "" {
+			title += SkinTitle(fmt.Sprintf(ContextsFmt, s), &styles)
+		}
+	}
 
 	buff := t.cmdBuff.GetText()
 	if internal.IsLabelSelector(buff) {
 		if sel, err := ExtractLabelSelector(buff); err == nil {
 			buff = render.Truncate(sel.String(), maxTruncate)
 		}
+	} else if internal.IsFieldSelector(buff) {
+		if sel, err := ExtractFieldSelector(buff); err == nil {
+			buff = render.Truncate(sel.String(), maxTruncate)
+		}
 	} else if l := t.GetModel().GetLabelSelector(); l != nil && !l.Empty() {
 		buff = render.Truncate(l.String(), maxTruncate)
 	} else if buff != "" {
@@ -9,11 +9,17 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/render"
 	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/tview"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/duration"
 )
 
 const (
@@ -23,6 +29,15 @@ const (
 	// SearchFmt represents a filter view title.
 	SearchFmt = "<[filter:bg:r]/%s[fg:bg:-]> "
 
+	// ContextsFmt represents a multi-context view title badge.
+	ContextsFmt = "<[fg:bg:-]%s[fg:bg:-]> "
+
+	// TruncatedFmt represents a truncated view title badge.
+	TruncatedFmt = "<[filter:bg:b]TRUNCATED %s[fg:bg:-]> "
+
+	// PausedFmt represents a paused view title badge.
+	PausedFmt = "<[filter:bg:b]PAUSED%s[fg:bg:-]> "
+
 	// NSTitleFmt represents a namespaced view title.
 	NSTitleFmt = " [fg:bg:b]%s([hilite:bg:b]%s[fg:bg:-])[fg:bg:-][[count:bg:b]%s[fg:bg:-]][fg:bg:-] "
 
@@ -39,6 +54,37 @@ const (
 	NoNSFmat = "%s-%d.csv"
 )
 
+// ContextStatusTitle summarizes the rows count and freshness of each context
+// of a multi-context view, ie `prod-1:42 ✓ 5s | prod-2:38 ✗ 2m`. Contexts
+// holding more resources than listed show a `+`, ie `prod-3:5000+ ✓ 1s`.
+func ContextStatusTitle(ss []model1.ContextStatus, now time.Time) string {
+	if len(ss) == 0 {
+		return ""
+	}
+	ll := make([]string, 0, len(ss))
+	for _, s := range ss {
+		state, age := "✓", render.NAValue
+		switch {
+		case s.Loading:
+			state = "…"
+		case s.Err != nil:
+			state = "✗"
+		case s.FetchedAt.IsZero():
+			state = "…"
+		}
+		if !s.FetchedAt.IsZero() {
+			age = duration.HumanDuration(now.Sub(s.FetchedAt))
+		}
+		var more string
+		if s.Truncated {
+			more = "+"
+		}
+		ll = append(ll, fmt.Sprintf("%s:%d%s %s %s", tview.Escape(s.Context), s.Count, more, state, age))
+	}
+
+	return strings.Join(ll, " | ")
+}
+
 func mustExtractStyles(ctx context.Context) *config.Styles {
 	styles, ok := ctx.Value(internal.KeyStyles).(*config.Styles)
 	if !ok {
@@ -68,6 +114,11 @@ func ExtractLabelSelector(s string) (labels.Selector, error) {
 	return labels.Parse(selStr)
 }
 
+// ExtractFieldSelector extracts field query.
+func ExtractFieldSelector(s string) (fields.Selector, error) {
+	return fields.ParseSelector(strings.TrimSpace(strings.TrimPrefix(s, "-F")))
+}
+
 // SkinTitle decorates a title.
 func SkinTitle(fmat string, style *config.Frame) string {
 	bgColor := style.Title.BgColor
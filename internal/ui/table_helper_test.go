@@ -4,8 +4,11 @@
 package ui
 
 import (
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/derailed/k9s/internal/model1"
 	"github.com/derailed/k9s/internal/render"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/labels"
@@ -61,3 +64,52 @@ func TestExtractLabelSelector(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractFieldSelector(t *testing.T) {
+	uu := map[string]struct {
+		sel string
+		err bool
+		e   string
+	}{
+		"cool": {
+			sel: "-F status.phase!=Succeeded",
+			e:   "status.phase!=Succeeded",
+		},
+		"no-space": {
+			sel: "-Fspec.nodeName=n1,status.phase=Running",
+			e:   "spec.nodeName=n1,status.phase=Running",
+		},
+		"toast": {
+			sel: "-F status.phase",
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			sel, err := ExtractFieldSelector(u.sel)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, sel.String())
+		})
+	}
+}
+
+func TestContextStatusTitle(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	ss := []model1.ContextStatus{
+		{Context: "prod-1", Count: 42, FetchedAt: now.Add(-5 * time.Second)},
+		{Context: "prod-2", Count: 38, FetchedAt: now.Add(-2 * time.Minute), Err: errors.New("boom")},
+		{Context: "prod-3", Err: errors.New("boom")},
+		{Context: "prod-4"},
+		{Context: "prod-5", Count: 7, FetchedAt: now.Add(-10 * time.Second), Err: errors.New("boom"), Loading: true},
+		{Context: "prod-6", Count: 500, FetchedAt: now.Add(-time.Second), Truncated: true},
+	}
+
+	assert.Equal(t, "prod-1:42 ✓ 5s | prod-2:38 ✗ 2m | prod-3:0 ✗ n/a | prod-4:0 … n/a | prod-5:7 … 10s | prod-6:500+ ✓ 1s", ContextStatusTitle(ss, now))
+	assert.Empty(t, ContextStatusTitle(nil, now))
+}
@@ -64,6 +64,42 @@ func TestTableSelection(t *testing.T) {
 	assert.Equal(t, 1, v.GetSelectedRowIndex())
 }
 
+func TestTableStickySelection(t *testing.T) {
+	v := ui.NewTable(client.NewGVR("fred"))
+	v.Init(makeContext())
+	v.SetModel(new(mockModel))
+	v.SetSortCol("C", true)
+	v.Refresh()
+
+	v.SelectRow(2, 0, true)
+	assert.Equal(t, "r2", v.GetSelectedItem())
+	v.ToggleMark()
+	assert.True(t, v.IsMarked("r2"))
+
+	v.SortInvertCmd(nil)
+	assert.Equal(t, 1, v.GetSelectedRowIndex())
+	assert.Equal(t, "r2", v.GetSelectedItem())
+	assert.Equal(t, []string{"r2"}, v.GetSelectedItems())
+}
+
+func TestTablePaused(t *testing.T) {
+	v := ui.NewTable(client.NewGVR("fred"))
+	v.Init(makeContext())
+
+	v.SetPaused(true, 0)
+	v.UpdateTitle()
+	assert.Contains(t, v.GetTitle(), "PAUSED")
+	assert.NotContains(t, v.GetTitle(), "queued")
+
+	v.SetPaused(true, 3)
+	v.UpdateTitle()
+	assert.Contains(t, v.GetTitle(), "PAUSED 3 queued")
+
+	v.SetPaused(false, 0)
+	v.UpdateTitle()
+	assert.NotContains(t, v.GetTitle(), "PAUSED")
+}
+
 // ----------------------------------------------------------------------------
 // Helpers...
 
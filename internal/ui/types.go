@@ -42,6 +42,27 @@ type Lister interface {
 	Get(ctx context.Context, path string) (runtime.Object, error)
 }
 
+// ContextStatuser represents a model tracking the fetches of each context.
+type ContextStatuser interface {
+	// ContextStatuses returns the last fetch outcome of each context.
+	ContextStatuses() []model1.ContextStatus
+}
+
+// Truncater represents a model capping the rows of a view.
+type Truncater interface {
+	// Truncated returns the max number of rows and whether some were left out.
+	Truncated() (int, bool)
+}
+
+// FieldSelectable represents a model filtering resources server side.
+type FieldSelectable interface {
+	// SetFieldSelector sets the field selector.
+	SetFieldSelector(string)
+
+	// GetFieldSelector returns the field selector.
+	GetFieldSelector() string
+}
+
 // Tabular represents a tabular model.
 type Tabular interface {
 	Namespaceable
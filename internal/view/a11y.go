@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/ui"
+)
+
+// a11yCell represents a focused row value.
+type a11yCell struct {
+	Column string `json:"column"`
+	Value  string `json:"value"`
+}
+
+// a11ySnapshot represents the semantic content of the current view.
+type a11ySnapshot struct {
+	Time    time.Time  `json:"time"`
+	Context string     `json:"context"`
+	View    string     `json:"view"`
+	Title   string     `json:"title"`
+	Columns []string   `json:"columns,omitempty"`
+	Row     int        `json:"row,omitempty"`
+	Rows    int        `json:"rows,omitempty"`
+	Focused []a11yCell `json:"focused,omitempty"`
+
+	// Text summarizes the view as a sentence ready to be spoken.
+	Text string `json:"text"`
+}
+
+// a11yCmd appends the current view semantic content to the accessibility
+// output, either a file or a named pipe read by a screen reader.
+func (a *App) a11yCmd(path string) {
+	if path == "" {
+		path = a.Config.K9s.A11yOutput()
+	}
+	c := a.Content.Top()
+	if c == nil {
+		a.Flash().Warn("No view to dump")
+		return
+	}
+	s := newA11ySnapshot(a.Config.ActiveContextName(), c)
+	go func() {
+		err := writeA11y(path, s)
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.Flash().Errf("Accessibility dump failed: %s", err)
+				return
+			}
+			a.Flash().Infof("View dumped to %s", path)
+		})
+	}()
+}
+
+func newA11ySnapshot(ctx string, c model.Component) a11ySnapshot {
+	s := a11ySnapshot{
+		Time:    time.Now(),
+		Context: ctx,
+		View:    c.Name(),
+	}
+	if t, ok := c.(interface{ GetTitle() string }); ok {
+		s.Title = ui.StripTags(t.GetTitle())
+	}
+	if v, ok := c.(ResourceViewer); ok {
+		t := v.GetTable().A11y()
+		s.Columns, s.Row, s.Rows = t.Columns, t.Row, t.Rows
+		for i, val := range t.Focused {
+			if i < len(t.Columns) {
+				s.Focused = append(s.Focused, a11yCell{Column: t.Columns[i], Value: val})
+			}
+		}
+	}
+	s.Text = s.speech()
+
+	return s
+}
+
+// speech renders the snapshot as a single sentence.
+func (s a11ySnapshot) speech() string {
+	title := s.Title
+	if title == "" {
+		title = s.View
+	}
+	tt := []string{title}
+	switch {
+	case s.Row > 0:
+		tt = append(tt, fmt.Sprintf("Row %d of %d", s.Row, s.Rows))
+	case s.Columns != nil:
+		tt = append(tt, fmt.Sprintf("%d rows, none focused", s.Rows))
+	}
+	if len(s.Focused) > 0 {
+		cc := make([]string, 0, len(s.Focused))
+		for _, c := range s.Focused {
+			if c.Value != "" {
+				cc = append(cc, c.Column+" "+c.Value)
+			}
+		}
+		tt = append(tt, strings.Join(cc, ", "))
+	}
+
+	return strings.Join(tt, ". ")
+}
+
+// writeA11y appends a snapshot as a json line. Writing to a named pipe blocks
+// until a reader is attached.
+func writeA11y(path string, s a11ySnapshot) error {
+	bb, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(bb, '\n'))
+
+	return err
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestA11ySpeech(t *testing.T) {
+	uu := map[string]struct {
+		s a11ySnapshot
+		e string
+	}{
+		"view": {
+			s: a11ySnapshot{View: "help"},
+			e: "help",
+		},
+		"no-focus": {
+			s: a11ySnapshot{Title: "pods(default)[0]", Columns: []string{"NAME"}},
+			e: "pods(default)[0]. 0 rows, none focused",
+		},
+		"focused": {
+			s: a11ySnapshot{
+				Title:   "pods(default)[2]",
+				Columns: []string{"NAME", "READY", "IP"},
+				Row:     2,
+				Rows:    2,
+				Focused: []a11yCell{
+					{Column: "NAME", Value: "fred"},
+					{Column: "READY", Value: "1/1"},
+					{Column: "IP"},
+				},
+			},
+			e: "pods(default)[2]. Row 2 of 2. NAME fred, READY 1/1",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.s.speech())
+		})
+	}
+}
+
+func TestWriteA11y(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a11y.jsonl")
+	require.NoError(t, writeA11y(path, a11ySnapshot{View: "pods", Text: "pods"}))
+	require.NoError(t, writeA11y(path, a11ySnapshot{View: "svc", Text: "svc"}))
+
+	bb, err := os.ReadFile(path)
+	require.NoError(t, err)
+	ll := strings.Split(strings.TrimSpace(string(bb)), "\n")
+	require.Len(t, ll, 2)
+
+	var s a11ySnapshot
+	require.NoError(t, json.Unmarshal([]byte(ll[1]), &s))
+	assert.Equal(t, "svc", s.View)
+	assert.Equal(t, "svc", s.Text)
+}
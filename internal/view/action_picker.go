@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/derailed/tcell/v2"
+)
+
+const (
+	actionPickerTitle = "Actions"
+	actionPickerKey   = tcell.KeyCtrlX
+)
+
+// Action picker groups, in display order.
+const (
+	actionGroupView = iota
+	actionGroupPlugin
+	actionGroupNav
+	actionGroupApp
+)
+
+var actionGroupTags = []string{"", "plugin", "goto", "global"}
+
+// pickerAction represents an action listed by the action picker.
+type pickerAction struct {
+	key    tcell.Key
+	group  int
+	action ui.KeyAction
+}
+
+// String returns the picker line of the action.
+func (p pickerAction) String() string {
+	s := fmt.Sprintf("%-12s %s", tcell.KeyNames[p.key], p.action.Description)
+	if tag := actionGroupTags[p.group]; tag != "" {
+		s += "  (" + tag + ")"
+	}
+
+	return s
+}
+
+// pickerActions lists the view actions followed by plugins, navigation
+// hotkeys and the app actions not shadowed by the view.
+func pickerActions(view, app *ui.KeyActions) []pickerAction {
+	var aa []pickerAction
+	add := func(k tcell.Key, a ui.KeyAction, group int) {
+		if k == actionPickerKey || strings.TrimSpace(a.Description) == "" {
+			return
+		}
+		if _, ok := tcell.KeyNames[k]; !ok {
+			return
+		}
+		aa = append(aa, pickerAction{key: k, group: group, action: a})
+	}
+	if view != nil {
+		view.Range(func(k tcell.Key, a ui.KeyAction) {
+			switch {
+			case a.Opts.Plugin:
+				add(k, a, actionGroupPlugin)
+			case a.Opts.HotKey:
+				add(k, a, actionGroupNav)
+			default:
+				add(k, a, actionGroupView)
+			}
+		})
+	}
+	if app != nil {
+		app.Range(func(k tcell.Key, a ui.KeyAction) {
+			if view != nil {
+				if _, ok := view.Get(k); ok {
+					return
+				}
+			}
+			add(k, a, actionGroupApp)
+		})
+	}
+	slices.SortFunc(aa, func(a, b pickerAction) int {
+		if a.group != b.group {
+			return a.group - b.group
+		}
+		return int(a.key) - int(b.key)
+	})
+
+	return aa
+}
+
+// pickerKeyEvent returns the key event triggering an action key.
+func pickerKeyEvent(k tcell.Key) *tcell.EventKey {
+	if k >= ui.KeySpace && k < 127 {
+		return tcell.NewEventKey(tcell.KeyRune, rune(k), tcell.ModNone)
+	}
+
+	return tcell.NewEventKey(k, 0, tcell.ModNone)
+}
+
+// showActionPicker pops a searchable list of the actions available on the
+// current view and runs the picked one on the selected row.
+func (a *App) showActionPicker(view *ui.KeyActions) {
+	aa := pickerActions(view, a.GetActions())
+	if len(aa) == 0 {
+		return
+	}
+	oo := make([]string, 0, len(aa))
+	for _, p := range aa {
+		oo = append(oo, p.String())
+	}
+	d := a.Styles.Dialog()
+	dialog.ShowPicker(&d, a.Content.Pages, actionPickerTitle, oo, func(i int) {
+		p := aa[i]
+		p.action.Action(pickerKeyEvent(p.key))
+	})
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickerActions(t *testing.T) {
+	noop := func(*tcell.EventKey) *tcell.EventKey { return nil }
+	view := ui.NewKeyActionsFromMap(ui.KeyMap{
+		ui.KeyL:         ui.NewKeyAction("Logs", noop, true),
+		tcell.KeyCtrlD:  ui.NewKeyAction("Delete", noop, true),
+		ui.KeyShiftS:    ui.NewKeyActionWithOpts("Stern", noop, ui.ActionOpts{Plugin: true}),
+		tcell.KeyF2:     ui.NewKeyActionWithOpts("Nodes", noop, ui.ActionOpts{HotKey: true, Shared: true}),
+		actionPickerKey: ui.NewSharedKeyAction("Actions", noop, false),
+		ui.KeyZ:         ui.NewKeyAction("", noop, false),
+	})
+	app := ui.NewKeyActionsFromMap(ui.KeyMap{
+		ui.KeyHelp:     ui.NewSharedKeyAction("Help", noop, false),
+		tcell.KeyCtrlD: ui.NewKeyAction("Shadowed", noop, false),
+	})
+
+	aa := pickerActions(view, app)
+	ss := make([]string, 0, len(aa))
+	for _, a := range aa {
+		ss = append(ss, a.String())
+	}
+	assert.Equal(t, []string{
+		"Ctrl-D       Delete",
+		"l            Logs",
+		"Shift-S      Stern  (plugin)",
+		"F2           Nodes  (goto)",
+		"?            Help  (global)",
+	}, ss)
+}
+
+func TestPickerKeyEvent(t *testing.T) {
+	assert.Equal(t, ui.KeyL, ui.AsKey(pickerKeyEvent(ui.KeyL)))
+	assert.Equal(t, ui.KeyShiftS, ui.AsKey(pickerKeyEvent(ui.KeyShiftS)))
+	assert.Equal(t, tcell.KeyCtrlD, ui.AsKey(pickerKeyEvent(tcell.KeyCtrlD)))
+	assert.Equal(t, tcell.KeyF2, ui.AsKey(pickerKeyEvent(tcell.KeyF2)))
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/metrics"
 	"github.com/derailed/k9s/internal/slogs"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/derailed/k9s/internal/ui/dialog"
@@ -22,6 +23,11 @@ import (
 // AllScopes represents actions available for all views.
 const AllScopes = "all"
 
+const (
+	pluginOK     = "ok"
+	pluginFailed = "failed"
+)
+
 // Runner represents a runnable action handler.
 type Runner interface {
 	// App returns the current app.
@@ -203,16 +209,22 @@ func pluginAction(r Runner, p *config.Plugin) ui.ActionHandler {
 			pluginInView(r, p, args)
 			return nil
 		}
+		if p.Terminal {
+			pluginInTerminal(r, p, args)
+			return nil
+		}
 
 		cb := func() {
+			bin, args := r.App().pluginCommand(p, args)
 			opts := shellOpts{
-				binary:     p.Command,
+				binary:     bin,
 				background: p.Background,
 				pipes:      p.Pipes,
 				args:       args,
 			}
 			suspend, errChan, statusChan := run(r.App(), &opts)
 			if !suspend {
+				metrics.PluginRuns.Inc(p.Description, pluginFailed)
 				r.App().Flash().Infof("Plugin command failed: %q", p.Description)
 				return
 			}
@@ -220,6 +232,7 @@ func pluginAction(r Runner, p *config.Plugin) ui.ActionHandler {
 			for e := range errChan {
 				errs = errors.Join(errs, e)
 			}
+			metrics.PluginRuns.Inc(p.Description, pluginStatus(errs))
 			if errs != nil {
 				if !strings.Contains(errs.Error(), "signal: interrupt") {
 					slog.Error("Plugin command failed", slogs.Error, errs)
@@ -254,16 +267,18 @@ func pluginAction(r Runner, p *config.Plugin) ui.ActionHandler {
 func pluginInView(r Runner, p *config.Plugin, args []string) {
 	cb := func() {
 		r.App().Flash().Infof("Running %s...", p.Description)
+		bin, args := r.App().pluginCommand(p, args)
 		go func() {
 			out, err := oneShoot(context.Background(), &shellOpts{
-				binary: p.Command,
+				binary: bin,
 				args:   args,
 			})
+			metrics.PluginRuns.Inc(p.Description, pluginStatus(err))
 			if err != nil {
 				out = fmt.Sprintf("Error: %s\n\n%s", err, out)
 			}
 			r.App().QueueUpdateDraw(func() {
-				details := NewDetails(r.App(), p.Description, "plugin", contentTXT, true).Update(out)
+				details := NewDetails(r.App(), p.Description, "plugin", contentANSI, true).Update(out)
 				if e := r.App().inject(details, false); e != nil {
 					r.App().Flash().Err(e)
 				}
@@ -278,3 +293,11 @@ func pluginInView(r Runner, p *config.Plugin, args []string) {
 	}
 	cb()
 }
+
+func pluginStatus(err error) string {
+	if err != nil {
+		return pluginFailed
+	}
+
+	return pluginOK
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+)
+
+const agentLogsDeadline = 30 * time.Second
+
+// agentLogs shows the last log lines of a Rancher agent running on a
+// downstream cluster, reached through a kubeconfig context or the Rancher
+// cluster proxy.
+func (a *App) agentLogs(clusterID, name string, agent dao.Agent) {
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	ctxName, ok := dao.DownstreamContext(rawCfg, clusterID, name)
+	if !ok {
+		if ctxName, err = rancherHopContext(a, clusterID, name); err != nil {
+			a.Flash().Errf("No context found for cluster %q: %s", name, err)
+			return
+		}
+		if rawCfg, err = a.Conn().Config().RawConfig(); err != nil {
+			a.Flash().Err(err)
+			return
+		}
+	}
+
+	a.Flash().Infof("Fetching %s logs on %q...", agent.Name, ctxName)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), agentLogsDeadline)
+		defer cancel()
+
+		out, err := dao.AgentLogs(ctx, rawCfg, ctxName, agent, dao.AgentLogTail)
+		if err != nil {
+			out = fmt.Sprintf("Error: %s\n", err)
+		}
+		a.QueueUpdateDraw(func() {
+			details := NewDetails(a, agent.Name, ctxName, contentTXT, true).Update(out)
+			if e := a.inject(details, false); e != nil {
+				a.Flash().Err(e)
+			}
+		})
+	}()
+}
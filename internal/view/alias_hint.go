@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"strings"
+
+	"github.com/derailed/k9s/internal/view/cmd"
+)
+
+// aliasHint describes what a command resolves to, ie its full resource
+// along with the label selector, namespace or context baked into its alias.
+func (a *App) aliasHint(line string) string {
+	if a.command == nil || a.command.alias == nil {
+		return ""
+	}
+	p := cmd.NewInterpreter(line)
+	typed := p.Cmd()
+	if typed == "" {
+		return ""
+	}
+	gvr, ok := a.command.alias.Resolve(p)
+	if !ok || gvr == nil {
+		return ""
+	}
+
+	return resolvedHint(typed, gvr.String(), p)
+}
+
+// resolvedHint formats an alias expansion. It is blank when the command is
+// already spelled out.
+func resolvedHint(typed, res string, p *cmd.Interpreter) string {
+	ss := []string{res}
+	if sel, err := p.LabelsSelector(); err == nil && !sel.Empty() {
+		ss = append(ss, "-l "+sel.String())
+	}
+	if f, ok := p.FilterArg(); ok {
+		ss = append(ss, "/"+f)
+	}
+	if ns, ok := p.NSArg(); ok {
+		ss = append(ss, "ns:"+ns)
+	}
+	if ctx, ok := p.HasContext(); ok {
+		ss = append(ss, "@"+ctx)
+	}
+	if len(ss) == 1 && res == typed {
+		return ""
+	}
+
+	return strings.Join(ss, " ")
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/view/cmd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolvedHint(t *testing.T) {
+	uu := map[string]struct {
+		typed, res, line string
+		e                string
+	}{
+		"spelled-out": {
+			typed: "v1/pods",
+			res:   "v1/pods",
+			line:  "v1/pods",
+		},
+		"alias": {
+			typed: "lhv",
+			res:   "longhorn.io/v1beta2/volumes",
+			line:  "longhorn.io/v1beta2/volumes",
+			e:     "longhorn.io/v1beta2/volumes",
+		},
+		"labels": {
+			typed: "cp",
+			res:   "v1/nodes",
+			line:  "v1/nodes node-role.kubernetes.io/control-plane=true",
+			e:     "v1/nodes -l node-role.kubernetes.io/control-plane=true",
+		},
+		"full": {
+			typed: "prodpo",
+			res:   "v1/pods",
+			line:  "v1/pods kube-system app=fred /blee @prod",
+			e:     "v1/pods -l app=fred /blee ns:kube-system @prod",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, resolvedHint(u.typed, u.res, cmd.NewInterpreter(u.line)))
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/derailed/tview"
+)
+
+// ansiRX matches CSI, OSC and two characters escape sequences.
+var ansiRX = regexp.MustCompile(`\x1b(?:\[[0-9;?]*[ -/]*[@-~]|\][^\x07\x1b]*(?:\x07|\x1b\\)|[@-Z\\-_])`)
+
+// colorizeANSI translates the ANSI colors of a command output to color tags.
+// The output text is escaped so brackets are not mistaken for tags.
+func colorizeANSI(raw, fg, bg string) string {
+	var b strings.Builder
+	w := tview.ANSIWriter(&b, fg, bg)
+	for {
+		loc := ansiRX.FindStringIndex(raw)
+		if loc == nil {
+			_, _ = w.Write([]byte(tview.Escape(raw)))
+			break
+		}
+		_, _ = w.Write([]byte(tview.Escape(raw[:loc[0]])))
+		// Only colors are kept, other sequences are dropped.
+		if seq := raw[loc[0]:loc[1]]; strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m") {
+			_, _ = w.Write([]byte(seq))
+		}
+		raw = raw[loc[1]:]
+	}
+
+	return b.String()
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColorizeANSI(t *testing.T) {
+	uu := map[string]struct {
+		raw, e string
+	}{
+		"plain": {
+			raw: "NAME READY",
+			e:   "NAME READY",
+		},
+		"brackets": {
+			raw: "[OK] done [x]",
+			e:   "[OK[] done [x[]",
+		},
+		"reset": {
+			raw: "\x1b[0mdone",
+			e:   "[white:black:-]done",
+		},
+		"title": {
+			raw: "\x1b]0;title\x07done",
+			e:   "done",
+		},
+		"cursor": {
+			raw: "\x1b[2Kdone",
+			e:   "done",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, colorizeANSI(u.raw, "white", "black"))
+		})
+	}
+}
+
+func TestColorizeANSIColors(t *testing.T) {
+	s := colorizeANSI("\x1b[31m[FAIL]\x1b[0m pod-1", "white", "black")
+
+	assert.Contains(t, s, "[maroon")
+	assert.Contains(t, s, "[FAIL[]")
+	assert.Contains(t, s, "[white:black:-] pod-1")
+	assert.NotContains(t, s, "\x1b")
+}
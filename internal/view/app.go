@@ -8,12 +8,14 @@ import (
 	"fmt"
 	"log/slog"
 	"maps"
+	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
@@ -23,6 +25,7 @@ import (
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/model"
 	"github.com/derailed/k9s/internal/slogs"
 	"github.com/derailed/k9s/internal/ui"
@@ -49,9 +52,15 @@ type App struct {
 	version string
 	*ui.App
 	Content       *PageStack
+	body          *tview.Flex
+	frame         *tview.Flex
+	banner        *tview.TextView
+	preview       *RowPreview
 	command       *Command
 	factory       *watch.Factory
 	cancelFn      context.CancelFunc
+	appCtx        context.Context
+	appCancel     context.CancelFunc
 	clusterModel  *model.ClusterInfo
 	cmdHistory    *model.History
 	filterHistory *model.History
@@ -59,6 +68,31 @@ type App struct {
 	showHeader    bool
 	showLogo      bool
 	showCrumbs    bool
+	macro         *macroRecording
+	macroPlaying  atomic.Bool
+	inbox         *model.Inbox
+	waits         waitTracker
+	rules         *dao.RuleBook
+	canaryPins    dao.CanaryPins
+	janitorAge    time.Duration
+	hooksRan      string
+	hookProcs     []*exec.Cmd
+	hookMx        sync.Mutex
+	loginPending  atomic.Bool
+	loginDeclined atomic.Bool
+	paused        atomic.Bool
+	switching     atomic.Bool
+	suspended     atomic.Bool
+	teamNotes     atomic.Pointer[dao.TeamNotes]
+	metricsSrv    *http.Server
+	healthSrv     *http.Server
+	healthProbing atomic.Bool
+	capture       *watchCapture
+	chaosReverts  []*chaosReversal
+	chaosMx       sync.Mutex
+	mcNamespaces  mcNamespaces
+	gateRun       atomic.Uint64
+	idle          idleLock
 }
 
 // NewApp returns a K9s app instance.
@@ -68,11 +102,16 @@ func NewApp(cfg *config.Config) *App {
 		cmdHistory:    model.NewHistory(model.MaxHistory),
 		filterHistory: model.NewHistory(model.MaxHistory),
 		Content:       NewPageStack(),
+		inbox:         model.NewInbox(model.MaxInbox),
+		rules:         dao.NewRuleBook(),
+		janitorAge:    dao.DefaultJanitorAge,
 	}
+	a.appCtx, a.appCancel = context.WithCancel(context.Background())
 	a.ReloadStyles()
 
 	a.Views()["statusIndicator"] = ui.NewStatusIndicator(a.App, a.Styles)
 	a.Views()["clusterInfo"] = NewClusterInfo(&a)
+	a.Views()["nsBar"] = NewNsBar(&a)
 
 	return &a
 }
@@ -130,6 +169,8 @@ func (a *App) Init(version string, _ int) error {
 		return err
 	}
 	a.CmdBuff().SetSuggestionFn(a.suggestCommand())
+	a.CmdBuff().SetHintFn(a.aliasHint)
+	dao.SetMultiContextConfig(a.Config.K9s.MultiContext)
 
 	a.layout(ctx)
 	a.initSignals()
@@ -168,12 +209,23 @@ func (a *App) layout(ctx context.Context) {
 
 	main := tview.NewFlex().SetDirection(tview.FlexRow)
 	main.AddItem(a.statusIndicator(), 1, 1, false)
-	main.AddItem(a.Content, 0, 10, true)
+	a.banner = tview.NewTextView().SetDynamicColors(true).SetTextAlign(tview.AlignCenter)
+	main.AddItem(a.banner, 0, 0, false)
+	a.body = tview.NewFlex()
+	a.body.AddItem(a.Content, 0, 1, true)
+	main.AddItem(a.body, 0, 10, true)
 	main.AddItem(ui.NewFKeyBar(a.Styles), 1, 1, false)
 	if !a.Config.K9s.IsCrumbsless() {
 		main.AddItem(a.Crumbs(), 1, 1, false)
 	}
 	main.AddItem(flash, 1, 1, false)
+	a.frame = main
+
+	a.preview = NewRowPreview(a)
+	a.Content.AddListener(a.preview)
+	if p := a.Config.K9s.Preview; p != nil && p.Enable {
+		a.showPreview(p.Pos())
+	}
 
 	a.Main.AddPage("main", main, true, false)
 	a.toggleHeader(!a.Config.K9s.IsHeadless(), !a.Config.K9s.IsLogoless())
@@ -217,7 +269,9 @@ func (a *App) suggestCommand() model.SuggestionFunc {
 		if err != nil {
 			slog.Error("Failed to obtain list of namespaces", slogs.Error, err)
 		}
-		entries = append(entries, cmd.SuggestSubCommand(s, namespaceNames, contextNames)...)
+		counts, total := a.selectedNamespaces()
+		suggests := cmd.SuggestSubCommand(s, mergeNamespaces(namespaceNames, counts), contextNames)
+		entries = append(entries, annotateNSSuggestions(s, suggests, counts, total)...)
 		if len(entries) == 0 {
 			return nil
 		}
@@ -244,6 +298,16 @@ func (a *App) contextNames() ([]string, error) {
 }
 
 func (a *App) keyboard(evt *tcell.EventKey) *tcell.EventKey {
+	a.idle.touch(time.Now())
+	if a.idle.locked.Load() {
+		return a.idleLockKey(evt)
+	}
+	if t, ok := a.Content.Top().(*Terminal); ok && t.IsRunning() && !a.Content.IsTopDialog() {
+		// Consumes every key, Ctrl-C included, so it never reaches the app quit key.
+		t.keyboard(evt)
+		return nil
+	}
+	a.recordMacroKey(evt)
 	if k, ok := a.HasAction(ui.AsKey(evt)); ok && !a.Content.IsTopDialog() {
 		return k.Action(evt)
 	}
@@ -255,11 +319,13 @@ func (a *App) bindKeys() {
 	a.AddActions(ui.NewKeyActionsFromMap(ui.KeyMap{
 		tcell.KeyCtrlE:     ui.NewSharedKeyAction("ToggleHeader", a.toggleHeaderCmd, false),
 		tcell.KeyCtrlG:     ui.NewSharedKeyAction("ToggleCrumbs", a.toggleCrumbsCmd, false),
+		tcell.KeyCtrlV:     ui.NewSharedKeyAction("TogglePause", a.togglePauseCmd, false),
 		ui.KeyHelp:         ui.NewSharedKeyAction("Help", a.helpCmd, false),
 		ui.KeyLeftBracket:  ui.NewSharedKeyAction("Go Back", a.previousCommand, false),
 		ui.KeyRightBracket: ui.NewSharedKeyAction("Go Forward", a.nextCommand, false),
 		ui.KeyDash:         ui.NewSharedKeyAction("Last View", a.lastCommand, false),
 		tcell.KeyCtrlA:     ui.NewSharedKeyAction("Aliases", a.aliasCmd, false),
+		tcell.KeyF12:       ui.NewSharedKeyAction("Screen Capture", a.screenCaptureCmd, false),
 		tcell.KeyEnter:     ui.NewKeyAction("Goto", a.gotoCmd, false),
 		tcell.KeyCtrlC:     ui.NewKeyAction("Quit", a.quitCmd, false),
 	}))
@@ -342,7 +408,13 @@ func (a *App) buildHeader() tview.Primitive {
 		}
 	}
 	header.AddItem(a.clusterInfo(), clWidth, 1, false)
-	header.AddItem(a.Menu(), 0, 1, false)
+	menu := tview.NewFlex()
+	menu.SetBackgroundColor(a.Styles.BgColor())
+	menu.SetDirection(tview.FlexRow)
+	menu.AddItem(a.nsBar(), 1, 0, false)
+	menu.AddItem(a.Menu(), 0, 1, false)
+	header.AddItem(menu, 0, 1, false)
+	a.nsBar().Refresh()
 
 	if a.showLogo {
 		header.AddItem(a.Logo(), 26, 1, false)
@@ -391,7 +463,7 @@ func (a *App) clusterUpdater(ctx context.Context) {
 	}
 
 	bf := model.NewExpBackOff(ctx, clusterRefresh, 2*time.Minute)
-	delay := clusterRefresh
+	delay := a.Config.K9s.Stretch(clusterRefresh)
 	for {
 		select {
 		case <-ctx.Done():
@@ -406,7 +478,7 @@ func (a *App) clusterUpdater(ctx context.Context) {
 				}
 			} else {
 				bf.Reset()
-				delay = clusterRefresh
+				delay = a.Config.K9s.Stretch(clusterRefresh)
 			}
 		}
 	}
@@ -429,6 +501,12 @@ func (a *App) refreshCluster(context.Context) error {
 			a.ClearStatus(true)
 		}
 		a.factory.ValidatePortForwards()
+	} else if a.promptLogin() {
+		if c != nil {
+			c.Stop()
+		}
+		a.Status(model.FlashWarn, "Credentials expired, login required")
+		return nil
 	} else if c != nil {
 		atomic.AddInt32(&a.conRetry, 1)
 		c.Stop()
@@ -473,6 +551,7 @@ func (a *App) switchNS(ns string) error {
 	if err := a.Config.SetActiveNamespace(ns); err != nil {
 		return err
 	}
+	a.nsBar().Refresh()
 
 	return a.factory.SetActiveNS(ns)
 }
@@ -485,7 +564,13 @@ func (a *App) switchContext(ci *cmd.Interpreter, force bool) error {
 
 	a.Halt()
 	defer a.Resume()
+	a.loginDeclined.Store(false)
 	{
+		prevCtx, shellNS := a.Config.ActiveContextName(), a.shellPodNS()
+		var forwards int
+		if a.factory != nil {
+			forwards = len(a.factory.Forwarders())
+		}
 		a.Config.Reset()
 		ct, err := a.Config.ActivateContext(contextName)
 		if err != nil {
@@ -523,6 +608,9 @@ func (a *App) switchContext(ci *cmd.Interpreter, force bool) error {
 		if a.factory != nil {
 			a.initFactory(ns)
 		}
+		if prevCtx != contextName {
+			a.releaseContext(prevCtx, forwards, shellNS)
+		}
 
 		if err := a.command.Reset(a.Config.ContextAliasesPath(), true); err != nil {
 			return err
@@ -535,6 +623,8 @@ func (a *App) switchContext(ci *cmd.Interpreter, force bool) error {
 		)
 		a.Flash().Infof("Switching context to %q::%q", contextName, ns)
 		a.ReloadStyles()
+		a.loadTeamNotes()
+		a.evalGates(contextName)
 		a.gotoResource(a.Config.ActiveView(), "", true, true)
 
 		if a.clusterModel != nil {
@@ -561,8 +651,22 @@ func (a *App) BailOut(exitCode int) {
 	if err := nukeK9sShell(a); err != nil {
 		slog.Error("Unable to nuke k9s shell pod", slogs.Error, err)
 	}
+	if v, ok := a.Content.Top().(TableViewer); ok {
+		v.GetTable().saveState()
+	}
+
+	if err := config.ClearRancherHops(config.RancherHopPath()); err != nil {
+		slog.Warn("Unable to clear rancher hop contexts", slogs.Error, err)
+	}
 
 	a.stopImgScanner()
+	a.stopHookProcs()
+	a.stopMetrics()
+	a.stopHealth()
+	a.stopIdleLock()
+	a.appCancel()
+	a.revertChaos()
+	dao.StopMonitoringForwards()
 	a.factory.Terminate()
 	a.App.BailOut(exitCode)
 }
@@ -584,12 +688,24 @@ func (a *App) Run() error {
 		<-time.After(500 * time.Millisecond)
 		a.QueueUpdateDraw(func() {
 			a.showRk9sStatus()
+			a.offerChaosReverts()
+			a.playStartupMacros()
 		})
 	}()
 
+	a.evalGates(a.Config.K9s.ActiveContextName())
 	if err := a.command.defaultCmd(true); err != nil {
 		return err
 	}
+	a.startChecks()
+	a.startRules()
+	a.startWarmup()
+	a.pullOnStart()
+	a.loadTeamNotes()
+	a.startMetrics()
+	a.startHealth()
+	a.startIdleLock()
+	a.startLowPower()
 	a.SetRunning(true)
 	if err := a.Application.Run(); err != nil {
 		return err
@@ -713,6 +829,30 @@ func (a *App) toggleHeaderCmd(evt *tcell.EventKey) *tcell.EventKey {
 	return nil
 }
 
+// IsPaused checks if view updates are paused.
+func (a *App) IsPaused() bool {
+	return a.paused.Load()
+}
+
+func (a *App) togglePauseCmd(evt *tcell.EventKey) *tcell.EventKey {
+	if a.Prompt().InCmdMode() {
+		return evt
+	}
+
+	on := !a.paused.Load()
+	a.paused.Store(on)
+	if v, ok := a.Content.Top().(ResourceViewer); ok {
+		v.Pause(on)
+	}
+	if on {
+		a.Flash().Info("View updates paused, press <ctrl-r> to refresh or <ctrl-v> to resume")
+	} else {
+		a.Flash().Info("View updates resumed")
+	}
+
+	return nil
+}
+
 func (a *App) toggleCrumbsCmd(evt *tcell.EventKey) *tcell.EventKey {
 	if a.Prompt().InCmdMode() {
 		return evt
@@ -728,6 +868,7 @@ func (a *App) toggleCrumbsCmd(evt *tcell.EventKey) *tcell.EventKey {
 
 func (a *App) gotoCmd(evt *tcell.EventKey) *tcell.EventKey {
 	if a.CmdBuff().IsActive() && !a.CmdBuff().Empty() {
+		a.recordMacroCmd(a.GetCmd())
 		a.gotoResource(a.GetCmd(), "", true, true)
 		a.ResetCmd()
 		return nil
@@ -750,17 +891,22 @@ func pluginDir(appName string) string {
 }
 
 func (a *App) runDashScript(title, subject, script string) {
+	sh, ok := posixShell()
+	if !ok {
+		a.Flash().Warnf("The %s dashboard requires bash or sh in your path", title)
+		return
+	}
 	a.Flash().Infof("Loading %s dashboard...", title)
 	go func() {
 		out, err := oneShoot(context.Background(), &shellOpts{
-			binary: "bash",
+			binary: sh,
 			args:   []string{"-c", script},
 		})
 		if err != nil {
 			out = fmt.Sprintf("Error: %s\n\n%s", err, out)
 		}
 		a.QueueUpdateDraw(func() {
-			details := NewDetails(a, title, subject, contentTXT, true).Update(out)
+			details := NewDetails(a, title, subject, contentANSI, true).Update(out)
 			if e := a.inject(details, false); e != nil {
 				a.Flash().Err(e)
 			}
@@ -1133,17 +1279,8 @@ for _ctx in %s; do
   fi
 done
 echo ''
-echo '--- etcd Member List ---'
-for _ctx in %s; do
-  echo "  [$_ctx]"
-  _pod=$(kubectl --context "$_ctx" -n kube-system get pods -l component=etcd -o jsonpath='{.items[0].metadata.name}' 2>/dev/null)
-  if [ -z "$_pod" ]; then
-    _pod=$(kubectl --context "$_ctx" -n kube-system get pods -l tier=control-plane -o jsonpath='{range .items[*]}{.metadata.name}{"\n"}{end}' 2>/dev/null | grep etcd | head -1)
-  fi
-  if [ -n "$_pod" ]; then
-    kubectl --context "$_ctx" -n kube-system exec "$_pod" -- sh -c 'ETCDCTL_API=3 etcdctl --cacert /etc/kubernetes/pki/etcd/ca.crt --cert /etc/kubernetes/pki/etcd/server.crt --key /etc/kubernetes/pki/etcd/server.key member list -w table 2>&1' 2>/dev/null || echo '    (member list failed)'
-  fi
-done
+echo '--- etcd Members ---'
+echo '  Type :etcdmembers to list members with move-leader and remove actions.'
 echo ''
 echo '--- etcd DB Size & Alarms ---'
 for _ctx in %s; do
@@ -1191,7 +1328,6 @@ echo '  Shift-F  etcd defrag           Shift-A  etcd alarm disarm'
 			ctxListArg(ctxs),
 			ctxListArg(ctxs),
 			ctxListArg(ctxs),
-			ctxListArg(ctxs),
 		))
 	}
 }
@@ -1337,6 +1473,10 @@ func (a *App) clusterInfo() *ClusterInfo {
 	return a.Views()["clusterInfo"].(*ClusterInfo)
 }
 
+func (a *App) nsBar() *NsBar {
+	return a.Views()["nsBar"].(*NsBar)
+}
+
 func (a *App) statusIndicator() *ui.StatusIndicator {
 	return a.Views()["statusIndicator"].(*ui.StatusIndicator)
 }
@@ -44,6 +44,17 @@ type Browser struct {
 	mx         sync.RWMutex
 	updating   bool
 	firstView  atomic.Int32
+
+	// Paused updates state, only accessed on the UI thread.
+	pending     *model1.TableData
+	queued      int
+	refreshOnce bool
+
+	// Skipped contexts last reported, only accessed on the UI thread.
+	skipped string
+
+	// Truncation last reported, only accessed on the UI thread.
+	truncated bool
 }
 
 // NewBrowser returns a new browser.
@@ -208,10 +219,8 @@ func (b *Browser) nextCRDCmd(*tcell.EventKey) *tcell.EventKey {
 	// Skip entries that aren't installed in the current cluster.
 	for step := 1; step < len(grp); step++ {
 		candidate := grp[(entry.pos+step)%len(grp)]
-		navCmd, labelSel, _ := parseCRDEntry(candidate)
-		if b.app.command.CanResolve(navCmd) {
-			b.app.gotoResource(navCmd, "", true, true)
-			b.applyCRDLabelFilter(labelSel)
+		if navCmd, _, _ := parseCRDEntry(candidate); b.app.command.CanResolve(navCmd) {
+			b.gotoCRDTab(aliasKey, candidate)
 			return nil
 		}
 	}
@@ -225,10 +234,8 @@ func (b *Browser) prevCRDCmd(*tcell.EventKey) *tcell.EventKey {
 	grp := crdGroups[entry.group]
 	for step := 1; step < len(grp); step++ {
 		candidate := grp[(entry.pos-step+len(grp))%len(grp)]
-		navCmd, labelSel, _ := parseCRDEntry(candidate)
-		if b.app.command.CanResolve(navCmd) {
-			b.app.gotoResource(navCmd, "", true, true)
-			b.applyCRDLabelFilter(labelSel)
+		if navCmd, _, _ := parseCRDEntry(candidate); b.app.command.CanResolve(navCmd) {
+			b.gotoCRDTab(aliasKey, candidate)
 			return nil
 		}
 	}
@@ -281,7 +288,9 @@ func (b *Browser) activateMultiContext() {
 		ui.KeyShiftC,
 		ui.NewKeyAction("Sort Cluster", b.GetTable().SortColCmd("CLUSTER", true), false),
 	)
-	b.GetTable().SetSortCol("CLUSTER", true)
+	if _, manual := b.GetTable().SortCol(); !manual {
+		b.GetTable().SetSortCol("CLUSTER", true)
+	}
 }
 
 // Stop terminates browser updates.
@@ -320,6 +329,26 @@ func (b *Browser) BufferCompleted(text, _ string) {
 	} else {
 		b.GetModel().SetLabelSelector(labels.Everything())
 	}
+	b.setFieldSelector(text)
+}
+
+// setFieldSelector filters the resources server side when the query is a
+// field selector, ie `-F status.phase!=Succeeded`.
+func (b *Browser) setFieldSelector(text string) {
+	m, ok := b.GetModel().(ui.FieldSelectable)
+	if !ok {
+		return
+	}
+	var sel string
+	if internal.IsFieldSelector(text) {
+		fsel, err := ui.ExtractFieldSelector(text)
+		if err != nil {
+			b.app.Flash().Errf("Invalid field selector: %s", err)
+		} else {
+			sel = fsel.String()
+		}
+	}
+	m.SetFieldSelector(sel)
 }
 
 // BufferActive indicates the buff activity changed.
@@ -413,6 +442,9 @@ func (b *Browser) TableNoData(mdata *model1.TableData) {
 		if b.getUpdating() {
 			return
 		}
+		if b.holdUpdate(mdata) {
+			return
+		}
 		b.setUpdating(true)
 		defer b.setUpdating(false)
 		if b.GetColumnCount() == 0 {
@@ -420,6 +452,8 @@ func (b *Browser) TableNoData(mdata *model1.TableData) {
 		}
 		b.refreshActions()
 		b.UpdateUI(cdata, mdata)
+		b.reportSkippedContexts()
+		b.reportTruncation()
 	})
 }
 
@@ -439,6 +473,9 @@ func (b *Browser) TableDataChanged(mdata *model1.TableData) {
 		if b.getUpdating() {
 			return
 		}
+		if b.holdUpdate(mdata) {
+			return
+		}
 		b.setUpdating(true)
 		defer b.setUpdating(false)
 		if b.GetColumnCount() == 0 {
@@ -450,9 +487,47 @@ func (b *Browser) TableDataChanged(mdata *model1.TableData) {
 		}
 		b.refreshActions()
 		b.UpdateUI(cdata, mdata)
+		b.reportSkippedContexts()
+		b.reportTruncation()
 	})
 }
 
+// Pause freezes or resumes the view updates. Changes received while paused
+// are applied on resume.
+func (b *Browser) Pause(on bool) {
+	mdata := b.pending
+	b.pending, b.queued, b.refreshOnce = nil, 0, false
+	b.SetPaused(on, 0)
+	if on || mdata == nil {
+		b.UpdateTitle()
+		return
+	}
+	b.refreshActions()
+	b.UpdateUI(b.Update(mdata, b.app.Conn().HasMetrics()), mdata)
+}
+
+// holdUpdate queues a data change while updates are paused and reports
+// whether it should be skipped. The first load and forced refreshes always
+// go through.
+func (b *Browser) holdUpdate(mdata *model1.TableData) bool {
+	if !b.app.IsPaused() {
+		b.pending, b.queued = nil, 0
+		b.SetPaused(false, 0)
+		return false
+	}
+	if b.refreshOnce || b.GetColumnCount() == 0 {
+		b.pending, b.queued, b.refreshOnce = nil, 0, false
+		b.SetPaused(true, 0)
+		return false
+	}
+	b.pending = mdata
+	b.queued++
+	b.SetPaused(true, b.queued)
+	b.UpdateTitle()
+
+	return true
+}
+
 // TableLoadFailed notifies view something went south.
 func (b *Browser) TableLoadFailed(err error) {
 	b.app.QueueUpdateDraw(func() {
@@ -523,6 +598,7 @@ func (b *Browser) resetCmd(evt *tcell.EventKey) *tcell.EventKey {
 		b.CmdBuff().ClearText(false)
 		if hasFilter {
 			b.GetModel().SetLabelSelector(labels.Everything())
+			b.setFieldSelector("")
 			b.Refresh()
 		}
 		return b.App().PrevCmd(evt)
@@ -543,7 +619,7 @@ func (b *Browser) filterCmd(evt *tcell.EventKey) *tcell.EventKey {
 	}
 
 	b.CmdBuff().SetActive(false)
-	if internal.IsLabelSelector(b.CmdBuff().GetText()) {
+	if q := b.CmdBuff().GetText(); internal.IsLabelSelector(q) || internal.IsFieldSelector(q) {
 		b.Start()
 		return nil
 	}
@@ -570,6 +646,9 @@ func (b *Browser) enterCmd(evt *tcell.EventKey) *tcell.EventKey {
 		return nil
 	}
 
+	if b.enterFn == nil && b.drillDown(path) {
+		return nil
+	}
 	f := describeResource
 	if b.enterFn != nil {
 		f = b.enterFn
@@ -581,6 +660,15 @@ func (b *Browser) enterCmd(evt *tcell.EventKey) *tcell.EventKey {
 
 func (b *Browser) refreshCmd(*tcell.EventKey) *tcell.EventKey {
 	b.app.Flash().Info("Refreshing...")
+	if b.app.IsPaused() {
+		b.refreshOnce = true
+		go func() {
+			if err := b.GetModel().Refresh(b.GetContext()); err != nil {
+				b.TableLoadFailed(err)
+			}
+		}()
+		return nil
+	}
 	b.refresh()
 
 	return nil
@@ -599,6 +687,7 @@ func (b *Browser) deleteCmd(evt *tcell.EventKey) *tcell.EventKey {
 		if len(selections) > 1 {
 			msg = fmt.Sprintf("Delete %d marked %s?", len(selections), b.GVR())
 		}
+		msg = b.app.withTeamNotes(msg, b.GVR(), selections)
 		if !dao.IsK8sMeta(b.meta) {
 			b.simpleDelete(selections, msg)
 			return nil
@@ -642,11 +731,21 @@ func (b *Browser) editCmd(evt *tcell.EventKey) *tcell.EventKey {
 		path = realPath
 	}
 
-	b.Stop()
-	defer b.Start()
-	if err := editRes(b.app, b.GVR(), path, ctxOverride); err != nil {
-		b.App().Flash().Err(err)
+	edit := func() {
+		b.Stop()
+		defer b.Start()
+		if err := editRes(b.app, b.GVR(), path, ctxOverride); err != nil {
+			b.App().Flash().Err(err)
+		}
 	}
+	msg := fmt.Sprintf("Edit %s %s?", b.GVR().R(), path)
+	if noted := b.app.withTeamNotes(msg, b.GVR(), []string{path}); noted != msg {
+		msg = noted
+		d := b.app.Styles.Dialog()
+		dialog.ShowConfirm(&d, b.app.Content.Pages, "Confirm Edit", msg, edit, func() {})
+		return nil
+	}
+	edit()
 
 	return nil
 }
@@ -747,6 +846,9 @@ func (b *Browser) defaultContext() context.Context {
 	}
 	ctx = context.WithValue(ctx, internal.KeyNamespace, client.CleanseNamespace(b.App().Config.ActiveNamespace()))
 	ctx = context.WithValue(ctx, internal.KeyWithMetrics, b.app.factory.Client().HasMetrics())
+	if ct, err := b.app.Config.K9s.ActiveContext(); err == nil {
+		ctx = context.WithValue(ctx, internal.KeyPrometheus, ct.Prometheus)
+	}
 
 	return ctx
 }
@@ -770,6 +872,12 @@ func (b *Browser) refreshActions() {
 						Visible:   true,
 						Dangerous: true,
 					}))
+				aa.Add(tcell.KeyCtrlY, ui.NewKeyAction("Dry-Run Apply", b.dryRunCmd, true))
+				aa.Add(ui.KeyShiftQ, ui.NewKeyActionWithOpts("Quick Patch", b.quickPatchCmd,
+					ui.ActionOpts{
+						Visible:   true,
+						Dangerous: true,
+					}))
 			}
 			if client.Can(b.meta.Verbs, "delete") {
 				aa.Add(tcell.KeyCtrlD, ui.NewKeyActionWithOpts("Delete", b.deleteCmd,
@@ -785,6 +893,12 @@ func (b *Browser) refreshActions() {
 	if !dao.IsK9sMeta(b.meta) {
 		aa.Add(ui.KeyY, ui.NewKeyAction(yamlAction, b.viewCmd, true))
 		aa.Add(ui.KeyD, ui.NewKeyAction("Describe", b.describeCmd, true))
+		if b.history() != nil {
+			aa.Add(ui.KeyShiftY, ui.NewKeyAction("YAML As Of", b.historyCmd, true))
+		}
+	}
+	if b.app.ConOK() && !b.app.Config.IsReadOnly() && !dao.IsK9sMeta(b.meta) {
+		b.Actions().Delete(gateActions(b, aa, b.accessGates())...)
 	}
 	for _, f := range b.bindKeysFn {
 		f(aa)
@@ -806,6 +920,18 @@ func (b *Browser) refreshActions() {
 	b.app.Menu().HydrateMenu(b.Hints())
 }
 
+// accessGates returns the accesses required by the edit and delete actions.
+func (b *Browser) accessGates() actionGates {
+	edit := []actionAccess{{gvr: b.GVR(), verbs: client.PatchAccess, clusterWide: !b.meta.Namespaced}}
+
+	return actionGates{
+		ui.KeyE:        edit,
+		tcell.KeyCtrlY: edit,
+		ui.KeyShiftQ:   edit,
+		tcell.KeyCtrlD: {{gvr: b.GVR(), verbs: []string{client.DeleteVerb}, clusterWide: !b.meta.Namespaced}},
+	}
+}
+
 func (b *Browser) namespaceActions(aa *ui.KeyActions) {
 	if !b.meta.Namespaced || b.GetTable().Path != "" {
 		return
@@ -816,29 +942,9 @@ func (b *Browser) namespaceActions(aa *ui.KeyActions) {
 	}
 
 	b.namespaces = make(map[int]string, data.MaxFavoritesNS)
-	var index int
-	if ok, _ := b.app.Conn().CanI(client.NamespaceAll, client.NsGVR, "", client.ListAccess); ok {
-		aa.Add(ui.Key0, ui.NewKeyAction(client.NamespaceAll, b.switchNamespaceCmd, true))
-		b.namespaces[0] = client.NamespaceAll
-		index = 1
-	}
-	favNamespaces := b.app.Config.FavNamespaces()
-	for _, ns := range favNamespaces {
-		if ns == client.NamespaceAll {
-			continue
-		}
-		if numKey, ok := ui.NumKeys[index]; ok {
-			aa.Add(numKey, ui.NewKeyAction(ns, b.switchNamespaceCmd, true))
-			b.namespaces[index] = ns
-			index++
-		} else {
-			slog.Warn("No number key available for favorite namespace. Skipping...",
-				slogs.Namespace, ns,
-				slogs.Index, index,
-				slogs.Max, len(favNamespaces),
-			)
-			break
-		}
+	for i, ns := range b.app.favNamespaceSlots() {
+		aa.Add(ui.NumKeys[i], ui.NewKeyAction(ns, b.switchNamespaceCmd, true))
+		b.namespaces[i] = ns
 	}
 }
 
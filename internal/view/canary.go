@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tcell/v2"
+)
+
+// Canary presents the workloads of a namespace side by side on the pinned
+// baseline and canary contexts.
+type Canary struct {
+	ResourceViewer
+}
+
+// NewCanary returns a new viewer.
+func NewCanary(gvr *client.GVR) ResourceViewer {
+	c := Canary{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	c.SetContextFn(c.canaryContext)
+	c.AddBindKeysFn(c.bindKeys)
+	c.GetTable().SetSortCol("VERDICT", true)
+
+	return &c
+}
+
+func (c *Canary) canaryContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, internal.KeyCanary, c.App().canaryPins)
+}
+
+func (c *Canary) bindKeys(aa *ui.KeyActions) {
+	aa.Bulk(ui.KeyMap{
+		ui.KeyShiftV: ui.NewKeyAction("Sort Verdict", c.GetTable().SortColCmd("VERDICT", true), false),
+		ui.KeyShiftK: ui.NewKeyAction("Sort Kind", c.GetTable().SortColCmd("KIND", true), false),
+		ui.KeyShiftW: ui.NewKeyAction("Swap Contexts", c.swapCmd, true),
+	})
+}
+
+func (c *Canary) swapCmd(*tcell.EventKey) *tcell.EventKey {
+	p := &c.App().canaryPins
+	p.Baseline, p.Canary = p.Canary, p.Baseline
+	c.App().Flash().Infof("Baseline %q, canary %q", p.Baseline, p.Canary)
+	c.Refresh()
+
+	return nil
+}
+
+// canaryCmd pins the baseline and canary contexts and shows their comparison.
+// Without arguments the last pinned contexts are reused.
+func (a *App) canaryCmd(args string) {
+	ff := strings.Fields(args)
+	switch len(ff) {
+	case 0:
+	case 2:
+		cc, err := a.contextNames()
+		if err != nil {
+			a.Flash().Err(err)
+			return
+		}
+		for _, ctx := range ff {
+			if !slices.Contains(cc, ctx) {
+				a.Flash().Errf("Unknown context %q", ctx)
+				return
+			}
+		}
+		a.canaryPins = dao.CanaryPins{Baseline: ff[0], Canary: ff[1]}
+	default:
+		a.Flash().Errf("Invalid command. Use `compare <baseline-context> <canary-context>`")
+		return
+	}
+	if err := a.canaryPins.Validate(); err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	a.Flash().Infof("Baseline %q, canary %q", a.canaryPins.Baseline, a.canaryPins.Canary)
+	a.gotoResource(client.CnyGVR.String(), "", false, true)
+}
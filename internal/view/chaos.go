@@ -0,0 +1,415 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	chaosDeadline = 30 * time.Second
+	chaosPod      = "pod"
+	chaosNode     = "node"
+	chaosNetDeny  = "netdeny"
+	chaosRevert   = "revert"
+)
+
+// chaosActions tracks the chaos menu entries.
+var chaosActions = []struct{ name, desc string }{
+	{chaosPod, "Delete a random pod of the selected deployment"},
+	{chaosNode, "Cordon a random worker node"},
+	{chaosNetDeny, "Deny network traffic of the selected deployment"},
+}
+
+// chaosReversal tracks a chaos action waiting to be reverted.
+type chaosReversal struct {
+	context, action, target string
+	due                     time.Time
+	timer                   *time.Timer
+	revert                  func(context.Context) error
+}
+
+// chaosRequest represents a parsed chaos command.
+type chaosRequest struct {
+	action, target string
+	duration       time.Duration
+}
+
+// parseChaosArgs parses `pod [NS/]DEPLOY`, `node [DURATION]` and
+// `netdeny [NS/]DEPLOY [DURATION]` chaos commands.
+func parseChaosArgs(arg, ns string, d time.Duration) (chaosRequest, error) {
+	ff := strings.Fields(arg)
+	if len(ff) == 0 {
+		return chaosRequest{}, errors.New("missing chaos action")
+	}
+	r := chaosRequest{action: ff[0], duration: d}
+	args := ff[1:]
+	switch r.action {
+	case chaosPod, chaosNetDeny:
+		if len(args) == 0 {
+			return r, fmt.Errorf("chaos %s expects a deployment", r.action)
+		}
+		r.target, args = args[0], args[1:]
+		if !strings.Contains(r.target, "/") {
+			r.target = client.FQN(ns, r.target)
+		}
+	case chaosNode, chaosRevert:
+	default:
+		return r, fmt.Errorf("unknown chaos action %q", r.action)
+	}
+	if len(args) == 0 {
+		return r, nil
+	}
+	if r.action == chaosPod || r.action == chaosRevert || len(args) > 1 {
+		return r, fmt.Errorf("too many arguments for chaos %s", r.action)
+	}
+	dur, err := time.ParseDuration(args[0])
+	if err != nil || dur <= 0 {
+		return r, fmt.Errorf("invalid chaos duration %q", args[0])
+	}
+	r.duration = dur
+
+	return r, nil
+}
+
+// chaosCmd runs a guarded chaos action on the active context.
+func (a *App) chaosCmd(arg string) {
+	c := a.Config.K9s.Chaos
+	ctxName := a.Config.K9s.ActiveContextName()
+	if err := c.Guard(ctxName); err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	if a.Config.IsReadOnly() {
+		a.Flash().Warn("Chaos actions are not allowed in read-only mode")
+		return
+	}
+	d, err := c.RevertAfter()
+	if err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	if arg == "" {
+		a.chaosMenu(d)
+		return
+	}
+	r, err := parseChaosArgs(arg, a.Config.ActiveNamespace(), d)
+	if err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	if r.action == chaosRevert {
+		n := a.revertChaos()
+		a.Flash().Infof("Reverted %d chaos actions", n)
+		return
+	}
+	a.confirmChaos(ctxName, r)
+}
+
+func (a *App) chaosMenu(d time.Duration) {
+	oo := make([]string, 0, len(chaosActions))
+	for _, c := range chaosActions {
+		oo = append(oo, c.desc)
+	}
+	ctxName := a.Config.K9s.ActiveContextName()
+	st := a.Styles.Dialog()
+	dialog.ShowSelection(&st, a.Content.Pages, "Chaos", oo, func(i int) {
+		r := chaosRequest{action: chaosActions[i].name, duration: d}
+		if r.action != chaosNode {
+			r.target = a.selectedDeployment()
+			if r.target == "" {
+				a.Flash().Warn("Select a deployment first or use `chaos ACTION DEPLOY`")
+				return
+			}
+		}
+		a.confirmChaos(ctxName, r)
+	})
+}
+
+// selectedDeployment returns the selected deployment of the current view if any.
+func (a *App) selectedDeployment() string {
+	v, ok := a.Content.Top().(ResourceViewer)
+	if !ok || v.GVR() != client.DpGVR {
+		return ""
+	}
+
+	return v.GetTable().GetSelectedItem()
+}
+
+func (a *App) confirmChaos(ctxName string, r chaosRequest) {
+	var msg string
+	switch r.action {
+	case chaosPod:
+		msg = fmt.Sprintf("Delete a random pod of deployment %s on %s?", r.target, ctxName)
+	case chaosNode:
+		msg = fmt.Sprintf("Cordon a random worker node of %s for %s?", ctxName, r.duration)
+	case chaosNetDeny:
+		msg = fmt.Sprintf("Deny all network traffic of deployment %s on %s for %s?", r.target, ctxName, r.duration)
+	}
+	d := a.Styles.Dialog()
+	dialog.ShowConfirm(&d, a.Content.Pages, "Confirm Chaos", msg, func() {
+		rawCfg, err := a.Conn().Config().RawConfig()
+		if err != nil {
+			a.Flash().Err(err)
+			return
+		}
+		dial, err := dao.ChaosClient(rawCfg, ctxName)
+		if err != nil {
+			a.Flash().Err(err)
+			return
+		}
+		go a.runChaos(dial, ctxName, r)
+	}, func() {})
+}
+
+func (a *App) runChaos(dial kubernetes.Interface, ctxName string, r chaosRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), chaosDeadline)
+	defer cancel()
+
+	var (
+		target string
+		revert func(context.Context) error
+		err    error
+	)
+	switch r.action {
+	case chaosPod:
+		target, err = dao.DeleteRandomPod(ctx, dial, r.target)
+	case chaosNode:
+		target, err = dao.CordonRandomNode(ctx, dial)
+		revert = chaosRevertFn(dial, r.action, target)
+	case chaosNetDeny:
+		target, err = dao.DenyNetwork(ctx, dial, r.target)
+		revert = chaosRevertFn(dial, r.action, target)
+	}
+	if err != nil {
+		chaosAudit(ctxName, r.action, r.target, "failed: "+err.Error())
+		a.QueueUpdateDraw(func() {
+			a.Flash().Errf("Chaos %s failed: %s", r.action, err)
+		})
+		return
+	}
+	chaosAudit(ctxName, r.action, target, "done")
+	if revert == nil {
+		a.QueueUpdateDraw(func() {
+			a.Flash().Infof("Chaos %s: deleted %s", r.action, target)
+		})
+		return
+	}
+	a.scheduleChaosRevert(&chaosReversal{context: ctxName, action: r.action, target: target, revert: revert}, r.duration)
+	a.QueueUpdateDraw(func() {
+		a.Flash().Infof("Chaos %s: %s, reverting in %s", r.action, target, r.duration)
+	})
+}
+
+// chaosRevertFn returns the reversal of a chaos action on a target.
+func chaosRevertFn(dial kubernetes.Interface, action, target string) func(context.Context) error {
+	switch action {
+	case chaosNode:
+		return func(ctx context.Context) error {
+			return dao.SetNodeUnschedulable(ctx, dial, target, false)
+		}
+	case chaosNetDeny:
+		return func(ctx context.Context) error {
+			return dao.RemoveNetworkDeny(ctx, dial, target)
+		}
+	default:
+		return func(context.Context) error {
+			return fmt.Errorf("unknown chaos action %q", action)
+		}
+	}
+}
+
+func (a *App) scheduleChaosRevert(r *chaosReversal, d time.Duration) {
+	a.chaosMx.Lock()
+	defer a.chaosMx.Unlock()
+
+	r.due = time.Now().Add(d)
+	r.timer = time.AfterFunc(d, func() {
+		if !a.dropChaosRevert(r) {
+			return
+		}
+		err := r.run()
+		if err != nil {
+			a.keepChaosReverts(r)
+		}
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.Flash().Errf("Chaos %s revert of %s failed: %s", r.action, r.target, err)
+				return
+			}
+			a.Flash().Infof("Chaos %s reverted on %s", r.action, r.target)
+		})
+	})
+	a.chaosReverts = append(a.chaosReverts, r)
+	a.saveChaosRevertsLocked()
+}
+
+// keepChaosReverts tracks reversals to run on `:chaos revert` or exit.
+func (a *App) keepChaosReverts(rr ...*chaosReversal) {
+	a.chaosMx.Lock()
+	defer a.chaosMx.Unlock()
+
+	a.chaosReverts = append(a.chaosReverts, rr...)
+	a.saveChaosRevertsLocked()
+}
+
+// dropChaosRevert removes a pending reversal and returns false if it already ran.
+func (a *App) dropChaosRevert(r *chaosReversal) bool {
+	a.chaosMx.Lock()
+	defer a.chaosMx.Unlock()
+
+	for i, rr := range a.chaosReverts {
+		if rr == r {
+			a.chaosReverts = append(a.chaosReverts[:i], a.chaosReverts[i+1:]...)
+			a.saveChaosRevertsLocked()
+			return true
+		}
+	}
+
+	return false
+}
+
+// revertChaos reverts all pending chaos actions now. Failed reversals stay
+// pending.
+func (a *App) revertChaos() int {
+	a.chaosMx.Lock()
+	rr := a.chaosReverts
+	a.chaosReverts = nil
+	a.chaosMx.Unlock()
+
+	var failed []*chaosReversal
+	for _, r := range rr {
+		if r.timer != nil {
+			r.timer.Stop()
+		}
+		if err := r.run(); err != nil {
+			slogs.CtxLog(r.context).Error("Chaos revert failed",
+				slogs.FQN, r.target,
+				slogs.Error, err,
+			)
+			failed = append(failed, r)
+		}
+	}
+	a.keepChaosReverts(failed...)
+
+	return len(rr) - len(failed)
+}
+
+// saveChaosRevertsLocked persists the pending reversals so a crash does not
+// leave chaos in place. chaosMx must be held.
+func (a *App) saveChaosRevertsLocked() {
+	path, err := config.ChaosPendingFile()
+	if err == nil {
+		pp := make([]config.ChaosPending, 0, len(a.chaosReverts))
+		for _, r := range a.chaosReverts {
+			pp = append(pp, config.ChaosPending{Context: r.context, Action: r.action, Target: r.target, Due: r.due})
+		}
+		err = config.SaveChaosPending(path, pp)
+	}
+	if err != nil {
+		slog.Error("Unable to save pending chaos reversals", slogs.Error, err)
+	}
+}
+
+// offerChaosReverts offers to revert the chaos actions a previous session
+// left pending. Declined reversals run on `:chaos revert` or exit.
+func (a *App) offerChaosReverts() {
+	path, err := config.ChaosPendingFile()
+	var pp []config.ChaosPending
+	if err == nil {
+		pp, err = config.LoadChaosPending(path)
+	}
+	if err != nil {
+		slog.Error("Unable to load pending chaos reversals", slogs.Error, err)
+		return
+	}
+	if len(pp) == 0 {
+		return
+	}
+	rr := make([]*chaosReversal, 0, len(pp))
+	tt := make([]string, 0, len(pp))
+	for _, p := range pp {
+		rr = append(rr, &chaosReversal{
+			context: p.Context,
+			action:  p.Action,
+			target:  p.Target,
+			due:     p.Due,
+			revert:  a.pendingChaosRevert(p),
+		})
+		tt = append(tt, fmt.Sprintf("%s %s on %s", p.Action, p.Target, p.Context))
+	}
+	a.keepChaosReverts(rr...)
+
+	msg := fmt.Sprintf("A previous session left %d chaos action(s) pending: %s. Revert them now?", len(pp), strings.Join(tt, ", "))
+	d := a.Styles.Dialog()
+	dialog.ShowConfirm(&d, a.Content.Pages, "Pending Chaos", msg, func() {
+		go func() {
+			n := a.revertChaos()
+			a.QueueUpdateDraw(func() {
+				a.Flash().Infof("Reverted %d chaos actions", n)
+			})
+		}()
+	}, func() {
+		a.Flash().Warn("Pending chaos actions are reverted on `:chaos revert` or exit")
+	})
+}
+
+// pendingChaosRevert returns the reversal of a chaos action persisted by a
+// previous session, dialing its context when run.
+func (a *App) pendingChaosRevert(p config.ChaosPending) func(context.Context) error {
+	return func(ctx context.Context) error {
+		rawCfg, err := a.Conn().Config().RawConfig()
+		if err != nil {
+			return err
+		}
+		dial, err := dao.ChaosClient(rawCfg, p.Context)
+		if err != nil {
+			return err
+		}
+
+		return chaosRevertFn(dial, p.Action, p.Target)(ctx)
+	}
+}
+
+func (r *chaosReversal) run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), chaosDeadline)
+	defer cancel()
+
+	err := r.revert(ctx)
+	result := "reverted"
+	if err != nil {
+		result = "revert failed: " + err.Error()
+	}
+	chaosAudit(r.context, r.action, r.target, result)
+
+	return err
+}
+
+func chaosAudit(ctxName, action, target, result string) {
+	path, err := config.ChaosAuditFile()
+	if err == nil {
+		err = config.AppendChaosAudit(path, config.ChaosAuditEntry{
+			At:      time.Now(),
+			Context: ctxName,
+			Action:  action,
+			Target:  target,
+			Result:  result,
+		})
+	}
+	if err != nil {
+		slog.Error("Unable to write chaos audit entry", slogs.Error, err)
+	}
+}
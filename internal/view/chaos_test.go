@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChaosArgs(t *testing.T) {
+	uu := map[string]struct {
+		arg string
+		e   chaosRequest
+		err string
+	}{
+		"empty": {
+			err: "missing chaos action",
+		},
+		"pod": {
+			arg: "pod fred",
+			e:   chaosRequest{action: chaosPod, target: "ns1/fred", duration: 5 * time.Minute},
+		},
+		"pod-fqn": {
+			arg: "pod ns2/fred",
+			e:   chaosRequest{action: chaosPod, target: "ns2/fred", duration: 5 * time.Minute},
+		},
+		"pod-no-target": {
+			arg: "pod",
+			err: "chaos pod expects a deployment",
+		},
+		"pod-duration": {
+			arg: "pod fred 1m",
+			err: "too many arguments for chaos pod",
+		},
+		"node": {
+			arg: "node",
+			e:   chaosRequest{action: chaosNode, duration: 5 * time.Minute},
+		},
+		"node-duration": {
+			arg: "node 2m",
+			e:   chaosRequest{action: chaosNode, duration: 2 * time.Minute},
+		},
+		"netdeny": {
+			arg: "netdeny fred 10m",
+			e:   chaosRequest{action: chaosNetDeny, target: "ns1/fred", duration: 10 * time.Minute},
+		},
+		"netdeny-bad-duration": {
+			arg: "netdeny fred soon",
+			err: `invalid chaos duration "soon"`,
+		},
+		"revert": {
+			arg: "revert",
+			e:   chaosRequest{action: chaosRevert, duration: 5 * time.Minute},
+		},
+		"unknown": {
+			arg: "dns",
+			err: `unknown chaos action "dns"`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			r, err := parseChaosArgs(u.arg, "ns1", 5*time.Minute)
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, r)
+		})
+	}
+}
@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/k9s/internal/view/cmd"
+)
+
+const (
+	checkTick     = time.Minute
+	checkDeadline = 2 * time.Minute
+	inboxTitle    = "Inbox"
+	unreadMarker  = "●"
+)
+
+// startChecks runs the configured checks in the background on their schedule
+// until the app exits. Checks are paused in low power mode.
+func (a *App) startChecks() {
+	go func() {
+		next := make(map[string]time.Time)
+		for {
+			now, cc := time.Now(), a.Config.K9s.Checks
+			if a.Config.K9s.IsLowPower() {
+				cc = nil
+			}
+			for _, c := range cc {
+				if err := c.Validate(); err != nil {
+					slog.Warn("Skipping invalid check", slogs.Error, err)
+					continue
+				}
+				if now.Before(next[c.Name]) {
+					continue
+				}
+				every, _ := c.Interval()
+				next[c.Name] = now.Add(every)
+				go a.runCheck(c)
+			}
+			select {
+			case <-a.appCtx.Done():
+				return
+			case <-time.After(checkTick):
+			}
+		}
+	}()
+}
+
+func (a *App) runCheck(c config.Check) {
+	if a.Conn() == nil || !a.Conn().ConnectionOK() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(a.appCtx, checkDeadline)
+	defer cancel()
+
+	ctxs := []string{a.Config.K9s.ActiveContextName()}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		ctxs = sel
+	}
+	var rr []model.CheckResult
+	switch kind, _ := c.Kind(); kind {
+	case config.CheckCount:
+		rr = a.countCheck(ctx, c, ctxs)
+	case config.CheckPlugin:
+		rr = a.pluginCheck(ctx, c, ctxs)
+	case config.CheckDiag:
+		rr = []model.CheckResult{a.diagCheck(ctx, c, ctxs)}
+	}
+
+	var n int
+	for _, r := range rr {
+		r.Check, r.At = c.Name, time.Now()
+		if a.inbox.Add(r) {
+			n++
+		}
+	}
+	if n > 0 {
+		a.QueueUpdateDraw(func() {
+			a.Flash().Warnf("%d unread check results, see :inbox", a.inbox.Unread())
+		})
+	}
+}
+
+// countCheck counts the resources matching a view command, ie `pods /crash`,
+// and fails when a context goes over the check max.
+func (a *App) countCheck(ctx context.Context, c config.Check, ctxs []string) []model.CheckResult {
+	p := cmd.NewInterpreter(c.Count)
+	gvr, _, _, err := a.command.viewMetaFor(p)
+	if err != nil {
+		return checkErrors(ctxs, err)
+	}
+	ns, _ := p.NSArg()
+	var lbls string
+	if sel, err := p.LabelsSelector(); err == nil && !sel.Empty() {
+		lbls = sel.String()
+	}
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return checkErrors(ctxs, err)
+	}
+	oo, err := dao.MultiContextList(ctx, rawCfg, ctxs, gvr.GVR(), ns, lbls)
+	failed := dao.FailedContexts(err)
+	if err != nil && failed == nil {
+		return checkErrors(ctxs, err)
+	}
+	f, _ := p.FilterArg()
+	counts := dao.MatchCounts(oo, ctxs, f)
+
+	rr := make([]model.CheckResult, 0, len(ctxs))
+	for _, ct := range ctxs {
+		if err, ok := failed[ct]; ok {
+			rr = append(rr, checkErrors([]string{ct}, err)...)
+			continue
+		}
+		rr = append(rr, model.CheckResult{
+			Context: ct,
+			Failed:  counts[ct] > c.Max,
+			Summary: fmt.Sprintf("%d matching %s (max %d)", counts[ct], c.Count, c.Max),
+		})
+	}
+
+	return rr
+}
+
+// pluginCheck runs a plugin on each context and fails when it exits non zero.
+// Dangerous plugins are refused on read-only or gated contexts.
+func (a *App) pluginCheck(ctx context.Context, c config.Check, ctxs []string) []model.CheckResult {
+	rr := make([]model.CheckResult, 0, len(ctxs))
+	for _, ct := range ctxs {
+		p, err := a.automatedPlugin(c.Plugin, ct)
+		if err != nil {
+			rr = append(rr, checkErrors([]string{ct}, err)...)
+			continue
+		}
+		env := Env{"CONTEXT": ct, "CLUSTER": ct, "CONTEXTS": strings.Join(ctxs, ",")}
+		args := make([]string, 0, len(p.Args))
+		for _, arg := range p.Args {
+			if s, err := env.Substitute(arg); err == nil {
+				arg = s
+			}
+			args = append(args, arg)
+		}
+		bin, args := a.pluginCommand(&p, args)
+		out, err := oneShoot(ctx, &shellOpts{binary: bin, args: args})
+		r := model.CheckResult{Context: ct, Output: out, Summary: lastLine(out)}
+		if err != nil {
+			r.Failed, r.Summary = true, err.Error()
+		}
+		rr = append(rr, r)
+	}
+
+	return rr
+}
+
+// diagCheck runs a diagnostic and fails when it reports issues.
+func (a *App) diagCheck(ctx context.Context, c config.Check, ctxs []string) model.CheckResult {
+	r := model.CheckResult{Context: strings.Join(ctxs, ",")}
+	fn, ok := diagnostics[c.Diag]
+	if !ok {
+		r.Failed, r.Summary = true, fmt.Sprintf("unknown diagnostic %q", c.Diag)
+		return r
+	}
+	out, err := fn(ctx, a, client.BlankNamespace, "")
+	r.Output, r.Summary = out, "no issues"
+	if err != nil {
+		r.Failed, r.Summary = true, err.Error()
+		return r
+	}
+	var ii []string
+	for _, l := range strings.Split(out, "\n") {
+		if strings.HasPrefix(l, "! ") {
+			ii = append(ii, strings.TrimPrefix(l, "! "))
+		}
+	}
+	if len(ii) > 0 {
+		r.Failed, r.Summary = true, fmt.Sprintf("%d issues: %s", len(ii), ii[0])
+	}
+
+	return r
+}
+
+// lookupPlugin returns a configured plugin by name.
+func (a *App) lookupPlugin(name string) (config.Plugin, error) {
+	pp := config.NewPlugins()
+	if path, err := a.Config.ContextPluginsPath(); err == nil {
+		if err := pp.Load(path, true); err != nil {
+			slog.Warn("Plugins load failed", slogs.Error, err)
+		}
+	}
+	p, ok := pp.Plugins[name]
+	if !ok {
+		return config.Plugin{}, fmt.Errorf("unknown plugin %q", name)
+	}
+
+	return p, nil
+}
+
+// automatedPlugin returns a plugin run without user input on a context.
+// Dangerous plugins are refused on read-only or gated contexts.
+func (a *App) automatedPlugin(name, ctxName string) (config.Plugin, error) {
+	p, err := a.lookupPlugin(name)
+	if err != nil || !p.Dangerous {
+		return p, err
+	}
+	if err := a.checkContextWritable(ctxName); err != nil {
+		return config.Plugin{}, fmt.Errorf("dangerous plugin %q refused: %w", name, err)
+	}
+
+	return p, nil
+}
+
+func checkErrors(ctxs []string, err error) []model.CheckResult {
+	rr := make([]model.CheckResult, 0, len(ctxs))
+	for _, ctx := range ctxs {
+		rr = append(rr, model.CheckResult{Context: ctx, Failed: true, Summary: err.Error()})
+	}
+
+	return rr
+}
+
+func lastLine(s string) string {
+	ll := strings.Split(strings.TrimSpace(s), "\n")
+
+	return strings.TrimSpace(ll[len(ll)-1])
+}
+
+// inboxCmd shows the check results and marks them read.
+func (a *App) inboxCmd() {
+	rr, unread := a.inbox.Results(), a.inbox.Unread()
+	var b strings.Builder
+	if len(a.Config.K9s.Checks) == 0 {
+		b.WriteString("No checks configured. Add `checks` to your config to schedule background checks.\n")
+	} else if len(rr) == 0 {
+		b.WriteString("No results yet, checks only report failures and recoveries.\n")
+	}
+	for _, r := range rr {
+		marker, state := " ", "OK"
+		if r.Unread {
+			marker = unreadMarker
+		}
+		if r.Failed {
+			state = "FAILED"
+		}
+		fmt.Fprintf(&b, "%s %s %-6s %s  %s\n", marker, r.At.Format(time.DateTime), state, r.ID(), r.Summary)
+		if out := strings.TrimSpace(r.Output); out != "" && r.Failed {
+			for _, l := range strings.Split(out, "\n") {
+				fmt.Fprintf(&b, "    %s\n", l)
+			}
+		}
+	}
+	a.inbox.MarkRead()
+
+	subject := fmt.Sprintf("%d results, %d unread", len(rr), unread)
+	details := NewDetails(a, inboxTitle, subject, contentTXT, true).Update(b.String())
+	if err := a.inject(details, false); err != nil {
+		a.Flash().Err(err)
+	}
+}
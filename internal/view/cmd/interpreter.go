@@ -18,6 +18,7 @@ type Interpreter struct {
 	cmd     string
 	aliases []string
 	args    args
+	pipe    string
 }
 
 // NewInterpreter returns a new instance.
@@ -60,6 +61,10 @@ func (c *Interpreter) Merge(p *Interpreter) {
 }
 
 func (c *Interpreter) grok() {
+	c.pipe = ""
+	if line, pipe, ok := strings.Cut(c.line, pipeFlag); ok {
+		c.line, c.pipe = strings.TrimSpace(line), strings.TrimSpace(pipe)
+	}
 	ff := strings.Fields(c.line)
 	if len(ff) == 0 {
 		return
@@ -220,6 +225,116 @@ func (c *Interpreter) Rk9sDashArg() string {
 	return c.cmd
 }
 
+// IsRancherAPICmd returns true if a Rancher API view cmd is detected.
+func (c *Interpreter) IsRancherAPICmd() bool {
+	return rancherAPICmd.Has(c.cmd)
+}
+
+// IsDiagCmd returns true if a diagnostic cmd is detected.
+func (c *Interpreter) IsDiagCmd() bool {
+	return diagCmd.Has(c.cmd)
+}
+
+// IsWorkflowCmd returns true if a guided workflow cmd is detected.
+func (c *Interpreter) IsWorkflowCmd() bool {
+	return workflowCmd.Has(c.cmd)
+}
+
+// IsMacroCmd returns true if a macro cmd is detected.
+func (c *Interpreter) IsMacroCmd() bool {
+	return macroCmd.Has(c.cmd)
+}
+
+// IsInboxCmd returns true if the check results inbox cmd is detected.
+func (c *Interpreter) IsInboxCmd() bool {
+	return inboxCmd.Has(c.cmd)
+}
+
+// IsSyncCmd returns true if the config sync cmd is detected.
+func (c *Interpreter) IsSyncCmd() bool {
+	return c.cmd == syncCmd
+}
+
+// IsNotesCmd returns true if the team notes cmd is detected.
+func (c *Interpreter) IsNotesCmd() bool {
+	return c.cmd == notesCmd
+}
+
+// IsCaptureCmd returns true if the watch recording cmd is detected.
+func (c *Interpreter) IsCaptureCmd() bool {
+	return c.cmd == captureCmd
+}
+
+// IsA11yCmd returns true if the screen reader dump cmd is detected.
+func (c *Interpreter) IsA11yCmd() bool {
+	return c.cmd == a11yCmd
+}
+
+// IsPreviewCmd returns true if the preview pane cmd is detected.
+func (c *Interpreter) IsPreviewCmd() bool {
+	return c.cmd == previewCmd
+}
+
+// IsCanaryCmd returns true if the canary comparison cmd is detected.
+func (c *Interpreter) IsCanaryCmd() bool {
+	return c.cmd == canaryCmd
+}
+
+// IsJanitorCmd returns true if the stale resources cleanup cmd is detected.
+func (c *Interpreter) IsJanitorCmd() bool {
+	return c.cmd == janitorCmd
+}
+
+// IsTimelineCmd returns true if the namespace timeline cmd is detected.
+func (c *Interpreter) IsTimelineCmd() bool {
+	return c.cmd == timelineCmd
+}
+
+// IsGatesCmd returns true if the context gates cmd is detected.
+func (c *Interpreter) IsGatesCmd() bool {
+	return c.cmd == gatesCmd
+}
+
+// IsScreencapCmd returns true if the screen capture cmd is detected.
+func (c *Interpreter) IsScreencapCmd() bool {
+	return c.cmd == screencapCmd
+}
+
+// IsLowPowerCmd returns true if the low power mode cmd is detected.
+func (c *Interpreter) IsLowPowerCmd() bool {
+	return c.cmd == lowPowerCmd
+}
+
+// IsSelfLogCmd returns true if the rk9s logs cmd is detected.
+func (c *Interpreter) IsSelfLogCmd() bool {
+	return c.cmd == selfLogCmd
+}
+
+// IsWaitCmd returns true if the wait for condition cmd is detected.
+func (c *Interpreter) IsWaitCmd() bool {
+	return c.cmd == waitCmd
+}
+
+// IsReplayCmd returns true if the watch replay cmd is detected.
+func (c *Interpreter) IsReplayCmd() bool {
+	return c.cmd == replayCmd
+}
+
+// IsChaosCmd returns true if the chaos cmd is detected.
+func (c *Interpreter) IsChaosCmd() bool {
+	return c.cmd == chaosCmd
+}
+
+// IsMcCmd returns true if the multi-context kubectl cmd is detected.
+func (c *Interpreter) IsMcCmd() bool {
+	return c.cmd == mcCmd
+}
+
+// IsTermCmd returns true if the terminal pane cmd is detected.
+func (c *Interpreter) IsTermCmd() bool {
+	return c.cmd == termCmd
+}
+
 // IsRBACCmd returns true if rbac cmd is detected.
 func (c *Interpreter) IsRBACCmd() bool {
 	return c.cmd == canCmd
@@ -303,6 +418,11 @@ func (c *Interpreter) FilterArg() (string, bool) {
 	return f, ok && f != ""
 }
 
+// PipeArg returns the command the view rows are piped to if any.
+func (c *Interpreter) PipeArg() (string, bool) {
+	return c.pipe, c.pipe != ""
+}
+
 // FuzzyArg returns the fuzzy filter if any.
 func (c *Interpreter) FuzzyArg() (string, bool) {
 	f, ok := c.args[fuzzyKey]
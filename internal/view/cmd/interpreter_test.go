@@ -705,3 +705,53 @@ func Test_grokLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestPipeCmd(t *testing.T) {
+	uu := map[string]struct {
+		cmd, line, pipe string
+		ok              bool
+		filter          string
+	}{
+		"empty": {},
+
+		"none": {
+			cmd:    "pod /fred",
+			line:   "pod /fred",
+			filter: "fred",
+		},
+
+		"plugin": {
+			cmd:    "pods /crash | restart-report",
+			line:   "pods /crash",
+			pipe:   "restart-report",
+			ok:     true,
+			filter: "crash",
+		},
+
+		"command": {
+			cmd:  "pods kube-system | jq -r .[].NAME",
+			line: "pods kube-system",
+			pipe: "jq -r .[].NAME",
+			ok:   true,
+		},
+
+		"regex": {
+			cmd:    "pods /crash|oom",
+			line:   "pods /crash|oom",
+			filter: "crash|oom",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			p := cmd.NewInterpreter(u.cmd)
+			pipe, ok := p.PipeArg()
+			assert.Equal(t, u.ok, ok)
+			assert.Equal(t, u.pipe, pipe)
+			assert.Equal(t, u.line, p.GetLine())
+			f, _ := p.FilterArg()
+			assert.Equal(t, u.filter, f)
+		})
+	}
+}
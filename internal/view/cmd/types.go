@@ -12,6 +12,23 @@ import (
 const (
 	cowCmd         = "cow"
 	canCmd         = "can"
+	syncCmd        = "sync"
+	notesCmd       = "notes"
+	captureCmd     = "capture"
+	replayCmd      = "replay"
+	chaosCmd       = "chaos"
+	mcCmd          = "mc"
+	termCmd        = "term"
+	a11yCmd        = "a11y"
+	previewCmd     = "preview"
+	canaryCmd      = "compare"
+	timelineCmd    = "timeline"
+	gatesCmd       = "gates"
+	screencapCmd   = "screencap"
+	lowPowerCmd    = "lowpower"
+	selfLogCmd     = "selflog"
+	waitCmd        = "wait"
+	janitorCmd     = "janitor"
 	nsFlag         = "-n"
 	filterFlag     = "/"
 	labelFlagEq    = "="
@@ -23,6 +40,7 @@ const (
 	label
 	fuzzyFlag   = "-f"
 	contextFlag = "@"
+	pipeFlag    = " | "
 )
 
 var (
@@ -83,4 +101,40 @@ var (
 		"home",
 		"rke2k3s",
 	)
+	rancherAPICmd = sets.New(
+		"rancher-audit",
+		"rancher-drivers",
+		"rancher-sessions",
+	)
+	diagCmd = sets.New(
+		"pullcheck",
+		"dns",
+		"timeskew",
+		"mirrors",
+		"encryption",
+		"dsgaps",
+		"crdversions",
+		"preemptions",
+		"deprecations",
+		"scmatrix",
+		"gvrmatrix",
+		"agentreach",
+		"lhbackups",
+		"prime",
+		"helmdrift",
+	)
+	workflowCmd = sets.New(
+		"rotate-encryption",
+		"rotate-certs",
+		"rotate-token",
+		"migrate-crd",
+	)
+
+	macroCmd = sets.New(
+		"macro",
+		"macros",
+		"record",
+	)
+
+	inboxCmd = sets.New("inbox", "checks")
 )
@@ -12,10 +12,12 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/model"
 	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/k9s/internal/ui"
 	"github.com/derailed/k9s/internal/view/cmd"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -34,9 +36,10 @@ var (
 
 // Command represents a user command.
 type Command struct {
-	app   *App
-	alias *dao.Alias
-	mx    sync.Mutex
+	app     *App
+	alias   *dao.Alias
+	visited map[string]struct{}
+	mx      sync.Mutex
 }
 
 // NewCommand returns a new command.
@@ -116,7 +119,7 @@ func (c *Command) contextCmd(p *cmd.Interpreter, pushCmd bool) error {
 	}
 
 	if ct != "" {
-		return useContext(c.app, ct)
+		return useContext(c.app, ct, nil)
 	}
 
 	gvr, v, comd, err := c.viewMetaFor(p)
@@ -184,6 +187,7 @@ func (c *Command) xrayCmd(p *cmd.Interpreter, pushCmd bool) error {
 
 // Run execs the command by showing associated display.
 func (c *Command) run(p *cmd.Interpreter, fqn string, clearStack, pushCmd bool) error {
+	pipe, piped := p.PipeArg()
 	if c.specialCmd(p, pushCmd) {
 		return nil
 	}
@@ -197,6 +201,9 @@ func (c *Command) run(p *cmd.Interpreter, fqn string, clearStack, pushCmd bool)
 
 	if context, ok := p.HasContext(); ok {
 		if context != c.app.Config.ActiveContextName() {
+			if c.app.deferToHooks(context, func() error { return c.run(p, fqn, clearStack, pushCmd) }) {
+				return nil
+			}
 			if err := c.app.Config.Save(true); err != nil {
 				slog.Error("Config save failed during command exec", slogs.Error, err)
 			} else {
@@ -220,9 +227,13 @@ func (c *Command) run(p *cmd.Interpreter, fqn string, clearStack, pushCmd bool)
 		}
 	}
 
+	st, _ := c.app.Config.ViewState(gvr.String())
+	first := c.firstVisit(c.app.Config.ActiveContextName(), gvr)
 	ns := c.app.Config.ActiveNamespace()
 	if cns, ok := p.NSArg(); ok {
 		ns = cns
+	} else if first && st.Namespace != "" && c.app.Conn() != nil && c.app.Conn().IsValidNamespace(client.CleanseNamespace(st.Namespace)) {
+		ns = st.Namespace
 	}
 	if ok, err := dao.MetaAccess.IsNamespaced(gvr); ok && err == nil {
 		if err := c.app.switchNS(ns); err != nil {
@@ -234,6 +245,7 @@ func (c *Command) run(p *cmd.Interpreter, fqn string, clearStack, pushCmd bool)
 	}
 
 	co := c.componentFor(gvr, fqn, v)
+	co.GetTable().SetPersistent(true)
 	co.SetFilter("", true)
 	co.SetLabelSelector(labels.Everything(), true)
 	if f, ok := p.FilterArg(); ok {
@@ -247,8 +259,18 @@ func (c *Command) run(p *cmd.Interpreter, fqn string, clearStack, pushCmd bool)
 	} else {
 		slog.Error("Unable to grok labels selector", slogs.Error, err)
 	}
+	if !hasFilterArgs(p) && st.Filter != "" {
+		restoreFilter(co, st.Filter)
+	}
+
+	if err := c.exec(p, gvr, co, clearStack, pushCmd); err != nil {
+		return err
+	}
+	if piped {
+		c.app.pipeCmd(co, pipe)
+	}
 
-	return c.exec(p, gvr, co, clearStack, pushCmd)
+	return nil
 }
 
 func (c *Command) defaultCmd(isRoot bool) error {
@@ -319,6 +341,50 @@ func (c *Command) specialCmd(p *cmd.Interpreter, pushCmd bool) bool {
 		c.app.rk9sCmd()
 	case p.IsRk9sDashCmd():
 		c.app.rk9sDashboard(p.Rk9sDashArg())
+	case p.IsRancherAPICmd():
+		c.app.rancherAPICmd(p.Cmd())
+	case p.IsDiagCmd():
+		c.app.diagCmd(p.Cmd(), p.Args())
+	case p.IsWorkflowCmd():
+		c.app.workflowCmd(p.Cmd(), p.Args())
+	case p.IsMacroCmd():
+		c.app.macroCmd(p.Cmd(), p.Args())
+	case p.IsInboxCmd():
+		c.app.inboxCmd()
+	case p.IsSyncCmd():
+		c.app.syncCmd(p.Args())
+	case p.IsNotesCmd():
+		c.app.notesCmd(p.Args())
+	case p.IsCaptureCmd():
+		c.app.captureCmd(p.Args())
+	case p.IsReplayCmd():
+		c.app.replayCmd(p.Args())
+	case p.IsChaosCmd():
+		c.app.chaosCmd(p.Args())
+	case p.IsMcCmd():
+		c.app.mcCmd(p.Args())
+	case p.IsTermCmd():
+		c.app.termCmd(p.Args())
+	case p.IsA11yCmd():
+		c.app.a11yCmd(p.Args())
+	case p.IsPreviewCmd():
+		c.app.previewCmd(p.Args())
+	case p.IsCanaryCmd():
+		c.app.canaryCmd(p.Args())
+	case p.IsJanitorCmd():
+		c.app.janitorCmd(p.Args())
+	case p.IsTimelineCmd():
+		c.app.nsTimelineCmd(p.Args())
+	case p.IsGatesCmd():
+		c.app.gatesCmd()
+	case p.IsScreencapCmd():
+		c.app.screencapCmd(p.Args())
+	case p.IsLowPowerCmd():
+		c.app.lowPowerCmd(p.Args())
+	case p.IsSelfLogCmd():
+		c.app.selfLogCmd(p.Args())
+	case p.IsWaitCmd():
+		c.app.waitCmd(p.Args())
 	default:
 		return false
 	}
@@ -399,3 +465,56 @@ func (c *Command) exec(p *cmd.Interpreter, gvr *client.GVR, comp model.Component
 
 	return
 }
+
+func hasFilterArgs(p *cmd.Interpreter) bool {
+	if _, ok := p.FilterArg(); ok {
+		return true
+	}
+	if _, ok := p.FuzzyArg(); ok {
+		return true
+	}
+	sel, err := p.LabelsSelector()
+
+	return err == nil && !sel.Empty()
+}
+
+// firstVisit checks if a resource view is visited for the first time on a
+// context since rk9s started.
+func (c *Command) firstVisit(ctxName string, gvr *client.GVR) bool {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if c.visited == nil {
+		c.visited = make(map[string]struct{})
+	}
+	k := ctxName + "|" + gvr.String()
+	if _, ok := c.visited[k]; ok {
+		return false
+	}
+	c.visited[k] = struct{}{}
+
+	return true
+}
+
+// restoreFilter reapplies the last filter of a view, fuzzy, label and field
+// selectors included.
+func restoreFilter(co ResourceViewer, f string) {
+	if q, ok := internal.IsFuzzySelector(f); ok {
+		f = "-f " + q
+	}
+	co.SetFilter(f, true)
+	switch {
+	case internal.IsLabelSelector(f):
+		if sel, err := ui.ExtractLabelSelector(f); err == nil {
+			co.GetTable().GetModel().SetLabelSelector(sel)
+		}
+	case internal.IsFieldSelector(f):
+		m, ok := co.GetTable().GetModel().(ui.FieldSelectable)
+		if !ok {
+			return
+		}
+		if sel, err := ui.ExtractFieldSelector(f); err == nil {
+			m.SetFieldSelector(sel.String())
+		}
+	}
+}
@@ -82,3 +82,45 @@ func Test_viewMetaFor(t *testing.T) {
 		})
 	}
 }
+
+func Test_hasFilterArgs(t *testing.T) {
+	uu := map[string]struct {
+		cmd string
+		e   bool
+	}{
+		"none": {
+			cmd: "pods",
+		},
+		"namespace": {
+			cmd: "pods fred",
+		},
+		"filter": {
+			cmd: "pods /fred",
+			e:   true,
+		},
+		"fuzzy": {
+			cmd: "pods -f fred",
+			e:   true,
+		},
+		"labels": {
+			cmd: "pods app=fred",
+			e:   true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, hasFilterArgs(cmd.NewInterpreter(u.cmd)))
+		})
+	}
+}
+
+func TestCommandFirstVisit(t *testing.T) {
+	c := NewCommand(nil)
+
+	assert.True(t, c.firstVisit("c1", client.PodGVR))
+	assert.False(t, c.firstVisit("c1", client.PodGVR))
+	assert.True(t, c.firstVisit("c2", client.PodGVR))
+	assert.True(t, c.firstVisit("c1", client.DpGVR))
+}
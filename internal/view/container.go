@@ -96,7 +96,7 @@ func (c *Container) bindKeys(aa *ui.KeyActions) {
 func (c *Container) k9sEnv() Env {
 	path := c.GetTable().GetSelectedItem()
 	row := c.GetTable().GetSelectedRow(path)
-	env := defaultEnv(c.App().Conn().Config(), path, c.GetTable().GetModel().Peek().Header(), row)
+	env := redactEnv(c.App().redactor(), defaultEnv(c.App().Conn().Config(), path, c.GetTable().GetModel().Peek().Header(), row))
 	env["NAMESPACE"], env["POD"] = client.Namespaced(c.GetTable().Path)
 
 	return env
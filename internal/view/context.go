@@ -65,6 +65,7 @@ func (c *Context) toggleSelectCtx(evt *tcell.EventKey) *tcell.EventKey {
 	if selected == nil {
 		selected = []string{}
 	}
+	before := slices.Clone(selected)
 	if idx := slices.Index(selected, ctxName); idx >= 0 {
 		selected = append(selected[:idx], selected[idx+1:]...)
 		c.App().Flash().Infof("Deselected context %q", ctxName)
@@ -78,6 +79,7 @@ func (c *Context) toggleSelectCtx(evt *tcell.EventKey) *tcell.EventKey {
 		return nil
 	}
 	c.App().Flash().Infof("%d context(s) selected for multi-context operations", len(selected))
+	c.App().releaseDeselected(before, selected)
 	c.Refresh()
 	return nil
 }
@@ -102,11 +104,13 @@ func (c *Context) selectAllCtx(evt *tcell.EventKey) *tcell.EventKey {
 }
 
 func (c *Context) selectNoneCtx(evt *tcell.EventKey) *tcell.EventKey {
+	before, _ := config.LoadSelectedContexts()
 	if err := config.SaveSelectedContexts(nil); err != nil {
 		c.App().Flash().Err(err)
 		return nil
 	}
 	c.App().Flash().Info("Cleared context selection")
+	c.App().releaseDeselected(before, nil)
 	c.Refresh()
 	return nil
 }
@@ -194,16 +198,28 @@ func (c *Context) useCtx(app *App, _ ui.Tabular, gvr *client.GVR, path string) {
 		slogs.GVR, gvr,
 		slogs.FQN, path,
 	)
-	if err := useContext(app, path); err != nil {
+	err := useContext(app, path, func() {
+		app.clearHistory()
+		c.Refresh()
+		c.GetTable().Select(1, 0)
+	})
+	if err != nil {
 		app.Flash().Err(err)
-		return
 	}
-	c.App().clearHistory()
-	c.Refresh()
-	c.GetTable().Select(1, 0)
 }
 
-func useContext(app *App, name string) error {
+// useContext switches to a context once its hooks ran. done, if set, runs
+// once the context is active.
+func useContext(app *App, name string, done func()) error {
+	if app.deferToHooks(name, func() error { return useContext(app, name, done) }) {
+		return nil
+	}
+
+	return app.switchWithProgress(name, done)
+}
+
+// activateContext switches the connection and the app over to a given context.
+func activateContext(app *App, name string) error {
 	if app.Content.Top() != nil {
 		app.Content.Top().Stop()
 	}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/derailed/tview"
+)
+
+const maxSkippedReason = 80
+
+// reportSkippedContexts warns when a multi-context view is missing contexts.
+// A dismissed warning stays hidden until a different set of contexts fails.
+func (b *Browser) reportSkippedContexts() {
+	cs, ok := b.GetModel().(ui.ContextStatuser)
+	if !ok {
+		return
+	}
+	ff := failedContexts(cs.ContextStatuses())
+	key := skippedKey(ff)
+	if key == b.skipped {
+		return
+	}
+	if key == "" {
+		b.skipped = ""
+		return
+	}
+	// Only let go of the clients of contexts we can't reach anymore.
+	for _, f := range ff {
+		if dao.IsUnreachable(f.Err) {
+			b.app.releaseContext(f.Context, 0, "")
+		}
+	}
+	if b.app.Content.IsTopDialog() {
+		return
+	}
+	b.skipped = key
+	d := b.app.Styles.Dialog()
+	dialog.ShowContextErrors(&d, b.app.Content.Pages, skippedContextsMsg(ff), func() {
+		b.skipped = ""
+		b.refreshCmd(nil)
+	})
+}
+
+func failedContexts(ss []model1.ContextStatus) []model1.ContextStatus {
+	ff := make([]model1.ContextStatus, 0, len(ss))
+	for _, s := range ss {
+		if s.Err != nil {
+			ff = append(ff, s)
+		}
+	}
+
+	return ff
+}
+
+func skippedKey(ff []model1.ContextStatus) string {
+	cc := make([]string, 0, len(ff))
+	for _, s := range ff {
+		cc = append(cc, s.Context)
+	}
+
+	return strings.Join(cc, ",")
+}
+
+func skippedContextsMsg(ff []model1.ContextStatus) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "This view is incomplete, %d contexts were skipped:\n\n", len(ff))
+	for _, s := range ff {
+		fmt.Fprintf(&b, "%s: %s\n", s.Context, tview.Escape(render.Truncate(s.Err.Error(), maxSkippedReason)))
+	}
+
+	return b.String()
+}
+
+// answeredContexts drops the contexts skipped by a multi-context fetch.
+func answeredContexts(ctxs []string, err error) []string {
+	failed := dao.FailedContexts(err)
+	if err != nil && failed == nil {
+		return nil
+	}
+	cc := make([]string, 0, len(ctxs))
+	for _, c := range ctxs {
+		if _, ok := failed[c]; !ok {
+			cc = append(cc, c)
+		}
+	}
+
+	return cc
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkippedContexts(t *testing.T) {
+	uu := map[string]struct {
+		ss  []model1.ContextStatus
+		key string
+		msg string
+	}{
+		"none": {
+			ss: []model1.ContextStatus{{Context: "c1", Count: 2}},
+		},
+		"skipped": {
+			ss: []model1.ContextStatus{
+				{Context: "c1", Count: 2},
+				{Context: "c2", Err: errors.New("connection refused")},
+				{Context: "c3", Err: errors.New("forbidden")},
+			},
+			key: "c2,c3",
+			msg: "This view is incomplete, 2 contexts were skipped:\n\nc2: connection refused\nc3: forbidden\n",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ff := failedContexts(u.ss)
+			assert.Equal(t, u.key, skippedKey(ff))
+			if u.msg != "" {
+				assert.Equal(t, u.msg, skippedContextsMsg(ff))
+			}
+		})
+	}
+}
+
+func TestAnsweredContexts(t *testing.T) {
+	uu := map[string]struct {
+		err error
+		e   []string
+	}{
+		"ok": {
+			e: []string{"c1", "c2"},
+		},
+		"skipped": {
+			err: dao.NewMultiContextError([]dao.ContextResult{{Context: "c1", Err: errors.New("boom")}}),
+			e:   []string{"c2"},
+		},
+		"failed": {
+			err: errors.New("boom"),
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, answeredContexts([]string{"c1", "c2"}, u.err))
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/derailed/k9s/internal/config/data"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/tview"
+)
+
+// gatesCmd re-evaluates the gates of the active context.
+func (a *App) gatesCmd() {
+	name := a.Config.K9s.ActiveContextName()
+	if !a.evalGates(name) {
+		a.Flash().Infof("No gates defined for context %s", name)
+		return
+	}
+	a.Flash().Infof("Checking gates for context %s...", name)
+}
+
+// evalGates holds the context in read-only mode until all its gates passed.
+// It returns false when the context defines no gates.
+func (a *App) evalGates(name string) bool {
+	run := a.gateRun.Add(1)
+	gg, err := a.Config.K9s.ContextGates(name)
+	if err != nil {
+		slogs.CtxLog(name).Warn("Unable to load context gates", slogs.Error, err)
+	}
+	if len(gg) == 0 {
+		a.Config.K9s.SetGated(false)
+		a.setBanner("")
+		return false
+	}
+	a.Config.K9s.SetGated(true)
+	a.setBanner(fmt.Sprintf("READ-ONLY: checking %d gates for context %s...", len(gg), name))
+
+	go func() {
+		for _, g := range gg {
+			out, err := runGate(name, g)
+			if err == nil {
+				continue
+			}
+			slogs.CtxLog(name).Warn("Context gate failed",
+				slogs.ResName, g.Name,
+				slogs.Error, err,
+			)
+			msg := fmt.Sprintf("READ-ONLY: gate %q failed: %s", g.Name, gateReason(out, err))
+			a.QueueUpdateDraw(func() {
+				if a.gateRun.Load() == run {
+					a.setBanner(msg + " (:gates to re-check)")
+				}
+			})
+			return
+		}
+		a.QueueUpdateDraw(func() {
+			if a.gateRun.Load() != run {
+				return
+			}
+			a.Config.K9s.SetGated(false)
+			a.setBanner("")
+			a.Flash().Infof("All gates passed, mutations enabled in context %s", name)
+			a.gotoResource(a.Config.ActiveView(), "", true, true)
+		})
+	}()
+
+	return true
+}
+
+// checkContextWritable ensures a context allows mutations, ie it is not
+// read-only and all its gates pass, before acting on it without switching.
+func (a *App) checkContextWritable(name string) error {
+	ro, err := a.Config.K9s.IsContextReadOnly(name)
+	if err != nil {
+		return fmt.Errorf("context %s: %w", name, err)
+	}
+	if ro {
+		return fmt.Errorf("context %s is read-only", name)
+	}
+	gg, err := a.Config.K9s.ContextGates(name)
+	if err != nil {
+		return fmt.Errorf("context %s gates: %w", name, err)
+	}
+	for _, g := range gg {
+		if out, err := runGate(name, g); err != nil {
+			return fmt.Errorf("context %s gate %q failed: %s", name, g.Name, gateReason(out, err))
+		}
+	}
+
+	return nil
+}
+
+// setBanner shows a message above the main view or hides it when blank.
+func (a *App) setBanner(msg string) {
+	if a.banner == nil || a.frame == nil {
+		return
+	}
+	if msg == "" {
+		a.banner.Clear()
+		a.frame.ResizeItem(a.banner, 0, 0)
+		return
+	}
+	st := a.Styles.Frame().Status
+	a.banner.SetBackgroundColor(st.ErrorColor.Color())
+	a.banner.SetTextColor(a.Styles.BgColor())
+	a.banner.SetText(tview.Escape(msg))
+	a.frame.ResizeItem(a.banner, 1, 0)
+}
+
+// runGate runs a gate check to completion.
+func runGate(name string, g data.Gate) (string, error) {
+	timeout, err := g.Deadline()
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var buff bytes.Buffer
+	c := exec.CommandContext(ctx, g.Command, g.Args...)
+	c.Env = append(os.Environ(), "K9S_CONTEXT="+name)
+	c.Stdout, c.Stderr = &buff, &buff
+	if err := c.Run(); err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+		return buff.String(), err
+	}
+
+	return buff.String(), nil
+}
+
+// gateReason returns the last line a gate printed or its error.
+func gateReason(out string, err error) string {
+	ll := strings.Split(strings.TrimSpace(out), "\n")
+	if l := strings.TrimSpace(ll[len(ll)-1]); l != "" {
+		return l
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGateReason(t *testing.T) {
+	uu := map[string]struct {
+		out string
+		err error
+		e   string
+	}{
+		"output": {
+			out: "checking calendar\nchange freeze until friday\n",
+			err: errors.New("exit status 1"),
+			e:   "change freeze until friday",
+		},
+		"silent": {
+			err: errors.New("exit status 2"),
+			e:   "exit status 2",
+		},
+		"blank": {
+			out: "\n  \n",
+			err: errors.New("timed out after 10s"),
+			e:   "timed out after 10s",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, gateReason(u.out, u.err))
+		})
+	}
+}
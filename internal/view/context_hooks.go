@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/config/data"
+	"github.com/derailed/k9s/internal/slogs"
+)
+
+const hooksTitle = "Hooks"
+
+// deferToHooks runs the pre-flight hooks of a context before switching to it.
+// It returns true when the switch is deferred, the continuation then resumes
+// the switch once all hooks succeeded.
+func (a *App) deferToHooks(name string, cont func() error) bool {
+	if a.hooksRan == name {
+		a.hooksRan = ""
+		return false
+	}
+	hh, err := a.Config.K9s.ContextHooks(name)
+	if err != nil {
+		slogs.CtxLog(name).Warn("Unable to load context hooks", slogs.Error, err)
+		return false
+	}
+	a.stopHookProcs()
+	if len(hh) == 0 {
+		return false
+	}
+	a.runContextHooks(name, hh, cont)
+
+	return true
+}
+
+// runContextHooks runs hooks in order, logging the progress in a details view.
+// The switch is aborted on the first failing hook unless it may continue on error.
+func (a *App) runContextHooks(name string, hh []data.Hook, cont func() error) {
+	d := NewDetails(a, hooksTitle, name, contentTXT, true)
+	if err := a.inject(d, false); err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	w := d.GetWriter()
+	log := func(format string, args ...any) {
+		a.QueueUpdateDraw(func() {
+			fmt.Fprintf(w, "%s %s\n", time.Now().Format(time.TimeOnly), fmt.Sprintf(format, args...))
+		})
+	}
+	fmt.Fprintf(w, "Running %d pre-flight hooks for context %s...\n\n", len(hh), name)
+	a.Flash().Infof("Running pre-flight hooks for context %s...", name)
+
+	go func() {
+		for i, h := range hh {
+			progress := fmt.Sprintf("[%d/%d]", i+1, len(hh))
+			log("%s %s", progress, h.Name)
+			out, err := a.runHook(name, h)
+			for _, l := range strings.Split(out, "\n") {
+				if l = strings.TrimSpace(l); l != "" {
+					log("  %s", l)
+				}
+			}
+			switch {
+			case err == nil:
+				log("DONE %s", progress)
+			case h.ContinueOnError:
+				log("FAILED %s: %s (continuing)", progress, err)
+			default:
+				log("FAILED %s: %s", progress, err)
+				log("Switch to context %s aborted.", name)
+				a.QueueUpdateDraw(func() {
+					a.Flash().Errf("Context %s hook %q failed: %s", name, h.Name, err)
+				})
+				return
+			}
+		}
+		log("All hooks completed, switching to context %s.", name)
+		a.QueueUpdateDraw(func() {
+			a.hooksRan = name
+			if err := cont(); err != nil {
+				a.hooksRan = ""
+				a.Flash().Err(err)
+			}
+		})
+	}()
+}
+
+// runHook runs a hook to completion or starts it in the background.
+func (a *App) runHook(name string, h data.Hook) (string, error) {
+	env := append(os.Environ(), "K9S_CONTEXT="+name)
+	if h.Background {
+		c := exec.Command(h.Command, h.Args...)
+		c.Env = env
+		if err := c.Start(); err != nil {
+			return "", err
+		}
+		a.hookMx.Lock()
+		a.hookProcs = append(a.hookProcs, c)
+		a.hookMx.Unlock()
+		go func() {
+			if err := c.Wait(); err != nil {
+				slogs.CtxLog(name).Debug("Background hook exited", slogs.Error, err)
+			}
+		}()
+		return fmt.Sprintf("started in the background (pid %d)", c.Process.Pid), nil
+	}
+
+	timeout, err := h.Deadline()
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var buff bytes.Buffer
+	c := exec.CommandContext(ctx, h.Command, h.Args...)
+	c.Env, c.Stdout, c.Stderr = env, &buff, &buff
+	if err := c.Run(); err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+		return buff.String(), err
+	}
+
+	return buff.String(), nil
+}
+
+// stopHookProcs kills the background hooks started by a previous context.
+func (a *App) stopHookProcs() {
+	a.hookMx.Lock()
+	defer a.hookMx.Unlock()
+
+	for _, c := range a.hookProcs {
+		if err := c.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			slog.Debug("Unable to stop background hook", slogs.Error, err)
+		}
+	}
+	a.hookProcs = nil
+}
@@ -6,6 +6,7 @@ package view
 import (
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tcell/v2"
 )
 
 // CRD represents a crd viewer.
@@ -18,11 +19,34 @@ func NewCRD(gvr *client.GVR) ResourceViewer {
 	s := CRD{
 		ResourceViewer: NewOwnerExtender(NewBrowser(gvr)),
 	}
+	s.AddBindKeysFn(s.bindKeys)
 	s.GetTable().SetEnterFn(s.showCRD)
 
 	return &s
 }
 
+func (s *CRD) bindKeys(aa *ui.KeyActions) {
+	if s.App().Config.IsReadOnly() {
+		return
+	}
+	aa.Add(ui.KeyShiftM, ui.NewKeyActionWithOpts("Migrate Storage", s.migrateCmd,
+		ui.ActionOpts{
+			Visible:   true,
+			Dangerous: true,
+		},
+	))
+}
+
+func (s *CRD) migrateCmd(*tcell.EventKey) *tcell.EventKey {
+	path := s.GetTable().GetSelectedItem()
+	if path == "" {
+		return nil
+	}
+	s.App().workflowCmd("migrate-crd", path)
+
+	return nil
+}
+
 func (*CRD) showCRD(app *App, _ ui.Tabular, _ *client.GVR, path string) {
 	_, crd := client.Namespaced(path)
 	app.gotoResource(crd, "", false, true)
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+// crdDrillDowns lists the resources opened by Enter on a row of a grouped
+// CRD, pre-filtered to the objects related to that row.
+var crdDrillDowns = map[string]crdScopeLink{
+	"volumes.longhorn.io": {
+		to:     "replicas.longhorn.io",
+		label:  "longhornvolume",
+		sameNS: true,
+	},
+	"engines.longhorn.io": {
+		to:        "replicas.longhorn.io",
+		label:     "longhornvolume",
+		fromLabel: "longhornvolume",
+		sameNS:    true,
+	},
+	"gitrepos.fleet.cattle.io": {
+		to:     "bundles.fleet.cattle.io",
+		label:  "fleet.cattle.io/repo-name",
+		sameNS: true,
+	},
+	"bundles.fleet.cattle.io": {
+		to:    "bundledeployments.fleet.cattle.io",
+		label: "fleet.cattle.io/bundle-name",
+	},
+	"plans.upgrade.cattle.io": {
+		to:     "jobs",
+		label:  "upgrade.cattle.io/plan",
+		sameNS: true,
+	},
+	"helmcharts.helm.cattle.io": {
+		to:     "jobs",
+		label:  "helmcharts.helm.cattle.io/chart",
+		sameNS: true,
+	},
+}
+
+// drillDown opens the resources related to a row when its resource defines a
+// drill-down. It returns false when the row has no related resources to show.
+func (b *Browser) drillDown(path string) bool {
+	l, ok := crdDrillDowns[gvrToAliasKey(b.GVR().String())]
+	if !ok || !b.app.command.CanResolve(l.to) {
+		return false
+	}
+	s := l.scope(b.GetNamespace(), b.selectedMeta(path))
+	if s.Labels == "" {
+		return false
+	}
+	b.app.gotoResource(s.command(l.to), "", false, true)
+	b.applyCRDLabelFilter(s.Labels)
+
+	return true
+}
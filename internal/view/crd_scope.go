@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"github.com/derailed/k9s/internal/client"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// crdScope tracks the namespace and labels carried over to a related tab.
+type crdScope struct {
+	Namespace string
+	Labels    string
+}
+
+// crdScopeLink describes how the selected resource of a tab scopes a related tab.
+type crdScopeLink struct {
+	from, to string
+	// label is the label of the target resources pointing back to the selection.
+	label string
+	// fromLabel is the label of the selection holding the value, its name when blank.
+	fromLabel string
+	// sameNS indicates both resources live in the same namespace.
+	sameNS bool
+}
+
+// crdScopeLinks lists the tab transitions carrying over a scope. Tabs not
+// listed keep the current namespace.
+var crdScopeLinks = []crdScopeLink{
+	{from: "volumes.longhorn.io", to: "replicas.longhorn.io", label: "longhornvolume", sameNS: true},
+	{from: "volumes.longhorn.io", to: "engines.longhorn.io", label: "longhornvolume", sameNS: true},
+	{from: "replicas.longhorn.io", to: "engines.longhorn.io", label: "longhornvolume", fromLabel: "longhornvolume", sameNS: true},
+	{from: "engines.longhorn.io", to: "replicas.longhorn.io", label: "longhornvolume", fromLabel: "longhornvolume", sameNS: true},
+	{from: "replicas.longhorn.io", to: "volumes.longhorn.io", sameNS: true},
+	{from: "engines.longhorn.io", to: "volumes.longhorn.io", sameNS: true},
+	{from: "engines.longhorn.io", to: "nodes.longhorn.io", sameNS: true},
+	{from: "volumes.longhorn.io", to: "backupvolumes.longhorn.io", sameNS: true},
+	{from: "gitrepos.fleet.cattle.io", to: "bundles.fleet.cattle.io", label: "fleet.cattle.io/repo-name", sameNS: true},
+	{from: "gitrepos.fleet.cattle.io", to: "bundledeployments.fleet.cattle.io", label: "fleet.cattle.io/repo-name"},
+	{from: "bundledeployments.fleet.cattle.io", to: "gitrepos.fleet.cattle.io"},
+	{from: "bundledeployments.fleet.cattle.io", to: "bundles.fleet.cattle.io", label: "fleet.cattle.io/repo-name", fromLabel: "fleet.cattle.io/repo-name"},
+	{from: "bundles.fleet.cattle.io", to: "bundledeployments.fleet.cattle.io", label: "fleet.cattle.io/bundle-name"},
+	{from: "bundles.fleet.cattle.io", to: "gitrepos.fleet.cattle.io", sameNS: true},
+	{from: "bundles.fleet.cattle.io", to: "clustergroups.fleet.cattle.io", sameNS: true},
+	{from: "clustergroups.fleet.cattle.io", to: "clusters.fleet.cattle.io", sameNS: true},
+	{from: "clusteradmissionpolicies.policies.kubewarden.io", to: "admissionpolicies.policies.kubewarden.io"},
+	{from: "admissionpolicies.policies.kubewarden.io", to: "policyservers.policies.kubewarden.io"},
+}
+
+func crdScopeLinkFor(from, to string) (crdScopeLink, bool) {
+	for _, l := range crdScopeLinks {
+		if l.from == from && l.to == to {
+			return l, true
+		}
+	}
+
+	return crdScopeLink{}, false
+}
+
+// inferCRDScope computes the scope of a related tab from the current
+// namespace and the selected resource if any. A blank namespace keeps the
+// active one.
+func inferCRDScope(from, to, ns string, sel metav1.Object) crdScope {
+	l, ok := crdScopeLinkFor(from, to)
+	if !ok {
+		return crdScope{}
+	}
+
+	return l.scope(ns, sel)
+}
+
+// scope returns the scope of the target resources related to a selection.
+func (l crdScopeLink) scope(ns string, sel metav1.Object) crdScope {
+	var s crdScope
+	switch {
+	case !l.sameNS:
+		s.Namespace = client.NamespaceAll
+	case client.IsAllNamespaces(ns) && sel != nil && sel.GetNamespace() != "":
+		s.Namespace = sel.GetNamespace()
+	}
+	if l.label == "" || sel == nil {
+		return s
+	}
+	v := sel.GetName()
+	if l.fromLabel != "" {
+		v = sel.GetLabels()[l.fromLabel]
+	}
+	if v != "" {
+		s.Labels = l.label + "=" + v
+	}
+
+	return s
+}
+
+// withLabels merges additional labels into the scope.
+func (s crdScope) withLabels(sel string) crdScope {
+	switch {
+	case sel == "":
+	case s.Labels == "":
+		s.Labels = sel
+	default:
+		s.Labels += "," + sel
+	}
+
+	return s
+}
+
+// command returns the navigation command for a tab in this scope.
+func (s crdScope) command(navCmd string) string {
+	if s.Namespace == "" {
+		return navCmd
+	}
+
+	return navCmd + " " + s.Namespace
+}
+
+// gotoCRDTab navigates to a related tab carrying over a compatible scope.
+func (b *Browser) gotoCRDTab(from, candidate string) {
+	navCmd, labelSel, _ := parseCRDEntry(candidate)
+	s := inferCRDScope(from, navCmd, b.GetNamespace(), b.selectedMeta(b.GetSelectedItem())).withLabels(labelSel)
+	b.app.gotoResource(s.command(navCmd), "", true, true)
+	b.applyCRDLabelFilter(s.Labels)
+}
+
+// selectedMeta returns the metadata of a resource from the cache or nil if
+// not available, ie rows from other contexts.
+func (b *Browser) selectedMeta(path string) metav1.Object {
+	if path == "" {
+		return nil
+	}
+	o, err := b.app.factory.Get(b.GVR(), path, true, labels.Everything())
+	if err != nil {
+		return nil
+	}
+	m, err := meta.Accessor(o)
+	if err != nil {
+		return nil
+	}
+
+	return m
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInferCRDScope(t *testing.T) {
+	obj := func(ns, n string, ll map[string]string) metav1.Object {
+		return &metav1.ObjectMeta{Namespace: ns, Name: n, Labels: ll}
+	}
+	uu := map[string]struct {
+		from, to, ns string
+		sel          metav1.Object
+		e            crdScope
+	}{
+		"unlinked": {
+			from: "nodes.longhorn.io",
+			to:   "backupvolumes.longhorn.io",
+			ns:   "longhorn-system",
+			sel:  obj("longhorn-system", "n1", nil),
+		},
+		"no-selection": {
+			from: "volumes.longhorn.io",
+			to:   "replicas.longhorn.io",
+			ns:   "longhorn-system",
+		},
+		"keep-ns": {
+			from: "volumes.longhorn.io",
+			to:   "replicas.longhorn.io",
+			ns:   "longhorn-system",
+			sel:  obj("longhorn-system", "pvc-1", nil),
+			e:    crdScope{Labels: "longhornvolume=pvc-1"},
+		},
+		"infer-ns": {
+			from: "volumes.longhorn.io",
+			to:   "engines.longhorn.io",
+			ns:   client.NamespaceAll,
+			sel:  obj("longhorn-system", "pvc-1", nil),
+			e:    crdScope{Namespace: "longhorn-system", Labels: "longhornvolume=pvc-1"},
+		},
+		"from-label": {
+			from: "replicas.longhorn.io",
+			to:   "engines.longhorn.io",
+			ns:   client.BlankNamespace,
+			sel:  obj("longhorn-system", "pvc-1-r-abc", map[string]string{"longhornvolume": "pvc-1"}),
+			e:    crdScope{Namespace: "longhorn-system", Labels: "longhornvolume=pvc-1"},
+		},
+		"missing-label": {
+			from: "replicas.longhorn.io",
+			to:   "engines.longhorn.io",
+			ns:   "longhorn-system",
+			sel:  obj("longhorn-system", "pvc-1-r-abc", nil),
+		},
+		"other-ns": {
+			from: "gitrepos.fleet.cattle.io",
+			to:   "bundledeployments.fleet.cattle.io",
+			ns:   "fleet-default",
+			sel:  obj("fleet-default", "apps", nil),
+			e:    crdScope{Namespace: client.NamespaceAll, Labels: "fleet.cattle.io/repo-name=apps"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, inferCRDScope(u.from, u.to, u.ns, u.sel))
+		})
+	}
+}
+
+func TestCRDScopeCommand(t *testing.T) {
+	s := crdScope{Labels: "longhornvolume=pvc-1"}.withLabels("app=fred")
+	assert.Equal(t, "longhornvolume=pvc-1,app=fred", s.Labels)
+	assert.Equal(t, "replicas.longhorn.io", s.command("replicas.longhorn.io"))
+
+	s.Namespace = "longhorn-system"
+	assert.Equal(t, "replicas.longhorn.io longhorn-system", s.command("replicas.longhorn.io"))
+}
+
+func TestCRDDrillDowns(t *testing.T) {
+	uu := map[string]struct {
+		from string
+		sel  metav1.Object
+		to   string
+		e    crdScope
+	}{
+		"volume": {
+			from: "volumes.longhorn.io",
+			sel:  &metav1.ObjectMeta{Namespace: "longhorn-system", Name: "pvc-1"},
+			to:   "replicas.longhorn.io",
+			e:    crdScope{Namespace: "longhorn-system", Labels: "longhornvolume=pvc-1"},
+		},
+		"gitrepo": {
+			from: "gitrepos.fleet.cattle.io",
+			sel:  &metav1.ObjectMeta{Namespace: "fleet-default", Name: "apps"},
+			to:   "bundles.fleet.cattle.io",
+			e:    crdScope{Namespace: "fleet-default", Labels: "fleet.cattle.io/repo-name=apps"},
+		},
+		"plan": {
+			from: "plans.upgrade.cattle.io",
+			sel:  &metav1.ObjectMeta{Namespace: "system-upgrade", Name: "server-plan"},
+			to:   "jobs",
+			e:    crdScope{Namespace: "system-upgrade", Labels: "upgrade.cattle.io/plan=server-plan"},
+		},
+		"no-selection": {
+			from: "plans.upgrade.cattle.io",
+			to:   "jobs",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			l, ok := crdDrillDowns[u.from]
+			assert.True(t, ok)
+			assert.Equal(t, u.to, l.to)
+			assert.Equal(t, u.e, l.scope(client.NamespaceAll, u.sel))
+		})
+	}
+}
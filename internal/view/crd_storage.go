@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+)
+
+// crdVersionsDiag flags CRDs with objects left at old stored versions or a
+// deprecated storage version.
+func crdVersionsDiag(ctx context.Context, a *App, _, _ string) (string, error) {
+	ss, err := dao.FetchCRDStorages(ctx, a.Conn())
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		b  strings.Builder
+		ii []string
+	)
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CRD\tSTORAGE\tSTORED\tDEPRECATED")
+	for _, s := range ss {
+		issues := s.Issues()
+		if len(issues) == 0 {
+			continue
+		}
+		ii = append(ii, issues...)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Name, s.Storage, strings.Join(s.Stored, ","), orNA(strings.Join(s.Deprecated, ",")))
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	b.WriteString("\n=== Issues ===\n")
+	if len(ii) == 0 {
+		fmt.Fprintf(&b, "(none, %d CRDs only store their storage version)\n", len(ss))
+	}
+	for _, i := range ii {
+		fmt.Fprintf(&b, "! %s\n", i)
+	}
+	b.WriteString("\nUse `:migrate-crd NAME` to rewrite the objects of a CRD at its storage version and trim its stored versions.\n")
+
+	return b.String(), nil
+}
+
+// crdMigrationWorkflow rewrites the objects of a CRD at its storage version,
+// then trims the CRD stored versions so old versions can be dropped. The steps
+// target the context active when the workflow starts.
+func crdMigrationWorkflow(a *App, arg string) (string, []workflowStep, error) {
+	if arg == "" {
+		return "", nil, errors.New("missing crd name, use `migrate-crd NAME`")
+	}
+	_, name := client.Namespaced(arg)
+	ctxName := a.Config.K9s.ActiveContextName()
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return "", nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), a.Conn().Config().CallTimeout())
+	defer cancel()
+	s, err := dao.FetchCRDStorage(ctx, a.Conn(), name)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(s.Stale()) == 0 {
+		return "", nil, fmt.Errorf("crd %s only stores %s, nothing to migrate", name, s.Storage)
+	}
+
+	steps := []workflowStep{
+		{
+			title: fmt.Sprintf("Rewrite all %s objects on %s at version %s", s.Resource, ctxName, s.Storage),
+			run: func(ctx context.Context, log logFn) error {
+				n, err := dao.RewriteCRDObjects(ctx, rawCfg, ctxName, s, func(done int) {
+					log("%d objects rewritten...", done)
+				})
+				if err != nil {
+					return err
+				}
+				log("%d objects now stored at %s", n, s.Storage)
+				return nil
+			},
+		},
+		{
+			title: fmt.Sprintf("Trim %s stored versions %v to [%s] on %s", name, s.Stored, s.Storage, ctxName),
+			run: func(ctx context.Context, log logFn) error {
+				if err := dao.TrimStoredVersions(ctx, rawCfg, ctxName, name); err != nil {
+					return err
+				}
+				log("stored versions trimmed, %v can now be removed from the CRD", s.Stale())
+				return nil
+			},
+		},
+	}
+
+	return name, steps, nil
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/slogs"
+)
+
+const shellPodGCTimeout = 5 * time.Second
+
+// shellPodNS returns the namespace of the node shell pod of the active
+// context or blank when node shells are off.
+func (a *App) shellPodNS() string {
+	ct, err := a.Config.K9s.ActiveContext()
+	if err != nil || !ct.FeatureGates.NodeShell || a.Config.K9s.ShellPod == nil {
+		return ""
+	}
+
+	return a.Config.K9s.ShellPod.Namespace
+}
+
+// releaseContext tears down the port-forwards, node shell pod and cached
+// clients of a context no longer in use and reports what was cleaned.
+// Forwards counts the port-forwards already stopped by the caller.
+func (a *App) releaseContext(ctxName string, forwards int, shellNS string) {
+	if ctxName == "" {
+		return
+	}
+	go func() {
+		c := dao.ReleaseContext(ctxName)
+		c.Forwards += forwards
+		if shellNS != "" && a.Conn() != nil {
+			if raw, err := a.Conn().Config().RawConfig(); err == nil {
+				ctx, cancel := context.WithTimeout(context.Background(), shellPodGCTimeout)
+				ok, err := dao.DeleteShellPod(ctx, raw, ctxName, shellNS, k9sShellPodName())
+				cancel()
+				if err != nil {
+					slogs.CtxLog(ctxName).Warn("Unable to delete shell pod of inactive context", slogs.Error, err)
+				}
+				if ok {
+					c.ShellPods++
+				}
+			}
+		}
+		if c.IsEmpty() {
+			return
+		}
+		slogs.CtxLog(ctxName).Info("Released inactive context", slogs.Message, c.String())
+		a.QueueUpdateDraw(func() {
+			a.Flash().Infof("Cleaned up %s", c)
+		})
+	}()
+}
+
+// releaseDeselected releases the contexts dropped from the multi-context
+// selection, the active context excepted.
+func (a *App) releaseDeselected(before, after []string) {
+	active := a.Config.ActiveContextName()
+	for _, n := range before {
+		if n == active || slices.Contains(after, n) {
+			continue
+		}
+		a.releaseContext(n, 0, "")
+	}
+}
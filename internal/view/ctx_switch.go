@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/watch"
+	"github.com/derailed/tview"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	ctxSwitchDialogKey    = "ctx-switch"
+	ctxSwitchTitle        = "Context Switch"
+	ctxSwitchSyncTimeout  = 30 * time.Second
+	ctxSwitchPollInterval = 200 * time.Millisecond
+)
+
+// ctxSwitchDialog tracks a context switch stages.
+type ctxSwitchDialog struct {
+	app   *App
+	name  string
+	modal *tview.ModalForm
+}
+
+func newCtxSwitchDialog(a *App, name string, cancel context.CancelFunc) *ctxSwitchDialog {
+	styles := a.Styles.Dialog()
+	f := tview.NewForm().
+		SetItemPadding(0).
+		SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(styles.ButtonBgColor.Color()).
+		SetButtonTextColor(styles.ButtonFgColor.Color()).
+		SetLabelColor(styles.LabelFgColor.Color()).
+		SetFieldTextColor(styles.FieldFgColor.Color())
+	f.AddButton("Cancel", func() {
+		cancel()
+	})
+	f.GetButton(0).
+		SetBackgroundColorActivated(styles.ButtonFocusBgColor.Color()).
+		SetLabelColorActivated(styles.ButtonFocusFgColor.Color())
+
+	d := ctxSwitchDialog{
+		app:   a,
+		name:  name,
+		modal: tview.NewModalForm("<"+ctxSwitchTitle+">", f),
+	}
+	d.modal.SetDoneFunc(func(int, string) {
+		cancel()
+	})
+	d.setStage(dao.SwitchAuth)
+	a.Content.AddPage(ctxSwitchDialogKey, d.modal, false, false)
+	a.Content.ShowPage(ctxSwitchDialogKey)
+
+	return &d
+}
+
+func (d *ctxSwitchDialog) setStage(s dao.SwitchStage) {
+	d.modal.SetText(ctxSwitchText(d.name, s))
+}
+
+// show brings the dialog back on top once the new context view is loaded.
+func (d *ctxSwitchDialog) show() {
+	d.app.Content.SendToFront(ctxSwitchDialogKey)
+	d.app.Content.ShowPage(ctxSwitchDialogKey)
+	d.app.SetFocus(d.modal)
+}
+
+func (d *ctxSwitchDialog) dismiss() {
+	d.app.Content.RemovePage(ctxSwitchDialogKey)
+}
+
+// ctxSwitchText renders the progress of a context switch.
+func ctxSwitchText(name string, current dao.SwitchStage) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Switching to context %q\n\n", name)
+	for _, s := range dao.SwitchStages() {
+		mark := "·"
+		switch {
+		case s < current:
+			mark = "✓"
+		case s == current:
+			mark = "»"
+		}
+		fmt.Fprintf(&b, "%s %s\n", mark, s)
+	}
+
+	return b.String()
+}
+
+// switchWithProgress switches to a context in the background, tracking its
+// stages in a dialog. Canceling prior to the switch leaves the active context
+// untouched. Canceling while the informers sync reverts to the previous
+// context. done, if set, runs once the context is active.
+func (a *App) switchWithProgress(name string, done func()) error {
+	if a.Conn() == nil {
+		return activateNow(a, name, done)
+	}
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return activateNow(a, name, done)
+	}
+	if !a.switching.CompareAndSwap(false, true) {
+		return errors.New("a context switch is already in progress")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d := newCtxSwitchDialog(a, name, cancel)
+	go a.warmUpContext(ctx, cancel, rawCfg, a.Config.ActiveContextName(), name, d, done)
+
+	return nil
+}
+
+// activateNow switches to a context right away, then runs done if set.
+func activateNow(a *App, name string, done func()) error {
+	if err := activateContext(a, name); err != nil {
+		return err
+	}
+	if done != nil {
+		done()
+	}
+
+	return nil
+}
+
+func (a *App) warmUpContext(ctx context.Context, cancel context.CancelFunc, rawCfg api.Config, from, to string, d *ctxSwitchDialog, done func()) {
+	err := dao.WarmUpContext(ctx, rawCfg, to, func(s dao.SwitchStage) {
+		a.QueueUpdateDraw(func() {
+			d.setStage(s)
+		})
+	})
+	a.QueueUpdateDraw(func() {
+		switch {
+		case ctx.Err() != nil:
+			a.endContextSwitch(d, cancel)
+			a.Flash().Infof("Context switch to %q canceled", to)
+			return
+		case err != nil:
+			a.endContextSwitch(d, cancel)
+			a.Flash().Err(err)
+			return
+		}
+
+		d.setStage(dao.SwitchInformers)
+		if err := activateContext(a, to); err != nil {
+			a.endContextSwitch(d, cancel)
+			a.Flash().Err(err)
+			return
+		}
+		if done != nil {
+			done()
+		}
+		d.show()
+		gvr, ns := client.PodGVR, a.Config.ActiveNamespace()
+		if v, ok := a.Content.Top().(ResourceViewer); ok {
+			gvr = v.GVR()
+		}
+		go a.awaitContextSync(ctx, cancel, a.factory, gvr, ns, from, to, d)
+	})
+}
+
+func (a *App) awaitContextSync(ctx context.Context, cancel context.CancelFunc, f *watch.Factory, gvr *client.GVR, ns, from, to string, d *ctxSwitchDialog) {
+	err := waitForSync(ctx, f, gvr, ns)
+	a.QueueUpdateDraw(func() {
+		canceled := ctx.Err() != nil
+		a.endContextSwitch(d, cancel)
+		switch {
+		case canceled:
+			a.revertContext(from, to)
+		case err != nil:
+			a.Flash().Warnf("Context %q is still syncing %s", to, gvr)
+		}
+	})
+}
+
+func (a *App) endContextSwitch(d *ctxSwitchDialog, cancel context.CancelFunc) {
+	d.dismiss()
+	cancel()
+	a.switching.Store(false)
+}
+
+// revertContext switches back to the context active prior to a canceled switch.
+func (a *App) revertContext(from, to string) {
+	if from == "" || from == to {
+		a.Flash().Warnf("Context switch to %q canceled", to)
+		return
+	}
+	revert := func() error {
+		if err := activateContext(a, from); err != nil {
+			return err
+		}
+		a.Flash().Warnf("Context switch to %q canceled. Reverted to %q", to, from)
+		return nil
+	}
+	if a.deferToHooks(from, revert) {
+		return
+	}
+	if err := revert(); err != nil {
+		a.Flash().Err(err)
+	}
+}
+
+// waitForSync waits for a resource informer to sync. Resources not backed by
+// an informer are deemed synced.
+func waitForSync(ctx context.Context, f *watch.Factory, gvr *client.GVR, ns string) error {
+	if f == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, ctxSwitchSyncTimeout)
+	defer cancel()
+
+	t := time.NewTicker(ctxSwitchPollInterval)
+	defer t.Stop()
+	for {
+		if ok, err := f.HasSynced(gvr, ns); err != nil || ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCtxSwitchText(t *testing.T) {
+	uu := map[string]struct {
+		stage dao.SwitchStage
+		e     string
+	}{
+		"auth": {
+			stage: dao.SwitchAuth,
+			e:     "Switching to context \"fred\"\n\n» auth\n· discovery\n· informer sync\n",
+		},
+		"discovery": {
+			stage: dao.SwitchDiscovery,
+			e:     "Switching to context \"fred\"\n\n✓ auth\n» discovery\n· informer sync\n",
+		},
+		"informers": {
+			stage: dao.SwitchInformers,
+			e:     "Switching to context \"fred\"\n\n✓ auth\n✓ discovery\n» informer sync\n",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, ctxSwitchText("fred", u.stage))
+		})
+	}
+}
@@ -23,6 +23,7 @@ const (
 	detailsTitleFmt = "[fg:bg:b] %s([hilite:bg:b]%s[fg:bg:-])[fg:bg:-] "
 	contentTXT      = "text"
 	contentYAML     = "yaml"
+	contentANSI     = "ansi"
 )
 
 // Details represents a generic text viewer.
@@ -38,7 +39,9 @@ type Details struct {
 	currentRegion, maxRegions int
 	searchable                bool
 	fullScreen                bool
+	sensitive                 bool
 	contentType               string
+	stopFn                    func()
 }
 
 // NewDetails returns a details viewer.
@@ -124,6 +127,8 @@ func (d *Details) TextChanged(lines []string) {
 	switch d.contentType {
 	case contentYAML:
 		d.text.SetText(colorizeYAML(d.app.Styles.Views().Yaml, strings.Join(lines, "\n")))
+	case contentANSI:
+		d.text.SetText(d.colorizeANSI(strings.Join(lines, "\n")))
 	default:
 		d.text.SetText(strings.Join(lines, "\n"))
 	}
@@ -135,7 +140,14 @@ func (d *Details) TextFiltered(lines []string, matches fuzzy.Matches) {
 	d.currentRegion, d.maxRegions = 0, len(matches)
 	ll := linesWithRegions(lines, matches)
 
-	d.text.SetText(colorizeYAML(d.app.Styles.Views().Yaml, strings.Join(ll, "\n")))
+	switch d.contentType {
+	case contentYAML:
+		d.text.SetText(colorizeYAML(d.app.Styles.Views().Yaml, strings.Join(ll, "\n")))
+	case contentANSI:
+		d.text.SetText(enableRegion(d.colorizeANSI(strings.Join(ll, "\n"))))
+	default:
+		d.text.SetText(strings.Join(ll, "\n"))
+	}
 	d.text.Highlight()
 	if len(matches) > 0 {
 		d.text.Highlight("search_0")
@@ -143,6 +155,12 @@ func (d *Details) TextFiltered(lines []string, matches fuzzy.Matches) {
 	}
 }
 
+func (d *Details) colorizeANSI(raw string) string {
+	body := d.app.Styles.Body()
+
+	return colorizeANSI(raw, body.FgColor.String(), body.BgColor.String())
+}
+
 // BufferChanged indicates the buffer was changed.
 func (*Details) BufferChanged(_, _ string) {}
 
@@ -222,6 +240,17 @@ func (*Details) Start() {}
 // Stop terminates the updater.
 func (d *Details) Stop() {
 	d.app.Styles.RemoveListener(d)
+	if d.stopFn != nil {
+		d.stopFn()
+	}
+}
+
+// SetStopFn sets a function called when the view closes, ie to cancel the
+// work it reports on.
+func (d *Details) SetStopFn(f func()) *Details {
+	d.stopFn = f
+
+	return d
 }
 
 // Hints returns menu hints.
@@ -238,6 +267,9 @@ func (d *Details) nextCmd(evt *tcell.EventKey) *tcell.EventKey {
 	if d.cmdBuff.Empty() {
 		return evt
 	}
+	if d.maxRegions == 0 {
+		return nil
+	}
 
 	d.currentRegion++
 	if d.currentRegion >= d.maxRegions {
@@ -275,6 +307,9 @@ func (d *Details) prevCmd(evt *tcell.EventKey) *tcell.EventKey {
 	if d.cmdBuff.Empty() {
 		return evt
 	}
+	if d.maxRegions == 0 {
+		return nil
+	}
 
 	d.currentRegion--
 	if d.currentRegion < 0 {
@@ -329,8 +364,22 @@ func (d *Details) resetCmd(evt *tcell.EventKey) *tcell.EventKey {
 	return nil
 }
 
+// SetSensitive flags the content as secret data, ie decoded Secrets, so it is
+// redacted as a whole when saved.
+func (d *Details) SetSensitive(b bool) *Details {
+	d.sensitive = b
+
+	return d
+}
+
 func (d *Details) saveCmd(*tcell.EventKey) *tcell.EventKey {
-	if path, err := saveYAML(d.app.Config.K9s.ContextScreenDumpDir(), d.title, d.text.GetText(true)); err != nil {
+	raw, r := d.text.GetText(true), d.app.redactor()
+	if d.sensitive {
+		raw = r.Data(raw)
+	} else {
+		raw = r.YAML(raw)
+	}
+	if path, err := saveYAML(d.app.Config.K9s.ContextScreenDumpDir(), d.title, raw); err != nil {
 		d.app.Flash().Err(err)
 	} else {
 		d.app.Flash().Infof("Log %s saved successfully!", path)
@@ -354,8 +403,11 @@ func (d *Details) updateTitle() {
 		return
 	}
 
-	if d.maxRegions != 0 {
+	switch {
+	case d.maxRegions != 0:
 		buff += fmt.Sprintf("[%d:%d]", d.currentRegion+1, d.maxRegions)
+	case !d.cmdBuff.IsActive():
+		buff += "[0:0]"
 	}
 	fmat += fmt.Sprintf(ui.SearchFmt, buff)
 	d.SetTitle(ui.SkinTitle(fmat, &styles))
@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	diagDeadline       = 2 * time.Minute
+	nodeProbeDeadline  = 60 * time.Second
+	hostMountTimeout   = 20 * time.Second
+	nodeProbeParallel  = 5
+	defaultProbeImage  = "busybox:1.37.0"
+	hostProbeNamespace = "kube-system"
+)
+
+type diagFn func(ctx context.Context, a *App, ns, arg string) (string, error)
+
+// diagnostics tracks the available cluster diagnostics by command name.
+var diagnostics = map[string]diagFn{
+	"pullcheck":    pullCheckDiag,
+	"dns":          dnsDiag,
+	"timeskew":     timeSkewDiag,
+	"mirrors":      mirrorsDiag,
+	"encryption":   encryptionDiag,
+	"dsgaps":       dsGapsDiag,
+	"crdversions":  crdVersionsDiag,
+	"preemptions":  preemptionsDiag,
+	"deprecations": deprecatedAPIsDiag,
+	"scmatrix":     scMatrixDiag,
+	"gvrmatrix":    gvrMatrixDiag,
+	"agentreach":   agentReachDiag,
+	"lhbackups":    longhornBackupsDiag,
+	"prime":        primeDiag,
+	"helmdrift":    helmDriftDiag,
+}
+
+// diagRefreshes tracks the diagnostics monitored live, refreshed on an
+// interval while their view is open.
+var diagRefreshes = map[string]time.Duration{
+	"deprecations": deprecationsRefresh,
+}
+
+// diagConfirms tracks the diagnostics asking for confirmation before they
+// create resources, returning the confirmation message for an argument.
+var diagConfirms = map[string]func(arg string) string{
+	"lhbackups": func(arg string) string {
+		if arg != longhornProbeArg {
+			return ""
+		}
+		return "Run a probe pod in " + dao.LonghornNamespace + " for each backup target?"
+	},
+}
+
+// diagCmd runs a cluster diagnostic against the active namespace.
+func (a *App) diagCmd(name, arg string) {
+	fn, ok := diagnostics[name]
+	if !ok {
+		a.Flash().Warnf("Unknown diagnostic %q", name)
+		return
+	}
+	if a.Conn() == nil || !a.Conn().ConnectionOK() {
+		a.Flash().Warn("Diagnostics require a cluster connection")
+		return
+	}
+
+	ns, subject := a.Config.ActiveNamespace(), a.Config.ActiveNamespace()
+	if client.IsAllNamespaces(ns) {
+		ns, subject = client.BlankNamespace, client.NamespaceAll
+	}
+	run := func() {
+		a.Flash().Infof("Running %s diagnostic...", name)
+		ctx, cancel := context.WithCancel(a.appCtx)
+		details := NewDetails(a, name, subject, contentTXT, true).SetStopFn(cancel)
+		every, live := diagRefreshes[name]
+		go func() {
+			out := runDiag(ctx, a, fn, ns, arg, every)
+			a.QueueUpdateDraw(func() {
+				if e := a.inject(details.Update(out), false); e != nil {
+					cancel()
+					a.Flash().Err(e)
+				}
+			})
+			if !live {
+				cancel()
+				return
+			}
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(a.Config.K9s.Stretch(every)):
+				}
+				out := runDiag(ctx, a, fn, ns, arg, every)
+				if ctx.Err() != nil {
+					return
+				}
+				a.QueueUpdateDraw(func() {
+					details.Update(out)
+				})
+			}
+		}()
+	}
+	confirm, ok := diagConfirms[name]
+	if !ok || confirm(arg) == "" {
+		run()
+		return
+	}
+	d := a.Styles.Dialog()
+	dialog.ShowConfirm(&d, a.Content.Pages, "Confirm "+name, confirm(arg), run, func() {})
+}
+
+// runDiag runs a diagnostic, reporting its error along with its output. Live
+// diagnostics are stamped with their refresh time.
+func runDiag(ctx context.Context, a *App, fn diagFn, ns, arg string, every time.Duration) string {
+	ctx, cancel := context.WithTimeout(ctx, diagDeadline)
+	defer cancel()
+
+	out, err := fn(ctx, a, ns, arg)
+	if err != nil {
+		out = fmt.Sprintf("Error: %s\n\n%s", err, out)
+	}
+	if every > 0 {
+		out = fmt.Sprintf("Refreshed at %s, every %s while this view is open.\n\n%s", time.Now().Format(time.TimeOnly), a.Config.K9s.Stretch(every), out)
+	}
+
+	return out
+}
+
+// pullCheckDiag pings registries using the namespace pull secrets and flags
+// pods stuck pulling their images along with the likely failure cause.
+func pullCheckDiag(ctx context.Context, a *App, ns, _ string) (string, error) {
+	var insecure bool
+	if r := a.Config.K9s.ImageRegistry; r != nil {
+		insecure = r.IsInsecure()
+	}
+	cc, err := dao.CheckPullSecrets(ctx, a.Conn(), ns, insecure)
+	if err != nil {
+		return "", err
+	}
+	ff, err := dao.PullFailures(ctx, a.Conn(), ns)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("=== Pull secrets ===\n")
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tSECRET\tREGISTRY\tAUTH\tERROR")
+	for _, c := range cc {
+		status, msg := "OK", ""
+		if c.Err != nil {
+			status, msg = "FAILED", c.Err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.Namespace, c.Secret, orNA(c.Registry), status, msg)
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	b.WriteString("\n=== Image pull failures ===\n")
+	if len(ff) == 0 {
+		b.WriteString("(none)\n")
+		return b.String(), nil
+	}
+	w = tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tIMAGE\tREASON\tCAUSE\tPULL-SECRETS")
+	for _, f := range ff {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			f.Namespace, f.Pod, f.Container, f.Image, f.Reason, f.Class, orNA(strings.Join(f.Secrets, ",")))
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	b.WriteString("\n=== Messages ===\n")
+	for _, f := range ff {
+		fmt.Fprintf(&b, "%s/%s[%s]: %s\n", f.Namespace, f.Pod, f.Container, f.Message)
+	}
+
+	return b.String(), nil
+}
+
+// probeImage returns the image used by diagnostic pods.
+func probeImage(a *App) string {
+	if sp := a.Config.K9s.ShellPod; sp != nil && sp.Image != "" {
+		return sp.Image
+	}
+
+	return defaultProbeImage
+}
+
+// runOnNode runs a command in a short lived pod pinned to a node, optionally
+// mounting host files read-only under the same path. Probe containers share the
+// node kernel, clock and network. Pods missing a host file fail to start.
+func runOnNode(ctx context.Context, a *App, ctxName, ns, node, prefix string, hostFiles []string, cmd ...string) (string, error) {
+	spec := map[string]any{
+		"nodeName":    node,
+		"tolerations": []map[string]string{{"operator": "Exists"}},
+	}
+	if len(hostFiles) == 0 {
+		return runProbePod(ctx, a, ctxName, ns, prefix, spec, cmd)
+	}
+	vols, mounts := make([]map[string]any, 0, len(hostFiles)), make([]map[string]any, 0, len(hostFiles))
+	for i, p := range hostFiles {
+		v := fmt.Sprintf("host-%d", i)
+		vols = append(vols, map[string]any{"name": v, "hostPath": map[string]string{"path": p, "type": "File"}})
+		mounts = append(mounts, map[string]any{"name": v, "mountPath": p, "readOnly": true})
+	}
+	spec["volumes"] = vols
+	spec["containers"] = []map[string]any{{
+		"name":         prefix,
+		"image":        probeImage(a),
+		"command":      cmd,
+		"volumeMounts": mounts,
+	}}
+
+	return runProbePod(ctx, a, ctxName, ns, prefix, spec, nil, "--pod-running-timeout", hostMountTimeout.String())
+}
+
+// runOnHost runs a shell script in the host namespaces of a node from a
+// privileged pod, the way node-shell does. The pod lands in kube-system which
+// admits privileged pods on RKE2/K3s.
+func runOnHost(ctx context.Context, a *App, ctxName, node, prefix, script string) (string, error) {
+	spec := map[string]any{
+		"nodeName":    node,
+		"hostPID":     true,
+		"hostNetwork": true,
+		"tolerations": []map[string]string{{"operator": "Exists"}},
+		"containers": []map[string]any{{
+			"name":            prefix,
+			"image":           probeImage(a),
+			"command":         []string{"nsenter", "-t", "1", "-m", "-u", "-i", "-n", "-p", "--", "sh", "-c", script},
+			"securityContext": map[string]bool{"privileged": true},
+		}},
+	}
+
+	return runProbePod(ctx, a, ctxName, hostProbeNamespace, prefix, spec, nil)
+}
+
+// runProbePod runs a throwaway pod with the given spec overrides and returns
+// its output. Commands are only passed to kubectl when the overrides do not
+// define the containers. Extra flags are passed to kubectl run.
+func runProbePod(ctx context.Context, a *App, ctxName, ns, prefix string, spec map[string]any, cmd []string, flags ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, nodeProbeDeadline)
+	defer cancel()
+
+	overrides, err := json.Marshal(map[string]any{"apiVersion": "v1", "spec": spec})
+	if err != nil {
+		return "", err
+	}
+	args := []string{
+		"run", prefix + "-" + rand.String(5),
+		"-n", ns,
+		"--image", probeImage(a),
+		"--overrides", string(overrides),
+		"--restart=Never", "--rm", "--attach", "--quiet",
+	}
+	args = append(args, flags...)
+	if len(cmd) > 0 {
+		args = append(append(args, "--command", "--"), cmd...)
+	}
+
+	return runKu(ctx, a, &shellOpts{overrideContext: ctxName, args: args})
+}
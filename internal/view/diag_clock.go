@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+)
+
+// timeSkewDiag compares the node clocks of the active or selected contexts
+// against the local clock using kubelet heartbeats and per node date probes.
+func timeSkewDiag(ctx context.Context, a *App, ns, _ string) (string, error) {
+	if ns == client.BlankNamespace {
+		ns = client.DefaultNamespace
+	}
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return "", err
+	}
+	ctxs := []string{a.Config.K9s.ActiveContextName()}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		ctxs = sel
+	}
+
+	var (
+		all  []dao.NodeClock
+		errs []string
+	)
+	for _, ctxName := range ctxs {
+		cc, err := dao.FetchNodeClocks(rawCfg, ctxName)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", ctxName, err))
+			continue
+		}
+		all = append(all, cc...)
+	}
+	now := time.Now()
+	probe := !a.Config.IsReadOnly()
+	if probe {
+		probeClocks(ctx, a, ns, all)
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CONTEXT\tNODE\tCONTROL-PLANE\tHEARTBEAT-AGE\tPROBE-OFFSET")
+	for _, c := range all {
+		hb := client.NA
+		if !c.Heartbeat.IsZero() {
+			hb = now.Sub(c.Heartbeat).Round(time.Second).String()
+		}
+		off := client.NA
+		switch {
+		case c.ProbeErr != nil:
+			off = "error: " + c.ProbeErr.Error()
+		case c.Probed:
+			off = c.Offset.String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\n", c.Context, c.Node, c.ControlPlane, hb, off)
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	if !probe {
+		b.WriteString("\nDate probes skipped in read-only mode.\n")
+	}
+	b.WriteString("\n=== Issues ===\n")
+	for _, e := range errs {
+		fmt.Fprintf(&b, "! %s\n", e)
+	}
+	ii := dao.ClockSkewIssues(all, now)
+	if len(ii) == 0 && len(errs) == 0 {
+		fmt.Fprintf(&b, "(none, all clocks within %s)\n", dao.ClockSkewTolerance)
+	}
+	for _, i := range ii {
+		fmt.Fprintf(&b, "! %s\n", i)
+	}
+	b.WriteString("\nProbe offsets are lower bounds measured against this workstation clock, heartbeat ages above the lease renew interval (10s) point to stale kubelets.\n")
+
+	return b.String(), nil
+}
+
+func probeClocks(ctx context.Context, a *App, ns string, cc []dao.NodeClock) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, nodeProbeParallel)
+	for i := range cc {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(c *dao.NodeClock) {
+			defer func() { <-sem; wg.Done() }()
+			start := time.Now()
+			out, err := runOnNode(ctx, a, c.Context, ns, c.Node, "rk9s-clock", nil, "date", "-u", "+%s")
+			c.Probed = true
+			if err != nil {
+				c.ProbeErr = err
+				return
+			}
+			c.Offset, c.ProbeErr = dao.ProbeOffset(out, start, time.Now())
+		}(&cc[i])
+	}
+	wg.Wait()
+}
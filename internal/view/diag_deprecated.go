@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+)
+
+// deprecationsRefresh tracks how often the deprecated APIs monitor refreshes.
+const deprecationsRefresh = time.Minute
+
+// deprecatedAPIsDiag lists the deprecated APIs requested on the active or
+// selected contexts. The optional arg points to a local JSON audit log used
+// to identify the clients calling them.
+func deprecatedAPIsDiag(ctx context.Context, a *App, _, arg string) (string, error) {
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return "", err
+	}
+	ctxs := []string{a.Config.K9s.ActiveContextName()}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		ctxs = sel
+	}
+
+	var (
+		all  []dao.DeprecatedAPI
+		errs []string
+	)
+	for _, ctxName := range ctxs {
+		dd, err := dao.FetchDeprecatedAPIs(ctx, rawCfg, ctxName)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", ctxName, err))
+			continue
+		}
+		all = append(all, dd...)
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CONTEXT\tAPI\tRESOURCE\tREMOVED IN")
+	for _, d := range all {
+		res := d.Resource
+		if d.Subresource != "" {
+			res += "/" + d.Subresource
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", d.Context, d.GroupVersion(), res, orNA(d.RemovedRelease))
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	if arg != "" {
+		if err := writeDeprecatedCallers(&b, arg); err != nil {
+			return "", err
+		}
+	}
+
+	b.WriteString("\n=== Issues ===\n")
+	for _, e := range errs {
+		fmt.Fprintf(&b, "! %s\n", e)
+	}
+	for _, d := range all {
+		if d.RemovedRelease != "" {
+			fmt.Fprintf(&b, "! %s: %s %s is removed in %s\n", d.Context, d.GroupVersion(), d.Resource, d.RemovedRelease)
+		}
+	}
+	if len(errs) == 0 && len(all) == 0 {
+		b.WriteString("(none, no deprecated API requested since the API servers started)\n")
+	}
+	b.WriteString("\nMetrics are reset when an API server restarts and only cover the API server answering the scrape. Pass a JSON audit log, ie `:deprecations /var/lib/rancher/rke2/server/logs/audit.log`, to list the clients calling them.\n")
+
+	return b.String(), nil
+}
+
+func writeDeprecatedCallers(b *strings.Builder, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	cc, err := dao.DeprecatedCallers(f)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(b, "\n=== Callers (%s) ===\n", path)
+	if len(cc) == 0 {
+		b.WriteString("(none, no deprecated API calls annotated in the audit log)\n")
+		return nil
+	}
+	w := tabwriter.NewWriter(b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "USER\tUSER AGENT\tAPI\tREMOVED IN\tCALLS\tLAST")
+	for _, c := range cc {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n", c.User, c.UserAgent, c.API, orNA(c.RemovedRelease), c.Count, c.Last.Format(time.DateTime))
+	}
+
+	return w.Flush()
+}
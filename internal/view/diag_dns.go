@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	dnsDefaultLookup  = "kubernetes.default"
+	dnsExternalLookup = "kubernetes.io"
+)
+
+// dnsDiag checks the cluster DNS of the active or selected contexts and
+// resolves the given name from a throwaway pod.
+func dnsDiag(ctx context.Context, a *App, ns, arg string) (string, error) {
+	lookup := strings.TrimSpace(arg)
+	if lookup == "" {
+		lookup = dnsDefaultLookup
+	}
+	if ns == client.BlankNamespace {
+		ns = client.DefaultNamespace
+	}
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return "", err
+	}
+	ctxs := []string{a.Config.K9s.ActiveContextName()}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		ctxs = sel
+	}
+
+	var b strings.Builder
+	for _, ctxName := range ctxs {
+		fmt.Fprintf(&b, "########## %s ##########\n", ctxName)
+		r, err := dao.FetchDNSReport(rawCfg, ctxName)
+		if err != nil {
+			fmt.Fprintf(&b, "Error: %s\n\n", err)
+			continue
+		}
+		renderDNSReport(&b, r)
+		b.WriteString("\n=== Live lookups ===\n")
+		if a.Config.IsReadOnly() {
+			b.WriteString("skipped, read-only mode does not allow test pods\n\n")
+			continue
+		}
+		out, err := dnsProbe(ctx, a, ctxName, ns, lookup)
+		if err != nil {
+			fmt.Fprintf(&b, "probe failed: %s\n", err)
+		}
+		b.WriteString(out + "\n")
+		for _, issue := range dao.ResolvConfIssues(out, dnsExternalLookup) {
+			fmt.Fprintf(&b, "! %s\n", issue)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String(), nil
+}
+
+func renderDNSReport(b *strings.Builder, r *dao.DNSReport) {
+	w := tabwriter.NewWriter(b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "POD\tNODE\tREADY\tRESTARTS")
+	for _, p := range r.Pods {
+		fmt.Fprintf(w, "%s\t%s\t%t\t%d\n", p.Name, orNA(p.Node), p.Ready, p.Restarts)
+	}
+	_ = w.Flush()
+
+	fmt.Fprintf(b, "\nConfig:     %s\n", orNA(r.ConfigMap))
+	fmt.Fprintf(b, "Upstreams:  %s\n", orNA(strings.Join(r.Upstreams, ", ")))
+	fmt.Fprintf(b, "Node cache: %s\n", orNA(r.NodeLocal))
+
+	b.WriteString("\n=== Issues ===\n")
+	if len(r.Issues) == 0 {
+		b.WriteString("(none)\n")
+	}
+	for _, issue := range r.Issues {
+		fmt.Fprintf(b, "! %s\n", issue)
+	}
+}
+
+// dnsProbe resolves the given name, a cluster name and an external name from a
+// short lived pod, exercising CoreDNS and its upstreams.
+func dnsProbe(ctx context.Context, a *App, ctxName, ns, lookup string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, nodeProbeDeadline)
+	defer cancel()
+
+	names := []string{lookup}
+	if lookup != dnsDefaultLookup {
+		names = append(names, dnsDefaultLookup)
+	}
+	names = append(names, dnsExternalLookup)
+
+	return runKu(ctx, a, &shellOpts{
+		overrideContext: ctxName,
+		args: []string{
+			"run", "rk9s-dns-" + rand.String(5),
+			"-n", ns,
+			"--image", probeImage(a),
+			"--restart=Never", "--rm", "--attach", "--quiet",
+			"--command", "--", "sh", "-c", dao.DNSProbeScript(names...),
+		},
+	})
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+)
+
+// dsGapsDiag lists the nodes missing a ready DaemonSet pod on the active or
+// selected contexts. The optional arg restricts the DaemonSets by [NS/]NAME.
+func dsGapsDiag(_ context.Context, a *App, ns, arg string) (string, error) {
+	if strings.Contains(arg, "/") {
+		ns, arg = client.Namespaced(arg)
+	}
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return "", err
+	}
+	ctxs := []string{a.Config.K9s.ActiveContextName()}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		ctxs = sel
+	}
+
+	var all []dao.DaemonSetGap
+	for _, ctxName := range ctxs {
+		gg, err := dao.FetchDaemonSetGaps(rawCfg, ctxName, ns, arg)
+		if err != nil {
+			return "", err
+		}
+		all = append(all, gg...)
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CONTEXT\tDAEMONSET\tNODE\tSTATE\tREASON")
+	for _, g := range all {
+		state := "MISSING"
+		if g.Excluded {
+			state = "EXCLUDED"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", g.Context, g.DaemonSet, g.Node, state, g.Reason)
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	b.WriteString("\n=== Issues ===\n")
+	var n int
+	for _, g := range all {
+		if !g.Excluded {
+			fmt.Fprintf(&b, "! %s: %s on %s: %s\n", g.Context, g.DaemonSet, g.Node, g.Reason)
+			n++
+		}
+	}
+	if n == 0 {
+		b.WriteString("(none, every eligible node runs a ready pod)\n")
+	}
+	b.WriteString("\nExcluded nodes are skipped by the DaemonSet nodeSelector, node affinity or tolerations; check them for node wide agents such as CNI, storage or monitoring.\n")
+
+	return b.String(), nil
+}
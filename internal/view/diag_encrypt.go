@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const encryptionPollTick = 5 * time.Second
+
+// encryptionDiag reports the secrets encryption status of the RKE2/K3s
+// servers of the active or selected contexts.
+func encryptionDiag(ctx context.Context, a *App, _, _ string) (string, error) {
+	if a.Config.IsReadOnly() {
+		return "", errors.New("secrets-encrypt status requires host probe pods, not allowed in read-only mode")
+	}
+	ctxs := []string{a.Config.K9s.ActiveContextName()}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		ctxs = sel
+	}
+	ss, err := encryptionStatuses(ctx, a, ctxs)
+	if len(ss) == 0 {
+		return "", err
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CONTEXT\tSERVER\tSTATUS\tSTAGE\tHASHES\tACTIVE-KEY\tKEYS")
+	for _, s := range ss {
+		if s.Err != nil {
+			fmt.Fprintf(w, "%s\t%s\terror: %s\t\t\t\t\n", s.Context, s.Node, s.Err)
+			continue
+		}
+		active := ""
+		for _, k := range s.Keys {
+			if k.Active {
+				active = k.Type + " " + k.Name
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n", s.Context, s.Node, s.Status, orNA(s.Stage), orNA(s.Hashes), orNA(active), len(s.Keys))
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	b.WriteString("\n=== Issues ===\n")
+	ii := dao.EncryptionIssues(ss)
+	if len(ii) == 0 {
+		b.WriteString("(none)\n")
+	}
+	for _, i := range ii {
+		fmt.Fprintf(&b, "! %s\n", i)
+	}
+	b.WriteString("\nRun `:rotate-encryption` to rotate the encryption keys of the active context.\n")
+
+	return b.String(), err
+}
+
+func encryptionStatuses(ctx context.Context, a *App, ctxs []string) ([]dao.EncryptionStatus, error) {
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return nil, err
+	}
+	oo, lerr := dao.MultiContextList(ctx, rawCfg, ctxs, client.NodeGVR.GVR(), client.BlankNamespace, dao.ControlPlaneSelector)
+	if len(answeredContexts(ctxs, lerr)) == 0 {
+		return nil, lerr
+	}
+	ss := make([]dao.EncryptionStatus, 0, len(oo))
+	for _, co := range oo {
+		if u, ok := co.Object.(*unstructured.Unstructured); ok {
+			ss = append(ss, dao.EncryptionStatus{Context: co.Context, Node: u.GetName()})
+		}
+	}
+	if len(ss) == 0 {
+		return nil, errors.Join(fmt.Errorf("no server nodes matching %s", dao.ControlPlaneSelector), lerr)
+	}
+	sort.Slice(ss, func(i, j int) bool {
+		return ss[i].ID() < ss[j].ID()
+	})
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, nodeProbeParallel)
+	for i := range ss {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(s *dao.EncryptionStatus) {
+			defer func() { <-sem; wg.Done() }()
+			*s = encryptionStatus(ctx, a, s.Context, s.Node)
+		}(&ss[i])
+	}
+	wg.Wait()
+
+	return ss, lerr
+}
+
+func encryptionStatus(ctx context.Context, a *App, ctxName, node string) dao.EncryptionStatus {
+	out, err := runOnHost(ctx, a, ctxName, node, "rk9s-encrypt", dao.DistroScript("$bin secrets-encrypt status"))
+	if err != nil {
+		return dao.EncryptionStatus{Context: ctxName, Node: node, Err: err}
+	}
+	s, err := dao.ParseEncryptionStatus(out)
+	s.Context, s.Node, s.Err = ctxName, node, err
+
+	return s
+}
+
+// encryptionRotationWorkflow rotates the secrets encryption keys of the active
+// context: rotate on the first server, wait for secrets to be re-encrypted and
+// restart every server one at a time.
+func encryptionRotationWorkflow(a *App, _ string) (string, []workflowStep, error) {
+	ctxName := a.Config.K9s.ActiveContextName()
+	servers, _, err := clusterNodes(a)
+	if err != nil {
+		return "", nil, err
+	}
+	lead := servers[0]
+
+	steps := []workflowStep{
+		{
+			title: fmt.Sprintf("Check secrets encryption status on %d servers", len(servers)),
+			run: func(ctx context.Context, log logFn) error {
+				ss, err := encryptionStatuses(ctx, a, []string{ctxName})
+				if err != nil {
+					return err
+				}
+				for _, s := range ss {
+					log("%s: %s, stage %s, %s", s.Node, s.Status, orNA(s.Stage), orNA(s.Hashes))
+				}
+				if ii := dao.EncryptionIssues(ss); len(ii) > 0 {
+					return errors.New(strings.Join(ii, "; "))
+				}
+				return nil
+			},
+		},
+		{
+			title: "Rotate encryption keys on " + lead,
+			run: func(ctx context.Context, log logFn) error {
+				out, err := runOnHost(ctx, a, ctxName, lead, "rk9s-encrypt", dao.DistroScript("$bin secrets-encrypt rotate-keys"))
+				for _, l := range strings.Split(strings.TrimSpace(out), "\n") {
+					log("%s", l)
+				}
+				return err
+			},
+		},
+		{
+			title: "Wait for secrets re-encryption on " + lead,
+			run: func(ctx context.Context, log logFn) error {
+				var last string
+				for {
+					s := encryptionStatus(ctx, a, ctxName, lead)
+					if s.Err == nil && s.Stage != last {
+						log("rotation stage %s", s.Stage)
+						last = s.Stage
+					}
+					if s.Stage == dao.EncryptionStageFinished {
+						return nil
+					}
+					select {
+					case <-ctx.Done():
+						return fmt.Errorf("re-encryption did not finish (stage %s)", orNA(last))
+					case <-time.After(encryptionPollTick):
+					}
+				}
+			},
+		},
+	}
+	for _, node := range servers {
+		steps = append(steps, hostRestartStep(a, ctxName, node, "Restart server service on "+node, dao.DistroServiceScript()))
+	}
+	steps = append(steps, workflowStep{
+		title: "Verify encryption hashes match on all servers",
+		run: func(ctx context.Context, log logFn) error {
+			ss, err := encryptionStatuses(ctx, a, []string{ctxName})
+			if err != nil {
+				return err
+			}
+			if ii := dao.EncryptionIssues(ss); len(ii) > 0 {
+				return errors.New(strings.Join(ii, "; "))
+			}
+			log("all servers report %s", ss[0].Hashes)
+			return nil
+		},
+	})
+
+	return ctxName, steps, nil
+}
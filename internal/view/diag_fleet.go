@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+)
+
+// agentReachDiag checks the downstream Rancher/Fleet agents of the selected
+// contexts reach the management URL and that the active management context
+// sees their heartbeats.
+func agentReachDiag(ctx context.Context, a *App, _, _ string) (string, error) {
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return "", err
+	}
+	mgmt := a.Config.K9s.ActiveContextName()
+	hh, err := dao.FetchFleetHeartbeats(ctx, rawCfg, mgmt)
+	if err != nil {
+		return "", err
+	}
+	sel, _ := config.LoadSelectedContexts()
+	sel = slices.DeleteFunc(slices.Clone(sel), func(s string) bool { return s == mgmt })
+
+	exec := !a.Config.IsReadOnly()
+	pp := make([]dao.AgentProbe, len(sel))
+	var wg sync.WaitGroup
+	for i, ctxName := range sel {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pp[i] = dao.ProbeAgent(ctx, rawCfg, ctxName, exec)
+		}()
+	}
+	wg.Wait()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Management context: %s\n\n", mgmt)
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CONTEXT\tCLUSTER\tAGENT-PROBE\tHEARTBEAT\tVERDICT")
+	rr := dao.FleetReachability(hh, pp, time.Now())
+	for _, r := range rr {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", orNA(r.Context), orNA(r.Cluster), r.Agent, r.Heartbeat, r.Verdict)
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	if len(sel) == 0 {
+		b.WriteString("\nNo downstream contexts selected, select them in `:contexts` to probe their agents.\n")
+	}
+	if !exec {
+		b.WriteString("\nAgent probes skipped in read-only mode.\n")
+	}
+	b.WriteString("\n=== Issues ===\n")
+	var n int
+	for _, r := range rr {
+		if r.Hint == "" {
+			continue
+		}
+		n++
+		fmt.Fprintf(&b, "! %s: %s\n", reachSubject(r), r.Hint)
+	}
+	if n == 0 {
+		b.WriteString("(none)\n")
+	}
+	fmt.Fprintf(&b, "\nHeartbeats older than %s or a disconnected Rancher tunnel count as stale. AgentSide breaks sit between the agent and the management URL, ManagementSide breaks past it.\n", dao.FleetHeartbeatTolerance)
+
+	return b.String(), nil
+}
+
+func reachSubject(r dao.FleetReach) string {
+	if r.Context != "" {
+		return r.Context
+	}
+
+	return r.Cluster
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+)
+
+// gvrMatrixDiag shows the served versions of resources of interest on the
+// active or selected contexts. The optional arg lists the `resource.group`
+// names to check, the CRDs of the tab groups otherwise.
+func gvrMatrixDiag(_ context.Context, a *App, _, arg string) (string, error) {
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return "", err
+	}
+	ctxs := []string{a.Config.K9s.ActiveContextName()}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		ctxs = sel
+	}
+	resources := strings.Fields(strings.ReplaceAll(arg, ",", " "))
+	if len(resources) == 0 {
+		resources = matrixResources()
+	}
+	cc := dao.FetchServedVersions(rawCfg, ctxs)
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "RESOURCE\t%s\n", strings.Join(ctxs, "\t"))
+	for _, res := range resources {
+		vv := make([]string, 0, len(cc))
+		for _, c := range cc {
+			switch v, ok := c.Served[res]; {
+			case c.Err != nil:
+				vv = append(vv, "?")
+			case !ok:
+				vv = append(vv, "-")
+			default:
+				vv = append(vv, strings.Join(v, ","))
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\n", res, strings.Join(vv, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	b.WriteString("\nServed versions are listed preferred version first, `-` marks a missing resource and `?` a context that could not be reached.\n")
+
+	b.WriteString("\n=== Issues ===\n")
+	ii := dao.GVRMatrixIssues(resources, cc)
+	if len(ii) == 0 {
+		b.WriteString("(none, all contexts serve the same resources and versions)\n")
+	}
+	for _, i := range ii {
+		fmt.Fprintf(&b, "! %s\n", i)
+	}
+
+	return b.String(), nil
+}
+
+// matrixResources returns the CRDs of the tab groups and the CSI snapshot CRDs.
+func matrixResources() []string {
+	var rr []string
+	for _, grp := range crdGroups {
+		for _, e := range grp {
+			if cmd, _, _ := parseCRDEntry(e); !strings.Contains(cmd, "/") {
+				rr = append(rr, cmd)
+			}
+		}
+	}
+
+	return append(rr,
+		"volumesnapshots.snapshot.storage.k8s.io",
+		"volumesnapshotclasses.snapshot.storage.k8s.io",
+		"volumesnapshotcontents.snapshot.storage.k8s.io",
+	)
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+)
+
+// helmDriftValueWidth caps the values width in the drift table.
+const helmDriftValueWidth = 40
+
+// helmDriftDiag diffs the chart versions and effective values of a Helm
+// release, or helm.cattle.io HelmChart, across the selected contexts, ie
+// `helmdrift [namespace/]release`.
+func helmDriftDiag(ctx context.Context, a *App, _, arg string) (string, error) {
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return "", errors.New("missing release name. Use `helmdrift [namespace/]release`")
+	}
+	ns, name := client.Namespaced(arg)
+	sel, _ := config.LoadSelectedContexts()
+	if len(sel) < 2 {
+		return "", errors.New("select 2+ contexts in `:contexts` to compare releases across")
+	}
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return "", err
+	}
+
+	flags := a.Conn().Config().Flags()
+	hh := make([]dao.HelmValues, len(sel))
+	var wg sync.WaitGroup
+	for i, ctxName := range sel {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hh[i] = dao.FetchHelmValues(ctx, flags, rawCfg, ctxName, ns, name)
+		}()
+	}
+	wg.Wait()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s ===\n", arg)
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CONTEXT\tSOURCE\tNAMESPACE\tCHART\tVERSION\tAPP-VERSION")
+	var (
+		ok    []string
+		chart bool
+	)
+	for _, h := range hh {
+		if h.Err != nil {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", h.Context, client.NA, orNA(h.Namespace), "error: "+h.Err.Error(), client.NA, client.NA)
+			continue
+		}
+		ok, chart = append(ok, h.Context), chart || h.Source == dao.HelmSourceChart
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", h.Context, h.Source, h.Namespace, orNA(h.Chart), orNA(h.Version), orNA(h.AppVersion))
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	b.WriteString("\n=== Values drift ===\n")
+	if len(ok) < 2 {
+		b.WriteString("(need the release on 2+ contexts)\n")
+		return b.String(), nil
+	}
+	dd := dao.HelmValuesDrift(hh)
+	if len(dd) == 0 {
+		b.WriteString("(none) effective values match across contexts\n")
+		return b.String(), nil
+	}
+	r := a.redactor()
+	w = tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "KEY\t%s\n", strings.Join(ok, "\t"))
+	for _, d := range dd {
+		vv := make([]string, 0, len(ok))
+		for _, c := range ok {
+			vv = append(vv, driftValue(r.Value(d.Key, d.Values[c])))
+		}
+		fmt.Fprintf(w, "%s\t%s\n", d.Key, strings.Join(vv, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	fmt.Fprintf(&b, "\n%d value(s) differ. `-` marks values unset on a context.\n", len(dd))
+	if chart {
+		b.WriteString("HelmChart values exclude the chart defaults, only the HelmChart and HelmChartConfig values are compared.\n")
+	}
+
+	return b.String(), nil
+}
+
+// driftValue returns a value fitting the drift table, truncated on a rune
+// boundary.
+func driftValue(v string) string {
+	if v == "" {
+		return "-"
+	}
+	if rr := []rune(v); len(rr) > helmDriftValueWidth {
+		return string(rr[:helmDriftValueWidth-3]) + "..."
+	}
+
+	return v
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDriftValue(t *testing.T) {
+	uu := map[string]struct {
+		v, e string
+	}{
+		"unset": {
+			e: "-",
+		},
+		"short": {
+			v: "blee",
+			e: "blee",
+		},
+		"long": {
+			v: strings.Repeat("a", helmDriftValueWidth+1),
+			e: strings.Repeat("a", helmDriftValueWidth-3) + "...",
+		},
+		"multibyte": {
+			v: strings.Repeat("é", helmDriftValueWidth+1),
+			e: strings.Repeat("é", helmDriftValueWidth-3) + "...",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			v := driftValue(u.v)
+			assert.Equal(t, u.e, v)
+			assert.True(t, utf8.ValidString(v))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+// longhornProbeArg requests the backup target probes.
+const longhornProbeArg = "probe"
+
+// longhornBackupsDiag validates the Longhorn backup targets, lists the
+// recurring jobs with their next run and flags volumes no recurring backup
+// covers. Targets are only probed from a pod when requested.
+func longhornBackupsDiag(ctx context.Context, a *App, _, arg string) (string, error) {
+	// Longhorn evaluates recurring job crons in UTC.
+	now := time.Now().UTC()
+	r, err := dao.FetchLonghornBackups(ctx, a.Conn(), now)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("=== Backup targets ===\n")
+	if len(r.Targets) == 0 {
+		b.WriteString("(none) volumes can only be snapshotted in-cluster\n")
+	} else {
+		probes := make([]string, len(r.Targets))
+		for i, t := range r.Targets {
+			probes[i] = "skipped, use `:lhbackups " + longhornProbeArg + "`"
+			if arg == longhornProbeArg {
+				probes[i] = longhornTargetProbe(ctx, a, t)
+			}
+		}
+		w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tURL\tCREDENTIALS\tAVAILABLE\tLAST-SYNC\tPROBE\tMESSAGE")
+		for i, t := range r.Targets {
+			last := client.NA
+			if !t.LastSync.IsZero() {
+				last = now.Sub(t.LastSync).Round(time.Second).String()
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\t%s\n",
+				t.Name, orNA(t.URL), orNA(t.Credentials), t.Available, last, probes[i], t.Message)
+		}
+		if err := w.Flush(); err != nil {
+			return "", err
+		}
+	}
+
+	b.WriteString("\n=== Recurring jobs ===\n")
+	if len(r.Jobs) == 0 {
+		b.WriteString("(none)\n")
+	} else {
+		w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tTASK\tCRON\tNEXT-RUN\tIN\tRETAIN\tCONCURRENCY\tGROUPS")
+		for _, j := range r.Jobs {
+			next, in := client.NA, client.NA
+			switch {
+			case j.Err != nil:
+				next = "invalid: " + j.Err.Error()
+			case !j.Next.IsZero():
+				next, in = j.Next.Local().Format(time.DateTime), j.Next.Sub(now).Round(time.Minute).String()
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\n",
+				j.Name, j.Task, j.Cron, next, in, j.Retain, j.Concurrency, orNA(strings.Join(j.Groups, ",")))
+		}
+		if err := w.Flush(); err != nil {
+			return "", err
+		}
+	}
+
+	b.WriteString("\n=== Volumes without recurring backup ===\n")
+	if len(r.Uncovered) == 0 {
+		b.WriteString("(none)\n")
+	} else {
+		w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VOLUME\tPVC\tSTATE")
+		for _, v := range r.Uncovered {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", v.Volume, orNA(v.PVC), orNA(v.State))
+		}
+		if err := w.Flush(); err != nil {
+			return "", err
+		}
+		b.WriteString("\nLabel them with `recurring-job.longhorn.io/<job>: enabled` or `recurring-job-group.longhorn.io/<group>: enabled`. Volumes without recurring job labels belong to the default group.\n")
+	}
+
+	return b.String(), nil
+}
+
+// longhornTargetProbe checks a backup target is reachable from a short lived
+// pod in the Longhorn namespace.
+func longhornTargetProbe(ctx context.Context, a *App, t dao.LonghornBackupTarget) string {
+	if a.Config.IsReadOnly() {
+		return "skipped (read-only)"
+	}
+	creds, err := dao.LonghornTargetCredentials(ctx, a.Conn(), t)
+	if err != nil {
+		return "failed: " + err.Error()
+	}
+	cmd, err := dao.LonghornTargetProbe(t, creds)
+	if err != nil {
+		return "skipped: " + err.Error()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, nodeProbeDeadline)
+	defer cancel()
+	out, err := runKu(ctx, a, &shellOpts{
+		args: append([]string{
+			"run", "rk9s-lhtarget-" + rand.String(5),
+			"-n", dao.LonghornNamespace,
+			"--image", probeImage(a),
+			"--restart=Never", "--rm", "--attach", "--quiet",
+			"--command", "--",
+		}, cmd...),
+	})
+	if err != nil {
+		return "failed: " + strings.TrimSpace(err.Error())
+	}
+
+	return strings.TrimSpace(out)
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+)
+
+// preemptionsDiag lists the pods preempted by the scheduler and, given a
+// [NS/]DEPLOYMENT, checks whether a new pod of it would preempt anything.
+func preemptionsDiag(ctx context.Context, a *App, ns, arg string) (string, error) {
+	pp, err := dao.FetchPreemptions(ctx, a.Conn(), ns)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("=== Preemptions ===\n")
+	if len(pp) == 0 {
+		b.WriteString("(none in the retained events)\n")
+	} else {
+		w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "LAST\tCOUNT\tPOD\tMESSAGE")
+		for _, p := range pp {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", p.Last.Format(time.DateTime), p.Count, client.FQN(p.Namespace, p.Pod), p.Message)
+		}
+		if err := w.Flush(); err != nil {
+			return "", err
+		}
+	}
+	if arg == "" {
+		b.WriteString("\nUse `:preemptions [NS/]DEPLOYMENT` to check whether a new pod of a deployment would preempt others.\n")
+		return b.String(), nil
+	}
+
+	fqn := arg
+	if !strings.Contains(fqn, "/") {
+		if ns == client.BlankNamespace {
+			ns = client.DefaultNamespace
+		}
+		fqn = client.FQN(ns, arg)
+	}
+	prio, ff, err := dao.DeploymentWhatIf(ctx, a.Conn(), fqn)
+	if err != nil {
+		return b.String(), err
+	}
+	fmt.Fprintf(&b, "\n=== What if %s schedules a new pod (priority %d) ===\n", fqn, prio)
+	var fit, preempt []string
+	for _, f := range ff {
+		switch {
+		case f.Fits:
+			fit = append(fit, f.Node)
+		case len(f.Victims) > 0:
+			preempt = append(preempt, f.Node)
+			fmt.Fprintf(&b, "%s: would preempt %s\n", f.Node, strings.Join(f.Victims, ", "))
+		default:
+			fmt.Fprintf(&b, "%s: no room even after preempting lower priority pods\n", f.Node)
+		}
+	}
+	switch {
+	case len(fit) > 0:
+		fmt.Fprintf(&b, "Fits without preemption on %s\n", strings.Join(fit, ", "))
+	case len(preempt) > 0:
+		fmt.Fprintf(&b, "! %s would preempt pods, the scheduler picks the node with the fewest and lowest priority victims\n", fqn)
+	default:
+		fmt.Fprintf(&b, "! %s would stay pending, no node has room\n", fqn)
+	}
+	b.WriteString("\nOnly cpu and memory requests are accounted for, taints, affinities and PodDisruptionBudgets aren't.\n")
+
+	return b.String(), nil
+}
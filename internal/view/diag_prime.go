@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+)
+
+// primeDiag reports which builds the SUSE Rancher Prime supported components
+// run on the active or selected contexts and flags community builds running
+// on production contexts.
+func primeDiag(ctx context.Context, a *App, _, _ string) (string, error) {
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return "", err
+	}
+	ctxs := []string{a.Config.K9s.ActiveContextName()}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		ctxs = sel
+	}
+	prime := a.Config.K9s.Prime
+
+	var (
+		b      strings.Builder
+		issues []string
+	)
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CONTEXT\tPRODUCTION\tRANCHER-PRIME\tCOMPONENT\tBUILD\tIMAGES")
+	for _, ctxName := range ctxs {
+		prod, err := prime.IsProduction(ctxName)
+		if err != nil {
+			return "", err
+		}
+		r, err := dao.FetchPrimeReport(ctx, rawCfg, ctxName, prime.PrimeRegistries())
+		if err != nil {
+			fmt.Fprintf(w, "%s\t%t\t%s\t%s\t%s\t%s\n", ctxName, prod, client.NA, client.NA, client.NA, "error: "+err.Error())
+			continue
+		}
+		if len(r.Components) == 0 {
+			fmt.Fprintf(w, "%s\t%t\t%s\t%s\t%s\t%s\n", ctxName, prod, orNA(r.RancherPrime), client.NA, client.NA, client.NA)
+			continue
+		}
+		for _, c := range r.Components {
+			fmt.Fprintf(w, "%s\t%t\t%s\t%s\t%s\t%s\n", ctxName, prod, orNA(r.RancherPrime), c.Component, c.Build, strings.Join(c.Images, ","))
+			if prod && c.Build == dao.BuildCommunity {
+				issues = append(issues, fmt.Sprintf("%s: %s runs community images on a production context", ctxName, c.Component))
+			}
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	b.WriteString("\n=== Issues ===\n")
+	if len(issues) == 0 {
+		b.WriteString("(none)\n")
+	}
+	for _, i := range issues {
+		fmt.Fprintf(&b, "! %s\n", i)
+	}
+	if !prime.HasProduction() {
+		b.WriteString("\nNo production contexts listed, community builds are not flagged. List them under `k9s.prime.production`.\n")
+	}
+	fmt.Fprintf(&b, "\nPrime builds come from %s. Mirrored builds come from other registries and can not be told apart, list Prime mirrors under `k9s.prime.registries`.\n",
+		strings.Join(prime.PrimeRegistries(), ", "))
+
+	return b.String(), nil
+}
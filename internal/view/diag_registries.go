@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// mirrorsDiag dumps the RKE2/K3s registry mirrors configured on each node of
+// the active or selected contexts and flags nodes that disagree.
+func mirrorsDiag(ctx context.Context, a *App, ns, _ string) (string, error) {
+	if a.Config.IsReadOnly() {
+		return "", fmt.Errorf("reading node registries.yaml requires probe pods, not allowed in read-only mode")
+	}
+	if ns == client.BlankNamespace {
+		ns = client.DefaultNamespace
+	}
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return "", err
+	}
+	ctxs := []string{a.Config.K9s.ActiveContextName()}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		ctxs = sel
+	}
+	oo, err := dao.MultiContextList(ctx, rawCfg, ctxs, client.NodeGVR.GVR(), client.BlankNamespace, "")
+	if len(answeredContexts(ctxs, err)) == 0 {
+		return "", err
+	}
+	nn := make([]dao.NodeRegistries, 0, len(oo))
+	for _, co := range oo {
+		if u, ok := co.Object.(*unstructured.Unstructured); ok {
+			kubelet, _, _ := unstructured.NestedString(u.Object, "status", "nodeInfo", "kubeletVersion")
+			nn = append(nn, dao.NodeRegistries{Context: co.Context, Node: u.GetName(), Kubelet: kubelet})
+		}
+	}
+	sort.Slice(nn, func(i, j int) bool {
+		return nn[i].ID() < nn[j].ID()
+	})
+	probeRegistries(ctx, a, ns, nn)
+
+	var b strings.Builder
+	for _, n := range nn {
+		fmt.Fprintf(&b, "=== %s ===\n", n.ID())
+		switch {
+		case n.Err != nil:
+			fmt.Fprintf(&b, "probe failed: %s\n\n", n.Err)
+			continue
+		case n.Config == nil:
+			b.WriteString("no registries.yaml, images are pulled from upstream registries\n\n")
+			continue
+		}
+		fmt.Fprintf(&b, "%s\n", n.Path)
+		for _, s := range dao.RegistriesSettings(n.Config) {
+			fmt.Fprintf(&b, "  %s\n", s)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("=== Drifts ===\n")
+	dd := dao.RegistriesDrifts(nn)
+	if len(dd) == 0 {
+		b.WriteString("(none, all probed nodes share the same mirror config)\n")
+	}
+	for _, d := range dd {
+		fmt.Fprintf(&b, "! %s\n", d)
+	}
+
+	return b.String(), err
+}
+
+func probeRegistries(ctx context.Context, a *App, ns string, nn []dao.NodeRegistries) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, nodeProbeParallel)
+	for i := range nn {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(n *dao.NodeRegistries) {
+			defer func() { <-sem; wg.Done() }()
+			path, err := dao.RegistriesHostPath(n.Kubelet)
+			if err != nil {
+				n.Err = err
+				return
+			}
+			out, err := runOnNode(ctx, a, n.Context, ns, n.Node, "rk9s-mirrors", []string{path}, "sh", "-c", dao.RegistriesProbeScript())
+			if err != nil {
+				n.Err = fmt.Errorf("%w, check %s exists on the node", err, path)
+				return
+			}
+			n.Path, n.Config, n.Err = dao.ParseRegistriesProbe(out)
+		}(&nn[i])
+	}
+	wg.Wait()
+}
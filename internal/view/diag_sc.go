@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+)
+
+// scMatrixDiag compares the storage classes of the active or selected
+// contexts and flags the drifts breaking portable manifests.
+func scMatrixDiag(_ context.Context, a *App, _, _ string) (string, error) {
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return "", err
+	}
+	ctxs := []string{a.Config.K9s.ActiveContextName()}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		ctxs = sel
+	}
+	cc, err := dao.FetchStorageClassCaps(rawCfg, ctxs)
+	if ctxs = answeredContexts(ctxs, err); len(ctxs) == 0 {
+		return "", err
+	}
+
+	byName := make(map[string]map[string]dao.StorageClassCaps)
+	var names []string
+	for _, c := range cc {
+		if byName[c.Name] == nil {
+			byName[c.Name] = make(map[string]dao.StorageClassCaps)
+			names = append(names, c.Name)
+		}
+		byName[c.Name][c.Context] = c
+	}
+
+	var b strings.Builder
+	for _, n := range names {
+		fmt.Fprintf(&b, "=== %s ===\n", n)
+		w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(w, "ATTRIBUTE\t%s\n", strings.Join(ctxs, "\t"))
+		for _, attr := range dao.StorageClassAttrs(cc) {
+			vv, set := make([]string, 0, len(ctxs)), false
+			for _, ctx := range ctxs {
+				c, ok := byName[n][ctx]
+				switch {
+				case !ok:
+					vv = append(vv, "-")
+				case c.Attrs[attr] == "":
+					vv = append(vv, "")
+				default:
+					vv, set = append(vv, c.Attrs[attr]), true
+				}
+			}
+			if set {
+				fmt.Fprintf(w, "%s\t%s\n", attr, strings.Join(vv, "\t"))
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return "", err
+		}
+		b.WriteString("\n")
+	}
+	if len(names) == 0 {
+		b.WriteString("No storage classes found.\n\n")
+	}
+
+	b.WriteString("=== Drifts ===\n")
+	dd := dao.StorageClassDrifts(ctxs, cc)
+	if len(ctxs) < 2 {
+		b.WriteString("(select several contexts to compare them)\n")
+	} else if len(dd) == 0 {
+		b.WriteString("(none, storage classes match on all contexts)\n")
+	}
+	for _, d := range dd {
+		fmt.Fprintf(&b, "! %s\n", d)
+	}
+
+	return b.String(), err
+}
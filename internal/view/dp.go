@@ -29,8 +29,10 @@ func NewDeploy(gvr *client.GVR) ResourceViewer {
 			NewRestartExtender(
 				NewScaleExtender(
 					NewImageExtender(
-						NewOwnerExtender(
-							NewLogsExtender(NewBrowser(gvr), d.logOptions),
+						NewWaitExtender(
+							NewOwnerExtender(
+								NewLogsExtender(NewBrowser(gvr), d.logOptions),
+							),
 						),
 					),
 				),
@@ -45,8 +47,17 @@ func NewDeploy(gvr *client.GVR) ResourceViewer {
 
 func (d *Deploy) bindKeys(aa *ui.KeyActions) {
 	aa.Bulk(ui.KeyMap{
-		ui.KeyZ: ui.NewKeyAction("ReplicaSets", d.replicaSetsCmd, true),
+		ui.KeyZ:      ui.NewKeyAction("ReplicaSets", d.replicaSetsCmd, true),
+		ui.KeyShiftW: ui.NewKeyAction("Watch Rollout", d.rolloutCmd, true),
 	})
+	if d.App().Config.IsReadOnly() {
+		return
+	}
+	aa.Add(ui.KeyShiftI, ui.NewKeyActionWithOpts("Promote Image", d.promoteImageCmd,
+		ui.ActionOpts{
+			Visible:   true,
+			Dangerous: true,
+		}))
 }
 
 func (d *Deploy) logOptions(prev bool) (*dao.LogOptions, error) {
@@ -76,6 +87,16 @@ func (d *Deploy) replicaSetsCmd(evt *tcell.EventKey) *tcell.EventKey {
 	return nil
 }
 
+func (d *Deploy) rolloutCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := d.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	showRollout(d.App(), path)
+
+	return nil
+}
+
 func (d *Deploy) showPods(app *App, _ ui.Tabular, _ *client.GVR, fqn string) {
 	dp, err := d.getInstance(fqn)
 	if err != nil {
@@ -18,8 +18,17 @@ const drainKey = "drain"
 // DrainFunc represents a drain callback function.
 type DrainFunc func(v ResourceViewer, sels []string, opts dao.DrainOptions)
 
+// DrainNotice tracks the storage safety findings of a drain.
+type DrainNotice struct {
+	// Longhorn indicates Longhorn is installed on the cluster.
+	Longhorn bool
+
+	// Warning lists the volumes at risk if any.
+	Warning string
+}
+
 // ShowDrain pops a node drain dialog.
-func ShowDrain(view ResourceViewer, sels []string, opts dao.DrainOptions, okFn DrainFunc) {
+func ShowDrain(view ResourceViewer, sels []string, opts dao.DrainOptions, notice DrainNotice, okFn DrainFunc) {
 	styles := view.App().Styles.Dialog()
 
 	f := tview.NewForm().
@@ -61,6 +70,11 @@ func ShowDrain(view ResourceViewer, sels []string, opts dao.DrainOptions, okFn D
 	f.AddCheckbox("Disable Eviction:", opts.DisableEviction, func(_ string, v bool) {
 		opts.DisableEviction = v
 	})
+	if notice.Longhorn {
+		f.AddCheckbox("Evict Longhorn Replicas:", opts.EvictLonghornReplicas, func(_ string, v bool) {
+			opts.EvictLonghornReplicas = v
+		})
+	}
 
 	pages := view.App().Content.Pages
 	f.AddButton("Cancel", func() {
@@ -79,6 +93,9 @@ func ShowDrain(view ResourceViewer, sels []string, opts dao.DrainOptions, okFn D
 		path += fmt.Sprintf("(%d) nodes", len(sels))
 	}
 	path += "?"
+	if notice.Warning != "" {
+		path += "\n\n" + notice.Warning
+	}
 	modal.SetText(path)
 	modal.SetDoneFunc(func(int, string) {
 		DismissDrain(view, pages)
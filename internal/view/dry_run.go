@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/tcell/v2"
+)
+
+const dryRunDeadline = 30 * time.Second
+
+func (b *Browser) dryRunCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := b.GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+
+	ctxOverride, realPath := model1.SplitMultiContextID(path)
+	if ctxOverride != "" {
+		path = realPath
+	}
+
+	b.Stop()
+	defer b.Start()
+	if err := dryRunRes(b.app, b.GVR(), path, ctxOverride); err != nil {
+		b.App().Flash().Err(err)
+	}
+
+	return nil
+}
+
+// dryRunRes lets the user tweak a resource manifest in the editor, then runs it
+// through a server side dry-run so all mutating/validating admission webhooks
+// get a say. The webhook verdicts and the final mutated object are displayed.
+func dryRunRes(app *App, gvr *client.GVR, path, ctxOverride string) error {
+	ns, n := client.Namespaced(path)
+	if n == "" {
+		return fmt.Errorf("missing resource name in path %q", path)
+	}
+	args := []string{"get", gvr.FQN(n), "-o", "yaml"}
+	if !client.IsClusterScoped(ns) && ns != client.BlankNamespace {
+		args = append(args, "-n", ns)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dryRunDeadline)
+	defer cancel()
+	raw, err := runKu(ctx, app, &shellOpts{args: args, overrideContext: ctxOverride})
+	if err != nil {
+		return fmt.Errorf("unable to fetch %s: %w", path, err)
+	}
+
+	f, err := os.CreateTemp("", "rk9s-dry-run-*.yaml")
+	if err != nil {
+		return err
+	}
+	file := f.Name()
+	if _, err := f.WriteString(raw); err != nil {
+		_ = f.Close()
+		return errors.Join(err, os.Remove(file))
+	}
+	if err := f.Close(); err != nil {
+		return errors.Join(err, os.Remove(file))
+	}
+	if !edit(app, &shellOpts{clear: true, args: []string{file}}) {
+		return os.Remove(file)
+	}
+
+	app.Flash().Infof("Server dry-run for %s...", path)
+	go func() {
+		defer func() {
+			if err := os.Remove(file); err != nil {
+				slog.Warn("Dry-run manifest cleanup failed", slogs.Error, err)
+			}
+		}()
+		out := dryRunApply(app, file, ctxOverride)
+		app.QueueUpdateDraw(func() {
+			details := NewDetails(app, "Dry-Run", path, contentTXT, true).Update(out)
+			if e := app.inject(details, false); e != nil {
+				app.Flash().Err(e)
+			}
+		})
+	}()
+
+	return nil
+}
+
+func dryRunApply(app *App, file, ctxOverride string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), dryRunDeadline)
+	defer cancel()
+
+	var b strings.Builder
+	b.WriteString("=== Admission (mutating + validating webhooks) ===\n")
+	res, err := runKu(ctx, app, &shellOpts{
+		args:            []string{"apply", "--dry-run=server", "-f", file, "-o", "yaml"},
+		overrideContext: ctxOverride,
+	})
+	if err != nil {
+		fmt.Fprintf(&b, "DENIED: %s\n\n%s\n", err, res)
+		return b.String()
+	}
+	b.WriteString("ADMITTED\n\n")
+
+	b.WriteString("=== Changes vs live (including webhook mutations) ===\n")
+	diff, err := runKu(ctx, app, &shellOpts{
+		args:            []string{"diff", "-f", file},
+		overrideContext: ctxOverride,
+	})
+	// kubectl diff exits 1 when differences are found.
+	var exitErr *exec.ExitError
+	switch {
+	case err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1):
+		fmt.Fprintf(&b, "diff failed: %s\n%s\n", err, diff)
+	case diff == "":
+		b.WriteString("(no changes)\n")
+	default:
+		b.WriteString(diff + "\n")
+	}
+
+	b.WriteString("\n=== Final object ===\n")
+	b.WriteString(res + "\n")
+
+	return b.String()
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tcell/v2"
 	appsv1 "k8s.io/api/apps/v1"
 )
 
@@ -31,11 +32,26 @@ func NewDaemonSet(gvr *client.GVR) ResourceViewer {
 			),
 		),
 	)
+	d.AddBindKeysFn(d.bindKeys)
 	d.GetTable().SetEnterFn(d.showPods)
 
 	return &d
 }
 
+func (d *DaemonSet) bindKeys(aa *ui.KeyActions) {
+	aa.Add(ui.KeyShiftG, ui.NewKeyAction("Node Gaps", d.gapsCmd, true))
+}
+
+func (d *DaemonSet) gapsCmd(*tcell.EventKey) *tcell.EventKey {
+	path := d.GetTable().GetSelectedItem()
+	if path == "" {
+		return nil
+	}
+	d.App().diagCmd("dsgaps", path)
+
+	return nil
+}
+
 func (d *DaemonSet) showPods(app *App, _ ui.Tabular, _ *client.GVR, path string) {
 	var res dao.DaemonSet
 	res.Init(app.factory, d.GVR())
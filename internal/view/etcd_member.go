@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/derailed/tcell/v2"
+)
+
+const etcdActionDeadline = 30 * time.Second
+
+// EtcdMember presents etcd cluster members.
+type EtcdMember struct {
+	ResourceViewer
+}
+
+// NewEtcdMember returns a new viewer.
+func NewEtcdMember(gvr *client.GVR) ResourceViewer {
+	e := EtcdMember{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	e.AddBindKeysFn(e.bindKeys)
+	e.GetTable().SetSortCol("CONTEXT", true)
+
+	return &e
+}
+
+func (e *EtcdMember) bindKeys(aa *ui.KeyActions) {
+	aa.Bulk(ui.KeyMap{
+		ui.KeyShiftC: ui.NewKeyAction("Sort Context", e.GetTable().SortColCmd("CONTEXT", true), false),
+		ui.KeyShiftN: ui.NewKeyAction("Sort Name", e.GetTable().SortColCmd("NAME", true), false),
+	})
+	if e.App().Config.IsReadOnly() {
+		return
+	}
+	aa.Bulk(ui.KeyMap{
+		ui.KeyShiftL: ui.NewKeyActionWithOpts("Move Leader", e.moveLeaderCmd,
+			ui.ActionOpts{
+				Visible:   true,
+				Dangerous: true,
+			}),
+		tcell.KeyCtrlD: ui.NewKeyActionWithOpts("Remove Member", e.removeCmd,
+			ui.ActionOpts{
+				Visible:   true,
+				Dangerous: true,
+			}),
+	})
+}
+
+// etcdMemberRef tracks a selected etcd member.
+type etcdMemberRef struct {
+	context, pod, id, name string
+}
+
+// parseEtcdMemberPath splits an etcd member row id.
+func parseEtcdMemberPath(path string) (etcdMemberRef, error) {
+	tt := strings.Split(path, "|")
+	if len(tt) != 4 {
+		return etcdMemberRef{}, fmt.Errorf("unable to parse etcd member %q", path)
+	}
+
+	return etcdMemberRef{context: tt[0], pod: tt[1], id: tt[2], name: tt[3]}, nil
+}
+
+func (m etcdMemberRef) String() string {
+	if m.name == "" {
+		return m.id
+	}
+
+	return m.name + " (" + m.id + ")"
+}
+
+func (e *EtcdMember) moveLeaderCmd(evt *tcell.EventKey) *tcell.EventKey {
+	m, ok := e.selectedMember()
+	if !ok {
+		return evt
+	}
+	msg := fmt.Sprintf("Move the etcd leadership of %s to member %s?", m.context, m)
+	e.confirm("Confirm Move Leader", msg, fmt.Sprintf("Leadership moved to %s", m), func(ctx context.Context) error {
+		rawCfg, err := e.App().Conn().Config().RawConfig()
+		if err != nil {
+			return err
+		}
+		return dao.EtcdMoveLeader(ctx, rawCfg, m.context, m.pod, m.id)
+	})
+
+	return nil
+}
+
+func (e *EtcdMember) removeCmd(evt *tcell.EventKey) *tcell.EventKey {
+	m, ok := e.selectedMember()
+	if !ok {
+		return evt
+	}
+	msg := fmt.Sprintf("Remove member %s from the etcd cluster of %s?\nThe node must be drained and stopped first, removing a healthy member lowers the quorum margin.", m, m.context)
+	e.confirm("Confirm Remove Member", msg, fmt.Sprintf("Member %s removed", m), func(ctx context.Context) error {
+		rawCfg, err := e.App().Conn().Config().RawConfig()
+		if err != nil {
+			return err
+		}
+		return dao.EtcdRemoveMember(ctx, rawCfg, m.context, m.pod, m.id)
+	})
+
+	return nil
+}
+
+func (e *EtcdMember) selectedMember() (etcdMemberRef, bool) {
+	path := e.GetTable().GetSelectedItem()
+	if path == "" {
+		return etcdMemberRef{}, false
+	}
+	m, err := parseEtcdMemberPath(path)
+	if err != nil {
+		e.App().Flash().Err(err)
+		return etcdMemberRef{}, false
+	}
+
+	return m, true
+}
+
+func (e *EtcdMember) confirm(title, msg, done string, run func(context.Context) error) {
+	d := e.App().Styles.Dialog()
+	dialog.ShowConfirm(&d, e.App().Content.Pages, title, msg, func() {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), etcdActionDeadline)
+			defer cancel()
+			err := run(ctx)
+			e.App().QueueUpdateDraw(func() {
+				if err != nil {
+					e.App().Flash().Err(err)
+					return
+				}
+				e.App().Flash().Info(done)
+			})
+		}()
+	}, func() {})
+}
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEtcdMemberPath(t *testing.T) {
+	m, err := parseEtcdMemberPath("ct1|kube-system/etcd-cp1|8e9e05c52164694d|cp1")
+	require.NoError(t, err)
+	assert.Equal(t, etcdMemberRef{context: "ct1", pod: "kube-system/etcd-cp1", id: "8e9e05c52164694d", name: "cp1"}, m)
+	assert.Equal(t, "cp1 (8e9e05c52164694d)", m.String())
+
+	m, err = parseEtcdMemberPath("ct1|kube-system/etcd-cp1|3|")
+	require.NoError(t, err)
+	assert.Equal(t, "3", m.String())
+
+	_, err = parseEtcdMemberPath("ct1|3")
+	assert.Error(t, err)
+}
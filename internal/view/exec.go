@@ -48,6 +48,7 @@ type shellOpts struct {
 	banner            string
 	args              []string
 	overrideContext   string
+	input             io.Reader
 }
 
 func (s shellOpts) String() string {
@@ -117,6 +118,8 @@ func run(a *App, opts *shellOpts) (ok bool, errC chan error, outC chan string) {
 	defer a.Resume()
 
 	return a.Suspend(func() {
+		a.suspended.Store(true)
+		defer a.suspended.Store(false)
 		if err := execute(opts, statusChan); err != nil {
 			errChan <- err
 			a.Flash().Errf("Exec failed %q: %s", opts, err)
@@ -275,7 +278,7 @@ func runKInView(a *App, title string, opts *shellOpts) {
 			out = fmt.Sprintf("Error: %s\n\n%s", err, out)
 		}
 		a.QueueUpdateDraw(func() {
-			details := NewDetails(a, title, "kubectl", contentTXT, true).Update(out)
+			details := NewDetails(a, title, "kubectl", contentANSI, true).Update(out)
 			if e := a.inject(details, false); e != nil {
 				a.Flash().Err(e)
 			}
@@ -300,7 +303,11 @@ func runKu(ctx context.Context, a *App, opts *shellOpts) (string, error) {
 	if g, err := a.Conn().Config().ImpersonateGroups(); err == nil {
 		args = append(args, "--as-group", g)
 	}
-	args = append(args, "--context", a.Config.K9s.ActiveContextName())
+	ctxName := a.Config.K9s.ActiveContextName()
+	if opts.overrideContext != "" {
+		ctxName = opts.overrideContext
+	}
+	args = append(args, "--context", ctxName)
 	if cfg := a.Conn().Config().Flags().KubeConfig; cfg != nil && *cfg != "" {
 		args = append(args, "--kubeconfig", *cfg)
 	}
@@ -327,6 +334,9 @@ func oneShoot(ctx context.Context, opts *shellOpts) (string, error) {
 	buff := bytes.NewBufferString("")
 	// Use nil stdin so the child never touches the terminal (avoids TUI being suspended)
 	cmd.Stdin = nil
+	if opts.input != nil {
+		cmd.Stdin = opts.input
+	}
 	cmd.Stdout = buff
 	cmd.Stderr = buff
 	_, _ = cmd.Stdout.Write([]byte(opts.banner))
@@ -407,9 +417,10 @@ func sshIn(a *App, fqn, co string) error {
 		args = append(args, cfg.Args...)
 	} else {
 		if platform == windowsOS {
-			args = append(args, "--", powerShell)
+			args = append(args, powerShell)
+		} else {
+			args = append(args, "sh", "-c", shellCheck)
 		}
-		args = append(args, "sh", "-c", shellCheck)
 	}
 	slog.Debug("Running command with args", slogs.Args, args)
 
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tcell/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// FleetCluster represents a Fleet cluster registration viewer.
+type FleetCluster struct {
+	ResourceViewer
+}
+
+// NewFleetCluster returns a new viewer.
+func NewFleetCluster(gvr *client.GVR) ResourceViewer {
+	f := FleetCluster{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	f.AddBindKeysFn(f.bindKeys)
+
+	return &f
+}
+
+func (f *FleetCluster) bindKeys(aa *ui.KeyActions) {
+	aa.Bulk(ui.KeyMap{
+		ui.KeyA: ui.NewKeyAction("Cluster Agent Logs", f.agentLogsCmd(dao.CattleClusterAgent), true),
+		ui.KeyF: ui.NewKeyAction("Fleet Agent Logs", f.agentLogsCmd(dao.FleetAgent), true),
+	})
+}
+
+func (f *FleetCluster) agentLogsCmd(agent dao.Agent) ui.ActionHandler {
+	return func(evt *tcell.EventKey) *tcell.EventKey {
+		path := f.GetTable().GetSelectedItem()
+		if path == "" {
+			return evt
+		}
+		_, path = model1.SplitMultiContextID(path)
+		_, n := client.Namespaced(path)
+		id, name := n, n
+		if o, err := f.App().factory.Get(f.GVR(), path, true, labels.Everything()); err == nil {
+			if u, ok := o.(*unstructured.Unstructured); ok {
+				id, name = dao.FleetClusterRef(u)
+			}
+		}
+		f.App().agentLogs(id, name, agent)
+
+		return nil
+	}
+}
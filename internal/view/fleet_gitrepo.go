@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+const (
+	gitRepoBulkDialogKey = "gitrepo-bulk"
+	gitRepoBulkTitle     = "GitRepo Bulk Action"
+	gitRepoBulkTimeout   = 5 * time.Minute
+)
+
+// GitRepo represents a Fleet GitRepo viewer.
+type GitRepo struct {
+	ResourceViewer
+}
+
+// NewGitRepo returns a new viewer.
+func NewGitRepo(gvr *client.GVR) ResourceViewer {
+	g := GitRepo{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	g.AddBindKeysFn(g.bindKeys)
+
+	return &g
+}
+
+func (g *GitRepo) bindKeys(aa *ui.KeyActions) {
+	if g.App().Config.IsReadOnly() {
+		return
+	}
+	aa.Add(ui.KeyShiftB, ui.NewKeyActionWithOpts("Bulk Actions", g.bulkCmd,
+		ui.ActionOpts{
+			Visible:   true,
+			Dangerous: true,
+		}))
+}
+
+func (g *GitRepo) bulkCmd(evt *tcell.EventKey) *tcell.EventKey {
+	sels := g.GetTable().GetSelectedItems()
+	if len(sels) == 0 {
+		return evt
+	}
+
+	styles := g.App().Styles.Dialog()
+	f := tview.NewForm().
+		SetItemPadding(0).
+		SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(styles.ButtonBgColor.Color()).
+		SetButtonTextColor(styles.ButtonFgColor.Color()).
+		SetLabelColor(styles.LabelFgColor.Color()).
+		SetFieldTextColor(styles.FieldFgColor.Color())
+	for _, a := range []struct {
+		label  string
+		action dao.GitRepoAction
+	}{
+		{"Pause", dao.GitRepoPause},
+		{"Resume", dao.GitRepoResume},
+		{"Force Update", dao.GitRepoForceUpdate},
+	} {
+		f.AddButton(a.label, func() {
+			g.App().Content.RemovePage(gitRepoBulkDialogKey)
+			g.runBulk(a.action, sels)
+		})
+	}
+	f.AddButton("Cancel", func() {
+		g.App().Content.RemovePage(gitRepoBulkDialogKey)
+	})
+	for i := range f.GetButtonCount() {
+		f.GetButton(i).
+			SetBackgroundColorActivated(styles.ButtonFocusBgColor.Color()).
+			SetLabelColorActivated(styles.ButtonFocusFgColor.Color())
+	}
+
+	msg := fmt.Sprintf("Apply to %d GitRepos?", len(sels))
+	if len(sels) == 1 {
+		msg = fmt.Sprintf("Apply to %s?", sels[0])
+	}
+	modal := tview.NewModalForm("<"+gitRepoBulkTitle+">", f)
+	modal.SetText(msg)
+	modal.SetDoneFunc(func(int, string) {
+		g.App().Content.RemovePage(gitRepoBulkDialogKey)
+	})
+	g.App().Content.AddPage(gitRepoBulkDialogKey, modal, false, false)
+	g.App().Content.ShowPage(gitRepoBulkDialogKey)
+
+	return nil
+}
+
+// runBulk applies an action to the selected GitRepos, logging each outcome
+// in a details view.
+func (g *GitRepo) runBulk(action dao.GitRepoAction, sels []string) {
+	d := NewDetails(g.App(), gitRepoBulkTitle, string(action), contentTXT, true)
+	if err := g.App().inject(d, false); err != nil {
+		g.App().Flash().Err(err)
+		return
+	}
+	w := queuedWriter{app: g.App(), w: d.GetWriter()}
+	fmt.Fprintf(d.GetWriter(), "Applying %s to %d GitRepos...\n\n", action, len(sels))
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), gitRepoBulkTimeout)
+		defer cancel()
+
+		var i int
+		rr := dao.ApplyGitRepoAction(ctx, g.App().Conn(), action, sels, func(r dao.GitRepoResult) {
+			i++
+			status := "ok"
+			if r.Err != nil {
+				status = "failed: " + r.Err.Error()
+			}
+			fmt.Fprintf(w, "[%d/%d] %s %s\n", i, len(sels), r.Path, status)
+		})
+		ok, failed := dao.GitRepoSummary(rr)
+		fmt.Fprintf(w, "\nDone: %d ok, %d failed\n", ok, failed)
+		g.App().QueueUpdateDraw(func() {
+			if failed > 0 {
+				g.App().Flash().Warnf("GitRepo %s: %d ok, %d failed", action, ok, failed)
+				return
+			}
+			g.App().Flash().Infof("GitRepo %s: %d ok", action, ok)
+		})
+	}()
+}
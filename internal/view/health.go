@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/metrics"
+	"github.com/derailed/k9s/internal/slogs"
+)
+
+const (
+	healthPath         = "/healthz"
+	healthProbeTimeout = 2 * time.Second
+)
+
+// startHealth serves the session health when enabled so automation can
+// detect and restart dead sessions.
+func (a *App) startHealth() {
+	cfg := a.Config.K9s.Health
+	if cfg == nil || !cfg.Enable {
+		return
+	}
+	started := time.Now()
+	mux := http.NewServeMux()
+	mux.Handle(healthPath, metrics.HealthHandler(func(ctx context.Context) metrics.Health {
+		return a.health(ctx, started)
+	}))
+	a.healthSrv = &http.Server{
+		Addr:              cfg.Addr(),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func(srv *http.Server) {
+		slog.Info("Serving health", slogs.Address, srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Warn("Health endpoint failed", slogs.Error, err)
+			a.QueueUpdateDraw(func() {
+				a.Flash().Warnf("Health endpoint failed: %s", err)
+			})
+		}
+	}(a.healthSrv)
+}
+
+func (a *App) stopHealth() {
+	if a.healthSrv == nil {
+		return
+	}
+	if err := a.healthSrv.Close(); err != nil {
+		slog.Debug("Health endpoint close failed", slogs.Error, err)
+	}
+}
+
+// health reports the session liveness along with its connected contexts and
+// views last refreshes.
+func (a *App) health(ctx context.Context, started time.Time) metrics.Health {
+	now := time.Now()
+	active := a.Config.ActiveContextName()
+	ctxs := []string{active}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		for _, c := range sel {
+			if !slices.Contains(ctxs, c) {
+				ctxs = append(ctxs, c)
+			}
+		}
+	}
+	h := metrics.Health{
+		Status:        metrics.HealthOK,
+		PID:           os.Getpid(),
+		Version:       a.version,
+		StartedAt:     started,
+		Uptime:        now.Sub(started).Round(time.Second).Seconds(),
+		ActiveContext: active,
+		Contexts:      metrics.NewContextHealth(active, ctxs, now),
+		Views:         metrics.NewViewHealth(now),
+	}
+	switch {
+	case a.suspended.Load():
+		h.Status = metrics.HealthSuspended
+	case !a.uiResponsive(ctx):
+		h.Status = metrics.HealthUnresponsive
+	case a.Conn() == nil || !a.Conn().ConnectionOK():
+		h.Status = metrics.HealthDisconnected
+	}
+
+	return h
+}
+
+// uiResponsive checks the UI event loop processes updates in a timely manner.
+// A single probe is in flight at a time so a stuck event loop does not pile
+// up blocked probes across health requests.
+func (a *App) uiResponsive(ctx context.Context) bool {
+	if !a.healthProbing.CompareAndSwap(false, true) {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer a.healthProbing.Store(false)
+		a.QueueUpdate(func() {
+			close(done)
+		})
+	}()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-a.appCtx.Done():
+		return false
+	}
+}
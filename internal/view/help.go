@@ -162,6 +162,11 @@ func (h *Help) showRk9s() model.MenuHints {
 		{Mnemonic: ":home", Description: "Home dashboard"},
 		{Mnemonic: ":rke2k3s", Description: "RKE2/K3s config info"},
 		{Mnemonic: ":etcd", Description: "etcd health info"},
+		{Mnemonic: ":rancher-sessions", Description: "Rancher API (also -drivers/-audit)"},
+		{Mnemonic: "Ctrl-O", Description: "Copy table as markdown"},
+		{Mnemonic: ":ooms", Description: "OOM kills & evictions (24h)"},
+		{Mnemonic: ":inventory", Description: "Node OS/kernel inventory"},
+		{Mnemonic: ":janitor", Description: "Stale resources cleanup"},
 		// -- Rancher [clusters.mgmt.cattle.io] --
 		{Mnemonic: "Shift-O", Description: "Cluster overview [rancher]"},
 		{Mnemonic: "Shift-R", Description: "RBAC [rancher]"},
@@ -171,6 +176,7 @@ func (h *Help) showRk9s() model.MenuHints {
 		{Mnemonic: "Shift-G", Description: "GitRepo status [gitrepos]"},
 		{Mnemonic: "Shift-S", Description: "Suspend [gitrepos]"},
 		{Mnemonic: "Shift-U", Description: "Resume [gitrepos]"},
+		{Mnemonic: "Shift-B", Description: "Bulk pause/resume/force update [gitrepos]"},
 		// -- Longhorn [volumes.longhorn.io] --
 		{Mnemonic: "Shift-C", Description: "Create snapshot [volumes]"},
 		{Mnemonic: "Shift-B", Description: "List snapshots [volumes]"},
@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config/data"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	historyDialogKey = "history"
+	historyTitle     = "History"
+)
+
+// history returns the active context resource history backend if any.
+func (b *Browser) history() *data.History {
+	ct, err := b.app.Config.K9s.ActiveContext()
+	if err != nil || ct.History == nil {
+		return nil
+	}
+
+	return ct.History
+}
+
+func (b *Browser) historyCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := b.GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	if ctxName, _ := model1.SplitMultiContextID(path); ctxName != "" {
+		b.App().Flash().Warn("Resource history is only available on the active context")
+		return nil
+	}
+
+	asOf := "1h"
+	styles := b.App().Styles.Dialog()
+	f := tview.NewForm().
+		SetItemPadding(0).
+		SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(styles.ButtonBgColor.Color()).
+		SetButtonTextColor(styles.ButtonFgColor.Color()).
+		SetLabelColor(styles.LabelFgColor.Color()).
+		SetFieldTextColor(styles.FieldFgColor.Color())
+	f.AddInputField("As of:", asOf, 30, nil, func(changed string) {
+		asOf = changed
+	})
+	f.AddButton("OK", func() {
+		b.App().Content.RemovePage(historyDialogKey)
+		at, err := dao.ParseAsOf(asOf, time.Now())
+		if err != nil {
+			b.App().Flash().Err(err)
+			return
+		}
+		b.showHistory(path, at)
+	})
+	f.AddButton("Cancel", func() {
+		b.App().Content.RemovePage(historyDialogKey)
+	})
+	for i := range f.GetButtonCount() {
+		f.GetButton(i).
+			SetBackgroundColorActivated(styles.ButtonFocusBgColor.Color()).
+			SetLabelColorActivated(styles.ButtonFocusFgColor.Color())
+	}
+
+	modal := tview.NewModalForm("<"+historyTitle+">", f)
+	modal.SetText(fmt.Sprintf("Show %s as of a duration ago (2h, 3d) or a date (2006-01-02 15:04)", path))
+	modal.SetDoneFunc(func(int, string) {
+		b.App().Content.RemovePage(historyDialogKey)
+	})
+	b.App().Content.AddPage(historyDialogKey, modal, false, false)
+	b.App().Content.ShowPage(historyDialogKey)
+
+	return nil
+}
+
+func (b *Browser) showHistory(path string, at time.Time) {
+	ns, n := client.Namespaced(path)
+	if !b.meta.Namespaced {
+		ns = client.BlankNamespace
+	}
+	ref := dao.HistoryRef{Group: b.GVR().G(), Resource: b.GVR().R(), Namespace: ns, Name: n}
+	h := b.history()
+
+	rawCfg, err := b.app.Conn().Config().RawConfig()
+	if err != nil {
+		b.App().Flash().Err(err)
+		return
+	}
+	ctxName := b.app.Config.K9s.ActiveContextName()
+
+	b.App().Flash().Infof("Looking up %s history...", path)
+	go func() {
+		rev, err := dao.ResourceAt(context.Background(), rawCfg, ctxName, h, ref, at)
+		var out string
+		if err == nil {
+			var live string
+			if o, e := b.app.factory.Get(b.GVR(), path, true, labels.Everything()); e == nil {
+				live, err = dao.ToYAML(o, false)
+			}
+			if err == nil {
+				out, err = historyReport(ref, at, rev, live)
+			}
+		}
+		b.App().QueueUpdateDraw(func() {
+			if err != nil {
+				b.App().Flash().Err(err)
+				return
+			}
+			details := NewDetails(b.App(), historyTitle, path, contentTXT, true).Update(out)
+			if e := b.App().inject(details, false); e != nil {
+				b.App().Flash().Err(e)
+			}
+		})
+	}()
+}
+
+// historyReport renders a resource past revision and its changes to date.
+// A blank live manifest indicates the resource no longer exists.
+func historyReport(ref dao.HistoryRef, at time.Time, rev dao.HistoryRevision, live string) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s as of %s ===\n", ref, at.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Last %s by %s at %s\n\n", rev.Verb, rev.User, rev.At.Format(time.RFC3339))
+	if rev.Object == nil {
+		b.WriteString("(deleted)\n")
+		if live != "" {
+			b.WriteString("\n=== Recreated since then ===\n" + live)
+		}
+		return b.String(), nil
+	}
+	past, err := dao.ToYAML(rev.Object, false)
+	if err != nil {
+		return "", err
+	}
+	b.WriteString(past)
+
+	b.WriteString("\n=== Changes since then ===\n")
+	if live == "" {
+		b.WriteString("(deleted)\n")
+		return b.String(), nil
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(past),
+		B:        difflib.SplitLines(live),
+		FromFile: rev.At.Format(time.RFC3339),
+		ToFile:   "live",
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to diff revisions: %w", err)
+	}
+	if diff == "" {
+		diff = "(no changes)\n"
+	}
+	b.WriteString(diff)
+
+	return b.String(), nil
+}
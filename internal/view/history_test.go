@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestHistoryReport(t *testing.T) {
+	ref := dao.HistoryRef{Group: "apps", Resource: "deployments", Namespace: "ns1", Name: "web"}
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	past := dao.HistoryRevision{
+		At:   at.Add(-time.Hour),
+		User: "bob",
+		Verb: "patch",
+		Object: &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]any{"name": "web", "namespace": "ns1"},
+			"spec":       map[string]any{"replicas": int64(3)},
+		}},
+	}
+	live := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n  namespace: ns1\nspec:\n  replicas: 5\n"
+
+	uu := map[string]struct {
+		rev  dao.HistoryRevision
+		live string
+		ee   []string
+	}{
+		"changed": {
+			rev:  past,
+			live: live,
+			ee: []string{
+				"=== deployments.apps ns1/web as of 2026-01-01T12:00:00Z ===",
+				"Last patch by bob at 2026-01-01T11:00:00Z",
+				"=== Changes since then ===",
+				"-  replicas: 3",
+				"+  replicas: 5",
+			},
+		},
+		"unchanged": {
+			rev:  past,
+			live: "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n  namespace: ns1\nspec:\n  replicas: 3\n",
+			ee:   []string{"(no changes)"},
+		},
+		"gone": {
+			rev: past,
+			ee:  []string{"replicas: 3", "=== Changes since then ===\n(deleted)"},
+		},
+		"deleted": {
+			rev:  dao.HistoryRevision{At: at, User: "dave", Verb: "delete"},
+			live: live,
+			ee:   []string{"Last delete by dave", "(deleted)", "=== Recreated since then ==="},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			out, err := historyReport(ref, at, u.rev, u.live)
+			require.NoError(t, err)
+			for _, e := range u.ee {
+				assert.Contains(t, out, e)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/derailed/tcell/v2"
+)
+
+const hygieneActionDeadline = 30 * time.Second
+
+// Hygiene presents Rancher user and token hygiene findings.
+type Hygiene struct {
+	ResourceViewer
+}
+
+// NewHygiene returns a new viewer.
+func NewHygiene(gvr *client.GVR) ResourceViewer {
+	h := Hygiene{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	h.SetContextFn(h.hygieneContext)
+	h.AddBindKeysFn(h.bindKeys)
+	h.GetTable().SetSortCol("FINDING", true)
+
+	return &h
+}
+
+func (h *Hygiene) hygieneContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, internal.KeyRancher, h.App().Config.K9s.Rancher)
+}
+
+func (h *Hygiene) bindKeys(aa *ui.KeyActions) {
+	aa.Bulk(ui.KeyMap{
+		ui.KeyShiftF: ui.NewKeyAction("Sort Finding", h.GetTable().SortColCmd("FINDING", true), false),
+		ui.KeyShiftU: ui.NewKeyAction("Sort User", h.GetTable().SortColCmd("USER", true), false),
+	})
+	if h.App().Config.IsReadOnly() {
+		return
+	}
+	aa.Bulk(ui.KeyMap{
+		ui.KeyShiftD: ui.NewKeyActionWithOpts("Deactivate User", h.deactivateCmd,
+			ui.ActionOpts{
+				Visible:   true,
+				Dangerous: true,
+			}),
+		ui.KeyShiftX: ui.NewKeyActionWithOpts("Expire Token", h.expireCmd,
+			ui.ActionOpts{
+				Visible:   true,
+				Dangerous: true,
+			}),
+	})
+}
+
+// hygieneRef represents a finding subject.
+type hygieneRef struct {
+	kind, name, userID string
+}
+
+// parseHygienePath splits a finding id into its kind, resource name and user id.
+func parseHygienePath(path string) (hygieneRef, error) {
+	tt := strings.Split(path, "|")
+	if len(tt) != 4 {
+		return hygieneRef{}, fmt.Errorf("unable to parse hygiene finding %q", path)
+	}
+
+	return hygieneRef{kind: tt[0], name: tt[1], userID: tt[2]}, nil
+}
+
+func (h *Hygiene) deactivateCmd(evt *tcell.EventKey) *tcell.EventKey {
+	ref, ok := h.selectedFinding()
+	if !ok {
+		return evt
+	}
+	if ref.userID == "" {
+		h.App().Flash().Warn("Finding is not bound to a Rancher user")
+		return nil
+	}
+	msg := fmt.Sprintf("Deactivate Rancher user %s? The user will no longer be able to log in.", ref.userID)
+	h.confirm("Deactivate User", msg, fmt.Sprintf("User %s deactivated", ref.userID), func(ctx context.Context) error {
+		return dao.DeactivateRancherUser(ctx, h.App().Conn(), ref.userID)
+	})
+
+	return nil
+}
+
+func (h *Hygiene) expireCmd(evt *tcell.EventKey) *tcell.EventKey {
+	ref, ok := h.selectedFinding()
+	if !ok {
+		return evt
+	}
+	if ref.kind != render.HygieneToken {
+		h.App().Flash().Warn("Select a token finding to expire")
+		return nil
+	}
+	msg := fmt.Sprintf("Expire Rancher token %s? Clients using it will be denied.", ref.name)
+	h.confirm("Expire Token", msg, fmt.Sprintf("Token %s expired", ref.name), func(ctx context.Context) error {
+		return dao.ExpireRancherToken(ctx, h.App().Conn(), ref.name)
+	})
+
+	return nil
+}
+
+func (h *Hygiene) selectedFinding() (hygieneRef, bool) {
+	path := h.GetTable().GetSelectedItem()
+	if path == "" {
+		return hygieneRef{}, false
+	}
+	ref, err := parseHygienePath(path)
+	if err != nil {
+		h.App().Flash().Err(err)
+		return hygieneRef{}, false
+	}
+
+	return ref, true
+}
+
+func (h *Hygiene) confirm(title, msg, done string, run func(context.Context) error) {
+	d := h.App().Styles.Dialog()
+	dialog.ShowConfirm(&d, h.App().Content.Pages, title, msg, func() {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), hygieneActionDeadline)
+			defer cancel()
+			err := run(ctx)
+			h.App().QueueUpdateDraw(func() {
+				if err != nil {
+					h.App().Flash().Err(err)
+					return
+				}
+				h.App().Flash().Info(done)
+			})
+		}()
+	}, func() {})
+}
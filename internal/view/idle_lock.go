@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+const (
+	idleLockPage = "idleLock"
+	idleLockTick = 5 * time.Second
+)
+
+// idleLock tracks the user activity to lock idle sessions.
+type idleLock struct {
+	lastInput atomic.Int64
+	locked    atomic.Bool
+
+	// Only accessed on the UI thread.
+	dropped bool
+	view    *tview.TextView
+	done    chan struct{}
+}
+
+func (l *idleLock) touch(now time.Time) {
+	l.lastInput.Store(now.UnixNano())
+}
+
+// expired checks if an unlocked session went idle for at least timeout.
+func (l *idleLock) expired(now time.Time, timeout time.Duration) bool {
+	if timeout <= 0 || l.locked.Load() {
+		return false
+	}
+
+	return now.Sub(time.Unix(0, l.lastInput.Load())) >= timeout
+}
+
+// startIdleLock locks the session once idle for the configured timeout.
+func (a *App) startIdleLock() {
+	timeout := a.Config.K9s.IdleLock.IdleTimeout()
+	if timeout == 0 {
+		return
+	}
+	a.idle.touch(time.Now())
+	a.idle.done = make(chan struct{})
+	go func(done <-chan struct{}) {
+		for {
+			select {
+			case <-done:
+				slog.Debug("Idle lock stopped")
+				return
+			case <-time.After(idleLockTick):
+			}
+			now := time.Now()
+			// Keys typed in a suspended shell do not reach us.
+			if a.suspended.Load() {
+				a.idle.touch(now)
+				continue
+			}
+			if a.idle.expired(now, timeout) {
+				a.QueueUpdateDraw(func() {
+					a.lockSession(timeout)
+				})
+			}
+		}
+	}(a.idle.done)
+}
+
+// stopIdleLock stops watching for idle sessions.
+func (a *App) stopIdleLock() {
+	if a.idle.done == nil {
+		return
+	}
+	close(a.idle.done)
+	a.idle.done = nil
+}
+
+// lockSession hides the screen until the user unlocks the session.
+func (a *App) lockSession(idle time.Duration) {
+	if !a.idle.locked.CompareAndSwap(false, true) {
+		return
+	}
+	cfg := a.Config.K9s.IdleLock
+	slog.Info("Locking idle session", slogs.Duration, idle)
+	if cfg != nil && cfg.DropCredentials {
+		a.dropCredentials()
+	}
+
+	a.idle.view = tview.NewTextView()
+	a.idle.view.SetDynamicColors(true)
+	a.idle.view.SetTextAlign(tview.AlignCenter)
+	a.idle.view.SetBackgroundColor(a.Styles.BgColor())
+	a.idle.view.SetTextColor(a.Styles.FgColor())
+	a.idle.view.SetText(idleLockMsg(cfg, idle, ""))
+	a.Main.AddPage(idleLockPage, a.idle.view, true, true)
+	a.SetFocus(a.idle.view)
+}
+
+// dropCredentials stops the informers and drops the cached clients so no
+// cluster data or connection lingers while locked.
+func (a *App) dropCredentials() {
+	if c := a.Content.Top(); c != nil {
+		c.Stop()
+	}
+	if a.factory != nil {
+		a.factory.Terminate()
+	}
+	dao.ResetDynClientCache()
+	dao.ResetPromCache()
+	dao.StopMonitoringForwards()
+	a.idle.dropped = true
+}
+
+// idleLockKey swallows all keys of a locked session, Enter unlocks it.
+func (a *App) idleLockKey(evt *tcell.EventKey) *tcell.EventKey {
+	if evt.Key() != tcell.KeyEnter {
+		return nil
+	}
+	cfg := a.Config.K9s.IdleLock
+	if cfg != nil && cfg.Reauth {
+		if err := a.reauth(); err != nil {
+			slog.Warn("Session unlock failed", slogs.Error, err)
+			a.idle.view.SetText(idleLockMsg(cfg, 0, err.Error()))
+			return nil
+		}
+	}
+	a.unlockSession()
+
+	return nil
+}
+
+// reauth runs the context login command. Sessions without a login command
+// unlock on a keypress.
+func (a *App) reauth() error {
+	opts, ok := a.loginOpts()
+	if !ok {
+		slog.Warn("No login command to re-authenticate, unlocking on keypress")
+		return nil
+	}
+	suspended, errChan, _ := run(a, opts)
+	if !suspended {
+		return fmt.Errorf("unable to run %s", opts)
+	}
+	var err error
+	for e := range errChan {
+		err = e
+	}
+
+	return err
+}
+
+func (a *App) unlockSession() {
+	a.Main.SwitchToPage("main")
+	a.Main.RemovePage(idleLockPage)
+	a.idle.view = nil
+	a.idle.touch(time.Now())
+	a.idle.locked.Store(false)
+	slog.Info("Idle session unlocked")
+
+	if a.idle.dropped {
+		a.idle.dropped = false
+		if err := activateContext(a, a.Config.ActiveContextName()); err != nil {
+			a.Flash().Errf("Unable to reconnect: %s", err)
+		}
+	}
+	if a.CmdBuff().IsActive() {
+		a.SetFocus(a.Prompt())
+	}
+}
+
+func idleLockMsg(cfg *config.IdleLock, idle time.Duration, failure string) string {
+	msg := "\n\n\n[::b]Session locked[::-]\n\n"
+	if idle > 0 {
+		msg += fmt.Sprintf("No activity for %s.\n\n", idle)
+	}
+	if failure != "" {
+		msg += fmt.Sprintf("[red::]Unlock failed: %s[-::]\n\n", tview.Escape(failure))
+	}
+	if cfg != nil && cfg.Reauth {
+		return msg + "Press Enter to log in again"
+	}
+
+	return msg + "Press Enter to resume"
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdleLockExpired(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	uu := map[string]struct {
+		idle    time.Duration
+		timeout time.Duration
+		locked  bool
+		e       bool
+	}{
+		"disabled": {idle: time.Hour},
+		"active":   {idle: time.Minute, timeout: 15 * time.Minute},
+		"idle":     {idle: 15 * time.Minute, timeout: 15 * time.Minute, e: true},
+		"locked":   {idle: time.Hour, timeout: 15 * time.Minute, locked: true},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			var l idleLock
+			l.touch(now.Add(-u.idle))
+			l.locked.Store(u.locked)
+			assert.Equal(t, u.e, l.expired(now, u.timeout))
+		})
+	}
+}
+
+func TestStopIdleLock(t *testing.T) {
+	var a App
+	a.stopIdleLock()
+
+	a.idle.done = make(chan struct{})
+	done := a.idle.done
+	a.stopIdleLock()
+	_, ok := <-done
+	assert.False(t, ok)
+	assert.Nil(t, a.idle.done)
+	a.stopIdleLock()
+}
+
+func TestIdleLockMsg(t *testing.T) {
+	assert.Equal(t, "\n\n\n[::b]Session locked[::-]\n\nNo activity for 15m0s.\n\nPress Enter to resume", idleLockMsg(nil, 15*time.Minute, ""))
+	assert.Equal(t, "\n\n\n[::b]Session locked[::-]\n\n[red::]Unlock failed: exit status 1[-::]\n\nPress Enter to log in again", idleLockMsg(&config.IdleLock{Reauth: true}, 0, "exit status 1"))
+}
@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"strings"
 
+	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/slogs"
 	"github.com/derailed/k9s/internal/ui"
@@ -58,6 +59,8 @@ func NewImageExtender(r ResourceViewer) ResourceViewer {
 }
 
 func (s *ImageExtender) bindKeys(aa *ui.KeyActions) {
+	aa.Add(tcell.KeyCtrlT, ui.NewKeyAction("Images", s.imagesCmd, true))
+	aa.Add(tcell.KeyCtrlN, ui.NewKeyAction("Check Newer Tags", s.newerTagsCmd, true))
 	if s.App().Config.IsReadOnly() {
 		return
 	}
@@ -138,6 +141,9 @@ func (s *ImageExtender) makeSetImageForm(fqn string) (*tview.Form, error) {
 				return
 			}
 			s.App().Flash().Infof("Resource %s:%s image updated successfully", s.GVR(), fqn)
+			if s.GVR() == client.DpGVR && len(imageSpecsModified) > 0 {
+				showRollout(s.App(), fqn)
+			}
 		}).
 		AddButton("Cancel", func() {
 			s.dismissDialog()
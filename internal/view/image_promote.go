@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+const (
+	promoteDialogKey = "promote-image"
+	promoteTitle     = "Promote Image"
+)
+
+// promoteImageCmd sets a container image tag and rolls it out across the
+// selected contexts, one context at a time.
+func (d *Deploy) promoteImageCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := d.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	if ctx, _ := model1.SplitMultiContextID(path); ctx != "" {
+		d.App().Flash().Warnf("Images are only available for the active context. Switch to %q first", ctx)
+		return nil
+	}
+	ii, err := dao.WorkloadImages(d.App().factory, d.GVR(), path)
+	if err != nil {
+		d.App().Flash().Err(err)
+		return nil
+	}
+	sel, _ := config.LoadSelectedContexts()
+	cc := promoteContexts(d.App().Config.ActiveContextName(), sel)
+
+	cfg := d.App().Config.K9s.ImageRegistry
+	if !cfg.LookupEnabled() {
+		d.showPromoteDialog(path, ii, cc)
+		return nil
+	}
+	d.App().Flash().Infof("Querying registries for %d images...", len(ii))
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), registryLookupDeadline)
+		defer cancel()
+		dao.RegistryLookup(ctx, ii, cfg.IsInsecure(), true)
+		d.App().QueueUpdateDraw(func() {
+			d.showPromoteDialog(path, ii, cc)
+		})
+	}()
+
+	return nil
+}
+
+func (d *Deploy) showPromoteDialog(path string, ii []dao.ImageInfo, cc []string) {
+	if len(ii) == 0 {
+		d.App().Flash().Warnf("No containers found on %s", path)
+		return
+	}
+	styles := d.App().Styles.Dialog()
+	f := tview.NewForm().
+		SetItemPadding(0).
+		SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(styles.ButtonBgColor.Color()).
+		SetButtonTextColor(styles.ButtonFgColor.Color()).
+		SetLabelColor(styles.LabelFgColor.Color()).
+		SetFieldTextColor(styles.FieldFgColor.Color())
+
+	var (
+		img   = ii[len(ii)-1]
+		tag   string
+		tagIn *tview.InputField
+	)
+	oo := make([]string, 0, len(ii))
+	for _, i := range ii {
+		n := i.Container
+		if i.Init {
+			n += " (init)"
+		}
+		oo = append(oo, n)
+	}
+	f.AddDropDown("Container:", oo, -1, func(_ string, idx int) {
+		if idx < 0 {
+			return
+		}
+		img = ii[idx]
+		if tagIn != nil {
+			tagIn.SetText(img.Tag)
+		}
+	})
+	f.AddInputField("Tag:", img.Tag, 30, nil, func(s string) {
+		tag = strings.TrimSpace(s)
+	})
+	if in, ok := f.GetFormItem(1).(*tview.InputField); ok {
+		tagIn = in
+		in.SetAutocompleteFunc(func(s string) []string {
+			return matchFields(img.Newer, s, quickPatchSuggestions)
+		})
+	}
+	if dd, ok := f.GetFormItem(0).(*tview.DropDown); ok {
+		dd.SetCurrentOption(len(ii) - 1)
+	}
+
+	dismiss := func() {
+		d.App().Content.RemovePage(promoteDialogKey)
+	}
+	f.AddButton("Promote", func() {
+		dismiss()
+		if tag == "" || tag == img.Tag {
+			d.App().Flash().Warn("Pick a new tag to promote")
+			return
+		}
+		d.promoteImage(dao.ImagePromotion{
+			Path:      path,
+			Container: img.Container,
+			Init:      img.Init,
+			Image:     dao.WithTag(img.Image, tag),
+			Contexts:  cc,
+		})
+	})
+	f.AddButton("Cancel", dismiss)
+	for i := range f.GetButtonCount() {
+		f.GetButton(i).
+			SetBackgroundColorActivated(styles.ButtonFocusBgColor.Color()).
+			SetLabelColorActivated(styles.ButtonFocusFgColor.Color())
+	}
+
+	modal := tview.NewModalForm("<Promote Image>", f)
+	msg := fmt.Sprintf("Roll out %s one context at a time: %s", path, strings.Join(cc, " -> "))
+	if len(img.Newer) > 0 {
+		msg += fmt.Sprintf("\nNewer tags: %s", strings.Join(img.Newer, ", "))
+	}
+	modal.SetText(msg)
+	modal.SetDoneFunc(func(int, string) {
+		dismiss()
+	})
+	d.App().Content.AddPage(promoteDialogKey, modal, false, false)
+	d.App().Content.ShowPage(promoteDialogKey)
+}
+
+// promoteImage rolls out the image one context at a time, confirming each
+// context and checking it allows mutations first.
+func (d *Deploy) promoteImage(p dao.ImagePromotion) {
+	rawCfg, err := d.App().Conn().Config().RawConfig()
+	if err != nil {
+		d.App().Flash().Err(err)
+		return
+	}
+	steps := make([]workflowStep, 0, len(p.Contexts))
+	for _, c := range p.Contexts {
+		steps = append(steps, workflowStep{
+			title: fmt.Sprintf("Promote %s on context %s", p.Image, c),
+			run: func(ctx context.Context, log logFn) error {
+				if err := d.App().checkContextWritable(c); err != nil {
+					return err
+				}
+				var last string
+				err := p.Promote(ctx, rawCfg, c, func(msg string) {
+					if msg != last {
+						log("%s: %s", c, msg)
+						last = msg
+					}
+				})
+				if err != nil {
+					return err
+				}
+				log("%s: rollout healthy", c)
+				return nil
+			},
+		})
+	}
+	d.App().runWorkflow(promoteTitle, p.Path, steps)
+}
+
+// promoteContexts returns the promotion targets, the active context first.
+func promoteContexts(active string, sel []string) []string {
+	if len(sel) < 2 {
+		return []string{active}
+	}
+	cc := make([]string, 0, len(sel))
+	if slices.Contains(sel, active) {
+		cc = append(cc, active)
+	}
+	for _, c := range sel {
+		if c != active {
+			cc = append(cc, c)
+		}
+	}
+
+	return cc
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/derailed/tcell/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	registryLookupDeadline = 45 * time.Second
+	maxNewerTags           = 3
+	shortDigestLen         = 19
+)
+
+func (s *ImageExtender) imagesCmd(evt *tcell.EventKey) *tcell.EventKey {
+	return s.showImages(evt, false)
+}
+
+func (s *ImageExtender) newerTagsCmd(evt *tcell.EventKey) *tcell.EventKey {
+	return s.showImages(evt, true)
+}
+
+func (s *ImageExtender) showImages(evt *tcell.EventKey, newer bool) *tcell.EventKey {
+	path := s.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	if ctx, _ := model1.SplitMultiContextID(path); ctx != "" {
+		s.App().Flash().Warnf("Images are only available for the active context. Switch to %q first", ctx)
+		return nil
+	}
+
+	cfg := s.App().Config.K9s.ImageRegistry
+	if newer && !cfg.LookupEnabled() {
+		s.App().Flash().Warn("Registry lookups are disabled. Set k9s.imageRegistry.lookup to true")
+		return nil
+	}
+	ii, err := dao.WorkloadImages(s.App().factory, s.GVR(), path)
+	if err != nil {
+		s.App().Flash().Err(err)
+		return nil
+	}
+
+	title := "Images"
+	if newer {
+		title = "Newer Tags"
+	}
+	if !cfg.LookupEnabled() {
+		s.showImageDetails(title, path, ii, false)
+		return nil
+	}
+
+	s.App().Flash().Infof("Querying registries for %d images...", len(ii))
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), registryLookupDeadline)
+		defer cancel()
+		dao.RegistryLookup(ctx, ii, cfg.IsInsecure(), newer)
+		s.App().QueueUpdateDraw(func() {
+			s.showImageDetails(title, path, ii, newer)
+		})
+	}()
+
+	return nil
+}
+
+func (s *ImageExtender) showImageDetails(title, path string, ii []dao.ImageInfo, newer bool) {
+	details := NewDetails(s.App(), title, path, contentTXT, true).Update(renderImages(ii, newer))
+	if err := s.App().inject(details, false); err != nil {
+		s.App().Flash().Err(err)
+	}
+}
+
+func renderImages(ii []dao.ImageInfo, newer bool) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	hh := "CONTAINER\tREGISTRY\tREPOSITORY\tTAG\tDIGEST\tCREATED"
+	if newer {
+		hh += "\tNEWER"
+	}
+	fmt.Fprintln(w, hh)
+
+	var errs error
+	for _, i := range ii {
+		co := i.Container
+		if i.Init {
+			co += " (init)"
+		}
+		created := render.NAValue
+		if !i.Created.IsZero() {
+			created = render.ToAge(metav1.NewTime(i.Created))
+		}
+		row := strings.Join([]string{
+			co,
+			orNA(i.Registry),
+			orNA(i.Repo),
+			orNA(i.Tag),
+			orNA(shortDigests(i.Digests)),
+			created,
+		}, "\t")
+		if newer {
+			row += "\t" + orNA(newerTags(i.Newer))
+		}
+		fmt.Fprintln(w, row)
+		if i.Err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", i.Image, i.Err))
+		}
+	}
+	_ = w.Flush()
+	if errs != nil {
+		fmt.Fprintf(&b, "\nErrors:\n%s\n", errs)
+	}
+
+	return b.String()
+}
+
+func shortDigests(dd []string) string {
+	ss := make([]string, 0, len(dd))
+	for _, d := range dd {
+		ss = append(ss, render.Truncate(d, shortDigestLen))
+	}
+
+	return strings.Join(ss, ",")
+}
+
+func newerTags(tt []string) string {
+	if len(tt) > maxNewerTags {
+		return strings.Join(tt[:maxNewerTags], ",") + ",..."
+	}
+
+	return strings.Join(tt, ",")
+}
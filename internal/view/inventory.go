@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tcell/v2"
+)
+
+// Inventory presents the node OS and component versions.
+type Inventory struct {
+	ResourceViewer
+}
+
+// NewInventory returns a new viewer.
+func NewInventory(gvr *client.GVR) ResourceViewer {
+	i := Inventory{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	i.AddBindKeysFn(i.bindKeys)
+	i.GetTable().SetSortCol("DRIFT", false)
+
+	return &i
+}
+
+func (i *Inventory) bindKeys(aa *ui.KeyActions) {
+	aa.Delete(ui.KeyShiftA, tcell.KeyCtrlD, ui.KeyE, tcell.KeyCtrlSpace, ui.KeySpace)
+	aa.Bulk(ui.KeyMap{
+		ui.KeyShiftC: ui.NewKeyAction("Sort Context", i.GetTable().SortColCmd("CONTEXT", true), false),
+		ui.KeyShiftO: ui.NewKeyAction("Sort OS", i.GetTable().SortColCmd("OS-IMAGE", true), false),
+		ui.KeyShiftK: ui.NewKeyAction("Sort Kernel", i.GetTable().SortColCmd("KERNEL", true), false),
+		ui.KeyShiftD: ui.NewKeyAction("Sort Drift", i.GetTable().SortColCmd("DRIFT", false), false),
+	})
+}
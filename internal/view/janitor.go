@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/derailed/tcell/v2"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const janitorDeadline = 5 * time.Minute
+
+// Janitor presents the stale resources of the active or selected contexts.
+type Janitor struct {
+	ResourceViewer
+}
+
+// NewJanitor returns a new viewer.
+func NewJanitor(gvr *client.GVR) ResourceViewer {
+	j := Janitor{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	j.SetContextFn(j.janitorContext)
+	j.AddBindKeysFn(j.bindKeys)
+	j.GetTable().SetSortCol("KIND", true)
+
+	return &j
+}
+
+func (j *Janitor) janitorContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, internal.KeyJanitor, j.App().janitorAge)
+}
+
+func (j *Janitor) bindKeys(aa *ui.KeyActions) {
+	aa.Bulk(ui.KeyMap{
+		ui.KeyShiftK: ui.NewKeyAction("Sort Kind", j.GetTable().SortColCmd("KIND", true), false),
+		ui.KeyShiftC: ui.NewKeyAction("Sort Context", j.GetTable().SortColCmd("CONTEXT", true), false),
+	})
+	if j.App().Config.IsReadOnly() {
+		return
+	}
+	aa.Add(tcell.KeyCtrlD, ui.NewKeyActionWithOpts("Clean Up", j.cleanCmd,
+		ui.ActionOpts{
+			Visible:   true,
+			Dangerous: true,
+		}))
+}
+
+// parseJanitorPath converts a candidate id back to a cleanup candidate.
+func parseJanitorPath(path string) (dao.JanitorCandidate, error) {
+	tt := strings.Split(path, "|")
+	if len(tt) != 5 {
+		return dao.JanitorCandidate{}, fmt.Errorf("unable to parse cleanup candidate %q", path)
+	}
+
+	return dao.JanitorCandidate{
+		Context:         tt[0],
+		GVR:             client.NewGVR(tt[1]),
+		Path:            tt[2],
+		UID:             types.UID(tt[3]),
+		ResourceVersion: tt[4],
+	}, nil
+}
+
+// cleanCmd dry runs the deletion of the marked or selected candidates, then
+// deletes the ones the API server accepted once confirmed.
+func (j *Janitor) cleanCmd(evt *tcell.EventKey) *tcell.EventKey {
+	ids := j.GetTable().GetSelectedItems()
+	if len(ids) == 0 {
+		return evt
+	}
+	cc := make([]dao.JanitorCandidate, 0, len(ids))
+	for _, id := range ids {
+		c, err := parseJanitorPath(id)
+		if err != nil {
+			j.App().Flash().Err(err)
+			return nil
+		}
+		cc = append(cc, c)
+	}
+	rawCfg, err := j.App().Conn().Config().RawConfig()
+	if err != nil {
+		j.App().Flash().Err(err)
+		return nil
+	}
+
+	j.App().Flash().Infof("Dry run deleting %d resources...", len(cc))
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), janitorDeadline)
+		defer cancel()
+		ok, skipped := janitorDelete(ctx, rawCfg, cc, true)
+		j.App().QueueUpdateDraw(func() {
+			j.confirmClean(rawCfg, ok, skipped)
+		})
+	}()
+
+	return nil
+}
+
+func (j *Janitor) confirmClean(rawCfg api.Config, cc []dao.JanitorCandidate, skipped []string) {
+	if len(cc) == 0 {
+		j.App().Flash().Errf("Dry run rejected all deletions: %s", skipped[0])
+		return
+	}
+	msg := fmt.Sprintf("Delete %s?", janitorSummary(cc))
+	if len(skipped) > 0 {
+		msg += fmt.Sprintf("\n\n%d skipped by the dry run, ie %s", len(skipped), skipped[0])
+	}
+	d := j.App().Styles.Dialog()
+	dialog.ShowConfirm(&d, j.App().Content.Pages, "Clean Up", msg, func() {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), janitorDeadline)
+			defer cancel()
+			ok, failed := janitorDelete(ctx, rawCfg, cc, false)
+			j.App().QueueUpdateDraw(func() {
+				j.GetTable().ClearMarks()
+				j.Refresh()
+				if len(failed) > 0 {
+					j.App().Flash().Errf("%d of %d deletions failed: %s", len(failed), len(cc), failed[0])
+					return
+				}
+				j.App().Flash().Infof("%d resources cleaned up", len(ok))
+			})
+		}()
+	}, func() {})
+}
+
+// janitorDelete deletes the given candidates and returns the deleted ones
+// along with the reasons of the others.
+func janitorDelete(ctx context.Context, rawCfg api.Config, cc []dao.JanitorCandidate, dry bool) ([]dao.JanitorCandidate, []string) {
+	var (
+		ok     []dao.JanitorCandidate
+		failed []string
+	)
+	for _, c := range cc {
+		if err := dao.JanitorDelete(ctx, rawCfg, c, dry); err != nil {
+			failed = append(failed, fmt.Sprintf("%s %s %s: %s", c.Context, c.GVR.R(), c.Path, err))
+			continue
+		}
+		ok = append(ok, c)
+	}
+
+	return ok, failed
+}
+
+// janitorSummary counts the candidates per kind, ie `3 pods, 1 jobs`.
+func janitorSummary(cc []dao.JanitorCandidate) string {
+	var (
+		kinds  []string
+		counts = make(map[string]int)
+	)
+	for _, c := range cc {
+		k := c.GVR.R()
+		if counts[k] == 0 {
+			kinds = append(kinds, k)
+		}
+		counts[k]++
+	}
+	ss := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		ss = append(ss, fmt.Sprintf("%d %s", counts[k], k))
+	}
+
+	return strings.Join(ss, ", ")
+}
+
+// janitorCmd lists the resources older than the given days, 7 by default,
+// on the active or selected contexts.
+func (a *App) janitorCmd(arg string) {
+	days := int(dao.DefaultJanitorAge / (24 * time.Hour))
+	if arg = strings.TrimSpace(arg); arg != "" {
+		n, err := strconv.Atoi(strings.TrimSuffix(arg, "d"))
+		if err != nil || n < 0 {
+			a.Flash().Errf("Invalid janitor age %q. Use `janitor [DAYS]`", arg)
+			return
+		}
+		days = n
+	}
+	a.janitorAge = time.Duration(days) * 24 * time.Hour
+	a.gotoResource(client.JanGVR.String(), "", false, true)
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJanitorPath(t *testing.T) {
+	c, err := parseJanitorPath("prod|apps/v1/replicasets|ns1/fred-1|u1|42")
+	require.NoError(t, err)
+	assert.Equal(t, "prod", c.Context)
+	assert.Equal(t, client.RsGVR.String(), c.GVR.String())
+	assert.Equal(t, "ns1/fred-1", c.Path)
+	assert.Equal(t, "u1", string(c.UID))
+	assert.Equal(t, "42", c.ResourceVersion)
+
+	_, err = parseJanitorPath("prod/fred")
+	assert.Error(t, err)
+}
+
+func TestJanitorSummary(t *testing.T) {
+	cc := []dao.JanitorCandidate{
+		{GVR: client.PodGVR},
+		{GVR: client.JobGVR},
+		{GVR: client.PodGVR},
+	}
+
+	assert.Equal(t, "2 pods, 1 jobs", janitorSummary(cc))
+}
@@ -26,7 +26,9 @@ func NewJob(gvr *client.GVR) ResourceViewer {
 
 	j.ResourceViewer = NewVulnerabilityExtender(
 		NewOwnerExtender(
-			NewLogsExtender(NewBrowser(gvr), j.logOptions),
+			NewWaitExtender(
+				NewLogsExtender(NewBrowser(gvr), j.logOptions),
+			),
 		),
 	)
 	j.GetTable().SetEnterFn(j.showPods)
@@ -24,6 +24,8 @@ import (
 
 const (
 	liveViewTitleFmt = "[fg:bg:b] %s([hilite:bg:b]%s[fg:bg:-])[fg:bg:-] "
+	staleIndicator   = "[orange:bg:b]<cached, refreshing...>[fg:bg:-] "
+	followIndicator  = "[green:bg:b]<following>[fg:bg:-] "
 	yamlAction       = "YAML"
 )
 
@@ -42,6 +44,7 @@ type LiveView struct {
 	fullScreen                bool
 	managedField              bool
 	autoRefresh               bool
+	prevLines                 []string
 }
 
 // NewLiveView returns a live viewer.
@@ -59,6 +62,9 @@ func NewLiveView(app *App, title string, m model.ResourceViewer) *LiveView {
 		autoRefresh:   app.Config.K9s.LiveViewAutoRefresh,
 	}
 	v.AddItem(v.text, 0, 1, true)
+	if r, ok := m.(model.RefreshRater); ok {
+		r.SetRefreshRate(app.Config.K9s.LiveViewRefreshDuration())
+	}
 
 	return &v
 }
@@ -118,8 +124,15 @@ func (v *LiveView) ResourceChanged(lines []string, matches fuzzy.Matches) {
 			v.text.ScrollToBeginning()
 		}
 
+		var changed map[int]struct{}
+		if v.autoRefresh && v.prevLines != nil {
+			changed = changedLines(v.prevLines, lines)
+		}
+		v.prevLines = lines
+
+		style := v.app.Styles.Views().Yaml
 		lines = linesWithRegions(lines, matches)
-		v.text.SetText(colorizeYAML(v.app.Styles.Views().Yaml, strings.Join(lines, "\n")))
+		v.text.SetText(markChangedLines(colorizeYAML(style, strings.Join(lines, "\n")), changed, style.ChangedColor))
 		v.text.Highlight()
 		if v.currentRegion < v.maxRegions {
 			v.text.Highlight("search_" + strconv.Itoa(v.currentRegion))
@@ -196,6 +209,7 @@ func (v *LiveView) editCmd(evt *tcell.EventKey) *tcell.EventKey {
 // ToggleRefreshCmd is used for pausing the refreshing of data on config map and secrets.
 func (v *LiveView) toggleRefreshCmd(*tcell.EventKey) *tcell.EventKey {
 	v.autoRefresh = !v.autoRefresh
+	v.updateTitle()
 	if v.autoRefresh {
 		v.Start()
 		v.app.Flash().Info("Auto-refresh is enabled")
@@ -247,7 +261,9 @@ func (v *LiveView) Start() {
 }
 
 func (v *LiveView) defaultCtx() context.Context {
-	return context.WithValue(context.Background(), internal.KeyFactory, v.app.factory)
+	ctx := context.WithValue(context.Background(), internal.KeyFactory, v.app.factory)
+
+	return context.WithValue(ctx, internal.KeyViewConfig, v.app.CustomView())
 }
 
 // Stop terminates the updater.
@@ -306,6 +322,9 @@ func (v *LiveView) nextCmd(evt *tcell.EventKey) *tcell.EventKey {
 	if v.cmdBuff.Empty() {
 		return evt
 	}
+	if v.maxRegions == 0 {
+		return nil
+	}
 
 	v.currentRegion++
 	if v.currentRegion >= v.maxRegions {
@@ -322,6 +341,9 @@ func (v *LiveView) prevCmd(evt *tcell.EventKey) *tcell.EventKey {
 	if v.cmdBuff.Empty() {
 		return evt
 	}
+	if v.maxRegions == 0 {
+		return nil
+	}
 
 	v.currentRegion--
 	if v.currentRegion < 0 {
@@ -378,7 +400,7 @@ func (v *LiveView) resetCmd(evt *tcell.EventKey) *tcell.EventKey {
 
 func (v *LiveView) saveCmd(*tcell.EventKey) *tcell.EventKey {
 	name := fmt.Sprintf("%s--%s", strings.Replace(v.model.GetPath(), "/", "-", 1), strings.ToLower(v.title))
-	if _, err := saveYAML(v.app.Config.K9s.ContextScreenDumpDir(), name, sanitizeEsc(v.text.GetText(true))); err != nil {
+	if _, err := saveYAML(v.app.Config.K9s.ContextScreenDumpDir(), name, v.app.redactor().YAML(sanitizeEsc(v.text.GetText(true)))); err != nil {
 		v.app.Flash().Err(err)
 	} else {
 		v.app.Flash().Infof("File %q saved successfully!", name)
@@ -394,6 +416,12 @@ func (v *LiveView) updateTitle() {
 	var fmat string
 	if v.model != nil {
 		fmat = fmt.Sprintf(liveViewTitleFmt, v.title, v.model.GetPath())
+		if s, ok := v.model.(model.Staler); ok && s.IsStale() {
+			fmat += staleIndicator
+		}
+		if v.autoRefresh {
+			fmat += followIndicator
+		}
 	}
 
 	var (
@@ -405,9 +433,46 @@ func (v *LiveView) updateTitle() {
 		return
 	}
 
-	if v.maxRegions > 0 {
+	switch {
+	case v.maxRegions > 0:
 		buff += fmt.Sprintf("[%d:%d]", v.currentRegion+1, v.maxRegions)
+	case !v.cmdBuff.IsActive():
+		buff += "[0:0]"
 	}
 	fmat += fmt.Sprintf(ui.SearchFmt, buff)
 	v.SetTitle(ui.SkinTitle(fmat, &styles))
 }
+
+// changedLines returns the indices of the lines that were not present in the
+// previous content.
+func changedLines(prev, lines []string) map[int]struct{} {
+	seen := make(map[string]int, len(prev))
+	for _, l := range prev {
+		seen[l]++
+	}
+	changed := make(map[int]struct{})
+	for i, l := range lines {
+		if seen[l] > 0 {
+			seen[l]--
+			continue
+		}
+		changed[i] = struct{}{}
+	}
+
+	return changed
+}
+
+// markChangedLines sets the background of the changed colorized lines.
+func markChangedLines(text string, changed map[int]struct{}, c config.Color) string {
+	if len(changed) == 0 {
+		return text
+	}
+	ll := strings.Split(text, "\n")
+	for i := range ll {
+		if _, ok := changed[i]; ok {
+			ll[i] = "[:" + c.String() + ":]" + ll[i] + "[:-:]"
+		}
+	}
+
+	return strings.Join(ll, "\n")
+}
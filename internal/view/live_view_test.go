@@ -26,3 +26,44 @@ apiVersion: v1
 
 	assert.Equal(t, s, sanitizeEsc(v.text.GetText(true)))
 }
+
+func TestChangedLines(t *testing.T) {
+	uu := map[string]struct {
+		prev, lines []string
+		e           map[int]struct{}
+	}{
+		"same": {
+			prev:  []string{"a: 1", "b: 2"},
+			lines: []string{"a: 1", "b: 2"},
+			e:     map[int]struct{}{},
+		},
+		"changed": {
+			prev:  []string{"a: 1", "b: 2"},
+			lines: []string{"a: 1", "b: 3"},
+			e:     map[int]struct{}{1: {}},
+		},
+		"inserted": {
+			prev:  []string{"a: 1", "b: 2"},
+			lines: []string{"a: 1", "c: 0", "b: 2"},
+			e:     map[int]struct{}{1: {}},
+		},
+		"dups": {
+			prev:  []string{"- x", "b: 2"},
+			lines: []string{"- x", "- x", "b: 2"},
+			e:     map[int]struct{}{1: {}},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, changedLines(u.prev, u.lines))
+		})
+	}
+}
+
+func TestMarkChangedLines(t *testing.T) {
+	s := markChangedLines("a\nb\nc", map[int]struct{}{1: {}}, config.Color("#ff0000"))
+
+	assert.Equal(t, "a\n[:#ff0000:]b[:-:]\nc", s)
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/ui/dialog"
+)
+
+// loginOpts returns the login command of the active context, either from the
+// context config or inferred from the kubeconfig exec credential plugin.
+func (a *App) loginOpts() (*shellOpts, bool) {
+	if ct, err := a.Config.K9s.ActiveContext(); err == nil && ct.Login != nil && ct.Login.Command != "" {
+		return &shellOpts{clear: true, binary: ct.Login.Command, args: ct.Login.Args}, true
+	}
+	exec, err := a.Conn().Config().CurrentExecConfig()
+	if err != nil {
+		return nil, false
+	}
+	bin, args, ok := client.LoginCommand(exec)
+	if !ok {
+		return nil, false
+	}
+
+	return &shellOpts{clear: true, binary: bin, args: args}, true
+}
+
+// promptLogin offers to run the context login command when the connection
+// failed on expired credentials. It returns true while a login is pending so
+// connection retries do not count against the retry budget.
+func (a *App) promptLogin() bool {
+	if a.loginDeclined.Load() || !client.IsAuthError(a.Conn().ConnectionError()) {
+		return false
+	}
+	opts, ok := a.loginOpts()
+	if !ok {
+		return false
+	}
+	if !a.loginPending.CompareAndSwap(false, true) {
+		return true
+	}
+
+	ctx := a.Config.ActiveContextName()
+	a.QueueUpdateDraw(func() {
+		msg := fmt.Sprintf("Credentials for context %s expired. Login with?\n%s", ctx, opts)
+		d := a.Styles.Dialog()
+		dialog.ShowConfirm(&d, a.Content.Pages, "Login Required", msg, func() {
+			a.login(opts)
+		}, func() {
+			a.loginDeclined.Store(true)
+			a.loginPending.Store(false)
+			a.Flash().Warnf("Login to %s declined", ctx)
+		})
+	})
+
+	return true
+}
+
+// login runs the login command interactively and resumes the connection.
+func (a *App) login(opts *shellOpts) {
+	suspended, errChan, _ := run(a, opts)
+	var err error
+	if !suspended {
+		err = fmt.Errorf("unable to run %s", opts)
+	}
+	for e := range errChan {
+		err = e
+	}
+	defer a.loginPending.Store(false)
+	if err != nil {
+		a.Flash().Errf("Login failed: %s", err)
+		return
+	}
+
+	go func() {
+		if !a.Conn().CheckConnectivity() {
+			a.QueueUpdateDraw(func() {
+				a.Flash().Errf("Still unable to connect: %v", a.Conn().ConnectionError())
+			})
+			return
+		}
+		atomic.StoreInt32(&a.conRetry, 0)
+		a.QueueUpdateDraw(func() {
+			a.Status(model.FlashInfo, "K8s connectivity OK")
+			if c := a.Content.Top(); c != nil {
+				c.Start()
+			}
+			a.clusterModel.Refresh()
+		})
+	}()
+}
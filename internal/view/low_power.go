@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/k9s/internal/vul"
+)
+
+const (
+	batteryPoll      = time.Minute
+	powerSupplyClass = "/sys/class/power_supply"
+)
+
+// lowPowerCmd switches low power mode, ie `:lowpower [on|off]`. No argument
+// toggles it.
+func (a *App) lowPowerCmd(arg string) {
+	on := !a.Config.K9s.IsLowPower()
+	switch strings.ToLower(strings.TrimSpace(arg)) {
+	case "":
+	case "on":
+		on = true
+	case "off":
+		on = false
+	default:
+		a.Flash().Warn("Invalid command. Use `lowpower [on|off]`")
+		return
+	}
+	a.setLowPower(on)
+	if on {
+		a.Flash().Info("Low power mode on, refreshes stretched and background scans paused")
+		return
+	}
+	a.Flash().Info("Low power mode off")
+}
+
+// startLowPower applies the configured low power mode and follows the
+// battery state when asked to, until the app exits.
+func (a *App) startLowPower() {
+	cfg := a.Config.K9s.LowPower
+	if cfg.IsEnabled() {
+		a.setLowPower(true)
+	}
+	if !cfg.IsOnBattery() {
+		return
+	}
+	go func() {
+		var last bool
+		for {
+			on, err := onBattery(a.appCtx)
+			if err != nil {
+				slog.Debug("Battery state unavailable, low power mode stays manual", slogs.Error, err)
+				return
+			}
+			if on != last {
+				last = on
+				a.QueueUpdateDraw(func() {
+					a.setLowPower(on)
+					if on {
+						a.Flash().Info("Running on battery, low power mode on")
+					} else {
+						a.Flash().Info("Back on AC power, low power mode off")
+					}
+				})
+			}
+			select {
+			case <-a.appCtx.Done():
+				return
+			case <-time.After(batteryPoll):
+			}
+		}
+	}()
+}
+
+// setLowPower switches low power mode and applies it to the current view.
+// Views opened later pick up the stretched refresh rate on their own. Checks
+// and rules skip their runs while low power mode is on.
+func (a *App) setLowPower(on bool) {
+	a.Config.K9s.SetLowPower(on)
+	if vul.ImgScanner != nil {
+		vul.ImgScanner.Pause(on)
+	}
+	dao.PausePromQueries(on)
+	if v, ok := a.Content.Top().(ResourceViewer); ok {
+		v.GetTable().GetModel().SetRefreshRate(a.Config.K9s.RefreshDuration())
+	}
+}
+
+// onBattery checks if the host runs on battery. Only Linux and macOS hosts
+// are supported.
+func onBattery(ctx context.Context) (bool, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return batteryDischarging(powerSupplyClass)
+	case "darwin":
+		out, err := exec.CommandContext(ctx, "pmset", "-g", "batt").Output()
+		if err != nil {
+			return false, err
+		}
+		return strings.Contains(string(out), "'Battery Power'"), nil
+	default:
+		return false, os.ErrNotExist
+	}
+}
+
+// batteryDischarging checks if a battery of a sysfs power supply class
+// discharges. Other supplies, ie UPS or peripheral batteries, are ignored.
+func batteryDischarging(class string) (bool, error) {
+	tt, err := filepath.Glob(filepath.Join(class, "*", "type"))
+	if err != nil {
+		return false, err
+	}
+	var found bool
+	for _, t := range tt {
+		raw, err := os.ReadFile(t)
+		if err != nil || strings.TrimSpace(string(raw)) != "Battery" {
+			continue
+		}
+		dir := filepath.Dir(t)
+		if scope, err := os.ReadFile(filepath.Join(dir, "scope")); err == nil && strings.TrimSpace(string(scope)) == "Device" {
+			continue
+		}
+		found = true
+		st, err := os.ReadFile(filepath.Join(dir, "status"))
+		if err == nil && strings.TrimSpace(string(st)) == "Discharging" {
+			return true, nil
+		}
+	}
+	if !found {
+		return false, os.ErrNotExist
+	}
+
+	return false, nil
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatteryDischarging(t *testing.T) {
+	type supply struct {
+		kind, status, scope string
+	}
+	uu := map[string]struct {
+		ss  map[string]supply
+		on  bool
+		err error
+	}{
+		"none": {
+			err: os.ErrNotExist,
+		},
+		"ac-only": {
+			ss:  map[string]supply{"AC": {kind: "Mains"}},
+			err: os.ErrNotExist,
+		},
+		"charging": {
+			ss: map[string]supply{"AC": {kind: "Mains"}, "BAT0": {kind: "Battery", status: "Charging"}},
+		},
+		"discharging": {
+			ss: map[string]supply{"AC": {kind: "Mains"}, "BAT0": {kind: "Battery", status: "Discharging"}},
+			on: true,
+		},
+		"ups": {
+			ss:  map[string]supply{"ups": {kind: "UPS", status: "Discharging"}},
+			err: os.ErrNotExist,
+		},
+		"mouse": {
+			ss: map[string]supply{
+				"BAT0":  {kind: "Battery", status: "Full"},
+				"hid-0": {kind: "Battery", status: "Discharging", scope: "Device"},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			class := t.TempDir()
+			for n, s := range u.ss {
+				dir := filepath.Join(class, n)
+				require.NoError(t, os.MkdirAll(dir, 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "type"), []byte(s.kind+"\n"), 0o644))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "status"), []byte(s.status+"\n"), 0o644))
+				if s.scope != "" {
+					require.NoError(t, os.WriteFile(filepath.Join(dir, "scope"), []byte(s.scope+"\n"), 0o644))
+				}
+			}
+			on, err := batteryDischarging(class)
+			assert.Equal(t, u.err, err)
+			assert.Equal(t, u.on, on)
+		})
+	}
+}
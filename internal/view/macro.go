@@ -0,0 +1,272 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/view/cmd"
+	"github.com/derailed/tcell/v2"
+)
+
+const (
+	macroStepDelay = 500 * time.Millisecond
+	macroWaitStep  = "wait "
+	macroSpaceKey  = "Space"
+)
+
+// macroRecording tracks a macro being recorded.
+type macroRecording struct {
+	name  string
+	steps []string
+}
+
+// macroStep represents a parsed macro step.
+type macroStep struct {
+	cmd   string
+	wait  time.Duration
+	until *dao.WaitSpec
+	key   *tcell.EventKey
+}
+
+// parseMacroStep parses `:command`, `wait <duration>` and key steps. A `:wait`
+// command step holds the replay until its condition is met.
+func parseMacroStep(s string) (macroStep, error) {
+	switch {
+	case strings.HasPrefix(s, ":"):
+		c := strings.TrimSpace(s[1:])
+		if c == "" {
+			return macroStep{}, fmt.Errorf("empty macro command %q", s)
+		}
+		if p := cmd.NewInterpreter(c); p.IsWaitCmd() {
+			spec, err := dao.ParseWait(p.Args())
+			if err != nil {
+				return macroStep{}, fmt.Errorf("invalid macro wait %q: %w", s, err)
+			}
+			return macroStep{cmd: c, until: &spec}, nil
+		}
+		return macroStep{cmd: c}, nil
+	case strings.HasPrefix(s, macroWaitStep):
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(s, macroWaitStep)))
+		if err != nil {
+			return macroStep{}, fmt.Errorf("invalid macro wait %q: %w", s, err)
+		}
+		return macroStep{wait: d}, nil
+	case s == macroSpaceKey:
+		return macroStep{key: tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone)}, nil
+	case len([]rune(s)) == 1:
+		return macroStep{key: tcell.NewEventKey(tcell.KeyRune, []rune(s)[0], tcell.ModNone)}, nil
+	}
+	k, err := asKey(s)
+	if err != nil {
+		return macroStep{}, fmt.Errorf("invalid macro step %q", s)
+	}
+
+	return macroStep{key: tcell.NewEventKey(k, 0, tcell.ModNone)}, nil
+}
+
+// macroKey returns the macro step for a key event or false if the key can not
+// be replayed.
+func macroKey(evt *tcell.EventKey) (string, bool) {
+	if evt.Key() == tcell.KeyRune {
+		if evt.Rune() == ' ' {
+			return macroSpaceKey, true
+		}
+		return string(evt.Rune()), true
+	}
+	n, ok := tcell.KeyNames[evt.Key()]
+
+	return n, ok
+}
+
+// macroCmd records, replays or lists macros.
+func (a *App) macroCmd(name, arg string) {
+	switch {
+	case name == "record":
+		a.recordMacro(arg)
+	case arg == "":
+		a.listMacros()
+	default:
+		a.playMacro(arg)
+	}
+}
+
+func (a *App) recordMacro(name string) {
+	if a.macro != nil {
+		m := a.macro
+		a.macro = nil
+		if len(m.steps) == 0 {
+			a.Flash().Warnf("Macro %q has no steps, discarded", m.name)
+			return
+		}
+		mm := config.NewMacros()
+		if err := mm.Load(config.AppMacrosFile); err != nil {
+			a.Flash().Err(err)
+			return
+		}
+		mac := mm.Macro[m.name]
+		mac.Steps = m.steps
+		mm.Macro[m.name] = mac
+		if err := mm.Save(config.AppMacrosFile); err != nil {
+			a.Flash().Err(err)
+			return
+		}
+		a.Flash().Infof("Macro %q saved with %d steps", m.name, len(m.steps))
+		return
+	}
+	if name == "" {
+		a.Flash().Warn("Invalid command. Use `record NAME` to start and `record` to stop")
+		return
+	}
+	if a.macroPlaying.Load() {
+		a.Flash().Warn("Can not record a macro while one is replaying")
+		return
+	}
+	a.macro = &macroRecording{name: name}
+	a.Flash().Infof("Recording macro %q, use `:record` to stop", name)
+}
+
+// recordMacroKey records a key press in the current macro. Keys typed in the
+// command prompt are recorded as the resulting command instead.
+func (a *App) recordMacroKey(evt *tcell.EventKey) {
+	if a.macro == nil || a.macroPlaying.Load() || a.CmdBuff().IsActive() {
+		return
+	}
+	if evt.Key() == tcell.KeyRune && evt.Rune() == ':' && !a.InCmdMode() {
+		return
+	}
+	if k, ok := macroKey(evt); ok {
+		a.macro.steps = append(a.macro.steps, k)
+	}
+}
+
+// recordMacroCmd records a prompt command in the current macro.
+func (a *App) recordMacroCmd(c string) {
+	if a.macro == nil || a.macroPlaying.Load() || cmd.NewInterpreter(c).IsMacroCmd() {
+		return
+	}
+	a.macro.steps = append(a.macro.steps, ":"+c)
+}
+
+func (a *App) listMacros() {
+	mm := config.NewMacros()
+	if err := mm.Load(config.AppMacrosFile); err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	nn := make([]string, 0, len(mm.Macro))
+	for k := range mm.Macro {
+		nn = append(nn, k)
+	}
+	sort.Strings(nn)
+
+	var b strings.Builder
+	if len(nn) == 0 {
+		b.WriteString("No macros defined. Use `:record NAME` to record one.\n")
+	}
+	for _, n := range nn {
+		m := mm.Macro[n]
+		fmt.Fprintf(&b, "%s", n)
+		if m.Startup {
+			b.WriteString(" (startup)")
+		}
+		if m.Description != "" {
+			fmt.Fprintf(&b, " - %s", m.Description)
+		}
+		b.WriteString("\n")
+		for _, s := range m.Steps {
+			fmt.Fprintf(&b, "  %s\n", s)
+		}
+	}
+	details := NewDetails(a, "Macros", config.AppMacrosFile, contentTXT, true).Update(b.String())
+	if err := a.inject(details, false); err != nil {
+		a.Flash().Err(err)
+	}
+}
+
+func (a *App) playMacro(name string) {
+	mm := config.NewMacros()
+	if err := mm.Load(config.AppMacrosFile); err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	a.playMacros(mm, name)
+}
+
+// playMacros replays macros in order, one step at a time.
+func (a *App) playMacros(mm config.Macros, names ...string) {
+	var ss []macroStep
+	for _, n := range names {
+		m, ok := mm.Macro[n]
+		if !ok {
+			a.Flash().Warnf("Unknown macro %q", n)
+			return
+		}
+		for _, s := range m.Steps {
+			st, err := parseMacroStep(s)
+			if err != nil {
+				a.Flash().Errf("Macro %s: %s", n, err)
+				return
+			}
+			if st.cmd != "" && cmd.NewInterpreter(st.cmd).IsMacroCmd() {
+				a.Flash().Errf("Macro %s: nested macro command %q is not supported", n, s)
+				return
+			}
+			ss = append(ss, st)
+		}
+	}
+	if a.macro != nil {
+		a.Flash().Warn("Can not replay a macro while recording")
+		return
+	}
+	if !a.macroPlaying.CompareAndSwap(false, true) {
+		a.Flash().Warn("A macro is already replaying")
+		return
+	}
+	a.Flash().Infof("Replaying macro %s...", strings.Join(names, ", "))
+
+	go func() {
+		defer a.macroPlaying.Store(false)
+		for _, s := range ss {
+			switch {
+			case s.wait > 0:
+				<-time.After(s.wait)
+				continue
+			case s.until != nil:
+				if err := a.waitFor(a.appCtx, *s.until); err != nil {
+					a.QueueUpdateDraw(func() {
+						a.Flash().Errf("Macro %s aborted: %s", strings.Join(names, ", "), err)
+					})
+					return
+				}
+				continue
+			case s.cmd != "":
+				c := s.cmd
+				a.QueueUpdateDraw(func() {
+					a.gotoResource(c, "", true, true)
+				})
+			default:
+				a.QueueEvent(s.key)
+			}
+			<-time.After(macroStepDelay)
+		}
+	}()
+}
+
+// playStartupMacros replays the macros flagged to run at startup.
+func (a *App) playStartupMacros() {
+	mm := config.NewMacros()
+	if err := mm.Load(config.AppMacrosFile); err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	if nn := mm.StartupMacros(); len(nn) > 0 {
+		a.playMacros(mm, nn...)
+	}
+}
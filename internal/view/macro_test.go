@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMacroStep(t *testing.T) {
+	uu := map[string]struct {
+		step  string
+		cmd   string
+		wait  time.Duration
+		until bool
+		key   tcell.Key
+		r     rune
+		err   bool
+	}{
+		"cmd": {
+			step: ":pods kube-system",
+			cmd:  "pods kube-system",
+		},
+		"empty-cmd": {
+			step: ": ",
+			err:  true,
+		},
+		"wait": {
+			step: "wait 2s",
+			wait: 2 * time.Second,
+		},
+		"wait-cmd": {
+			step:  ":wait deploy/web --for=condition=Available",
+			cmd:   "wait deploy/web --for=condition=Available",
+			until: true,
+		},
+		"bad-wait-cmd": {
+			step: ":wait deploy/web",
+			err:  true,
+		},
+		"bad-wait": {
+			step: "wait soon",
+			err:  true,
+		},
+		"rune": {
+			step: "L",
+			key:  tcell.KeyRune,
+			r:    'L',
+		},
+		"space": {
+			step: "Space",
+			key:  tcell.KeyRune,
+			r:    ' ',
+		},
+		"named": {
+			step: "Enter",
+			key:  tcell.KeyEnter,
+		},
+		"ctrl": {
+			step: "Ctrl-D",
+			key:  tcell.KeyCtrlD,
+		},
+		"unknown": {
+			step: "Blee",
+			err:  true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s, err := parseMacroStep(u.step)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.cmd, s.cmd)
+			assert.Equal(t, u.wait, s.wait)
+			assert.Equal(t, u.until, s.until != nil)
+			if u.key == 0 {
+				assert.Nil(t, s.key)
+				return
+			}
+			assert.Equal(t, u.key, s.key.Key())
+			if u.key == tcell.KeyRune {
+				assert.Equal(t, u.r, s.key.Rune())
+			}
+		})
+	}
+}
+
+func TestMacroKeyRoundTrip(t *testing.T) {
+	uu := map[string]*tcell.EventKey{
+		"rune":  tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone),
+		"space": tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone),
+		"enter": tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		"esc":   tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone),
+	}
+
+	for k := range uu {
+		evt := uu[k]
+		t.Run(k, func(t *testing.T) {
+			step, ok := macroKey(evt)
+			assert.True(t, ok)
+			s, err := parseMacroStep(step)
+			assert.NoError(t, err)
+			assert.Equal(t, evt.Key(), s.key.Key())
+			assert.Equal(t, evt.Rune(), s.key.Rune())
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/mc"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/derailed/tcell/v2"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const mcTitle = "mc"
+
+// mcReadVerbs tracks the kubectl verbs allowed in read-only mode.
+var mcReadVerbs = sets.New(
+	"get",
+	"describe",
+	"logs",
+	"top",
+	"explain",
+	"events",
+	"version",
+	"api-resources",
+	"api-versions",
+	"cluster-info",
+)
+
+// mcReadAuthVerbs tracks the kubectl auth subcommands allowed in read-only mode.
+var mcReadAuthVerbs = sets.New(
+	"can-i",
+	"whoami",
+)
+
+// mcRun tracks a multi-context kubectl run.
+type mcRun struct {
+	ctx     context.Context
+	runner  mc.Runner
+	args    []string
+	format  string
+	results []mc.Result
+}
+
+// isMcRead checks if kubectl args only read cluster state.
+func isMcRead(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	if args[0] == "auth" {
+		return len(args) > 1 && mcReadAuthVerbs.Has(args[1])
+	}
+
+	return mcReadVerbs.Has(args[0])
+}
+
+// mcCmd runs a kubectl command on the selected contexts, ie `:mc get nodes`.
+// Mutations are confirmed first and skipped on read-only or gated contexts.
+func (a *App) mcCmd(arg string) {
+	args, err := mc.SplitArgs(arg)
+	if err != nil {
+		a.Flash().Errf("Invalid kubectl args: %s", err)
+		return
+	}
+	if len(args) == 0 {
+		a.Flash().Warn("Invalid command. Use `mc KUBECTL_ARGS`, ie `mc get nodes -o table`")
+		return
+	}
+	read := isMcRead(args)
+	if a.Config.IsReadOnly() && !read {
+		a.Flash().Warnf("kubectl %s is not allowed in read-only mode", strings.Join(args[:min(2, len(args))], " "))
+		return
+	}
+	ctxs := []string{a.Config.K9s.ActiveContextName()}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		ctxs = sel
+	}
+	if read {
+		a.runMc(ctxs, args, nil)
+		return
+	}
+	msg := fmt.Sprintf("Run kubectl %s on %d contexts: %s?", strings.Join(args, " "), len(ctxs), strings.Join(ctxs, ", "))
+	d := a.Styles.Dialog()
+	dialog.ShowConfirm(&d, a.Content.Pages, "Confirm kubectl", msg, func() {
+		a.runMc(ctxs, args, a.checkContextWritable)
+	}, func() {})
+}
+
+// runMc runs kubectl on the given contexts, vetoed by check if set. Runs are
+// only bounded by the multi-context kubectl timeout, closing the view
+// cancels them.
+func (a *App) runMc(ctxs, args []string, check func(string) error) {
+	args, format := mcFormatArgs(args)
+	k, mcCfg := a.Config.K9s.Kubectl, a.Config.K9s.MultiContext
+	ctx, cancel := context.WithCancel(context.Background())
+	run := mcRun{
+		ctx: ctx,
+		runner: mc.Runner{
+			MaxProc: mcCfg.Parallelism(),
+			Binary:  k.BinaryFor,
+			Dir:     k.ManagedDir(),
+			MaxSkew: k.SkewLimit(),
+			Timeout: mcCfg.McTimeout(),
+			Check:   check,
+		},
+		args:   args,
+		format: format,
+	}
+	details := NewDetails(a, mcTitle, "running", contentANSI, true).SetStopFn(cancel)
+	details.Update(fmt.Sprintf("Running kubectl %s on %d contexts, close this view to cancel...\n", strings.Join(args, " "), len(ctxs)))
+	if err := a.inject(details, false); err != nil {
+		cancel()
+		a.Flash().Err(err)
+		return
+	}
+	go func() {
+		run.results = run.runner.RunContext(ctx, ctxs, run.args)
+		if ctx.Err() != nil {
+			return
+		}
+		a.QueueUpdateDraw(func() {
+			a.showMcRun(details, &run)
+			details.Actions().Add(ui.KeyR, ui.NewKeyAction("Retry Failed", func(*tcell.EventKey) *tcell.EventKey {
+				a.retryMcRun(details, &run)
+				return nil
+			}, true))
+		})
+	}()
+}
+
+// retryMcRun re-runs the command on the failed contexts only.
+func (a *App) retryMcRun(details *Details, run *mcRun) {
+	failed := mc.FailedContexts(run.results)
+	if len(failed) == 0 {
+		a.Flash().Info("No failed contexts to retry")
+		return
+	}
+	a.Flash().Infof("Retrying %s...", strings.Join(failed, ", "))
+	go func() {
+		rr := run.runner.RetryContext(run.ctx, run.results, run.args)
+		if run.ctx.Err() != nil {
+			return
+		}
+		a.QueueUpdateDraw(func() {
+			run.results = rr
+			a.showMcRun(details, run)
+			a.Flash().Info(mc.Summarize(rr).String())
+		})
+	}()
+}
+
+func (a *App) showMcRun(details *Details, run *mcRun) {
+	out, err := mc.Format(run.results, run.format)
+	if err != nil {
+		out = fmt.Sprintf("Error: %s\n", err)
+	}
+	details.SetSubject(mc.Summarize(run.results).String())
+	details.updateTitle()
+	details.Update(out)
+}
+
+// mcFormatArgs pulls rk9s output formats out of the kubectl args. Formats
+// rendered by rk9s request json from kubectl.
+func mcFormatArgs(args []string) ([]string, string) {
+	for i, a := range args {
+		if a == "--" {
+			break
+		}
+		var f string
+		switch {
+		case (a == "-o" || a == "--output") && i+1 < len(args):
+			f = args[i+1]
+		case strings.HasPrefix(a, "-o="):
+			f = strings.TrimPrefix(a, "-o=")
+		case strings.HasPrefix(a, "--output="):
+			f = strings.TrimPrefix(a, "--output=")
+		case strings.HasPrefix(a, "-o") && len(a) > 2:
+			f = strings.TrimPrefix(a, "-o")
+		default:
+			continue
+		}
+		if mc.NeedsJSON(f) {
+			return mc.JSONArgs(args), f
+		}
+		break
+	}
+
+	return args, mc.FormatText
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMcFormatArgs(t *testing.T) {
+	uu := map[string]struct {
+		args, eArgs []string
+		eFormat     string
+	}{
+		"plain": {
+			args:    []string{"get", "nodes"},
+			eArgs:   []string{"get", "nodes"},
+			eFormat: "text",
+		},
+		"kubectl-format": {
+			args:    []string{"get", "nodes", "-o", "wide"},
+			eArgs:   []string{"get", "nodes", "-o", "wide"},
+			eFormat: "text",
+		},
+		"table": {
+			args:    []string{"get", "pods", "-o", "table", "-A"},
+			eArgs:   []string{"get", "pods", "-A", "-o", "json"},
+			eFormat: "table",
+		},
+		"yaml": {
+			args:    []string{"get", "--output=yaml", "nodes"},
+			eArgs:   []string{"get", "nodes", "-o", "json"},
+			eFormat: "yaml",
+		},
+		"json": {
+			args:    []string{"get", "-ojson", "nodes"},
+			eArgs:   []string{"get", "nodes", "-o", "json"},
+			eFormat: "json",
+		},
+		"after-double-dash": {
+			args:    []string{"exec", "p1", "--", "ls", "-o", "table"},
+			eArgs:   []string{"exec", "p1", "--", "ls", "-o", "table"},
+			eFormat: "text",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			args, f := mcFormatArgs(u.args)
+			assert.Equal(t, u.eArgs, args)
+			assert.Equal(t, u.eFormat, f)
+		})
+	}
+}
+
+func TestIsMcRead(t *testing.T) {
+	uu := map[string]struct {
+		args []string
+		e    bool
+	}{
+		"get":            {args: []string{"get", "nodes"}, e: true},
+		"auth-can-i":     {args: []string{"auth", "can-i", "list", "pods"}, e: true},
+		"auth-whoami":    {args: []string{"auth", "whoami"}, e: true},
+		"auth-reconcile": {args: []string{"auth", "reconcile", "-f", "rbac.yaml"}},
+		"auth":           {args: []string{"auth"}},
+		"delete":         {args: []string{"delete", "po", "web"}},
+		"blank":          {},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, isMcRead(u.args))
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/derailed/k9s/internal/metrics"
+	"github.com/derailed/k9s/internal/slogs"
+)
+
+const metricsPath = "/metrics"
+
+// startMetrics serves rk9s own usage metrics when enabled.
+func (a *App) startMetrics() {
+	cfg := a.Config.K9s.Metrics
+	if cfg == nil || !cfg.Enable {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, metrics.Handler())
+	a.metricsSrv = &http.Server{
+		Addr:              cfg.Addr(),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func(srv *http.Server) {
+		slog.Info("Serving metrics", slogs.Address, srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Warn("Metrics endpoint failed", slogs.Error, err)
+			a.QueueUpdateDraw(func() {
+				a.Flash().Warnf("Metrics endpoint failed: %s", err)
+			})
+		}
+	}(a.metricsSrv)
+}
+
+func (a *App) stopMetrics() {
+	if a.metricsSrv == nil {
+		return
+	}
+	if err := a.metricsSrv.Close(); err != nil {
+		slog.Debug("Metrics endpoint close failed", slogs.Error, err)
+	}
+}
@@ -40,6 +40,15 @@ func (n *Node) nodeContext(ctx context.Context) context.Context {
 	return context.WithValue(ctx, internal.KeyPodCounting, !n.App().Config.K9s.DisablePodCounting)
 }
 
+var (
+	nodePatch = actionAccess{gvr: client.NodeGVR, verbs: client.PatchAccess, clusterWide: true}
+	nodeGates = actionGates{
+		ui.KeyC: {nodePatch},
+		ui.KeyU: {nodePatch},
+		ui.KeyR: {nodePatch, {gvr: client.PodGVR.WithSubResource("eviction"), verbs: []string{client.CreateVerb}, clusterWide: true}},
+	}
+)
+
 func (n *Node) bindDangerousKeys(aa *ui.KeyActions) {
 	aa.Bulk(ui.KeyMap{
 		ui.KeyC: ui.NewKeyActionWithOpts(
@@ -80,10 +89,13 @@ func (n *Node) bindDangerousKeys(aa *ui.KeyActions) {
 func (n *Node) bindKeys(aa *ui.KeyActions) {
 	if !n.App().Config.IsReadOnly() {
 		n.bindDangerousKeys(aa)
+		gateActions(n, aa, nodeGates)
 	}
 
 	aa.Bulk(ui.KeyMap{
-		ui.KeyY: ui.NewKeyAction(yamlAction, n.yamlCmd, true),
+		ui.KeyY:      ui.NewKeyAction(yamlAction, n.yamlCmd, true),
+		ui.KeyShiftH: ui.NewKeyAction("Condition History", n.historyCmd, true),
+		ui.KeyShiftX: ui.NewKeyAction("Kubelet Health", n.kubeletCmd, true),
 	})
 }
 
@@ -101,7 +113,18 @@ func (n *Node) drainCmd(evt *tcell.EventKey) *tcell.EventKey {
 		GracePeriodSeconds: -1,
 		Timeout:            5 * time.Second,
 	}
-	ShowDrain(n, sels, opts, drainNode)
+	var notice DrainNotice
+	risks, ok, err := dao.LonghornDrainCheck(n.App().factory, sels)
+	if err != nil {
+		slog.Warn("Longhorn drain check failed", slogs.Error, err)
+		notice.Warning = "Unable to check Longhorn volumes: " + err.Error()
+	}
+	notice.Longhorn = ok
+	if len(risks) > 0 {
+		notice.Warning = longhornDrainWarning(sels, risks)
+		opts.EvictLonghornReplicas = true
+	}
+	ShowDrain(n, sels, opts, notice, drainNode)
 
 	return nil
 }
@@ -118,20 +141,52 @@ func drainNode(v ResourceViewer, sels []string, opts dao.DrainOptions) {
 		return
 	}
 
+	d := NewDetails(v.App(), "Drain Progress", "nodes", contentYAML, true)
+	if err := v.App().inject(d, false); err != nil {
+		v.App().Flash().Err(err)
+	}
+	if !opts.EvictLonghornReplicas {
+		drainNodes(v, m, sels, opts, d)
+		return
+	}
+
+	w := queuedWriter{app: v.App(), w: d.GetWriter()}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), longhornEvictTimeout)
+		defer cancel()
+		for i, sel := range sels {
+			if err := dao.EvictLonghornReplicas(ctx, v.App().factory, sel, w); err != nil {
+				v.App().QueueUpdateDraw(func() {
+					fmt.Fprintf(d.GetWriter(), "[%s] Drain aborted: %s\n", sel, err)
+					v.App().Flash().Err(err)
+				})
+				clearLonghornEvictions(v.App(), sels[:i], w)
+				return
+			}
+		}
+		v.App().QueueUpdateDraw(func() {
+			if failed := drainNodes(v, m, sels, opts, d); len(failed) > 0 {
+				go clearLonghornEvictions(v.App(), failed, w)
+			}
+		})
+	}()
+}
+
+// drainNodes drains the given nodes and returns the ones that failed.
+func drainNodes(v ResourceViewer, m dao.NodeMaintainer, sels []string, opts dao.DrainOptions, d *Details) []string {
 	v.Stop()
 	defer v.Start()
-	{
-		d := NewDetails(v.App(), "Drain Progress", "nodes", contentYAML, true)
-		if err := v.App().inject(d, false); err != nil {
+
+	var failed []string
+	for _, sel := range sels {
+		if err := m.Drain(sel, opts, d.GetWriter()); err != nil {
 			v.App().Flash().Err(err)
+			failed = append(failed, sel)
 		}
-		for _, sel := range sels {
-			if err := m.Drain(sel, opts, d.GetWriter()); err != nil {
-				v.App().Flash().Err(err)
-			}
-		}
-		v.Refresh()
 	}
+	v.Refresh()
+
+	return failed
 }
 
 func (n *Node) toggleCordonCmd(cordon bool) func(evt *tcell.EventKey) *tcell.EventKey {
@@ -182,9 +237,16 @@ func (n *Node) sshCmd(evt *tcell.EventKey) *tcell.EventKey {
 		return evt
 	}
 
+	_, node := client.Namespaced(path)
+	if no, err := dao.FetchNode(context.Background(), n.App().factory, node); err == nil {
+		if platform, ok := osFromSelector(no.Labels); ok && platform == windowsOS {
+			n.App().Flash().Warnf("Node shell is not supported on Windows node %s", node)
+			return nil
+		}
+	}
+
 	n.Stop()
 	defer n.Start()
-	_, node := client.Namespaced(path)
 	launchNodeShell(n, n.App(), node)
 
 	return nil
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tcell/v2"
+)
+
+func (n *Node) historyCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := n.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	if ctx, _ := model1.SplitMultiContextID(path); ctx != "" {
+		n.App().Flash().Warnf("Condition history is only available for the active context. Switch to %q first", ctx)
+		return nil
+	}
+
+	_, name := client.Namespaced(path)
+	tt, since := dao.NodeConditions.Transitions(n.App().Conn().ActiveContext(), name)
+	if since.IsZero() {
+		n.App().Flash().Warnf("No history recorded for node %s yet", name)
+		return nil
+	}
+	res, err := dao.AccessorFor(n.App().factory, client.NodeGVR)
+	if err != nil {
+		n.App().Flash().Err(err)
+		return nil
+	}
+	no, ok := res.(*dao.Node)
+	if !ok {
+		n.App().Flash().Errf("expecting a node accessor but got %T", res)
+		return nil
+	}
+	pods, err := no.GetPods(name)
+	if err != nil {
+		n.App().Flash().Err(err)
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Observed since %s (%s ago)\n\n", since.Local().Format(timelineTimeFormat), time.Since(since).Round(time.Second))
+	b.WriteString(renderTimeline(dao.NodeTimeline(name, tt, pods, since)))
+	details := NewDetails(n.App(), "Condition History", name, contentTXT, true).Update(b.String())
+	if err := n.App().inject(details, false); err != nil {
+		n.App().Flash().Err(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tcell/v2"
+)
+
+const (
+	kubeletDeadline = 30 * time.Second
+	maxVolumeRows   = 20
+)
+
+func (n *Node) kubeletCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := n.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	if ctx, _ := model1.SplitMultiContextID(path); ctx != "" {
+		n.App().Flash().Warnf("Kubelet health is only available for the active context. Switch to %q first", ctx)
+		return nil
+	}
+
+	_, name := client.Namespaced(path)
+	n.App().Flash().Infof("Querying kubelet on %s...", name)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), kubeletDeadline)
+		defer cancel()
+
+		var out string
+		r, err := dao.FetchKubeletReport(ctx, n.App().Conn(), name)
+		if err != nil {
+			out = fmt.Sprintf("Error: %s\n", err)
+		} else {
+			out = renderKubeletReport(r)
+		}
+		n.App().QueueUpdateDraw(func() {
+			details := NewDetails(n.App(), "Kubelet", name, contentTXT, true).Update(out)
+			if e := n.App().inject(details, false); e != nil {
+				n.App().Flash().Err(e)
+			}
+		})
+	}()
+
+	return nil
+}
+
+func renderKubeletReport(r *dao.KubeletReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Kubelet:  %s\nRuntime:  %s\nKernel:   %s\nOS:       %s\n", r.KubeletVersion, r.RuntimeVersion, r.KernelVersion, r.OSImage)
+
+	b.WriteString("\n=== Healthz ===\n")
+	if r.HealthzErr != nil {
+		fmt.Fprintf(&b, "UNHEALTHY: %s\n", r.HealthzErr)
+	}
+	for _, l := range r.Healthz {
+		b.WriteString(l + "\n")
+	}
+
+	b.WriteString("\n=== PLEG ===\n")
+	switch {
+	case r.MetricsErr != nil:
+		fmt.Fprintf(&b, "metrics unavailable: %s\n", r.MetricsErr)
+	default:
+		fmt.Fprintf(&b, "Relist avg: %s\n", r.PLEGRelistAvg)
+		if !r.PLEGLastSeen.IsZero() {
+			fmt.Fprintf(&b, "Last seen:  %s ago\n", time.Since(r.PLEGLastSeen).Round(time.Second))
+		}
+	}
+
+	b.WriteString("\n=== Stats ===\n")
+	if r.SummaryErr != nil {
+		fmt.Fprintf(&b, "stats unavailable: %s\n", r.SummaryErr)
+		return b.String()
+	}
+	s := r.Summary
+	fmt.Fprintf(&b, "Node fs:  %s\n", fsUsage(s.Node.Fs))
+	if s.Node.Runtime != nil {
+		fmt.Fprintf(&b, "Image fs: %s\n", fsUsage(s.Node.Runtime.ImageFs))
+	}
+	var containers, volumes int
+	type volRow struct {
+		pod, vol string
+		used     uint64
+		usage    string
+	}
+	vv := make([]volRow, 0, len(s.Pods))
+	for _, p := range s.Pods {
+		containers += len(p.Containers)
+		volumes += len(p.Volumes)
+		for _, v := range p.Volumes {
+			var used uint64
+			if v.UsedBytes != nil {
+				used = *v.UsedBytes
+			}
+			vv = append(vv, volRow{pod: client.FQN(p.PodRef.Namespace, p.PodRef.Name), vol: v.Name, used: used, usage: fsUsage(&v.KubeletFsStats)})
+		}
+	}
+	fmt.Fprintf(&b, "Pods: %d  Containers: %d  Volumes: %d\n", len(s.Pods), containers, volumes)
+
+	if len(vv) == 0 {
+		return b.String()
+	}
+	sort.Slice(vv, func(i, j int) bool { return vv[i].used > vv[j].used })
+	if len(vv) > maxVolumeRows {
+		vv = vv[:maxVolumeRows]
+	}
+	fmt.Fprintf(&b, "\n=== Top %d volumes by usage ===\n", len(vv))
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "POD\tVOLUME\tUSAGE")
+	for _, v := range vv {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", v.pod, v.vol, v.usage)
+	}
+	_ = w.Flush()
+
+	return b.String()
+}
+
+func fsUsage(fs *dao.KubeletFsStats) string {
+	if fs == nil || fs.CapacityBytes == nil || *fs.CapacityBytes == 0 {
+		return client.NA
+	}
+	var used uint64
+	switch {
+	case fs.UsedBytes != nil:
+		used = *fs.UsedBytes
+	case fs.AvailableBytes != nil:
+		used = *fs.CapacityBytes - *fs.AvailableBytes
+	}
+
+	return fmt.Sprintf("%s/%s (%d%%)", toGi(used), toGi(*fs.CapacityBytes), used*100 / *fs.CapacityBytes)
+}
+
+func toGi(b uint64) string {
+	return fmt.Sprintf("%.1fGi", float64(b)/(1<<30))
+}
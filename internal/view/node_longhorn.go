@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+)
+
+const (
+	longhornEvictTimeout = 30 * time.Minute
+	maxDrainRisks        = 5
+)
+
+// longhornDrainWarning lists the Longhorn volumes at risk by node.
+func longhornDrainWarning(nodes []string, risks map[string][]dao.LonghornVolumeRisk) string {
+	var b strings.Builder
+	b.WriteString("Longhorn volumes at risk:")
+	for _, n := range nodes {
+		rr := risks[n]
+		if len(rr) == 0 {
+			continue
+		}
+		ss := make([]string, 0, maxDrainRisks)
+		for _, r := range rr[:min(len(rr), maxDrainRisks)] {
+			ss = append(ss, r.String())
+		}
+		if len(rr) > maxDrainRisks {
+			ss = append(ss, fmt.Sprintf("+%d more", len(rr)-maxDrainRisks))
+		}
+		fmt.Fprintf(&b, "\n%s: %s", n, strings.Join(ss, ", "))
+	}
+
+	return b.String()
+}
+
+// queuedWriter writes to a view from a background routine.
+type queuedWriter struct {
+	app *App
+	w   io.Writer
+}
+
+// Write queues the write on the UI thread.
+func (q queuedWriter) Write(p []byte) (int, error) {
+	bb := slices.Clone(p)
+	q.app.QueueUpdateDraw(func() {
+		_, _ = q.w.Write(bb)
+	})
+
+	return len(p), nil
+}
+
+// clearLonghornEvictions withdraws the Longhorn eviction requests of nodes
+// that did not end up drained.
+func clearLonghornEvictions(a *App, nodes []string, w io.Writer) {
+	for _, n := range nodes {
+		dao.ClearLonghornEviction(a.factory, n, w)
+	}
+}
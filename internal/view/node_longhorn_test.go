@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLonghornDrainWarning(t *testing.T) {
+	last := dao.LonghornVolumeRisk{Volume: "v1", Robustness: "degraded", Replicas: 2, Healthy: 1, OnNode: 1}
+	many := make([]dao.LonghornVolumeRisk, 0, 7)
+	for range 7 {
+		many = append(many, last)
+	}
+
+	uu := map[string]struct {
+		nodes []string
+		risks map[string][]dao.LonghornVolumeRisk
+		e     string
+	}{
+		"single": {
+			nodes: []string{"n1", "n2"},
+			risks: map[string][]dao.LonghornVolumeRisk{"n2": {last}},
+			e:     "Longhorn volumes at risk:\nn2: v1 (last healthy replica)",
+		},
+		"capped": {
+			nodes: []string{"n1"},
+			risks: map[string][]dao.LonghornVolumeRisk{"n1": many},
+			e: "Longhorn volumes at risk:\nn1: v1 (last healthy replica), v1 (last healthy replica), " +
+				"v1 (last healthy replica), v1 (last healthy replica), v1 (last healthy replica), +2 more",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, longhornDrainWarning(u.nodes, u.risks))
+		})
+	}
+}
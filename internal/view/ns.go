@@ -4,16 +4,20 @@
 package view
 
 import (
+	"strings"
+
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/model1"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
 	favNSIndicator     = "+"
 	defaultNSIndicator = "(*)"
+	favNSDialogKey     = "favorites"
 )
 
 // Namespace represents a namespace viewer.
@@ -36,7 +40,73 @@ func NewNamespace(gvr *client.GVR) ResourceViewer {
 func (n *Namespace) bindKeys(aa *ui.KeyActions) {
 	aa.Bulk(ui.KeyMap{
 		ui.KeyU: ui.NewKeyAction("Use", n.useNsCmd, true),
+		ui.KeyF: ui.NewKeyAction("Edit Favorites", n.favoritesCmd, true),
+		ui.KeyB: ui.NewKeyAction("Repro Bundle", n.reproBundleCmd, true),
+	})
+}
+
+func (n *Namespace) reproBundleCmd(*tcell.EventKey) *tcell.EventKey {
+	path := n.GetTable().GetSelectedItem()
+	if path == "" {
+		return nil
+	}
+	_, ns := client.Namespaced(path)
+	n.App().reproBundle(ns)
+
+	return nil
+}
+
+func (n *Namespace) favoritesCmd(*tcell.EventKey) *tcell.EventKey {
+	ct, err := n.App().Config.K9s.ActiveContext()
+	if err != nil {
+		n.App().Flash().Err(err)
+		return nil
+	}
+	favs, lock := strings.Join(ct.Namespace.Favorites, ", "), ct.Namespace.LockFavorites
+
+	styles := n.App().Styles.Dialog()
+	f := tview.NewForm().
+		SetItemPadding(0).
+		SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(styles.ButtonBgColor.Color()).
+		SetButtonTextColor(styles.ButtonFgColor.Color()).
+		SetLabelColor(styles.LabelFgColor.Color()).
+		SetFieldTextColor(styles.FieldFgColor.Color())
+	f.AddInputField("Favorites:", favs, 60, nil, func(changed string) {
+		favs = changed
+	})
+	f.AddCheckbox("Lock:", lock, func(_ string, checked bool) {
+		lock = checked
+	})
+	f.AddButton("OK", func() {
+		n.App().Content.RemovePage(favNSDialogKey)
+		ct.Namespace.SetFavorites(strings.Split(favs, ","), lock)
+		if err := n.App().Config.Save(true); err != nil {
+			n.App().Flash().Err(err)
+			return
+		}
+		n.App().nsBar().Refresh()
+		n.Refresh()
+		n.App().Flash().Infof("Saved %d favorite namespaces", len(ct.Namespace.Favorites))
 	})
+	f.AddButton("Cancel", func() {
+		n.App().Content.RemovePage(favNSDialogKey)
+	})
+	for i := range f.GetButtonCount() {
+		f.GetButton(i).
+			SetBackgroundColorActivated(styles.ButtonFocusBgColor.Color()).
+			SetLabelColorActivated(styles.ButtonFocusFgColor.Color())
+	}
+
+	modal := tview.NewModalForm("<Favorites>", f)
+	modal.SetText("Comma separated favorite namespaces, in number key order. Lock to stop adding visited namespaces.")
+	modal.SetDoneFunc(func(int, string) {
+		n.App().Content.RemovePage(favNSDialogKey)
+	})
+	n.App().Content.AddPage(favNSDialogKey, modal, false, false)
+	n.App().Content.ShowPage(favNSDialogKey)
+
+	return nil
 }
 
 func (n *Namespace) switchNs(app *App, _ ui.Tabular, _ *client.GVR, path string) {
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tview"
+)
+
+// NsBar presents the favorite namespaces along with their number keys.
+type NsBar struct {
+	*tview.TextView
+
+	app    *App
+	styles *config.Styles
+}
+
+// NewNsBar returns a new favorite namespaces bar.
+func NewNsBar(app *App) *NsBar {
+	b := NsBar{
+		TextView: tview.NewTextView(),
+		app:      app,
+		styles:   app.Styles,
+	}
+	b.SetDynamicColors(true)
+	b.SetWrap(false)
+	b.SetBackgroundColor(app.Styles.BgColor())
+	app.Styles.AddListener(&b)
+
+	return &b
+}
+
+// StylesChanged notifies skin changed.
+func (b *NsBar) StylesChanged(s *config.Styles) {
+	b.styles = s
+	b.SetBackgroundColor(s.BgColor())
+	b.Refresh()
+}
+
+// Refresh renders the favorite namespaces, highlighting the active one.
+func (b *NsBar) Refresh() {
+	if b.app.Conn() == nil || !b.app.ConOK() {
+		b.Clear()
+		return
+	}
+	active := b.app.Config.ActiveNamespace()
+	if client.IsAllNamespaces(active) {
+		active = client.NamespaceAll
+	}
+	b.SetText(nsBarText(b.app.favNamespaceSlots(), active, b.styles))
+}
+
+// favNamespaceSlots returns the favorite namespaces indexed by number key.
+// All namespaces take key 0 when the user may list them.
+func (a *App) favNamespaceSlots() []string {
+	ss := make([]string, 0, len(ui.NumKeys))
+	if ok, _ := a.Conn().CanI(client.NamespaceAll, client.NsGVR, "", client.ListAccess); ok {
+		ss = append(ss, client.NamespaceAll)
+	}
+	favs := a.Config.FavNamespaces()
+	for _, ns := range favs {
+		if ns == client.NamespaceAll {
+			continue
+		}
+		if _, ok := ui.NumKeys[len(ss)]; !ok {
+			slog.Warn("No number key available for favorite namespace. Skipping...",
+				slogs.Namespace, ns,
+				slogs.Index, len(ss),
+				slogs.Max, len(favs),
+			)
+			break
+		}
+		ss = append(ss, ns)
+	}
+
+	return ss
+}
+
+func nsBarText(slots []string, active string, s *config.Styles) string {
+	var (
+		b     strings.Builder
+		menu  = s.Frame().Menu
+		title = s.Frame().Title
+	)
+	for i, ns := range slots {
+		fmt.Fprintf(&b, " [%s::b]<%d>[-::-] ", menu.NumKeyColor, i)
+		if ns == active {
+			fmt.Fprintf(&b, "[%s::r]%s[-::-]", title.HighlightColor, ns)
+		} else {
+			fmt.Fprintf(&b, "[%s::]%s[-::]", menu.FgColor, ns)
+		}
+	}
+
+	return b.String()
+}
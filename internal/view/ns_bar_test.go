@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNsBarText(t *testing.T) {
+	s := config.NewStyles()
+	menu, title := s.Frame().Menu, s.Frame().Title
+
+	uu := map[string]struct {
+		slots  []string
+		active string
+		e      string
+	}{
+		"empty": {},
+		"active": {
+			slots:  []string{"all", "default", "fred"},
+			active: "fred",
+			e: " [" + menu.NumKeyColor.String() + "::b]<0>[-::-] [" + menu.FgColor.String() + "::]all[-::]" +
+				" [" + menu.NumKeyColor.String() + "::b]<1>[-::-] [" + menu.FgColor.String() + "::]default[-::]" +
+				" [" + menu.NumKeyColor.String() + "::b]<2>[-::-] [" + title.HighlightColor.String() + "::r]fred[-::-]",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, nsBarText(u.slots, u.active, s))
+		})
+	}
+}
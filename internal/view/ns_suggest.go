@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/k9s/internal/view/cmd"
+)
+
+const mcNamespacesTTL = time.Minute
+
+// mcNamespaces caches the namespaces of the selected contexts so prompt
+// suggestions never wait on remote clusters.
+type mcNamespaces struct {
+	mx      sync.Mutex
+	ctxs    []string
+	counts  map[string]int
+	at      time.Time
+	loading bool
+}
+
+// get returns the cached namespace counts of the given contexts and refreshes
+// them in the background once stale.
+func (m *mcNamespaces) get(ctxs []string, load func([]string) map[string]int) map[string]int {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	if !slices.Equal(ctxs, m.ctxs) {
+		m.ctxs, m.counts, m.at = slices.Clone(ctxs), nil, time.Time{}
+	}
+	if time.Since(m.at) > mcNamespacesTTL && !m.loading {
+		m.loading = true
+		go func() {
+			cc := load(ctxs)
+			m.mx.Lock()
+			defer m.mx.Unlock()
+			m.loading = false
+			if slices.Equal(ctxs, m.ctxs) {
+				m.counts, m.at = cc, time.Now()
+			}
+		}()
+	}
+
+	return m.counts
+}
+
+// selectedNamespaces returns the namespaces of the selected contexts along
+// with the number of contexts holding each, or nil in single context mode.
+func (a *App) selectedNamespaces() (map[string]int, int) {
+	sel, _ := config.LoadSelectedContexts()
+	if len(sel) < 2 || a.Conn() == nil {
+		return nil, 0
+	}
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return nil, 0
+	}
+
+	return a.mcNamespaces.get(sel, func(ctxs []string) map[string]int {
+		counts, err := dao.MultiContextNamespaces(context.Background(), rawCfg, ctxs)
+		if err != nil {
+			slog.Warn("Failed to list namespaces across contexts", slogs.Error, err)
+		}
+		return counts
+	}), len(sel)
+}
+
+// mergeNamespaces returns the union of the active context namespaces and the
+// namespaces of the selected contexts.
+func mergeNamespaces(nn client.NamespaceNames, counts map[string]int) client.NamespaceNames {
+	if len(counts) == 0 {
+		return nn
+	}
+	all := make(client.NamespaceNames, len(nn)+len(counts))
+	for ns := range nn {
+		all[ns] = struct{}{}
+	}
+	for ns := range counts {
+		all[ns] = struct{}{}
+	}
+
+	return all
+}
+
+// annotateNSSuggestions notes how many of the selected contexts hold each
+// suggested namespace, ie `ube-system  (2/3)` for `:pods k`.
+func annotateNSSuggestions(command string, suggests []string, counts map[string]int, total int) []string {
+	if total < 2 || len(counts) == 0 {
+		return suggests
+	}
+	p := cmd.NewInterpreter(command)
+	ns, ok := p.NSArg()
+	if p.IsXrayCmd() {
+		_, ns, ok = p.XrayArgs()
+	}
+	if !ok {
+		return suggests
+	}
+
+	out := make([]string, 0, len(suggests))
+	for _, s := range suggests {
+		if n, ok := counts[ns+s]; ok {
+			s += fmt.Sprintf("%s%d/%d)", model.SuggestionNoteSep, n, total)
+		}
+		out = append(out, s)
+	}
+
+	return out
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeNamespaces(t *testing.T) {
+	nn := client.NamespaceNames{"default": {}, "kube-system": {}}
+
+	assert.Equal(t, nn, mergeNamespaces(nn, nil))
+	assert.Equal(t, client.NamespaceNames{
+		"default":         {},
+		"kube-system":     {},
+		"longhorn-system": {},
+	}, mergeNamespaces(nn, map[string]int{"default": 2, "longhorn-system": 1}))
+	assert.Len(t, nn, 2)
+}
+
+func TestAnnotateNSSuggestions(t *testing.T) {
+	counts := map[string]int{"kube-system": 3, "kube-public": 1}
+
+	uu := map[string]struct {
+		cmd      string
+		suggests []string
+		total    int
+		e        []string
+	}{
+		"single-context": {
+			cmd:      "po k",
+			suggests: []string{"ube-public", "ube-system"},
+			total:    1,
+			e:        []string{"ube-public", "ube-system"},
+		},
+		"namespaces": {
+			cmd:      "po k",
+			suggests: []string{"ube-public", "ube-system"},
+			total:    3,
+			e:        []string{"ube-public  (1/3)", "ube-system  (3/3)"},
+		},
+		"contexts": {
+			cmd:      "ctx p",
+			suggests: []string{"re", "rod"},
+			total:    3,
+			e:        []string{"re", "rod"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, annotateNSSuggestions(u.cmd, u.suggests, counts, u.total))
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+)
+
+const (
+	timelineAxisWidth = 60
+	timelineLaneWidth = 36
+	timelineTicks     = 6
+	timelineTickFmt   = "15:04"
+)
+
+// nsTimelineCmd plots what happened in the active namespace over the given
+// window, from the informer cache.
+func (a *App) nsTimelineCmd(arg string) {
+	window := dao.NamespaceTimelineWindow
+	if arg != "" {
+		d, err := time.ParseDuration(arg)
+		if err != nil || d <= 0 {
+			a.Flash().Errf("Invalid command. Use `timeline [duration]`, ie `timeline 1h`")
+			return
+		}
+		window = d
+	}
+	if a.Conn() == nil || !a.Conn().ConnectionOK() {
+		a.Flash().Warn("Timeline requires a cluster connection")
+		return
+	}
+
+	ns, subject := a.Config.ActiveNamespace(), a.Config.ActiveNamespace()
+	if client.IsAllNamespaces(ns) {
+		ns, subject = client.BlankNamespace, client.NamespaceAll
+	}
+	f := a.factory
+	a.Flash().Infof("Assembling %s timeline...", subject)
+	go func() {
+		now := time.Now()
+		since := now.Add(-window)
+		var out string
+		tt, err := dao.FetchNamespaceTimeline(f, ns, since)
+		if err != nil {
+			out = fmt.Sprintf("Error: %s\n", err)
+		} else {
+			out = plotTimeline(tt, since, now, timelineAxisWidth) + renderTimeline(tt)
+		}
+		a.QueueUpdateDraw(func() {
+			details := NewDetails(a, "Timeline", subject, contentTXT, true).Update(out)
+			if e := a.inject(details, false); e != nil {
+				a.Flash().Err(e)
+			}
+		})
+	}()
+}
+
+// plotTimeline draws one lane per object on a time axis. Cells show a
+// warning, a single entry or the number of entries in that time slot.
+func plotTimeline(tt []dao.TimelineEntry, since, now time.Time, width int) string {
+	if len(tt) == 0 || !now.After(since) || width < 2 {
+		return ""
+	}
+	span := now.Sub(since)
+	col := func(at time.Time) int {
+		c := int(float64(at.Sub(since)) / float64(span) * float64(width-1))
+		return min(max(c, 0), width-1)
+	}
+
+	type lane struct {
+		counts []int
+		warn   []bool
+	}
+	var order []string
+	lanes := make(map[string]*lane)
+	for _, t := range tt {
+		l, ok := lanes[t.Source]
+		if !ok {
+			l = &lane{counts: make([]int, width), warn: make([]bool, width)}
+			lanes[t.Source] = l
+			order = append(order, t.Source)
+		}
+		c := col(t.At)
+		l.counts[c]++
+		l.warn[c] = l.warn[c] || t.Warn
+	}
+
+	var b strings.Builder
+	pad := strings.Repeat(" ", timelineLaneWidth+2)
+	labels, ruler := []byte(strings.Repeat(" ", width)), []byte(strings.Repeat("-", width))
+	for i := 0; i <= timelineTicks; i++ {
+		p := i * (width - 1) / timelineTicks
+		ruler[p] = '+'
+		l := since.Add(span * time.Duration(i) / timelineTicks).Local().Format(timelineTickFmt)
+		p = min(p, width-len(l))
+		copy(labels[p:], l)
+	}
+	fmt.Fprintf(&b, "%s%s\n%s%s\n", pad, strings.TrimRight(string(labels), " "), pad, ruler)
+	for _, src := range order {
+		l := lanes[src]
+		cells := make([]byte, width)
+		for i, n := range l.counts {
+			switch {
+			case l.warn[i]:
+				cells[i] = '!'
+			case n == 0:
+				cells[i] = ' '
+			case n == 1:
+				cells[i] = '*'
+			case n < 10:
+				cells[i] = byte('0' + n)
+			default:
+				cells[i] = '+'
+			}
+		}
+		fmt.Fprintf(&b, "%-*s |%s|\n", timelineLaneWidth, laneName(src), cells)
+	}
+	fmt.Fprintf(&b, "\n%s* entry  ! warning  2-9 entries  + more\n\n", pad)
+
+	return b.String()
+}
+
+func laneName(s string) string {
+	if len(s) <= timelineLaneWidth {
+		return s
+	}
+
+	return "..." + s[len(s)-timelineLaneWidth+3:]
+}
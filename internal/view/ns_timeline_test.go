@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlotTimeline(t *testing.T) {
+	t0 := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time {
+		return t0.Add(time.Duration(m) * time.Minute)
+	}
+	tt := []dao.TimelineEntry{
+		{At: at(0), Source: "pod/p1"},
+		{At: at(6), Source: "pod/p1", Warn: true},
+		{At: at(6), Source: "pod/p1"},
+		{At: at(12), Source: "deployment/d1"},
+		{At: at(12), Source: "deployment/d1"},
+	}
+
+	ll := strings.Split(plotTimeline(tt, at(0), at(12), 13), "\n")
+	require.Greater(t, len(ll), 4)
+	assert.Equal(t, strings.Repeat(" ", timelineLaneWidth+2)+"+-+-+-+-+-+-+", ll[1])
+	assert.Equal(t, "pod/p1"+strings.Repeat(" ", timelineLaneWidth-6)+" |*     !      |", ll[2])
+	assert.Equal(t, "deployment/d1"+strings.Repeat(" ", timelineLaneWidth-13)+" |            2|", ll[3])
+
+	assert.Empty(t, plotTimeline(nil, at(0), at(12), 13))
+}
+
+func TestLaneName(t *testing.T) {
+	assert.Equal(t, "pod/p1", laneName("pod/p1"))
+
+	n := laneName("ns1/pod/" + strings.Repeat("x", 40))
+	assert.Len(t, n, timelineLaneWidth)
+	assert.True(t, strings.HasPrefix(n, "..."))
+}
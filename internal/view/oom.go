@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tcell/v2"
+)
+
+// OOM presents OOM kills and evictions.
+type OOM struct {
+	ResourceViewer
+}
+
+// NewOOM returns a new viewer.
+func NewOOM(gvr *client.GVR) ResourceViewer {
+	o := OOM{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	o.GetTable().SetEnterFn(o.showWorkload)
+	o.AddBindKeysFn(o.bindKeys)
+	o.GetTable().SetSortCol("LAST-SEEN", true)
+
+	return &o
+}
+
+func (o *OOM) bindKeys(aa *ui.KeyActions) {
+	aa.Bulk(ui.KeyMap{
+		ui.KeyShiftC: ui.NewKeyAction("Sort Context", o.GetTable().SortColCmd("CONTEXT", true), false),
+		ui.KeyShiftR: ui.NewKeyAction("Sort Reason", o.GetTable().SortColCmd("REASON", true), false),
+		ui.KeyShiftT: ui.NewKeyAction("Sort Count", o.GetTable().SortColCmd("COUNT", false), false),
+		ui.KeyP:      ui.NewKeyAction("Show Pod", o.showPodCmd, true),
+	})
+}
+
+// parseOOMPath splits an OOM record id into its context, workload gvr,
+// workload path and pod path.
+func parseOOMPath(path string) (ctx, gvr, wfqn, pfqn string, err error) {
+	tt := strings.Split(path, "|")
+	if len(tt) != 7 {
+		return "", "", "", "", fmt.Errorf("unable to parse OOM record %q", path)
+	}
+
+	return tt[0], tt[1], client.FQN(tt[2], tt[3]), client.FQN(tt[2], tt[4]), nil
+}
+
+func (o *OOM) showWorkload(app *App, _ ui.Tabular, _ *client.GVR, path string) {
+	ctx, gvr, fqn, _, err := parseOOMPath(path)
+	if err != nil {
+		app.Flash().Err(err)
+		return
+	}
+	if !o.checkContext(ctx) {
+		return
+	}
+	app.gotoResource(gvr, fqn, false, true)
+}
+
+func (o *OOM) showPodCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := o.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	ctx, _, _, fqn, err := parseOOMPath(path)
+	if err != nil {
+		o.App().Flash().Err(err)
+		return nil
+	}
+	if !o.checkContext(ctx) {
+		return nil
+	}
+	o.App().gotoResource(client.PodGVR.String(), fqn, false, true)
+
+	return nil
+}
+
+func (o *OOM) checkContext(ctx string) bool {
+	if active := o.App().Config.K9s.ActiveContextName(); ctx != active {
+		o.App().Flash().Warnf("Record belongs to context %q. Switch to it first", ctx)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/k9s/internal/ui/dialog"
+)
+
+const pipeDeadline = 2 * time.Minute
+
+// pipeCmd feeds the filtered rows of a table view, as JSON, to a plugin or an
+// external command once the view data is loaded and shows the command output.
+func (a *App) pipeCmd(c model.Component, target string) {
+	tv, ok := c.(TableViewer)
+	if !ok {
+		a.Flash().Warnf("View %q can not be piped", c.Name())
+		return
+	}
+	opts, p, err := a.pipeOpts(tv, target)
+	if err != nil {
+		a.Flash().Err(err)
+		return
+	}
+
+	l := pipeListener{app: a, viewer: tv, opts: opts, plugin: p, target: target}
+	m := tv.GetTable().GetModel()
+	m.AddListener(&l)
+	if data := m.Peek(); data != nil && !data.Empty() {
+		l.TableDataChanged(data)
+	}
+}
+
+// pipeOpts resolves a pipe target to a plugin or to an external command.
+// External commands are unknown to rk9s so they are treated as dangerous.
+func (a *App) pipeOpts(tv TableViewer, target string) (*shellOpts, *config.Plugin, error) {
+	ff := strings.Fields(target)
+	if len(ff) == 0 {
+		return nil, nil, errors.New("missing pipe command")
+	}
+
+	pp := config.NewPlugins()
+	if path, err := a.Config.ContextPluginsPath(); err == nil {
+		if err := pp.Load(path, true); err != nil {
+			slog.Warn("Plugins load failed", slogs.Error, err)
+		}
+	}
+	p, ok := pp.Plugins[ff[0]]
+	if !ok {
+		if a.Config.IsReadOnly() {
+			return nil, nil, fmt.Errorf("command %q is not allowed in read-only mode", ff[0])
+		}
+		return &shellOpts{binary: ff[0], args: ff[1:]}, nil, nil
+	}
+	if p.Dangerous && a.Config.IsReadOnly() {
+		return nil, nil, fmt.Errorf("plugin %q is not allowed in read-only mode", ff[0])
+	}
+
+	args := make([]string, 0, len(p.Args)+len(ff)-1)
+	for _, arg := range p.Args {
+		if r, ok := tv.(Runner); ok && r.EnvFn() != nil {
+			if s, err := r.EnvFn()().Substitute(arg); err == nil {
+				arg = s
+			}
+		}
+		args = append(args, arg)
+	}
+
+	bin, args := a.pluginCommand(&p, append(args, ff[1:]...))
+
+	return &shellOpts{binary: bin, args: args}, &p, nil
+}
+
+// pipeListener runs a pipe command on the first data load of a view.
+type pipeListener struct {
+	app    *App
+	viewer TableViewer
+	opts   *shellOpts
+	plugin *config.Plugin
+	target string
+	once   sync.Once
+}
+
+// TableDataChanged runs the pipe command with the filtered view rows.
+func (l *pipeListener) TableDataChanged(*model1.TableData) {
+	l.once.Do(func() {
+		go l.viewer.GetTable().GetModel().RemoveListener(l)
+		l.run(l.viewer.GetTable().GetFilteredData())
+	})
+}
+
+// TableNoData runs the pipe command with no rows.
+func (l *pipeListener) TableNoData(data *model1.TableData) {
+	l.TableDataChanged(data)
+}
+
+// TableLoadFailed cancels the pipe.
+func (l *pipeListener) TableLoadFailed(err error) {
+	l.once.Do(func() {
+		go l.viewer.GetTable().GetModel().RemoveListener(l)
+		l.app.Flash().Errf("Pipe to %q canceled: %s", l.target, err)
+	})
+}
+
+func (l *pipeListener) run(data *model1.TableData) {
+	raw, err := json.Marshal(redactTable(l.app.redactor(), data).Records())
+	if err != nil {
+		l.app.Flash().Err(err)
+		return
+	}
+	l.opts.input = bytes.NewReader(raw)
+
+	cb := func() {
+		l.app.Flash().Infof("Piping %d rows to %s...", data.RowCount(), l.target)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), pipeDeadline)
+			defer cancel()
+
+			out, err := oneShoot(ctx, l.opts)
+			if err != nil {
+				out = fmt.Sprintf("Error: %s\n\n%s", err, out)
+			}
+			l.app.QueueUpdateDraw(func() {
+				details := NewDetails(l.app, "Pipe", l.target, contentANSI, true).Update(out)
+				if e := l.app.inject(details, false); e != nil {
+					l.app.Flash().Err(e)
+				}
+			})
+		}()
+	}
+	title := "Confirm Pipe"
+	if l.plugin != nil {
+		if !l.plugin.Confirm {
+			cb()
+			return
+		}
+		title = "Confirm " + l.plugin.Description
+	}
+	l.app.QueueUpdateDraw(func() {
+		msg := fmt.Sprintf("Pipe %d rows to?\n%s", data.RowCount(), l.opts)
+		d := l.app.Styles.Dialog()
+		dialog.ShowConfirm(&d, l.app.Content.Pages, title, msg, cb, func() {})
+	})
+}
@@ -55,7 +55,9 @@ func NewPod(gvr *client.GVR) ResourceViewer {
 		NewOwnerExtender(
 			NewVulnerabilityExtender(
 				NewImageExtender(
-					NewLogsExtender(NewBrowser(gvr), p.logOptions),
+					NewWaitExtender(
+						NewLogsExtender(NewBrowser(gvr), p.logOptions),
+					),
 				),
 			),
 		),
@@ -130,7 +132,8 @@ func (p *Pod) bindKeys(aa *ui.KeyActions) {
 	}
 
 	aa.Bulk(ui.KeyMap{
-		ui.KeyO: ui.NewKeyAction("Show Node", p.showNode, true),
+		ui.KeyO:      ui.NewKeyAction("Show Node", p.showNode, true),
+		ui.KeyShiftT: ui.NewKeyAction("Restart Timeline", p.timelineCmd, true),
 	})
 }
 
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tcell/v2"
+)
+
+const (
+	timelineDeadline   = 30 * time.Second
+	timelineTimeFormat = "2006-01-02 15:04:05"
+)
+
+func (p *Pod) timelineCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := p.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	if ctx, _ := model1.SplitMultiContextID(path); ctx != "" {
+		p.App().Flash().Warnf("Restart timeline is only available for the active context. Switch to %q first", ctx)
+		return nil
+	}
+	pod, err := fetchPod(p.App().factory, path)
+	if err != nil {
+		p.App().Flash().Err(err)
+		return nil
+	}
+
+	p.App().Flash().Infof("Assembling restart timeline for %s...", path)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), timelineDeadline)
+		defer cancel()
+
+		var out string
+		tt, err := dao.FetchRestartTimeline(ctx, p.App().Conn(), pod)
+		if err != nil {
+			out = fmt.Sprintf("Error: %s\n", err)
+		} else {
+			out = renderTimeline(tt) + "\nNote: the kubelet only retains the last termination of each container, earlier restarts surface through events.\n"
+		}
+		p.App().QueueUpdateDraw(func() {
+			details := NewDetails(p.App(), "Restart Timeline", path, contentTXT, true).Update(out)
+			if e := p.App().inject(details, false); e != nil {
+				p.App().Flash().Err(e)
+			}
+		})
+	}()
+
+	return nil
+}
+
+func renderTimeline(tt []dao.TimelineEntry) string {
+	if len(tt) == 0 {
+		return "No history available\n"
+	}
+
+	var b strings.Builder
+	for _, t := range tt {
+		marker := " "
+		if t.Warn {
+			marker = "!"
+		}
+		fmt.Fprintf(&b, "%s %s  %-24s %s\n", marker, t.At.Local().Format(timelineTimeFormat), t.Source, t.Message)
+	}
+
+	return b.String()
+}
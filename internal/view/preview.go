@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/derailed/tview"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const previewTitle = "Preview"
+
+// RowPreview presents a condensed summary of the highlighted row.
+type RowPreview struct {
+	*tview.TextView
+
+	app    *App
+	table  *Table
+	hooked map[*Table]struct{}
+	key    string
+	seq    atomic.Int64
+	active bool
+}
+
+// NewRowPreview returns a new preview pane.
+func NewRowPreview(a *App) *RowPreview {
+	p := RowPreview{
+		TextView: tview.NewTextView(),
+		app:      a,
+		hooked:   make(map[*Table]struct{}),
+	}
+	p.SetDynamicColors(true)
+	p.SetWrap(true)
+	p.SetBorder(true)
+	p.SetBorderPadding(0, 0, 1, 1)
+	p.SetTitle(" " + previewTitle + " ")
+	p.StylesChanged(a.Styles)
+	a.Styles.AddListener(&p)
+
+	return &p
+}
+
+// StylesChanged notifies the skin changed.
+func (p *RowPreview) StylesChanged(s *config.Styles) {
+	p.SetBackgroundColor(s.BgColor())
+	p.SetTextColor(s.FgColor())
+	p.SetBorderColor(s.Frame().Border.FgColor.Color())
+	p.SetTitleColor(s.Frame().Title.FgColor.Color())
+}
+
+// StackPushed notifies a new component was pushed.
+func (p *RowPreview) StackPushed(c model.Component) {
+	p.track(c)
+}
+
+// StackPopped notifies a component was removed.
+func (p *RowPreview) StackPopped(_, top model.Component) {
+	p.track(top)
+}
+
+// StackTop notifies the top component.
+func (p *RowPreview) StackTop(c model.Component) {
+	p.track(c)
+}
+
+// track follows the cursor of the given component when it is a resource table.
+func (p *RowPreview) track(c model.Component) {
+	p.table, p.key = nil, ""
+	if c == nil {
+		return
+	}
+	v, ok := c.(ResourceViewer)
+	if !ok {
+		p.show(fmt.Sprintf("[gray::]No preview for %s", c.Name()))
+		return
+	}
+	t := v.GetTable()
+	p.table = t
+	if _, ok := p.hooked[t]; !ok {
+		p.hooked[t] = struct{}{}
+		t.AddSelectedRowFn(func(int) {
+			if p.table == t {
+				p.load()
+			}
+		})
+	}
+	p.load()
+}
+
+// load fetches the summary of the highlighted row in the background.
+func (p *RowPreview) load() {
+	if !p.active || p.table == nil {
+		return
+	}
+	gvr, path := p.table.GVR(), p.table.GetSelectedItem()
+	key := gvr.String() + "|" + path
+	if key == p.key {
+		return
+	}
+	p.key = key
+	seq := p.seq.Add(1)
+	if path == "" {
+		p.show("[gray::]No row selected")
+		return
+	}
+	if ctx, _ := model1.SplitMultiContextID(path); ctx != "" {
+		p.show(fmt.Sprintf("[gray::]Row belongs to context %q", ctx))
+		return
+	}
+	meta, err := dao.MetaAccess.MetaFor(gvr)
+	if err != nil || !dao.IsK8sMeta(meta) || p.app.factory == nil {
+		p.show(fmt.Sprintf("[gray::]No preview for %s", gvr))
+		return
+	}
+
+	f := p.app.factory
+	go func() {
+		pv, err := dao.ResourcePreview(f, gvr, path)
+		p.app.QueueUpdateDraw(func() {
+			if p.seq.Load() != seq {
+				return
+			}
+			if err != nil {
+				p.show(fmt.Sprintf("[red::]%s", tview.Escape(err.Error())))
+				return
+			}
+			p.show(renderPreview(pv))
+		})
+	}()
+}
+
+// setActive turns the cursor tracking on or off.
+func (p *RowPreview) setActive(b bool) {
+	p.active, p.key = b, ""
+	p.load()
+}
+
+func (p *RowPreview) show(s string) {
+	p.SetText(s)
+	p.ScrollToBeginning()
+}
+
+// showPreview docks the preview pane on the right or at the bottom of the
+// content area.
+func (a *App) showPreview(pos string) {
+	a.body.Clear()
+	a.body.SetDirection(tview.FlexColumn)
+	if pos == config.PreviewBottom {
+		a.body.SetDirection(tview.FlexRow)
+	}
+	a.body.AddItem(a.Content, 0, 2, true)
+	a.body.AddItem(a.preview, 0, 1, false)
+	a.preview.setActive(true)
+}
+
+func (a *App) hidePreview() {
+	a.body.Clear()
+	a.body.AddItem(a.Content, 0, 1, true)
+	a.preview.setActive(false)
+}
+
+// previewCmd toggles the preview pane or moves it to the given position.
+func (a *App) previewCmd(arg string) {
+	switch arg {
+	case "":
+		if a.preview.active {
+			a.hidePreview()
+			return
+		}
+		var pos string
+		if p := a.Config.K9s.Preview; p != nil {
+			pos = p.Pos()
+		}
+		a.showPreview(pos)
+	case "off":
+		a.hidePreview()
+	case config.PreviewRight, config.PreviewBottom:
+		a.showPreview(arg)
+	default:
+		a.Flash().Errf("Invalid command. Use `preview [right|bottom|off]`")
+	}
+}
+
+// renderPreview formats a resource summary.
+func renderPreview(pv dao.Preview) string {
+	var b strings.Builder
+	name := pv.Name
+	if pv.Namespace != "" {
+		name = client.FQN(pv.Namespace, pv.Name)
+	}
+	fmt.Fprintf(&b, "[::b]%s[::-] %s\n", tview.Escape(pv.Kind), tview.Escape(name))
+	if !pv.Created.IsZero() {
+		fmt.Fprintf(&b, "[gray::]age[-::] %s\n", render.ToAge(metav1.NewTime(pv.Created)))
+	}
+	for _, f := range pv.Fields {
+		fmt.Fprintf(&b, "[gray::]%s[-::] %s\n", tview.Escape(f.Name), tview.Escape(f.Value))
+	}
+
+	if len(pv.Conditions) > 0 {
+		b.WriteString("\n[::b]Conditions[::-]\n")
+		for _, c := range pv.Conditions {
+			color := "green"
+			if c.Status != "True" {
+				color = "orange"
+			}
+			fmt.Fprintf(&b, "[%s::]%s=%s[-::]", color, tview.Escape(c.Type), tview.Escape(c.Status))
+			if c.Reason != "" {
+				fmt.Fprintf(&b, " %s", tview.Escape(c.Reason))
+			}
+			if c.Message != "" {
+				fmt.Fprintf(&b, " [gray::]%s[-::]", tview.Escape(c.Message))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n[::b]Events[::-]\n")
+	if len(pv.Events) == 0 {
+		b.WriteString("[gray::]none[-::]\n")
+	}
+	for _, e := range pv.Events {
+		color := "-"
+		if e.Type == "Warning" {
+			color = "orange"
+		}
+		fmt.Fprintf(&b, "[gray::]%s[-::] [%s::]%s[-::] x%d %s\n",
+			render.ToAge(metav1.NewTime(e.At)), color, tview.Escape(e.Reason), e.Count, tview.Escape(e.Message))
+	}
+
+	return b.String()
+}
@@ -112,6 +112,7 @@ func (p *Pulse) Init(ctx context.Context) error {
 		return err
 	}
 
+	p.model.SetRefreshRate(p.app.Config.K9s.Stretch(model.PulseRate))
 	ns := p.app.Config.ActiveNamespace()
 	frame := p.app.Styles.Frame()
 	p.SetTitle(ui.SkinTitle(fmt.Sprintf(NSTitleFmt, pulseTitle, ns), &frame))
@@ -377,6 +378,9 @@ func (*Pulse) AddBindKeysFn(BindKeysFunc) {}
 // SetContextFn sets custom context.
 func (*Pulse) SetContextFn(ContextFunc) {}
 
+// Pause freezes or resumes the view updates.
+func (*Pulse) Pause(bool) {}
+
 func (*Pulse) GetContextFn() ContextFunc { return nil }
 
 // GetTable return the view table if any.
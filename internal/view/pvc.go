@@ -4,11 +4,19 @@
 package view
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
 )
 
+const snapshotDialogKey = "snapshot"
+
 // PersistentVolumeClaim represents a PVC custom viewer.
 type PersistentVolumeClaim struct {
 	ResourceViewer
@@ -28,8 +36,68 @@ func (p *PersistentVolumeClaim) bindKeys(aa *ui.KeyActions) {
 	aa.Bulk(ui.KeyMap{
 		ui.KeyU: ui.NewKeyAction("UsedBy", p.refCmd, true),
 	})
+	if p.App().Config.IsReadOnly() {
+		return
+	}
+	aa.Add(ui.KeyS, ui.NewKeyActionWithOpts("Snapshot", p.snapshotCmd,
+		ui.ActionOpts{
+			Visible:   true,
+			Dangerous: true,
+		}))
 }
 
 func (p *PersistentVolumeClaim) refCmd(evt *tcell.EventKey) *tcell.EventKey {
 	return scanRefs(evt, p.App(), p.GetTable(), client.PvcGVR)
 }
+
+func (p *PersistentVolumeClaim) snapshotCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := p.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	ns, n := client.Namespaced(path)
+	name, class := fmt.Sprintf("%s-%s", n, time.Now().Format("20060102150405")), ""
+
+	styles := p.App().Styles.Dialog()
+	f := tview.NewForm().
+		SetItemPadding(0).
+		SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(styles.ButtonBgColor.Color()).
+		SetButtonTextColor(styles.ButtonFgColor.Color()).
+		SetLabelColor(styles.LabelFgColor.Color()).
+		SetFieldTextColor(styles.FieldFgColor.Color())
+	f.AddInputField("Name:", name, 40, nil, func(changed string) {
+		name = changed
+	})
+	f.AddInputField("Class:", class, 40, nil, func(changed string) {
+		class = changed
+	})
+	f.AddButton("OK", func() {
+		p.App().Content.RemovePage(snapshotDialogKey)
+		ctx, cancel := context.WithTimeout(context.Background(), p.App().Conn().Config().CallTimeout())
+		defer cancel()
+		if err := dao.CreateVolumeSnapshot(ctx, p.App().Conn(), path, class, name); err != nil {
+			p.App().Flash().Err(err)
+			return
+		}
+		p.App().Flash().Infof("Volume snapshot %s created", client.FQN(ns, name))
+	})
+	f.AddButton("Cancel", func() {
+		p.App().Content.RemovePage(snapshotDialogKey)
+	})
+	for i := range f.GetButtonCount() {
+		f.GetButton(i).
+			SetBackgroundColorActivated(styles.ButtonFocusBgColor.Color()).
+			SetLabelColorActivated(styles.ButtonFocusFgColor.Color())
+	}
+
+	modal := tview.NewModalForm("<Snapshot>", f)
+	modal.SetText(fmt.Sprintf("Snapshot %s? Leave the class blank to use the default snapshot class of its CSI driver.", path))
+	modal.SetDoneFunc(func(int, string) {
+		p.App().Content.RemovePage(snapshotDialogKey)
+	})
+	p.App().Content.AddPage(snapshotDialogKey, modal, false, false)
+	p.App().Content.ShowPage(snapshotDialogKey)
+
+	return nil
+}
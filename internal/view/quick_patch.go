@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	quickPatchDialogKey   = "quick-patch"
+	quickPatchSuggestions = 15
+	quickPatchFieldWidth  = 60
+)
+
+func (b *Browser) quickPatchCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := b.GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	if ctx, _ := model1.SplitMultiContextID(path); ctx != "" {
+		b.App().Flash().Warnf("Row belongs to context %q. Switch to it first", ctx)
+		return nil
+	}
+	o, err := dao.QuickPatchObject(b.app.factory, b.GVR(), path)
+	if err != nil {
+		b.App().Flash().Err(err)
+		return nil
+	}
+	b.showQuickPatch(path, o)
+
+	return nil
+}
+
+func (b *Browser) showQuickPatch(path string, o *unstructured.Unstructured) {
+	fields := dao.FieldPaths(o.Object)
+	styles := b.App().Styles.Dialog()
+	f := tview.NewForm().
+		SetItemPadding(0).
+		SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(styles.ButtonBgColor.Color()).
+		SetButtonTextColor(styles.ButtonFgColor.Color()).
+		SetLabelColor(styles.LabelFgColor.Color()).
+		SetFieldTextColor(styles.FieldFgColor.Color())
+
+	var (
+		field, value string
+		valueIn      *tview.InputField
+	)
+	f.AddInputField("Field:", "", quickPatchFieldWidth, nil, func(s string) {
+		field = s
+		ss, err := dao.ParseFieldPath(s)
+		if err != nil || valueIn == nil {
+			return
+		}
+		if v, ok := dao.FieldValue(o.Object, ss); ok {
+			valueIn.SetText(dao.FieldString(v))
+		}
+	})
+	f.AddInputField("Value:", "", quickPatchFieldWidth, nil, func(s string) {
+		value = s
+	})
+	if in, ok := f.GetFormItem(0).(*tview.InputField); ok {
+		in.SetAutocompleteFunc(func(s string) []string {
+			return matchFields(fields, s, quickPatchSuggestions)
+		})
+	}
+	valueIn, _ = f.GetFormItem(1).(*tview.InputField)
+
+	dismiss := func() {
+		b.App().Content.RemovePage(quickPatchDialogKey)
+	}
+	f.AddButton("Patch", func() {
+		dismiss()
+		b.applyQuickPatch(path, field, value)
+	})
+	f.AddButton("Cancel", dismiss)
+	for i := range f.GetButtonCount() {
+		f.GetButton(i).
+			SetBackgroundColorActivated(styles.ButtonFocusBgColor.Color()).
+			SetLabelColorActivated(styles.ButtonFocusFgColor.Color())
+	}
+
+	modal := tview.NewModalForm("<Quick Patch>", f)
+	modal.SetText(fmt.Sprintf("Patch %s %s", singularize(b.GVR().R()), path))
+	modal.SetDoneFunc(func(int, string) {
+		dismiss()
+	})
+	b.App().Content.AddPage(quickPatchDialogKey, modal, false, false)
+	b.App().Content.ShowPage(quickPatchDialogKey)
+}
+
+func (b *Browser) applyQuickPatch(path, field, value string) {
+	if strings.TrimSpace(field) == "" {
+		b.App().Flash().Warn("No field given")
+		return
+	}
+	gvr, conn := b.GVR(), b.App().Conn()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), conn.Config().CallTimeout())
+		defer cancel()
+		err := dao.ApplyQuickPatch(ctx, conn, gvr, path, field, value)
+		b.App().QueueUpdateDraw(func() {
+			if err != nil {
+				b.App().Flash().Errf("Quick patch failed: %s", err)
+				return
+			}
+			b.App().Flash().Infof("%s %s patched %s=%s", singularize(gvr.R()), path, field, value)
+		})
+	}()
+}
+
+// matchFields returns the field paths starting with the given text first,
+// then the ones containing it.
+func matchFields(fields []string, s string, limit int) []string {
+	if s == "" {
+		return nil
+	}
+	var pre, sub []string
+	for _, f := range fields {
+		switch {
+		case strings.HasPrefix(f, s):
+			pre = append(pre, f)
+		case strings.Contains(f, s):
+			sub = append(sub, f)
+		}
+	}
+	mm := append(pre, sub...)
+	if len(mm) > limit {
+		mm = mm[:limit]
+	}
+
+	return mm
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+)
+
+const (
+	rancherMgmtGVR     = "clusters.management.cattle.io"
+	rancherAuditTail   = int64(200)
+	rancherAPIDeadline = 30 * time.Second
+)
+
+// rancherAPICmd renders Rancher API data that is not exposed as CRDs.
+func (a *App) rancherAPICmd(topic string) {
+	if !a.command.CanResolve(rancherMgmtGVR) {
+		a.Flash().Warnf("Context %q is not a Rancher management cluster", a.Config.K9s.ActiveContextName())
+		return
+	}
+
+	title := strings.TrimPrefix(topic, "rancher-")
+	a.Flash().Infof("Querying Rancher %s...", title)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), rancherAPIDeadline)
+		defer cancel()
+
+		out, err := a.fetchRancherAPI(ctx, topic)
+		if err != nil {
+			out = fmt.Sprintf("Error: %s\n\n%s", err, out)
+		}
+		a.QueueUpdateDraw(func() {
+			details := NewDetails(a, "Rancher "+title, a.Config.K9s.ActiveContextName(), contentTXT, true).Update(out)
+			if e := a.inject(details, false); e != nil {
+				a.Flash().Err(e)
+			}
+		})
+	}()
+}
+
+func (a *App) fetchRancherAPI(ctx context.Context, topic string) (string, error) {
+	if topic == "rancher-audit" {
+		return dao.RancherAuditLog(ctx, a.Conn(), rancherAuditTail)
+	}
+
+	url, token, err := a.Config.K9s.Rancher.Credentials()
+	if err != nil {
+		return "", err
+	}
+	var insecure bool
+	if r := a.Config.K9s.Rancher; r != nil {
+		insecure = r.Insecure
+	}
+	api := dao.NewRancherAPI(url, token, insecure)
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	switch topic {
+	case "rancher-drivers":
+		dd, err := api.NodeDrivers(ctx)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintln(w, "NAME\tSTATE\tACTIVE\tBUILTIN\tURL")
+		for _, d := range dd {
+			fmt.Fprintf(w, "%s\t%s\t%t\t%t\t%s\n", d.Name, d.State, d.Active, d.Builtin, d.URL)
+		}
+	case "rancher-sessions":
+		tt, err := api.Sessions(ctx)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintln(w, "TOKEN\tUSER\tPROVIDER\tCREATED\tLAST-USED\tEXPIRES")
+		for _, t := range tt {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", t.Name, t.UserID, t.AuthProvider, t.Created, orNA(t.LastUsedAt), orNA(t.ExpiresAt))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Rancher API: %s\n\n%s", url, b.String()), nil
+}
+
+func orNA(s string) string {
+	if s == "" {
+		return client.NA
+	}
+
+	return s
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tcell/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// RancherCluster represents a Rancher management cluster viewer.
+type RancherCluster struct {
+	ResourceViewer
+}
+
+// NewRancherCluster returns a new viewer.
+func NewRancherCluster(gvr *client.GVR) ResourceViewer {
+	r := RancherCluster{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	r.AddBindKeysFn(r.bindKeys)
+
+	return &r
+}
+
+func (r *RancherCluster) bindKeys(aa *ui.KeyActions) {
+	aa.Bulk(ui.KeyMap{
+		ui.KeyShiftP: ui.NewKeyAction("Hop Into Cluster", r.hopCmd, true),
+		ui.KeyA:      ui.NewKeyAction("Cluster Agent Logs", r.agentLogsCmd(dao.CattleClusterAgent), true),
+		ui.KeyF:      ui.NewKeyAction("Fleet Agent Logs", r.agentLogsCmd(dao.FleetAgent), true),
+	})
+}
+
+func (r *RancherCluster) hopCmd(evt *tcell.EventKey) *tcell.EventKey {
+	id, name, ok := r.selectedCluster()
+	if !ok {
+		return evt
+	}
+	if err := rancherHop(r.App(), id, name); err != nil {
+		r.App().Flash().Err(err)
+	}
+
+	return nil
+}
+
+func (r *RancherCluster) agentLogsCmd(agent dao.Agent) ui.ActionHandler {
+	return func(evt *tcell.EventKey) *tcell.EventKey {
+		id, name, ok := r.selectedCluster()
+		if !ok {
+			return evt
+		}
+		r.App().agentLogs(id, name, agent)
+
+		return nil
+	}
+}
+
+// selectedCluster returns the ID and display name of the selected cluster.
+func (r *RancherCluster) selectedCluster() (string, string, bool) {
+	path := r.GetTable().GetSelectedItem()
+	if path == "" {
+		return "", "", false
+	}
+
+	id, name := path, path
+	if ctx, p := model1.SplitMultiContextID(path); ctx != "" {
+		id, name = p, p
+	} else if o, err := r.App().factory.Get(r.GVR(), path, true, labels.Everything()); err == nil {
+		if u, ok := o.(*unstructured.Unstructured); ok {
+			if dn, _, _ := unstructured.NestedString(u.Object, "spec", "displayName"); dn != "" {
+				name = dn
+			}
+		}
+	}
+
+	return id, name, true
+}
+
+// rancherHop opens a downstream cluster through the Rancher cluster proxy as
+// a temporary context. The context lives until rk9s exits.
+func rancherHop(a *App, clusterID, name string) error {
+	ctxName, err := rancherHopContext(a, clusterID, name)
+	if err != nil {
+		return err
+	}
+	a.Flash().Infof("Hopping into %q via Rancher proxy...", name)
+
+	return useContext(a, ctxName, nil)
+}
+
+// rancherHopContext saves a temporary context reaching a downstream cluster
+// through the Rancher cluster proxy and returns its name.
+func rancherHopContext(a *App, clusterID, name string) (string, error) {
+	if f := a.Conn().Config().Flags().KubeConfig; f != nil && *f != "" {
+		return "", errors.New("rancher hop is not available when --kubeconfig is set. Use KUBECONFIG instead")
+	}
+	url, token, err := a.Config.K9s.Rancher.Credentials()
+	if err != nil {
+		return "", err
+	}
+	var insecure bool
+	if r := a.Config.K9s.Rancher; r != nil {
+		insecure = r.Insecure
+	}
+
+	ctxName := config.RancherHopPrefix + strings.ReplaceAll(name, " ", "-")
+	if err := config.SaveRancherHop(config.RancherHopPath(), ctxName, url, clusterID, token, insecure); err != nil {
+		return "", err
+	}
+	a.Conn().Config().Refresh()
+
+	return ctxName, nil
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tcell/v2"
+)
+
+// actionAccess represents an access an action requires on a resource.
+type actionAccess struct {
+	gvr         *client.GVR
+	verbs       []string
+	clusterWide bool
+}
+
+// actionGates tracks the accesses required by keyed actions.
+type actionGates map[tcell.Key][]actionAccess
+
+// gateActions hides the actions the user cannot perform in the viewed
+// namespace, based on cached SelfSubjectAccessReviews. When a namespaced
+// resource is viewed across all namespaces, the access is checked on the
+// namespaces of the selected rows before the action runs instead. Returns
+// the hidden action keys.
+func gateActions(v ResourceViewer, aa *ui.KeyActions, gates actionGates) []tcell.Key {
+	conn := v.App().Conn()
+	if conn == nil || !conn.ConnectionOK() {
+		return nil
+	}
+	var hidden []tcell.Key
+	ns := v.GetTable().GetNamespace()
+	for k, acc := range gates {
+		a, ok := aa.Get(k)
+		if !ok {
+			continue
+		}
+		if !client.IsAllNamespaces(ns) || clusterWide(acc) {
+			if err := canI(conn, ns, acc); err != nil {
+				slog.Debug("Action hidden by RBAC",
+					slogs.Key, tcell.KeyNames[k],
+					slogs.Error, err,
+				)
+				aa.Delete(k)
+				hidden = append(hidden, k)
+			}
+			continue
+		}
+		aa.Add(k, guardAction(v, conn, a, acc))
+	}
+
+	return hidden
+}
+
+// guardAction checks the access on each selected row namespace before
+// running the action.
+func guardAction(v ResourceViewer, conn client.Connection, a ui.KeyAction, acc []actionAccess) ui.KeyAction {
+	run := a.Action
+	a.Action = func(evt *tcell.EventKey) *tcell.EventKey {
+		checked := make(map[string]struct{})
+		for _, path := range v.GetTable().GetSelectedItems() {
+			ns, _ := client.Namespaced(path)
+			if _, ok := checked[ns]; ok {
+				continue
+			}
+			checked[ns] = struct{}{}
+			if err := canI(conn, ns, acc); err != nil {
+				v.App().Flash().Err(err)
+				return nil
+			}
+		}
+		return run(evt)
+	}
+
+	return a
+}
+
+func clusterWide(acc []actionAccess) bool {
+	for _, a := range acc {
+		if !a.clusterWide {
+			return false
+		}
+	}
+
+	return true
+}
+
+func canI(conn client.Authorizer, ns string, acc []actionAccess) error {
+	for _, a := range acc {
+		n := ns
+		if a.clusterWide {
+			n = client.ClusterScope
+		}
+		ok, err := conn.CanI(n, a.gvr, "", a.verbs)
+		if ok {
+			continue
+		}
+		if err == nil {
+			err = fmt.Errorf("(%s) access denied on %s in namespace %q", strings.Join(a.verbs, ","), a.gvr, n)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanI(t *testing.T) {
+	auth := fakeAuthorizer{
+		"ns1:v1/pods:delete":        true,
+		"-:v1/nodes:patch":          true,
+		"-:v1/pods:eviction:create": false,
+		"ns2:v1/pods:delete":        false,
+	}
+	podDelete := []actionAccess{{gvr: client.PodGVR, verbs: []string{client.DeleteVerb}}}
+	drain := []actionAccess{
+		{gvr: client.NodeGVR, verbs: client.PatchAccess, clusterWide: true},
+		{gvr: client.PodGVR.WithSubResource("eviction"), verbs: []string{client.CreateVerb}, clusterWide: true},
+	}
+
+	uu := map[string]struct {
+		ns  string
+		acc []actionAccess
+		e   bool
+	}{
+		"allowed": {
+			ns:  "ns1",
+			acc: podDelete,
+			e:   true,
+		},
+		"denied": {
+			ns:  "ns2",
+			acc: podDelete,
+		},
+		"cluster-wide": {
+			ns:  "ns1",
+			acc: drain[:1],
+			e:   true,
+		},
+		"partial": {
+			ns:  "ns1",
+			acc: drain,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := canI(auth, u.ns, u.acc)
+			if u.e {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestClusterWide(t *testing.T) {
+	assert.True(t, clusterWide(nodeGates[ui.KeyC]))
+	assert.False(t, clusterWide([]actionAccess{{gvr: client.PodGVR}}))
+}
+
+// Helpers...
+
+type fakeAuthorizer map[string]bool
+
+func (f fakeAuthorizer) CanI(ns string, gvr *client.GVR, _ string, verbs []string) (bool, error) {
+	for _, v := range verbs {
+		if !f[ns+":"+gvr.String()+":"+v] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"strings"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/derailed/k9s/internal/redact"
+)
+
+// redactor returns the redactor of dumps, exports and plugin env or nil when
+// redaction is disabled.
+func (a *App) redactor() *redact.Redactor {
+	r := a.Config.K9s.Redaction
+	if !r.Enabled() {
+		return nil
+	}
+
+	return redact.New(r.Allowlists())
+}
+
+// redactTable returns a copy of the table data with sensitive columns masked.
+func redactTable(r *redact.Redactor, data *model1.TableData) *model1.TableData {
+	if r == nil {
+		return data
+	}
+	cols := data.ColumnNames(true)
+	var masked bool
+	for _, c := range cols {
+		if redact.Sensitive(c) {
+			masked = true
+			break
+		}
+	}
+	if !masked {
+		return data
+	}
+
+	data = data.Clone()
+	data.RowsRange(func(_ int, re model1.RowEvent) bool {
+		for i, c := range cols {
+			if i < len(re.Row.Fields) {
+				re.Row.Fields[i] = r.Value(c, re.Row.Fields[i])
+			}
+		}
+		return true
+	})
+
+	return data
+}
+
+// redactEnv masks the sensitive column values passed to plugins.
+func redactEnv(r *redact.Redactor, env Env) Env {
+	for k, v := range env {
+		if strings.HasPrefix(k, "COL-") {
+			env[k] = r.Value(k, v)
+		}
+	}
+
+	return env
+}
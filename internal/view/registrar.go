@@ -16,6 +16,7 @@ func loadCustomViewers() MetaViewers {
 	batchViewers(m)
 	crdViewers(m)
 	helmViewers(m)
+	rancherViewers(m)
 
 	return m
 }
@@ -26,6 +27,18 @@ func helmViewers(vv MetaViewers) {
 	}
 }
 
+func rancherViewers(vv MetaViewers) {
+	vv[client.RancherClusterGVR] = MetaViewer{
+		viewerFn: NewRancherCluster,
+	}
+	vv[client.FleetGitRepoGVR] = MetaViewer{
+		viewerFn: NewGitRepo,
+	}
+	vv[client.FleetClusterGVR] = MetaViewer{
+		viewerFn: NewFleetCluster,
+	}
+}
+
 func coreViewers(vv MetaViewers) {
 	vv[client.NsGVR] = MetaViewer{
 		viewerFn: NewNamespace,
@@ -57,6 +70,9 @@ func coreViewers(vv MetaViewers) {
 	vv[client.PvcGVR] = MetaViewer{
 		viewerFn: NewPersistentVolumeClaim,
 	}
+	vv[client.VolumeSnapshotGVR] = MetaViewer{
+		viewerFn: NewVolumeSnapshot,
+	}
 }
 
 func miscViewers(vv MetaViewers) {
@@ -90,6 +106,30 @@ func miscViewers(vv MetaViewers) {
 	vv[client.PuGVR] = MetaViewer{
 		viewerFn: NewPulse,
 	}
+	vv[client.OomGVR] = MetaViewer{
+		viewerFn: NewOOM,
+	}
+	vv[client.InvGVR] = MetaViewer{
+		viewerFn: NewInventory,
+	}
+	vv[client.EtcdGVR] = MetaViewer{
+		viewerFn: NewEtcdMember,
+	}
+	vv[client.RuleGVR] = MetaViewer{
+		viewerFn: NewRule,
+	}
+	vv[client.HygGVR] = MetaViewer{
+		viewerFn: NewHygiene,
+	}
+	vv[client.CnyGVR] = MetaViewer{
+		viewerFn: NewCanary,
+	}
+	vv[client.JanGVR] = MetaViewer{
+		viewerFn: NewJanitor,
+	}
+	vv[client.WchGVR] = MetaViewer{
+		viewerFn: NewWatcher,
+	}
 }
 
 func appsViewers(vv MetaViewers) {
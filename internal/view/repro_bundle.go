@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/derailed/k9s/internal/config/data"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/slogs"
+)
+
+const reproBundleDeadline = 2 * time.Minute
+
+// reproBundle exports the workloads, redacted configs, events and recent
+// logs of a namespace into a tarball in the screen dumps directory.
+func (a *App) reproBundle(ns string) {
+	dir := a.Config.K9s.ContextScreenDumpDir()
+	a.Flash().Infof("Building repro bundle for namespace %q...", ns)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), reproBundleDeadline)
+		defer cancel()
+
+		path, errs, err := writeReproBundle(ctx, a, dir, ns)
+		a.QueueUpdateDraw(func() {
+			switch {
+			case err != nil:
+				a.Flash().Errf("Repro bundle failed: %s", err)
+			case errs > 0:
+				a.Flash().Warnf("Repro bundle saved to %s (%d items skipped, see manifest)", path, errs)
+			default:
+				a.Flash().Infof("Repro bundle saved to %s", path)
+			}
+		})
+	}()
+}
+
+func writeReproBundle(ctx context.Context, a *App, dir, ns string) (string, int, error) {
+	b, err := dao.FetchReproBundle(ctx, a.Conn(), ns, a.version, a.redactor(), dao.ReproLogTail)
+	if err != nil {
+		return "", 0, err
+	}
+	if err := ensureDir(dir); err != nil {
+		return "", 0, err
+	}
+	path := filepath.Join(dir, data.SanitizeFileName(b.FileName()))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			slog.Error("Closing repro bundle failed",
+				slogs.Path, path,
+				slogs.Error, err,
+			)
+		}
+	}()
+
+	return path, len(b.Manifest.Errors), b.Write(f)
+}
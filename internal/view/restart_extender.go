@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/derailed/k9s/internal/ui/dialog"
@@ -63,13 +64,18 @@ func (r *RestartExtender) restartCmd(*tcell.EventKey) *tcell.EventKey {
 		Ack: func(opts *metav1.PatchOptions) bool {
 			ctx, cancel := context.WithTimeout(context.Background(), r.App().Conn().Config().CallTimeout())
 			defer cancel()
+			var restarted bool
 			for _, path := range paths {
 				if err := r.restartRollout(ctx, path, opts); err != nil {
 					r.App().Flash().Err(err)
 				} else {
+					restarted = true
 					r.App().Flash().Infof("Restart in progress for `%s...", path)
 				}
 			}
+			if restarted && len(paths) == 1 && r.GVR() == client.DpGVR {
+				showRollout(r.App(), paths[0])
+			}
 			return true
 		},
 		Cancel: func() {},
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/derailed/tcell/v2"
+)
+
+const rolloutTitle = "Rollout"
+
+// rolloutWatcher follows a deployment rollout with keys to pause, resume
+// or undo it mid-flight.
+type rolloutWatcher struct {
+	*LiveView
+
+	path string
+}
+
+// showRollout opens a rollout watcher on a deployment.
+func showRollout(app *App, path string) {
+	w := rolloutWatcher{
+		LiveView: NewLiveView(app, rolloutTitle, model.NewRollout(path)),
+		path:     path,
+	}
+	w.autoRefresh = true
+	if !app.Config.IsReadOnly() {
+		w.actions.Bulk(ui.KeyMap{
+			ui.KeyP: ui.NewKeyActionWithOpts("Pause/Resume", w.pauseCmd,
+				ui.ActionOpts{
+					Visible:   true,
+					Dangerous: true,
+				}),
+			ui.KeyU: ui.NewKeyActionWithOpts("Undo", w.undoCmd,
+				ui.ActionOpts{
+					Visible:   true,
+					Dangerous: true,
+				}),
+		})
+	}
+	if err := app.inject(w.LiveView, false); err != nil {
+		app.Flash().Err(err)
+	}
+}
+
+func (w *rolloutWatcher) deployment() *dao.Deployment {
+	var dp dao.Deployment
+	dp.Init(w.app.factory, client.DpGVR)
+
+	return &dp
+}
+
+func (w *rolloutWatcher) pauseCmd(*tcell.EventKey) *tcell.EventKey {
+	dp := w.deployment()
+	o, err := dp.GetInstance(w.path)
+	if err != nil {
+		w.app.Flash().Err(err)
+		return nil
+	}
+	on, verb := !o.Spec.Paused, "paused"
+	if !on {
+		verb = "resumed"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), w.app.Conn().Config().CallTimeout())
+	defer cancel()
+	if err := dp.Pause(ctx, w.path, on); err != nil {
+		w.app.Flash().Err(err)
+		return nil
+	}
+	w.app.Flash().Infof("Rollout of %s %s", w.path, verb)
+
+	return nil
+}
+
+func (w *rolloutWatcher) undoCmd(*tcell.EventKey) *tcell.EventKey {
+	d := w.app.Styles.Dialog()
+	msg := fmt.Sprintf("Undo rollout of %s to its previous revision?", w.path)
+	dialog.ShowConfirm(&d, w.app.Content.Pages, "Undo Rollout", msg, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), w.app.Conn().Config().CallTimeout())
+		defer cancel()
+		if err := w.deployment().Undo(ctx, w.path); err != nil {
+			w.app.Flash().Err(err)
+			return
+		}
+		w.app.Flash().Infof("Rollout of %s undone", w.path)
+	}, func() {})
+
+	return nil
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	joinTokenLength = 32
+	healthyWindow   = time.Minute
+)
+
+// certRotationWorkflow rotates the RKE2/K3s certificates of the active context
+// one server at a time, then restarts the agents to pick up fresh client
+// certificates.
+func certRotationWorkflow(a *App, _ string) (string, []workflowStep, error) {
+	ctxName := a.Config.K9s.ActiveContextName()
+	servers, agents, err := clusterNodes(a)
+	if err != nil {
+		return "", nil, err
+	}
+
+	steps := []workflowStep{clusterHealthStep(a, ctxName, append(servers, agents...))}
+	for _, node := range servers {
+		steps = append(steps, hostRestartStep(a, ctxName, node, "Rotate certificates on server "+node, dao.CertRotateScript()))
+	}
+	for _, node := range agents {
+		steps = append(steps, hostRestartStep(a, ctxName, node, "Restart agent "+node, dao.DistroServiceScript()))
+	}
+
+	return ctxName, steps, nil
+}
+
+// tokenRotationWorkflow rotates the join token of the active context from the
+// first server, then updates the node configs one node at a time.
+func tokenRotationWorkflow(a *App, _ string) (string, []workflowStep, error) {
+	ctxName := a.Config.K9s.ActiveContextName()
+	servers, agents, err := clusterNodes(a)
+	if err != nil {
+		return "", nil, err
+	}
+	token := rand.String(joinTokenLength)
+	rotate, err := dao.TokenRotateScript(token)
+	if err != nil {
+		return "", nil, err
+	}
+	update, err := dao.TokenConfigScript(token)
+	if err != nil {
+		return "", nil, err
+	}
+
+	lead := servers[0]
+	steps := []workflowStep{
+		clusterHealthStep(a, ctxName, append(servers, agents...)),
+		{
+			title: "Rotate join token on " + lead,
+			run: func(ctx context.Context, log logFn) error {
+				out, err := runOnHost(ctx, a, ctxName, lead, "rk9s-token", rotate)
+				log("%s", strings.TrimSpace(out))
+				if err != nil {
+					return err
+				}
+				log("new join token %s, store it with your node provisioning config", token)
+				return nil
+			},
+		},
+	}
+	for _, node := range append(servers, agents...) {
+		steps = append(steps, hostRestartStep(a, ctxName, node, "Update join token and restart "+node, update))
+	}
+
+	return ctxName, steps, nil
+}
+
+// clusterHealthStep checks all nodes of a context are ready with a live
+// kubelet before a rolling operation starts.
+func clusterHealthStep(a *App, ctxName string, nodes []string) workflowStep {
+	return workflowStep{
+		title: fmt.Sprintf("Check the API server and %d nodes are healthy", len(nodes)),
+		run: func(ctx context.Context, log logFn) error {
+			rawCfg, err := a.Conn().Config().RawConfig()
+			if err != nil {
+				return err
+			}
+			var bad []string
+			since := time.Now().Add(-healthyWindow)
+			for _, node := range nodes {
+				if ok, msg := dao.NodeRecoveredInContext(ctx, rawCfg, ctxName, node, since); !ok {
+					bad = append(bad, node+": "+msg)
+				}
+			}
+			if len(bad) > 0 {
+				return fmt.Errorf("unhealthy nodes, %s", strings.Join(bad, "; "))
+			}
+			log("all %d nodes ready", len(nodes))
+			return nil
+		},
+	}
+}
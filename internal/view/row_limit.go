@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"fmt"
+
+	"github.com/derailed/k9s/internal/render"
+	"github.com/derailed/k9s/internal/ui"
+)
+
+// reportTruncation warns once when a multi-context view got capped.
+func (b *Browser) reportTruncation() {
+	tr, ok := b.GetModel().(ui.Truncater)
+	if !ok {
+		return
+	}
+	limit, truncated := tr.Truncated()
+	if truncated == b.truncated {
+		return
+	}
+	b.truncated = truncated
+	if truncated {
+		b.app.Flash().Warn(truncationMsg(limit))
+	}
+}
+
+func truncationMsg(limit int) string {
+	return fmt.Sprintf("Showing the first %s rows only. Narrow your filter with a namespace, `-l` labels or `-F` fields to see the rest", render.AsThousands(int64(limit)))
+}
@@ -0,0 +1,321 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/config/data"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/view/cmd"
+	"github.com/derailed/tcell/v2"
+)
+
+const ruleTick = config.MinRuleInterval
+
+// Rule presents the automation rules.
+type Rule struct {
+	ResourceViewer
+}
+
+// NewRule returns a new viewer.
+func NewRule(gvr *client.GVR) ResourceViewer {
+	r := Rule{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	r.SetContextFn(r.ruleContext)
+	r.AddBindKeysFn(r.bindKeys)
+	r.GetTable().SetEnterFn(r.toggleRule)
+
+	return &r
+}
+
+func (r *Rule) ruleContext(ctx context.Context) context.Context {
+	r.App().rules.Sync(r.App().Config.K9s.Rules)
+
+	return context.WithValue(ctx, internal.KeyRules, r.App().rules)
+}
+
+func (r *Rule) bindKeys(aa *ui.KeyActions) {
+	aa.Delete(ui.KeyShiftA, tcell.KeyCtrlD, ui.KeyE, tcell.KeyCtrlSpace, ui.KeySpace)
+	aa.Bulk(ui.KeyMap{
+		ui.KeyT:      ui.NewKeyAction("Toggle", r.toggleCmd, true),
+		ui.KeyShiftF: ui.NewKeyAction("Sort Fired", r.GetTable().SortColCmd("FIRED", false), false),
+	})
+}
+
+func (r *Rule) toggleCmd(evt *tcell.EventKey) *tcell.EventKey {
+	name := r.GetTable().GetSelectedItem()
+	if name == "" {
+		return evt
+	}
+	r.toggleRule(r.App(), r.GetTable().GetModel(), r.GVR(), name)
+
+	return nil
+}
+
+func (r *Rule) toggleRule(app *App, _ ui.Tabular, _ *client.GVR, name string) {
+	on, err := app.rules.Toggle(name)
+	if err != nil {
+		app.Flash().Err(err)
+		return
+	}
+	state := "disabled"
+	if on {
+		state = "enabled"
+	}
+	app.Flash().Infof("Rule %q %s", name, state)
+	r.Refresh()
+}
+
+// startRules evaluates the enabled automation rules in the background until
+// the app exits. A rule is skipped while its previous run is still going and
+// all rules are paused in low power mode.
+func (a *App) startRules() {
+	go func() {
+		next := make(map[string]time.Time)
+		busy := make(map[string]*atomic.Bool)
+		for {
+			a.rules.Sync(a.Config.K9s.Rules)
+			now, ss := time.Now(), a.rules.States()
+			if a.Config.K9s.IsLowPower() {
+				ss = nil
+			}
+			for _, s := range ss {
+				if !s.Enabled {
+					continue
+				}
+				if err := s.Validate(); err != nil {
+					a.rules.Record(s.Name, 0, err)
+					continue
+				}
+				if now.Before(next[s.Name]) {
+					continue
+				}
+				running, ok := busy[s.Name]
+				if !ok {
+					running = new(atomic.Bool)
+					busy[s.Name] = running
+				}
+				if !running.CompareAndSwap(false, true) {
+					continue
+				}
+				every, _ := s.Interval()
+				next[s.Name] = now.Add(every)
+				go func(r config.Rule) {
+					defer running.Store(false)
+					a.runRule(r)
+				}(s.Rule)
+			}
+			select {
+			case <-a.appCtx.Done():
+				return
+			case <-time.After(ruleTick):
+			}
+		}
+	}()
+}
+
+// runRule lists the resources watched by a rule and fires its action on the
+// ones that started matching its condition.
+func (a *App) runRule(r config.Rule) {
+	if a.Conn() == nil || !a.Conn().ConnectionOK() {
+		return
+	}
+	ctxs := []string{a.Config.K9s.ActiveContextName()}
+	if sel, _ := config.LoadSelectedContexts(); len(sel) > 1 {
+		ctxs = sel
+	}
+	var watched []string
+	for _, ct := range ctxs {
+		if r.AppliesTo(ct) {
+			watched = append(watched, ct)
+		}
+	}
+	if len(watched) == 0 {
+		return
+	}
+
+	p := cmd.NewInterpreter(r.Watch)
+	gvr, _, _, err := a.command.viewMetaFor(p)
+	if err != nil {
+		a.rules.Record(r.Name, 0, err)
+		return
+	}
+	ns, _ := p.NSArg()
+	var lbls string
+	if sel, err := p.LabelsSelector(); err == nil && !sel.Empty() {
+		lbls = sel.String()
+	}
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		a.rules.Record(r.Name, 0, err)
+		return
+	}
+	oo, lerr := dao.MultiContextList(a.appCtx, rawCfg, watched, gvr.GVR(), ns, lbls)
+	skipped := dao.FailedContexts(lerr)
+	if lerr != nil && skipped == nil {
+		a.rules.Record(r.Name, 0, lerr)
+		return
+	}
+	mm, err := a.rules.Observe(r.Name, watched, oo, skipped)
+	if err != nil || len(mm) == 0 {
+		a.rules.Record(r.Name, 0, errors.Join(err, lerr))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(a.appCtx, checkDeadline)
+	defer cancel()
+	var last model.CheckResult
+	for _, res := range a.fireRuleMatches(ctx, r, gvr, mm) {
+		last = res
+		a.inbox.Push(res)
+	}
+	if last.Failed {
+		err = errors.New(last.Summary)
+	}
+	a.rules.Record(r.Name, len(mm), errors.Join(err, lerr))
+
+	a.QueueUpdateDraw(func() {
+		if len(mm) == 1 {
+			a.Flash().Warnf("Rule %s: %s", r.Name, last.Summary)
+			return
+		}
+		a.Flash().Warnf("Rule %s fired on %d resources, see :inbox", r.Name, len(mm))
+	})
+}
+
+// fireRuleMatches runs a rule action on the newly matching resources. Waits
+// run concurrently so a slow resource does not hold up the others, other
+// actions run one at a time. Results are returned in the matches order.
+func (a *App) fireRuleMatches(ctx context.Context, r config.Rule, gvr *client.GVR, mm []dao.RuleMatch) []model.CheckResult {
+	rr := make([]model.CheckResult, len(mm))
+	if action, _ := r.Action(); action != config.RuleWait {
+		for i, m := range mm {
+			rr[i] = a.fireRule(ctx, r, gvr, m)
+		}
+		return rr
+	}
+
+	var wg sync.WaitGroup
+	for i, m := range mm {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rr[i] = a.fireRule(ctx, r, gvr, m)
+		}()
+	}
+	wg.Wait()
+
+	return rr
+}
+
+// fireRule runs a rule action on a newly matching resource.
+func (a *App) fireRule(ctx context.Context, r config.Rule, gvr *client.GVR, m dao.RuleMatch) model.CheckResult {
+	fqn := client.FQN(m.Object.GetNamespace(), m.Object.GetName())
+	res := model.CheckResult{Check: r.Name, Context: m.Context, At: time.Now()}
+	env := Env{
+		"CONTEXT":   m.Context,
+		"CLUSTER":   m.Context,
+		"NAMESPACE": m.Object.GetNamespace(),
+		"NAME":      m.Object.GetName(),
+		"RULE":      r.Name,
+	}
+
+	action, _ := r.Action()
+	switch action {
+	case config.RuleNotify:
+		res.Summary, _ = env.Substitute(r.Notify)
+	case config.RuleExport:
+		path, _ := env.Substitute(r.Export)
+		if err := exportRuleMatch(path, m); err != nil {
+			res.Failed, res.Summary = true, fmt.Sprintf("%s export failed: %s", fqn, err)
+			break
+		}
+		res.Summary = fmt.Sprintf("%s exported to %s", fqn, path)
+	case config.RulePlugin:
+		p, err := a.automatedPlugin(r.Plugin, m.Context)
+		if err != nil {
+			res.Failed, res.Summary = true, err.Error()
+			break
+		}
+		args := make([]string, 0, len(p.Args))
+		for _, arg := range p.Args {
+			if s, err := env.Substitute(arg); err == nil {
+				arg = s
+			}
+			args = append(args, arg)
+		}
+		bin, args := a.pluginCommand(&p, args)
+		out, err := oneShoot(ctx, &shellOpts{binary: bin, args: args})
+		res.Output, res.Summary = out, fmt.Sprintf("%s %s: %s", fqn, r.Plugin, lastLine(out))
+		if err != nil {
+			res.Failed, res.Summary = true, fmt.Sprintf("%s %s failed: %s", fqn, r.Plugin, err)
+		}
+	case config.RuleWait:
+		res.Summary, res.Failed = a.waitRuleMatch(r, gvr, m)
+	}
+	if res.Failed {
+		slog.Warn("Rule action failed",
+			slogs.Name, r.Name,
+			slogs.FQN, fqn,
+			slogs.Error, res.Summary,
+		)
+	}
+
+	return res
+}
+
+// waitRuleMatch waits for a matching resource to satisfy the rule condition.
+// The wait runs past the rule check deadline, bounded by its own timeout.
+func (a *App) waitRuleMatch(r config.Rule, gvr *client.GVR, m dao.RuleMatch) (string, bool) {
+	fqn := client.FQN(m.Object.GetNamespace(), m.Object.GetName())
+	w, timeout, err := dao.ParseWaitFlags(r.Wait)
+	if err != nil {
+		return fmt.Sprintf("%s wait failed: %s", fqn, err), true
+	}
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return fmt.Sprintf("%s wait failed: %s", fqn, err), true
+	}
+	start := time.Now()
+	err = dao.WaitInContext(a.appCtx, rawCfg, m.Context, gvr.GVR(), m.Object.GetNamespace(), m.Object.GetName(), w, timeout)
+	if err != nil {
+		return fmt.Sprintf("%s wait failed: %s", fqn, err), true
+	}
+
+	return fmt.Sprintf("%s met %s after %s", fqn, w, time.Since(start).Round(time.Second)), false
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func exportRuleMatch(path string, m dao.RuleMatch) error {
+	if strings.TrimSpace(path) == "" {
+		return errors.New("blank export path")
+	}
+	raw, err := dao.ToYAML(m.Object, false)
+	if err != nil {
+		return err
+	}
+	if err := data.EnsureDirPath(path, data.DefaultDirMod); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(raw), data.DefaultFileMod)
+}
@@ -5,12 +5,22 @@ package view
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+const (
+	tokenDialogKey  = "token"
+	defaultTokenTTL = 10 * time.Minute
 )
 
 // ServiceAccount represents a serviceaccount viewer.
@@ -32,8 +42,17 @@ func NewServiceAccount(gvr *client.GVR) ResourceViewer {
 func (s *ServiceAccount) bindKeys(aa *ui.KeyActions) {
 	aa.Bulk(ui.KeyMap{
 		ui.KeyU:        ui.NewKeyAction("UsedBy", s.refCmd, true),
+		ui.KeyShiftB:   ui.NewKeyAction("Bindings", s.bindingsCmd, true),
 		tcell.KeyEnter: ui.NewKeyAction("Rules", s.policyCmd, true),
 	})
+	if s.App().Config.IsReadOnly() {
+		return
+	}
+	aa.Add(ui.KeyShiftT, ui.NewKeyActionWithOpts("Mint Token", s.tokenCmd,
+		ui.ActionOpts{
+			Visible:   true,
+			Dangerous: true,
+		}))
 }
 
 func (*ServiceAccount) subjectCtx(ctx context.Context) context.Context {
@@ -56,6 +75,132 @@ func (s *ServiceAccount) policyCmd(evt *tcell.EventKey) *tcell.EventKey {
 	return nil
 }
 
+func (s *ServiceAccount) bindingsCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := s.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.App().Conn().Config().CallTimeout())
+	defer cancel()
+	u, err := dao.FetchSAUsage(ctx, s.App().Conn(), path)
+	if err != nil {
+		s.App().Flash().Err(err)
+		return nil
+	}
+
+	ns, _ := client.Namespaced(path)
+	var b strings.Builder
+	fmt.Fprintf(&b, "Groups: %s\n\n", strings.Join(dao.SAGroups(ns), ", "))
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tBINDING\tROLE\tVIA")
+	for _, bi := range u.Bindings {
+		via := bi.Via
+		if via == "" {
+			via = "direct"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", bi.Kind, client.FQN(bi.Namespace, bi.Name), bi.Role, via)
+	}
+	if err := w.Flush(); err != nil {
+		s.App().Flash().Err(err)
+		return nil
+	}
+	if len(u.Bindings) == 0 {
+		b.WriteString("No bindings found.\n")
+	}
+	fmt.Fprintf(&b, "\nPods (%d):\n", len(u.Pods))
+	for _, p := range u.Pods {
+		fmt.Fprintf(&b, "  %s\n", p)
+	}
+
+	details := NewDetails(s.App(), "Bindings", path, contentTXT, true).Update(b.String())
+	if err := s.App().inject(details, false); err != nil {
+		s.App().Flash().Err(err)
+	}
+
+	return nil
+}
+
+func (s *ServiceAccount) tokenCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := s.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+
+	audience, expiry := "", defaultTokenTTL.String()
+	styles := s.App().Styles.Dialog()
+	f := tview.NewForm().
+		SetItemPadding(0).
+		SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(styles.ButtonBgColor.Color()).
+		SetButtonTextColor(styles.ButtonFgColor.Color()).
+		SetLabelColor(styles.LabelFgColor.Color()).
+		SetFieldTextColor(styles.FieldFgColor.Color())
+	f.AddInputField("Audiences:", audience, 40, nil, func(changed string) {
+		audience = changed
+	})
+	f.AddInputField("Expiry:", expiry, 10, nil, func(changed string) {
+		expiry = changed
+	})
+	f.AddButton("OK", func() {
+		s.App().Content.RemovePage(tokenDialogKey)
+		ttl, err := time.ParseDuration(expiry)
+		if err != nil {
+			s.App().Flash().Err(err)
+			return
+		}
+		if ttl < defaultTokenTTL {
+			s.App().Flash().Errf("Token expiry must be at least %s", defaultTokenTTL)
+			return
+		}
+		if limit := s.App().Config.K9s.ServiceAccounts.TokenTTLLimit(); ttl > limit {
+			s.App().Flash().Errf("Token expiry must be at most %s", limit)
+			return
+		}
+		s.mintToken(path, strings.FieldsFunc(audience, func(r rune) bool {
+			return r == ',' || r == ' '
+		}), ttl)
+	})
+	f.AddButton("Cancel", func() {
+		s.App().Content.RemovePage(tokenDialogKey)
+	})
+	for i := range f.GetButtonCount() {
+		f.GetButton(i).
+			SetBackgroundColorActivated(styles.ButtonFocusBgColor.Color()).
+			SetLabelColorActivated(styles.ButtonFocusFgColor.Color())
+	}
+
+	modal := tview.NewModalForm("<Mint Token>", f)
+	modal.SetText(fmt.Sprintf("Request a short lived token for %s? Leave audiences blank for the API server.", path))
+	modal.SetDoneFunc(func(int, string) {
+		s.App().Content.RemovePage(tokenDialogKey)
+	})
+	s.App().Content.AddPage(tokenDialogKey, modal, false, false)
+	s.App().Content.ShowPage(tokenDialogKey)
+
+	return nil
+}
+
+func (s *ServiceAccount) mintToken(path string, audiences []string, ttl time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.App().Conn().Config().CallTimeout())
+	defer cancel()
+	st, err := dao.MintSAToken(ctx, s.App().Conn(), path, audiences, ttl)
+	if err != nil {
+		s.App().Flash().Err(err)
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Expires: %s\n", st.ExpirationTimestamp.Format(time.RFC3339))
+	if len(audiences) > 0 {
+		fmt.Fprintf(&b, "Audiences: %s\n", strings.Join(audiences, ", "))
+	}
+	fmt.Fprintf(&b, "\n%s\n", st.Token)
+	details := NewDetails(s.App(), "Token", path, contentTXT, true).Update(b.String()).SetSensitive(true)
+	if err := s.App().inject(details, false); err != nil {
+		s.App().Flash().Err(err)
+	}
+}
+
 func scanSARefs(evt *tcell.EventKey, a *App, t *Table, gvr *client.GVR) *tcell.EventKey {
 	path := t.GetSelectedItem()
 	if path == "" {
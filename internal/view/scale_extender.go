@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/slogs"
 	"github.com/derailed/k9s/internal/ui"
@@ -51,6 +52,9 @@ func (s *ScaleExtender) bindKeys(aa *ui.KeyActions) {
 				Dangerous: true,
 			},
 		))
+		gateActions(s, aa, actionGates{
+			ui.KeyS: {{gvr: s.GVR().WithSubResource("scale"), verbs: []string{client.GetVerb, client.UpdateVerb}, clusterWide: !meta.Namespaced}},
+		})
 	}
 }
 
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tcell/v2"
+)
+
+const screenCaptureANSI = "ansi"
+
+func (a *App) screenCaptureCmd(*tcell.EventKey) *tcell.EventKey {
+	a.captureScreen(false)
+
+	return nil
+}
+
+// screencapCmd writes the rendered screen to the screen dumps directory, as
+// plain text or with ANSI colors.
+func (a *App) screencapCmd(arg string) {
+	switch arg {
+	case "":
+		a.captureScreen(false)
+	case screenCaptureANSI:
+		a.captureScreen(true)
+	default:
+		a.Flash().Errf("Invalid command. Use `screencap [%s]`", screenCaptureANSI)
+	}
+}
+
+func (a *App) captureScreen(ansi bool) {
+	s := a.Screen()
+	if s == nil {
+		a.Flash().Warn("Nothing rendered yet")
+		return
+	}
+	text, err := a.screenText(s, ansi)
+	if err != nil {
+		a.Flash().Warnf("Screen capture skipped: %s", err)
+		return
+	}
+	path, err := saveScreen(a.Config.K9s.ContextScreenDumpDir(), text, ansi)
+	if err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	if err := clipboardWrite(path); err != nil {
+		a.Flash().Infof("Screen captured to %s", path)
+		return
+	}
+	a.Flash().Infof("Screen captured to %s (path copied to clipboard)", path)
+}
+
+// screenText returns the redacted screen content. Redaction runs on the plain
+// text, redacted lines then replace their colored counterparts.
+func (a *App) screenText(s tcell.Screen, ansi bool) (string, error) {
+	r := a.redactor()
+	if r == nil {
+		return ui.ScreenText(s, ansi), nil
+	}
+	top := a.Content.Top()
+	if d, ok := top.(*Details); ok && d.sensitive {
+		return "", errors.New("decoded secrets are not captured while redaction is on")
+	}
+	plain := ui.ScreenText(s, false)
+	red := r.Text(plain)
+	if v, ok := top.(*LiveView); ok && v.model.GVR() == client.SecGVR {
+		red = r.SecretText(plain)
+	}
+	if !ansi {
+		return red, nil
+	}
+
+	return mergeRedacted(ui.ScreenText(s, true), plain, red), nil
+}
+
+// mergeRedacted replaces the colored lines whose plain text got redacted.
+func mergeRedacted(ansi, plain, red string) string {
+	aa, pp, rr := strings.Split(ansi, "\n"), strings.Split(plain, "\n"), strings.Split(red, "\n")
+	for i := range min(len(aa), len(pp), len(rr)) {
+		if pp[i] != rr[i] {
+			aa[i] = rr[i] + "\x1b[0m"
+		}
+	}
+
+	return strings.Join(aa, "\n")
+}
+
+func saveScreen(dir, text string, ansi bool) (string, error) {
+	if err := ensureDir(dir); err != nil {
+		return "", err
+	}
+	ext := "txt"
+	if ansi {
+		ext = "ans"
+	}
+	path := filepath.Join(dir, fmt.Sprintf("screen-%d.%s", time.Now().UnixNano(), ext))
+	if err := os.WriteFile(path, []byte(text), 0600); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
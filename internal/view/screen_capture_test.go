@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeRedacted(t *testing.T) {
+	ansi := "\x1b[32mkind: Secret\x1b[0m\n\x1b[32m  tls.key: LS0t\x1b[0m\n"
+	plain := "kind: Secret\n  tls.key: LS0t\n"
+	red := "kind: Secret\n  tls.key: <redacted>\n"
+
+	assert.Equal(t, "\x1b[32mkind: Secret\x1b[0m\n  tls.key: <redacted>\x1b[0m\n", mergeRedacted(ansi, plain, red))
+}
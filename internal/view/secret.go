@@ -62,7 +62,7 @@ func (s *Secret) decodeCmd(evt *tcell.EventKey) *tcell.EventKey {
 		return nil
 	}
 
-	details := NewDetails(s.App(), "Secret Decoder", path, contentYAML, true).Update(string(raw))
+	details := NewDetails(s.App(), "Secret Decoder", path, contentYAML, true).Update(string(raw)).SetSensitive(true)
 	if err := s.App().inject(details, false); err != nil {
 		s.App().Flash().Err(err)
 	}
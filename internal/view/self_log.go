@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/config"
+)
+
+const (
+	selfLogTitle = "SelfLog"
+	// selfLogTail caps the bytes read from the end of the log file.
+	selfLogTail = 1 << 20
+	// noContext labels records not tied to any context.
+	noContext = "-"
+)
+
+var logCtxRX = regexp.MustCompile(`(?:^| )context=("(?:[^"\\]|\\.)*"|\S+)`)
+
+// selfLogCmd shows the tail of the rk9s log, only keeping the records of the
+// given context when one is specified.
+func (a *App) selfLogCmd(ctxName string) {
+	raw, err := tailFile(config.AppLogFile, selfLogTail)
+	if err != nil {
+		a.Flash().Errf("Unable to read rk9s logs: %s", err)
+		return
+	}
+	subject := ctxName
+	if subject == "" {
+		subject = "all"
+	}
+	ll, stats := filterSelfLog(strings.Split(strings.TrimRight(raw, "\n"), "\n"), ctxName)
+	out := renderSelfLogStats(stats) + strings.Join(ll, "\n") + "\n"
+
+	details := NewDetails(a, selfLogTitle, subject, contentANSI, true).Update(out)
+	if err := a.inject(details, false); err != nil {
+		a.Flash().Err(err)
+	}
+}
+
+// selfLogStat tracks the records logged for a context.
+type selfLogStat struct {
+	Context         string
+	Records, Errors int
+}
+
+// filterSelfLog keeps the log records of the given context, all of them when
+// blank, and tallies records and errors per context.
+func filterSelfLog(ll []string, ctxName string) ([]string, []selfLogStat) {
+	out := make([]string, 0, len(ll))
+	stats := make(map[string]*selfLogStat)
+	for _, l := range ll {
+		if l == "" {
+			continue
+		}
+		plain := ansiRX.ReplaceAllString(l, "")
+		n := logContext(plain)
+		st, ok := stats[n]
+		if !ok {
+			st = &selfLogStat{Context: n}
+			stats[n] = st
+		}
+		st.Records++
+		if isErrorRecord(plain) {
+			st.Errors++
+		}
+		if ctxName == "" || n == ctxName {
+			out = append(out, l)
+		}
+	}
+
+	ss := make([]selfLogStat, 0, len(stats))
+	for _, s := range stats {
+		ss = append(ss, *s)
+	}
+	slices.SortFunc(ss, func(a, b selfLogStat) int {
+		if a.Errors != b.Errors {
+			return b.Errors - a.Errors
+		}
+		return strings.Compare(a.Context, b.Context)
+	})
+
+	return out, ss
+}
+
+// logContext returns the context a plain log record is tagged with.
+func logContext(l string) string {
+	m := logCtxRX.FindStringSubmatch(l)
+	if m == nil {
+		return noContext
+	}
+	if n, err := strconv.Unquote(m[1]); err == nil {
+		return n
+	}
+
+	return m[1]
+}
+
+func isErrorRecord(l string) bool {
+	return strings.Contains(l, " ERR ") || strings.Contains(l, " level=ERROR ")
+}
+
+func renderSelfLogStats(ss []selfLogStat) string {
+	if len(ss) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("Records per context (`:selflog <context>` to isolate one):\n")
+	for _, s := range ss {
+		fmt.Fprintf(&b, "  %-40s %6d records %6d errors\n", s.Context, s.Records, s.Errors)
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// tailFile returns up to max trailing bytes of a file, starting at a line
+// boundary.
+func tailFile(path string, max int64) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	var off int64
+	if fi.Size() > max {
+		off = fi.Size() - max
+	}
+	bb := make([]byte, fi.Size()-off)
+	if _, err := f.ReadAt(bb, off); err != nil && err != io.EOF {
+		return "", err
+	}
+	if off > 0 {
+		if i := strings.IndexByte(string(bb), '\n'); i >= 0 {
+			bb = bb[i+1:]
+		}
+	}
+
+	return string(bb), nil
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterSelfLog(t *testing.T) {
+	ll := []string{
+		"2026-01-02T00:00:00Z INF Serving health address=:8080",
+		"2026-01-02T00:00:01Z WRN Context warmup failed \x1b[2mcontext=\x1b[0mfred \x1b[91merror=\x1b[0mboom",
+		"2026-01-02T00:00:02Z ERR Refresh failed gvr=v1/pods context=fred",
+		`2026-01-02T00:00:03Z INF Released inactive context context="blee dev" message=done`,
+		"",
+	}
+
+	uu := map[string]struct {
+		ctx   string
+		count int
+	}{
+		"all": {
+			count: 4,
+		},
+		"fred": {
+			ctx:   "fred",
+			count: 2,
+		},
+		"quoted": {
+			ctx:   "blee dev",
+			count: 1,
+		},
+		"none": {
+			ctx: "zorg",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			out, stats := filterSelfLog(ll, u.ctx)
+			assert.Len(t, out, u.count)
+			assert.Equal(t, []selfLogStat{
+				{Context: "fred", Records: 2, Errors: 1},
+				{Context: noContext, Records: 1},
+				{Context: "blee dev", Records: 1},
+			}, stats)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"os/exec"
+	"runtime"
+
+	"github.com/derailed/k9s/internal/config"
+)
+
+// shellFallbacks tracks the shells tried when the configured one is missing.
+var shellFallbacks = map[string][][]string{
+	windowsOS: {
+		{"pwsh", "-NoProfile", "-Command"},
+		{"powershell", "-NoProfile", "-Command"},
+		{"cmd", "/C"},
+	},
+	"": {
+		{"bash", "-c"},
+		{"sh", "-c"},
+	},
+}
+
+// localShell returns the local shell command running scripts on this platform.
+func (a *App) localShell() []string {
+	cc := a.Config.K9s.Shell.Command(runtime.GOOS)
+	if _, err := exec.LookPath(cc[0]); err == nil {
+		return cc
+	}
+	ff, ok := shellFallbacks[runtime.GOOS]
+	if !ok {
+		ff = shellFallbacks[""]
+	}
+	for _, f := range ff {
+		if _, err := exec.LookPath(f[0]); err == nil {
+			return f
+		}
+	}
+
+	return cc
+}
+
+// pluginCommand returns the binary and arguments running a plugin. Shell
+// plugins run their command in the local shell with quoted arguments.
+func (a *App) pluginCommand(p *config.Plugin, args []string) (string, []string) {
+	if !p.Shell {
+		return p.Command, args
+	}
+	sh := a.localShell()
+	script := config.ShellScript(sh[0], p.Command, args)
+
+	return sh[0], append(append([]string{}, sh[1:]...), script)
+}
+
+// posixShell returns a local POSIX shell or false when none is available,
+// ie on Windows without Git Bash or WSL.
+func posixShell() (string, bool) {
+	for _, sh := range []string{"bash", "sh"} {
+		if _, err := exec.LookPath(sh); err == nil {
+			return sh, true
+		}
+	}
+
+	return "", false
+}
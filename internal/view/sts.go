@@ -4,14 +4,28 @@
 package view
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
 	appsv1 "k8s.io/api/apps/v1"
 )
 
+const (
+	partitionDialogKey = "partition"
+	stsRestartDeadline = 30 * time.Minute
+)
+
 // StatefulSet represents a statefulset viewer.
 type StatefulSet struct {
 	ResourceViewer
@@ -33,11 +47,182 @@ func NewStatefulSet(gvr *client.GVR) ResourceViewer {
 			),
 		),
 	)
+	s.AddBindKeysFn(s.bindKeys)
 	s.GetTable().SetEnterFn(s.showPods)
 
 	return &s
 }
 
+func (s *StatefulSet) bindKeys(aa *ui.KeyActions) {
+	aa.Add(ui.KeyShiftE, ui.NewKeyAction("PVC Retention", s.claimsCmd, true))
+	if s.App().Config.IsReadOnly() {
+		return
+	}
+	aa.Bulk(ui.KeyMap{
+		ui.KeyShiftR: ui.NewKeyActionWithOpts("Ordered Restart", s.orderedRestartCmd,
+			ui.ActionOpts{
+				Visible:   true,
+				Dangerous: true,
+			},
+		),
+		ui.KeyShiftP: ui.NewKeyActionWithOpts("Partition", s.partitionCmd,
+			ui.ActionOpts{
+				Visible:   true,
+				Dangerous: true,
+			},
+		),
+	})
+}
+
+func (s *StatefulSet) orderedRestartCmd(*tcell.EventKey) *tcell.EventKey {
+	path := s.GetTable().GetSelectedItem()
+	if path == "" {
+		return nil
+	}
+
+	msg := fmt.Sprintf("Restart the pods of statefulset %s one at a time?", path)
+	d := s.App().Styles.Dialog()
+	dialog.ShowConfirm(&d, s.App().Content.Pages, "Confirm Ordered Restart", msg, func() {
+		go s.orderedRestart(path)
+	}, func() {})
+
+	return nil
+}
+
+func (s *StatefulSet) orderedRestart(path string) {
+	ctx, cancel := context.WithTimeout(context.Background(), stsRestartDeadline)
+	defer cancel()
+
+	var sts dao.StatefulSet
+	sts.Init(s.App().factory, client.StsGVR)
+	err := sts.RestartOrdered(ctx, path, func(msg string) {
+		s.App().QueueUpdateDraw(func() {
+			s.App().Flash().Info(msg)
+		})
+	})
+	s.App().QueueUpdateDraw(func() {
+		if err != nil {
+			s.App().Flash().Errf("Ordered restart of %s failed: %s", path, err)
+			return
+		}
+		s.App().Flash().Infof("Ordered restart of %s completed", path)
+	})
+}
+
+func (s *StatefulSet) partitionCmd(*tcell.EventKey) *tcell.EventKey {
+	path := s.GetTable().GetSelectedItem()
+	if path == "" {
+		return nil
+	}
+	sts, err := s.getInstance(path)
+	if err != nil {
+		s.App().Flash().Err(err)
+		return nil
+	}
+	var partition int32
+	if u := sts.Spec.UpdateStrategy.RollingUpdate; u != nil && u.Partition != nil {
+		partition = *u.Partition
+	}
+
+	value := strconv.Itoa(int(partition))
+	styles := s.App().Styles.Dialog()
+	f := tview.NewForm().
+		SetItemPadding(0).
+		SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(styles.ButtonBgColor.Color()).
+		SetButtonTextColor(styles.ButtonFgColor.Color()).
+		SetLabelColor(styles.LabelFgColor.Color()).
+		SetFieldTextColor(styles.FieldFgColor.Color())
+	f.AddInputField("Partition:", value, 4, func(textToCheck string, _ rune) bool {
+		_, err := strconv.Atoi(textToCheck)
+		return err == nil
+	}, func(changed string) {
+		value = changed
+	})
+	f.AddButton("OK", func() {
+		defer s.App().Content.RemovePage(partitionDialogKey)
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			s.App().Flash().Err(err)
+			return
+		}
+		var res dao.StatefulSet
+		res.Init(s.App().factory, client.StsGVR)
+		ctx, cancel := context.WithTimeout(context.Background(), s.App().Conn().Config().CallTimeout())
+		defer cancel()
+		if err := res.SetPartition(ctx, path, int32(n)); err != nil {
+			s.App().Flash().Err(err)
+			return
+		}
+		s.App().Flash().Infof("Statefulset %s partition set to %d", path, n)
+	})
+	f.AddButton("Cancel", func() {
+		s.App().Content.RemovePage(partitionDialogKey)
+	})
+	for i := range f.GetButtonCount() {
+		f.GetButton(i).
+			SetBackgroundColorActivated(styles.ButtonFocusBgColor.Color()).
+			SetLabelColorActivated(styles.ButtonFocusFgColor.Color())
+	}
+
+	var replicas int32 = 1
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	modal := tview.NewModalForm("<Partition>", f)
+	modal.SetText(fmt.Sprintf("Only update the replicas of %s with an ordinal >= partition (0-%d)?", path, replicas))
+	modal.SetDoneFunc(func(int, string) {
+		s.App().Content.RemovePage(partitionDialogKey)
+	})
+	s.App().Content.AddPage(partitionDialogKey, modal, false, false)
+	s.App().Content.ShowPage(partitionDialogKey)
+
+	return nil
+}
+
+func (s *StatefulSet) claimsCmd(*tcell.EventKey) *tcell.EventKey {
+	path := s.GetTable().GetSelectedItem()
+	if path == "" {
+		return nil
+	}
+	var sts dao.StatefulSet
+	sts.Init(s.App().factory, client.StsGVR)
+	ctx, cancel := context.WithTimeout(context.Background(), s.App().Conn().Config().CallTimeout())
+	defer cancel()
+	cc, err := sts.Claims(ctx, path)
+	if err != nil {
+		s.App().Flash().Err(err)
+		return nil
+	}
+
+	details := NewDetails(s.App(), "PVC Retention", path, contentTXT, true).Update(renderStsClaims(cc))
+	if err := s.App().inject(details, false); err != nil {
+		s.App().Flash().Err(err)
+	}
+
+	return nil
+}
+
+func renderStsClaims(cc []dao.StsClaim) string {
+	if len(cc) == 0 {
+		return "No volume claim templates defined.\n"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-8s %-40s %-8s %-11s %-11s\n", "ORDINAL", "CLAIM", "STATUS", "WHEN-SCALED", "WHEN-DELETED")
+	for _, c := range cc {
+		ordinal := strconv.Itoa(c.Ordinal)
+		if c.Orphan {
+			ordinal += "*"
+		}
+		fmt.Fprintf(&b, "%-8s %-40s %-8s %-11s %-11s\n", ordinal, c.Claim, c.Status, c.WhenScaled, c.WhenDeleted)
+	}
+	if slices.ContainsFunc(cc, func(c dao.StsClaim) bool { return c.Orphan }) {
+		b.WriteString("\n* replica scaled away, its claim was retained\n")
+	}
+
+	return b.String()
+}
+
 func (s *StatefulSet) logOptions(prev bool) (*dao.LogOptions, error) {
 	path := s.GetTable().GetSelectedItem()
 	if path == "" {
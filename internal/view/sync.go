@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/k9s/internal/ui/dialog"
+)
+
+const syncDeadline = 2 * time.Minute
+
+// syncCmd pulls or pushes the user config from the sync store.
+func (a *App) syncCmd(arg string) {
+	s := a.Config.K9s.Sync
+	if s == nil {
+		a.Flash().Warn("No sync store configured. Add `sync` to your config")
+		return
+	}
+	if err := s.Validate(); err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	switch arg {
+	case "", "pull":
+		a.Flash().Info("Pulling config from sync store...")
+		go a.syncPull(s)
+	case "push":
+		msg := fmt.Sprintf("Push local config to?\n%s", syncStore(s))
+		d := a.Styles.Dialog()
+		dialog.ShowConfirm(&d, a.Content.Pages, "Confirm Sync Push", msg, func() {
+			a.Flash().Info("Pushing config to sync store...")
+			go a.syncPush(s)
+		}, func() {})
+	default:
+		a.Flash().Warn("Invalid command. Use `sync pull` or `sync push`")
+	}
+}
+
+// pullOnStart pulls the user config when the sync store asks for it.
+func (a *App) pullOnStart() {
+	if s := a.Config.K9s.Sync; s != nil && s.PullOnStart {
+		if err := s.Validate(); err != nil {
+			slog.Warn("Invalid sync config", slogs.Error, err)
+			return
+		}
+		go a.syncPull(s)
+	}
+}
+
+func (a *App) syncPull(s *config.Sync) {
+	ctx, cancel := context.WithTimeout(context.Background(), syncDeadline)
+	defer cancel()
+
+	nn, err := pullStore(ctx, s, config.SyncDir())
+	a.QueueUpdateDraw(func() {
+		if err != nil {
+			a.Flash().Errf("Sync pull failed: %s", err)
+			return
+		}
+		a.Flash().Infof("Synced %s from %s", syncSummary(nn), syncStore(s))
+	})
+}
+
+func (a *App) syncPush(s *config.Sync) {
+	ctx, cancel := context.WithTimeout(context.Background(), syncDeadline)
+	defer cancel()
+
+	nn, err := pushStore(ctx, s, config.SyncDir())
+	a.QueueUpdateDraw(func() {
+		if err != nil {
+			a.Flash().Errf("Sync push failed: %s", err)
+			return
+		}
+		a.Flash().Infof("Pushed %s to %s", syncSummary(nn), syncStore(s))
+	})
+}
+
+func pullStore(ctx context.Context, s *config.Sync, dir string) ([]string, error) {
+	if err := fetchStore(ctx, s, dir); err != nil {
+		return nil, err
+	}
+
+	return config.SyncCopy(config.SyncItems(), dir, false)
+}
+
+func pushStore(ctx context.Context, s *config.Sync, dir string) ([]string, error) {
+	if s.Git != "" {
+		if err := fetchStore(ctx, s, dir); err != nil {
+			return nil, err
+		}
+	} else if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	nn, err := config.SyncCopy(config.SyncItems(), dir, true)
+	if err != nil {
+		return nil, err
+	}
+	if s.S3 != "" {
+		_, err := syncExec(ctx, "aws", "s3", "sync", dir, s.S3, "--exclude", ".git/*")
+		return nn, err
+	}
+
+	if _, err := syncExec(ctx, "git", "-C", dir, "add", "-A"); err != nil {
+		return nil, err
+	}
+	host, _ := os.Hostname()
+	if out, err := syncExec(ctx, "git", "-C", dir, "commit", "-m", "Sync rk9s config from "+host); err != nil {
+		if !strings.Contains(out, "nothing to commit") {
+			return nil, err
+		}
+	}
+	_, err = syncExec(ctx, "git", "-C", dir, "push")
+
+	return nn, err
+}
+
+// fetchStore clones or updates the local copy of the sync store.
+func fetchStore(ctx context.Context, s *config.Sync, dir string) error {
+	if s.S3 != "" {
+		_, err := syncExec(ctx, "aws", "s3", "sync", s.S3, dir)
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); errors.Is(err, fs.ErrNotExist) {
+		args := []string{"clone", "--depth", "1"}
+		if s.Branch != "" {
+			args = append(args, "--branch", s.Branch)
+		}
+		_, err := syncExec(ctx, "git", append(args, s.Git, dir)...)
+		return err
+	}
+	_, err := syncExec(ctx, "git", "-C", dir, "pull", "--ff-only")
+
+	return err
+}
+
+func syncExec(ctx context.Context, bin string, args ...string) (string, error) {
+	out, err := oneShoot(ctx, &shellOpts{binary: bin, args: args})
+	if err != nil {
+		return out, fmt.Errorf("%s failed: %w: %s", bin, err, lastLine(out))
+	}
+
+	return out, nil
+}
+
+func syncStore(s *config.Sync) string {
+	if s.S3 != "" {
+		return s.S3
+	}
+
+	return s.Git
+}
+
+func syncSummary(nn []string) string {
+	if len(nn) == 0 {
+		return "nothing"
+	}
+
+	return strings.Join(nn, ", ")
+}
@@ -8,10 +8,12 @@ import (
 	"log/slog"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/config/data"
 	"github.com/derailed/k9s/internal/model"
 	"github.com/derailed/k9s/internal/model1"
 	"github.com/derailed/k9s/internal/render"
@@ -30,6 +32,7 @@ type Table struct {
 	envFn      EnvFunc
 	bindKeysFn []BindKeysFunc
 	command    *cmd.Interpreter
+	persistent bool
 }
 
 // NewTable returns a new viewer.
@@ -63,10 +66,53 @@ func (t *Table) Init(ctx context.Context) (err error) {
 	t.bindKeys()
 	t.GetModel().SetRefreshRate(t.app.Config.K9s.RefreshDuration())
 	t.CmdBuff().AddListener(t)
+	t.restoreSortCol()
 
 	return nil
 }
 
+// SetPersistent flags the view sort, filter and namespace to be remembered
+// across restarts.
+func (t *Table) SetPersistent(b bool) {
+	t.persistent = b
+}
+
+func (t *Table) restoreSortCol() {
+	if !t.persistent || t.GVR() == nil {
+		return
+	}
+	st, ok := t.app.Config.ViewState(t.GVR().String())
+	if !ok {
+		return
+	}
+	if name, asc, ok := st.SortCol(); ok {
+		t.RestoreSortCol(name, asc)
+	}
+}
+
+// saveState records the view sort, filter and namespace in the context config.
+func (t *Table) saveState() {
+	if !t.persistent || t.app == nil || t.GVR() == nil {
+		return
+	}
+	st := data.ViewState{Filter: t.CmdBuff().GetText()}
+	if sc, manual := t.SortCol(); manual && sc.Name != "" {
+		dir := "desc"
+		if sc.ASC {
+			dir = "asc"
+		}
+		st.SortColumn = sc.Name + ":" + dir
+	}
+	switch ns := t.GetModel().GetNamespace(); {
+	case client.IsClusterScoped(ns):
+	case client.IsAllNamespaces(ns):
+		st.Namespace = client.NamespaceAll
+	default:
+		st.Namespace = ns
+	}
+	t.app.Config.SetViewState(t.GVR().String(), st)
+}
+
 // SetCommand sets the current command.
 func (t *Table) SetCommand(i *cmd.Interpreter) {
 	t.command = i
@@ -140,7 +186,7 @@ func (t *Table) EnvFn() EnvFunc {
 func (t *Table) defaultEnv() Env {
 	path := t.GetSelectedItem()
 	row := t.GetSelectedRow(path)
-	env := defaultEnv(t.app.Conn().Config(), path, t.GetModel().Peek().Header(), row)
+	env := redactEnv(t.app.redactor(), defaultEnv(t.app.Conn().Config(), path, t.GetModel().Peek().Header(), row))
 	env["FILTER"] = t.CmdBuff().GetText()
 	if env["FILTER"] == "" {
 		env["NAMESPACE"], env["FILTER"] = client.Namespaced(path)
@@ -186,6 +232,7 @@ func (t *Table) Start() {
 
 // Stop terminates the component.
 func (t *Table) Stop() {
+	t.saveState()
 	t.CmdBuff().RemoveListener(t)
 	t.Styles().RemoveListener(t.Table)
 	t.App().CustomView().RemoveListener(t.Table)
@@ -218,7 +265,7 @@ func (t *Table) BufferActive(state bool, k model.BufferKind) {
 }
 
 func (t *Table) saveCmd(*tcell.EventKey) *tcell.EventKey {
-	if path, err := saveTable(t.app.Config.K9s.ContextScreenDumpDir(), t.GVR().R(), t.Path, t.GetFilteredData()); err != nil {
+	if path, err := saveTable(t.app.Config.K9s.ContextScreenDumpDir(), t.GVR().R(), t.Path, redactTable(t.app.redactor(), t.GetFilteredData())); err != nil {
 		t.app.Flash().Err(err)
 	} else {
 		t.app.Flash().Infof("File saved successfully: %q", render.Truncate(filepath.Base(path), 50))
@@ -227,6 +274,35 @@ func (t *Table) saveCmd(*tcell.EventKey) *tcell.EventKey {
 	return nil
 }
 
+func (t *Table) markdownCmd(*tcell.EventKey) *tcell.EventKey {
+	data := redactTable(t.app.redactor(), t.GetFilteredData())
+	ctxs := []string{t.app.Config.K9s.ActiveContextName()}
+	if mt, ok := t.GetModel().(*model.Table); ok && mt.IsMultiContext() {
+		ctxs = mt.MultiContexts()
+	}
+	var filters []string
+	if f := t.CmdBuff().GetText(); f != "" {
+		filters = append(filters, "/"+f)
+	}
+	if sel := t.GetModel().GetLabelSelector(); sel != nil && !sel.Empty() {
+		filters = append(filters, "-l "+sel.String())
+	}
+	md := tableToMarkdown(t.GVR().R(), ctxs, filters, data, time.Now())
+
+	if err := clipboardWrite(md); err == nil {
+		t.app.Flash().Infof("%d rows copied to clipboard as markdown...", data.RowCount())
+		return nil
+	}
+	path, err := saveMarkdown(t.app.Config.K9s.ContextScreenDumpDir(), t.GVR().R(), t.Path, data.GetNamespace(), md)
+	if err != nil {
+		t.app.Flash().Err(err)
+		return nil
+	}
+	t.app.Flash().Infof("Clipboard unavailable. Markdown saved: %q", render.Truncate(filepath.Base(path), 50))
+
+	return nil
+}
+
 func (t *Table) bindKeys() {
 	t.Actions().Bulk(ui.KeyMap{
 		ui.KeyHelp:             ui.NewKeyAction("Help", t.App().helpCmd, true),
@@ -234,6 +310,8 @@ func (t *Table) bindKeys() {
 		tcell.KeyCtrlSpace:     ui.NewSharedKeyAction("Mark Range", t.markSpanCmd, false),
 		tcell.KeyCtrlBackslash: ui.NewSharedKeyAction("Marks Clear", t.clearMarksCmd, false),
 		tcell.KeyCtrlS:         ui.NewSharedKeyAction("Save", t.saveCmd, false),
+		tcell.KeyCtrlO:         ui.NewSharedKeyAction("Copy Markdown", t.markdownCmd, false),
+		actionPickerKey:        ui.NewSharedKeyAction("Actions", t.actionsCmd, false),
 		ui.KeySlash:            ui.NewSharedKeyAction("Filter Mode", t.activateCmd, false),
 		tcell.KeyCtrlZ:         ui.NewKeyAction("Toggle Faults", t.toggleFaultCmd, false),
 		tcell.KeyCtrlW:         ui.NewKeyAction("Toggle Wide", t.toggleWideCmd, false),
@@ -244,6 +322,11 @@ func (t *Table) bindKeys() {
 	})
 }
 
+func (t *Table) actionsCmd(*tcell.EventKey) *tcell.EventKey {
+	t.app.showActionPicker(t.Actions())
+	return nil
+}
+
 func (t *Table) toggleFaultCmd(*tcell.EventKey) *tcell.EventKey {
 	t.ToggleToast()
 	return nil
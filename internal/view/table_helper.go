@@ -82,3 +82,56 @@ func saveTable(dir, title, path string, mdata *model1.TableData) (string, error)
 
 	return fPath, nil
 }
+
+func saveMarkdown(dir, title, path, ns, md string) (string, error) {
+	if client.IsClusterWide(ns) {
+		ns = client.NamespaceAll
+	}
+	fPath, err := computeFilename(dir, ns, title, path)
+	if err != nil {
+		return "", err
+	}
+	fPath = strings.TrimSuffix(fPath, filepath.Ext(fPath)) + ".md"
+	slog.Debug("Saving markdown to disk", slogs.FileName, fPath)
+
+	return fPath, os.WriteFile(fPath, []byte(md), 0600)
+}
+
+// tableToMarkdown renders table data along with its capture context as a
+// markdown snippet suitable for runbooks, incident docs and PRs.
+func tableToMarkdown(title string, ctxs, filters []string, mdata *model1.TableData, at time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "#### %s", title)
+	if ns := mdata.GetNamespace(); !client.IsClusterWide(ns) && !client.IsClusterScoped(ns) {
+		fmt.Fprintf(&b, " (%s)", ns)
+	}
+	b.WriteString("\n\n")
+	if len(ctxs) > 0 {
+		fmt.Fprintf(&b, "- **Contexts:** `%s`\n", strings.Join(ctxs, "`, `"))
+	}
+	if len(filters) > 0 {
+		fmt.Fprintf(&b, "- **Filters:** `%s`\n", strings.Join(filters, "`, `"))
+	}
+	fmt.Fprintf(&b, "- **Rows:** %d\n", mdata.RowCount())
+	fmt.Fprintf(&b, "- **Captured:** %s\n\n", at.UTC().Format(time.RFC3339))
+
+	cols := mdata.ColumnNames(true)
+	b.WriteString("| " + strings.Join(cols, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(cols)) + "\n")
+	mdata.RowsRange(func(_ int, re model1.RowEvent) bool {
+		ff := make([]string, len(re.Row.Fields))
+		for i, f := range re.Row.Fields {
+			ff[i] = mdEscape(f)
+		}
+		b.WriteString("| " + strings.Join(ff, " | ") + " |\n")
+		return true
+	})
+
+	return b.String()
+}
+
+func mdEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableToMarkdown(t *testing.T) {
+	data := model1.NewTableDataFull(
+		client.PodGVR,
+		"default",
+		model1.Header{
+			model1.HeaderColumn{Name: "NAMESPACE"},
+			model1.HeaderColumn{Name: "NAME"},
+			model1.HeaderColumn{Name: "STATUS"},
+		},
+		model1.NewRowEventsWithEvts(
+			model1.RowEvent{Row: model1.Row{Fields: model1.Fields{"default", "fred", "Running"}}},
+			model1.RowEvent{Row: model1.Row{Fields: model1.Fields{"default", "blee", "a|b"}}},
+		),
+	)
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	e := `#### pods (default)
+
+- **Contexts:** ` + "`c1`, `c2`" + `
+- **Filters:** ` + "`/fred`" + `
+- **Rows:** 2
+- **Captured:** 2025-01-02T03:04:05Z
+
+| NAMESPACE | NAME | STATUS |
+| --- | --- | --- |
+| default | fred | Running |
+| default | blee | a\|b |
+`
+	assert.Equal(t, e, tableToMarkdown("pods", []string{"c1", "c2"}, []string{"/fred"}, data, at))
+}
+
+func TestSaveMarkdown(t *testing.T) {
+	// Like other table exports, the whole path is lowercased.
+	dir, err := os.MkdirTemp("", "rk9s-md")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	path, err := saveMarkdown(dir, "pods", "", "default", "# blee\n")
+	require.NoError(t, err)
+	assert.Equal(t, strings.ToLower(dir), filepath.Dir(path))
+	assert.Equal(t, ".md", filepath.Ext(path))
+
+	bb, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# blee\n", string(bb))
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/k9s/internal/view/cmd"
+)
+
+const (
+	teamNotesDeadline = 10 * time.Second
+	starMarker        = "★"
+
+	// teamFilterDenied tracks the characters a team filter may not contain.
+	teamFilterDenied = "|!`$;&<>"
+)
+
+// loadTeamNotes refreshes the team notes of the active context in the background.
+func (a *App) loadTeamNotes() {
+	cfg := a.Config.K9s.TeamNotes
+	if cfg == nil || a.Conn() == nil {
+		a.teamNotes.Store(nil)
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), teamNotesDeadline)
+		defer cancel()
+		tn, err := dao.FetchTeamNotes(ctx, a.Conn(), cfg.Namespace, cfg.Name())
+		if err != nil {
+			slog.Debug("Team notes unavailable", slogs.Error, err)
+		}
+		a.teamNotes.Store(tn)
+	}()
+}
+
+// teamNotesFor returns the team notes on the selected resources.
+func (a *App) teamNotesFor(gvr *client.GVR, paths []string) []string {
+	tn := a.teamNotes.Load()
+	var ss []string
+	for _, p := range paths {
+		for _, n := range tn.For(gvr, p) {
+			ss = append(ss, fmt.Sprintf("%s: %s", p, n.Note))
+		}
+	}
+
+	return ss
+}
+
+// withTeamNotes appends the team notes on the selected resources to a message.
+func (a *App) withTeamNotes(msg string, gvr *client.GVR, paths []string) string {
+	ss := a.teamNotesFor(gvr, paths)
+	if len(ss) == 0 {
+		return msg
+	}
+
+	return msg + "\n\nTeam notes:\n" + strings.Join(ss, "\n")
+}
+
+// notesCmd lists the team notes or runs a saved team filter.
+func (a *App) notesCmd(arg string) {
+	if a.Config.K9s.TeamNotes == nil {
+		a.Flash().Warn("No team notes configured. Add `teamNotes` to your config")
+		return
+	}
+	if arg != "" {
+		tn := a.teamNotes.Load()
+		if tn == nil || tn.Filters[arg] == "" {
+			a.Flash().Warnf("Unknown team filter %q", arg)
+			return
+		}
+		var aa *config.Aliases
+		if a.command.alias != nil {
+			aa = a.command.alias.Aliases
+		}
+		f := tn.Filters[arg]
+		if err := checkTeamFilter(aa, f); err != nil {
+			a.Flash().Err(err)
+			return
+		}
+		a.gotoResource(f, "", true, true)
+		return
+	}
+
+	cfg := a.Config.K9s.TeamNotes
+	a.Flash().Info("Loading team notes...")
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), teamNotesDeadline)
+		defer cancel()
+		tn, err := dao.FetchTeamNotes(ctx, a.Conn(), cfg.Namespace, cfg.Name())
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.Flash().Errf("Team notes load failed: %s", err)
+				return
+			}
+			a.teamNotes.Store(tn)
+			subject := client.FQN(cfg.Namespace, cfg.Name())
+			details := NewDetails(a, "Team Notes", subject, contentTXT, true).Update(renderTeamNotes(tn))
+			if err := a.inject(details, false); err != nil {
+				a.Flash().Err(err)
+			}
+		})
+	}()
+}
+
+// checkTeamFilter ensures a team filter is a plain resource command, ie no
+// pipe, shell escape, context switch or special command, since filters come
+// from a cluster ConfigMap anyone with write access can edit.
+func checkTeamFilter(aa *config.Aliases, f string) error {
+	if strings.ContainsAny(f, teamFilterDenied) {
+		return fmt.Errorf("team filter %q is not a plain resource command", f)
+	}
+	p := cmd.NewInterpreter(f)
+	if _, piped := p.PipeArg(); piped || p.IsBlank() || p.IsContextCmd() || p.IsNamespaceCmd() {
+		return fmt.Errorf("team filter %q is not a plain resource command", f)
+	}
+	if _, ok := p.HasContext(); ok {
+		return fmt.Errorf("team filter %q may not switch contexts", f)
+	}
+	if aa == nil {
+		return fmt.Errorf("team filter %q: no aliases available", f)
+	}
+	if gvr, ok := aa.Resolve(p); !ok || !gvr.IsK8sRes() {
+		return fmt.Errorf("team filter %q is not a resource command", f)
+	}
+
+	return nil
+}
+
+func renderTeamNotes(tn *dao.TeamNotes) string {
+	var b strings.Builder
+	nn := append([]dao.TeamNote(nil), tn.Notes...)
+	sort.SliceStable(nn, func(i, j int) bool {
+		return nn[i].Starred && !nn[j].Starred
+	})
+	b.WriteString("Notes\n")
+	if len(nn) == 0 {
+		b.WriteString("  none\n")
+	}
+	for _, n := range nn {
+		marker := " "
+		if n.Starred {
+			marker = starMarker
+		}
+		target := n.Name
+		if n.Namespace != "" {
+			target = client.FQN(n.Namespace, n.Name)
+		}
+		fmt.Fprintf(&b, "%s %s %s\n    %s\n", marker, n.Resource, target, n.Note)
+	}
+
+	ff := make([]string, 0, len(tn.Filters))
+	for k := range tn.Filters {
+		ff = append(ff, k)
+	}
+	sort.Strings(ff)
+	b.WriteString("\nFilters (run with :notes NAME)\n")
+	if len(ff) == 0 {
+		b.WriteString("  none\n")
+	}
+	for _, f := range ff {
+		fmt.Fprintf(&b, "  %-20s %s\n", f, tn.Filters[f])
+	}
+
+	return b.String()
+}
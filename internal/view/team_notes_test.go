@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTeamNotes(t *testing.T) {
+	tn := dao.TeamNotes{
+		Notes: []dao.TeamNote{
+			{Resource: "deployments", Name: "payments", Note: "Scale through the HPA only"},
+			{Resource: "pods", Namespace: "kube-system", Name: "etcd-*", Note: "Do not touch", Starred: true},
+		},
+		Filters: map[string]string{"crashing": "pods /CrashLoop -A"},
+	}
+
+	e := `Notes
+★ pods kube-system/etcd-*
+    Do not touch
+  deployments payments
+    Scale through the HPA only
+
+Filters (run with :notes NAME)
+  crashing             pods /CrashLoop -A
+`
+	assert.Equal(t, e, renderTeamNotes(&tn))
+}
+
+func TestCheckTeamFilter(t *testing.T) {
+	aa := config.NewAliases()
+	aa.Define(client.PodGVR, "pods", "po")
+	aa.Define(client.NewGVR("crashing"), "crash")
+
+	uu := map[string]struct {
+		f   string
+		err string
+	}{
+		"plain": {
+			f: "pods /CrashLoop -A",
+		},
+		"labels": {
+			f: "po app=web",
+		},
+		"pipe": {
+			f:   "pods | sh -c id",
+			err: `team filter "pods | sh -c id" is not a plain resource command`,
+		},
+		"shell": {
+			f:   "!id",
+			err: `team filter "!id" is not a plain resource command`,
+		},
+		"context": {
+			f:   "pods @prod",
+			err: `team filter "pods @prod" may not switch contexts`,
+		},
+		"ctx-cmd": {
+			f:   "ctx prod",
+			err: `team filter "ctx prod" is not a plain resource command`,
+		},
+		"special": {
+			f:   "mc delete ns default",
+			err: `team filter "mc delete ns default" is not a resource command`,
+		},
+		"alias": {
+			f:   "crash",
+			err: `team filter "crash" is not a resource command`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := checkTeamFilter(aa, u.f)
+			if u.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, u.err)
+		})
+	}
+}
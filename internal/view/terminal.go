@@ -0,0 +1,339 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/creack/pty"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/derailed/k9s/internal/view/cmd"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	terminalTitle    = "Terminal"
+	terminalTitleFmt = "[fg:bg:b] %s([hilite:bg:b]%s[fg:bg:-])[fg:bg:-] "
+	terminalEnv      = "TERM=vt100"
+	terminalRows     = 24
+	terminalCols     = 80
+
+	// terminalReapTimeout tracks how long to wait for a killed command to exit.
+	terminalReapTimeout = 2 * time.Second
+)
+
+// Terminal represents an embedded terminal pane running an interactive
+// command, split below the view it was started from.
+type Terminal struct {
+	*tview.Flex
+
+	app     *App
+	text    *tview.TextView
+	buff    *model.TermBuffer
+	actions *ui.KeyActions
+	subject string
+	bin     string
+	args    []string
+	cmd     *exec.Cmd
+	pty     *os.File
+	done    chan struct{}
+	running atomic.Bool
+	dirty   atomic.Bool
+}
+
+// NewTerminal returns a new terminal pane.
+func NewTerminal(app *App, subject, bin string, args []string) *Terminal {
+	return &Terminal{
+		Flex:    tview.NewFlex().SetDirection(tview.FlexRow),
+		app:     app,
+		text:    tview.NewTextView(),
+		buff:    model.NewTermBuffer(terminalRows, terminalCols),
+		actions: ui.NewKeyActions(),
+		subject: subject,
+		bin:     bin,
+		args:    args,
+	}
+}
+
+func (*Terminal) SetCommand(*cmd.Interpreter)            {}
+func (*Terminal) SetFilter(string, bool)                 {}
+func (*Terminal) SetLabelSelector(labels.Selector, bool) {}
+
+// Init initializes the pane and starts the command.
+func (t *Terminal) Init(context.Context) error {
+	if top := t.app.Content.Top(); top != nil {
+		t.AddItem(top, 0, 1, false)
+	}
+	t.AddItem(t.text, 0, 2, true)
+	t.text.SetBorder(true)
+	t.text.SetBorderPadding(0, 0, 1, 1)
+	t.text.SetScrollable(true).SetWrap(true)
+	t.text.SetInputCapture(t.keyboard)
+	t.StylesChanged(t.app.Styles)
+	t.app.Styles.AddListener(t)
+	t.bindKeys()
+
+	c := exec.Command(t.bin, t.args...)
+	c.Env = append(os.Environ(), terminalEnv)
+	f, err := pty.StartWithSize(c, &pty.Winsize{Rows: terminalRows, Cols: terminalCols})
+	if err != nil {
+		t.app.Styles.RemoveListener(t)
+		return fmt.Errorf("terminal %s failed: %w", t.bin, err)
+	}
+	t.cmd, t.pty, t.done = c, f, make(chan struct{})
+	t.running.Store(true)
+	t.updateTitle()
+	go t.read()
+
+	return nil
+}
+
+func (t *Terminal) bindKeys() {
+	t.actions.Bulk(ui.KeyMap{
+		tcell.KeyCtrlQ: ui.NewKeyAction("Close", t.closeCmd, true),
+	})
+}
+
+// IsRunning returns true while the command runs. Keys then go to the
+// command instead of the app.
+func (t *Terminal) IsRunning() bool {
+	return t.running.Load()
+}
+
+func (t *Terminal) read() {
+	_, err := io.Copy(t, t.pty)
+	if err != nil && !errors.Is(err, os.ErrClosed) {
+		slog.Debug("Terminal read ended", slogs.Error, err)
+	}
+	status := "done"
+	if err := t.cmd.Wait(); err != nil {
+		status = err.Error()
+	}
+	t.running.Store(false)
+	_ = t.pty.Close()
+	close(t.done)
+	_, _ = fmt.Fprintf(t.buff, "\r\n[process exited: %s, press Esc to close]\r\n", status)
+	t.app.QueueUpdateDraw(func() {
+		t.updateTitle()
+		t.refresh()
+	})
+}
+
+// Write feeds the command output to the screen and schedules a redraw.
+func (t *Terminal) Write(p []byte) (int, error) {
+	n, err := t.buff.Write(p)
+	if t.dirty.CompareAndSwap(false, true) {
+		t.app.QueueUpdateDraw(func() {})
+	}
+
+	return n, err
+}
+
+// Draw draws the pane and keeps the command terminal size in sync.
+func (t *Terminal) Draw(screen tcell.Screen) {
+	if t.dirty.CompareAndSwap(true, false) {
+		t.refresh()
+	}
+	t.Flex.Draw(screen)
+	t.resize()
+}
+
+func (t *Terminal) refresh() {
+	t.text.SetText(tview.Escape(t.buff.String()))
+	t.text.ScrollToEnd()
+}
+
+func (t *Terminal) resize() {
+	_, _, w, h := t.text.GetInnerRect()
+	if w <= 0 || h <= 0 {
+		return
+	}
+	if rows, cols := t.buff.Size(); rows == h && cols == w {
+		return
+	}
+	t.buff.SetSize(h, w)
+	if t.IsRunning() {
+		if err := pty.Setsize(t.pty, &pty.Winsize{Rows: uint16(h), Cols: uint16(w)}); err != nil {
+			slog.Debug("Terminal resize failed", slogs.Error, err)
+		}
+	}
+}
+
+func (t *Terminal) keyboard(evt *tcell.EventKey) *tcell.EventKey {
+	if a, ok := t.actions.Get(ui.AsKey(evt)); ok {
+		return a.Action(evt)
+	}
+	if !t.IsRunning() {
+		if evt.Key() == tcell.KeyEscape || (evt.Key() == tcell.KeyRune && evt.Rune() == 'q') {
+			return t.closeCmd(evt)
+		}
+		return evt
+	}
+	if bb := termKeyBytes(evt); len(bb) > 0 {
+		if _, err := t.pty.Write(bb); err != nil {
+			slog.Debug("Terminal write failed", slogs.Error, err)
+		}
+	}
+
+	return nil
+}
+
+func (t *Terminal) closeCmd(evt *tcell.EventKey) *tcell.EventKey {
+	return t.app.PrevCmd(evt)
+}
+
+// kill terminates the command and waits for the reader to reap it.
+func (t *Terminal) kill() {
+	if t.done == nil {
+		return
+	}
+	if t.IsRunning() && t.cmd.Process != nil {
+		_ = t.cmd.Process.Kill()
+	}
+	// Unblocks the reader should a child of the command hold the pty open.
+	_ = t.pty.Close()
+	select {
+	case <-t.done:
+	case <-time.After(terminalReapTimeout):
+		slog.Warn("Terminal command not reaped", slogs.Command, t.subject)
+	}
+}
+
+func (t *Terminal) updateTitle() {
+	status := "running"
+	if !t.IsRunning() {
+		status = "exited"
+	}
+	title := fmt.Sprintf(terminalTitleFmt, terminalTitle, t.subject+" "+status)
+	styles := t.app.Styles.Frame()
+	t.text.SetTitle(ui.SkinTitle(title, &styles))
+}
+
+// StylesChanged notifies the skin changed.
+func (t *Terminal) StylesChanged(s *config.Styles) {
+	t.SetBackgroundColor(s.BgColor())
+	t.text.SetBackgroundColor(s.BgColor())
+	t.text.SetTextColor(s.FgColor())
+	t.text.SetBorderFocusColor(s.Frame().Border.FocusColor.Color())
+}
+
+// Name returns the component name.
+func (*Terminal) Name() string { return terminalTitle }
+
+// Start starts the view updater.
+func (*Terminal) Start() {}
+
+// Stop terminates the updater and the command.
+func (t *Terminal) Stop() {
+	t.app.Styles.RemoveListener(t)
+	t.kill()
+}
+
+// InCmdMode checks if prompt is active.
+func (*Terminal) InCmdMode() bool { return false }
+
+// Hints returns menu hints.
+func (t *Terminal) Hints() model.MenuHints {
+	return t.actions.Hints()
+}
+
+// ExtraHints returns additional hints.
+func (*Terminal) ExtraHints() map[string]string {
+	return nil
+}
+
+// termKeyBytes returns the bytes a terminal sends for a key.
+func termKeyBytes(evt *tcell.EventKey) []byte {
+	switch k := evt.Key(); k {
+	case tcell.KeyRune:
+		if evt.Modifiers()&tcell.ModAlt != 0 {
+			return append([]byte{0x1b}, string(evt.Rune())...)
+		}
+		return []byte(string(evt.Rune()))
+	case tcell.KeyEnter:
+		return []byte{'\r'}
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		return []byte{0x7f}
+	case tcell.KeyTab:
+		return []byte{'\t'}
+	case tcell.KeyEscape:
+		return []byte{0x1b}
+	case tcell.KeyUp:
+		return []byte("\x1b[A")
+	case tcell.KeyDown:
+		return []byte("\x1b[B")
+	case tcell.KeyRight:
+		return []byte("\x1b[C")
+	case tcell.KeyLeft:
+		return []byte("\x1b[D")
+	case tcell.KeyHome:
+		return []byte("\x1b[H")
+	case tcell.KeyEnd:
+		return []byte("\x1b[F")
+	case tcell.KeyDelete:
+		return []byte("\x1b[3~")
+	default:
+		if k >= tcell.KeyCtrlA && k <= tcell.KeyCtrlZ {
+			return []byte{byte(k)}
+		}
+		return nil
+	}
+}
+
+// termCmd opens a terminal pane running a command or the user shell.
+func (a *App) termCmd(arg string) {
+	if a.Config.IsReadOnly() {
+		a.Flash().Warn("Terminal is not allowed in read-only mode")
+		return
+	}
+	args := strings.Fields(arg)
+	if len(args) == 0 {
+		sh := os.Getenv("SHELL")
+		if sh == "" {
+			sh, _ = posixShell()
+		}
+		if sh == "" {
+			a.Flash().Warn("Invalid command. Use `term COMMAND`")
+			return
+		}
+		args = []string{sh}
+	}
+	a.openTerminal(strings.Join(args, " "), args[0], args[1:])
+}
+
+func (a *App) openTerminal(subject, bin string, args []string) {
+	if err := a.inject(NewTerminal(a, subject, bin, args), false); err != nil {
+		a.Flash().Err(err)
+	}
+}
+
+// pluginInTerminal runs an interactive plugin in a terminal pane.
+func pluginInTerminal(r Runner, p *config.Plugin, args []string) {
+	cb := func() {
+		bin, args := r.App().pluginCommand(p, args)
+		r.App().openTerminal(p.Description, bin, args)
+	}
+	if p.Confirm {
+		msg := fmt.Sprintf("Run?\n%s %s", p.Command, strings.Join(args, " "))
+		d := r.App().Styles.Dialog()
+		dialog.ShowConfirm(&d, r.App().Content.Pages, "Confirm "+p.Description, msg, cb, func() {})
+		return
+	}
+	cb()
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+
+	"github.com/derailed/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTermKeyBytes(t *testing.T) {
+	uu := map[string]struct {
+		evt *tcell.EventKey
+		e   []byte
+	}{
+		"rune": {
+			evt: tcell.NewEventKey(tcell.KeyRune, 'é', tcell.ModNone),
+			e:   []byte("é"),
+		},
+		"alt-rune": {
+			evt: tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModAlt),
+			e:   []byte("\x1bb"),
+		},
+		"enter": {
+			evt: tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+			e:   []byte("\r"),
+		},
+		"backspace": {
+			evt: tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone),
+			e:   []byte{0x7f},
+		},
+		"up": {
+			evt: tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone),
+			e:   []byte("\x1b[A"),
+		},
+		"ctrl-c": {
+			evt: tcell.NewEventKey(tcell.KeyCtrlC, 0, tcell.ModCtrl),
+			e:   []byte{0x03},
+		},
+		"function": {
+			evt: tcell.NewEventKey(tcell.KeyF5, 0, tcell.ModNone),
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, termKeyBytes(u.evt))
+		})
+	}
+}
@@ -99,6 +99,9 @@ type ResourceViewer interface {
 
 	// SetCommand sets the current command.
 	SetCommand(*cmd.Interpreter)
+
+	// Pause freezes or resumes the view updates.
+	Pause(bool)
 }
 
 // LogViewer represents a log viewer.
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const restoreDialogKey = "restore"
+
+// VolumeSnapshot represents a CSI volume snapshot viewer.
+type VolumeSnapshot struct {
+	ResourceViewer
+}
+
+// NewVolumeSnapshot returns a new viewer.
+func NewVolumeSnapshot(gvr *client.GVR) ResourceViewer {
+	v := VolumeSnapshot{
+		ResourceViewer: NewOwnerExtender(NewBrowser(gvr)),
+	}
+	v.AddBindKeysFn(v.bindKeys)
+
+	return &v
+}
+
+func (v *VolumeSnapshot) bindKeys(aa *ui.KeyActions) {
+	if v.App().Config.IsReadOnly() {
+		return
+	}
+	aa.Add(ui.KeyShiftR, ui.NewKeyActionWithOpts("Restore", v.restoreCmd,
+		ui.ActionOpts{
+			Visible:   true,
+			Dangerous: true,
+		}))
+}
+
+func (v *VolumeSnapshot) restoreCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := v.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	ns, n := client.Namespaced(path)
+	name := n + "-restore"
+	if o, err := v.App().factory.Get(client.VolumeSnapshotGVR, path, false, nil); err == nil {
+		if u, ok := o.(*unstructured.Unstructured); ok {
+			if src, _, _ := unstructured.NestedString(u.Object, "spec", "source", "persistentVolumeClaimName"); src != "" {
+				name = src + "-restore"
+			}
+		}
+	}
+
+	styles := v.App().Styles.Dialog()
+	f := tview.NewForm().
+		SetItemPadding(0).
+		SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(styles.ButtonBgColor.Color()).
+		SetButtonTextColor(styles.ButtonFgColor.Color()).
+		SetLabelColor(styles.LabelFgColor.Color()).
+		SetFieldTextColor(styles.FieldFgColor.Color())
+	f.AddInputField("PVC:", name, 40, nil, func(changed string) {
+		name = changed
+	})
+	f.AddButton("OK", func() {
+		v.App().Content.RemovePage(restoreDialogKey)
+		ctx, cancel := context.WithTimeout(context.Background(), v.App().Conn().Config().CallTimeout())
+		defer cancel()
+		if err := dao.RestoreVolumeSnapshot(ctx, v.App().Conn(), path, name); err != nil {
+			v.App().Flash().Err(err)
+			return
+		}
+		v.App().Flash().Infof("PVC %s restoring snapshot %s", client.FQN(ns, name), path)
+	})
+	f.AddButton("Cancel", func() {
+		v.App().Content.RemovePage(restoreDialogKey)
+	})
+	for i := range f.GetButtonCount() {
+		f.GetButton(i).
+			SetBackgroundColorActivated(styles.ButtonFocusBgColor.Color()).
+			SetLabelColorActivated(styles.ButtonFocusFgColor.Color())
+	}
+
+	modal := tview.NewModalForm("<Restore>", f)
+	modal.SetText(fmt.Sprintf("Restore %s into a new PVC in namespace %s?", path, ns))
+	modal.SetDoneFunc(func(int, string) {
+		v.App().Content.RemovePage(restoreDialogKey)
+	})
+	v.App().Content.AddPage(restoreDialogKey, modal, false, false)
+	v.App().Content.ShowPage(restoreDialogKey)
+
+	return nil
+}
@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/view/cmd"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	waitDialogKey = "wait"
+	waitBarWidth  = 10
+	waitCancelArg = "cancel"
+)
+
+// waitDefaults tracks the default condition of the wait action per resource.
+var waitDefaults = map[*client.GVR]string{
+	client.DpGVR:  "condition=Available",
+	client.PodGVR: "condition=Ready",
+	client.JobGVR: "condition=Complete",
+}
+
+// waitTracker tracks the pending waits so they can be cancelled.
+type waitTracker struct {
+	mx      sync.Mutex
+	seq     int
+	pending map[int]pendingWait
+}
+
+type pendingWait struct {
+	target string
+	cancel context.CancelFunc
+}
+
+// add tracks a pending wait on a `<resource>/<name>` target.
+func (w *waitTracker) add(target string, cancel context.CancelFunc) int {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	if w.pending == nil {
+		w.pending = make(map[int]pendingWait)
+	}
+	w.seq++
+	w.pending[w.seq] = pendingWait{target: target, cancel: cancel}
+
+	return w.seq
+}
+
+// done stops tracking a wait.
+func (w *waitTracker) done(id int) {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	delete(w.pending, id)
+}
+
+// cancel cancels the pending waits on a target, or all of them when blank,
+// and returns how many got cancelled.
+func (w *waitTracker) cancel(target string) int {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	var n int
+	for id, p := range w.pending {
+		if target != "" && p.target != target {
+			continue
+		}
+		p.cancel()
+		delete(w.pending, id)
+		n++
+	}
+
+	return n
+}
+
+// waitCmd waits in the background for a resource to satisfy a condition, or
+// cancels pending waits, ie `:wait cancel [<resource>/<name>]`.
+func (a *App) waitCmd(args string) {
+	if ff := strings.Fields(args); len(ff) > 0 && ff[0] == waitCancelArg {
+		a.cancelWaits(strings.Join(ff[1:], " "))
+		return
+	}
+	spec, err := dao.ParseWait(args)
+	if err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	go func() {
+		_ = a.waitFor(a.appCtx, spec)
+	}()
+}
+
+func (a *App) cancelWaits(target string) {
+	if n := a.waits.cancel(target); n > 0 {
+		a.Flash().Infof("Cancelled %d pending wait(s)", n)
+		return
+	}
+	if target == "" {
+		a.Flash().Warn("No pending waits")
+		return
+	}
+	a.Flash().Warnf("No pending wait on %s", target)
+}
+
+// waitFor blocks until a resource satisfies a condition, showing the wait
+// progress and notifying the outcome.
+func (a *App) waitFor(ctx context.Context, spec dao.WaitSpec) error {
+	gvr, ns, err := a.waitTarget(spec)
+	var dyn dynamic.Interface
+	if err == nil {
+		dyn, err = a.Conn().DynDial()
+	}
+	if err != nil {
+		a.QueueUpdateDraw(func() {
+			a.Flash().Err(err)
+		})
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	id := a.waits.add(spec.Resource+"/"+spec.Name, cancel)
+	defer a.waits.done(id)
+
+	target := spec.Resource + "/" + client.FQN(ns, spec.Name)
+	start := time.Now()
+	err = dao.Wait(ctx, dyn, gvr.GVR(), ns, spec.Name, spec.For, spec.Timeout, func(state string) {
+		msg := fmt.Sprintf("Waiting for %s %s %s: %s", target, spec.For, waitProgress(time.Since(start), spec.Timeout), state)
+		a.QueueUpdateDraw(func() {
+			a.Flash().Info(msg)
+		})
+	})
+
+	res := model.CheckResult{
+		Check:   "wait " + target,
+		Context: a.Config.K9s.ActiveContextName(),
+		At:      time.Now(),
+		Summary: fmt.Sprintf("%s met %s after %s", target, spec.For, time.Since(start).Round(time.Second)),
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		res.Failed, res.Summary = true, fmt.Sprintf("%s: wait cancelled", target)
+	case err != nil:
+		res.Failed, res.Summary = true, fmt.Sprintf("%s: %s", target, err)
+	}
+	a.inbox.Push(res)
+	a.QueueUpdateDraw(func() {
+		if res.Failed {
+			a.Flash().Err(errors.New(res.Summary))
+			return
+		}
+		a.Flash().Info(res.Summary)
+	})
+
+	return err
+}
+
+// waitTarget resolves the resource and namespace of a wait request.
+// Namespaced resources default to the active namespace.
+func (a *App) waitTarget(spec dao.WaitSpec) (*client.GVR, string, error) {
+	gvr, _, _, err := a.command.viewMetaFor(cmd.NewInterpreter(spec.Resource))
+	if err != nil {
+		return nil, "", err
+	}
+	meta, err := dao.MetaAccess.MetaFor(gvr)
+	if err != nil {
+		return nil, "", err
+	}
+	if !meta.Namespaced {
+		return gvr, client.BlankNamespace, nil
+	}
+	ns := spec.Namespace
+	if ns == "" {
+		ns = client.CleanseNamespace(a.Config.ActiveNamespace())
+	}
+	if client.IsAllNamespaces(ns) {
+		return nil, "", fmt.Errorf("a namespace is required to wait for %s/%s, use -n", spec.Resource, spec.Name)
+	}
+
+	return gvr, ns, nil
+}
+
+// waitProgress renders the elapsed share of a wait timeout.
+func waitProgress(elapsed, timeout time.Duration) string {
+	n := min(int(float64(waitBarWidth)*elapsed.Seconds()/timeout.Seconds()), waitBarWidth)
+
+	return fmt.Sprintf("[%s%s] %s/%s",
+		strings.Repeat("█", n),
+		strings.Repeat("░", waitBarWidth-n),
+		elapsed.Round(time.Second),
+		timeout,
+	)
+}
+
+// WaitExtender adds a wait for condition action to a resource viewer.
+type WaitExtender struct {
+	ResourceViewer
+}
+
+// NewWaitExtender returns a new extender.
+func NewWaitExtender(v ResourceViewer) ResourceViewer {
+	w := WaitExtender{ResourceViewer: v}
+	v.AddBindKeysFn(w.bindKeys)
+
+	return &w
+}
+
+func (w *WaitExtender) bindKeys(aa *ui.KeyActions) {
+	aa.Add(ui.KeyShiftU, ui.NewKeyAction("Wait Until...", w.waitCmd, true))
+}
+
+func (w *WaitExtender) waitCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := w.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	ns, n := client.Namespaced(path)
+	cond, timeout := waitDefaults[w.GVR()], dao.DefaultWaitTimeout.String()
+
+	styles := w.App().Styles.Dialog()
+	f := tview.NewForm().
+		SetItemPadding(0).
+		SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(styles.ButtonBgColor.Color()).
+		SetButtonTextColor(styles.ButtonFgColor.Color()).
+		SetLabelColor(styles.LabelFgColor.Color()).
+		SetFieldTextColor(styles.FieldFgColor.Color())
+	f.AddInputField("For:", cond, 50, nil, func(changed string) {
+		cond = changed
+	})
+	f.AddInputField("Timeout:", timeout, 10, nil, func(changed string) {
+		timeout = changed
+	})
+	f.AddButton("OK", func() {
+		wf, err := dao.ParseWaitFor(strings.TrimSpace(cond))
+		if err != nil {
+			w.App().Flash().Err(err)
+			return
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(timeout))
+		if err != nil || d <= 0 {
+			w.App().Flash().Errf("Invalid wait timeout %q", timeout)
+			return
+		}
+		spec := dao.WaitSpec{Resource: w.GVR().R(), Name: n, For: wf, Timeout: d}
+		if client.IsNamespaced(ns) {
+			spec.Namespace = ns
+		}
+		w.App().Content.RemovePage(waitDialogKey)
+		go func() {
+			_ = w.App().waitFor(w.App().appCtx, spec)
+		}()
+	})
+	f.AddButton("Cancel", func() {
+		w.App().Content.RemovePage(waitDialogKey)
+	})
+	for i := range f.GetButtonCount() {
+		f.GetButton(i).
+			SetBackgroundColorActivated(styles.ButtonFocusBgColor.Color()).
+			SetLabelColorActivated(styles.ButtonFocusFgColor.Color())
+	}
+
+	modal := tview.NewModalForm("<Wait Until>", f)
+	modal.SetText(fmt.Sprintf("Wait for %s %s to satisfy condition=<type>[=<status>], jsonpath=<path>[=<value>] or delete.", singularize(w.GVR().R()), path))
+	modal.SetDoneFunc(func(int, string) {
+		w.App().Content.RemovePage(waitDialogKey)
+	})
+	w.App().Content.AddPage(waitDialogKey, modal, false, false)
+	w.App().Content.ShowPage(waitDialogKey)
+
+	return nil
+}
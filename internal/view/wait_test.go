@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitTrackerCancel(t *testing.T) {
+	uu := map[string]struct {
+		target   string
+		n        int
+		canceled []bool
+	}{
+		"all": {
+			n:        3,
+			canceled: []bool{true, true, true},
+		},
+		"target": {
+			target:   "deploy/web",
+			n:        2,
+			canceled: []bool{true, false, true},
+		},
+		"none": {
+			target:   "job/migrate",
+			canceled: []bool{false, false, false},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			var w waitTracker
+			cc := make([]context.Context, 0, 3)
+			for _, target := range []string{"deploy/web", "po/web-0", "deploy/web"} {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				w.add(target, cancel)
+				cc = append(cc, ctx)
+			}
+
+			assert.Equal(t, u.n, w.cancel(u.target))
+			for i, ctx := range cc {
+				assert.Equal(t, u.canceled[i], ctx.Err() != nil)
+			}
+			assert.Equal(t, 0, w.cancel(u.target))
+		})
+	}
+}
+
+func TestWaitTrackerDone(t *testing.T) {
+	var w waitTracker
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w.done(w.add("deploy/web", cancel))
+
+	assert.Equal(t, 0, w.cancel(""))
+	assert.NoError(t, ctx.Err())
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/slogs"
+)
+
+const warmupDeadline = 2 * time.Minute
+
+// startWarmup warms up the selected contexts in the background so the first
+// multi-context view does not stall on cold clients. Each context status
+// shows in the contexts view.
+func (a *App) startWarmup() {
+	if !a.Config.K9s.WarmupEnabled() || a.Conn() == nil {
+		return
+	}
+	sel, _ := config.LoadSelectedContexts()
+	if len(sel) < 2 {
+		return
+	}
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		slog.Warn("Warmup: unable to get raw config", slogs.Error, err)
+		return
+	}
+	prefetch := a.Config.K9s.Warmup != nil && a.Config.K9s.Warmup.Prefetch
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), warmupDeadline)
+		defer cancel()
+
+		t := time.Now()
+		ww := dao.WarmUpContexts(ctx, rawCfg, sel, prefetch, func(w dao.ContextWarmup) {
+			if w.Err != nil {
+				slogs.CtxLog(w.Context).Warn("Context warmup failed", slogs.Error, w.Err)
+			}
+		})
+		msg, ok := warmupSummary(ww, time.Since(t))
+		a.QueueUpdateDraw(func() {
+			if ok {
+				a.Flash().Info(msg)
+				return
+			}
+			a.Flash().Warn(msg)
+		})
+	}()
+}
+
+// warmupSummary reports the outcome of a contexts warm up.
+func warmupSummary(ww []dao.ContextWarmup, elapsed time.Duration) (string, bool) {
+	var failed []string
+	for _, w := range ww {
+		if w.State == dao.WarmupFailed {
+			failed = append(failed, w.Context)
+		}
+	}
+	msg := fmt.Sprintf("Warmed up %d/%d contexts in %s", len(ww)-len(failed), len(ww), elapsed.Round(100*time.Millisecond))
+	if len(failed) == 0 {
+		return msg, true
+	}
+
+	return msg + " (failed: " + strings.Join(failed, ", ") + ")", false
+}
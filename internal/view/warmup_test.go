@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmupSummary(t *testing.T) {
+	uu := map[string]struct {
+		ww []dao.ContextWarmup
+		e  string
+		ok bool
+	}{
+		"ready": {
+			ww: []dao.ContextWarmup{
+				{Context: "c1", State: dao.WarmupReady},
+				{Context: "c2", State: dao.WarmupReady},
+			},
+			e:  "Warmed up 2/2 contexts in 1.5s",
+			ok: true,
+		},
+		"failed": {
+			ww: []dao.ContextWarmup{
+				{Context: "c1", State: dao.WarmupReady},
+				{Context: "c2", State: dao.WarmupFailed},
+				{Context: "c3", State: dao.WarmupFailed},
+			},
+			e: "Warmed up 1/3 contexts in 1.5s (failed: c2, c3)",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			msg, ok := warmupSummary(u.ww, 1520*time.Millisecond)
+			assert.Equal(t, u.e, msg)
+			assert.Equal(t, u.ok, ok)
+		})
+	}
+}
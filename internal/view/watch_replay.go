@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/slogs"
+	"github.com/derailed/k9s/internal/view/cmd"
+)
+
+const (
+	replayTitle     = "Replay"
+	maxReplayGap    = 2 * time.Second
+	replayRecentMax = 10
+)
+
+// watchCapture tracks an active watch recording.
+type watchCapture struct {
+	path   string
+	cancel context.CancelFunc
+}
+
+// captureCmd starts recording the watch events of comma separated resources
+// in the active namespace or stops the active recording.
+func (a *App) captureCmd(arg string) {
+	if a.capture != nil {
+		a.capture.cancel()
+		a.Flash().Infof("Watch events recorded to %s", a.capture.path)
+		a.capture = nil
+		return
+	}
+	ff := strings.Fields(arg)
+	if len(ff) == 0 {
+		a.Flash().Warn("Invalid command. Use `capture RESOURCES [FILE]` to start and `capture` to stop")
+		return
+	}
+	var gvrs []*client.GVR
+	for _, r := range strings.Split(ff[0], ",") {
+		gvr, _, _, err := a.command.viewMetaFor(cmd.NewInterpreter(r))
+		if err != nil {
+			a.Flash().Err(err)
+			return
+		}
+		gvrs = append(gvrs, gvr)
+	}
+	name := time.Now().Format("20060102-150405")
+	if len(ff) > 1 {
+		name = ff[1]
+	}
+	path, err := config.WatchRecordingFile(name)
+	if err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	dial, err := a.Conn().DynDial()
+	if err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		a.Flash().Err(err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.capture = &watchCapture{path: path, cancel: cancel}
+	ns := a.Config.ActiveNamespace()
+	go func() {
+		defer func() {
+			if err := f.Close(); err != nil {
+				slog.Warn("Closing watch recording failed", slogs.Error, err)
+			}
+		}()
+		if err := dao.RecordWatch(ctx, dial, gvrs, ns, a.redactor(), f); err != nil {
+			a.QueueUpdateDraw(func() {
+				a.Flash().Errf("Watch recording failed: %s", err)
+			})
+		}
+	}()
+	a.Flash().Infof("Recording %s watch events to %s, use `:capture` to stop", ff[0], path)
+}
+
+// replayCmd replays a watch recording offline: `replay FILE [SPEED]`.
+func (a *App) replayCmd(arg string) {
+	ff := strings.Fields(arg)
+	if len(ff) == 0 {
+		a.Flash().Warn("Invalid command. Use `replay FILE [SPEED]`")
+		return
+	}
+	speed := 1.0
+	if len(ff) > 1 {
+		s, err := strconv.ParseFloat(strings.TrimSuffix(ff[1], "x"), 64)
+		if err != nil || s <= 0 {
+			a.Flash().Warnf("Invalid replay speed %q", ff[1])
+			return
+		}
+		speed = s
+	}
+	path, err := config.WatchRecordingFile(ff[0])
+	if err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	ee, err := dao.LoadRecording(f)
+	if e := f.Close(); e != nil {
+		slog.Warn("Closing watch recording failed", slogs.Error, e)
+	}
+	if err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	if len(ee) == 0 {
+		a.Flash().Warnf("Recording %s has no events", path)
+		return
+	}
+
+	d := NewDetails(a, replayTitle, path, contentTXT, true)
+	if err := a.inject(d, false); err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	go a.replay(d, path, ee, speed)
+}
+
+// replay applies the recorded events at the recorded pace, scaled by speed.
+// Idle gaps are capped and the replay stops once its view is dismissed.
+func (a *App) replay(d *Details, path string, ee []dao.WatchEvent, speed float64) {
+	s := dao.NewReplayState()
+	for i, e := range ee {
+		if i > 0 {
+			gap := time.Duration(float64(e.At.Sub(ee[i-1].At)) / speed)
+			<-time.After(min(gap, maxReplayGap))
+		}
+		s.Apply(e)
+		out := renderReplay(path, ee, i, speed, s)
+		done := make(chan bool, 1)
+		a.QueueUpdateDraw(func() {
+			if a.Content.Top() != d {
+				done <- false
+				return
+			}
+			d.Update(out)
+			done <- true
+		})
+		if !<-done {
+			return
+		}
+	}
+}
+
+func renderReplay(path string, ee []dao.WatchEvent, i int, speed float64, s *dao.ReplayState) string {
+	var b strings.Builder
+	state := "replaying"
+	if i == len(ee)-1 {
+		state = "completed"
+	}
+	fmt.Fprintf(&b, "%s %s event %d/%d at %s (%gx)\n\n", path, state, i+1, len(ee), replayOffset(ee, i), speed)
+	for _, l := range s.Lines() {
+		b.WriteString(l + "\n")
+	}
+	b.WriteString("\nRecent events\n")
+	for j := max(0, i-replayRecentMax+1); j <= i; j++ {
+		fmt.Fprintf(&b, "  %s %-8s %s %s\n", replayOffset(ee, j), ee[j].Type, ee[j].GVR, ee[j].Subject())
+	}
+
+	return b.String()
+}
+
+func replayOffset(ee []dao.WatchEvent, i int) string {
+	return "+" + ee[i].At.Sub(ee[0].At).Round(time.Second).String()
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func TestRenderReplay(t *testing.T) {
+	at := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	pod := func(n, phase string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"name": n, "namespace": "ns1"},
+			"status":   map[string]any{"phase": phase},
+		}}
+	}
+	ee := []dao.WatchEvent{
+		{At: at, Type: watch.Added, GVR: "v1/pods", Object: pod("p1", "Pending")},
+		{At: at.Add(12 * time.Second), Type: watch.Modified, GVR: "v1/pods", Object: pod("p1", "Running")},
+	}
+	s := dao.NewReplayState()
+	for _, e := range ee {
+		s.Apply(e)
+	}
+
+	e := `incident.jsonl completed event 2/2 at +12s (2x)
+
+v1/pods (1)
+  ns1/p1 Running
+
+Recent events
+  +0s ADDED    v1/pods ns1/p1
+  +12s MODIFIED v1/pods ns1/p1
+`
+	assert.Equal(t, e, renderReplay("incident.jsonl", ee, 1, 2, s))
+}
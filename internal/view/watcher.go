@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"errors"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tcell/v2"
+)
+
+// Watcher presents the active informers.
+type Watcher struct {
+	ResourceViewer
+}
+
+// NewWatcher returns a new viewer.
+func NewWatcher(gvr *client.GVR) ResourceViewer {
+	w := Watcher{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	w.AddBindKeysFn(w.bindKeys)
+	w.GetTable().SetEnterFn(w.gotoResource)
+	w.GetTable().SetSortCol("RESOURCE", true)
+
+	return &w
+}
+
+func (w *Watcher) bindKeys(aa *ui.KeyActions) {
+	aa.Delete(ui.KeyShiftA, tcell.KeyCtrlD, ui.KeyE, tcell.KeyCtrlSpace, ui.KeySpace)
+	aa.Bulk(ui.KeyMap{
+		ui.KeyS:      ui.NewKeyAction("Stop", w.stopCmd, true),
+		ui.KeyR:      ui.NewKeyAction("Restart", w.restartCmd, true),
+		ui.KeyShiftE: ui.NewKeyAction("Sort Events", w.GetTable().SortColCmd("EVENTS", false), false),
+		ui.KeyShiftR: ui.NewKeyAction("Sort Rate", w.GetTable().SortColCmd("RATE/S", false), false),
+		ui.KeyShiftI: ui.NewKeyAction("Sort Items", w.GetTable().SortColCmd("ITEMS", false), false),
+	})
+}
+
+func (*Watcher) gotoResource(app *App, _ ui.Tabular, _ *client.GVR, path string) {
+	_, gvr, err := dao.ParseWatcherID(path)
+	if err != nil {
+		app.Flash().Err(err)
+		return
+	}
+	app.gotoResource(gvr.String(), "", false, true)
+}
+
+func (w *Watcher) stopCmd(evt *tcell.EventKey) *tcell.EventKey {
+	return w.informerCmd(evt, "stopped", (*dao.Watcher).Stop)
+}
+
+func (w *Watcher) restartCmd(evt *tcell.EventKey) *tcell.EventKey {
+	return w.informerCmd(evt, "restarted", (*dao.Watcher).Restart)
+}
+
+func (w *Watcher) informerCmd(evt *tcell.EventKey, verb string, fn func(*dao.Watcher, string) error) *tcell.EventKey {
+	path := w.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	if err := w.informerAction(path, fn); err != nil {
+		w.App().Flash().Err(err)
+		return nil
+	}
+	_, gvr, _ := dao.ParseWatcherID(path)
+	w.App().Flash().Infof("Informer %s %s", gvr, verb)
+	w.Refresh()
+
+	return nil
+}
+
+func (w *Watcher) informerAction(path string, fn func(*dao.Watcher, string) error) error {
+	res, err := dao.AccessorFor(w.App().factory, w.GVR())
+	if err != nil {
+		return err
+	}
+	wa, ok := res.(*dao.Watcher)
+	if !ok {
+		return errors.New("expecting a watcher resource")
+	}
+
+	return fn(wa, path)
+}
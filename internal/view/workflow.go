@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	workflowStepDeadline = 10 * time.Minute
+	nodeRecoveryGrace    = 20 * time.Second
+	nodeRecoveryTick     = 5 * time.Second
+)
+
+type logFn func(format string, args ...any)
+
+// workflowStep represents a step of a guided workflow.
+type workflowStep struct {
+	title string
+	run   func(ctx context.Context, log logFn) error
+}
+
+// workflows tracks the guided workflows by command name.
+var workflows = map[string]func(a *App, arg string) (string, []workflowStep, error){
+	"rotate-encryption": encryptionRotationWorkflow,
+	"rotate-certs":      certRotationWorkflow,
+	"rotate-token":      tokenRotationWorkflow,
+	"migrate-crd":       crdMigrationWorkflow,
+}
+
+// workflowCmd walks through a guided workflow, confirming each step.
+func (a *App) workflowCmd(name, arg string) {
+	build, ok := workflows[name]
+	if !ok {
+		a.Flash().Warnf("Unknown workflow %q", name)
+		return
+	}
+	if a.Config.IsReadOnly() {
+		a.Flash().Warnf("Workflow %s is not available in read-only mode", name)
+		return
+	}
+	if a.Conn() == nil || !a.Conn().ConnectionOK() {
+		a.Flash().Warn("Workflows require a cluster connection")
+		return
+	}
+	subject, steps, err := build(a, arg)
+	if err != nil {
+		a.Flash().Err(err)
+		return
+	}
+	a.runWorkflow(name, subject, steps)
+}
+
+// runWorkflow logs the workflow progress in a details view. Each step must be
+// confirmed and the workflow stops at the first declined or failed step.
+// Closing the view cancels the workflow.
+func (a *App) runWorkflow(title, subject string, steps []workflowStep) {
+	wctx, cancel := context.WithCancel(context.Background())
+	d := NewDetails(a, title, subject, contentTXT, true).SetStopFn(cancel)
+	if err := a.inject(d, false); err != nil {
+		cancel()
+		a.Flash().Err(err)
+		return
+	}
+	w := d.GetWriter()
+	log := func(format string, args ...any) {
+		a.QueueUpdateDraw(func() {
+			fmt.Fprintf(w, "%s %s\n", time.Now().Format(time.TimeOnly), fmt.Sprintf(format, args...))
+		})
+	}
+	fmt.Fprintf(w, "%d steps, each step is confirmed before it runs. Close this view to cancel.\n\n", len(steps))
+
+	var next func(i int)
+	next = func(i int) {
+		if i == len(steps) {
+			fmt.Fprintf(w, "\nWorkflow %s completed.\n", title)
+			return
+		}
+		if wctx.Err() != nil {
+			return
+		}
+		s, progress := steps[i], fmt.Sprintf("[%d/%d]", i+1, len(steps))
+		dlg := a.Styles.Dialog()
+		dialog.ShowConfirm(&dlg, a.Content.Pages, "Confirm "+progress, s.title+"?", func() {
+			fmt.Fprintf(w, "%s %s\n", progress, s.title)
+			go func() {
+				ctx, cancel := context.WithTimeout(wctx, workflowStepDeadline)
+				defer cancel()
+				if err := s.run(ctx, log); err != nil {
+					log("FAILED %s: %s", progress, err)
+					log("Workflow stopped, resolve the failure before running it again.")
+					return
+				}
+				log("DONE %s", progress)
+				a.QueueUpdateDraw(func() { next(i + 1) })
+			}()
+		}, func() {
+			fmt.Fprintf(w, "\nWorkflow aborted before step %s %s.\n", progress, s.title)
+		})
+	}
+	next(0)
+}
+
+// waitForNode waits for a node of a context to come back from a service
+// restart issued at a given time, logging the reason it is not back yet.
+func waitForNode(ctx context.Context, a *App, ctxName, node string, since time.Time, log logFn) error {
+	rawCfg, err := a.Conn().Config().RawConfig()
+	if err != nil {
+		return err
+	}
+	log("waiting for %s to come back...", node)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(nodeRecoveryGrace):
+	}
+	var last string
+	for {
+		ok, msg := dao.NodeRecoveredInContext(ctx, rawCfg, ctxName, node, since)
+		if ok {
+			log("%s is back (%s)", node, time.Since(since).Round(time.Second))
+			return nil
+		}
+		if msg != last {
+			log("%s: %s", node, msg)
+			last = msg
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s did not come back: %s", node, last)
+		case <-time.After(nodeRecoveryTick):
+		}
+	}
+}
+
+// hostRestartStep runs a script restarting a node service and waits for the
+// node and the API server to come back.
+func hostRestartStep(a *App, ctxName, node, title, script string) workflowStep {
+	return workflowStep{
+		title: title,
+		run: func(ctx context.Context, log logFn) error {
+			since := time.Now()
+			out, err := runOnHost(ctx, a, ctxName, node, "rk9s-restart", script)
+			if err != nil {
+				return err
+			}
+			log("%s", strings.TrimSpace(out))
+			return waitForNode(ctx, a, ctxName, node, since, log)
+		},
+	}
+}
+
+// clusterNodes returns the sorted server and agent node names of the active context.
+func clusterNodes(a *App) (servers, agents []string, err error) {
+	dial, err := a.Conn().Dial()
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), a.Conn().Config().CallTimeout())
+	defer cancel()
+	ll, err := dial.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	sel, err := labels.Parse(dao.ControlPlaneSelector)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range ll.Items {
+		no := &ll.Items[i]
+		if sel.Matches(labels.Set(no.Labels)) {
+			servers = append(servers, no.Name)
+		} else {
+			agents = append(agents, no.Name)
+		}
+	}
+	if len(servers) == 0 {
+		return nil, nil, fmt.Errorf("no server nodes matching %s", dao.ControlPlaneSelector)
+	}
+	sort.Strings(servers)
+	sort.Strings(agents)
+
+	return servers, agents, nil
+}
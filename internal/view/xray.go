@@ -646,6 +646,9 @@ func (*Xray) AddBindKeysFn(BindKeysFunc) {}
 // SetContextFn sets custom context.
 func (*Xray) SetContextFn(ContextFunc) {}
 
+// Pause freezes or resumes the view updates.
+func (*Xray) Pause(bool) {}
+
 // Name returns the component name.
 func (*Xray) Name() string { return "XRay" }
 
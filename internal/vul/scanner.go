@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/anchore/clio"
@@ -46,6 +47,7 @@ type imageScanner struct {
 	scans       Scans
 	mx          sync.RWMutex
 	initialized bool
+	paused      atomic.Bool
 	config      config.ImageScans
 	log         *slog.Logger
 }
@@ -144,7 +146,15 @@ func (s *imageScanner) IsInitialized() bool {
 	return s.initialized
 }
 
+// Pause suspends or resumes scanning new images. Known scans are kept.
+func (s *imageScanner) Pause(b bool) {
+	s.paused.Store(b)
+}
+
 func (s *imageScanner) Enqueue(ctx context.Context, images ...string) {
+	if s.paused.Load() {
+		return
+	}
 	ctx, cancel := context.WithTimeout(ctx, imgScanTimeout)
 	defer cancel()
 
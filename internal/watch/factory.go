@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/metrics"
 	"github.com/derailed/k9s/internal/slogs"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -27,10 +28,11 @@ const (
 
 // Factory tracks various resource informers.
 type Factory struct {
-	factories  map[string]di.DynamicSharedInformerFactory
+	factories  map[string]*informerFactory
 	client     client.Connection
 	stopChan   chan struct{}
 	forwarders Forwarders
+	watched    map[string]struct{}
 	mx         sync.RWMutex
 }
 
@@ -38,8 +40,9 @@ type Factory struct {
 func NewFactory(clt client.Connection) *Factory {
 	return &Factory{
 		client:     clt,
-		factories:  make(map[string]di.DynamicSharedInformerFactory),
+		factories:  make(map[string]*informerFactory),
 		forwarders: NewForwarders(),
+		watched:    make(map[string]struct{}),
 	}
 }
 
@@ -68,6 +71,8 @@ func (f *Factory) Terminate() {
 	for k := range f.factories {
 		delete(f.factories, k)
 	}
+	clear(f.watched)
+	metrics.Informers.Set(0)
 	f.forwarders.DeleteAll()
 }
 
@@ -179,7 +184,11 @@ func (f *Factory) Client() client.Connection {
 
 // FactoryFor returns a factory for a given namespace.
 func (f *Factory) FactoryFor(ns string) di.DynamicSharedInformerFactory {
-	return f.factories[ns]
+	if fac, ok := f.factories[ns]; ok {
+		return fac
+	}
+
+	return nil
 }
 
 // SetActiveNS sets the active namespace.
@@ -255,6 +264,11 @@ func (f *Factory) ForResource(ns string, gvr *client.GVR) (informers.GenericInfo
 		return inf, nil
 	}
 
+	f.mx.Lock()
+	f.watched[client.FQN(ns, gvr.String())] = struct{}{}
+	metrics.Informers.Set(float64(len(f.watched)))
+	f.mx.Unlock()
+
 	f.mx.RLock()
 	defer f.mx.RUnlock()
 	fact.Start(f.stopChan)
@@ -262,7 +276,7 @@ func (f *Factory) ForResource(ns string, gvr *client.GVR) (informers.GenericInfo
 	return inf, nil
 }
 
-func (f *Factory) ensureFactory(ns string) (di.DynamicSharedInformerFactory, error) {
+func (f *Factory) ensureFactory(ns string) (*informerFactory, error) {
 	if client.IsClusterWide(ns) {
 		ns = client.BlankNamespace
 	}
@@ -276,16 +290,67 @@ func (f *Factory) ensureFactory(ns string) (di.DynamicSharedInformerFactory, err
 	if err != nil {
 		return nil, err
 	}
-	f.factories[ns] = di.NewFilteredDynamicSharedInformerFactory(
-		dial,
-		defaultResync,
-		ns,
-		nil,
-	)
+	f.factories[ns] = newInformerFactory(dial, f.client.ActiveContext(), ns, defaultResync)
 
 	return f.factories[ns], nil
 }
 
+// Informers returns the health of all informers.
+func (f *Factory) Informers() []InformerStats {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+
+	now := time.Now()
+	var ss []InformerStats
+	for _, fac := range f.factories {
+		ss = append(ss, fac.Stats(now)...)
+	}
+
+	return ss
+}
+
+// StopInformer stops a resource informer. Its cache is no longer updated
+// until the informer is restarted.
+func (f *Factory) StopInformer(ns string, gvr *client.GVR) error {
+	fac, err := f.factoryFor(ns)
+	if err != nil {
+		return err
+	}
+
+	return fac.Stop(gvr.GVR())
+}
+
+// RestartInformer replaces a resource informer with a fresh one.
+func (f *Factory) RestartInformer(ns string, gvr *client.GVR) error {
+	fac, err := f.factoryFor(ns)
+	if err != nil {
+		return err
+	}
+	if err := fac.Restart(gvr.GVR()); err != nil {
+		return err
+	}
+
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+	fac.Start(f.stopChan)
+
+	return nil
+}
+
+func (f *Factory) factoryFor(ns string) (*informerFactory, error) {
+	if client.IsClusterWide(ns) {
+		ns = client.BlankNamespace
+	}
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+	fac, ok := f.factories[ns]
+	if !ok {
+		return nil, fmt.Errorf("no informers for namespace %q", ns)
+	}
+
+	return fac, nil
+}
+
 // AddForwarder registers a new portforward for a given container.
 func (f *Factory) AddForwarder(pf Forwarder) {
 	f.mx.Lock()
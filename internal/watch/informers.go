@@ -0,0 +1,291 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package watch
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/metrics"
+	"github.com/derailed/k9s/internal/slogs"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	di "k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// InformerStats tracks the health of a resource informer.
+type InformerStats struct {
+	Context     string
+	Namespace   string
+	GVR         string
+	Synced      bool
+	Stopped     bool
+	Items       int
+	Events      int64
+	Rate        float64
+	LastError   string
+	LastErrorAt time.Time
+	StartedAt   time.Time
+}
+
+// informerFactory tracks informers for a namespace. Unlike the client-go
+// shared factory, each informer can be stopped and restarted on its own.
+type informerFactory struct {
+	client    dynamic.Interface
+	context   string
+	ns        string
+	resync    time.Duration
+	informers map[schema.GroupVersionResource]*trackedInformer
+	mx        sync.Mutex
+}
+
+var _ di.DynamicSharedInformerFactory = (*informerFactory)(nil)
+
+func newInformerFactory(c dynamic.Interface, ctx, ns string, resync time.Duration) *informerFactory {
+	return &informerFactory{
+		client:    c,
+		context:   ctx,
+		ns:        ns,
+		resync:    resync,
+		informers: make(map[schema.GroupVersionResource]*trackedInformer),
+	}
+}
+
+// ForResource returns an informer for a given resource.
+func (f *informerFactory) ForResource(gvr schema.GroupVersionResource) informers.GenericInformer {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	if ti, ok := f.informers[gvr]; ok {
+		return ti
+	}
+	ti := f.newInformer(gvr)
+	f.informers[gvr] = ti
+
+	return ti
+}
+
+// Start runs all informers not yet started.
+func (f *informerFactory) Start(stopCh <-chan struct{}) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	for _, ti := range f.informers {
+		ti.run(stopCh)
+	}
+}
+
+// WaitForCacheSync waits for all started informers to sync.
+func (f *informerFactory) WaitForCacheSync(stopCh <-chan struct{}) map[schema.GroupVersionResource]bool {
+	f.mx.Lock()
+	ii := make(map[schema.GroupVersionResource]cache.SharedIndexInformer, len(f.informers))
+	for gvr, ti := range f.informers {
+		if ti.isRunning() {
+			ii[gvr] = ti.Informer()
+		}
+	}
+	f.mx.Unlock()
+
+	res := make(map[schema.GroupVersionResource]bool, len(ii))
+	for gvr, inf := range ii {
+		res[gvr] = cache.WaitForCacheSync(stopCh, inf.HasSynced)
+	}
+
+	return res
+}
+
+// Shutdown stops all informers.
+func (f *informerFactory) Shutdown() {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	for _, ti := range f.informers {
+		ti.halt()
+	}
+}
+
+// Stop stops a given informer. Its cache is kept but no longer updated.
+func (f *informerFactory) Stop(gvr schema.GroupVersionResource) error {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	ti, ok := f.informers[gvr]
+	if !ok {
+		return fmt.Errorf("no informer for %q", gvr)
+	}
+	ti.halt()
+
+	return nil
+}
+
+// Restart replaces a given informer with a fresh one. The caller must start
+// the factory again.
+func (f *informerFactory) Restart(gvr schema.GroupVersionResource) error {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	ti, ok := f.informers[gvr]
+	if !ok {
+		return fmt.Errorf("no informer for %q", gvr)
+	}
+	ti.halt()
+	f.informers[gvr] = f.newInformer(gvr)
+
+	return nil
+}
+
+// Stats returns the informers health.
+func (f *informerFactory) Stats(now time.Time) []InformerStats {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	ss := make([]InformerStats, 0, len(f.informers))
+	for gvr, ti := range f.informers {
+		s := ti.stats(now)
+		s.Context, s.Namespace, s.GVR = f.context, f.ns, client.FromGVAndR(gvr.GroupVersion().String(), gvr.Resource).String()
+		ss = append(ss, s)
+	}
+	sort.Slice(ss, func(i, j int) bool {
+		return ss[i].GVR < ss[j].GVR
+	})
+
+	return ss
+}
+
+func (f *informerFactory) newInformer(gvr schema.GroupVersionResource) *trackedInformer {
+	ti := &trackedInformer{
+		GenericInformer: di.NewFilteredDynamicInformer(
+			f.client,
+			gvr,
+			f.ns,
+			f.resync,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+			nil,
+		),
+		stop: make(chan struct{}),
+	}
+	res, ns := client.FromGVAndR(gvr.GroupVersion().String(), gvr.Resource).String(), f.ns
+	record := func() {
+		ti.events.Add(1)
+		metrics.InformerEvents.Inc(f.context, res, ns)
+	}
+	_, err := ti.Informer().AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(_ any, initial bool) {
+			if !initial {
+				record()
+			}
+		},
+		UpdateFunc: func(_, _ any) { record() },
+		DeleteFunc: func(any) { record() },
+	})
+	if err != nil {
+		slog.Warn("Unable to track informer events", slogs.Context, f.context, slogs.GVR, res, slogs.Error, err)
+	}
+	err = ti.Informer().SetWatchErrorHandlerWithContext(func(_ context.Context, _ *cache.Reflector, err error) {
+		slog.Debug("Informer watch failed", slogs.Context, f.context, slogs.GVR, res, slogs.Namespace, ns, slogs.Error, err)
+		ti.setError(err)
+		metrics.InformerErrors.Inc(f.context, res, ns)
+	})
+	if err != nil {
+		slog.Warn("Unable to track informer errors", slogs.Context, f.context, slogs.GVR, res, slogs.Error, err)
+	}
+
+	return ti
+}
+
+// trackedInformer records the activity of an informer.
+type trackedInformer struct {
+	informers.GenericInformer
+
+	stop        chan struct{}
+	started     bool
+	stopped     bool
+	startedAt   time.Time
+	events      atomic.Int64
+	sampled     int64
+	sampledAt   time.Time
+	lastErr     error
+	lastErrorAt time.Time
+	mx          sync.Mutex
+}
+
+func (t *trackedInformer) run(stopCh <-chan struct{}) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if t.started || t.stopped {
+		return
+	}
+	t.started, t.startedAt = true, time.Now()
+	stop, done := t.stop, make(chan struct{})
+	go func() {
+		select {
+		case <-stopCh:
+		case <-stop:
+		}
+		close(done)
+	}()
+	go t.Informer().Run(done)
+}
+
+func (t *trackedInformer) halt() {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if !t.stopped {
+		t.stopped = true
+		close(t.stop)
+	}
+}
+
+func (t *trackedInformer) isRunning() bool {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	return t.started && !t.stopped
+}
+
+func (t *trackedInformer) setError(err error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.lastErr, t.lastErrorAt = err, time.Now()
+}
+
+// stats returns the informer health. The event rate is measured since the
+// previous sample.
+func (t *trackedInformer) stats(now time.Time) InformerStats {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	s := InformerStats{
+		Synced:      t.Informer().HasSynced(),
+		Stopped:     t.stopped,
+		Items:       len(t.Informer().GetStore().ListKeys()),
+		Events:      t.events.Load(),
+		LastErrorAt: t.lastErrorAt,
+		StartedAt:   t.startedAt,
+	}
+	if t.lastErr != nil {
+		s.LastError = t.lastErr.Error()
+	}
+	from := t.sampledAt
+	if from.IsZero() {
+		from = t.startedAt
+	}
+	if d := now.Sub(from).Seconds(); !from.IsZero() && d > 0 {
+		s.Rate = float64(s.Events-t.sampled) / d
+	}
+	t.sampled, t.sampledAt = s.Events, now
+
+	return s
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of rk9s
+
+package watch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestInformerFactoryStopRestart(t *testing.T) {
+	po := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	f := newInformerFactory(nil, "ct-1", "ns1", time.Minute)
+
+	inf := f.ForResource(po)
+	assert.Same(t, inf, f.ForResource(po))
+
+	require.NoError(t, f.Stop(po))
+	ss := f.Stats(time.Now())
+	require.Len(t, ss, 1)
+	assert.Equal(t, "ct-1", ss[0].Context)
+	assert.Equal(t, "ns1", ss[0].Namespace)
+	assert.Equal(t, "v1/pods", ss[0].GVR)
+	assert.True(t, ss[0].Stopped)
+
+	require.NoError(t, f.Restart(po))
+	assert.NotSame(t, inf, f.ForResource(po))
+	assert.False(t, f.Stats(time.Now())[0].Stopped)
+
+	dp := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	assert.EqualError(t, f.Stop(dp), `no informer for "apps/v1, Resource=deployments"`)
+	assert.Error(t, f.Restart(dp))
+}
+
+func TestTrackedInformerRate(t *testing.T) {
+	t0 := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	f := newInformerFactory(nil, "ct-1", "", time.Minute)
+	ti := f.newInformer(schema.GroupVersionResource{Version: "v1", Resource: "pods"})
+	ti.startedAt = t0
+
+	ti.events.Add(20)
+	s := ti.stats(t0.Add(10 * time.Second))
+	assert.Equal(t, int64(20), s.Events)
+	assert.InDelta(t, 2.0, s.Rate, 0.001)
+
+	ti.events.Add(5)
+	s = ti.stats(t0.Add(20 * time.Second))
+	assert.Equal(t, int64(25), s.Events)
+	assert.InDelta(t, 0.5, s.Rate, 0.001)
+}